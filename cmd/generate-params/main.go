@@ -0,0 +1,246 @@
+// Command generate-params generates typed parameter structs for the tools in pkg/github from their input schemas,
+// along with functions that extract and validate them from a tool call. Run it with go generate ./pkg/github.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func main() {
+	output := flag.String("o", "params_gen.go", "file to write the generated code to")
+	flag.Parse()
+
+	src, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// tools returns every tool served by the server, sorted by name, including the tools only registered when the server
+// is configured for them.
+func tools() []mcp.Tool {
+	t := translations.NullTranslationHelper
+	tsg := github.DefaultToolsetGroup(false, nil, nil, t)
+
+	all := []*toolsets.Toolset{
+		github.InitContextToolset(nil, t),
+		github.InitUndoToolset(nil, t),
+		github.InitContinuationToolset(nil, t),
+		github.InitSessionToolset(nil, t),
+		github.InitStatsToolset(nil, nil, t),
+		github.InitAppToolset(nil, t),
+		github.InitWebhookToolset(nil, t),
+		github.InitDynamicToolset(nil, tsg, t),
+		toolsets.NewToolset("configured", "").AddReadTools(
+			toolsets.NewServerTool(github.ExportIssues(nil, "", t)),
+			toolsets.NewServerTool(github.GetReviewChecklist(nil, nil, t)),
+		),
+	}
+	for _, ts := range tsg.Toolsets {
+		all = append(all, ts)
+	}
+
+	var result []mcp.Tool
+	for _, ts := range all {
+		for _, st := range ts.GetAvailableTools() {
+			result = append(result, st.Tool)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// generate renders the parameter structs and parse functions of all tools.
+func generate() ([]byte, error) {
+	var body bytes.Buffer
+	for _, tool := range tools() {
+		if err := writeTool(&body, tool); err != nil {
+			return nil, fmt.Errorf("tool %s: %w", tool.Name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by generate-params. DO NOT EDIT.\n\n")
+	buf.WriteString("package github\n\n")
+	buf.WriteString("import (\n")
+	if bytes.Contains(body.Bytes(), []byte("fmt.")) {
+		buf.WriteString("\t\"fmt\"\n\n")
+	}
+	buf.WriteString("\t\"github.com/mark3labs/mcp-go/mcp\"\n)\n")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+type param struct {
+	name     string
+	field    string
+	goType   string
+	required bool
+	schema   map[string]any
+}
+
+func writeTool(buf *bytes.Buffer, tool mcp.Tool) error {
+	required := map[string]bool{}
+	for _, name := range tool.InputSchema.Required {
+		required[name] = true
+	}
+
+	// Required parameters come first, in the order they are declared, so that the first missing one is reported
+	var optional []string
+	for name := range tool.InputSchema.Properties {
+		if !required[name] {
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(optional)
+	names := append(append([]string{}, tool.InputSchema.Required...), optional...)
+
+	params := make([]param, 0, len(names))
+	for _, name := range names {
+		schema, ok := tool.InputSchema.Properties[name].(map[string]any)
+		if !ok {
+			return fmt.Errorf("parameter %s has no schema", name)
+		}
+		goType, err := goTypeOf(schema)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", name, err)
+		}
+		params = append(params, param{name: name, field: exportedName(name), goType: goType, required: required[name], schema: schema})
+	}
+
+	typeName := exportedName(tool.Name) + "Params"
+
+	fmt.Fprintf(buf, "\n// %s holds the arguments of the %s tool.\n", typeName, tool.Name)
+	fmt.Fprintf(buf, "type %s struct {\n", typeName)
+	for _, p := range params {
+		if desc, _ := p.schema["description"].(string); desc != "" {
+			fmt.Fprintf(buf, "\t// %s\n", strings.ReplaceAll(desc, "\n", " "))
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:\"%s\"`\n", p.field, p.goType, p.name)
+	}
+	buf.WriteString("}\n")
+
+	fmt.Fprintf(buf, "\n// parse%s extracts and validates the arguments of the %s tool.\n", typeName, tool.Name)
+	fmt.Fprintf(buf, "func parse%s(r mcp.CallToolRequest) (%s, error) {\n", typeName, typeName)
+	fmt.Fprintf(buf, "\tvar params %s\n", typeName)
+	if len(params) > 0 {
+		buf.WriteString("\tvar err error\n")
+	}
+	for _, p := range params {
+		fmt.Fprintf(buf, "\tif params.%s, err = %s; err != nil {\n\t\treturn params, err\n\t}\n", p.field, extractor(p))
+		if p.required && strings.HasPrefix(p.goType, "[]") {
+			fmt.Fprintf(buf, "\tif len(params.%s) == 0 {\n\t\treturn params, fmt.Errorf(\"missing required parameter: %s\")\n\t}\n", p.field, p.name)
+		}
+	}
+	buf.WriteString("\treturn params, nil\n}\n")
+	return nil
+}
+
+// goTypeOf maps a JSON Schema type to the Go type of the struct field.
+func goTypeOf(schema map[string]any) (string, error) {
+	switch schema["type"] {
+	case "string":
+		return "string", nil
+	case "number", "integer":
+		// All numeric parameters of the tools are counts, numbers or IDs
+		return "int", nil
+	case "boolean":
+		return "bool", nil
+	case "object":
+		return "map[string]any", nil
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		if items != nil && items["type"] == "string" {
+			return "[]string", nil
+		}
+		return "[]any", nil
+	default:
+		return "", fmt.Errorf("unsupported type %v", schema["type"])
+	}
+}
+
+// extractor returns the expression that extracts a parameter, using the same helpers the handlers used before.
+func extractor(p param) string {
+	switch {
+	case p.goType == "int" && p.required:
+		return fmt.Sprintf("RequiredInt(r, %q)", p.name)
+	case p.goType == "int" && p.name == "page":
+		return fmt.Sprintf("OptionalIntParamWithDefault(r, %q, 1)", p.name)
+	case p.goType == "int" && (p.name == "perPage" || p.name == "per_page"):
+		return fmt.Sprintf("OptionalIntParamWithDefault(r, %q, 30)", p.name)
+	case p.goType == "int":
+		return fmt.Sprintf("OptionalIntParam(r, %q)", p.name)
+	case p.goType == "[]string":
+		return fmt.Sprintf("OptionalStringArrayParam(r, %q)", p.name)
+	case p.goType == "string" || p.goType == "bool":
+		if p.required && p.goType == "string" {
+			return fmt.Sprintf("requiredParam[string](r, %q)", p.name)
+		}
+		// A required boolean may be false, which requiredParam would treat as missing
+		if p.required {
+			return fmt.Sprintf("requiredPresentParam[bool](r, %q)", p.name)
+		}
+		return fmt.Sprintf("OptionalParam[%s](r, %q)", p.goType, p.name)
+	default:
+		if p.required {
+			return fmt.Sprintf("requiredPresentParam[%s](r, %q)", p.goType, p.name)
+		}
+		return fmt.Sprintf("OptionalParam[%s](r, %q)", p.goType, p.name)
+	}
+}
+
+// initialisms are written in upper case in field names, following Go naming conventions.
+var initialisms = map[string]bool{"id": true, "sha": true, "url": true, "api": true, "html": true, "json": true, "ci": true}
+
+// exportedName converts a snake_case or camelCase name to an exported Go identifier.
+func exportedName(name string) string {
+	var words []string
+	var word []rune
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-':
+			words = append(words, string(word))
+			word = nil
+		case unicode.IsUpper(r) && len(word) > 0:
+			words = append(words, string(word))
+			word = []rune{unicode.ToLower(r)}
+		default:
+			word = append(word, unicode.ToLower(r))
+		}
+	}
+	words = append(words, string(word))
+
+	var b strings.Builder
+	for _, w := range words {
+		if w == "" {
+			continue
+		}
+		if initialisms[w] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
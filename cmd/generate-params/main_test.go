@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedParamsUpToDate(t *testing.T) {
+	want, err := generate()
+	require.NoError(t, err)
+
+	got, err := os.ReadFile("../../pkg/github/params_gen.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), string(got), "pkg/github/params_gen.go is out of date, run go generate ./pkg/github")
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"owner":               "Owner",
+		"issue_number":        "IssueNumber",
+		"perPage":             "PerPage",
+		"expected_sha":        "ExpectedSHA",
+		"get_ci_matrix":       "GetCIMatrix",
+		"commit_id":           "CommitID",
+		"expected_updated_at": "ExpectedUpdatedAt",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, exportedName(name), name)
+	}
+}
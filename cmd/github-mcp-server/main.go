@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/github/github-mcp-server/internal/ghclient"
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/spf13/cobra"
@@ -29,37 +30,79 @@ var (
 		Short: "Start stdio server",
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			token := viper.GetString("personal_access_token")
-			if token == "" {
-				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
-			}
-
-			// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
-			// it's because viper doesn't handle comma-separated values correctly for env
-			// vars when using GetStringSlice.
-			// https://github.com/spf13/viper/issues/380
-			var enabledToolsets []string
-			if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
-				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
+			serverConfig, err := newServerConfig()
+			if err != nil {
+				return err
 			}
+			return ghmcp.RunStdioServer(serverConfig)
+		},
+	}
 
-			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
+	httpCmd = &cobra.Command{
+		Use:   "http",
+		Short: "Start HTTP server",
+		Long:  `Start a server that communicates over the streamable HTTP transport, with health and readiness endpoints for use behind load balancers.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			serverConfig, err := newServerConfig()
+			if err != nil {
+				return err
 			}
-
-			return ghmcp.RunStdioServer(stdioServerConfig)
+			return ghmcp.RunHTTPServer(ghmcp.HTTPServerConfig{
+				ServerConfig: serverConfig,
+				Address:      viper.GetString("address"),
+			})
 		},
 	}
 )
 
+// newServerConfig reads the configuration shared by all transports from the flags and environment.
+func newServerConfig() (ghmcp.ServerConfig, error) {
+	token := viper.GetString("personal_access_token")
+	if token == "" && !viper.GetBool("anonymous") {
+		return ghmcp.ServerConfig{}, errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set, pass --anonymous to serve public data without a token")
+	}
+
+	// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
+	// it's because viper doesn't handle comma-separated values correctly for env
+	// vars when using GetStringSlice.
+	// https://github.com/spf13/viper/issues/380
+	var enabledToolsets []string
+	if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
+		return ghmcp.ServerConfig{}, fmt.Errorf("failed to unmarshal toolsets: %w", err)
+	}
+
+	return ghmcp.ServerConfig{
+		Version:              version,
+		Host:                 viper.GetString("host"),
+		Token:                token,
+		Anonymous:            viper.GetBool("anonymous"),
+		AppID:                viper.GetInt64("app_id"),
+		AppPrivateKeyPath:    viper.GetString("app_private_key_path"),
+		EnabledToolsets:      enabledToolsets,
+		DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
+		ReadOnly:             viper.GetBool("read-only"),
+		ExportTranslations:   viper.GetBool("export-translations"),
+		EnableCommandLogging: viper.GetBool("enable-command-logging"),
+		LogFilePath:          viper.GetString("log-file"),
+		MaxWriteCalls:        viper.GetInt("max_write_calls"),
+		MaxRequestsPerMinute: viper.GetInt("max_requests_per_minute"),
+		MaxResultBytes:       viper.GetInt("max_result_bytes"),
+		ExportDir:            viper.GetString("export_dir"),
+		SessionLogDir:        viper.GetString("session_log"),
+		StatsLogInterval:     viper.GetDuration("stats_log_interval"),
+		UserAgentSuffix:      viper.GetString("user_agent_suffix"),
+		ShutdownTimeout:      viper.GetDuration("shutdown_timeout"),
+		ConfigPath:           viper.GetString("config"),
+		ScheduleConfigPath:   viper.GetString("schedule_config"),
+		Network: ghclient.NetworkConfig{
+			ProxyURL:       viper.GetString("proxy"),
+			CACertFile:     viper.GetString("ca_cert"),
+			ClientCertFile: viper.GetString("client_cert"),
+			ClientKeyFile:  viper.GetString("client_key"),
+		},
+	}, nil
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
@@ -69,22 +112,61 @@ func init() {
 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().Bool("anonymous", false, "Serve the read tools of public data without a token, with cached responses and a low request rate")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().Int("max-write-calls", 0, "Maximum number of write tool calls per session, 0 for unlimited")
+	rootCmd.PersistentFlags().Int("max-requests-per-minute", 0, "Maximum number of GitHub API requests per session per minute, 0 for unlimited")
+	rootCmd.PersistentFlags().Int("max-result-bytes", 0, fmt.Sprintf("Size in bytes above which tool results are split into pages read with fetch_more, 0 for the default of %d, -1 to disable", github.DefaultMaxResultBytes))
+	rootCmd.PersistentFlags().String("export-dir", "", "Directory export_issues can write exports to, exports are only returned in results when unset")
+	rootCmd.PersistentFlags().String("session-log", "", "Directory to log the tool calls and results of each session to, as JSONL with secrets redacted")
+	rootCmd.PersistentFlags().Duration("stats-log-interval", ghmcp.DefaultStatsLogInterval, "How often to log a summary of the tool calls, errors and latencies, 0 to disable")
+	rootCmd.PersistentFlags().String("user-agent-suffix", "", "Text appended to the User-Agent of GitHub API requests, to attribute them in audit logs")
+	rootCmd.PersistentFlags().String("proxy", "", "Proxy URL for GitHub API requests, defaults to the HTTPS_PROXY and HTTP_PROXY environment variables")
+	rootCmd.PersistentFlags().String("ca-cert", "", "Path to a PEM bundle of certificate authorities to trust in addition to the system ones, e.g. for a TLS inspecting proxy")
+	rootCmd.PersistentFlags().String("client-cert", "", "Path to a PEM encoded client certificate, for GitHub hosts requiring mutual TLS")
+	rootCmd.PersistentFlags().String("client-key", "", "Path to the PEM encoded private key of the client certificate")
+	rootCmd.PersistentFlags().String("schedule-config", "", "Path to a scheduler configuration file of tools to run periodically")
+	rootCmd.PersistentFlags().String("config", "", "Path to a YAML configuration file, reloaded on SIGHUP and when it changes")
+	rootCmd.PersistentFlags().Duration("shutdown-timeout", ghmcp.DefaultShutdownTimeout, "Maximum time to wait for in-flight tool calls when shutting down")
+	rootCmd.PersistentFlags().Int64("app-id", 0, "ID of a GitHub App, to mint scoped installation tokens with the apps toolset")
+	rootCmd.PersistentFlags().String("app-private-key-path", "", "Path to the PEM encoded private key of the GitHub App")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("anonymous", rootCmd.PersistentFlags().Lookup("anonymous"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("max_write_calls", rootCmd.PersistentFlags().Lookup("max-write-calls"))
+	_ = viper.BindPFlag("max_requests_per_minute", rootCmd.PersistentFlags().Lookup("max-requests-per-minute"))
+	_ = viper.BindPFlag("max_result_bytes", rootCmd.PersistentFlags().Lookup("max-result-bytes"))
+	_ = viper.BindPFlag("export_dir", rootCmd.PersistentFlags().Lookup("export-dir"))
+	_ = viper.BindPFlag("session_log", rootCmd.PersistentFlags().Lookup("session-log"))
+	_ = viper.BindPFlag("stats_log_interval", rootCmd.PersistentFlags().Lookup("stats-log-interval"))
+	_ = viper.BindPFlag("user_agent_suffix", rootCmd.PersistentFlags().Lookup("user-agent-suffix"))
+	_ = viper.BindPFlag("proxy", rootCmd.PersistentFlags().Lookup("proxy"))
+	_ = viper.BindPFlag("ca_cert", rootCmd.PersistentFlags().Lookup("ca-cert"))
+	_ = viper.BindPFlag("client_cert", rootCmd.PersistentFlags().Lookup("client-cert"))
+	_ = viper.BindPFlag("client_key", rootCmd.PersistentFlags().Lookup("client-key"))
+	_ = viper.BindPFlag("schedule_config", rootCmd.PersistentFlags().Lookup("schedule-config"))
+	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
+	_ = viper.BindPFlag("shutdown_timeout", rootCmd.PersistentFlags().Lookup("shutdown-timeout"))
+	_ = viper.BindPFlag("app_id", rootCmd.PersistentFlags().Lookup("app-id"))
+	_ = viper.BindPFlag("app_private_key_path", rootCmd.PersistentFlags().Lookup("app-private-key-path"))
+
+	// Add HTTP specific flags
+	httpCmd.Flags().String("address", ":8080", "Address for the HTTP server to listen on")
+	_ = viper.BindPFlag("address", httpCmd.Flags().Lookup("address"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
+	rootCmd.AddCommand(httpCmd)
 }
 
 func initConfig() {
@@ -0,0 +1,106 @@
+package ghclient
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime is how long the JSON Web Tokens authenticating as a GitHub App are valid. GitHub accepts at
+	// most 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockDrift backdates the tokens, as GitHub recommends, in case its clock is behind.
+	appJWTClockDrift = time.Minute
+)
+
+// ParseAppPrivateKey parses the PEM encoded private key of a GitHub App, as downloaded from the App settings
+// (PKCS #1) or converted to PKCS #8.
+func ParseAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found in the GitHub App private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// appJWTTransport authenticates requests as a GitHub App itself, rather than as one of its installations, with JSON
+// Web Tokens signed by the App's private key. A token is reused until shortly before it expires.
+type appJWTTransport struct {
+	transport http.RoundTripper
+	appID     int64
+	key       *rsa.PrivateKey
+	now       func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func (t *appJWTTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.jwt()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.transport.RoundTrip(req)
+}
+
+// jwt returns a valid token, signing a new one when the current one expires within a minute.
+func (t *appJWTTransport) jwt() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if t.token != "" && now.Add(time.Minute).Before(t.expires) {
+		return t.token, nil
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	expires := now.Add(appJWTLifetime)
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": expires.Unix(),
+		"iss": fmt.Sprint(t.appID),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App token: %w", err)
+	}
+
+	t.token = signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+	t.expires = expires
+	return t.token, nil
+}
@@ -0,0 +1,106 @@
+package ghclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAppPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	parsed, err := ParseAppPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	parsed, err = ParseAppPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	_, err = ParseAppPrivateKey([]byte("not a key"))
+	assert.EqualError(t, err, "no PEM data found in the GitHub App private key")
+}
+
+func TestNewApp(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	transport := &recordingTransport{}
+	clients, err := New(Config{
+		Token:         "secret",
+		Version:       "1.2.3",
+		Transport:     transport,
+		AppID:         42,
+		AppPrivateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, clients.App)
+
+	clients.SetUserAgent(UserAgent("1.2.3", "editor", "4.5.6"))
+	_, _, err = clients.App.Apps.Get(context.Background(), "")
+	require.NoError(t, err)
+	_, _, err = clients.App.Apps.Get(context.Background(), "")
+	require.NoError(t, err)
+
+	require.Len(t, transport.requests, 2)
+	req := transport.requests[0]
+	assert.Equal(t, "https://api.github.com/app", req.URL.String())
+	assert.Equal(t, "github-mcp-server/1.2.3 (editor/4.5.6)", req.Header.Get("User-Agent"))
+	assert.Equal(t, req.Header.Get("Authorization"), transport.requests[1].Header.Get("Authorization"), "the token is reused")
+
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	require.True(t, ok)
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "42", claims.Issuer)
+	assert.Equal(t, int64((appJWTLifetime+appJWTClockDrift)/time.Second), claims.ExpiresAt-claims.IssuedAt)
+}
+
+func TestAppJWTTransportRenewsToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	transport := &appJWTTransport{appID: 42, key: key, now: func() time.Time { return now }}
+
+	first, err := transport.jwt()
+	require.NoError(t, err)
+	now = now.Add(appJWTLifetime - 2*time.Minute)
+	second, err := transport.jwt()
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	now = now.Add(time.Minute + time.Second)
+	third, err := transport.jwt()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, third)
+}
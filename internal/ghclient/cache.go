@@ -0,0 +1,156 @@
+package ghclient
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCachedBodyBytes bounds the size of the responses CachingTransport keeps, larger ones are passed through.
+const maxCachedBodyBytes = 1 << 20
+
+// cachedResponse is a successful response kept by CachingTransport.
+type cachedResponse struct {
+	key     string
+	status  string
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// CachingTransport caches the successful responses of GET requests in memory. Responses younger than the TTL are
+// served without a request, older ones are revalidated with their ETag or Last-Modified date, which GitHub answers
+// with a 304 that does not count against the rate limit.
+type CachingTransport struct {
+	transport http.RoundTripper
+	ttl       time.Duration
+	size      int
+	now       func() time.Time
+
+	mu        sync.Mutex
+	responses map[string]*list.Element
+	// lru holds the responses from the most to the least recently used.
+	lru *list.List
+}
+
+// NewCachingTransport returns a transport caching at most size responses of requests sent through transport.
+func NewCachingTransport(transport http.RoundTripper, ttl time.Duration, size int) *CachingTransport {
+	return &CachingTransport{
+		transport: transport,
+		ttl:       ttl,
+		size:      size,
+		now:       time.Now,
+		responses: make(map[string]*list.Element),
+		lru:       list.New(),
+	}
+}
+
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.transport.RoundTrip(req)
+	}
+
+	// The media type requested changes the representation returned, e.g. a diff rather than JSON
+	key := req.URL.String() + "\x00" + req.Header.Get("Accept")
+	cached := t.get(key)
+	if cached != nil && t.now().Before(cached.expires) {
+		return cached.response(req, nil), nil
+	}
+
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if etag := cached.header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_ = resp.Body.Close()
+		t.put(&cachedResponse{key: key, status: cached.status, header: cached.header, body: cached.body, expires: t.now().Add(t.ttl)})
+		return cached.response(req, resp.Header), nil
+	}
+	if resp.StatusCode != http.StatusOK || resp.ContentLength > maxCachedBodyBytes {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodyBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodyBytes {
+		// Too large to keep, pass the body on as it was
+		resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.put(&cachedResponse{key: key, status: resp.Status, header: resp.Header.Clone(), body: body, expires: t.now().Add(t.ttl)})
+	return resp, nil
+}
+
+// response returns the cached response to req. The rate limit headers of a revalidation are copied over those of
+// the cached response, so that clients see the current limit.
+func (c *cachedResponse) response(req *http.Request, revalidated http.Header) *http.Response {
+	header := c.header.Clone()
+	for name, values := range revalidated {
+		if strings.HasPrefix(name, "X-Ratelimit-") {
+			header[name] = values
+		}
+	}
+	return &http.Response{
+		Status:        c.status,
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+func (t *CachingTransport) get(key string) *cachedResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	element, ok := t.responses[key]
+	if !ok {
+		return nil
+	}
+	t.lru.MoveToFront(element)
+	return element.Value.(*cachedResponse)
+}
+
+func (t *CachingTransport) put(response *cachedResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if element, ok := t.responses[response.key]; ok {
+		element.Value = response
+		t.lru.MoveToFront(element)
+		return
+	}
+	t.responses[response.key] = t.lru.PushFront(response)
+	for t.lru.Len() > max(t.size, 0) {
+		oldest := t.lru.Back()
+		t.lru.Remove(oldest)
+		delete(t.responses, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
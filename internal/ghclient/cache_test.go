@@ -0,0 +1,118 @@
+package ghclient
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conditionalTransport answers like GitHub: a 304 to requests whose If-None-Match matches the current ETag.
+type conditionalTransport struct {
+	etag     string
+	body     string
+	requests []*http.Request
+}
+
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	if req.Header.Get("If-None-Match") == t.etag {
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Header:     http.Header{"X-Ratelimit-Remaining": []string{"41"}},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": []string{t.etag}, "X-Ratelimit-Remaining": []string{"42"}},
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestCachingTransport(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	upstream := &conditionalTransport{etag: `"v1"`, body: `{"name":"repo"}`}
+	transport := NewCachingTransport(upstream, time.Minute, 2)
+	transport.now = func() time.Time { return now }
+
+	get := func(url, accept string) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp, string(body)
+	}
+
+	resp, body := get("https://api.github.com/repos/owner/repo", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"name":"repo"}`, body)
+	require.Len(t, upstream.requests, 1)
+
+	t.Run("fresh responses are served from the cache", func(t *testing.T) {
+		resp, body := get("https://api.github.com/repos/owner/repo", "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"name":"repo"}`, body)
+		assert.Len(t, upstream.requests, 1)
+	})
+
+	t.Run("other media types are requested", func(t *testing.T) {
+		get("https://api.github.com/repos/owner/repo", "application/vnd.github.diff")
+		assert.Len(t, upstream.requests, 2)
+	})
+
+	t.Run("stale responses are revalidated", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		resp, body := get("https://api.github.com/repos/owner/repo", "")
+		require.Len(t, upstream.requests, 3)
+		assert.Equal(t, `"v1"`, upstream.requests[2].Header.Get("If-None-Match"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"name":"repo"}`, body)
+		assert.Equal(t, "41", resp.Header.Get("X-RateLimit-Remaining"))
+
+		// The revalidated response is fresh again
+		get("https://api.github.com/repos/owner/repo", "")
+		assert.Len(t, upstream.requests, 3)
+	})
+
+	t.Run("changed responses replace the cached one", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		upstream.etag, upstream.body = `"v2"`, `{"name":"renamed"}`
+		_, body := get("https://api.github.com/repos/owner/repo", "")
+		assert.Equal(t, `{"name":"renamed"}`, body)
+		_, body = get("https://api.github.com/repos/owner/repo", "")
+		assert.Equal(t, `{"name":"renamed"}`, body)
+		assert.Len(t, upstream.requests, 4)
+	})
+
+	t.Run("least recently used responses are evicted", func(t *testing.T) {
+		get("https://api.github.com/repos/owner/other", "")
+		get("https://api.github.com/repos/owner/third", "")
+		requests := len(upstream.requests)
+		get("https://api.github.com/repos/owner/repo", "")
+		assert.Len(t, upstream.requests, requests+1)
+	})
+
+	t.Run("other methods pass through", func(t *testing.T) {
+		requests := len(upstream.requests)
+		req, err := http.NewRequest(http.MethodPost, "https://api.github.com/repos/owner/third", nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Len(t, upstream.requests, requests+1)
+	})
+}
@@ -0,0 +1,278 @@
+// Package ghclient constructs the GitHub REST and GraphQL clients used by the server. It is the one place that
+// decides the API URLs of a host, the user agent and the transport stack, so that every client talks to GitHub the
+// same way and upgrading go-github means changing the import below and in the tool packages, nothing else.
+package ghclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// GoGitHubModule is the go-github module the server is built against. All packages must import this major version,
+// as clients and types of different major versions cannot be mixed.
+const GoGitHubModule = "github.com/google/go-github/v69"
+
+// Config configures the clients.
+type Config struct {
+	// Host is the GitHub host to target (e.g. https://github.com or https://github.enterprise.com), empty means
+	// github.com
+	Host string
+
+	// Token authenticates requests to the API, which are anonymous when it is empty
+	Token string
+
+	// Version of the server, reported in the user agent
+	Version string
+
+	// UserAgentSuffix is appended to the user agent of every request, e.g. to attribute the traffic of a deployment
+	// in the audit log of an enterprise
+	UserAgentSuffix string
+
+	// Transport sends the requests, defaults to http.DefaultTransport. Authentication and the user agent are added
+	// on top of it.
+	Transport http.RoundTripper
+
+	// AppID and AppPrivateKey, the PEM encoded private key of the App, are the credentials of a GitHub App. When
+	// set, an App client authenticated as the App itself is created as well.
+	AppID         int64
+	AppPrivateKey []byte
+}
+
+// Clients holds a REST and a GraphQL client sharing the same host, credentials and transport.
+type Clients struct {
+	REST    *github.Client
+	GraphQL *githubv4.Client
+
+	// App is authenticated as the GitHub App of the configuration, to manage its installations. It is nil when no
+	// App is configured.
+	App *github.Client
+
+	userAgent *userAgentTransport
+}
+
+// New creates the clients for cfg. Requests of both clients go through the same transport stack: the user agent and
+// authorization headers are set, then the request is passed to cfg.Transport.
+func New(cfg Config) (*Clients, error) {
+	host, err := ParseHost(cfg.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	if strings.ContainsAny(cfg.UserAgentSuffix, "\r\n") {
+		return nil, fmt.Errorf("user agent suffix must be a single line")
+	}
+
+	transport := cfg.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	authenticated := transport
+	if cfg.Token != "" {
+		authenticated = &bearerAuthTransport{transport: transport, token: cfg.Token}
+	}
+	userAgent := &userAgentTransport{
+		transport: authenticated,
+		agent:     new(atomic.Value),
+		suffix:    cfg.UserAgentSuffix,
+	}
+	userAgent.agent.Store(UserAgent(cfg.Version, "", ""))
+	httpClient := &http.Client{Transport: userAgent}
+
+	restClient := github.NewClient(httpClient)
+	restClient.BaseURL = host.RESTURL
+	restClient.UploadURL = host.UploadURL
+
+	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
+	// did the necessary API host parsing so that github.com will return the correct URL anyway.
+	gqlClient := githubv4.NewEnterpriseClient(host.GraphQLURL.String(), httpClient)
+
+	clients := &Clients{
+		REST:      restClient,
+		GraphQL:   gqlClient,
+		userAgent: userAgent,
+	}
+
+	if cfg.AppID != 0 {
+		key, err := ParseAppPrivateKey(cfg.AppPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		appClient := github.NewClient(&http.Client{Transport: &userAgentTransport{
+			transport: &appJWTTransport{transport: transport, appID: cfg.AppID, key: key, now: time.Now},
+			agent:     userAgent.agent,
+			suffix:    cfg.UserAgentSuffix,
+		}})
+		appClient.BaseURL = host.RESTURL
+		appClient.UploadURL = host.UploadURL
+		clients.App = appClient
+	}
+	return clients, nil
+}
+
+// SetUserAgent changes the user agent of both clients. It is safe to call while requests are being made.
+func (c *Clients) SetUserAgent(agent string) {
+	c.userAgent.agent.Store(agent)
+}
+
+// UserAgent returns the user agent of the server, including the name and version of the MCP client when known.
+func UserAgent(version, clientName, clientVersion string) string {
+	if clientName == "" {
+		return fmt.Sprintf("github-mcp-server/%s", version)
+	}
+	return fmt.Sprintf("github-mcp-server/%s (%s/%s)", version, clientName, clientVersion)
+}
+
+// Host holds the API URLs of a GitHub host.
+type Host struct {
+	RESTURL    *url.URL
+	GraphQLURL *url.URL
+	UploadURL  *url.URL
+}
+
+func newDotcomHost() (Host, error) {
+	baseRestURL, err := url.Parse("https://api.github.com/")
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse dotcom REST URL: %w", err)
+	}
+
+	gqlURL, err := url.Parse("https://api.github.com/graphql")
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse dotcom GraphQL URL: %w", err)
+	}
+
+	uploadURL, err := url.Parse("https://uploads.github.com")
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse dotcom Upload URL: %w", err)
+	}
+
+	return Host{
+		RESTURL:    baseRestURL,
+		GraphQLURL: gqlURL,
+		UploadURL:  uploadURL,
+	}, nil
+}
+
+func newGHECHost(hostname string) (Host, error) {
+	u, err := url.Parse(hostname)
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHEC URL: %w", err)
+	}
+
+	// Unsecured GHEC would be an error
+	if u.Scheme == "http" {
+		return Host{}, fmt.Errorf("GHEC URL must be HTTPS")
+	}
+
+	restURL, err := url.Parse(fmt.Sprintf("https://api.%s/", u.Hostname()))
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHEC REST URL: %w", err)
+	}
+
+	gqlURL, err := url.Parse(fmt.Sprintf("https://api.%s/graphql", u.Hostname()))
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHEC GraphQL URL: %w", err)
+	}
+
+	uploadURL, err := url.Parse(fmt.Sprintf("https://uploads.%s", u.Hostname()))
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHEC Upload URL: %w", err)
+	}
+
+	return Host{
+		RESTURL:    restURL,
+		GraphQLURL: gqlURL,
+		UploadURL:  uploadURL,
+	}, nil
+}
+
+func newGHESHost(hostname string) (Host, error) {
+	u, err := url.Parse(hostname)
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHES URL: %w", err)
+	}
+
+	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Hostname()))
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHES REST URL: %w", err)
+	}
+
+	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Hostname()))
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHES GraphQL URL: %w", err)
+	}
+
+	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Hostname()))
+	if err != nil {
+		return Host{}, fmt.Errorf("failed to parse GHES Upload URL: %w", err)
+	}
+
+	return Host{
+		RESTURL:    restURL,
+		GraphQLURL: gqlURL,
+		UploadURL:  uploadURL,
+	}, nil
+}
+
+// ParseHost returns the API URLs of a github.com, GHEC (ghe.com) or GHES host.
+// Note that this does not handle ports yet, so development environments are out.
+func ParseHost(s string) (Host, error) {
+	if s == "" {
+		return newDotcomHost()
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return Host{}, fmt.Errorf("could not parse host as URL: %s", s)
+	}
+
+	if u.Scheme == "" {
+		return Host{}, fmt.Errorf("host must have a scheme (http or https): %s", s)
+	}
+
+	if strings.HasSuffix(u.Hostname(), "github.com") {
+		return newDotcomHost()
+	}
+
+	if strings.HasSuffix(u.Hostname(), "ghe.com") {
+		return newGHECHost(s)
+	}
+
+	return newGHESHost(s)
+}
+
+// userAgentTransport sets the user agent of requests, followed by the configured suffix. The App client shares the
+// agent of the other clients.
+type userAgentTransport struct {
+	transport http.RoundTripper
+	agent     *atomic.Value
+	suffix    string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	agent := t.agent.Load().(string)
+	if t.suffix != "" {
+		agent += " " + t.suffix
+	}
+	req.Header.Set("User-Agent", agent)
+	return t.transport.RoundTrip(req)
+}
+
+type bearerAuthTransport struct {
+	transport http.RoundTripper
+	token     string
+}
+
+func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,180 @@
+package ghclient
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHost(t *testing.T) {
+	tests := []struct {
+		host          string
+		rest          string
+		graphql       string
+		upload        string
+		expectedError string
+	}{
+		{
+			host:    "",
+			rest:    "https://api.github.com/",
+			graphql: "https://api.github.com/graphql",
+			upload:  "https://uploads.github.com",
+		},
+		{
+			host:    "https://github.com",
+			rest:    "https://api.github.com/",
+			graphql: "https://api.github.com/graphql",
+			upload:  "https://uploads.github.com",
+		},
+		{
+			host:    "https://octocorp.ghe.com",
+			rest:    "https://api.octocorp.ghe.com/",
+			graphql: "https://api.octocorp.ghe.com/graphql",
+			upload:  "https://uploads.octocorp.ghe.com",
+		},
+		{
+			host:    "http://github.example.com",
+			rest:    "http://github.example.com/api/v3/",
+			graphql: "http://github.example.com/api/graphql",
+			upload:  "http://github.example.com/api/uploads/",
+		},
+		{
+			host:          "http://octocorp.ghe.com",
+			expectedError: "GHEC URL must be HTTPS",
+		},
+		{
+			host:          "github.example.com",
+			expectedError: "host must have a scheme (http or https): github.example.com",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			host, err := ParseHost(tc.host)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.rest, host.RESTURL.String())
+			assert.Equal(t, tc.graphql, host.GraphQLURL.String())
+			assert.Equal(t, tc.upload, host.UploadURL.String())
+		})
+	}
+}
+
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"data":{"viewer":{"login":"octocat"}}}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestNew(t *testing.T) {
+	transport := &recordingTransport{}
+	clients, err := New(Config{
+		Host:      "https://github.example.com",
+		Token:     "secret",
+		Version:   "1.2.3",
+		Transport: transport,
+	})
+	require.NoError(t, err)
+
+	_, _, err = clients.REST.Users.Get(context.Background(), "")
+	require.NoError(t, err)
+
+	clients.SetUserAgent(UserAgent("1.2.3", "editor", "4.5.6"))
+	var query struct {
+		Viewer struct {
+			Login string
+		}
+	}
+	require.NoError(t, clients.GraphQL.Query(context.Background(), &query, nil))
+	assert.Equal(t, "octocat", query.Viewer.Login)
+
+	require.Len(t, transport.requests, 2)
+	rest, gql := transport.requests[0], transport.requests[1]
+
+	assert.Equal(t, "https://github.example.com/api/v3/user", rest.URL.String())
+	assert.Equal(t, "Bearer secret", rest.Header.Get("Authorization"))
+	assert.Equal(t, "github-mcp-server/1.2.3", rest.Header.Get("User-Agent"))
+
+	assert.Equal(t, "https://github.example.com/api/graphql", gql.URL.String())
+	assert.Equal(t, "Bearer secret", gql.Header.Get("Authorization"))
+	assert.Equal(t, "github-mcp-server/1.2.3 (editor/4.5.6)", gql.Header.Get("User-Agent"))
+}
+
+func TestUserAgentSuffix(t *testing.T) {
+	transport := &recordingTransport{}
+	clients, err := New(Config{Version: "1.2.3", UserAgentSuffix: "acme-platform/7", Transport: transport})
+	require.NoError(t, err)
+
+	clients.SetUserAgent(UserAgent("1.2.3", "editor", "4.5.6"))
+	_, _, err = clients.REST.Users.Get(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, transport.requests, 1)
+	assert.Equal(t, "github-mcp-server/1.2.3 (editor/4.5.6) acme-platform/7", transport.requests[0].Header.Get("User-Agent"))
+	// Requests are anonymous without a token
+	_, hasAuthorization := transport.requests[0].Header["Authorization"]
+	assert.False(t, hasAuthorization)
+
+	_, err = New(Config{UserAgentSuffix: "a\r\nX-Injected: 1"})
+	require.EqualError(t, err, "user agent suffix must be a single line")
+}
+
+// TestSingleGoGitHubVersion guards against packages importing a different major version of go-github, whose types
+// cannot be used with the clients created here.
+func TestSingleGoGitHubVersion(t *testing.T) {
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(importPath, "github.com/google/go-github/") {
+				assert.True(t, strings.HasPrefix(importPath, GoGitHubModule+"/"), "%s imports %s, use %s", path, importPath, GoGitHubModule)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
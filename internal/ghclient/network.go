@@ -0,0 +1,85 @@
+package ghclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// NetworkConfig configures how the clients reach GitHub, for networks where requests go through a proxy, possibly
+// one inspecting TLS, or where GitHub requires client certificates.
+type NetworkConfig struct {
+	// ProxyURL is the proxy requests go through, e.g. http://proxy.example.com:3128. When empty, the HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables are used.
+	ProxyURL string
+
+	// CACertFile is a PEM bundle of certificate authorities trusted in addition to those of the system, such as the
+	// authority of a TLS inspecting proxy
+	CACertFile string
+
+	// ClientCertFile and ClientKeyFile are the PEM encoded certificate and private key presented to servers that
+	// require mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// NewTransport returns the transport sending requests as configured: http.DefaultTransport when nothing is set,
+// otherwise a copy of it with the proxy and TLS settings applied.
+func NewTransport(cfg NetworkConfig) (*http.Transport, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("the default HTTP transport was replaced, it cannot be configured")
+	}
+	if cfg == (NetworkConfig{}) {
+		return base, nil
+	}
+
+	transport := base.Clone()
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("proxy URL must have an http, https or socks5 scheme: %s", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in %s", cfg.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if (cfg.ClientCertFile == "") != (cfg.ClientKeyFile == "") {
+		return nil, errors.New("a client certificate and its private key must be set together")
+	}
+	if cfg.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
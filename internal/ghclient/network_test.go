@@ -0,0 +1,110 @@
+package ghclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes a PEM block to a file in dir and returns its path.
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// clientCertificate creates a self-signed client certificate and returns the paths of its certificate and key.
+func clientCertificate(t *testing.T, dir string) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "github-mcp-server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return cert, writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "PRIVATE KEY", keyDER)
+}
+
+func TestNewTransport(t *testing.T) {
+	transport, err := NewTransport(NetworkConfig{})
+	require.NoError(t, err)
+	assert.Same(t, http.DefaultTransport, transport)
+
+	t.Run("proxy", func(t *testing.T) {
+		transport, err := NewTransport(NetworkConfig{ProxyURL: "http://proxy.example.com:3128"})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		proxy, err := transport.Proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, "http://proxy.example.com:3128", proxy.String())
+
+		_, err = NewTransport(NetworkConfig{ProxyURL: "proxy.example.com:3128"})
+		require.ErrorContains(t, err, "proxy URL must have an http, https or socks5 scheme")
+	})
+
+	t.Run("custom CA and client certificate", func(t *testing.T) {
+		dir := t.TempDir()
+		clientCert, certFile, keyFile := clientCertificate(t, dir)
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(clientCert)
+
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}))
+		srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+		srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+		srv.StartTLS()
+		defer srv.Close()
+		caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+
+		// The server is neither trusted nor given a client certificate by default
+		_, err := (&http.Client{Transport: http.DefaultTransport}).Get(srv.URL)
+		require.Error(t, err)
+
+		transport, err := NewTransport(NetworkConfig{CACertFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile})
+		require.NoError(t, err)
+		defer transport.CloseIdleConnections()
+		resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "github-mcp-server", string(body))
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		dir := t.TempDir()
+		notPEM := filepath.Join(dir, "ca.pem")
+		require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+		_, err := NewTransport(NetworkConfig{CACertFile: notPEM})
+		require.ErrorContains(t, err, "no PEM encoded certificates found")
+
+		_, err = NewTransport(NetworkConfig{ClientCertFile: notPEM})
+		require.EqualError(t, err, "a client certificate and its private key must be set together")
+	})
+}
@@ -0,0 +1,104 @@
+package ghmcp
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCompression(t *testing.T) {
+	large := strings.Repeat(`{"key":"value"}`, 200)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		flush          bool
+		expectGzip     bool
+	}{
+		{
+			name:           "large JSON is compressed",
+			acceptEncoding: "gzip, deflate",
+			contentType:    "application/json",
+			body:           large,
+			expectGzip:     true,
+		},
+		{
+			name:           "small JSON is not compressed",
+			acceptEncoding: "gzip",
+			contentType:    "application/json",
+			body:           `{"key":"value"}`,
+		},
+		{
+			name:        "client without gzip support",
+			contentType: "application/json",
+			body:        large,
+		},
+		{
+			name:           "gzip refused by the client",
+			acceptEncoding: "gzip;q=0",
+			contentType:    "application/json",
+			body:           large,
+		},
+		{
+			name:           "event streams are not compressed",
+			acceptEncoding: "gzip",
+			contentType:    "text/event-stream",
+			body:           large,
+			flush:          true,
+		},
+		{
+			name:           "flushed before the threshold",
+			acceptEncoding: "gzip",
+			contentType:    "application/json",
+			body:           `{"key":"value"}`,
+			flush:          true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := withCompression(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.WriteHeader(http.StatusAccepted)
+				for _, chunk := range []string{tc.body[:len(tc.body)/2], tc.body[len(tc.body)/2:]} {
+					_, _ = w.Write([]byte(chunk))
+					if tc.flush {
+						w.(http.Flusher).Flush()
+					}
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+
+			body := rec.Body.String()
+			if tc.expectGzip {
+				require.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+				assert.Less(t, rec.Body.Len(), len(tc.body))
+				gz, err := gzip.NewReader(rec.Body)
+				require.NoError(t, err)
+				decompressed, err := io.ReadAll(gz)
+				require.NoError(t, err)
+				body = string(decompressed)
+			} else {
+				assert.Empty(t, rec.Header().Get("Content-Encoding"))
+			}
+			assert.Equal(t, tc.body, body)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig is the configuration read from the file passed with --config. It is read again on SIGHUP and whenever
+// the file changes, without restarting the server.
+type FileConfig struct {
+	// Toolsets replaces the toolsets enabled with --toolsets when set
+	Toolsets []string `mapstructure:"toolsets"`
+
+	// ReadOnly restricts the server to read-only tools. It cannot lift --read-only.
+	ReadOnly bool `mapstructure:"read_only"`
+
+	Policies PoliciesConfig `mapstructure:"policies"`
+
+	Cache CacheConfig `mapstructure:"cache"`
+
+	// Defaults holds argument values used for each tool, by tool name, when the caller leaves them out
+	Defaults map[string]map[string]any `mapstructure:"defaults"`
+
+	// WebhookSecrets are the secrets of webhooks by name, used to verify deliveries. A value of the form
+	// env:NAME is read from the environment variable NAME when the file is loaded.
+	WebhookSecrets map[string]string `mapstructure:"webhook_secrets"`
+
+	// ReviewChecklists are the checklists get_review_checklist maps the changed paths of pull requests to
+	ReviewChecklists []github.ReviewChecklist `mapstructure:"review_checklists"`
+
+	// Translations overrides tool descriptions and titles, by translation key
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
+	Translations map[string]string `mapstructure:"translations"`
+}
+
+// PoliciesConfig replaces the session budget flags when its values are set.
+type PoliciesConfig struct {
+	MaxWriteCalls        int `mapstructure:"max_write_calls"`
+	MaxRequestsPerMinute int `mapstructure:"max_requests_per_minute"`
+	// MaxResultBytes is the size above which tool results are split into pages read with fetch_more, -1 disables
+	// splitting
+	MaxResultBytes int `mapstructure:"max_result_bytes"`
+}
+
+// CacheConfig configures the in-memory caches of the server.
+type CacheConfig struct {
+	// IdempotencyTTL is how long write results are remembered by idempotency key
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+
+	// CommitResults is how many results of content, tree and blame tools are cached by commit SHA, -1 disables
+	// the cache
+	CommitResults int `mapstructure:"commit_results"`
+}
+
+// LoadFileConfig reads and validates a YAML configuration file. The file is not read with viper, which would
+// lowercase the tool argument names in defaults.
+func LoadFileConfig(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	var cfg FileConfig
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  mapstructure.StringToTimeDurationHookFunc(),
+		ErrorUnused: true,
+		Result:      &cfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	for name, secret := range cfg.WebhookSecrets {
+		if variable, ok := strings.CutPrefix(secret, "env:"); ok {
+			value, ok := os.LookupEnv(variable)
+			if !ok || value == "" {
+				return nil, fmt.Errorf("webhook secret %s in config %s: environment variable %s is not set", name, path, variable)
+			}
+			cfg.WebhookSecrets[name] = value
+		}
+		if cfg.WebhookSecrets[name] == "" {
+			return nil, fmt.Errorf("webhook secret %s in config %s is empty", name, path)
+		}
+	}
+
+	if err := github.ValidateReviewChecklists(cfg.ReviewChecklists); err != nil {
+		return nil, fmt.Errorf("invalid review checklists in config %s: %w", path, err)
+	}
+
+	// Tool handlers expect arguments as decoded from JSON, e.g. numbers as float64
+	if len(cfg.Defaults) > 0 {
+		b, err := json.Marshal(cfg.Defaults)
+		if err != nil {
+			return nil, fmt.Errorf("invalid defaults in config %s: %w", path, err)
+		}
+		cfg.Defaults = nil
+		if err := json.Unmarshal(b, &cfg.Defaults); err != nil {
+			return nil, fmt.Errorf("invalid defaults in config %s: %w", path, err)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// apply returns cfg with the settings of the file applied on top.
+func (fc *FileConfig) apply(cfg MCPServerConfig) MCPServerConfig {
+	if len(fc.Toolsets) > 0 {
+		cfg.EnabledToolsets = fc.Toolsets
+	}
+	cfg.ReadOnly = cfg.ReadOnly || fc.ReadOnly
+	if fc.Policies.MaxWriteCalls > 0 {
+		cfg.Budget.MaxWriteCalls = fc.Policies.MaxWriteCalls
+	}
+	if fc.Policies.MaxRequestsPerMinute > 0 {
+		cfg.Budget.MaxRequestsPerMinute = fc.Policies.MaxRequestsPerMinute
+	}
+	if fc.Policies.MaxResultBytes != 0 {
+		cfg.MaxResultBytes = fc.Policies.MaxResultBytes
+	}
+	if fc.Cache.IdempotencyTTL > 0 {
+		cfg.IdempotencyTTL = fc.Cache.IdempotencyTTL
+	}
+	if fc.Cache.CommitResults != 0 {
+		cfg.SHACacheSize = fc.Cache.CommitResults
+	}
+	cfg.ArgumentDefaults = fc.Defaults
+	cfg.WebhookSecrets = fc.WebhookSecrets
+	cfg.ReviewChecklists = fc.ReviewChecklists
+	if len(fc.Translations) > 0 {
+		cfg.Translator = overrideTranslations(cfg.Translator, fc.Translations)
+	}
+	return cfg
+}
+
+// overrideTranslations returns a translation helper that prefers the given values over those of t.
+func overrideTranslations(t translations.TranslationHelperFunc, overrides map[string]string) translations.TranslationHelperFunc {
+	upper := make(map[string]string, len(overrides))
+	for k, v := range overrides {
+		upper[strings.ToUpper(k)] = v
+	}
+	return func(key string, defaultValue string) string {
+		if value, ok := upper[strings.ToUpper(key)]; ok {
+			return value
+		}
+		return t(key, defaultValue)
+	}
+}
+
+// watchConfig reloads the configuration file on SIGHUP and whenever it changes, until ctx is done. base holds the
+// settings from flags and the environment that the file is applied on top of. A file that fails to load is logged
+// and the running configuration is kept.
+func watchConfig(ctx context.Context, path string, base MCPServerConfig, tools *serverTools, logger *logrus.Logger) {
+	reload := func(reason string) {
+		fc, err := LoadFileConfig(path)
+		if err != nil {
+			logger.Errorf("not reloading configuration: %v", err)
+			return
+		}
+		if err := tools.register(fc.apply(base)); err != nil {
+			logger.Errorf("not reloading configuration: %v", err)
+			return
+		}
+		logger.Infof("reloaded configuration from %s after %s", path, reason)
+	}
+
+	// viper watches the directory of the file, so that editors replacing the file are noticed too
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.OnConfigChange(func(_ fsnotify.Event) {
+		if ctx.Err() == nil {
+			reload("file change")
+		}
+	})
+	v.WatchConfig()
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-hup:
+				reload("SIGHUP")
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,233 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadFileConfig(t *testing.T) {
+	path := writeConfig(t, `
+toolsets: [repos, issues]
+read_only: true
+policies:
+  max_write_calls: 10
+  max_requests_per_minute: 100
+  max_result_bytes: 50000
+cache:
+  idempotency_ttl: 1h
+  commit_results: 500
+defaults:
+  list_issues:
+    perPage: 50
+translations:
+  TOOL_GET_ME_DESCRIPTION: Who am I
+`)
+
+	cfg, err := LoadFileConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"repos", "issues"}, cfg.Toolsets)
+	assert.True(t, cfg.ReadOnly)
+	assert.Equal(t, PoliciesConfig{MaxWriteCalls: 10, MaxRequestsPerMinute: 100, MaxResultBytes: 50000}, cfg.Policies)
+	assert.Equal(t, time.Hour, cfg.Cache.IdempotencyTTL)
+	assert.Equal(t, 500, cfg.Cache.CommitResults)
+	// Numbers are decoded as they would be from a JSON tool call
+	assert.Equal(t, map[string]map[string]any{"list_issues": {"perPage": float64(50)}}, cfg.Defaults)
+	assert.Equal(t, "Who am I", cfg.Translations["TOOL_GET_ME_DESCRIPTION"])
+}
+
+func TestLoadFileConfigRejectsUnknownKeys(t *testing.T) {
+	path := writeConfig(t, "toolset: [repos]\n")
+
+	_, err := LoadFileConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "toolset")
+}
+
+func TestLoadFileConfigWebhookSecrets(t *testing.T) {
+	t.Setenv("TEST_WEBHOOK_SECRET", "from-env")
+	path := writeConfig(t, `
+webhook_secrets:
+  issues: literal
+  releases: env:TEST_WEBHOOK_SECRET
+`)
+
+	cfg, err := LoadFileConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"issues": "literal", "releases": "from-env"}, cfg.WebhookSecrets)
+
+	_, err = LoadFileConfig(writeConfig(t, "webhook_secrets:\n  issues: env:TEST_UNSET_WEBHOOK_SECRET\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "environment variable TEST_UNSET_WEBHOOK_SECRET is not set")
+}
+
+func TestLoadFileConfigReviewChecklists(t *testing.T) {
+	path := writeConfig(t, `
+review_checklists:
+  - name: security
+    team: "@acme/security"
+    paths: [auth/, "**/*token*"]
+    items:
+      - Secrets are not logged
+  - name: schema
+    paths: [migrations/]
+    items: [Migration is reversible]
+`)
+
+	cfg, err := LoadFileConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []github.ReviewChecklist{
+		{Name: "security", Team: "@acme/security", Paths: []string{"auth/", "**/*token*"}, Items: []string{"Secrets are not logged"}},
+		{Name: "schema", Paths: []string{"migrations/"}, Items: []string{"Migration is reversible"}},
+	}, cfg.ReviewChecklists)
+	assert.Equal(t, cfg.ReviewChecklists, cfg.apply(MCPServerConfig{}).ReviewChecklists)
+
+	_, err = LoadFileConfig(writeConfig(t, "review_checklists:\n  - name: schema\n    paths: [migrations/]\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "review checklist schema has no items")
+}
+
+func TestFileConfigApply(t *testing.T) {
+	base := MCPServerConfig{
+		EnabledToolsets: []string{"all"},
+		ReadOnly:        true,
+		Translator:      translations.NullTranslationHelper,
+	}
+	base.Budget.MaxWriteCalls = 5
+
+	fc := &FileConfig{
+		Toolsets:     []string{"repos"},
+		Policies:     PoliciesConfig{MaxRequestsPerMinute: 60, MaxResultBytes: -1},
+		Cache:        CacheConfig{CommitResults: -1},
+		Translations: map[string]string{"tool_get_me_description": "Who am I"},
+	}
+	cfg := fc.apply(base)
+
+	assert.Equal(t, []string{"repos"}, cfg.EnabledToolsets)
+	// The file cannot lift read-only mode set by flags
+	assert.True(t, cfg.ReadOnly)
+	assert.Equal(t, 5, cfg.Budget.MaxWriteCalls)
+	assert.Equal(t, 60, cfg.Budget.MaxRequestsPerMinute)
+	assert.Equal(t, -1, cfg.SHACacheSize)
+	assert.Equal(t, -1, cfg.MaxResultBytes)
+	assert.Equal(t, "Who am I", cfg.Translator("TOOL_GET_ME_DESCRIPTION", "default"))
+	assert.Equal(t, "default", cfg.Translator("TOOL_OTHER_DESCRIPTION", "default"))
+}
+
+func TestServerToolsRegisterReplacesTools(t *testing.T) {
+	ghServer, tools, err := newMCPServer(MCPServerConfig{
+		Version:         "test",
+		EnabledToolsets: []string{"repos", "issues"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	listTools := func() []string {
+		return listToolNames(t, ghServer)
+	}
+
+	assert.Contains(t, listTools(), "create_issue")
+	assert.Contains(t, listTools(), "create_branch")
+
+	require.NoError(t, tools.register(MCPServerConfig{
+		EnabledToolsets: []string{"repos"},
+		ReadOnly:        true,
+		Translator:      translations.NullTranslationHelper,
+	}))
+
+	names := listTools()
+	assert.Contains(t, names, "get_file_contents")
+	assert.NotContains(t, names, "create_issue")
+	assert.NotContains(t, names, "create_branch")
+	assert.NotContains(t, names, "undo_last_action")
+	assert.Contains(t, names, "get_server_stats")
+}
+
+func TestUndoCountsAgainstWriteBudget(t *testing.T) {
+	ghServer, _, err := newMCPServer(MCPServerConfig{
+		Version:         "test",
+		EnabledToolsets: []string{"repos"},
+		Budget:          github.BudgetConfig{MaxWriteCalls: 1},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	callUndo := func() string {
+		response := ghServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"undo_last_action","arguments":{}}}`))
+		raw, err := json.Marshal(response)
+		require.NoError(t, err)
+		return string(raw)
+	}
+
+	assert.Contains(t, callUndo(), "there are no actions to undo in this session")
+	assert.Contains(t, callUndo(), "the limit of 1 write calls for this session has been reached")
+}
+
+func TestAnonymousServer(t *testing.T) {
+	ghServer, _, err := newMCPServer(MCPServerConfig{
+		Version:         "test",
+		Anonymous:       true,
+		EnabledToolsets: []string{"all"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	names := listToolNames(t, ghServer)
+	assert.Contains(t, names, "get_file_contents")
+	assert.Contains(t, names, "list_issues")
+	assert.NotContains(t, names, "get_me")
+	assert.NotContains(t, names, "create_issue")
+	assert.NotContains(t, names, "undo_last_action")
+
+	// Write tools are refused even when called by name
+	response := ghServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"create_issue","arguments":{"owner":"o","repo":"r","title":"t"}}}`))
+	raw, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"error"`)
+	assert.Contains(t, string(raw), "create_issue")
+
+	_, _, err = newMCPServer(MCPServerConfig{
+		Version:    "test",
+		Token:      "secret",
+		Anonymous:  true,
+		Translator: translations.NullTranslationHelper,
+	})
+	require.EqualError(t, err, "anonymous mode cannot be combined with a token or GitHub App credentials")
+}
+
+// listToolNames returns the names of the tools the server lists.
+func listToolNames(t *testing.T, ghServer *server.MCPServer) []string {
+	t.Helper()
+	response := ghServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	raw, err := json.Marshal(response)
+	require.NoError(t, err)
+	var decoded struct {
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	names := make([]string, 0, len(decoded.Result.Tools))
+	for _, tool := range decoded.Result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
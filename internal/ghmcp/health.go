@@ -0,0 +1,124 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	gogithub "github.com/google/go-github/v69/github"
+)
+
+const (
+	// healthCheckTTL is how long the result of a GitHub check is reused, so that frequent probes do not consume
+	// API rate limit.
+	healthCheckTTL = 30 * time.Second
+
+	// healthCheckTimeout bounds a single GitHub check.
+	healthCheckTimeout = 5 * time.Second
+)
+
+// healthStatus is the outcome of checking the token against the GitHub API.
+type healthStatus struct {
+	// TokenValid is false only when GitHub rejected the token; it stays true when GitHub could not be reached.
+	TokenValid bool      `json:"token_valid"`
+	Reachable  bool      `json:"github_reachable"`
+	Login      string    `json:"login,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// healthChecker verifies token validity and GitHub reachability for the /healthz and /readyz endpoints, caching
+// results for healthCheckTTL.
+type healthChecker struct {
+	client *gogithub.Client
+	now    func() time.Time
+	// anonymous checks GitHub with the rate limit endpoint, as there is no token to check and it doesn't count
+	// against the limit.
+	anonymous bool
+	// draining reports whether the server is shutting down, failing readiness so that no new sessions are routed to
+	// it. It is optional.
+	draining func() bool
+
+	mu   sync.Mutex
+	last *healthStatus
+}
+
+func newHealthChecker(client *gogithub.Client) *healthChecker {
+	return &healthChecker{client: client, now: time.Now}
+}
+
+// status returns the cached status, checking GitHub again once it has expired.
+func (h *healthChecker) status(ctx context.Context) healthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.last != nil && h.now().Sub(h.last.CheckedAt) < healthCheckTTL {
+		return *h.last
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	status := healthStatus{TokenValid: true, CheckedAt: h.now()}
+	var user *gogithub.User
+	var resp *gogithub.Response
+	var err error
+	if h.anonymous {
+		_, resp, err = h.client.RateLimit.Get(ctx)
+	} else {
+		user, resp, err = h.client.Users.Get(ctx, "")
+	}
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	switch {
+	case err == nil:
+		status.Reachable = true
+		status.Login = user.GetLogin()
+	case resp != nil && resp.StatusCode == http.StatusUnauthorized:
+		status.Reachable = true
+		status.TokenValid = false
+		status.Error = "GitHub rejected the token"
+	default:
+		var rateLimitErr *gogithub.RateLimitError
+		// Being rate limited still proves both the token and the connection work.
+		if errors.As(err, &rateLimitErr) {
+			status.Reachable = true
+		}
+		status.Error = err.Error()
+	}
+
+	h.last = &status
+	return status
+}
+
+func writeHealth(w http.ResponseWriter, ok bool, status healthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleHealthz is the liveness probe. It only fails when the token is invalid, as restarting the server cannot fix
+// GitHub being unreachable.
+func (h *healthChecker) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	status := h.status(r.Context())
+	writeHealth(w, status.TokenValid, status)
+}
+
+// handleReadyz is the readiness probe. It fails unless GitHub is reachable and accepts the token, taking the server
+// out of rotation until it can serve tool calls, and once the server is shutting down.
+func (h *healthChecker) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if h.draining != nil && h.draining() {
+		writeHealth(w, false, healthStatus{TokenValid: true, Error: "the server is shutting down", CheckedAt: h.now()})
+		return
+	}
+	status := h.status(r.Context())
+	writeHealth(w, status.TokenValid && status.Reachable, status)
+}
@@ -0,0 +1,128 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecker(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		expectHealthz int
+		expectReadyz  int
+	}{
+		{
+			name: "valid token",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(mock.MustMarshal(&gogithub.User{Login: gogithub.Ptr("octocat")}))
+			},
+			expectHealthz: http.StatusOK,
+			expectReadyz:  http.StatusOK,
+		},
+		{
+			name: "invalid token",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+			},
+			expectHealthz: http.StatusServiceUnavailable,
+			expectReadyz:  http.StatusServiceUnavailable,
+		},
+		{
+			name: "GitHub unavailable",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			expectHealthz: http.StatusOK,
+			expectReadyz:  http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := gogithub.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetUser, tc.handler),
+			))
+			h := newHealthChecker(client)
+
+			rec := httptest.NewRecorder()
+			h.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, tc.expectHealthz, rec.Code)
+
+			rec = httptest.NewRecorder()
+			h.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Equal(t, tc.expectReadyz, rec.Code)
+		})
+	}
+}
+
+func TestHealthCheckerAnonymous(t *testing.T) {
+	client := gogithub.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetRateLimit, &gogithub.RateLimits{Core: &gogithub.Rate{Limit: 60, Remaining: 42}}),
+	))
+	h := newHealthChecker(client)
+	h.anonymous = true
+
+	rec := httptest.NewRecorder()
+	h.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHealthCheckerCachesResults(t *testing.T) {
+	var calls atomic.Int32
+	client := gogithub.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(mock.GetUser, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(mock.MustMarshal(&gogithub.User{Login: gogithub.Ptr("octocat")}))
+		})),
+	))
+	h := newHealthChecker(client)
+	now := time.Now()
+	h.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		h.handleReadyz(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(healthCheckTTL)
+	h.handleReadyz(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHealthCheckerDraining(t *testing.T) {
+	client := gogithub.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetUser, &gogithub.User{Login: gogithub.Ptr("octocat")}),
+	))
+	drain := newDrainer()
+	h := newHealthChecker(client)
+	h.draining = drain.isDraining
+
+	rec := httptest.NewRecorder()
+	h.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	require.NoError(t, drain.drain(context.Background()))
+
+	rec = httptest.NewRecorder()
+	h.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "the server is shutting down")
+
+	// Liveness is unaffected, so that the server isn't restarted while it drains
+	rec = httptest.NewRecorder()
+	h.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,128 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghclient"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// HTTPServerConfig configures the server served over the streamable HTTP transport.
+type HTTPServerConfig struct {
+	ServerConfig
+
+	// Address to listen on, e.g. ":8080"
+	Address string
+}
+
+// RunHTTPServer serves MCP over the streamable HTTP transport at /mcp, along with /healthz and /readyz probes.
+func RunHTTPServer(cfg HTTPServerConfig) error {
+	// Create app context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	rt, err := newServerRuntime(cfg.ServerConfig)
+	if err != nil {
+		return err
+	}
+	defer rt.close()
+
+	clients, err := ghclient.New(rt.clientCfg)
+	if err != nil {
+		return err
+	}
+	health := newHealthChecker(clients.REST)
+	health.anonymous = cfg.Anonymous
+	health.draining = rt.drain.isDraining
+
+	var mcpHandler http.Handler = server.NewStreamableHTTPServer(rt.server)
+	if cfg.EnableCommandLogging {
+		mcpHandler = withCommandLogging(mcpHandler, rt.logger)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", withCompression(mcpHandler))
+	mux.HandleFunc("GET /healthz", health.handleHealthz)
+	mux.HandleFunc("GET /readyz", health.handleReadyz)
+
+	httpServer := &http.Server{
+		Addr:              cfg.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- httpServer.ListenAndServe()
+	}()
+
+	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on http://%s/mcp\n", cfg.Address)
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		rt.logger.Infof("shutting down server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout(cfg.ShutdownTimeout))
+		defer cancel()
+		// Refuse new tool calls and fail readiness first, then stop accepting connections once running calls have
+		// responded
+		if err := rt.drain.drain(shutdownCtx); err != nil {
+			rt.logger.Warnf("%v", err)
+		}
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("error shutting down server: %w", err)
+		}
+	case err := <-errC:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("error running server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// withCommandLogging logs the MCP messages received and sent over HTTP, as the stdio server does with its streams.
+func withCommandLogging(next http.Handler, logger *logrus.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			_ = r.Body.Close()
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			if len(body) > 0 {
+				logger.Infof("[http]: received %d bytes: %s", len(body), string(body))
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		next.ServeHTTP(&loggingWriter{ResponseWriter: w, logger: logger}, r)
+	})
+}
+
+// loggingWriter logs the responses written to it, keeping event streams flushed as they are written.
+type loggingWriter struct {
+	http.ResponseWriter
+	logger *logrus.Logger
+}
+
+func (w *loggingWriter) Write(p []byte) (int, error) {
+	w.logger.Infof("[http]: sending %d bytes: %s", len(p), string(p))
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *loggingWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
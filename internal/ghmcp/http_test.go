@@ -0,0 +1,37 @@
+package ghmcp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCommandLogging(t *testing.T) {
+	var logs bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+
+	handler := withCommandLogging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The handler still reads the whole request
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"jsonrpc":"2.0","id":1,"method":"ping"}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	}), logger)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`)))
+
+	assert.Equal(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, rec.Body.String())
+	assert.Contains(t, logs.String(), `[http]: received 40 bytes: {\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"ping\"}`)
+	assert.Contains(t, logs.String(), `[http]: sending 36 bytes: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":{}}`)
+}
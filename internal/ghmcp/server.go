@@ -6,14 +6,16 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"net/url"
 	"os"
 	"os/signal"
-	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/github/github-mcp-server/internal/ghclient"
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/scheduler"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v69/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -22,6 +24,19 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// DefaultStatsLogInterval is how often a summary of the usage of the tools is logged by default.
+const DefaultStatsLogInterval = time.Hour
+
+const (
+	// anonymousCacheTTL is how long responses are served from the cache without revalidation in anonymous mode.
+	anonymousCacheTTL = 5 * time.Minute
+	// anonymousCacheSize is how many responses are cached in anonymous mode.
+	anonymousCacheSize = 1000
+	// anonymousMaxRequestsPerMinute is the request rate of each session in anonymous mode unless configured, so that
+	// a single session doesn't use up the 60 requests per hour GitHub allows without a token at once.
+	anonymousMaxRequestsPerMinute = 10
+)
+
 type MCPServerConfig struct {
 	// Version of the server
 	Version string
@@ -32,6 +47,20 @@ type MCPServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// Anonymous serves the read tools of public data without a token, caching responses and limiting the request
+	// rate of sessions. It cannot be combined with a token or App credentials.
+	Anonymous bool
+
+	// UserAgentSuffix is appended to the user agent of API requests, to attribute them in audit logs
+	UserAgentSuffix string
+
+	// Transport sends the API requests, defaults to http.DefaultTransport
+	Transport http.RoundTripper
+
+	// AppID and AppPrivateKey, PEM encoded, are the credentials of a GitHub App, enabling the apps toolset
+	AppID         int64
+	AppPrivateKey []byte
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -45,53 +74,174 @@ type MCPServerConfig struct {
 
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
+
+	// Budget caps the write calls and API request rate of each session
+	Budget github.BudgetConfig
+
+	// IdempotencyTTL is how long write results are remembered by idempotency key, 0 means the default
+	IdempotencyTTL time.Duration
+
+	// SHACacheSize is how many results of content, tree and blame tools are cached by commit SHA, 0 means the
+	// default and a negative size disables the cache
+	SHACacheSize int
+
+	// MaxResultBytes is the size above which tool results are split into pages read with fetch_more, 0 means the
+	// default and a negative size disables splitting
+	MaxResultBytes int
+
+	// ArgumentDefaults holds argument values used for each tool, by tool name, when the caller leaves them out
+	ArgumentDefaults map[string]map[string]any
+
+	// WebhookSecrets are the secrets of webhooks, by name, that the webhooks toolset verifies deliveries with
+	WebhookSecrets map[string]string
+
+	// ReviewChecklists are the checklists get_review_checklist maps the changed paths of pull requests to
+	ReviewChecklists []github.ReviewChecklist
+
+	// ExportDir is the directory export_issues can write exports to, exports are only returned when it is empty
+	ExportDir string
+
+	// SessionLogDir is the directory the tool calls of each session are logged to, as JSONL, when set
+	SessionLogDir string
+
+	// Logger receives the logs of tool calls, defaults to the standard logrus logger
+	Logger *logrus.Logger
+
+	// ServerOptions are additional options applied to the underlying MCP server
+	ServerOptions []server.ServerOption
 }
 
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
-	apiHost, err := parseAPIHost(cfg.Host)
+	ghServer, _, err := newMCPServer(cfg)
+	return ghServer, err
+}
+
+// newMCPServer creates the server along with its tools, so that they can be rebuilt when the configuration changes.
+func newMCPServer(cfg MCPServerConfig) (*server.MCPServer, *serverTools, error) {
+	if cfg.Anonymous && (cfg.Token != "" || cfg.AppID != 0) {
+		return nil, nil, fmt.Errorf("anonymous mode cannot be combined with a token or GitHub App credentials")
+	}
+
+	// API requests are counted against the budget of the session that made them.
+	// They are always counted, as the limits can be enabled by reloading the configuration.
+	// In anonymous mode, responses are cached in front of the budget, so that cached responses aren't counted.
+	// The request IDs of their responses are logged with the tool call that made them.
+	budget := github.NewSessionBudget(cfg.Budget)
+	transport := cfg.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	transport = budget.Transport(transport)
+	if cfg.Anonymous {
+		transport = ghclient.NewCachingTransport(transport, anonymousCacheTTL, anonymousCacheSize)
+	}
+
+	clients, err := ghclient.New(ghclient.Config{
+		Host:            cfg.Host,
+		Token:           cfg.Token,
+		Version:         cfg.Version,
+		UserAgentSuffix: cfg.UserAgentSuffix,
+		Transport:       github.RequestIDTransport(transport),
+		AppID:           cfg.AppID,
+		AppPrivateKey:   cfg.AppPrivateKey,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse API host: %w", err)
-	}
-
-	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
-	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
-	restClient.BaseURL = apiHost.baseRESTURL
-	restClient.UploadURL = apiHost.uploadURL
-
-	// Construct our GraphQL client
-	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
-	// did the necessary API host parsing so that github.com will return the correct URL anyway.
-	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
-		},
-	} // We're going to wrap the Transport later in beforeInit
-	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+		return nil, nil, err
+	}
 
 	// When a client send an initialize request, update the user agent to include the client info.
 	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
-		userAgent := fmt.Sprintf(
-			"github-mcp-server/%s (%s/%s)",
-			cfg.Version,
-			message.Params.ClientInfo.Name,
-			message.Params.ClientInfo.Version,
-		)
-
-		restClient.UserAgent = userAgent
-
-		gqlHTTPClient.Transport = &userAgentTransport{
-			transport: gqlHTTPClient.Transport,
-			agent:     userAgent,
+		clients.SetUserAgent(ghclient.UserAgent(cfg.Version, message.Params.ClientInfo.Name, message.Params.ClientInfo.Version))
+	}
+
+	var sessionLog *github.SessionLog
+	if cfg.SessionLogDir != "" {
+		if sessionLog, err = github.NewSessionLog(cfg.SessionLogDir); err != nil {
+			return nil, nil, err
 		}
 	}
 
 	hooks := &server.Hooks{
 		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
+		OnUnregisterSession: []server.OnUnregisterSessionHookFunc{
+			func(_ context.Context, session server.ClientSession) {
+				budget.Forget(session.SessionID())
+				if sessionLog != nil {
+					sessionLog.Forget(session.SessionID())
+				}
+			},
+		},
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = logrus.StandardLogger()
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	ghServer := github.NewServer(cfg.Version, append([]server.ServerOption{server.WithHooks(hooks)}, cfg.ServerOptions...)...)
+
+	getClient := func(_ context.Context) (*gogithub.Client, error) {
+		return clients.REST, nil // closing over client
+	}
+
+	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
+		return clients.GraphQL, nil // closing over client
+	}
+
+	var getAppClient github.GetClientFn
+	if clients.App != nil {
+		getAppClient = func(_ context.Context) (*gogithub.Client, error) {
+			return clients.App, nil
+		}
+	}
+
+	tools := &serverTools{
+		server:        ghServer,
+		getClient:     getClient,
+		getGQLClient:  getGQLClient,
+		getAppClient:  getAppClient,
+		undoLog:       github.NewUndoLog(getClient),
+		budget:        budget,
+		idempotency:   github.NewIdempotencyStore(github.DefaultIdempotencyTTL),
+		shaCache:      github.NewSHACache(github.DefaultSHACacheSize),
+		stats:         github.NewToolStats(),
+		continuations: github.NewContinuationStore(github.DefaultMaxResultBytes),
+		sessionLog:    sessionLog,
+		logger:        logger,
+	}
+	if err := tools.register(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	github.RegisterResources(ghServer, getClient, cfg.Translator)
+
+	return ghServer, tools, nil
+}
+
+// serverTools builds the tools of a server from its configuration, and can build them again when the
+// configuration is reloaded. State shared across reloads, such as the undo log and session budgets, is kept.
+type serverTools struct {
+	server        *server.MCPServer
+	getClient     github.GetClientFn
+	getGQLClient  github.GetGQLClientFn
+	getAppClient  github.GetClientFn
+	undoLog       *github.UndoLog
+	budget        *github.SessionBudget
+	idempotency   *github.IdempotencyStore
+	shaCache      *github.SHACache
+	stats         *github.ToolStats
+	continuations *github.ContinuationStore
+	sessionLog    *github.SessionLog
+	logger        *logrus.Logger
+
+	mu sync.Mutex
+}
+
+// register replaces the tools of the server with those configured by cfg. Connected clients are notified that the
+// tool list changed.
+func (st *serverTools) register(cfg MCPServerConfig) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -104,42 +254,129 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
-		return restClient, nil // closing over client
+	if cfg.Anonymous {
+		cfg.ReadOnly = true
+		if cfg.Budget.MaxRequestsPerMinute == 0 {
+			cfg.Budget.MaxRequestsPerMinute = anonymousMaxRequestsPerMinute
+		}
 	}
 
-	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
-		return gqlClient, nil // closing over client
+	registry := github.NewRegistry(github.RegistryConfig{
+		GetClient:        st.getClient,
+		GetGQLClient:     st.getGQLClient,
+		GetAppClient:     st.getAppClient,
+		Translator:       cfg.Translator,
+		ReadOnly:         cfg.ReadOnly,
+		WebhookSecrets:   cfg.WebhookSecrets,
+		ReviewChecklists: cfg.ReviewChecklists,
+		ExportDir:        cfg.ExportDir,
+		Anonymous:        cfg.Anonymous,
+	})
+	if err := registry.EnableToolsets(enabledToolsets); err != nil {
+		return fmt.Errorf("failed to initialize toolsets: %w", err)
 	}
 
-	// Create default toolsets
-	toolsets, err := github.InitToolsets(
-		enabledToolsets,
-		cfg.ReadOnly,
-		getClient,
-		getGQLClient,
-		cfg.Translator,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize toolsets: %w", err)
+	st.budget.SetConfig(cfg.Budget)
+	idempotencyTTL := cfg.IdempotencyTTL
+	if idempotencyTTL <= 0 {
+		idempotencyTTL = github.DefaultIdempotencyTTL
+	}
+	st.idempotency.SetTTL(idempotencyTTL)
+	shaCacheSize := cfg.SHACacheSize
+	if shaCacheSize == 0 {
+		shaCacheSize = github.DefaultSHACacheSize
+	}
+	st.shaCache.SetSize(shaCacheSize)
+	maxResultBytes := cfg.MaxResultBytes
+	if maxResultBytes == 0 {
+		maxResultBytes = github.DefaultMaxResultBytes
+	}
+	st.continuations.SetMaxBytes(maxResultBytes)
+
+	// Every tool call goes through the same chain, outermost first. Arguments are validated before any policy
+	// counts the call, and undo recording sits inside idempotency so that deduplicated retries are recorded once.
+	// Large results are split last, once rendered in the requested output format, and the session log records the
+	// results as the client receives them.
+	var limitRate, limitWrites github.ToolMiddleware = noMiddleware, noMiddleware
+	if cfg.Budget.Enabled() {
+		limitRate, limitWrites = st.budget.LimitRate, st.budget.LimitWrites
+	}
+	recordSession := noMiddleware
+	if st.sessionLog != nil {
+		recordSession = st.sessionLog.Record
+	}
+	registry.WrapTools(github.Chain(
+		github.WithRecovery(st.logger),
+		github.WithLogging(st.logger),
+		st.stats.Record,
+		recordSession,
+		limitRate,
+		github.WithContinuation(st.continuations),
+		github.WithArgumentDefaults(cfg.ArgumentDefaults),
+		github.WithOutputFormat(),
+		github.WithProjection(),
+		github.ValidateArguments,
+		github.WithSHACache(st.shaCache, st.getClient),
+		github.WriteOnly(github.WithIdempotency(st.idempotency)),
+		github.WriteOnly(limitWrites),
+		github.WriteOnly(st.undoLog.Record),
+	))
+
+	// The undo tool is not itself recorded or deduplicated, but its compensating calls are writes
+	undo := github.InitUndoToolset(st.undoLog, cfg.Translator)
+	undo.WrapTools(github.Chain(
+		github.WithRecovery(st.logger),
+		github.WithLogging(st.logger),
+		st.stats.Record,
+		recordSession,
+		limitRate,
+		github.WriteOnly(limitWrites),
+	))
+
+	toolsetsByTool := registry.ToolsetsByTool()
+	for _, tool := range undo.GetAvailableTools() {
+		toolsetsByTool[tool.Tool.Name] = undo.Name
 	}
+	st.stats.SetToolsets(toolsetsByTool)
 
-	context := github.InitContextToolset(getClient, cfg.Translator)
-	github.RegisterResources(ghServer, getClient, cfg.Translator)
+	tools := registry.Tools()
+	if !cfg.ReadOnly {
+		tools = append(tools, undo.GetActiveTools()...)
+	}
 
-	// Register the tools with the server
-	toolsets.RegisterTools(ghServer)
-	context.RegisterTools(ghServer)
+	stats := github.InitStatsToolset(st.stats, st.shaCache, cfg.Translator)
+	stats.WrapTools(github.Chain(github.WithRecovery(st.logger), github.WithLogging(st.logger)))
+	tools = append(tools, stats.GetActiveTools()...)
+
+	if maxResultBytes > 0 {
+		continuation := github.InitContinuationToolset(st.continuations, cfg.Translator)
+		continuation.WrapTools(github.Chain(github.WithRecovery(st.logger), github.WithLogging(st.logger)))
+		tools = append(tools, continuation.GetActiveTools()...)
+	}
+
+	if st.sessionLog != nil {
+		session := github.InitSessionToolset(st.sessionLog, cfg.Translator)
+		session.WrapTools(github.Chain(github.WithRecovery(st.logger), github.WithLogging(st.logger)))
+		tools = append(tools, session.GetActiveTools()...)
+	}
 
 	if cfg.DynamicToolsets {
-		dynamic := github.InitDynamicToolset(ghServer, toolsets, cfg.Translator)
-		dynamic.RegisterTools(ghServer)
+		dynamic := github.InitDynamicToolset(st.server, registry.Toolsets(), cfg.Translator)
+		dynamic.WrapTools(github.Chain(github.WithRecovery(st.logger), github.WithLogging(st.logger)))
+		tools = append(tools, dynamic.GetActiveTools()...)
 	}
 
-	return ghServer, nil
+	// Replace the tools, which notifies connected clients with tools/list_changed
+	st.server.SetTools(tools...)
+	return nil
+}
+
+func noMiddleware(st server.ServerTool) server.ServerTool {
+	return st
 }
 
-type StdioServerConfig struct {
+// ServerConfig configures the server, whatever transport it is served over.
+type ServerConfig struct {
 	// Version of the server
 	Version string
 
@@ -149,6 +386,20 @@ type StdioServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// Anonymous serves the read tools of public data without a token
+	Anonymous bool
+
+	// UserAgentSuffix is appended to the user agent of API requests, to attribute them in audit logs
+	UserAgentSuffix string
+
+	// Network configures the proxy and TLS settings of the connections to GitHub
+	Network ghclient.NetworkConfig
+
+	// AppID and AppPrivateKeyPath, the path of its PEM encoded private key, are the credentials of a GitHub App,
+	// enabling the apps toolset
+	AppID             int64
+	AppPrivateKeyPath string
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -164,65 +415,190 @@ type StdioServerConfig struct {
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
 
-	// EnableCommandLogging indicates if we should log commands
+	// EnableCommandLogging indicates if we should log the messages exchanged with clients
 	EnableCommandLogging bool
 
 	// Path to the log file if not stderr
 	LogFilePath string
+
+	// MaxWriteCalls caps the number of write tool calls per session, 0 means unlimited
+	MaxWriteCalls int
+
+	// MaxRequestsPerMinute caps the GitHub API requests per session per minute, 0 means unlimited
+	MaxRequestsPerMinute int
+
+	// MaxResultBytes is the size above which tool results are split into pages read with fetch_more, 0 means
+	// the default and a negative size disables splitting
+	MaxResultBytes int
+
+	// ExportDir is the directory export_issues can write exports to, exports are only returned when it is empty
+	ExportDir string
+
+	// SessionLogDir is the directory the tool calls of each session are logged to, as JSONL, when set
+	SessionLogDir string
+
+	// StatsLogInterval is how often a summary of the usage of the tools is logged, 0 disables the summaries
+	StatsLogInterval time.Duration
+
+	// Path to a scheduler configuration file, enabling scheduled tool runs when set
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#scheduled-reports
+	ScheduleConfigPath string
+
+	// ShutdownTimeout bounds how long shutdown waits for in-flight tool calls, 0 means DefaultShutdownTimeout
+	ShutdownTimeout time.Duration
+
+	// Path to a configuration file that is reloaded on SIGHUP and when it changes
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#configuration-file
+	ConfigPath string
 }
 
-// RunStdioServer is not concurrent safe.
-func RunStdioServer(cfg StdioServerConfig) error {
-	// Create app context
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
+// serverRuntime is the MCP server built from a ServerConfig, with the background work running alongside it, ready to
+// be served over a transport.
+type serverRuntime struct {
+	// ctx is the context of the server, which keeps running after a signal so that in-flight calls can drain
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	logger    *logrus.Logger
+	transport *http.Transport
+	clientCfg ghclient.Config
+	server    *server.MCPServer
+	drain     *drainer
+}
 
+// newServerRuntime creates the MCP server for cfg and starts the config watcher, usage summaries and scheduler it enables.
+func newServerRuntime(cfg ServerConfig) (*serverRuntime, error) {
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
+	logrusLogger, err := newLogger(cfg.LogFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	appPrivateKey, err := loadAppPrivateKey(cfg.AppID, cfg.AppPrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := ghclient.NewTransport(cfg.Network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the connection to GitHub: %w", err)
+	}
+
+	drain := newDrainer()
+	mcpCfg := MCPServerConfig{
 		Version:         cfg.Version,
 		Host:            cfg.Host,
 		Token:           cfg.Token,
+		Anonymous:       cfg.Anonymous,
+		UserAgentSuffix: cfg.UserAgentSuffix,
+		Transport:       transport,
+		AppID:           cfg.AppID,
+		AppPrivateKey:   appPrivateKey,
 		EnabledToolsets: cfg.EnabledToolsets,
 		DynamicToolsets: cfg.DynamicToolsets,
 		ReadOnly:        cfg.ReadOnly,
 		Translator:      t,
-	})
+		Budget: github.BudgetConfig{
+			MaxWriteCalls:        cfg.MaxWriteCalls,
+			MaxRequestsPerMinute: cfg.MaxRequestsPerMinute,
+		},
+		MaxResultBytes: cfg.MaxResultBytes,
+		ExportDir:      cfg.ExportDir,
+		SessionLogDir:  cfg.SessionLogDir,
+		Logger:         logrusLogger,
+		ServerOptions:  []server.ServerOption{server.WithToolHandlerMiddleware(drain.middleware)},
+	}
+	serverCfg := mcpCfg
+	if cfg.ConfigPath != "" {
+		fileCfg, err := LoadFileConfig(cfg.ConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		serverCfg = fileCfg.apply(mcpCfg)
+	}
+
+	ghServer, tools, err := newMCPServer(serverCfg)
 	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
+		return nil, fmt.Errorf("failed to create MCP server: %w", err)
 	}
 
-	stdioServer := server.NewStdioServer(ghServer)
+	rt := &serverRuntime{
+		logger:    logrusLogger,
+		transport: transport,
+		clientCfg: ghclient.Config{
+			Host:            cfg.Host,
+			Token:           cfg.Token,
+			Version:         cfg.Version,
+			UserAgentSuffix: cfg.UserAgentSuffix,
+			Transport:       transport,
+		},
+		server: ghServer,
+		drain:  drain,
+	}
 
-	logrusLogger := logrus.New()
-	if cfg.LogFilePath != "" {
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	var sched *scheduler.Scheduler
+	if cfg.ScheduleConfigPath != "" {
+		sched, err = newScheduler(cfg.ScheduleConfigPath, rt.clientCfg, ghServer, logrusLogger)
 		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
+			return nil, err
 		}
+	}
 
-		logrusLogger.SetLevel(logrus.DebugLevel)
-		logrusLogger.SetOutput(file)
+	rt.ctx, rt.cancel = context.WithCancel(context.Background())
+	if cfg.ConfigPath != "" {
+		watchConfig(rt.ctx, cfg.ConfigPath, mcpCfg, tools, logrusLogger)
+	}
+	if cfg.StatsLogInterval > 0 {
+		go tools.stats.LogSummaries(rt.ctx, logrusLogger, cfg.StatsLogInterval)
+	}
+	if sched != nil {
+		go sched.Run(rt.ctx)
 	}
-	stdLogger := log.New(logrusLogger.Writer(), "stdioserver", 0)
-	stdioServer.SetErrorLogger(stdLogger)
 
 	if cfg.ExportTranslations {
 		// Once server is initialized, all translations are loaded
 		dumpTranslations()
 	}
 
+	return rt, nil
+}
+
+// close stops the background work and releases the connections and log file of the server.
+func (rt *serverRuntime) close() {
+	rt.cancel()
+	closeIdleConnections(rt.transport)
+	closeLogger(rt.logger)
+}
+
+// RunStdioServer is not concurrent safe.
+func RunStdioServer(cfg ServerConfig) error {
+	// Create app context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	rt, err := newServerRuntime(cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.close()
+
+	stdioServer := server.NewStdioServer(rt.server)
+
+	stdLogger := log.New(rt.logger.Writer(), "stdioserver", 0)
+	stdioServer.SetErrorLogger(stdLogger)
+
 	// Start listening for messages
 	errC := make(chan error, 1)
 	go func() {
 		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
 
 		if cfg.EnableCommandLogging {
-			loggedIO := mcplog.NewIOLogger(in, out, logrusLogger)
+			loggedIO := mcplog.NewIOLogger(in, out, rt.logger)
 			in, out = loggedIO, loggedIO
 		}
 
-		errC <- stdioServer.Listen(ctx, in, out)
+		errC <- stdioServer.Listen(rt.ctx, in, out)
 	}()
 
 	// Output github-mcp-server string
@@ -231,7 +607,12 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	// Wait for shutdown signal
 	select {
 	case <-ctx.Done():
-		logrusLogger.Infof("shutting down server...")
+		rt.logger.Infof("shutting down server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout(cfg.ShutdownTimeout))
+		defer cancel()
+		if err := rt.drain.drain(shutdownCtx); err != nil {
+			rt.logger.Warnf("%v", err)
+		}
 	case err := <-errC:
 		if err != nil {
 			return fmt.Errorf("error running server: %w", err)
@@ -241,140 +622,51 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	return nil
 }
 
-type apiHost struct {
-	baseRESTURL *url.URL
-	graphqlURL  *url.URL
-	uploadURL   *url.URL
-}
-
-func newDotcomHost() (apiHost, error) {
-	baseRestURL, err := url.Parse("https://api.github.com/")
-	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse dotcom REST URL: %w", err)
-	}
-
-	gqlURL, err := url.Parse("https://api.github.com/graphql")
-	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse dotcom GraphQL URL: %w", err)
-	}
+// newLogger creates the server logger, writing to the given file at debug level, or to stderr if path is empty.
+func newLogger(path string) (*logrus.Logger, error) {
+	logrusLogger := logrus.New()
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
 
-	uploadURL, err := url.Parse("https://uploads.github.com")
-	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse dotcom Upload URL: %w", err)
+		logrusLogger.SetLevel(logrus.DebugLevel)
+		logrusLogger.SetOutput(file)
 	}
-
-	return apiHost{
-		baseRESTURL: baseRestURL,
-		graphqlURL:  gqlURL,
-		uploadURL:   uploadURL,
-	}, nil
+	return logrusLogger, nil
 }
 
-func newGHECHost(hostname string) (apiHost, error) {
-	u, err := url.Parse(hostname)
-	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHEC URL: %w", err)
+// loadAppPrivateKey reads the private key of the GitHub App, if one is configured.
+func loadAppPrivateKey(appID int64, path string) ([]byte, error) {
+	if appID == 0 && path == "" {
+		return nil, nil
 	}
-
-	// Unsecured GHEC would be an error
-	if u.Scheme == "http" {
-		return apiHost{}, fmt.Errorf("GHEC URL must be HTTPS")
+	if appID == 0 || path == "" {
+		return nil, fmt.Errorf("both the GitHub App ID and private key path must be set")
 	}
-
-	restURL, err := url.Parse(fmt.Sprintf("https://api.%s/", u.Hostname()))
-	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHEC REST URL: %w", err)
-	}
-
-	gqlURL, err := url.Parse(fmt.Sprintf("https://api.%s/graphql", u.Hostname()))
+	key, err := os.ReadFile(path)
 	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHEC GraphQL URL: %w", err)
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
 	}
-
-	uploadURL, err := url.Parse(fmt.Sprintf("https://uploads.%s", u.Hostname()))
-	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHEC Upload URL: %w", err)
-	}
-
-	return apiHost{
-		baseRESTURL: restURL,
-		graphqlURL:  gqlURL,
-		uploadURL:   uploadURL,
-	}, nil
+	return key, nil
 }
 
-func newGHESHost(hostname string) (apiHost, error) {
-	u, err := url.Parse(hostname)
-	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHES URL: %w", err)
-	}
-
-	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Hostname()))
+// newScheduler creates the scheduler for the jobs configured in path, calling tools on the given server.
+func newScheduler(path string, clientCfg ghclient.Config, ghServer *server.MCPServer, logger *logrus.Logger) (*scheduler.Scheduler, error) {
+	schedCfg, err := scheduler.LoadConfig(path)
 	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHES REST URL: %w", err)
+		return nil, err
 	}
 
-	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Hostname()))
+	clients, err := ghclient.New(clientCfg)
 	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHES GraphQL URL: %w", err)
+		return nil, err
 	}
 
-	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Hostname()))
+	sched, err := scheduler.New(schedCfg, scheduler.ServerToolCaller(ghServer), clients.REST, logger)
 	if err != nil {
-		return apiHost{}, fmt.Errorf("failed to parse GHES Upload URL: %w", err)
+		return nil, fmt.Errorf("failed to create scheduler: %w", err)
 	}
-
-	return apiHost{
-		baseRESTURL: restURL,
-		graphqlURL:  gqlURL,
-		uploadURL:   uploadURL,
-	}, nil
-}
-
-// Note that this does not handle ports yet, so development environments are out.
-func parseAPIHost(s string) (apiHost, error) {
-	if s == "" {
-		return newDotcomHost()
-	}
-
-	u, err := url.Parse(s)
-	if err != nil {
-		return apiHost{}, fmt.Errorf("could not parse host as URL: %s", s)
-	}
-
-	if u.Scheme == "" {
-		return apiHost{}, fmt.Errorf("host must have a scheme (http or https): %s", s)
-	}
-
-	if strings.HasSuffix(u.Hostname(), "github.com") {
-		return newDotcomHost()
-	}
-
-	if strings.HasSuffix(u.Hostname(), "ghe.com") {
-		return newGHECHost(s)
-	}
-
-	return newGHESHost(s)
-}
-
-type userAgentTransport struct {
-	transport http.RoundTripper
-	agent     string
-}
-
-func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req = req.Clone(req.Context())
-	req.Header.Set("User-Agent", t.agent)
-	return t.transport.RoundTrip(req)
-}
-
-type bearerAuthTransport struct {
-	transport http.RoundTripper
-	token     string
-}
-
-func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req = req.Clone(req.Context())
-	req.Header.Set("Authorization", "Bearer "+t.token)
-	return t.transport.RoundTrip(req)
+	return sched, nil
 }
@@ -0,0 +1,92 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultShutdownTimeout bounds how long shutdown waits for in-flight tool calls.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// drainer tracks in-flight tool calls so that shutdown can stop accepting new calls and wait for running ones.
+type drainer struct {
+	mu       sync.Mutex
+	draining bool
+	inflight sync.WaitGroup
+}
+
+func newDrainer() *drainer {
+	return &drainer{}
+}
+
+// middleware refuses tool calls once draining has started and tracks the ones already running.
+func (d *drainer) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		d.mu.Lock()
+		if d.draining {
+			d.mu.Unlock()
+			return mcp.NewToolResultError("the server is shutting down and not accepting new tool calls, retry shortly"), nil
+		}
+		d.inflight.Add(1)
+		d.mu.Unlock()
+		defer d.inflight.Done()
+
+		return next(ctx, request)
+	}
+}
+
+// isDraining reports whether draining has started.
+func (d *drainer) isDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// drain stops new tool calls and waits for in-flight ones to finish, or for ctx to be done.
+func (d *drainer) drain(ctx context.Context) error {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("in-flight tool calls did not finish in time: %w", ctx.Err())
+	}
+}
+
+// shutdownTimeout returns the configured timeout, or the default if none was set.
+func shutdownTimeout(configured time.Duration) time.Duration {
+	if configured <= 0 {
+		return DefaultShutdownTimeout
+	}
+	return configured
+}
+
+// closeIdleConnections closes the keep-alive connections held by the GitHub clients.
+func closeIdleConnections(transport *http.Transport) {
+	transport.CloseIdleConnections()
+}
+
+// closeLogger flushes and closes the log file, if the logger writes to one.
+func closeLogger(logger *logrus.Logger) {
+	if file, ok := logger.Out.(*os.File); ok && file != os.Stderr && file != os.Stdout {
+		_ = file.Sync()
+		_ = file.Close()
+	}
+}
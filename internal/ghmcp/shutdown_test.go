@@ -0,0 +1,78 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainerWaitsForInFlightCalls(t *testing.T) {
+	d := newDrainer()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := d.middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	resultC := make(chan *mcp.CallToolResult, 1)
+	go func() {
+		result, _ := handler(context.Background(), mcp.CallToolRequest{})
+		resultC <- result
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- d.drain(context.Background())
+	}()
+
+	// New calls are refused while draining
+	require.Eventually(t, func() bool {
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+		return err == nil && result.IsError
+	}, time.Second, 10*time.Millisecond)
+
+	select {
+	case <-drained:
+		t.Fatal("drain returned before the in-flight call finished")
+	default:
+	}
+
+	close(release)
+	require.NoError(t, <-drained)
+	result := <-resultC
+	assert.False(t, result.IsError)
+}
+
+func TestDrainerTimesOut(t *testing.T) {
+	d := newDrainer()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := d.middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+	go func() {
+		_, _ = handler(context.Background(), mcp.CallToolRequest{})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := d.drain(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	assert.Equal(t, DefaultShutdownTimeout, shutdownTimeout(0))
+	assert.Equal(t, 5*time.Second, shutdownTimeout(5*time.Second))
+}
@@ -2,6 +2,8 @@
 // because I do not want to take a dependency on the entire testify module just to use this equality check.
 //
 // There is a modification in objectsAreEqual to check that typed nils are equal, even if their types are different.
+// There is another in objectsAreEqualValues to compare pointers by the values they point to, as variables are sent
+// as plain JSON values.
 //
 // The original license, copied from https://github.com/stretchr/testify/blob/016e2e9c269209287f33ec203f340a9a723fe22c/LICENSE
 //
@@ -38,6 +40,10 @@ func objectsAreEqualValues(expected, actual any) bool {
 		return true
 	}
 
+	if v := reflect.ValueOf(expected); v.Kind() == reflect.Ptr && !v.IsNil() {
+		return objectsAreEqualValues(v.Elem().Interface(), actual)
+	}
+
 	expectedValue := reflect.ValueOf(expected)
 	actualValue := reflect.ValueOf(actual)
 	if !expectedValue.IsValid() || !actualValue.IsValid() {
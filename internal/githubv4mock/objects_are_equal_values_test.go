@@ -36,6 +36,7 @@ import (
 
 func TestObjectsAreEqualValues(t *testing.T) {
 	now := time.Now()
+	branch := "main"
 
 	cases := []struct {
 		expected interface{}
@@ -59,6 +60,8 @@ func TestObjectsAreEqualValues(t *testing.T) {
 		{complex64(1e+10 + 1e+10i), complex128(1e+10 + 1e+10i), true},
 		{(*string)(nil), nil, true},         // typed nil vs untyped nil
 		{(*string)(nil), (*int)(nil), true}, // different typed nils
+		{&branch, "main", true},             // pointer vs decoded JSON value
+		{&branch, "dev", false},
 	}
 
 	for _, c := range cases {
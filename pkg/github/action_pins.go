@@ -0,0 +1,257 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// usesPattern matches the uses: lines of workflow files referring to an action or reusable workflow at a ref, with
+// the groups: the text before the reference, an optional quote, the action, its ref and the rest of the line.
+var usesPattern = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*)(["']?)([^\s"'#@]+)@([^\s"'#]+)["']?(.*)$`)
+
+// commitSHAPattern matches a full commit SHA, the only immutable ref of an action.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// actionRef is a reference to an action at a mutable ref in a workflow file.
+type actionRef struct {
+	Path   string `json:"path"`
+	Line   int    `json:"line"`
+	Action string `json:"action"`
+	Ref    string `json:"ref"`
+	SHA    string `json:"sha,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// actionPinsAudit is the result of audit_action_pins.
+type actionPinsAudit struct {
+	Base          string                      `json:"base"`
+	BaseSHA       string                      `json:"base_sha"`
+	ScannedFiles  int                         `json:"scanned_files"`
+	PinnedRefs    int                         `json:"pinned_refs"`
+	UnpinnedRefs  []actionRef                 `json:"unpinned_refs"`
+	ResolveFailed int                         `json:"resolve_failed,omitempty"`
+	Branch        string                      `json:"branch,omitempty"`
+	PullRequest   *textReplacementPullRequest `json:"pull_request,omitempty"`
+}
+
+// actionRepository returns the repository holding an action or reusable workflow referred to as
+// owner/repo[/path], or false for local and Docker actions.
+func actionRepository(action string) (owner, repo string, ok bool) {
+	if strings.HasPrefix(action, "./") || strings.HasPrefix(action, "docker://") {
+		return "", "", false
+	}
+	parts := strings.SplitN(action, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// findUnpinnedActions lists the actions a workflow file refers to at a mutable ref, and counts the ones pinned to a
+// commit SHA.
+func findUnpinnedActions(filePath, content string) (unpinned []actionRef, pinned int) {
+	for i, line := range strings.Split(content, "\n") {
+		m := usesPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		action, ref := m[3], m[4]
+		if _, _, ok := actionRepository(action); !ok {
+			continue
+		}
+		if commitSHAPattern.MatchString(ref) {
+			pinned++
+			continue
+		}
+		unpinned = append(unpinned, actionRef{Path: filePath, Line: i + 1, Action: action, Ref: ref})
+	}
+	return unpinned, pinned
+}
+
+// pinActions rewrites the lines of a workflow file referring to the given actions, pinning them to their SHA and
+// keeping the ref they were at in a comment.
+func pinActions(content string, refs []actionRef) string {
+	lines := strings.Split(content, "\n")
+	for _, ref := range refs {
+		if ref.SHA == "" {
+			continue
+		}
+		m := usesPattern.FindStringSubmatch(lines[ref.Line-1])
+		lines[ref.Line-1] = fmt.Sprintf("%s%s%s@%s%s # %s", m[1], m[2], ref.Action, ref.SHA, m[2], ref.Ref)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AuditActionPins creates a tool to find the actions used at mutable refs in the workflows of a repository, and
+// optionally open a pull request pinning them to commit SHAs.
+func AuditActionPins(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("audit_action_pins",
+			mcp.WithDescription(t("TOOL_AUDIT_ACTION_PINS_DESCRIPTION", "Find the actions and reusable workflows the workflow files of a repository use at a mutable tag or branch instead of a commit SHA, and resolve each ref to the commit SHA it currently points to. With open_pr, also opens a pull request pinning them to these SHAs, keeping the original ref in a comment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_AUDIT_ACTION_PINS_USER_TITLE", "Audit action pins"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to audit, defaults to the default branch"),
+			),
+			mcp.WithBoolean("open_pr",
+				mcp.Description("Open a pull request pinning the actions to the resolved SHAs"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to create for the pull request, defaults to pin-actions"),
+			),
+			mcp.WithString("title",
+				mcp.Description("Title of the pull request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseAuditActionPinsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Branch == "" {
+				params.Branch = "pin-actions"
+			}
+			if params.Title == "" {
+				params.Title = "Pin actions to commit SHAs"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if params.Base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				params.Base = repository.GetDefaultBranch()
+			}
+			baseRef, resp, err := client.Git.GetRef(ctx, params.Owner, params.Repo, "refs/heads/"+params.Base)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+			}
+			_ = resp.Body.Close()
+			baseSHA := baseRef.GetObject().GetSHA()
+
+			workflows, err := getWorkflowFiles(ctx, client, params.Owner, params.Repo, baseSHA)
+			if err != nil {
+				return nil, err
+			}
+
+			audit := actionPinsAudit{
+				Base:         params.Base,
+				BaseSHA:      baseSHA,
+				ScannedFiles: len(workflows),
+				UnpinnedRefs: []actionRef{},
+			}
+			refsByPath := make(map[string][]actionRef)
+			// The same action is usually used at the same ref in several places, resolve it once
+			resolved := make(map[string]actionRef)
+			for _, wf := range workflows {
+				unpinned, pinned := findUnpinnedActions(wf.Path, wf.Content)
+				audit.PinnedRefs += pinned
+				for _, ref := range unpinned {
+					key := ref.Action + "@" + ref.Ref
+					r, ok := resolved[key]
+					if !ok {
+						owner, repo, _ := actionRepository(ref.Action)
+						sha, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, ref.Ref, "")
+						if resp != nil {
+							_ = resp.Body.Close()
+						}
+						if err != nil {
+							r.Error = fmt.Sprintf("failed to resolve %s: %s", key, err)
+						} else {
+							r.SHA = sha
+						}
+						resolved[key] = r
+					}
+					ref.SHA, ref.Error = r.SHA, r.Error
+					if ref.Error != "" {
+						audit.ResolveFailed++
+					}
+					audit.UnpinnedRefs = append(audit.UnpinnedRefs, ref)
+					refsByPath[wf.Path] = append(refsByPath[wf.Path], ref)
+				}
+			}
+
+			if !params.OpenPr || len(audit.UnpinnedRefs) == audit.ResolveFailed {
+				r, err := json.Marshal(audit)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			var entries []*github.TreeEntry
+			var body strings.Builder
+			body.WriteString("Pins the following actions to the commit their ref currently points to, so that the workflows keep running the reviewed code even if the ref is moved:\n\n")
+			for _, wf := range workflows {
+				refs := refsByPath[wf.Path]
+				if len(refs) == 0 {
+					continue
+				}
+				entries = append(entries, &github.TreeEntry{
+					Path:    github.Ptr(wf.Path),
+					Mode:    github.Ptr("100644"),
+					Type:    github.Ptr("blob"),
+					Content: github.Ptr(pinActions(wf.Content, refs)),
+				})
+				for _, ref := range refs {
+					if ref.SHA != "" {
+						fmt.Fprintf(&body, "- `%s:%d` `%s@%s` to `%s`\n", ref.Path, ref.Line, ref.Action, ref.Ref, ref.SHA)
+					}
+				}
+			}
+
+			baseCommit, resp, err := client.Git.GetCommit(ctx, params.Owner, params.Repo, baseSHA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get base commit: %w", err)
+			}
+			_ = resp.Body.Close()
+			if _, err := commitToNewBranch(ctx, client, params.Owner, params.Repo, baseCommit, entries, params.Title, params.Branch); err != nil {
+				return nil, err
+			}
+			audit.Branch = params.Branch
+
+			pr, resp, err := client.PullRequests.Create(ctx, params.Owner, params.Repo, &github.NewPullRequest{
+				Title: github.Ptr(params.Title),
+				Head:  github.Ptr(params.Branch),
+				Base:  github.Ptr(params.Base),
+				Body:  github.Ptr(body.String()),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			audit.PullRequest = &textReplacementPullRequest{
+				Number: pr.GetNumber(),
+				URL:    pr.GetHTMLURL(),
+			}
+
+			r, err := json.Marshal(audit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	checkoutSHA = "11bd71901bbe5b1630ceea73d27597364c9af683"
+	setupGoSHA  = "0aaccfd150d50ccaeb58ebd88d36e91967a5f35b"
+)
+
+const unpinnedWorkflow = `name: CI
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: "actions/setup-go@v5" # setup
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.20
+      - uses: octo-org/cache@` + checkoutSHA + ` # v1
+  deploy:
+    uses: octo-org/workflows/.github/workflows/deploy.yml@main
+`
+
+func Test_FindUnpinnedActions(t *testing.T) {
+	unpinned, pinned := findUnpinnedActions(".github/workflows/ci.yml", unpinnedWorkflow)
+	assert.Equal(t, 1, pinned)
+	assert.Equal(t, []actionRef{
+		{Path: ".github/workflows/ci.yml", Line: 7, Action: "actions/checkout", Ref: "v4"},
+		{Path: ".github/workflows/ci.yml", Line: 8, Action: "actions/setup-go", Ref: "v5"},
+		{Path: ".github/workflows/ci.yml", Line: 13, Action: "octo-org/workflows/.github/workflows/deploy.yml", Ref: "main"},
+	}, unpinned)
+}
+
+func Test_PinActions(t *testing.T) {
+	content := "steps:\n  - uses: actions/checkout@v4\n  - uses: \"actions/setup-go@v5\" # setup\n  - uses: octo-org/missing@v1\n"
+	pinnedContent := pinActions(content, []actionRef{
+		{Line: 2, Action: "actions/checkout", Ref: "v4", SHA: checkoutSHA},
+		{Line: 3, Action: "actions/setup-go", Ref: "v5", SHA: setupGoSHA},
+		{Line: 4, Action: "octo-org/missing", Ref: "v1", Error: "failed to resolve"},
+	})
+	assert.Equal(t, "steps:\n"+
+		"  - uses: actions/checkout@"+checkoutSHA+" # v4\n"+
+		"  - uses: \"actions/setup-go@"+setupGoSHA+"\" # v5\n"+
+		"  - uses: octo-org/missing@v1\n", pinnedContent)
+}
+
+func Test_AuditActionPins(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AuditActionPins(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "audit_action_pins", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "open_pr")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	workflow := "jobs:\n  build:\n    steps:\n      - uses: actions/checkout@v4\n      - uses: actions/setup-go@v5\n      - uses: actions/checkout@v4\n"
+	contents := map[string]any{
+		".github/workflows": []*github.RepositoryContent{
+			{Type: github.Ptr("file"), Name: github.Ptr("ci.yml"), Path: github.Ptr(".github/workflows/ci.yml")},
+		},
+		".github/workflows/ci.yml": fileContent(".github/workflows/ci.yml", workflow),
+	}
+	readOptions := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+					mockResponse(t, http.StatusOK, &github.Reference{Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				expectQueryParams(t, map[string]string{"ref": "base-sha"}).andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						mockResponse(t, http.StatusOK, contents[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/")])(w, r)
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepoByRef,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/repos/actions/checkout/commits/v4":
+						mockResponse(t, http.StatusOK, checkoutSHA)(w, r)
+					case "/repos/actions/setup-go/commits/v5":
+						mockResponse(t, http.StatusOK, setupGoSHA)(w, r)
+					default:
+						t.Errorf("unexpected request %s", r.URL.Path)
+					}
+				}),
+			),
+		}
+	}
+
+	t.Run("reports unpinned actions", func(t *testing.T) {
+		_, handler := AuditActionPins(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(readOptions()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var audit actionPinsAudit
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &audit))
+		assert.Equal(t, actionPinsAudit{
+			Base:         "main",
+			BaseSHA:      "base-sha",
+			ScannedFiles: 1,
+			UnpinnedRefs: []actionRef{
+				{Path: ".github/workflows/ci.yml", Line: 4, Action: "actions/checkout", Ref: "v4", SHA: checkoutSHA},
+				{Path: ".github/workflows/ci.yml", Line: 5, Action: "actions/setup-go", Ref: "v5", SHA: setupGoSHA},
+				{Path: ".github/workflows/ci.yml", Line: 6, Action: "actions/checkout", Ref: "v4", SHA: checkoutSHA},
+			},
+		}, audit)
+	})
+
+	t.Run("opens a pull request pinning them", func(t *testing.T) {
+		options := append(readOptions(),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, &github.Commit{
+				SHA:  github.Ptr("base-sha"),
+				Tree: &github.Tree{SHA: github.Ptr("base-tree")},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"base_tree": "base-tree",
+					"tree": []any{
+						map[string]any{
+							"path":    ".github/workflows/ci.yml",
+							"mode":    "100644",
+							"type":    "blob",
+							"content": "jobs:\n  build:\n    steps:\n      - uses: actions/checkout@" + checkoutSHA + " # v4\n      - uses: actions/setup-go@" + setupGoSHA + " # v5\n      - uses: actions/checkout@" + checkoutSHA + " # v4\n",
+						},
+					},
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("new-tree")})),
+			),
+			mock.WithRequestMatch(mock.PostReposGitCommitsByOwnerByRepo, &github.Commit{SHA: github.Ptr("new-commit")}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"ref": "refs/heads/pin-actions",
+					"sha": "new-commit",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/heads/pin-actions")})),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposPullsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var pr github.NewPullRequest
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&pr))
+					assert.Equal(t, "Pin actions to commit SHAs", pr.GetTitle())
+					assert.Equal(t, "pin-actions", pr.GetHead())
+					assert.Equal(t, "main", pr.GetBase())
+					assert.Contains(t, pr.GetBody(), "`.github/workflows/ci.yml:5` `actions/setup-go@v5` to `"+setupGoSHA+"`")
+					mockResponse(t, http.StatusCreated, &github.PullRequest{
+						Number:  github.Ptr(9),
+						HTMLURL: github.Ptr("https://github.com/owner/repo/pull/9"),
+					})(w, r)
+				}),
+			),
+		)
+		_, handler := AuditActionPins(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"open_pr": true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var audit actionPinsAudit
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &audit))
+		assert.Len(t, audit.UnpinnedRefs, 3)
+		assert.Equal(t, "pin-actions", audit.Branch)
+		assert.Equal(t, &textReplacementPullRequest{Number: 9, URL: "https://github.com/owner/repo/pull/9"}, audit.PullRequest)
+	})
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ciMatrixMaxRepos bounds the number of repositories a single get_ci_matrix call inspects, as each one costs
+// several API requests.
+const ciMatrixMaxRepos = 50
+
+// Overall health states reported per repository by get_ci_matrix.
+const (
+	ciHealthGreen   = "green"
+	ciHealthRed     = "red"
+	ciHealthPending = "pending"
+	ciHealthUnknown = "unknown"
+)
+
+// ciFailureConclusions are the workflow run conclusions that mark a build as red.
+var ciFailureConclusions = map[string]bool{
+	"failure":         true,
+	"timed_out":       true,
+	"startup_failure": true,
+	"action_required": true,
+}
+
+// ciWorkflowStatus is the outcome of the latest default branch run of a single workflow.
+type ciWorkflowStatus struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Status     string `json:"status,omitempty"`
+	Conclusion string `json:"conclusion,omitempty"`
+	RunURL     string `json:"run_url,omitempty"`
+	HeadSHA    string `json:"head_sha,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// ciRepoStatus is a row of the CI matrix.
+type ciRepoStatus struct {
+	Repository    string             `json:"repository"`
+	DefaultBranch string             `json:"default_branch,omitempty"`
+	Health        string             `json:"health"`
+	Failing       []string           `json:"failing,omitempty"`
+	Workflows     []ciWorkflowStatus `json:"workflows,omitempty"`
+	Error         string             `json:"error,omitempty"`
+}
+
+// ciMatrix is the result of get_ci_matrix.
+type ciMatrix struct {
+	RedRepositories []string       `json:"red_repositories"`
+	Repositories    []ciRepoStatus `json:"repositories"`
+}
+
+// splitRepoFullName splits an "owner/repo" string into its parts.
+func splitRepoFullName(fullName string) (string, string, error) {
+	owner, repo, ok := strings.Cut(strings.TrimSpace(fullName), "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("invalid repository %q, expected owner/repo", fullName)
+	}
+	return owner, repo, nil
+}
+
+// ciHealth derives the overall health of a repository from the latest run of each of its workflows.
+func ciHealth(workflows []ciWorkflowStatus) (string, []string) {
+	var failing []string
+	pending := false
+	for _, wf := range workflows {
+		switch {
+		case ciFailureConclusions[wf.Conclusion]:
+			failing = append(failing, wf.Name)
+		case wf.Status != "" && wf.Status != "completed":
+			pending = true
+		}
+	}
+	switch {
+	case len(failing) > 0:
+		return ciHealthRed, failing
+	case pending:
+		return ciHealthPending, nil
+	case len(workflows) == 0:
+		return ciHealthUnknown, nil
+	default:
+		return ciHealthGreen, nil
+	}
+}
+
+// getRepoCIStatus collects the latest default branch run of every active workflow in a repository.
+func getRepoCIStatus(ctx context.Context, client *github.Client, owner, repo string) (ciRepoStatus, error) {
+	status := ciRepoStatus{Repository: owner + "/" + repo}
+
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return status, fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+	status.DefaultBranch = repository.GetDefaultBranch()
+
+	workflows, resp, err := client.Actions.ListWorkflows(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return status, fmt.Errorf("failed to list workflows: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	// Runs are returned newest first, so the first run seen for a workflow is its latest.
+	runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+		Branch:              status.DefaultBranch,
+		ExcludePullRequests: true,
+		ListOptions:         github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return status, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	latest := make(map[int64]*github.WorkflowRun)
+	for _, run := range runs.WorkflowRuns {
+		if _, seen := latest[run.GetWorkflowID()]; !seen {
+			latest[run.GetWorkflowID()] = run
+		}
+	}
+
+	for _, wf := range workflows.Workflows {
+		if wf.GetState() != "active" {
+			continue
+		}
+		wfStatus := ciWorkflowStatus{
+			Name: wf.GetName(),
+			Path: wf.GetPath(),
+		}
+		if run, ok := latest[wf.GetID()]; ok {
+			wfStatus.Status = run.GetStatus()
+			wfStatus.Conclusion = run.GetConclusion()
+			wfStatus.RunURL = run.GetHTMLURL()
+			wfStatus.HeadSHA = run.GetHeadSHA()
+			wfStatus.UpdatedAt = run.GetUpdatedAt().Format(time.RFC3339)
+		}
+		status.Workflows = append(status.Workflows, wfStatus)
+	}
+
+	status.Health, status.Failing = ciHealth(status.Workflows)
+	return status, nil
+}
+
+// GetCIMatrix creates a tool that reports the latest default branch workflow results across several repositories.
+func GetCIMatrix(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ci_matrix",
+			mcp.WithDescription(t("TOOL_GET_CI_MATRIX_DESCRIPTION", "Report the conclusion of the latest default branch run of every workflow across a list of repositories, highlighting repositories with failing builds. Useful as a fleet health overview.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CI_MATRIX_USER_TITLE", "Get CI status matrix"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Repositories to inspect, in owner/repo form (at most %d)", ciMatrixMaxRepos)),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetCIMatrixParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(params.Repositories) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repositories"), nil
+			}
+			if len(params.Repositories) > ciMatrixMaxRepos {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be inspected at once", ciMatrixMaxRepos)), nil
+			}
+
+			type repoRef struct{ owner, repo string }
+			refs := make([]repoRef, 0, len(params.Repositories))
+			for _, fullName := range params.Repositories {
+				owner, repo, err := splitRepoFullName(fullName)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				refs = append(refs, repoRef{owner, repo})
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			matrix := ciMatrix{
+				RedRepositories: []string{},
+				Repositories:    make([]ciRepoStatus, 0, len(refs)),
+			}
+			progress := newProgressReporter(ctx, request, len(refs))
+			for _, ref := range refs {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				// A single inaccessible repository should not hide the state of the rest of the fleet.
+				status, err := getRepoCIStatus(ctx, client, ref.owner, ref.repo)
+				if err != nil {
+					status.Health = ciHealthUnknown
+					status.Error = err.Error()
+				}
+				if status.Health == ciHealthRed {
+					matrix.RedRepositories = append(matrix.RedRepositories, status.Repository)
+				}
+				matrix.Repositories = append(matrix.Repositories, status)
+				progress.step(ctx, 1, fmt.Sprintf("%s/%s: %s", ref.owner, ref.repo, status.Health))
+			}
+
+			r, err := json.Marshal(matrix)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
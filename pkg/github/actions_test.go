@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ciHealth(t *testing.T) {
+	tests := []struct {
+		name            string
+		workflows       []ciWorkflowStatus
+		expectedHealth  string
+		expectedFailing []string
+	}{
+		{
+			name:           "no workflows",
+			expectedHealth: ciHealthUnknown,
+		},
+		{
+			name: "all green",
+			workflows: []ciWorkflowStatus{
+				{Name: "CI", Status: "completed", Conclusion: "success"},
+				{Name: "Lint", Status: "completed", Conclusion: "skipped"},
+			},
+			expectedHealth: ciHealthGreen,
+		},
+		{
+			name: "failure wins over pending",
+			workflows: []ciWorkflowStatus{
+				{Name: "CI", Status: "in_progress"},
+				{Name: "Lint", Status: "completed", Conclusion: "failure"},
+				{Name: "Deploy", Status: "completed", Conclusion: "timed_out"},
+			},
+			expectedHealth:  ciHealthRed,
+			expectedFailing: []string{"Lint", "Deploy"},
+		},
+		{
+			name: "pending",
+			workflows: []ciWorkflowStatus{
+				{Name: "CI", Status: "queued"},
+				{Name: "Lint", Status: "completed", Conclusion: "success"},
+			},
+			expectedHealth: ciHealthPending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			health, failing := ciHealth(tc.workflows)
+			assert.Equal(t, tc.expectedHealth, health)
+			assert.Equal(t, tc.expectedFailing, failing)
+		})
+	}
+}
+
+func Test_GetCIMatrix(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCIMatrix(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_ci_matrix", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"repositories"})
+
+	mockWorkflows := &github.Workflows{
+		TotalCount: github.Ptr(3),
+		Workflows: []*github.Workflow{
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("CI"), Path: github.Ptr(".github/workflows/ci.yml"), State: github.Ptr("active")},
+			{ID: github.Ptr(int64(2)), Name: github.Ptr("Release"), Path: github.Ptr(".github/workflows/release.yml"), State: github.Ptr("active")},
+			{ID: github.Ptr(int64(3)), Name: github.Ptr("Old"), Path: github.Ptr(".github/workflows/old.yml"), State: github.Ptr("disabled_manually")},
+		},
+	}
+
+	mockRuns := &github.WorkflowRuns{
+		TotalCount: github.Ptr(3),
+		WorkflowRuns: []*github.WorkflowRun{
+			{ID: github.Ptr(int64(30)), WorkflowID: github.Ptr(int64(1)), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), HTMLURL: github.Ptr("https://github.com/octo/app/actions/runs/30")},
+			{ID: github.Ptr(int64(20)), WorkflowID: github.Ptr(int64(2)), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			{ID: github.Ptr(int64(10)), WorkflowID: github.Ptr(int64(1)), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedRed    []string
+		expectedHealth map[string]string
+	}{
+		{
+			name: "latest run per workflow determines health",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsByOwnerByRepo,
+					mockWorkflows,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"branch":                "main",
+						"exclude_pull_requests": "true",
+						"per_page":              "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuns),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"repositories": []any{"octo/app"},
+			},
+			expectedRed:    []string{"octo/app"},
+			expectedHealth: map[string]string{"octo/app": ciHealthRed},
+		},
+		{
+			name: "inaccessible repository is reported without failing the call",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"repositories": []any{"octo/missing"},
+			},
+			expectedRed:    []string{},
+			expectedHealth: map[string]string{"octo/missing": ciHealthUnknown},
+		},
+		{
+			name:         "invalid repository name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"repositories": []any{"octo"},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid repository \"octo\", expected owner/repo",
+		},
+		{
+			name:         "no repositories",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"repositories": []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCIMatrix(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned ciMatrix
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedRed, returned.RedRepositories)
+			for _, repo := range returned.Repositories {
+				assert.Equal(t, tc.expectedHealth[repo.Repository], repo.Health)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxAffectedPackagesFiles bounds the changed files of a pull request detect_affected_packages maps, the API
+	// lists at most 3000.
+	maxAffectedPackagesFiles = 3000
+	// maxWorkspaceManifests bounds the manifests read to find the dependencies between packages, one request each.
+	maxWorkspaceManifests = 200
+)
+
+// packageManifests are the manifest files marking the root of a package, by the kind of package.
+var packageManifests = map[string]string{
+	"go.mod":           "go",
+	"package.json":     "npm",
+	"Cargo.toml":       "cargo",
+	"pyproject.toml":   "python",
+	"setup.py":         "python",
+	"pom.xml":          "maven",
+	"build.gradle":     "gradle",
+	"build.gradle.kts": "gradle",
+}
+
+// workspacePackage is a package of a monorepo.
+type workspacePackage struct {
+	// Path is the root directory of the package, "." for the repository root.
+	Path string
+	Kind string
+	// Name and dependencies are read from the manifests of go and npm packages.
+	Name         string
+	Dependencies []string
+}
+
+// containsPath reports whether a file is under a package root.
+func (p workspacePackage) containsPath(filePath string) bool {
+	return p.Path == "." || strings.HasPrefix(filePath, p.Path+"/")
+}
+
+// packageForPath returns the innermost package containing a file, and false if none does.
+func packageForPath(packages []workspacePackage, filePath string) (workspacePackage, bool) {
+	var found workspacePackage
+	ok := false
+	for _, p := range packages {
+		if p.containsPath(filePath) && (!ok || found.Path == "." || len(p.Path) > len(found.Path)) {
+			found, ok = p, true
+		}
+	}
+	return found, ok
+}
+
+// matchesGlobSegments reports whether a directory matches a glob pattern, segment by segment.
+func matchesGlobSegments(dir, pattern string) bool {
+	dirSegments := strings.Split(dir, "/")
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	if len(dirSegments) != len(patternSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if ok, _ := path.Match(segment, dirSegments[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseGoModDependencies returns the module path and the required modules of a go.mod file.
+func parseGoModDependencies(content string) (module string, requires []string) {
+	inRequire := false
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(strings.Split(line, "//")[0])
+		switch {
+		case len(fields) == 0:
+		case inRequire && fields[0] == ")":
+			inRequire = false
+		case inRequire:
+			requires = append(requires, fields[0])
+		case fields[0] == "module" && len(fields) > 1:
+			module = strings.Trim(fields[1], `"`)
+		case fields[0] == "require" && len(fields) > 1:
+			if fields[1] == "(" {
+				inRequire = true
+			} else {
+				requires = append(requires, fields[1])
+			}
+		}
+	}
+	return module, requires
+}
+
+// parsePackageJSONDependencies returns the name and the dependencies of any kind of a package.json file.
+func parsePackageJSONDependencies(content string) (name string, dependencies []string) {
+	var manifest struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return "", nil
+	}
+	for _, deps := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.PeerDependencies, manifest.OptionalDependencies} {
+		for dep := range deps {
+			dependencies = append(dependencies, dep)
+		}
+	}
+	sort.Strings(dependencies)
+	return manifest.Name, dependencies
+}
+
+// affectedPackage is a package impacted by a pull request.
+type affectedPackage struct {
+	Path string `json:"path"`
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+	// ChangedFiles are the files of the package changed by the pull request.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// Via is the package this one depends on through which it is impacted, for packages without changed files.
+	Via string `json:"via,omitempty"`
+}
+
+// affectedPackagesReport is the result of detect_affected_packages.
+type affectedPackagesReport struct {
+	PullRequest  int `json:"pull_request"`
+	ChangedFiles int `json:"changed_files"`
+	// Discovery is manifests or globs.
+	Discovery string            `json:"discovery"`
+	Packages  []affectedPackage `json:"packages"`
+	// Dependents are the packages depending, directly or not, on the changed ones.
+	Dependents []affectedPackage `json:"dependents"`
+	// OutsidePackages are the changed files in no package.
+	OutsidePackages []string `json:"outside_packages"`
+	Warnings        []string `json:"warnings,omitempty"`
+}
+
+// DetectAffectedPackages creates a tool to map the changed files of a pull request to the packages of a monorepo.
+func DetectAffectedPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("detect_affected_packages",
+			mcp.WithDescription(t("TOOL_DETECT_AFFECTED_PACKAGES_DESCRIPTION", "Map the files changed by a pull request to the packages of a monorepo, and return the affected packages, e.g. to select the CI jobs to run or the reviewers to request. Packages are the directories matching package_globs, or else the directories with a manifest (go.mod, package.json, Cargo.toml, pyproject.toml, setup.py, pom.xml, build.gradle). For go and npm packages found from their manifests, the packages depending on the affected ones are returned too.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DETECT_AFFECTED_PACKAGES_USER_TITLE", "Detect affected packages"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("package_globs",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Glob patterns of the package directories, e.g. packages/* or services/*/api. Defaults to the directories with a manifest"),
+			),
+			mcp.WithBoolean("include_dependents",
+				mcp.Description("Also return the packages depending on the affected ones, defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDetectAffectedPackagesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["include_dependents"]; !ok {
+				params.IncludeDependents = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			headSHA := pr.GetHead().GetSHA()
+
+			report := affectedPackagesReport{
+				PullRequest:     params.PullNumber,
+				Discovery:       "manifests",
+				Packages:        []affectedPackage{},
+				Dependents:      []affectedPackage{},
+				OutsidePackages: []string{},
+			}
+			var files []string
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				page, resp, err := client.PullRequests.ListFiles(ctx, params.Owner, params.Repo, params.PullNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list pull request files: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, file := range page {
+					files = append(files, file.GetFilename())
+					// A file moved across packages affects both
+					if file.GetPreviousFilename() != "" {
+						files = append(files, file.GetPreviousFilename())
+					}
+					report.ChangedFiles++
+				}
+				if resp.NextPage == 0 || report.ChangedFiles >= maxAffectedPackagesFiles {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if pr.GetChangedFiles() > report.ChangedFiles {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("only %d of the %d changed files could be listed", report.ChangedFiles, pr.GetChangedFiles()))
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, headSHA, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree: %w", err)
+			}
+			_ = resp.Body.Close()
+			if tree.GetTruncated() {
+				report.Warnings = append(report.Warnings, "the repository tree is too large to list completely, packages may be missing")
+			}
+
+			var packages []workspacePackage
+			manifests := map[string]string{}
+			for _, entry := range tree.Entries {
+				p := entry.GetPath()
+				if len(params.PackageGlobs) > 0 {
+					if entry.GetType() != "tree" {
+						continue
+					}
+					for _, glob := range params.PackageGlobs {
+						if matchesGlobSegments(p, glob) {
+							packages = append(packages, workspacePackage{Path: p})
+							break
+						}
+					}
+					continue
+				}
+				kind, ok := packageManifests[path.Base(p)]
+				if entry.GetType() != "blob" || !ok || isVendoredPath(p) {
+					continue
+				}
+				dir := path.Dir(p)
+				if _, seen := manifests[dir]; seen {
+					continue
+				}
+				manifests[dir] = p
+				packages = append(packages, workspacePackage{Path: dir, Kind: kind})
+			}
+			if len(params.PackageGlobs) > 0 {
+				report.Discovery = "globs"
+				params.IncludeDependents = false
+			}
+
+			// Dependencies between packages are read from the go.mod and package.json manifests
+			if params.IncludeDependents {
+				readable := 0
+				for _, p := range packages {
+					if p.Kind == "go" || p.Kind == "npm" {
+						readable++
+					}
+				}
+				if readable > maxWorkspaceManifests {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("%d manifests would have to be read to find the dependents, at most %d can be", readable, maxWorkspaceManifests))
+					params.IncludeDependents = false
+				}
+			}
+			if params.IncludeDependents {
+				for i, p := range packages {
+					if p.Kind != "go" && p.Kind != "npm" {
+						continue
+					}
+					content, _, found, err := getFileAtRef(ctx, client, params.Owner, params.Repo, manifests[p.Path], headSHA)
+					if err != nil {
+						return nil, err
+					}
+					if !found {
+						continue
+					}
+					if p.Kind == "go" {
+						packages[i].Name, packages[i].Dependencies = parseGoModDependencies(string(content))
+					} else {
+						packages[i].Name, packages[i].Dependencies = parsePackageJSONDependencies(string(content))
+					}
+				}
+			}
+
+			changed := map[string]*affectedPackage{}
+			for _, file := range files {
+				p, ok := packageForPath(packages, file)
+				if !ok {
+					report.OutsidePackages = append(report.OutsidePackages, file)
+					continue
+				}
+				if changed[p.Path] == nil {
+					changed[p.Path] = &affectedPackage{Path: p.Path, Kind: p.Kind, Name: p.Name}
+				}
+				changed[p.Path].ChangedFiles = append(changed[p.Path].ChangedFiles, file)
+			}
+			for _, p := range changed {
+				report.Packages = append(report.Packages, *p)
+			}
+			sort.Slice(report.Packages, func(i, j int) bool { return report.Packages[i].Path < report.Packages[j].Path })
+
+			if params.IncludeDependents {
+				byName := map[string]workspacePackage{}
+				for _, p := range packages {
+					if p.Name != "" {
+						byName[p.Kind+":"+p.Name] = p
+					}
+				}
+				// Walk the dependents breadth first from the changed packages
+				impacted := map[string]bool{}
+				var queue []string
+				for _, p := range report.Packages {
+					impacted[p.Path] = true
+					queue = append(queue, p.Path)
+				}
+				for len(queue) > 0 {
+					current := queue[0]
+					queue = queue[1:]
+					for _, p := range packages {
+						if impacted[p.Path] {
+							continue
+						}
+						for _, dep := range p.Dependencies {
+							if target, ok := byName[p.Kind+":"+dep]; ok && target.Path == current {
+								impacted[p.Path] = true
+								queue = append(queue, p.Path)
+								report.Dependents = append(report.Dependents, affectedPackage{Path: p.Path, Kind: p.Kind, Name: p.Name, Via: current})
+								break
+							}
+						}
+					}
+				}
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
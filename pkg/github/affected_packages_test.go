@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseGoModDependencies(t *testing.T) {
+	module, requires := parseGoModDependencies(`module example.com/mono/services/api // the API
+
+go 1.23
+
+require example.com/mono/libs/core v0.0.0
+
+require (
+	github.com/google/go-github/v69 v69.2.0
+	// indirect dependencies
+	golang.org/x/net v0.30.0 // indirect
+)
+
+replace example.com/mono/libs/core => ../../libs/core
+`)
+	assert.Equal(t, "example.com/mono/services/api", module)
+	assert.Equal(t, []string{"example.com/mono/libs/core", "github.com/google/go-github/v69", "golang.org/x/net"}, requires)
+}
+
+func Test_PackageForPath(t *testing.T) {
+	packages := []workspacePackage{{Path: "."}, {Path: "libs"}, {Path: "libs/core"}}
+	p, ok := packageForPath(packages, "libs/core/util.go")
+	assert.True(t, ok)
+	assert.Equal(t, "libs/core", p.Path)
+	p, _ = packageForPath(packages, "libs/README.md")
+	assert.Equal(t, "libs", p.Path)
+	p, _ = packageForPath(packages, "libsearch/main.go")
+	assert.Equal(t, ".", p.Path)
+	_, ok = packageForPath(packages[1:], "main.go")
+	assert.False(t, ok)
+
+	assert.True(t, matchesGlobSegments("services/api", "services/*/"))
+	assert.False(t, matchesGlobSegments("services/api/v2", "services/*"))
+}
+
+func Test_DetectAffectedPackages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DetectAffectedPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "detect_affected_packages", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "package_globs")
+	assert.Contains(t, tool.InputSchema.Properties, "include_dependents")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	entry := func(p, kind string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(p), Type: github.Ptr(kind)}
+	}
+	manifests := map[string]*github.RepositoryContent{
+		"libs/core/go.mod":       fileContent("libs/core/go.mod", "module example.com/mono/libs/core\n"),
+		"services/api/go.mod":    fileContent("services/api/go.mod", "module example.com/mono/services/api\n\nrequire example.com/mono/libs/core v0.0.0\n"),
+		"services/worker/go.mod": fileContent("services/worker/go.mod", "module example.com/mono/services/worker\n\nrequire (\n\texample.com/mono/services/api v0.0.0\n)\n"),
+		"web/package.json":       fileContent("web/package.json", `{"name": "web", "dependencies": {"react": "^19.0.0"}}`),
+	}
+	options := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{
+				Number:       github.Ptr(5),
+				ChangedFiles: github.Ptr(3),
+				Head:         &github.PullRequestBranch{SHA: github.Ptr("head-sha")},
+			}),
+			mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, []*github.CommitFile{
+				{Filename: github.Ptr("libs/core/util.go")},
+				{Filename: github.Ptr("README.md")},
+				{Filename: github.Ptr("web/src/app.ts"), PreviousFilename: github.Ptr("web/app.ts")},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				expectPath(t, "/repos/owner/repo/git/trees/head-sha").andThen(
+					mockResponse(t, http.StatusOK, &github.Tree{Entries: []*github.TreeEntry{
+						entry("README.md", "blob"),
+						entry("libs", "tree"),
+						entry("libs/core", "tree"),
+						entry("libs/core/go.mod", "blob"),
+						entry("libs/core/util.go", "blob"),
+						entry("node_modules/react/package.json", "blob"),
+						entry("services", "tree"),
+						entry("services/api", "tree"),
+						entry("services/api/go.mod", "blob"),
+						entry("services/worker", "tree"),
+						entry("services/worker/go.mod", "blob"),
+						entry("web", "tree"),
+						entry("web/package.json", "blob"),
+					}}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				expectQueryParams(t, map[string]string{"ref": "head-sha"}).andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						mockResponse(t, http.StatusOK, manifests[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/")])(w, r)
+					}),
+				),
+			),
+		}
+	}
+
+	run := func(t *testing.T, args map[string]any) affectedPackagesReport {
+		_, handler := DetectAffectedPackages(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options()...))), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report affectedPackagesReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		return report
+	}
+
+	t.Run("discovers packages from manifests", func(t *testing.T) {
+		report := run(t, map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(5),
+		})
+		assert.Equal(t, 3, report.ChangedFiles)
+		assert.Equal(t, "manifests", report.Discovery)
+		assert.Equal(t, []affectedPackage{
+			{Path: "libs/core", Kind: "go", Name: "example.com/mono/libs/core", ChangedFiles: []string{"libs/core/util.go"}},
+			{Path: "web", Kind: "npm", Name: "web", ChangedFiles: []string{"web/src/app.ts", "web/app.ts"}},
+		}, report.Packages)
+		assert.Equal(t, []affectedPackage{
+			{Path: "services/api", Kind: "go", Name: "example.com/mono/services/api", Via: "libs/core"},
+			{Path: "services/worker", Kind: "go", Name: "example.com/mono/services/worker", Via: "services/api"},
+		}, report.Dependents)
+		assert.Equal(t, []string{"README.md"}, report.OutsidePackages)
+	})
+
+	t.Run("leaves out dependents", func(t *testing.T) {
+		report := run(t, map[string]any{
+			"owner":              "owner",
+			"repo":               "repo",
+			"pullNumber":         float64(5),
+			"include_dependents": false,
+		})
+		assert.Len(t, report.Packages, 2)
+		assert.Empty(t, report.Packages[0].Name)
+		assert.Empty(t, report.Dependents)
+	})
+
+	t.Run("uses package globs", func(t *testing.T) {
+		report := run(t, map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"pullNumber":    float64(5),
+			"package_globs": []any{"libs/*", "services/*"},
+		})
+		assert.Equal(t, "globs", report.Discovery)
+		assert.Equal(t, []affectedPackage{{Path: "libs/core", ChangedFiles: []string{"libs/core/util.go"}}}, report.Packages)
+		assert.Empty(t, report.Dependents)
+		assert.Equal(t, []string{"README.md", "web/src/app.ts", "web/app.ts"}, report.OutsidePackages)
+	})
+}
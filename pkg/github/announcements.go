@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// defaultAnnouncementCategory is the discussion category GitHub creates for announcements in every repository with
+// discussions.
+const defaultAnnouncementCategory = "Announcements"
+
+// announcementExpiryPattern finds the expiry create_discussion_announcement records in the body of an announcement,
+// as a comment hidden from readers.
+var announcementExpiryPattern = regexp.MustCompile(`<!-- announcement-expires: (\d{4}-\d{2}-\d{2}) -->`)
+
+// discussionCategoryQuery looks up a repository and its discussion categories.
+type discussionCategoryQuery struct {
+	Repository struct {
+		ID                   githubv4.ID
+		DiscussionCategories struct {
+			Nodes []struct {
+				ID   githubv4.ID
+				Name githubv4.String
+				Slug githubv4.String
+			}
+		} `graphql:"discussionCategories(first: 100)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// findDiscussionCategory returns the ID of a repository and of its discussion category with the given name or slug.
+// Its errors are meant for the user.
+func findDiscussionCategory(ctx context.Context, client *githubv4.Client, owner, repo, category string) (githubv4.ID, githubv4.ID, error) {
+	var query discussionCategoryQuery
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return nil, nil, err
+	}
+	available := make([]string, 0, len(query.Repository.DiscussionCategories.Nodes))
+	for _, c := range query.Repository.DiscussionCategories.Nodes {
+		if strings.EqualFold(string(c.Name), category) || strings.EqualFold(string(c.Slug), category) {
+			return query.Repository.ID, c.ID, nil
+		}
+		available = append(available, string(c.Name))
+	}
+	if len(available) == 0 {
+		return nil, nil, fmt.Errorf("discussions are not enabled for %s/%s or it has no categories", owner, repo)
+	}
+	return nil, nil, fmt.Errorf("discussion category %q not found, available categories: %s", category, strings.Join(available, ", "))
+}
+
+// CreateDiscussionAnnouncement creates a tool to post an announcement as a discussion, optionally expiring on a date.
+func CreateDiscussionAnnouncement(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_discussion_announcement",
+			mcp.WithDescription(t("TOOL_CREATE_DISCUSSION_ANNOUNCEMENT_DESCRIPTION", "Post an announcement as a discussion of a repository, such as a release or an outage notice. For an organization-wide announcement, use the repository the organization's discussions come from, often .github. An expiry date can be recorded for close_expired_announcements to retire the announcement. The GitHub API cannot pin discussions: to feature the announcement, pin it from its page.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DISCUSSION_ANNOUNCEMENT_USER_TITLE", "Create discussion announcement"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name, for organization-wide announcements the repository of the organization's discussions"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the announcement"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Body of the announcement, in Markdown"),
+			),
+			mcp.WithString("category",
+				mcp.Description(fmt.Sprintf("Name or slug of the discussion category, defaults to %s", defaultAnnouncementCategory)),
+			),
+			mcp.WithString("expires",
+				mcp.Description("Date after which the announcement is outdated, as YYYY-MM-DD"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCreateDiscussionAnnouncementParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Category == "" {
+				params.Category = defaultAnnouncementCategory
+			}
+			body := params.Body
+			if params.Expires != "" {
+				expires, err := time.Parse(time.DateOnly, params.Expires)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("expires must be a date as YYYY-MM-DD: %s", params.Expires)), nil
+				}
+				if !expires.After(time.Now()) {
+					return mcp.NewToolResultError(fmt.Sprintf("expires must be in the future: %s", params.Expires)), nil
+				}
+				body = fmt.Sprintf("%s\n\n<!-- announcement-expires: %s -->", body, params.Expires)
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			repositoryID, categoryID, err := findDiscussionCategory(ctx, client, params.Owner, params.Repo, params.Category)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var createDiscussion struct {
+				CreateDiscussion struct {
+					Discussion struct {
+						ID     githubv4.ID
+						Number githubv4.Int
+						URL    githubv4.URI
+					}
+				} `graphql:"createDiscussion(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &createDiscussion, githubv4.CreateDiscussionInput{
+				RepositoryID: repositoryID,
+				Title:        githubv4.String(params.Title),
+				Body:         githubv4.String(body),
+				CategoryID:   categoryID,
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create discussion: %s", err.Error())), nil
+			}
+			discussion := createDiscussion.CreateDiscussion.Discussion
+
+			r, err := json.Marshal(map[string]any{
+				"number":  discussion.Number,
+				"url":     discussion.URL.String(),
+				"expires": params.Expires,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// expiredAnnouncement is an announcement close_expired_announcements retired.
+type expiredAnnouncement struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Expired string `json:"expired"`
+	// Pinned announcements stay pinned once closed, the GitHub API cannot unpin them.
+	Pinned bool   `json:"pinned"`
+	Closed bool   `json:"closed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CloseExpiredAnnouncements creates a tool to close the announcements past the expiry recorded by
+// create_discussion_announcement.
+func CloseExpiredAnnouncements(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("close_expired_announcements",
+			mcp.WithDescription(t("TOOL_CLOSE_EXPIRED_ANNOUNCEMENTS_DESCRIPTION", "Close as outdated the open announcement discussions whose expiry, recorded by create_discussion_announcement, has passed. The GitHub API cannot unpin discussions, so the closed announcements still pinned are flagged for unpinning from their page. Use dry_run to only list them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLOSE_EXPIRED_ANNOUNCEMENTS_USER_TITLE", "Close expired announcements"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("category",
+				mcp.Description(fmt.Sprintf("Name or slug of the discussion category of the announcements, defaults to %s", defaultAnnouncementCategory)),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("List the expired announcements without closing them"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCloseExpiredAnnouncementsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Category == "" {
+				params.Category = defaultAnnouncementCategory
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			_, categoryID, err := findDiscussionCategory(ctx, client, params.Owner, params.Repo, params.Category)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var query struct {
+				Repository struct {
+					Discussions struct {
+						Nodes []struct {
+							ID     githubv4.ID
+							Number githubv4.Int
+							Title  githubv4.String
+							URL    githubv4.URI
+							Body   githubv4.String
+							Closed githubv4.Boolean
+						}
+					} `graphql:"discussions(first: 100, categoryId: $categoryId)"`
+					PinnedDiscussions struct {
+						Nodes []struct {
+							Discussion struct {
+								Number githubv4.Int
+							}
+						}
+					} `graphql:"pinnedDiscussions(first: 100)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":      githubv4.String(params.Owner),
+				"repo":       githubv4.String(params.Repo),
+				"categoryId": categoryID,
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pinned := map[int]bool{}
+			for _, node := range query.Repository.PinnedDiscussions.Nodes {
+				pinned[int(node.Discussion.Number)] = true
+			}
+
+			today := time.Now().UTC().Format(time.DateOnly)
+			expired := []expiredAnnouncement{}
+			for _, discussion := range query.Repository.Discussions.Nodes {
+				match := announcementExpiryPattern.FindStringSubmatch(string(discussion.Body))
+				// Dates as YYYY-MM-DD compare as strings
+				if discussion.Closed || match == nil || match[1] >= today {
+					continue
+				}
+				announcement := expiredAnnouncement{
+					Number:  int(discussion.Number),
+					Title:   string(discussion.Title),
+					URL:     discussion.URL.String(),
+					Expired: match[1],
+					Pinned:  pinned[int(discussion.Number)],
+				}
+				if !params.DryRun {
+					var closeDiscussion struct {
+						CloseDiscussion struct {
+							Typename string `graphql:"__typename"`
+						} `graphql:"closeDiscussion(input: $input)"`
+					}
+					if err := client.Mutate(ctx, &closeDiscussion, githubv4.CloseDiscussionInput{
+						DiscussionID: discussion.ID,
+						Reason:       newGQLStringlike[githubv4.DiscussionCloseReason](string(githubv4.DiscussionCloseReasonOutdated)),
+					}, nil); err != nil {
+						announcement.Error = err.Error()
+					} else {
+						announcement.Closed = true
+					}
+				}
+				expired = append(expired, announcement)
+			}
+
+			r, err := json.Marshal(expired)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
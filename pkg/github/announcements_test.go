@@ -0,0 +1,244 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// discussionCategoriesMatcher answers the discussion category lookup of owner/repo.
+func discussionCategoriesMatcher() githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		discussionCategoryQuery{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"id": "R_1",
+				"discussionCategories": map[string]any{
+					"nodes": []any{
+						map[string]any{"id": "DIC_1", "name": "Announcements", "slug": "announcements"},
+						map[string]any{"id": "DIC_2", "name": "Q&A", "slug": "q-a"},
+					},
+				},
+			},
+		}),
+	)
+}
+
+func Test_CreateDiscussionAnnouncement(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CreateDiscussionAnnouncement(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_discussion_announcement", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "category")
+	assert.Contains(t, tool.InputSchema.Properties, "expires")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title", "body"})
+
+	createDiscussion := githubv4mock.NewMutationMatcher(
+		struct {
+			CreateDiscussion struct {
+				Discussion struct {
+					ID     githubv4.ID
+					Number githubv4.Int
+					URL    githubv4.URI
+				}
+			} `graphql:"createDiscussion(input: $input)"`
+		}{},
+		githubv4.CreateDiscussionInput{
+			RepositoryID: githubv4.ID("R_1"),
+			Title:        githubv4.String("v2.0 is out"),
+			Body:         githubv4.String("Read the release notes.\n\n<!-- announcement-expires: 2999-01-31 -->"),
+			CategoryID:   githubv4.ID("DIC_1"),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"createDiscussion": map[string]any{
+				"discussion": map[string]any{
+					"id":     "D_7",
+					"number": 7,
+					"url":    "https://github.com/owner/repo/discussions/7",
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "announcement with an expiry",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"title":   "v2.0 is out",
+				"body":    "Read the release notes.",
+				"expires": "2999-01-31",
+			},
+		},
+		{
+			name: "past expiry",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"title":   "v2.0 is out",
+				"body":    "Read the release notes.",
+				"expires": "2020-01-31",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "expires must be in the future",
+		},
+		{
+			name: "unknown category",
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"title":    "v2.0 is out",
+				"body":     "Read the release notes.",
+				"category": "News",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: `discussion category "News" not found, available categories: Announcements, Q&A`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(discussionCategoriesMatcher(), createDiscussion))
+			_, handler := CreateDiscussionAnnouncement(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, float64(7), returned["number"])
+			assert.Equal(t, "https://github.com/owner/repo/discussions/7", returned["url"])
+			assert.Equal(t, "2999-01-31", returned["expires"])
+		})
+	}
+}
+
+func Test_CloseExpiredAnnouncements(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CloseExpiredAnnouncements(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "close_expired_announcements", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "category")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	discussions := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				Discussions struct {
+					Nodes []struct {
+						ID     githubv4.ID
+						Number githubv4.Int
+						Title  githubv4.String
+						URL    githubv4.URI
+						Body   githubv4.String
+						Closed githubv4.Boolean
+					}
+				} `graphql:"discussions(first: 100, categoryId: $categoryId)"`
+				PinnedDiscussions struct {
+					Nodes []struct {
+						Discussion struct {
+							Number githubv4.Int
+						}
+					}
+				} `graphql:"pinnedDiscussions(first: 100)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner":      githubv4.String("owner"),
+			"repo":       githubv4.String("repo"),
+			"categoryId": githubv4.ID("DIC_1"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussions": map[string]any{
+					"nodes": []any{
+						map[string]any{"id": "D_1", "number": 1, "title": "Maintenance window", "url": "https://github.com/owner/repo/discussions/1", "body": "Downtime on Sunday\n\n<!-- announcement-expires: 2020-03-01 -->", "closed": false},
+						map[string]any{"id": "D_2", "number": 2, "title": "v2.0 is out", "url": "https://github.com/owner/repo/discussions/2", "body": "Upgrade\n\n<!-- announcement-expires: 2999-01-31 -->", "closed": false},
+						map[string]any{"id": "D_3", "number": 3, "title": "Old news", "url": "https://github.com/owner/repo/discussions/3", "body": "<!-- announcement-expires: 2020-01-01 -->", "closed": true},
+						map[string]any{"id": "D_4", "number": 4, "title": "Welcome", "url": "https://github.com/owner/repo/discussions/4", "body": "Say hi", "closed": false},
+					},
+				},
+				"pinnedDiscussions": map[string]any{
+					"nodes": []any{
+						map[string]any{"discussion": map[string]any{"number": 1}},
+						map[string]any{"discussion": map[string]any{"number": 4}},
+					},
+				},
+			},
+		}),
+	)
+	closeDiscussion := githubv4mock.NewMutationMatcher(
+		struct {
+			CloseDiscussion struct {
+				Typename string `graphql:"__typename"`
+			} `graphql:"closeDiscussion(input: $input)"`
+		}{},
+		githubv4.CloseDiscussionInput{
+			DiscussionID: githubv4.ID("D_1"),
+			Reason:       githubv4mock.Ptr(githubv4.DiscussionCloseReasonOutdated),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{}),
+	)
+
+	for _, dryRun := range []bool{false, true} {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(discussionCategoriesMatcher(), discussions, closeDiscussion))
+		_, handler := CloseExpiredAnnouncements(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"dry_run": dryRun,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var expired []expiredAnnouncement
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &expired))
+		assert.Equal(t, []expiredAnnouncement{{
+			Number:  1,
+			Title:   "Maintenance window",
+			URL:     "https://github.com/owner/repo/discussions/1",
+			Expired: "2020-03-01",
+			Pinned:  true,
+			Closed:  !dryRun,
+		}}, expired)
+	}
+}
@@ -0,0 +1,62 @@
+package github
+
+import "github.com/mark3labs/mcp-go/server"
+
+// publicDataTools are the tools served in anonymous mode, by name. They only read repositories, issues, pull
+// requests and workflow runs through REST endpoints open to unauthenticated requests. Tools using GraphQL or code
+// search, which require a token, and tools reading admin-only or user data are left out, as are tools making a
+// request per repository, ref, commit, run, workflow, file, stack frame, issue or sub-issue, which could use up the
+// 60 requests per hour GitHub allows without a token in a single call.
+var publicDataTools = map[string]bool{
+	// actions
+	"get_workflow_duration_trends": true,
+
+	// dependabot
+	"get_dependabot_config":         true,
+	"list_dependabot_pull_requests": true,
+
+	// issues
+	"find_duplicate_issues": true,
+	"get_issue":             true,
+	"get_issue_comments":    true,
+	"get_issue_timeline":    true,
+	"get_parent_issue":      true,
+	"list_issue_events":     true,
+	"list_issues":           true,
+	"search_issues":         true,
+
+	// pull_requests
+	"evaluate_pr_description":   true,
+	"get_pull_request":          true,
+	"get_pull_request_checks":   true,
+	"get_pull_request_comments": true,
+	"get_pull_request_diff":     true,
+	"get_pull_request_files":    true,
+	"get_pull_request_reviews":  true,
+	"get_pull_request_status":   true,
+	"list_pull_requests":        true,
+
+	// reactions
+	"list_reactions": true,
+
+	// repos
+	"diff_file_between_refs":   true,
+	"get_codebase_stats":       true,
+	"get_code_snippet":         true,
+	"get_commit":               true,
+	"get_file_contents":        true,
+	"get_tag":                  true,
+	"list_branches":            true,
+	"list_commits":             true,
+	"list_tags":                true,
+	"search_repositories":      true,
+	"summarize_branch_changes": true,
+
+	// users
+	"search_users": true,
+}
+
+// isPublicDataTool reports whether a tool is served in anonymous mode.
+func isPublicDataTool(tool server.ServerTool) bool {
+	return publicDataTools[tool.Tool.Name]
+}
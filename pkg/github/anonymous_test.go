@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fanOutTools make a request per repository, ref, commit, check run, run, branch, workflow, file, stack frame, issue
+// or sub-issue.
+var fanOutTools = []string{
+	"analyze_check_failures",
+	"analyze_fork_network",
+	"check_issue_slas",
+	"detect_affected_packages",
+	"diff_workflows",
+	"export_issues",
+	"find_latest_green_commit",
+	"find_merged_branches",
+	"get_ci_matrix",
+	"get_job_step_timings",
+	"list_sub_issue_tree",
+	"list_sub_issues",
+	"map_workflow_dependencies",
+	"resolve_stack_trace",
+	"sub_issue_progress",
+}
+
+// notFoundTransport answers every request with 404, counting them.
+type notFoundTransport struct {
+	requests atomic.Int64
+}
+
+func (t *notFoundTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
+// schemaArguments returns a valid-looking value for each parameter of a tool, so that its handler gets past
+// argument validation.
+func schemaArguments(tool mcp.Tool) map[string]any {
+	args := make(map[string]any)
+	for name, property := range tool.InputSchema.Properties {
+		schema, _ := property.(map[string]any)
+		if values, ok := schema["enum"].([]string); ok && len(values) > 0 {
+			args[name] = values[0]
+			continue
+		}
+		switch schema["type"] {
+		case "number", "integer":
+			value := float64(1)
+			if minimum, ok := schema["minimum"].(float64); ok && minimum > value {
+				value = minimum
+			}
+			args[name] = value
+		case "boolean":
+			args[name] = false
+		case "array":
+			args[name] = []any{"x"}
+		case "object":
+			args[name] = map[string]any{}
+		default:
+			args[name] = "x"
+		}
+	}
+	return args
+}
+
+func Test_PublicDataTools(t *testing.T) {
+	transport := &notFoundTransport{}
+	var graphQLTools []string
+	var current string
+	registry := NewRegistry(RegistryConfig{
+		GetClient: stubGetClientFn(github.NewClient(&http.Client{Transport: transport})),
+		GetGQLClient: func(_ context.Context) (*githubv4.Client, error) {
+			graphQLTools = append(graphQLTools, current)
+			return nil, errors.New("GraphQL needs a token")
+		},
+		Anonymous: true,
+	})
+	require.NoError(t, registry.EnableToolsets([]string{"all"}))
+	tools := registry.Tools()
+	names := toolNames(tools)
+
+	for _, name := range fanOutTools {
+		assert.NotContains(t, names, name, "%s makes a request per item", name)
+	}
+
+	for _, tool := range tools {
+		// Writes are refused, whatever toolsets are enabled
+		require.NotNil(t, tool.Tool.Annotations.ReadOnlyHint, tool.Tool.Name)
+		assert.True(t, *tool.Tool.Annotations.ReadOnlyHint, "%s writes", tool.Tool.Name)
+
+		current = tool.Tool.Name
+		_, _ = tool.Handler(context.Background(), createMCPRequest(schemaArguments(tool.Tool)))
+	}
+	assert.Empty(t, graphQLTools, "tools using GraphQL, which needs a token")
+	assert.Positive(t, transport.requests.Load())
+}
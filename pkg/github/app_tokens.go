@@ -0,0 +1,145 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxScopedTokenRepositories is the most repositories GitHub allows an installation token to be restricted to.
+const maxScopedTokenRepositories = 500
+
+// scopedInstallationToken is the result of create_scoped_installation_token.
+type scopedInstallationToken struct {
+	Token          string            `json:"token"`
+	ExpiresAt      *github.Timestamp `json:"expires_at,omitempty"`
+	InstallationID int64             `json:"installation_id"`
+	Repositories   []string          `json:"repositories"`
+	Permissions    map[string]string `json:"permissions"`
+}
+
+// parseInstallationPermissions validates the permissions argument, permission names mapped to read, write or
+// admin, and converts it to the permissions of an installation token.
+func parseInstallationPermissions(permissions map[string]any) (*github.InstallationPermissions, error) {
+	if len(permissions) == 0 {
+		return nil, fmt.Errorf("missing required parameter: permissions")
+	}
+	for name, access := range permissions {
+		switch access {
+		case "read", "write", "admin":
+		default:
+			return nil, fmt.Errorf("permission %s must be read, write or admin", name)
+		}
+	}
+
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var parsed github.InstallationPermissions
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("invalid permissions: %w", err)
+	}
+	return &parsed, nil
+}
+
+// InitAppToolset creates the toolset of the tools that need to authenticate as the GitHub App itself, rather than
+// with the token of the server.
+func InitAppToolset(getAppClient GetClientFn, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	return toolsets.NewToolset("apps", "GitHub App related tools, available when the server is configured with GitHub App credentials").
+		AddWriteTools(
+			toolsets.NewServerTool(CreateScopedInstallationToken(getAppClient, t)),
+		)
+}
+
+// CreateScopedInstallationToken creates a tool to mint an installation token of the GitHub App restricted to some
+// repositories and permissions.
+func CreateScopedInstallationToken(getAppClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_scoped_installation_token",
+			mcp.WithDescription(t("TOOL_CREATE_SCOPED_INSTALLATION_TOKEN_DESCRIPTION", "Mint a short-lived installation token of the GitHub App the server is configured with, restricted to some repositories of an account and to some permissions, e.g. to hand limited credentials to a downstream tool. The token expires after one hour and cannot be given more access than the App installation has. Treat the returned token as a secret.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_SCOPED_INSTALLATION_TOKEN_USER_TITLE", "Create scoped installation token"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Account owning the repositories, where the App is installed"),
+			),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description(fmt.Sprintf("Names of the repositories the token can access (at most %d)", maxScopedTokenRepositories)),
+			),
+			mcp.WithObject("permissions",
+				mcp.Required(),
+				mcp.Description("Permissions of the token, by name, each read, write or admin, e.g. {\"contents\": \"read\", \"issues\": \"write\"}"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCreateScopedInstallationTokenParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, repositories := params.Owner, params.Repositories
+			if len(repositories) > maxScopedTokenRepositories {
+				return mcp.NewToolResultError(fmt.Sprintf("a token can be restricted to at most %d repositories", maxScopedTokenRepositories)), nil
+			}
+			permissions, err := parseInstallationPermissions(params.Permissions)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getAppClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub App client: %w", err)
+			}
+
+			installation, resp, err := client.Apps.FindRepositoryInstallation(ctx, owner, repositories[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to find the App installation of %s/%s: %w", owner, repositories[0], err)
+			}
+			_ = resp.Body.Close()
+
+			token, resp, err := client.Apps.CreateInstallationToken(ctx, installation.GetID(), &github.InstallationTokenOptions{
+				Repositories: repositories,
+				Permissions:  permissions,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create installation token: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			result := scopedInstallationToken{
+				Token:          token.GetToken(),
+				ExpiresAt:      token.ExpiresAt,
+				InstallationID: installation.GetID(),
+				Repositories:   make([]string, 0, len(token.Repositories)),
+			}
+			for _, repo := range token.Repositories {
+				result.Repositories = append(result.Repositories, repo.GetName())
+			}
+			// The permissions actually granted, which GitHub returns in the same shape as they were asked for
+			granted, err := json.Marshal(token.Permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal permissions: %w", err)
+			}
+			if err := json.Unmarshal(granted, &result.Permissions); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal permissions: %w", err)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateScopedInstallationToken(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateScopedInstallationToken(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_scoped_installation_token", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "permissions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repositories", "permissions"})
+
+	expiresAt := time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedToken  scopedInstallationToken
+	}{
+		{
+			name: "mints a scoped token",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposInstallationByOwnerByRepo,
+					expectPath(t, "/repos/octo-org/api/installation").andThen(
+						mockResponse(t, http.StatusOK, &github.Installation{ID: github.Ptr(int64(7))}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostAppInstallationsAccessTokensByInstallationId,
+					expectPath(t, "/app/installations/7/access_tokens").andThen(
+						expectRequestBody(t, map[string]any{
+							"repositories": []any{"api", "web"},
+							"permissions":  map[string]any{"contents": "read", "issues": "write"},
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.InstallationToken{
+								Token:     github.Ptr("ghs_scoped"),
+								ExpiresAt: &github.Timestamp{Time: expiresAt},
+								Permissions: &github.InstallationPermissions{
+									Contents: github.Ptr("read"),
+									Issues:   github.Ptr("write"),
+								},
+								Repositories: []*github.Repository{{Name: github.Ptr("api")}, {Name: github.Ptr("web")}},
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{"api", "web"},
+				"permissions":  map[string]any{"contents": "read", "issues": "write"},
+			},
+			expectedToken: scopedInstallationToken{
+				Token:          "ghs_scoped",
+				ExpiresAt:      &github.Timestamp{Time: expiresAt},
+				InstallationID: 7,
+				Repositories:   []string{"api", "web"},
+				Permissions:    map[string]string{"contents": "read", "issues": "write"},
+			},
+		},
+		{
+			name:         "unknown permission",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{"api"},
+				"permissions":  map[string]any{"everything": "write"},
+			},
+			expectError:    true,
+			expectedErrMsg: `unknown field "everything"`,
+		},
+		{
+			name:         "invalid access",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{"api"},
+				"permissions":  map[string]any{"contents": "all"},
+			},
+			expectError:    true,
+			expectedErrMsg: "permission contents must be read, write or admin",
+		},
+		{
+			name:         "no repositories",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "octo-org",
+				"repositories": []any{},
+				"permissions":  map[string]any{"contents": "read"},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateScopedInstallationToken(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var token scopedInstallationToken
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &token))
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxReportedArtifacts bounds the artifacts get_artifact_storage_report lists, as the workflow of each is looked up.
+const maxReportedArtifacts = 1000
+
+// workflowArtifactUsage is the storage used by the artifacts of a workflow.
+type workflowArtifactUsage struct {
+	Workflow  string `json:"workflow"`
+	Artifacts int    `json:"artifacts"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// oldArtifact is an artifact older than the age limit that the keep policy does not keep.
+type oldArtifact struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Workflow  string    `json:"workflow"`
+	Branch    string    `json:"branch,omitempty"`
+	SizeBytes int64     `json:"size_bytes"`
+	CreatedAt time.Time `json:"created_at"`
+	AgeDays   int       `json:"age_days"`
+	Deleted   bool      `json:"deleted,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// artifactStorageReport is the result of get_artifact_storage_report.
+type artifactStorageReport struct {
+	Artifacts  int                     `json:"artifacts"`
+	Bytes      int64                   `json:"bytes"`
+	Expired    int                     `json:"expired"`
+	Workflows  []workflowArtifactUsage `json:"workflows"`
+	Old        []oldArtifact           `json:"old"`
+	OldBytes   int64                   `json:"old_bytes"`
+	KeptOld    int                     `json:"kept_old"`
+	FreedBytes int64                   `json:"freed_bytes,omitempty"`
+	Warnings   []string                `json:"warnings,omitempty"`
+}
+
+// GetArtifactStorageReport creates a tool to report the storage used by the GitHub Actions artifacts of a repository,
+// and optionally delete the old ones.
+func GetArtifactStorageReport(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_artifact_storage_report",
+			mcp.WithDescription(t("TOOL_GET_ARTIFACT_STORAGE_REPORT_DESCRIPTION", "Report the storage used by the GitHub Actions artifacts of a repository, in total and per workflow, and list the artifacts older than the age limit. The newest artifacts of each name and workflow are kept by the keep_last policy and not listed, even when old. Expired artifacts no longer use storage and are only counted. With delete, also deletes the listed artifacts and reports the result for each.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_GET_ARTIFACT_STORAGE_REPORT_USER_TITLE", "Get artifact storage report"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("older_than_days",
+				mcp.Description("Age in days from which an artifact is old, defaults to 30"),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("keep_last",
+				mcp.Description("Number of newest artifacts of each name and workflow to keep, even when old, defaults to 1"),
+				mcp.Min(0),
+			),
+			mcp.WithBoolean("delete",
+				mcp.Description("Delete the listed old artifacts"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetArtifactStorageReportParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["older_than_days"]; !ok {
+				params.OlderThanDays = 30
+			}
+			if _, ok := request.GetArguments()["keep_last"]; !ok {
+				params.KeepLast = 1
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			report := artifactStorageReport{Workflows: []workflowArtifactUsage{}, Old: []oldArtifact{}}
+			var artifacts []*github.Artifact
+			opts := &github.ListArtifactsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				page, resp, err := client.Actions.ListArtifacts(ctx, params.Owner, params.Repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list artifacts: %w", err)
+				}
+				_ = resp.Body.Close()
+				artifacts = append(artifacts, page.Artifacts...)
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(artifacts) >= maxReportedArtifacts {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("only the latest %d artifacts were reported", maxReportedArtifacts))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			// Artifacts only know their run, so the workflow of each run is looked up once
+			workflows := make(map[int64]string)
+			workflowOf := func(runID int64) (string, error) {
+				if name, ok := workflows[runID]; ok {
+					return name, nil
+				}
+				run, resp, err := client.Actions.GetWorkflowRunByID(ctx, params.Owner, params.Repo, runID)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						workflows[runID] = "(deleted run)"
+						return workflows[runID], nil
+					}
+					return "", fmt.Errorf("failed to get workflow run %d: %w", runID, err)
+				}
+				_ = resp.Body.Close()
+				workflows[runID] = run.GetName()
+				return workflows[runID], nil
+			}
+
+			usage := make(map[string]*workflowArtifactUsage)
+			byNameAndWorkflow := make(map[string][]oldArtifact)
+			now := time.Now()
+			for _, artifact := range artifacts {
+				if artifact.GetExpired() {
+					report.Expired++
+					continue
+				}
+				workflow, err := workflowOf(artifact.GetWorkflowRun().GetID())
+				if err != nil {
+					return nil, err
+				}
+				report.Artifacts++
+				report.Bytes += artifact.GetSizeInBytes()
+				if usage[workflow] == nil {
+					usage[workflow] = &workflowArtifactUsage{Workflow: workflow}
+				}
+				usage[workflow].Artifacts++
+				usage[workflow].Bytes += artifact.GetSizeInBytes()
+
+				key := workflow + "\x00" + artifact.GetName()
+				byNameAndWorkflow[key] = append(byNameAndWorkflow[key], oldArtifact{
+					ID:        artifact.GetID(),
+					Name:      artifact.GetName(),
+					Workflow:  workflow,
+					Branch:    artifact.GetWorkflowRun().GetHeadBranch(),
+					SizeBytes: artifact.GetSizeInBytes(),
+					CreatedAt: artifact.GetCreatedAt().Time,
+					AgeDays:   int(now.Sub(artifact.GetCreatedAt().Time).Hours() / 24),
+				})
+			}
+			for _, u := range usage {
+				report.Workflows = append(report.Workflows, *u)
+			}
+			sort.Slice(report.Workflows, func(i, j int) bool {
+				if report.Workflows[i].Bytes != report.Workflows[j].Bytes {
+					return report.Workflows[i].Bytes > report.Workflows[j].Bytes
+				}
+				return report.Workflows[i].Workflow < report.Workflows[j].Workflow
+			})
+
+			for _, group := range byNameAndWorkflow {
+				sort.Slice(group, func(i, j int) bool { return group[i].CreatedAt.After(group[j].CreatedAt) })
+				for i, artifact := range group {
+					if artifact.AgeDays < params.OlderThanDays {
+						continue
+					}
+					if i < params.KeepLast {
+						report.KeptOld++
+						continue
+					}
+					report.Old = append(report.Old, artifact)
+					report.OldBytes += artifact.SizeBytes
+				}
+			}
+			sort.Slice(report.Old, func(i, j int) bool { return report.Old[i].CreatedAt.Before(report.Old[j].CreatedAt) })
+
+			if params.Delete {
+				for i := range report.Old {
+					artifact := &report.Old[i]
+					resp, err := client.Actions.DeleteArtifact(ctx, params.Owner, params.Repo, artifact.ID)
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					if err != nil {
+						artifact.Error = fmt.Sprintf("failed to delete: %s", err)
+						continue
+					}
+					artifact.Deleted = true
+					report.FreedBytes += artifact.SizeBytes
+				}
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
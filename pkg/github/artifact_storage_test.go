@@ -0,0 +1,132 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// artifactStorageOptions mocks a repository whose CI workflow uploaded three coverage reports, and whose release
+// workflow uploaded a binary from a run since deleted, deleting artifacts with deleteArtifact.
+func artifactStorageOptions(t *testing.T, deleteArtifact http.HandlerFunc) []mock.MockBackendOption {
+	daysAgo := func(days int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Now().Add(-time.Duration(days)*24*time.Hour - time.Hour)}
+	}
+	return []mock.MockBackendOption{
+		mock.WithRequestMatch(mock.GetReposActionsArtifactsByOwnerByRepo, &github.ArtifactList{Artifacts: []*github.Artifact{
+			{ID: github.Ptr(int64(4)), Name: github.Ptr("coverage"), SizeInBytes: github.Ptr(int64(100)), CreatedAt: daysAgo(1), WorkflowRun: &github.ArtifactWorkflowRun{ID: github.Ptr(int64(14)), HeadBranch: github.Ptr("main")}},
+			{ID: github.Ptr(int64(3)), Name: github.Ptr("coverage"), SizeInBytes: github.Ptr(int64(200)), CreatedAt: daysAgo(40), WorkflowRun: &github.ArtifactWorkflowRun{ID: github.Ptr(int64(13)), HeadBranch: github.Ptr("main")}},
+			{ID: github.Ptr(int64(2)), Name: github.Ptr("coverage"), SizeInBytes: github.Ptr(int64(300)), CreatedAt: daysAgo(50), WorkflowRun: &github.ArtifactWorkflowRun{ID: github.Ptr(int64(12)), HeadBranch: github.Ptr("fix")}},
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("binary"), SizeInBytes: github.Ptr(int64(1000)), CreatedAt: daysAgo(60), WorkflowRun: &github.ArtifactWorkflowRun{ID: github.Ptr(int64(11))}},
+			{ID: github.Ptr(int64(0)), Name: github.Ptr("coverage"), SizeInBytes: github.Ptr(int64(500)), CreatedAt: daysAgo(95), Expired: github.Ptr(true)},
+		}}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsByOwnerByRepoByRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/repos/owner/repo/actions/runs/11" {
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, &github.WorkflowRun{Name: github.Ptr("CI")})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposActionsArtifactsByOwnerByRepoByArtifactId,
+			deleteArtifact,
+		),
+	}
+}
+
+func Test_GetArtifactStorageReport(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetArtifactStorageReport(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_artifact_storage_report", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "older_than_days")
+	assert.Contains(t, tool.InputSchema.Properties, "keep_last")
+	assert.Contains(t, tool.InputSchema.Properties, "delete")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	noDelete := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected deletion of %s", r.URL.Path)
+	})
+
+	t.Run("reports the storage used", func(t *testing.T) {
+		_, handler := GetArtifactStorageReport(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(artifactStorageOptions(t, noDelete)...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report artifactStorageReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, 4, report.Artifacts)
+		assert.Equal(t, int64(1600), report.Bytes)
+		assert.Equal(t, 1, report.Expired)
+		assert.Equal(t, []workflowArtifactUsage{
+			{Workflow: "(deleted run)", Artifacts: 1, Bytes: 1000},
+			{Workflow: "CI", Artifacts: 3, Bytes: 600},
+		}, report.Workflows)
+		// The newest coverage report is recent, so both old ones are listed; the binary is the last of its name
+		assert.Equal(t, 1, report.KeptOld)
+		require.Len(t, report.Old, 2)
+		assert.Equal(t, int64(2), report.Old[0].ID)
+		assert.Equal(t, "fix", report.Old[0].Branch)
+		assert.Equal(t, 50, report.Old[0].AgeDays)
+		assert.Equal(t, int64(3), report.Old[1].ID)
+		assert.Equal(t, int64(500), report.OldBytes)
+		assert.False(t, report.Old[0].Deleted)
+	})
+
+	t.Run("deletes old artifacts", func(t *testing.T) {
+		var deleted []string
+		deleteArtifact := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/actions/artifacts/"))
+			if strings.HasSuffix(r.URL.Path, "/3") {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Resource not accessible"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+		_, handler := GetArtifactStorageReport(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(artifactStorageOptions(t, deleteArtifact)...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":     "owner",
+			"repo":      "repo",
+			"keep_last": float64(0),
+			"delete":    true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report artifactStorageReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, 0, report.KeptOld)
+		require.Len(t, report.Old, 3)
+		assert.Equal(t, []string{"1", "2", "3"}, deleted)
+		assert.True(t, report.Old[0].Deleted)
+		assert.True(t, report.Old[1].Deleted)
+		assert.False(t, report.Old[2].Deleted)
+		assert.Contains(t, report.Old[2].Error, "Resource not accessible")
+		assert.Equal(t, int64(1300), report.FreedBytes)
+	})
+}
@@ -0,0 +1,385 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultMaxAttachments is the number of attachments fetched when the caller does not say.
+	defaultMaxAttachments = 10
+
+	// maxAttachmentSize is the largest attachment returned, larger ones are listed but not fetched.
+	maxAttachmentSize = 10 << 20
+)
+
+// attachmentURLPattern matches the URLs of files uploaded to issues and pull requests: user-attachments, the older
+// user-images and private-user-images hosts, and repository assets.
+var attachmentURLPattern = regexp.MustCompile(
+	`https?://(?:` +
+		`[^\s()<>"'\[\]]+/user-attachments/(?:assets|files)/` +
+		`|(?:private-)?user-images\.githubusercontent\.com/` +
+		`|[^\s()<>"'\[\]/]+/[^\s()<>"'\[\]/]+/[^\s()<>"'\[\]/]+/assets/\d+/` +
+		`)[^\s()<>"'\[\]]+`,
+)
+
+// issueAttachment describes an attachment found in an issue or pull request.
+type issueAttachment struct {
+	URL      string `json:"url"`
+	Source   string `json:"source"`
+	MIMEType string `json:"mime_type,omitempty"`
+	Size     int    `json:"size,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// findAttachmentURLs returns the attachment URLs in a Markdown body, in order and without duplicates.
+func findAttachmentURLs(body string, seen map[string]bool) []string {
+	var urls []string
+	for _, u := range attachmentURLPattern.FindAllString(body, -1) {
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// fetchAttachment downloads an attachment with the authenticated client, so that attachments of private
+// repositories can be read.
+func fetchAttachment(ctx context.Context, client *github.Client, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Client().Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch attachment: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch attachment: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAttachmentSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if len(data) > maxAttachmentSize {
+		return nil, "", fmt.Errorf("attachment is larger than %d bytes", maxAttachmentSize)
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// GetIssueAttachments creates a tool to fetch the images and files attached to an issue or pull request.
+func GetIssueAttachments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_attachments",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_ATTACHMENTS_DESCRIPTION", "Get the images and files attached to the body and comments of an issue or pull request, such as screenshots. Images are returned as image content, other files as text or base64 encoded blobs, after a JSON list of the attachments found.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_ATTACHMENTS_USER_TITLE", "Get issue attachments"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithBoolean("body_only",
+				mcp.Description("Only look for attachments in the body, not in the comments"),
+			),
+			mcp.WithNumber("max_attachments",
+				mcp.Description(fmt.Sprintf("Maximum number of attachments to fetch (default %d)", defaultMaxAttachments)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetIssueAttachmentsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxAttachments <= 0 {
+				params.MaxAttachments = defaultMaxAttachments
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			issue, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, params.IssueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
+			}
+
+			seen := map[string]bool{}
+			var attachments []issueAttachment
+			for _, u := range findAttachmentURLs(issue.GetBody(), seen) {
+				attachments = append(attachments, issueAttachment{URL: u, Source: "body"})
+			}
+
+			if !params.BodyOnly {
+				opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+				for {
+					comments, resp, err := client.Issues.ListComments(ctx, params.Owner, params.Repo, params.IssueNumber, opts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get issue comments: %w", err)
+					}
+					_ = resp.Body.Close()
+
+					for _, comment := range comments {
+						for _, u := range findAttachmentURLs(comment.GetBody(), seen) {
+							attachments = append(attachments, issueAttachment{
+								URL:    u,
+								Source: fmt.Sprintf("comment %d by %s", comment.GetID(), comment.GetUser().GetLogin()),
+							})
+						}
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+
+			var contents []mcp.Content
+			for i := range attachments {
+				attachment := &attachments[i]
+				if i >= params.MaxAttachments {
+					attachment.Error = "not fetched, max_attachments reached"
+					continue
+				}
+				data, contentType, err := fetchAttachment(ctx, client, attachment.URL)
+				if err != nil {
+					attachment.Error = err.Error()
+					continue
+				}
+				resource := newResourceContents(attachment.URL, path.Base(attachment.URL), data, contentType)
+				attachment.Size = len(data)
+				attachment.MIMEType = resourceMIMEType(resource)
+				contents = append(contents, resourceToolContent(resource))
+			}
+
+			if attachments == nil {
+				attachments = []issueAttachment{}
+			}
+			r, err := json.Marshal(attachments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: append([]mcp.Content{mcp.NewTextContent(string(r))}, contents...),
+			}, nil
+		}
+}
+
+const (
+	// defaultAttachmentsReleaseTag is the release that holds uploaded attachments when the caller does not name one.
+	defaultAttachmentsReleaseTag = "issue-attachments"
+
+	// maxUploadSize is the largest attachment that can be uploaded, the same limit GitHub applies to files attached
+	// in the browser.
+	maxUploadSize = 25 << 20
+)
+
+// attachmentsRelease returns the release holding uploaded attachments, creating it if needed.
+func attachmentsRelease(ctx context.Context, client *github.Client, owner, repo, tag string) (*github.RepositoryRelease, error) {
+	release, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	if err == nil {
+		_ = resp.Body.Close()
+		return release, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get release %s: %w", tag, err)
+	}
+
+	release, resp, err = client.Repositories.CreateRelease(ctx, owner, repo, &github.RepositoryRelease{
+		TagName:    github.Ptr(tag),
+		Name:       github.Ptr("Issue attachments"),
+		Body:       github.Ptr("Files attached to issue and pull request comments."),
+		Prerelease: github.Ptr(true),
+		MakeLatest: github.Ptr("false"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create release %s: %w", tag, err)
+	}
+	_ = resp.Body.Close()
+	return release, nil
+}
+
+// uploadReleaseAsset uploads data as an asset of a release. Assets already uploaded under the same name are reused,
+// so that uploading the same file twice does not fail.
+func uploadReleaseAsset(ctx context.Context, client *github.Client, owner, repo string, release *github.RepositoryRelease, name, mimeType string, data []byte) (*github.ReleaseAsset, error) {
+	for _, asset := range release.Assets {
+		if asset.GetName() == name {
+			return asset, nil
+		}
+	}
+
+	u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?name=%s", owner, repo, release.GetID(), url.QueryEscape(name))
+	req, err := client.NewUploadRequest(u, bytes.NewReader(data), int64(len(data)), mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload request: %w", err)
+	}
+	asset := new(github.ReleaseAsset)
+	resp, err := client.Do(ctx, req, asset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	_ = resp.Body.Close()
+	return asset, nil
+}
+
+// UploadIssueAttachment creates a tool to attach a file to an issue or pull request in a new comment.
+func UploadIssueAttachment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_issue_attachment",
+			mcp.WithDescription(t("TOOL_UPLOAD_ISSUE_ATTACHMENT_DESCRIPTION", "Attach a file, such as a generated chart or a log, to an issue or pull request by posting a comment that embeds it; images are embedded, other files linked. The API cannot upload files to user content the way the browser does, so the file is uploaded as an asset of a pre-release of the repository instead, which requires use_release to be true: the pre-release and its tag (issue-attachments by default) are created in the repository if missing, and stay visible in its releases.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_ISSUE_ATTACHMENT_USER_TITLE", "Upload attachment to issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithString("filename",
+				mcp.Required(),
+				mcp.Description("Name of the file, its extension determines how it is displayed"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Base64 encoded content of the file"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Text of the comment, the attachment is added after it"),
+			),
+			mcp.WithBoolean("use_release",
+				mcp.Description("Allow uploading the file as an asset of a pre-release of the repository, creating the pre-release and its tag if missing. Required, as there is no other way to upload files with the API"),
+			),
+			mcp.WithString("release_tag",
+				mcp.Description(fmt.Sprintf("Tag of the release holding attachments, created if missing (default %s)", defaultAttachmentsReleaseTag)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseUploadIssueAttachmentParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.ReleaseTag == "" {
+				params.ReleaseTag = defaultAttachmentsReleaseTag
+			}
+
+			data, err := base64.StdEncoding.DecodeString(params.Content)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %v", err)), nil
+			}
+			if len(data) == 0 {
+				return mcp.NewToolResultError("content is empty"), nil
+			}
+			if len(data) > maxUploadSize {
+				return mcp.NewToolResultError(fmt.Sprintf("attachment is larger than %d bytes", maxUploadSize)), nil
+			}
+
+			if !params.UseRelease {
+				return mcp.NewToolResultError(fmt.Sprintf("files can only be uploaded with the API as assets of a release: set use_release to true to upload it to the %s pre-release of %s/%s, which is created with its tag if missing", params.ReleaseTag, params.Owner, params.Repo)), nil
+			}
+
+			filename := path.Base(params.Filename)
+			mimeType := detectMIMEType(filename, data, "")
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			release, err := attachmentsRelease(ctx, client, params.Owner, params.Repo, params.ReleaseTag)
+			if err != nil {
+				return nil, err
+			}
+
+			// Asset names are unique per release, so they are prefixed with the issue and a hash of the content
+			sum := sha256.Sum256(data)
+			assetName := fmt.Sprintf("issue-%d-%s-%s", params.IssueNumber, hex.EncodeToString(sum[:4]), filename)
+			asset, err := uploadReleaseAsset(ctx, client, params.Owner, params.Repo, release, assetName, mimeType, data)
+			if err != nil {
+				return nil, err
+			}
+
+			link := fmt.Sprintf("[%s](%s)", filename, asset.GetBrowserDownloadURL())
+			if strings.HasPrefix(mimeType, "image/") {
+				link = "!" + link
+			}
+			body := link
+			if params.Body != "" {
+				body = params.Body + "\n\n" + link
+			}
+
+			comment, resp, err := client.Issues.CreateComment(ctx, params.Owner, params.Repo, params.IssueNumber, &github.IssueComment{Body: github.Ptr(body)})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create comment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				respBody, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create comment: %s", string(respBody))), nil
+			}
+
+			r, err := json.Marshal(struct {
+				Comment       *github.IssueComment `json:"comment"`
+				AttachmentURL string               `json:"attachment_url"`
+				Release       string               `json:"release"`
+			}{
+				Comment:       comment,
+				AttachmentURL: asset.GetBrowserDownloadURL(),
+				Release:       release.GetHTMLURL(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,337 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindAttachmentURLs(t *testing.T) {
+	body := "Broken layout:\n" +
+		"![screenshot](https://github.com/user-attachments/assets/0a1b2c3d-4e5f)\n" +
+		`<img width="400" src="https://user-images.githubusercontent.com/583231/12345-abc.png">` + "\n" +
+		"Logs: [build.log](https://github.com/user-attachments/files/42/build.log)\n" +
+		"Old style: https://github.com/octo-org/repo/assets/583231/9f8e7d6c\n" +
+		"Not an attachment: https://github.com/octo-org/repo/issues/1\n" +
+		"Again: ![screenshot](https://github.com/user-attachments/assets/0a1b2c3d-4e5f)"
+
+	urls := findAttachmentURLs(body, map[string]bool{})
+	assert.Equal(t, []string{
+		"https://github.com/user-attachments/assets/0a1b2c3d-4e5f",
+		"https://user-images.githubusercontent.com/583231/12345-abc.png",
+		"https://github.com/user-attachments/files/42/build.log",
+		"https://github.com/octo-org/repo/assets/583231/9f8e7d6c",
+	}, urls)
+}
+
+func Test_GetIssueAttachments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueAttachments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_attachments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "body_only")
+	assert.Contains(t, tool.InputSchema.Properties, "max_attachments")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockIssue := &github.Issue{
+		Number: github.Ptr(42),
+		Body:   github.Ptr("It looks like this:\n![screenshot](https://github.com/user-attachments/assets/screenshot-id)"),
+	}
+	mockComments := []*github.IssueComment{
+		{
+			ID:   github.Ptr(int64(7)),
+			Body: github.Ptr("Test results: [results.json](https://github.com/user-attachments/files/1/results.json)"),
+			User: &github.User{Login: github.Ptr("octocat")},
+		},
+		{
+			ID:   github.Ptr(int64(8)),
+			Body: github.Ptr("Same here ![screenshot](https://github.com/user-attachments/assets/screenshot-id)"),
+			User: &github.User{Login: github.Ptr("hubot")},
+		},
+	}
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	mockedClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, mockIssue),
+			mock.WithRequestMatch(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, mockComments),
+			mock.WithRequestMatchHandler(
+				mock.EndpointPattern{Pattern: "/user-attachments/assets/screenshot-id", Method: http.MethodGet},
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "image/png")
+					_, _ = w.Write(png)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.EndpointPattern{Pattern: "/user-attachments/files/1/results.json", Method: http.MethodGet},
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"failed":1}`))
+				}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name                string
+		requestArgs         map[string]any
+		expectedAttachments []issueAttachment
+		expectedContents    int
+	}{
+		{
+			name: "fetches attachments of the body and comments",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectedAttachments: []issueAttachment{
+				{URL: "https://github.com/user-attachments/assets/screenshot-id", Source: "body", MIMEType: "image/png", Size: len(png)},
+				{URL: "https://github.com/user-attachments/files/1/results.json", Source: "comment 7 by octocat", MIMEType: "application/json", Size: 12},
+			},
+			expectedContents: 2,
+		},
+		{
+			name: "body only",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"body_only":    true,
+			},
+			expectedAttachments: []issueAttachment{
+				{URL: "https://github.com/user-attachments/assets/screenshot-id", Source: "body", MIMEType: "image/png", Size: len(png)},
+			},
+			expectedContents: 1,
+		},
+		{
+			name: "attachments over the limit are listed but not fetched",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"issue_number":    float64(42),
+				"max_attachments": float64(1),
+			},
+			expectedAttachments: []issueAttachment{
+				{URL: "https://github.com/user-attachments/assets/screenshot-id", Source: "body", MIMEType: "image/png", Size: len(png)},
+				{URL: "https://github.com/user-attachments/files/1/results.json", Source: "comment 7 by octocat", Error: "not fetched, max_attachments reached"},
+			},
+			expectedContents: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetIssueAttachments(stubGetClientFn(github.NewClient(mockedClient())), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			require.Len(t, result.Content, tc.expectedContents+1)
+
+			summary, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			var attachments []issueAttachment
+			require.NoError(t, json.Unmarshal([]byte(summary.Text), &attachments))
+			assert.Equal(t, tc.expectedAttachments, attachments)
+
+			image, ok := result.Content[1].(mcp.ImageContent)
+			require.True(t, ok)
+			assert.Equal(t, "image/png", image.MIMEType)
+
+			if tc.expectedContents > 1 {
+				results, ok := result.Content[2].(mcp.TextContent)
+				require.True(t, ok)
+				assert.Equal(t, `{"failed":1}`, results.Text)
+			}
+		})
+	}
+}
+
+func Test_UploadIssueAttachment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UploadIssueAttachment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "upload_issue_attachment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "filename")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "use_release")
+	assert.Contains(t, tool.InputSchema.Properties, "release_tag")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "filename", "content"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	encoded := base64.StdEncoding.EncodeToString(png)
+	// The asset name carries the first bytes of the SHA-256 of the content
+	sum := sha256.Sum256(png)
+	assetName := "issue-42-" + hex.EncodeToString(sum[:4]) + "-chart.png"
+	assetURL := "https://github.com/owner/repo/releases/download/issue-attachments/" + assetName
+
+	release := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(1)),
+		TagName: github.Ptr("issue-attachments"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/issue-attachments"),
+	}
+	uploadAsset := mock.WithRequestMatchHandler(
+		mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, assetName, r.URL.Query().Get("name"))
+			assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, png, body)
+			mockResponse(t, http.StatusCreated, &github.ReleaseAsset{
+				Name:               github.Ptr(assetName),
+				BrowserDownloadURL: github.Ptr(assetURL),
+			})(w, r)
+		}),
+	)
+	createComment := func(expectedBody string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			expectRequestBody(t, map[string]any{"body": expectedBody}).andThen(
+				mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(99)), Body: github.Ptr(expectedBody)}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedComment    string
+	}{
+		{
+			name: "creates the release and embeds the image",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":    "issue-attachments",
+						"name":        "Issue attachments",
+						"body":        "Files attached to issue and pull request comments.",
+						"prerelease":  true,
+						"make_latest": "false",
+					}).andThen(mockResponse(t, http.StatusCreated, release)),
+				),
+				uploadAsset,
+				createComment("Here is the chart\n\n![chart.png]("+assetURL+")"),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"filename":     "chart.png",
+				"content":      encoded,
+				"body":         "Here is the chart",
+				"use_release":  true,
+			},
+			expectedComment: "Here is the chart\n\n![chart.png](" + assetURL + ")",
+		},
+		{
+			name: "reuses an asset already uploaded",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					&github.RepositoryRelease{
+						ID:      github.Ptr(int64(1)),
+						HTMLURL: release.HTMLURL,
+						Assets: []*github.ReleaseAsset{
+							{Name: github.Ptr(assetName), BrowserDownloadURL: github.Ptr(assetURL)},
+						},
+					},
+				),
+				createComment("![chart.png]("+assetURL+")"),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"filename":     "chart.png",
+				"content":      encoded,
+				"use_release":  true,
+			},
+			expectedComment: "![chart.png](" + assetURL + ")",
+		},
+		{
+			name:         "requires use_release",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"filename":     "chart.png",
+				"content":      encoded,
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "set use_release to true to upload it to the issue-attachments pre-release of owner/repo",
+		},
+		{
+			name:         "invalid base64",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"filename":     "chart.png",
+				"content":      "not base64!",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "content is not valid base64",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := UploadIssueAttachment(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returned struct {
+				Comment       github.IssueComment `json:"comment"`
+				AttachmentURL string              `json:"attachment_url"`
+				Release       string              `json:"release"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedComment, returned.Comment.GetBody())
+			assert.Equal(t, assetURL, returned.AttachmentURL)
+			assert.Equal(t, release.GetHTMLURL(), returned.Release)
+		})
+	}
+}
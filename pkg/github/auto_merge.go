@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// autoMergeRequestNode is the auto-merge request of a pull request, null when auto-merge is disabled.
+type autoMergeRequestNode struct {
+	EnabledAt githubv4.DateTime
+	EnabledBy struct {
+		Login githubv4.String
+	}
+	MergeMethod githubv4.PullRequestMergeMethod
+}
+
+// autoMergePullRequestNode is the pull request returned by the auto-merge mutations.
+type autoMergePullRequestNode struct {
+	Number           githubv4.Int
+	URL              githubv4.URI
+	AutoMergeRequest *autoMergeRequestNode
+}
+
+// autoMergeStatus is the auto-merge state of a pull request returned by the auto-merge tools.
+type autoMergeStatus struct {
+	PullNumber  int    `json:"pull_number"`
+	URL         string `json:"url"`
+	Enabled     bool   `json:"enabled"`
+	MergeMethod string `json:"merge_method,omitempty"`
+	EnabledBy   string `json:"enabled_by,omitempty"`
+	EnabledAt   string `json:"enabled_at,omitempty"`
+}
+
+func newAutoMergeStatus(node autoMergePullRequestNode) autoMergeStatus {
+	status := autoMergeStatus{
+		PullNumber: int(node.Number),
+		URL:        node.URL.String(),
+	}
+	if request := node.AutoMergeRequest; request != nil {
+		status.Enabled = true
+		status.MergeMethod = strings.ToLower(string(request.MergeMethod))
+		status.EnabledBy = string(request.EnabledBy.Login)
+		status.EnabledAt = request.EnabledAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return status
+}
+
+// pullRequestNodeID looks up the GraphQL node ID of a pull request, which the mutations on pull requests take.
+func pullRequestNodeID(ctx context.Context, client *githubv4.Client, owner, repo string, number int) (githubv4.ID, error) {
+	var query struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	if err := client.Query(ctx, &query, map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(int32(number)), // #nosec G115 - pull request numbers are well below 2^31
+	}); err != nil {
+		return nil, err
+	}
+	return query.Repository.PullRequest.ID, nil
+}
+
+// EnablePullRequestAutoMerge creates a tool to merge a pull request once its requirements are met.
+func EnablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Enable auto-merge on a pull request, so that GitHub merges it once the required reviews and checks pass, without polling its status. The repository must allow auto-merge, and the pull request must still have requirements to meet, otherwise merge it with merge_pull_request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Enable pull request auto-merge"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("merge_method",
+				mcp.Description("Merge method, defaults to merge. Ignored when the base branch uses a merge queue"),
+				mcp.Enum("merge", "squash", "rebase"),
+			),
+			mcp.WithString("commit_title",
+				mcp.Description("Title for the merge commit"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Extra detail for the merge commit"),
+			),
+			mcp.WithString("expected_sha",
+				mcp.Description("SHA the pull request head must match. Auto-merge is refused if new commits were pushed since it was reviewed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseEnablePullRequestAutoMergeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := pullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", err.Error())), nil
+			}
+
+			input := githubv4.EnablePullRequestAutoMergeInput{
+				PullRequestID: id,
+			}
+			if params.MergeMethod != "" {
+				method := githubv4.PullRequestMergeMethod(strings.ToUpper(params.MergeMethod))
+				input.MergeMethod = &method
+			}
+			if params.CommitTitle != "" {
+				input.CommitHeadline = githubv4.NewString(githubv4.String(params.CommitTitle))
+			}
+			if params.CommitMessage != "" {
+				input.CommitBody = githubv4.NewString(githubv4.String(params.CommitMessage))
+			}
+			if params.ExpectedSHA != "" {
+				input.ExpectedHeadOid = githubv4.NewGitObjectID(githubv4.GitObjectID(params.ExpectedSHA))
+			}
+
+			var enable struct {
+				EnablePullRequestAutoMerge struct {
+					PullRequest autoMergePullRequestNode
+				} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &enable, input, nil); err != nil {
+				message := fmt.Sprintf("failed to enable auto-merge: %s", err.Error())
+				if strings.Contains(err.Error(), "clean status") {
+					// GitHub refuses auto-merge for pull requests that can already be merged
+					message += ". The pull request can be merged now, use merge_pull_request"
+				}
+				return mcp.NewToolResultError(message), nil
+			}
+
+			r, err := json.Marshal(newAutoMergeStatus(enable.EnablePullRequestAutoMerge.PullRequest))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DisablePullRequestAutoMerge creates a tool to cancel the auto-merge of a pull request.
+func DisablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Disable auto-merge on a pull request, so that it is no longer merged once its requirements are met.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Disable pull request auto-merge"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDisablePullRequestAutoMergeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := pullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", err.Error())), nil
+			}
+
+			var disable struct {
+				DisablePullRequestAutoMerge struct {
+					PullRequest autoMergePullRequestNode
+				} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &disable, githubv4.DisablePullRequestAutoMergeInput{
+				PullRequestID: id,
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to disable auto-merge: %s", err.Error())), nil
+			}
+
+			r, err := json.Marshal(newAutoMergeStatus(disable.DisablePullRequestAutoMerge.PullRequest))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
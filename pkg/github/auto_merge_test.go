@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pullRequestNodeIDQuery() githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				PullRequest struct {
+					ID githubv4.ID
+				} `graphql:"pullRequest(number: $prNum)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{"id": "PR_42"},
+			},
+		}),
+	)
+}
+
+func Test_EnablePullRequestAutoMerge(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := EnablePullRequestAutoMerge(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "enable_pull_request_auto_merge", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "merge_method")
+	assert.Contains(t, tool.InputSchema.Properties, "commit_title")
+	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	enableMutation := struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest autoMergePullRequestNode
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}{}
+	enabledResponse := githubv4mock.DataResponse(map[string]any{
+		"enablePullRequestAutoMerge": map[string]any{
+			"pullRequest": map[string]any{
+				"number": 42,
+				"url":    "https://github.com/owner/repo/pull/42",
+				"autoMergeRequest": map[string]any{
+					"enabledAt":   "2024-05-01T10:00:00Z",
+					"enabledBy":   map[string]any{"login": "octocat"},
+					"mergeMethod": "SQUASH",
+				},
+			},
+		},
+	})
+	squash := githubv4.PullRequestMergeMethodSquash
+
+	tests := []struct {
+		name           string
+		input          githubv4.EnablePullRequestAutoMergeInput
+		response       githubv4mock.GQLResponse
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "enable with merge method and commit message",
+			input: githubv4.EnablePullRequestAutoMergeInput{
+				PullRequestID:   githubv4.ID("PR_42"),
+				MergeMethod:     &squash,
+				CommitHeadline:  githubv4.NewString("Add feature (#42)"),
+				ExpectedHeadOid: githubv4.NewGitObjectID("abc123"),
+			},
+			response: enabledResponse,
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"merge_method": "squash",
+				"commit_title": "Add feature (#42)",
+				"expected_sha": "abc123",
+			},
+		},
+		{
+			name:           "pull request already mergeable",
+			input:          githubv4.EnablePullRequestAutoMergeInput{PullRequestID: githubv4.ID("PR_42")},
+			response:       githubv4mock.ErrorResponse("Pull request Pull request is in clean status"),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectError:    true,
+			expectedErrMsg: "use merge_pull_request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				pullRequestNodeIDQuery(),
+				githubv4mock.NewMutationMatcher(enableMutation, tc.input, nil, tc.response),
+			))
+			_, handler := EnablePullRequestAutoMerge(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned autoMergeStatus
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, autoMergeStatus{
+				PullNumber:  42,
+				URL:         "https://github.com/owner/repo/pull/42",
+				Enabled:     true,
+				MergeMethod: "squash",
+				EnabledBy:   "octocat",
+				EnabledAt:   "2024-05-01T10:00:00Z",
+			}, returned)
+		})
+	}
+}
+
+func Test_DisablePullRequestAutoMerge(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := DisablePullRequestAutoMerge(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "disable_pull_request_auto_merge", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		pullRequestNodeIDQuery(),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DisablePullRequestAutoMerge struct {
+					PullRequest autoMergePullRequestNode
+				} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+			}{},
+			githubv4.DisablePullRequestAutoMergeInput{PullRequestID: githubv4.ID("PR_42")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"disablePullRequestAutoMerge": map[string]any{
+					"pullRequest": map[string]any{
+						"number":           42,
+						"url":              "https://github.com/owner/repo/pull/42",
+						"autoMergeRequest": nil,
+					},
+				},
+			}),
+		),
+	))
+	_, handler := DisablePullRequestAutoMerge(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, map[string]any{"pull_number": float64(42), "url": "https://github.com/owner/repo/pull/42", "enabled": false}, returned)
+}
@@ -0,0 +1,219 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listAutolinks lists the autolink references of a repository. GitHub returns them all at once.
+func listAutolinks(ctx context.Context, client *github.Client, owner, repo string) ([]*github.Autolink, error) {
+	autolinks, resp, err := client.Repositories.ListAutolinks(ctx, owner, repo, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list autolinks: %w", err)
+	}
+	_ = resp.Body.Close()
+	return autolinks, nil
+}
+
+// findAutolink returns the autolink reference of a repository with the given key prefix, which GitHub compares
+// case-insensitively.
+func findAutolink(autolinks []*github.Autolink, keyPrefix string) *github.Autolink {
+	for _, autolink := range autolinks {
+		if strings.EqualFold(autolink.GetKeyPrefix(), keyPrefix) {
+			return autolink
+		}
+	}
+	return nil
+}
+
+// ListAutolinks creates a tool to list the autolink references of a repository.
+func ListAutolinks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_autolinks",
+			mcp.WithDescription(t("TOOL_LIST_AUTOLINKS_DESCRIPTION", "List the autolink references of a repository, which turn references such as JIRA-123 into links to external systems.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_AUTOLINKS_USER_TITLE", "List autolink references"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListAutolinksParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			autolinks, err := listAutolinks(ctx, client, params.Owner, params.Repo)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(autolinks)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateAutolink creates a tool to add an autolink reference to a repository.
+func CreateAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_autolink",
+			mcp.WithDescription(t("TOOL_CREATE_AUTOLINK_DESCRIPTION", "Add an autolink reference to a repository, turning references such as JIRA-123 into links to an external system. When the repository already has the same autolink, it is returned unchanged, so the call can be repeated across repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_AUTOLINK_USER_TITLE", "Create autolink reference"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("key_prefix",
+				mcp.Required(),
+				mcp.Description("Prefix of the references, e.g. JIRA-"),
+			),
+			mcp.WithString("url_template",
+				mcp.Required(),
+				mcp.Description("URL the references link to, where <num> is replaced by what follows the prefix, e.g. https://jira.example.com/browse/JIRA-<num>"),
+			),
+			mcp.WithBoolean("is_alphanumeric",
+				mcp.Description("Whether what follows the prefix can contain letters, or only digits. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCreateAutolinkParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["is_alphanumeric"]; !ok {
+				params.IsAlphanumeric = true
+			}
+			if !strings.Contains(params.URLTemplate, "<num>") {
+				return mcp.NewToolResultError("url_template must contain <num>"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			autolinks, err := listAutolinks(ctx, client, params.Owner, params.Repo)
+			if err != nil {
+				return nil, err
+			}
+			autolink := findAutolink(autolinks, params.KeyPrefix)
+			switch {
+			case autolink == nil:
+				var resp *github.Response
+				autolink, resp, err = client.Repositories.AddAutolink(ctx, params.Owner, params.Repo, &github.AutolinkOptions{
+					KeyPrefix:      github.Ptr(params.KeyPrefix),
+					URLTemplate:    github.Ptr(params.URLTemplate),
+					IsAlphanumeric: github.Ptr(params.IsAlphanumeric),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create autolink: %w", err)
+				}
+				_ = resp.Body.Close()
+			case autolink.GetURLTemplate() != params.URLTemplate || autolink.GetIsAlphanumeric() != params.IsAlphanumeric:
+				return mcp.NewToolResultError(fmt.Sprintf("%s/%s already has an autolink for %s, linking to %s: delete it first to replace it", params.Owner, params.Repo, autolink.GetKeyPrefix(), autolink.GetURLTemplate())), nil
+			}
+
+			r, err := json.Marshal(autolink)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteAutolink creates a tool to delete an autolink reference of a repository.
+func DeleteAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_autolink",
+			mcp.WithDescription(t("TOOL_DELETE_AUTOLINK_DESCRIPTION", "Delete an autolink reference of a repository, given its ID or its key prefix. Returns the deleted autolink.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_AUTOLINK_USER_TITLE", "Delete autolink reference"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("autolink_id",
+				mcp.Description("ID of the autolink"),
+			),
+			mcp.WithString("key_prefix",
+				mcp.Description("Key prefix of the autolink, instead of its ID"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDeleteAutolinkParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (params.AutolinkID == 0) == (params.KeyPrefix == "") {
+				return mcp.NewToolResultError("exactly one of autolink_id and key_prefix is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var autolink *github.Autolink
+			if params.KeyPrefix != "" {
+				autolinks, err := listAutolinks(ctx, client, params.Owner, params.Repo)
+				if err != nil {
+					return nil, err
+				}
+				if autolink = findAutolink(autolinks, params.KeyPrefix); autolink == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s/%s has no autolink for %s", params.Owner, params.Repo, params.KeyPrefix)), nil
+				}
+			} else {
+				var resp *github.Response
+				autolink, resp, err = client.Repositories.GetAutolink(ctx, params.Owner, params.Repo, int64(params.AutolinkID))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get autolink: %w", err)
+				}
+				_ = resp.Body.Close()
+			}
+
+			resp, err := client.Repositories.DeleteAutolink(ctx, params.Owner, params.Repo, autolink.GetID())
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete autolink: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			r, err := json.Marshal(autolink)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
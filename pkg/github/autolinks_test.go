@@ -0,0 +1,272 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockAutolinks = []*github.Autolink{
+	{
+		ID:             github.Ptr(int64(1)),
+		KeyPrefix:      github.Ptr("JIRA-"),
+		URLTemplate:    github.Ptr("https://jira.example.com/browse/JIRA-<num>"),
+		IsAlphanumeric: github.Ptr(true),
+	},
+	{
+		ID:             github.Ptr(int64(2)),
+		KeyPrefix:      github.Ptr("TICKET-"),
+		URLTemplate:    github.Ptr("https://tickets.example.com/<num>"),
+		IsAlphanumeric: github.Ptr(false),
+	},
+}
+
+func Test_ListAutolinks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAutolinks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_autolinks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposAutolinksByOwnerByRepo, mockAutolinks),
+	))
+	_, handler := ListAutolinks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var autolinks []*github.Autolink
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &autolinks))
+	assert.Equal(t, mockAutolinks, autolinks)
+}
+
+func Test_CreateAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "is_alphanumeric")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "key_prefix", "url_template"})
+
+	listAutolinks := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposAutolinksByOwnerByRepo, mockAutolinks)
+	}
+	created := &github.Autolink{
+		ID:             github.Ptr(int64(3)),
+		KeyPrefix:      github.Ptr("OPS-"),
+		URLTemplate:    github.Ptr("https://ops.example.com/<num>"),
+		IsAlphanumeric: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedAutolink *github.Autolink
+	}{
+		{
+			name: "creates alphanumeric autolink by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				listAutolinks(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"key_prefix":      "OPS-",
+						"url_template":    "https://ops.example.com/<num>",
+						"is_alphanumeric": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, created),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"key_prefix":   "OPS-",
+				"url_template": "https://ops.example.com/<num>",
+			},
+			expectedAutolink: created,
+		},
+		{
+			name:         "returns the existing identical autolink",
+			mockedClient: mock.NewMockedHTTPClient(listAutolinks()),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"key_prefix":      "ticket-",
+				"url_template":    "https://tickets.example.com/<num>",
+				"is_alphanumeric": false,
+			},
+			expectedAutolink: mockAutolinks[1],
+		},
+		{
+			name:         "conflicting autolink",
+			mockedClient: mock.NewMockedHTTPClient(listAutolinks()),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"key_prefix":   "JIRA-",
+				"url_template": "https://jira.example.org/browse/JIRA-<num>",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner/repo already has an autolink for JIRA-, linking to https://jira.example.com/browse/JIRA-<num>",
+		},
+		{
+			name:         "url template without <num>",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"key_prefix":   "OPS-",
+				"url_template": "https://ops.example.com/",
+			},
+			expectError:    true,
+			expectedErrMsg: "url_template must contain <num>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var autolink *github.Autolink
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &autolink))
+			assert.Equal(t, tc.expectedAutolink, autolink)
+		})
+	}
+}
+
+func Test_DeleteAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "autolink_id")
+	assert.Contains(t, tool.InputSchema.Properties, "key_prefix")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	deleteAutolink := func(path string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.DeleteReposAutolinksByOwnerByRepoByAutolinkId,
+			expectPath(t, path).andThen(mockResponse(t, http.StatusNoContent, "")),
+		)
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedAutolink *github.Autolink
+	}{
+		{
+			name: "deletes by key prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposAutolinksByOwnerByRepo, mockAutolinks),
+				deleteAutolink("/repos/owner/repo/autolinks/2"),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"key_prefix": "TICKET-",
+			},
+			expectedAutolink: mockAutolinks[1],
+		},
+		{
+			name: "deletes by ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposAutolinksByOwnerByRepoByAutolinkId, mockAutolinks[0]),
+				deleteAutolink("/repos/owner/repo/autolinks/1"),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"autolink_id": float64(1),
+			},
+			expectedAutolink: mockAutolinks[0],
+		},
+		{
+			name: "unknown key prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposAutolinksByOwnerByRepo, mockAutolinks),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"key_prefix": "OPS-",
+			},
+			expectError:    true,
+			expectedErrMsg: "owner/repo has no autolink for OPS-",
+		},
+		{
+			name:         "both ID and key prefix",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"autolink_id": float64(1),
+				"key_prefix":  "JIRA-",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of autolink_id and key_prefix is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var autolink *github.Autolink
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &autolink))
+			assert.Equal(t, tc.expectedAutolink, autolink)
+		})
+	}
+}
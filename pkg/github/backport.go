@@ -0,0 +1,281 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxBackportCommits bounds the commits of a pull request backport_pull_request cherry-picks.
+const maxBackportCommits = 250
+
+// cherryPick applies the changes of a commit on top of the head of a branch, the way git cherry-pick does. The API
+// has no cherry-pick endpoint, so the branch is first pointed at a sibling of the commit, a commit with the tree of
+// the head and the parent of the commit, into which the commit is merged: the merge tree is the head tree with the
+// changes of the commit. It reports a conflict when the changes do not apply cleanly, and returns a nil commit when
+// they are already on the branch. The branch is left pointing at the new head in both cases, or at the sibling
+// after a conflict.
+func cherryPick(ctx context.Context, client *github.Client, owner, repo, branch string, head *github.Commit, commit *github.RepositoryCommit) (picked *github.Commit, conflict bool, err error) {
+	ref := &github.Reference{Ref: github.Ptr("refs/heads/" + branch)}
+	sibling, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr("Cherry-pick " + commit.GetSHA()),
+		Tree:    &github.Tree{SHA: head.GetTree().SHA},
+		Parents: []*github.Commit{{SHA: commit.Parents[0].SHA}},
+	}, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create commit: %w", err)
+	}
+	_ = resp.Body.Close()
+	ref.Object = &github.GitObject{SHA: sibling.SHA}
+	if _, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, true); err != nil {
+		return nil, false, fmt.Errorf("failed to update branch %s: %w", branch, err)
+	}
+	_ = resp.Body.Close()
+
+	merged, mergeResp, err := client.Repositories.Merge(ctx, owner, repo, &github.RepositoryMergeRequest{
+		Base: github.Ptr(branch),
+		Head: commit.SHA,
+	})
+	if mergeResp != nil {
+		defer func() { _ = mergeResp.Body.Close() }()
+	}
+	if mergeResp != nil && mergeResp.StatusCode == http.StatusConflict {
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to merge %s: %w", commit.GetSHA(), err)
+	}
+
+	if mergeResp.StatusCode == http.StatusNoContent || merged.GetCommit().GetTree().GetSHA() == head.GetTree().GetSHA() {
+		// Nothing to apply, put the branch back
+		ref.Object = &github.GitObject{SHA: head.SHA}
+		if _, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, true); err != nil {
+			return nil, false, fmt.Errorf("failed to update branch %s: %w", branch, err)
+		}
+		_ = resp.Body.Close()
+		return nil, false, nil
+	}
+
+	picked, resp, err = client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr(fmt.Sprintf("%s\n\n(cherry picked from commit %s)", commit.GetCommit().GetMessage(), commit.GetSHA())),
+		Tree:    &github.Tree{SHA: merged.GetCommit().GetTree().SHA},
+		Parents: []*github.Commit{{SHA: head.SHA}},
+		Author:  commit.GetCommit().GetAuthor(),
+	}, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create commit: %w", err)
+	}
+	_ = resp.Body.Close()
+	ref.Object = &github.GitObject{SHA: picked.SHA}
+	if _, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, true); err != nil {
+		return nil, false, fmt.Errorf("failed to update branch %s: %w", branch, err)
+	}
+	_ = resp.Body.Close()
+	return picked, false, nil
+}
+
+// backportTarget is the outcome of backporting a pull request to a branch.
+type backportTarget struct {
+	Label  string `json:"label"`
+	Branch string `json:"branch"`
+	// Status is created, conflict, already_applied or failed.
+	Status         string `json:"status"`
+	BackportBranch string `json:"backport_branch,omitempty"`
+	PullRequest    int    `json:"pull_request,omitempty"`
+	URL            string `json:"url,omitempty"`
+	// ConflictingCommit is the commit whose changes did not apply cleanly.
+	ConflictingCommit string `json:"conflicting_commit,omitempty"`
+	// CommitFiles are the files changed by the conflicting commit, among which are the conflicts.
+	CommitFiles []string `json:"commit_files,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// backportReport is the result of backport_pull_request.
+type backportReport struct {
+	PullRequest int `json:"pull_request"`
+	Commits     int `json:"commits"`
+	// SkippedMergeCommits are merge commits of the pull request, which are not cherry-picked.
+	SkippedMergeCommits []string         `json:"skipped_merge_commits,omitempty"`
+	Targets             []backportTarget `json:"targets"`
+}
+
+// backport cherry-picks commits onto a new branch created from the target branch, and opens a pull request from it.
+func backport(ctx context.Context, client *github.Client, owner, repo string, pr *github.PullRequest, commits []*github.RepositoryCommit, target *backportTarget) error {
+	branch, resp, err := client.Repositories.GetBranch(ctx, owner, repo, target.Branch, 1)
+	if err != nil {
+		return fmt.Errorf("failed to get branch %s: %w", target.Branch, err)
+	}
+	_ = resp.Body.Close()
+
+	target.BackportBranch = fmt.Sprintf("backport-%d-to-%s", pr.GetNumber(), target.Branch)
+	head := branch.GetCommit().GetCommit()
+	head.SHA = branch.GetCommit().SHA
+	_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.Ptr("refs/heads/" + target.BackportBranch),
+		Object: &github.GitObject{SHA: head.SHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", target.BackportBranch, err)
+	}
+	_ = resp.Body.Close()
+
+	deleteBranch := func() {
+		resp, err := client.Git.DeleteRef(ctx, owner, repo, "refs/heads/"+target.BackportBranch)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+	applied := 0
+	for _, commit := range commits {
+		picked, conflict, err := cherryPick(ctx, client, owner, repo, target.BackportBranch, head, commit)
+		if err != nil {
+			deleteBranch()
+			return err
+		}
+		if conflict {
+			deleteBranch()
+			target.Status = "conflict"
+			target.ConflictingCommit = commit.GetSHA()
+			if details, resp, err := client.Repositories.GetCommit(ctx, owner, repo, commit.GetSHA(), nil); err == nil {
+				_ = resp.Body.Close()
+				for _, file := range details.Files {
+					target.CommitFiles = append(target.CommitFiles, file.GetFilename())
+				}
+			}
+			return nil
+		}
+		if picked != nil {
+			head = picked
+			applied++
+		}
+	}
+	if applied == 0 {
+		deleteBranch()
+		target.Status = "already_applied"
+		return nil
+	}
+
+	created, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.Ptr(fmt.Sprintf("[Backport %s] %s", target.Branch, pr.GetTitle())),
+		Head:  github.Ptr(target.BackportBranch),
+		Base:  github.Ptr(target.Branch),
+		Body:  github.Ptr(fmt.Sprintf("Backport of #%d to `%s`.", pr.GetNumber(), target.Branch)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create pull request: %w", err)
+	}
+	_ = resp.Body.Close()
+	target.Status = "created"
+	target.PullRequest = created.GetNumber()
+	target.URL = created.GetHTMLURL()
+	return nil
+}
+
+// BackportPullRequest creates a tool to backport a merged pull request to the branches named by its labels.
+func BackportPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("backport_pull_request",
+			mcp.WithDescription(t("TOOL_BACKPORT_PULL_REQUEST_DESCRIPTION", "Backport a merged pull request to the branches named by its labels, e.g. the label backport-1.2 targets the branch 1.2. For each target, cherry-picks the commits of the pull request onto a new branch created from the target and opens a pull request from it. Targets whose cherry-pick conflicts are reported with the conflicting commit, and left for a manual backport.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BACKPORT_PULL_REQUEST_USER_TITLE", "Backport pull request"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("label_prefix",
+				mcp.Description("Prefix of the labels naming target branches, defaults to backport-"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseBackportPullRequestParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.LabelPrefix == "" {
+				params.LabelPrefix = "backport-"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			if !pr.GetMerged() {
+				return mcp.NewToolResultError(fmt.Sprintf("pull request #%d is not merged", params.PullNumber)), nil
+			}
+
+			report := backportReport{PullRequest: params.PullNumber, Targets: []backportTarget{}}
+			seen := map[string]bool{}
+			for _, label := range pr.Labels {
+				name := label.GetName()
+				branch := strings.TrimPrefix(name, params.LabelPrefix)
+				if branch == name || branch == "" || seen[branch] {
+					continue
+				}
+				seen[branch] = true
+				report.Targets = append(report.Targets, backportTarget{Label: name, Branch: branch})
+			}
+			if len(report.Targets) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("pull request #%d has no label starting with %s", params.PullNumber, params.LabelPrefix)), nil
+			}
+
+			var commits []*github.RepositoryCommit
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				page, resp, err := client.PullRequests.ListCommits(ctx, params.Owner, params.Repo, params.PullNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, commit := range page {
+					report.Commits++
+					if len(commit.Parents) != 1 {
+						report.SkippedMergeCommits = append(report.SkippedMergeCommits, commit.GetSHA())
+						continue
+					}
+					commits = append(commits, commit)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if report.Commits >= maxBackportCommits {
+					return mcp.NewToolResultError(fmt.Sprintf("pull request #%d has more than %d commits", params.PullNumber, maxBackportCommits)), nil
+				}
+				opts.Page = resp.NextPage
+			}
+
+			for i := range report.Targets {
+				if err := backport(ctx, client, params.Owner, params.Repo, pr, commits, &report.Targets[i]); err != nil {
+					report.Targets[i].Status = "failed"
+					report.Targets[i].Error = err.Error()
+				}
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,184 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BackportPullRequest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BackportPullRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "backport_pull_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "label_prefix")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	labels := func(names ...string) []*github.Label {
+		var l []*github.Label
+		for _, name := range names {
+			l = append(l, &github.Label{Name: github.Ptr(name)})
+		}
+		return l
+	}
+	mergedPR := &github.PullRequest{
+		Number: github.Ptr(7),
+		Title:  github.Ptr("Fix crash"),
+		Merged: github.Ptr(true),
+		Labels: labels("bug", "backport-1.x", "backport-2.x"),
+	}
+
+	t.Run("backports to each labeled branch", func(t *testing.T) {
+		var deletedRefs, createdCommits []string
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mergedPR),
+			mock.WithRequestMatch(mock.GetReposPullsCommitsByOwnerByRepoByPullNumber, []*github.RepositoryCommit{
+				{SHA: github.Ptr("fix-sha"), Commit: &github.Commit{Message: github.Ptr("Fix crash")}, Parents: []*github.Commit{{SHA: github.Ptr("base-sha")}}},
+				{SHA: github.Ptr("merge-sha"), Parents: []*github.Commit{{SHA: github.Ptr("fix-sha")}, {SHA: github.Ptr("main-sha")}}},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposBranchesByOwnerByRepoByBranch,
+				mockResponse(t, http.StatusOK, &github.Branch{Commit: &github.RepositoryCommit{
+					SHA:    github.Ptr("release-sha"),
+					Commit: &github.Commit{Tree: &github.Tree{SHA: github.Ptr("release-tree")}},
+				}}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				mockResponse(t, http.StatusCreated, &github.Reference{}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body struct {
+						Message string   `json:"message"`
+						Tree    string   `json:"tree"`
+						Parents []string `json:"parents"`
+					}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					createdCommits = append(createdCommits, body.Tree+" "+body.Parents[0])
+					mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr("new-sha")})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposGitRefsByOwnerByRepoByRef,
+				mockResponse(t, http.StatusOK, &github.Reference{}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposMergesByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body github.RepositoryMergeRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					if body.GetBase() == "backport-7-to-2.x" {
+						mockResponse(t, http.StatusConflict, map[string]string{"message": "Merge conflict"})(w, r)
+						return
+					}
+					mockResponse(t, http.StatusCreated, &github.RepositoryCommit{Commit: &github.Commit{Tree: &github.Tree{SHA: github.Ptr("picked-tree")}}})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposGitRefsByOwnerByRepoByRef,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					deletedRefs = append(deletedRefs, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			),
+			mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, &github.RepositoryCommit{
+				Files: []*github.CommitFile{{Filename: github.Ptr("pkg/server.go")}},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposPullsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"title": "[Backport 1.x] Fix crash",
+					"head":  "backport-7-to-1.x",
+					"base":  "1.x",
+					"body":  "Backport of #7 to `1.x`.",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.PullRequest{
+					Number:  github.Ptr(8),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/pull/8"),
+				})),
+			),
+		)
+		_, handler := BackportPullRequest(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(7),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report backportReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, 2, report.Commits)
+		assert.Equal(t, []string{"merge-sha"}, report.SkippedMergeCommits)
+		assert.Equal(t, []backportTarget{
+			{
+				Label:          "backport-1.x",
+				Branch:         "1.x",
+				Status:         "created",
+				BackportBranch: "backport-7-to-1.x",
+				PullRequest:    8,
+				URL:            "https://github.com/owner/repo/pull/8",
+			},
+			{
+				Label:             "backport-2.x",
+				Branch:            "2.x",
+				Status:            "conflict",
+				BackportBranch:    "backport-7-to-2.x",
+				ConflictingCommit: "fix-sha",
+				CommitFiles:       []string{"pkg/server.go"},
+			},
+		}, report.Targets)
+		// The sibling commit has the release tree and the parent of the fix, the picked one the merged tree on the release
+		assert.Equal(t, []string{"release-tree base-sha", "picked-tree release-sha", "release-tree base-sha"}, createdCommits)
+		assert.Equal(t, []string{"/repos/owner/repo/git/refs/heads/backport-7-to-2.x"}, deletedRefs)
+	})
+
+	t.Run("requires a merged pull request", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{Number: github.Ptr(7), Merged: github.Ptr(false)}),
+		)
+		_, handler := BackportPullRequest(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(7),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "pull request #7 is not merged")
+	})
+
+	t.Run("requires a backport label", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mergedPR),
+		)
+		_, handler := BackportPullRequest(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"pullNumber":   float64(7),
+			"label_prefix": "cherry-pick/",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "pull request #7 has no label starting with cherry-pick/")
+	})
+}
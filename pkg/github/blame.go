@@ -0,0 +1,254 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// defaultSuspectWindow is how far back find_suspect_commits looks when no start of the window is given.
+const defaultSuspectWindow = 30 * 24 * time.Hour
+
+type blameCommit struct {
+	OID             githubv4.GitObjectID `graphql:"oid"`
+	MessageHeadline githubv4.String
+	CommittedDate   githubv4.DateTime
+	URL             githubv4.URI
+	Author          struct {
+		Name githubv4.String
+		User struct {
+			Login githubv4.String
+		}
+	}
+	AssociatedPullRequests struct {
+		Nodes []struct {
+			Number   githubv4.Int
+			Title    githubv4.String
+			URL      githubv4.URI
+			MergedAt *githubv4.DateTime
+		}
+	} `graphql:"associatedPullRequests(first: 1)"`
+}
+
+type blameRange struct {
+	StartingLine githubv4.Int
+	EndingLine   githubv4.Int
+	Commit       blameCommit
+}
+
+type blameQuery struct {
+	Repository struct {
+		Object struct {
+			Commit struct {
+				OID   githubv4.GitObjectID `graphql:"oid"`
+				Blame struct {
+					Ranges []blameRange
+				} `graphql:"blame(path: $path)"`
+			} `graphql:"... on Commit"`
+		} `graphql:"object(expression: $ref)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// suspectPullRequest is the pull request a suspect commit was merged with.
+type suspectPullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	URL      string     `json:"url"`
+	MergedAt *time.Time `json:"merged_at,omitempty"`
+}
+
+// suspectCommit is a commit that last touched some of the lines being investigated.
+type suspectCommit struct {
+	SHA           string              `json:"sha"`
+	Headline      string              `json:"headline,omitempty"`
+	Author        string              `json:"author,omitempty"`
+	AuthorLogin   string              `json:"author_login,omitempty"`
+	CommittedDate time.Time           `json:"committed_date"`
+	URL           string              `json:"url,omitempty"`
+	Lines         [][2]int            `json:"lines"`
+	PullRequest   *suspectPullRequest `json:"pull_request,omitempty"`
+}
+
+// suspectCommitsResult is the result of the find_suspect_commits tool.
+type suspectCommitsResult struct {
+	Path      string    `json:"path"`
+	Commit    string    `json:"commit"`
+	StartLine int       `json:"start_line"`
+	EndLine   int       `json:"end_line"`
+	Since     time.Time `json:"since"`
+	Until     time.Time `json:"until"`
+
+	// Suspects are the commits that touched the lines within the window, most recent first.
+	Suspects []suspectCommit `json:"suspects"`
+	// OutsideWindow are the commits that last touched the other lines, before or after the window.
+	OutsideWindow []suspectCommit `json:"outside_window"`
+}
+
+// collectSuspects groups the blame ranges overlapping the lines from start to end by commit, splitting the commits
+// between those committed within the window and the others, each sorted most recent first.
+func collectSuspects(ranges []blameRange, start, end int, since, until time.Time) (suspects, outside []suspectCommit) {
+	byCommit := make(map[string]*suspectCommit)
+	var order []string
+	for _, r := range ranges {
+		from, to := max(int(r.StartingLine), start), min(int(r.EndingLine), end)
+		if from > to {
+			continue
+		}
+		sha := string(r.Commit.OID)
+		suspect, ok := byCommit[sha]
+		if !ok {
+			suspect = &suspectCommit{
+				SHA:           sha,
+				Headline:      string(r.Commit.MessageHeadline),
+				Author:        string(r.Commit.Author.Name),
+				AuthorLogin:   string(r.Commit.Author.User.Login),
+				CommittedDate: r.Commit.CommittedDate.Time,
+			}
+			if r.Commit.URL.URL != nil {
+				suspect.URL = r.Commit.URL.String()
+			}
+			if prs := r.Commit.AssociatedPullRequests.Nodes; len(prs) > 0 {
+				suspect.PullRequest = &suspectPullRequest{
+					Number: int(prs[0].Number),
+					Title:  string(prs[0].Title),
+				}
+				if prs[0].URL.URL != nil {
+					suspect.PullRequest.URL = prs[0].URL.String()
+				}
+				if prs[0].MergedAt != nil {
+					suspect.PullRequest.MergedAt = &prs[0].MergedAt.Time
+				}
+			}
+			byCommit[sha] = suspect
+			order = append(order, sha)
+		}
+		suspect.Lines = append(suspect.Lines, [2]int{from, to})
+	}
+
+	suspects, outside = []suspectCommit{}, []suspectCommit{}
+	for _, sha := range order {
+		suspect := byCommit[sha]
+		if suspect.CommittedDate.Before(since) || suspect.CommittedDate.After(until) {
+			outside = append(outside, *suspect)
+		} else {
+			suspects = append(suspects, *suspect)
+		}
+	}
+	for _, commits := range [][]suspectCommit{suspects, outside} {
+		sort.SliceStable(commits, func(i, j int) bool {
+			return commits[i].CommittedDate.After(commits[j].CommittedDate)
+		})
+	}
+	return suspects, outside
+}
+
+// FindSuspectCommits creates a tool to find the commits and pull requests that last changed a range of lines.
+func FindSuspectCommits(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("find_suspect_commits",
+			mcp.WithDescription(t("TOOL_FIND_SUSPECT_COMMITS_DESCRIPTION", "Find the commits and pull requests that last changed a range of lines of a file, e.g. the lines of a stack trace, within a time window. Uses git blame, so only the last change of each line is found. Useful to find what caused a regression.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_SUSPECT_COMMITS_USER_TITLE", "Find suspect commits"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Required(),
+				mcp.Description("First line of the range"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line of the range, defaults to start_line"),
+				mcp.Min(1),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to blame, defaults to the default branch"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Start of the window in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD), defaults to 30 days before until"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the window in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD), defaults to now"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseFindSuspectCommitsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.EndLine == 0 {
+				params.EndLine = params.StartLine
+			}
+			if params.StartLine < 1 || params.EndLine < params.StartLine {
+				return mcp.NewToolResultError("start_line must be positive and end_line must not be before start_line"), nil
+			}
+			if params.Ref == "" {
+				params.Ref = "HEAD"
+			}
+			until := time.Now()
+			if params.Until != "" {
+				if until, err = parseISOTimestamp(params.Until); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid until: %s", err)), nil
+				}
+			}
+			since := until.Add(-defaultSuspectWindow)
+			if params.Since != "" {
+				if since, err = parseISOTimestamp(params.Since); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since: %s", err)), nil
+				}
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query blameQuery
+			if err := client.Query(ctx, &query, map[string]any{
+				"owner": githubv4.String(params.Owner),
+				"repo":  githubv4.String(params.Repo),
+				"ref":   githubv4.String(params.Ref),
+				"path":  githubv4.String(params.Path),
+			}); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commit := query.Repository.Object.Commit
+			if commit.OID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("ref %s not found", params.Ref)), nil
+			}
+
+			result := suspectCommitsResult{
+				Path:      params.Path,
+				Commit:    string(commit.OID),
+				StartLine: params.StartLine,
+				EndLine:   params.EndLine,
+				Since:     since,
+				Until:     until,
+			}
+			result.Suspects, result.OutsideWindow = collectSuspects(commit.Blame.Ranges, params.StartLine, params.EndLine, since, until)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal result: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,206 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindSuspectCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := FindSuspectCommits(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "find_suspect_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "start_line"})
+
+	commit := func(sha, date string, pr map[string]any) map[string]any {
+		prs := []any{}
+		if pr != nil {
+			prs = append(prs, pr)
+		}
+		return map[string]any{
+			"oid":             sha,
+			"messageHeadline": "change " + sha,
+			"committedDate":   date,
+			"url":             "https://github.com/owner/repo/commit/" + sha,
+			"author": map[string]any{
+				"name": "Mona",
+				"user": map[string]any{"login": "octocat"},
+			},
+			"associatedPullRequests": map[string]any{"nodes": prs},
+		}
+	}
+	blameResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"oid": "head",
+				"blame": map[string]any{
+					"ranges": []any{
+						map[string]any{"startingLine": 1, "endingLine": 9, "commit": commit("old111", "2023-01-01T00:00:00Z", nil)},
+						map[string]any{"startingLine": 10, "endingLine": 11, "commit": commit("aaa111", "2024-03-01T00:00:00Z", map[string]any{
+							"number":   42,
+							"title":    "Rewrite parser",
+							"url":      "https://github.com/owner/repo/pull/42",
+							"mergedAt": "2024-03-02T00:00:00Z",
+						})},
+						map[string]any{"startingLine": 12, "endingLine": 12, "commit": commit("bbb222", "2024-03-10T00:00:00Z", nil)},
+						map[string]any{"startingLine": 13, "endingLine": 14, "commit": commit("aaa111", "2024-03-01T00:00:00Z", nil)},
+						map[string]any{"startingLine": 15, "endingLine": 30, "commit": commit("old111", "2023-01-01T00:00:00Z", nil)},
+					},
+				},
+			},
+		},
+	})
+	blameMatcher := func(ref string, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			blameQuery{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"ref":   githubv4.String(ref),
+				"path":  githubv4.String("pkg/parser.go"),
+			},
+			response,
+		)
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedSuspects []suspectCommit
+		expectedOutside  []string
+	}{
+		{
+			name:         "commits touching the lines within the window",
+			mockedClient: githubv4mock.NewMockedHTTPClient(blameMatcher("HEAD", blameResponse)),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "pkg/parser.go",
+				"start_line": float64(8),
+				"end_line":   float64(13),
+				"since":      "2024-02-15",
+				"until":      "2024-03-31",
+			},
+			expectedSuspects: []suspectCommit{
+				{
+					SHA:           "bbb222",
+					Headline:      "change bbb222",
+					Author:        "Mona",
+					AuthorLogin:   "octocat",
+					CommittedDate: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+					URL:           "https://github.com/owner/repo/commit/bbb222",
+					Lines:         [][2]int{{12, 12}},
+				},
+				{
+					SHA:           "aaa111",
+					Headline:      "change aaa111",
+					Author:        "Mona",
+					AuthorLogin:   "octocat",
+					CommittedDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+					URL:           "https://github.com/owner/repo/commit/aaa111",
+					Lines:         [][2]int{{10, 11}, {13, 13}},
+					PullRequest: &suspectPullRequest{
+						Number:   42,
+						Title:    "Rewrite parser",
+						URL:      "https://github.com/owner/repo/pull/42",
+						MergedAt: github.Ptr(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			expectedOutside: []string{"old111"},
+		},
+		{
+			name:         "single line at a ref before the window",
+			mockedClient: githubv4mock.NewMockedHTTPClient(blameMatcher("v1.0", blameResponse)),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "pkg/parser.go",
+				"start_line": float64(12),
+				"ref":        "v1.0",
+				"since":      "2024-03-15",
+				"until":      "2024-03-31",
+			},
+			expectedSuspects: []suspectCommit{},
+			expectedOutside:  []string{"bbb222"},
+		},
+		{
+			name:         "end line before start line",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "pkg/parser.go",
+				"start_line": float64(12),
+				"end_line":   float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "end_line must not be before start_line",
+		},
+		{
+			name: "blame fails",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				blameMatcher("HEAD", githubv4mock.ErrorResponse("Could not resolve file for path 'pkg/parser.go'.")),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "pkg/parser.go",
+				"start_line": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "Could not resolve file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := FindSuspectCommits(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned suspectCommitsResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "head", returned.Commit)
+			assert.Equal(t, tc.expectedSuspects, returned.Suspects)
+			var outside []string
+			for _, c := range returned.OutsideWindow {
+				outside = append(outside, c.SHA)
+			}
+			assert.Equal(t, tc.expectedOutside, outside)
+		})
+	}
+}
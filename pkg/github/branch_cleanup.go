@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxCheckedBranches bounds the number of branches find_merged_branches compares with the base branch.
+const maxCheckedBranches = 500
+
+// mergedBranch is a branch whose commits are all in the base branch.
+type mergedBranch struct {
+	Name         string    `json:"name"`
+	SHA          string    `json:"sha"`
+	LastCommitAt time.Time `json:"last_commit_at"`
+	AgeDays      int       `json:"age_days"`
+	Deleted      bool      `json:"deleted,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// mergedBranchesReport is the result of find_merged_branches.
+type mergedBranchesReport struct {
+	Base             string         `json:"base"`
+	BranchesChecked  int            `json:"branches_checked"`
+	ProtectedSkipped int            `json:"protected_skipped"`
+	ExcludedSkipped  int            `json:"excluded_skipped"`
+	Merged           []mergedBranch `json:"merged"`
+	Warnings         []string       `json:"warnings,omitempty"`
+}
+
+// FindMergedBranches creates a tool to list the branches of a repository that are fully merged into the base branch,
+// and optionally delete them.
+func FindMergedBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_merged_branches",
+			mcp.WithDescription(t("TOOL_FIND_MERGED_BRANCHES_DESCRIPTION", "List the branches of a repository whose commits are all in the base branch, with the date and age of their last commit. Protected branches and branches matching the exclude patterns are never listed. Branches merged by squash or rebase have different commits than the base branch and are not found. With delete, also deletes the listed branches and reports the result for each.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_FIND_MERGED_BRANCHES_USER_TITLE", "Find merged branches"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch the branches are merged into, defaults to the default branch"),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Glob patterns of branches to keep, e.g. release/*, where * does not match a slash"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("min_age_days",
+				mcp.Description("Only list branches whose last commit is at least this many days old"),
+				mcp.Min(0),
+			),
+			mcp.WithBoolean("delete",
+				mcp.Description("Delete the listed branches"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseFindMergedBranchesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, pattern := range params.Exclude {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid exclude pattern %q: %s", pattern, err)), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if params.Base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				params.Base = repository.GetDefaultBranch()
+			}
+
+			report := mergedBranchesReport{Base: params.Base, Merged: []mergedBranch{}}
+			var branches []*github.Branch
+			opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				page, resp, err := client.Repositories.ListBranches(ctx, params.Owner, params.Repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list branches: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, branch := range page {
+					switch {
+					case branch.GetName() == params.Base:
+					case branch.GetProtected():
+						report.ProtectedSkipped++
+					case matchesBranchPattern(branch.GetName(), params.Exclude):
+						report.ExcludedSkipped++
+					default:
+						branches = append(branches, branch)
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(branches) >= maxCheckedBranches {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("only the first %d branches were checked", maxCheckedBranches))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if len(branches) > maxCheckedBranches {
+				branches = branches[:maxCheckedBranches]
+			}
+
+			now := time.Now()
+			for _, branch := range branches {
+				report.BranchesChecked++
+				sha := branch.GetCommit().GetSHA()
+				// Only the counts are needed, not the commits
+				comparison, resp, err := client.Repositories.CompareCommits(ctx, params.Owner, params.Repo, params.Base, sha, &github.ListOptions{PerPage: 1})
+				if err != nil {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("failed to compare %s with %s: %s", branch.GetName(), params.Base, err))
+					continue
+				}
+				_ = resp.Body.Close()
+				if comparison.GetAheadBy() > 0 {
+					continue
+				}
+
+				commit, resp, err := client.Git.GetCommit(ctx, params.Owner, params.Repo, sha)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get commit of %s: %w", branch.GetName(), err)
+				}
+				_ = resp.Body.Close()
+				lastCommitAt := commit.GetCommitter().GetDate().Time
+				merged := mergedBranch{
+					Name:         branch.GetName(),
+					SHA:          sha,
+					LastCommitAt: lastCommitAt,
+					AgeDays:      int(now.Sub(lastCommitAt).Hours() / 24),
+				}
+				if merged.AgeDays < params.MinAgeDays {
+					continue
+				}
+
+				if params.Delete {
+					resp, err := client.Git.DeleteRef(ctx, params.Owner, params.Repo, "refs/heads/"+merged.Name)
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					if err != nil {
+						merged.Error = fmt.Sprintf("failed to delete: %s", err)
+					} else {
+						merged.Deleted = true
+					}
+				}
+				report.Merged = append(report.Merged, merged)
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mergedBranchesOptions mocks a repository with a merged branch, two unmerged ones and a protected one, deleting
+// branches with deleteRef.
+func mergedBranchesOptions(t *testing.T, deleteRef http.HandlerFunc) []mock.MockBackendOption {
+	lastCommitAt := time.Now().Add(-45 * 24 * time.Hour)
+	return []mock.MockBackendOption{
+		mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+		mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, []*github.Branch{
+			{Name: github.Ptr("main"), Commit: &github.RepositoryCommit{SHA: github.Ptr("main-sha")}, Protected: github.Ptr(true)},
+			{Name: github.Ptr("merged"), Commit: &github.RepositoryCommit{SHA: github.Ptr("merged-sha")}},
+			{Name: github.Ptr("unmerged"), Commit: &github.RepositoryCommit{SHA: github.Ptr("unmerged-sha")}},
+			{Name: github.Ptr("release/1.0"), Commit: &github.RepositoryCommit{SHA: github.Ptr("release-sha")}},
+			{Name: github.Ptr("gh-pages"), Commit: &github.RepositoryCommit{SHA: github.Ptr("pages-sha")}, Protected: github.Ptr(true)},
+		}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCompareByOwnerByRepoByBasehead,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/owner/repo/compare/main...merged-sha":
+					mockResponse(t, http.StatusOK, &github.CommitsComparison{Status: github.Ptr("behind"), AheadBy: github.Ptr(0), BehindBy: github.Ptr(3)})(w, r)
+				case "/repos/owner/repo/compare/main...unmerged-sha", "/repos/owner/repo/compare/main...release-sha":
+					mockResponse(t, http.StatusOK, &github.CommitsComparison{Status: github.Ptr("diverged"), AheadBy: github.Ptr(2), BehindBy: github.Ptr(1)})(w, r)
+				default:
+					t.Errorf("unexpected comparison %s", r.URL.Path)
+				}
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+			expectPath(t, "/repos/owner/repo/git/commits/merged-sha").andThen(
+				mockResponse(t, http.StatusOK, &github.Commit{
+					SHA:       github.Ptr("merged-sha"),
+					Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: lastCommitAt}},
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{Pattern: "/repos/owner/repo/git/refs/heads/{branch}", Method: http.MethodDelete},
+			deleteRef,
+		),
+	}
+}
+
+func Test_FindMergedBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindMergedBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "find_merged_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude")
+	assert.Contains(t, tool.InputSchema.Properties, "min_age_days")
+	assert.Contains(t, tool.InputSchema.Properties, "delete")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	noDelete := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected deletion of %s", r.URL.Path)
+	})
+
+	t.Run("lists merged branches", func(t *testing.T) {
+		_, handler := FindMergedBranches(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(mergedBranchesOptions(t, noDelete)...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"exclude": []any{"release/*"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report mergedBranchesReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, "main", report.Base)
+		assert.Equal(t, 2, report.BranchesChecked)
+		assert.Equal(t, 1, report.ProtectedSkipped)
+		assert.Equal(t, 1, report.ExcludedSkipped)
+		require.Len(t, report.Merged, 1)
+		assert.Equal(t, "merged", report.Merged[0].Name)
+		assert.Equal(t, "merged-sha", report.Merged[0].SHA)
+		assert.Equal(t, 45, report.Merged[0].AgeDays)
+		assert.False(t, report.Merged[0].Deleted)
+	})
+
+	t.Run("skips recent branches", func(t *testing.T) {
+		_, handler := FindMergedBranches(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(mergedBranchesOptions(t, noDelete)...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"min_age_days": float64(90),
+			"delete":       true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report mergedBranchesReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Empty(t, report.Merged)
+	})
+
+	t.Run("deletes merged branches", func(t *testing.T) {
+		var deleted []string
+		deleteRef := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/git/refs/"))
+			w.WriteHeader(http.StatusNoContent)
+		})
+		_, handler := FindMergedBranches(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(mergedBranchesOptions(t, deleteRef)...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"delete": true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report mergedBranchesReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		require.Len(t, report.Merged, 1)
+		assert.True(t, report.Merged[0].Deleted)
+		assert.Equal(t, []string{"heads/merged"}, deleted)
+	})
+
+	t.Run("reports failed deletions", func(t *testing.T) {
+		deleteRef := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message": "Reference does not exist"}`))
+		})
+		_, handler := FindMergedBranches(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(mergedBranchesOptions(t, deleteRef)...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"delete": true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report mergedBranchesReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		require.Len(t, report.Merged, 1)
+		assert.False(t, report.Merged[0].Deleted)
+		assert.Contains(t, report.Merged[0].Error, "Reference does not exist")
+	})
+}
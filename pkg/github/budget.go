@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// BudgetConfig caps the activity of a single session. Zero values disable the corresponding limit.
+type BudgetConfig struct {
+	// MaxWriteCalls is the number of write tool calls allowed per session.
+	MaxWriteCalls int
+
+	// MaxRequestsPerMinute is the number of GitHub API requests allowed per session in any one minute window.
+	MaxRequestsPerMinute int
+}
+
+// Enabled reports whether any limit is configured.
+func (c BudgetConfig) Enabled() bool {
+	return c.MaxWriteCalls > 0 || c.MaxRequestsPerMinute > 0
+}
+
+type sessionUsage struct {
+	writeCalls int
+	// requests holds the times of the API requests made in the last minute, oldest first.
+	requests []time.Time
+}
+
+// SessionBudget enforces per-session limits on write tool calls and API request rates, protecting organizations
+// from runaway agents.
+type SessionBudget struct {
+	cfg BudgetConfig
+
+	mu       sync.Mutex
+	sessions map[string]*sessionUsage
+	now      func() time.Time
+}
+
+// NewSessionBudget creates a budget enforcing the given limits.
+func NewSessionBudget(cfg BudgetConfig) *SessionBudget {
+	return &SessionBudget{
+		cfg:      cfg,
+		sessions: make(map[string]*sessionUsage),
+		now:      time.Now,
+	}
+}
+
+// SetConfig changes the enforced limits, keeping the usage already recorded for each session.
+func (b *SessionBudget) SetConfig(cfg BudgetConfig) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = cfg
+}
+
+// config returns the enforced limits.
+func (b *SessionBudget) config() BudgetConfig {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg
+}
+
+// usage returns the usage of a session, pruning requests that fell out of the rate window. It must be called with
+// the lock held.
+func (b *SessionBudget) usage(key string) *sessionUsage {
+	u, ok := b.sessions[key]
+	if !ok {
+		u = &sessionUsage{}
+		b.sessions[key] = u
+	}
+	cutoff := b.now().Add(-time.Minute)
+	i := 0
+	for i < len(u.requests) && !u.requests[i].After(cutoff) {
+		i++
+	}
+	u.requests = u.requests[i:]
+	return u
+}
+
+// Forget drops the usage of a session, typically once it has ended.
+func (b *SessionBudget) Forget(sessionID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.sessions, sessionID)
+}
+
+// budgetExhausted builds the error returned to the model when a limit is reached.
+func budgetExhausted(reason string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(budgetExhaustedMessage(reason))
+}
+
+func budgetExhaustedMessage(reason string) string {
+	return fmt.Sprintf("session budget exhausted: %s. Stop and ask the user to confirm whether to continue before making further calls", reason)
+}
+
+// rateExhaustedError is returned by the budget transport for requests beyond the session's API request rate.
+type rateExhaustedError struct {
+	reason string
+}
+
+func (e *rateExhaustedError) Error() string {
+	return budgetExhaustedMessage(e.reason)
+}
+
+// rateExhausted returns why the session has used up its API request rate, or an empty string if it has not. It must
+// be called with the lock held.
+func (b *SessionBudget) rateExhausted(u *sessionUsage) string {
+	if b.cfg.MaxRequestsPerMinute <= 0 || len(u.requests) < b.cfg.MaxRequestsPerMinute {
+		return ""
+	}
+	retryIn := u.requests[0].Add(time.Minute).Sub(b.now()).Round(time.Second)
+	return fmt.Sprintf("%d GitHub API requests were made in the last minute, the limit is %d; capacity frees up in %s", len(u.requests), b.cfg.MaxRequestsPerMinute, retryIn)
+}
+
+// checkRate returns an error result if the session has used up its API request rate.
+func (b *SessionBudget) checkRate(ctx context.Context) *mcp.CallToolResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.cfg.MaxRequestsPerMinute <= 0 {
+		return nil
+	}
+	if reason := b.rateExhausted(b.usage(sessionKey(ctx))); reason != "" {
+		return budgetExhausted(reason)
+	}
+	return nil
+}
+
+// LimitRate wraps a tool so that it refuses to run while the session has used up its API request rate, and reports
+// the budget as exhausted when the rate runs out while the tool runs.
+func (b *SessionBudget) LimitRate(st server.ServerTool) server.ServerTool {
+	next := st.Handler
+	st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if result := b.checkRate(ctx); result != nil {
+			return result, nil
+		}
+		result, err := next(ctx, request)
+		var exhausted *rateExhaustedError
+		if errors.As(err, &exhausted) {
+			return budgetExhausted(exhausted.reason), nil
+		}
+		return result, err
+	}
+	return st
+}
+
+// LimitWrites wraps a write tool so that it counts towards, and is refused beyond, the session's write budget.
+func (b *SessionBudget) LimitWrites(st server.ServerTool) server.ServerTool {
+	if b.config().MaxWriteCalls <= 0 {
+		return st
+	}
+
+	next := st.Handler
+	st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		b.mu.Lock()
+		if b.cfg.MaxWriteCalls <= 0 {
+			b.mu.Unlock()
+			return next(ctx, request)
+		}
+		u := b.usage(sessionKey(ctx))
+		if u.writeCalls >= b.cfg.MaxWriteCalls {
+			b.mu.Unlock()
+			return budgetExhausted(fmt.Sprintf("the limit of %d write calls for this session has been reached", b.cfg.MaxWriteCalls)), nil
+		}
+		u.writeCalls++
+		b.mu.Unlock()
+
+		return next(ctx, request)
+	}
+	return st
+}
+
+// Transport wraps an HTTP transport so that the API requests made on behalf of a session are counted against its
+// request rate, and refused once it is used up.
+func (b *SessionBudget) Transport(next http.RoundTripper) http.RoundTripper {
+	return &budgetTransport{budget: b, next: next}
+}
+
+type budgetTransport struct {
+	budget *SessionBudget
+	next   http.RoundTripper
+}
+
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.budget.mu.Lock()
+	if t.budget.cfg.MaxRequestsPerMinute > 0 {
+		u := t.budget.usage(sessionKey(req.Context()))
+		if reason := t.budget.rateExhausted(u); reason != "" {
+			t.budget.mu.Unlock()
+			if req.Body != nil {
+				_ = req.Body.Close()
+			}
+			return nil, &rateExhaustedError{reason: reason}
+		}
+		u.requests = append(u.requests, t.budget.now())
+	}
+	t.budget.mu.Unlock()
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func okTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("do_thing"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("done"), nil
+		},
+	}
+}
+
+func Test_SessionBudget_LimitWrites(t *testing.T) {
+	budget := NewSessionBudget(BudgetConfig{MaxWriteCalls: 2})
+	st := budget.LimitWrites(okTool())
+	request := createMCPRequest(map[string]any{})
+
+	for i := 0; i < 2; i++ {
+		result, err := st.Handler(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+	}
+
+	result, err := st.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "session budget exhausted: the limit of 2 write calls for this session has been reached")
+
+	// A new session starts with a fresh budget
+	budget.Forget("")
+	result, err = st.Handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func Test_SessionBudget_LimitRate(t *testing.T) {
+	budget := NewSessionBudget(BudgetConfig{MaxRequestsPerMinute: 3})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	budget.now = func() time.Time { return now }
+
+	httpClient := &http.Client{Transport: budget.Transport(roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))}
+	st := budget.LimitRate(okTool())
+	request := createMCPRequest(map[string]any{})
+
+	for i := 0; i < 3; i++ {
+		result, err := st.Handler(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		now = now.Add(10 * time.Second)
+	}
+
+	result, err := st.Handler(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "3 GitHub API requests were made in the last minute, the limit is 3; capacity frees up in 30s")
+
+	// Requests older than a minute no longer count
+	now = now.Add(31 * time.Second)
+	result, err = st.Handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func Test_SessionBudget_LimitRate_WithinCall(t *testing.T) {
+	budget := NewSessionBudget(BudgetConfig{MaxRequestsPerMinute: 2})
+	var sent int
+	client := github.NewClient(&http.Client{Transport: budget.Transport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"login":"octocat"}`)), Request: req}, nil
+	}))})
+
+	// A single call making more requests than the limit
+	st := budget.LimitRate(server.ServerTool{
+		Tool: mcp.NewTool("fan_out"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			for i := 0; i < 5; i++ {
+				if _, _, err := client.Users.Get(ctx, "octocat"); err != nil {
+					return nil, fmt.Errorf("failed to get user: %w", err)
+				}
+			}
+			return mcp.NewToolResultText("done"), nil
+		},
+	})
+
+	result, err := st.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "session budget exhausted: 2 GitHub API requests were made in the last minute, the limit is 2")
+	assert.Equal(t, 2, sent)
+}
+
+func Test_BudgetConfig_Enabled(t *testing.T) {
+	assert.False(t, BudgetConfig{}.Enabled())
+	assert.True(t, BudgetConfig{MaxWriteCalls: 1}.Enabled())
+	assert.True(t, BudgetConfig{MaxRequestsPerMinute: 1}.Enabled())
+}
@@ -0,0 +1,282 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxCommentItems bounds the issues and pull requests post_comment_to_items comments on in one call.
+	maxCommentItems = 100
+	// defaultCommentIntervalSeconds paces the comments as GitHub recommends for requests creating content, to stay
+	// clear of its secondary rate limit.
+	defaultCommentIntervalSeconds = 1
+	// maxCommentRetryWait bounds how long a comment refused by the secondary rate limit waits to be retried.
+	maxCommentRetryWait = time.Minute
+	// maxCommentBodyLength is the longest comment GitHub accepts.
+	maxCommentBodyLength = 65536
+)
+
+// commentItem is an issue or pull request post_comment_to_items comments on, which its comment template is
+// executed with.
+type commentItem struct {
+	Owner  string
+	Repo   string
+	Number int
+	// Vars are the variables of the item, used in the template as {{.Vars.name}}.
+	Vars map[string]any
+}
+
+// parseCommentItems validates the items argument of post_comment_to_items, the repository of each defaulting to
+// owner/repo.
+func parseCommentItems(items []any, owner, repo string) ([]commentItem, error) {
+	if len(items) == 0 {
+		return nil, errors.New("items must not be empty")
+	}
+	if len(items) > maxCommentItems {
+		return nil, fmt.Errorf("at most %d items can be commented on at once", maxCommentItems)
+	}
+	parsed := make([]commentItem, 0, len(items))
+	for i, item := range items {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("items[%d] must be an object", i)
+		}
+		number, ok := itemMap["number"].(float64)
+		if !ok || number <= 0 || number != float64(int(number)) {
+			return nil, fmt.Errorf("items[%d] must have a positive integer number", i)
+		}
+		parsedItem := commentItem{Owner: owner, Repo: repo, Number: int(number)}
+		if fullName, ok := itemMap["repository"].(string); ok && fullName != "" {
+			var err error
+			if parsedItem.Owner, parsedItem.Repo, err = splitRepoFullName(fullName); err != nil {
+				return nil, fmt.Errorf("items[%d]: %w", i, err)
+			}
+		}
+		if vars, ok := itemMap["vars"]; ok {
+			if parsedItem.Vars, ok = vars.(map[string]any); !ok {
+				return nil, fmt.Errorf("items[%d].vars must be an object", i)
+			}
+		}
+		parsed = append(parsed, parsedItem)
+	}
+	return parsed, nil
+}
+
+// renderComment renders the comment of an item, failing on variables the item does not set rather than posting
+// "<no value>".
+func renderComment(tmpl *template.Template, item commentItem) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, item); err != nil {
+		return "", err
+	}
+	if b.Len() > maxCommentBodyLength {
+		return "", fmt.Errorf("the comment is %d characters long, longer than the %d GitHub accepts", b.Len(), maxCommentBodyLength)
+	}
+	if strings.TrimSpace(b.String()) == "" {
+		return "", errors.New("the comment is empty")
+	}
+	return b.String(), nil
+}
+
+// waitOrCancel pauses for d, returning early with the error of ctx when it is cancelled.
+func waitOrCancel(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// postComment posts a comment, retrying it once when GitHub asks to slow down for its secondary rate limit.
+func postComment(ctx context.Context, client *github.Client, item commentItem, body string) (*github.IssueComment, error) {
+	for attempt := 0; ; attempt++ {
+		comment, resp, err := client.Issues.CreateComment(ctx, item.Owner, item.Repo, item.Number, &github.IssueComment{Body: github.Ptr(body)})
+		var abuseErr *github.AbuseRateLimitError
+		if attempt == 0 && errors.As(err, &abuseErr) {
+			// Without a Retry-After header, GitHub asks to wait at least a minute
+			retryAfter := maxCommentRetryWait
+			if abuseErr.RetryAfter != nil {
+				retryAfter = *abuseErr.RetryAfter
+			}
+			if retryAfter <= maxCommentRetryWait {
+				if err := waitOrCancel(ctx, retryAfter); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		return comment, nil
+	}
+}
+
+// itemComment is the outcome of post_comment_to_items for one item.
+type itemComment struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	// Status is posted, failed, skipped after a rate limit or cancellation, or rendered for dry runs.
+	Status    string `json:"status"`
+	CommentID int64  `json:"comment_id,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Body      string `json:"body,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// itemComments is the result of post_comment_to_items.
+type itemComments struct {
+	Posted int           `json:"posted"`
+	Failed int           `json:"failed"`
+	Items  []itemComment `json:"items"`
+}
+
+// PostCommentToItems creates a tool to post a comment rendered from a template to several issues and pull
+// requests, such as an announcement or a migration notice.
+func PostCommentToItems(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("post_comment_to_items",
+			mcp.WithDescription(t("TOOL_POST_COMMENT_TO_ITEMS_DESCRIPTION", fmt.Sprintf("Post a comment rendered from a Go template to up to %d issues or pull requests, e.g. for announcements or migration notices. The template is executed for each item with {{.Owner}}, {{.Repo}}, {{.Number}} and the item's own variables as {{.Vars.name}}; every comment is rendered before any is posted. Comments are posted one at a time, paced to stay clear of GitHub's secondary rate limit; use dry_run to preview them.", maxCommentItems))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_POST_COMMENT_TO_ITEMS_USER_TITLE", "Post comment to issues and pull requests"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repository of the items"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository of the items, unless an item sets its own"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Go template of the comment, e.g. \"This moves to {{.Vars.new_repo}}, see #{{.Number}} there\""),
+			),
+			mcp.WithArray("items",
+				mcp.Required(),
+				mcp.Items(
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"number"},
+						"properties": map[string]any{
+							"number": map[string]any{
+								"type":        "number",
+								"description": "issue or pull request number",
+							},
+							"repository": map[string]any{
+								"type":        "string",
+								"description": "repository of the item as owner/repo, defaults to owner and repo",
+							},
+							"vars": map[string]any{
+								"type":        "object",
+								"description": "variables of the item, used in the template as {{.Vars.name}}",
+							},
+						},
+					}),
+				mcp.Description(fmt.Sprintf("Issues and pull requests to comment on, at most %d", maxCommentItems)),
+			),
+			mcp.WithNumber("interval_seconds",
+				mcp.Description(fmt.Sprintf("Seconds to wait between two comments, defaults to %d", defaultCommentIntervalSeconds)),
+				mcp.Min(0),
+				mcp.Max(60),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Return the rendered comments without posting them"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parsePostCommentToItemsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			items, err := parseCommentItems(params.Items, params.Owner, params.Repo)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tmpl, err := template.New("comment").Option("missingkey=error").Parse(params.Body)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid body template: %v", err)), nil
+			}
+			if _, ok := request.GetArguments()["interval_seconds"]; !ok {
+				params.IntervalSeconds = defaultCommentIntervalSeconds
+			}
+			interval := time.Duration(params.IntervalSeconds) * time.Second
+
+			result := itemComments{Items: make([]itemComment, 0, len(items))}
+			bodies := make([]string, len(items))
+			for i, item := range items {
+				if bodies[i], err = renderComment(tmpl, item); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to render the comment of %s/%s#%d: %v", item.Owner, item.Repo, item.Number, err)), nil
+				}
+				if params.DryRun {
+					result.Items = append(result.Items, itemComment{Repository: item.Owner + "/" + item.Repo, Number: item.Number, Status: "rendered", Body: bodies[i]})
+				}
+			}
+			if params.DryRun {
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			progress := newProgressReporter(ctx, request, len(items))
+			var stopped error
+			for i, item := range items {
+				outcome := itemComment{Repository: item.Owner + "/" + item.Repo, Number: item.Number}
+				if stopped == nil && i > 0 && interval > 0 {
+					stopped = waitOrCancel(ctx, interval)
+				}
+				// Once out of rate limit or cancelled, the items left are reported so that a retry can target them
+				if stopped != nil {
+					outcome.Status = "skipped"
+					outcome.Error = stopped.Error()
+					result.Items = append(result.Items, outcome)
+					continue
+				}
+				comment, err := postComment(ctx, client, item, bodies[i])
+				if err != nil {
+					outcome.Status = "failed"
+					outcome.Error = err.Error()
+					result.Failed++
+					if isRateLimitError(err) || ctx.Err() != nil {
+						stopped = err
+					}
+				} else {
+					outcome.Status = "posted"
+					outcome.CommentID = comment.GetID()
+					outcome.URL = comment.GetHTMLURL()
+					result.Posted++
+				}
+				result.Items = append(result.Items, outcome)
+				progress.step(ctx, 1, fmt.Sprintf("%s#%d: %s", outcome.Repository, item.Number, outcome.Status))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
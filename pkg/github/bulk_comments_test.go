@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PostCommentToItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PostCommentToItems(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "post_comment_to_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "items")
+	assert.Contains(t, tool.InputSchema.Properties, "interval_seconds")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "body", "items"})
+
+	args := func(extra map[string]any) map[string]any {
+		a := map[string]any{
+			"owner": "octo-org",
+			"repo":  "legacy",
+			"body":  "This project moves to {{.Vars.target}}, please follow {{.Repo}}#{{.Number}} there.",
+			"items": []any{
+				map[string]any{"number": float64(1), "vars": map[string]any{"target": "octo-org/api"}},
+				map[string]any{"number": float64(2), "repository": "octo-org/tools", "vars": map[string]any{"target": "octo-org/cli"}},
+				map[string]any{"number": float64(3), "vars": map[string]any{"target": "octo-org/web"}},
+			},
+			"interval_seconds": float64(0),
+		}
+		for k, v := range extra {
+			a[k] = v
+		}
+		return a
+	}
+	call := func(t *testing.T, client *github.Client, args map[string]any) itemComments {
+		_, handler := PostCommentToItems(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		var comments itemComments
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comments))
+		return comments
+	}
+	// postComments mocks the comment creation, answering each call with the next response.
+	postComments := func(responses ...http.HandlerFunc) *github.Client {
+		var calls atomic.Int32
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					responses[calls.Add(1)-1](w, r)
+				}),
+			),
+		))
+	}
+	posted := func(w http.ResponseWriter, r *http.Request) {
+		// The path ends with /issues/{number}/comments
+		parts := strings.Split(r.URL.Path, "/")
+		number := parts[len(parts)-2]
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"html_url": "https://github.com/octo-org/x/issues/%s#issuecomment-1"}`, number)
+	}
+
+	t.Run("dry run renders the comments", func(t *testing.T) {
+		comments := call(t, github.NewClient(nil), args(map[string]any{"dry_run": true}))
+		assert.Equal(t, 0, comments.Posted)
+		assert.Equal(t, []itemComment{
+			{Repository: "octo-org/legacy", Number: 1, Status: "rendered", Body: "This project moves to octo-org/api, please follow legacy#1 there."},
+			{Repository: "octo-org/tools", Number: 2, Status: "rendered", Body: "This project moves to octo-org/cli, please follow tools#2 there."},
+			{Repository: "octo-org/legacy", Number: 3, Status: "rendered", Body: "This project moves to octo-org/web, please follow legacy#3 there."},
+		}, comments.Items)
+	})
+
+	t.Run("comments are posted and failures reported", func(t *testing.T) {
+		client := postComments(posted, mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`), posted)
+		comments := call(t, client, args(nil))
+		assert.Equal(t, 2, comments.Posted)
+		assert.Equal(t, 1, comments.Failed)
+		require.Len(t, comments.Items, 3)
+		assert.Equal(t, "posted", comments.Items[0].Status)
+		assert.Equal(t, "https://github.com/octo-org/x/issues/1#issuecomment-1", comments.Items[0].URL)
+		assert.Equal(t, "failed", comments.Items[1].Status)
+		assert.Contains(t, comments.Items[1].Error, "404")
+		assert.Equal(t, "posted", comments.Items[2].Status)
+	})
+
+	t.Run("secondary rate limits are waited for", func(t *testing.T) {
+		secondaryLimit := func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`))
+		}
+		client := postComments(posted, secondaryLimit, posted, posted)
+		comments := call(t, client, args(nil))
+		assert.Equal(t, 3, comments.Posted)
+		assert.Zero(t, comments.Failed)
+	})
+
+	t.Run("items left after the rate limit are skipped", func(t *testing.T) {
+		rateLimited := func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "4102444800")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+		}
+		client := postComments(posted, rateLimited)
+		comments := call(t, client, args(nil))
+		assert.Equal(t, 1, comments.Posted)
+		assert.Equal(t, 1, comments.Failed)
+		require.Len(t, comments.Items, 3)
+		assert.Equal(t, "failed", comments.Items[1].Status)
+		assert.Equal(t, "skipped", comments.Items[2].Status)
+		assert.Contains(t, comments.Items[2].Error, "rate limit")
+	})
+
+	t.Run("no comment is posted when one cannot be rendered", func(t *testing.T) {
+		_, handler := PostCommentToItems(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args(map[string]any{
+			"items": []any{
+				map[string]any{"number": float64(1), "vars": map[string]any{"target": "octo-org/api"}},
+				map[string]any{"number": float64(2)},
+			},
+		})))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "failed to render the comment of octo-org/legacy#2")
+	})
+}
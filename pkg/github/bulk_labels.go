@@ -0,0 +1,210 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxBulkLabelIssues bounds the issues bulk_update_labels updates in one call.
+const maxBulkLabelIssues = 100
+
+// issueLabelUpdate is the outcome of bulk_update_labels for one issue. Added and Removed only list the labels that
+// changed, leaving out those the issue already had or did not have.
+type issueLabelUpdate struct {
+	Number  int      `json:"number"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// bulkLabelUpdate is the result of bulk_update_labels.
+type bulkLabelUpdate struct {
+	Updated   int                `json:"updated"`
+	Unchanged int                `json:"unchanged"`
+	Failed    int                `json:"failed"`
+	Issues    []issueLabelUpdate `json:"issues"`
+}
+
+// parseIssueNumbers validates the issue_numbers argument, leaving out repeated numbers.
+func parseIssueNumbers(numbers []any) ([]int, error) {
+	if len(numbers) > maxBulkLabelIssues {
+		return nil, fmt.Errorf("at most %d issues can be updated at once", maxBulkLabelIssues)
+	}
+	seen := make(map[int]bool, len(numbers))
+	parsed := make([]int, 0, len(numbers))
+	for i, number := range numbers {
+		f, ok := number.(float64)
+		if !ok || f <= 0 || f != float64(int(f)) {
+			return nil, fmt.Errorf("issue_numbers[%d] must be a positive integer", i)
+		}
+		if !seen[int(f)] {
+			seen[int(f)] = true
+			parsed = append(parsed, int(f))
+		}
+	}
+	return parsed, nil
+}
+
+// hasLabel reports whether an issue has a label, compared without case as GitHub does.
+func hasLabel(issue *github.Issue, name string) bool {
+	for _, label := range issue.Labels {
+		if strings.EqualFold(label.GetName(), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateIssueLabels adds and removes labels on an issue, only calling the API for the labels that change.
+func updateIssueLabels(ctx context.Context, client *github.Client, owner, repo string, issue *github.Issue, add, remove []string) issueLabelUpdate {
+	update := issueLabelUpdate{Number: issue.GetNumber()}
+	var missing []string
+	for _, label := range add {
+		if !hasLabel(issue, label) {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) > 0 {
+		_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, update.Number, missing)
+		if err != nil {
+			update.Error = fmt.Sprintf("failed to add labels: %v", err)
+			return update
+		}
+		_ = resp.Body.Close()
+		update.Added = missing
+	}
+	for _, label := range remove {
+		if !hasLabel(issue, label) {
+			continue
+		}
+		resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, update.Number, label)
+		if err != nil {
+			update.Error = fmt.Sprintf("failed to remove label %s: %v", label, err)
+			return update
+		}
+		_ = resp.Body.Close()
+		update.Removed = append(update.Removed, label)
+	}
+	return update
+}
+
+// BulkUpdateLabels creates a tool to add and remove labels on several issues at once.
+func BulkUpdateLabels(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_update_labels",
+			mcp.WithDescription(t("TOOL_BULK_UPDATE_LABELS_DESCRIPTION", fmt.Sprintf("Add and remove labels on several issues or pull requests of a repository at once, given by number or by a search query, up to %d per call. Returns the labels actually added and removed on each issue, and the error of those that could not be updated; the others are still updated.", maxBulkLabelIssues))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BULK_UPDATE_LABELS_USER_TITLE", "Bulk update labels"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Items(map[string]any{"type": "number"}),
+				mcp.Description("Numbers of the issues to update, instead of query"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Search query selecting the issues to update, instead of issue_numbers, e.g. is:open label:needs-triage. It is limited to the repository, without repo:, org: or user: qualifiers"),
+			),
+			mcp.WithArray("add_labels",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Labels to add to each issue"),
+			),
+			mcp.WithArray("remove_labels",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Labels to remove from each issue"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseBulkUpdateLabelsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (len(params.IssueNumbers) == 0) == (params.Query == "") {
+				return mcp.NewToolResultError("exactly one of issue_numbers and query is required"), nil
+			}
+			if len(params.AddLabels) == 0 && len(params.RemoveLabels) == 0 {
+				return mcp.NewToolResultError("at least one of add_labels and remove_labels is required"), nil
+			}
+			for _, label := range params.AddLabels {
+				for _, other := range params.RemoveLabels {
+					if strings.EqualFold(label, other) {
+						return mcp.NewToolResultError(fmt.Sprintf("label %s cannot be both added and removed", label)), nil
+					}
+				}
+			}
+			if scopeQualifierPattern.MatchString(params.Query) {
+				return mcp.NewToolResultError("query must not contain repo:, org: or user: qualifiers, it is limited to the repository"), nil
+			}
+			numbers, err := parseIssueNumbers(params.IssueNumbers)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := bulkLabelUpdate{Issues: []issueLabelUpdate{}}
+			var issues []*github.Issue
+			if params.Query != "" {
+				query := fmt.Sprintf("%s repo:%s/%s", params.Query, params.Owner, params.Repo)
+				found, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: maxBulkLabelIssues}})
+				if err != nil {
+					return nil, fmt.Errorf("failed to search issues: %w", err)
+				}
+				_ = resp.Body.Close()
+				if found.GetTotal() > maxBulkLabelIssues {
+					return mcp.NewToolResultError(fmt.Sprintf("query matches %d issues, at most %d can be updated at once: narrow it down", found.GetTotal(), maxBulkLabelIssues)), nil
+				}
+				issues = found.Issues
+			} else {
+				for _, number := range numbers {
+					issue, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, number)
+					if err != nil {
+						if resp != nil && resp.StatusCode == http.StatusNotFound {
+							result.Issues = append(result.Issues, issueLabelUpdate{Number: number, Error: "issue not found"})
+							result.Failed++
+							continue
+						}
+						return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+					}
+					_ = resp.Body.Close()
+					issues = append(issues, issue)
+				}
+			}
+
+			for _, issue := range issues {
+				update := updateIssueLabels(ctx, client, params.Owner, params.Repo, issue, params.AddLabels, params.RemoveLabels)
+				switch {
+				case update.Error != "":
+					result.Failed++
+				case len(update.Added) == 0 && len(update.Removed) == 0:
+					result.Unchanged++
+				default:
+					result.Updated++
+				}
+				result.Issues = append(result.Issues, update)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
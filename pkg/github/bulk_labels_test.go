@@ -0,0 +1,235 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// labeledIssue returns an issue with the given labels.
+func labeledIssue(number int, labels ...string) *github.Issue {
+	issue := &github.Issue{Number: github.Ptr(number)}
+	for _, label := range labels {
+		issue.Labels = append(issue.Labels, &github.Label{Name: github.Ptr(label)})
+	}
+	return issue
+}
+
+func Test_BulkUpdateLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkUpdateLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "bulk_update_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "add_labels")
+	assert.Contains(t, tool.InputSchema.Properties, "remove_labels")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	t.Run("updates issues by number", func(t *testing.T) {
+		issues := map[string]*github.Issue{
+			"/repos/owner/repo/issues/1": labeledIssue(1, "Bug"),
+			"/repos/owner/repo/issues/2": labeledIssue(2, "triaged"),
+		}
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					issue, ok := issues[r.URL.Path]
+					if !ok {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+						return
+					}
+					mockResponse(t, http.StatusOK, issue)(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				expectPath(t, "/repos/owner/repo/issues/1/labels").andThen(
+					expectRequestBody(t, []any{"triaged"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("triaged")}}),
+					),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				expectPath(t, "/repos/owner/repo/issues/1/labels/bug").andThen(
+					mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("triaged")}}),
+				),
+			),
+		))
+		_, handler := BulkUpdateLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1), float64(2), float64(3), float64(1)},
+			"add_labels":    []any{"triaged"},
+			"remove_labels": []any{"bug"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var update bulkLabelUpdate
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &update))
+		assert.Equal(t, 1, update.Updated)
+		assert.Equal(t, 1, update.Unchanged)
+		assert.Equal(t, 1, update.Failed)
+		require.Len(t, update.Issues, 3)
+		assert.Equal(t, issueLabelUpdate{Number: 3, Error: "issue not found"}, update.Issues[0])
+		assert.Equal(t, issueLabelUpdate{Number: 1, Added: []string{"triaged"}, Removed: []string{"bug"}}, update.Issues[1])
+		assert.Equal(t, issueLabelUpdate{Number: 2}, update.Issues[2])
+	})
+
+	t.Run("updates issues matching a query", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				expectQueryParams(t, map[string]string{"q": "is:open label:needs-triage repo:owner/repo", "per_page": "100"}).andThen(
+					mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+						Total:  github.Ptr(1),
+						Issues: []*github.Issue{labeledIssue(4, "needs-triage")},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				expectPath(t, "/repos/owner/repo/issues/4/labels/needs-triage").andThen(
+					mockResponse(t, http.StatusOK, []*github.Label{}),
+				),
+			),
+		))
+		_, handler := BulkUpdateLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"query":         "is:open label:needs-triage",
+			"remove_labels": []any{"needs-triage"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var update bulkLabelUpdate
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &update))
+		assert.Equal(t, 1, update.Updated)
+		assert.Equal(t, []issueLabelUpdate{{Number: 4, Removed: []string{"needs-triage"}}}, update.Issues)
+	})
+
+	t.Run("reports per-issue failures", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				labeledIssue(1),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message": "Must have push access"}`))
+				}),
+			),
+		))
+		_, handler := BulkUpdateLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1)},
+			"add_labels":    []any{"triaged"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var update bulkLabelUpdate
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &update))
+		assert.Equal(t, 1, update.Failed)
+		require.Len(t, update.Issues, 1)
+		assert.Contains(t, update.Issues[0].Error, "failed to add labels")
+	})
+
+	t.Run("refuses queries matching too many issues", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetSearchIssues,
+				&github.IssuesSearchResult{Total: github.Ptr(250)},
+			),
+		))
+		_, handler := BulkUpdateLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"query":      "is:open",
+			"add_labels": []any{"stale"},
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "query matches 250 issues, at most 100 can be updated at once")
+	})
+
+	for _, tc := range []struct {
+		name        string
+		args        map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "neither numbers nor query",
+			args:        map[string]any{"add_labels": []any{"bug"}},
+			expectedErr: "exactly one of issue_numbers and query is required",
+		},
+		{
+			name:        "both numbers and query",
+			args:        map[string]any{"issue_numbers": []any{float64(1)}, "query": "is:open", "add_labels": []any{"bug"}},
+			expectedErr: "exactly one of issue_numbers and query is required",
+		},
+		{
+			name:        "no labels",
+			args:        map[string]any{"issue_numbers": []any{float64(1)}},
+			expectedErr: "at least one of add_labels and remove_labels is required",
+		},
+		{
+			name:        "label added and removed",
+			args:        map[string]any{"issue_numbers": []any{float64(1)}, "add_labels": []any{"bug"}, "remove_labels": []any{"Bug"}},
+			expectedErr: "label bug cannot be both added and removed",
+		},
+		{
+			name:        "scope qualifier",
+			args:        map[string]any{"query": "repo:other/repo is:open", "add_labels": []any{"bug"}},
+			expectedErr: "query must not contain repo:, org: or user: qualifiers",
+		},
+		{
+			name:        "invalid number",
+			args:        map[string]any{"issue_numbers": []any{float64(1.5)}, "add_labels": []any{"bug"}},
+			expectedErr: "issue_numbers[0] must be a positive integer",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := BulkUpdateLabels(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultCheckFailureSampleSize is the default number of commits or pull requests analyze_check_failures looks at.
+	defaultCheckFailureSampleSize = 30
+	// maxCheckFailureSampleSize bounds the commits or pull requests analyze_check_failures looks at, as each one costs
+	// a request.
+	maxCheckFailureSampleSize = 100
+)
+
+// ignoredCheckConclusions are the check run conclusions that say nothing about whether a check passes.
+var ignoredCheckConclusions = map[string]bool{
+	"cancelled": true,
+	"stale":     true,
+}
+
+// analyzedRef is a commit whose check runs are analyzed, with the pull request it is the head of, if any.
+type analyzedRef struct {
+	SHA         string
+	PullRequest int
+}
+
+// checkFailureStats aggregates the runs of a check over the analyzed commits.
+type checkFailureStats struct {
+	Name string `json:"name"`
+	// Pattern is "flaky", "failing", "occasional" or "passing".
+	Pattern     string  `json:"pattern"`
+	Runs        int     `json:"runs"`
+	Passed      int     `json:"passed"`
+	Failed      int     `json:"failed"`
+	FailureRate float64 `json:"failure_rate"`
+	// Commits is the number of commits the check ran on, and FailedCommits those whose last run failed.
+	Commits       int `json:"commits"`
+	FailedCommits int `json:"failed_commits"`
+	// PassedOnRerun is the number of commits where the check failed, then passed when run again.
+	PassedOnRerun int `json:"passed_on_rerun"`
+	// Flips is the number of times the outcome changed from one commit to the next.
+	Flips       int    `json:"flips"`
+	LastFailure string `json:"last_failure,omitempty"`
+
+	// lastOutcome is the outcome on the previous commit, to count flips.
+	lastOutcome string
+}
+
+// checkFailuresReport is the result of analyze_check_failures.
+type checkFailuresReport struct {
+	Source          string              `json:"source"`
+	Branch          string              `json:"branch,omitempty"`
+	CommitsAnalyzed int                 `json:"commits_analyzed"`
+	Flaky           []string            `json:"flaky"`
+	Failing         []string            `json:"failing"`
+	Checks          []checkFailureStats `json:"checks"`
+}
+
+// checkFailureAnalysis aggregates check runs per check name.
+type checkFailureAnalysis map[string]*checkFailureStats
+
+// add records the check runs of a commit. Commits must be added in the same order, newest or oldest first.
+func (a checkFailureAnalysis) add(runs []*github.CheckRun) {
+	byName := make(map[string][]*github.CheckRun)
+	for _, run := range runs {
+		if run.GetStatus() != "completed" || ignoredCheckConclusions[run.GetConclusion()] {
+			continue
+		}
+		byName[run.GetName()] = append(byName[run.GetName()], run)
+	}
+	for name, runs := range byName {
+		sort.Slice(runs, func(i, j int) bool {
+			return runs[i].GetCompletedAt().Before(runs[j].GetCompletedAt().Time)
+		})
+		stats, ok := a[name]
+		if !ok {
+			stats = &checkFailureStats{Name: name}
+			a[name] = stats
+		}
+		stats.Commits++
+		failedBefore := false
+		for _, run := range runs {
+			stats.Runs++
+			if passingCheckConclusions[run.GetConclusion()] {
+				stats.Passed++
+				continue
+			}
+			stats.Failed++
+			failedBefore = true
+			if stats.LastFailure == "" {
+				stats.LastFailure = run.GetHTMLURL()
+			}
+		}
+
+		outcome := "failed"
+		if passingCheckConclusions[runs[len(runs)-1].GetConclusion()] {
+			outcome = "passed"
+			if failedBefore {
+				stats.PassedOnRerun++
+			}
+		} else {
+			stats.FailedCommits++
+		}
+		if stats.lastOutcome != "" && stats.lastOutcome != outcome {
+			stats.Flips++
+		}
+		stats.lastOutcome = outcome
+	}
+}
+
+// report classifies the checks, listing flaky checks first, then by decreasing failure rate. A check is flaky when it
+// passed on rerun, or when its outcome went back and forth over the commits.
+func (a checkFailureAnalysis) report() ([]checkFailureStats, []string, []string) {
+	checks := []checkFailureStats{}
+	for _, stats := range a {
+		stats.FailureRate = float64(stats.Failed) / float64(stats.Runs)
+		switch {
+		case stats.Failed == 0:
+			stats.Pattern = "passing"
+		case stats.PassedOnRerun > 0 || stats.Flips >= 2:
+			stats.Pattern = "flaky"
+		case stats.FailedCommits == stats.Commits:
+			stats.Pattern = "failing"
+		default:
+			stats.Pattern = "occasional"
+		}
+		checks = append(checks, *stats)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		if (checks[i].Pattern == "flaky") != (checks[j].Pattern == "flaky") {
+			return checks[i].Pattern == "flaky"
+		}
+		if checks[i].FailureRate != checks[j].FailureRate {
+			return checks[i].FailureRate > checks[j].FailureRate
+		}
+		return checks[i].Name < checks[j].Name
+	})
+
+	flaky, failing := []string{}, []string{}
+	for _, stats := range checks {
+		switch stats.Pattern {
+		case "flaky":
+			flaky = append(flaky, stats.Name)
+		case "failing":
+			failing = append(failing, stats.Name)
+		}
+	}
+	return checks, flaky, failing
+}
+
+// AnalyzeCheckFailures creates a tool to find flaky and failing checks from their recent runs.
+func AnalyzeCheckFailures(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("analyze_check_failures",
+			mcp.WithDescription(t("TOOL_ANALYZE_CHECK_FAILURES_DESCRIPTION", "Aggregate the check run conclusions of each check over the recent commits of a branch or the heads of recent pull requests, to hunt flaky CI. A check is flaky when it failed then passed on rerun of the same commit, or when it kept alternating between failing and passing; it is failing when it failed on every commit. Checks are listed flaky first, then by failure rate.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ANALYZE_CHECK_FAILURES_USER_TITLE", "Analyze check failures"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("source",
+				mcp.Description("Analyze the recent commits of the branch, or the head commits of the recently updated pull requests targeting it. Defaults to commits"),
+				mcp.Enum("commits", "pull_requests"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch whose commits, or pull requests, are analyzed. Defaults to the default branch for commits, and to every branch for pull requests"),
+			),
+			mcp.WithArray("checks",
+				mcp.Description("Only analyze these checks"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Number of commits or pull requests to analyze, defaults to %d", defaultCheckFailureSampleSize)),
+				mcp.Min(1),
+				mcp.Max(maxCheckFailureSampleSize),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseAnalyzeCheckFailuresParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Source == "" {
+				params.Source = "commits"
+			}
+			if params.Source != "commits" && params.Source != "pull_requests" {
+				return mcp.NewToolResultError("source must be commits or pull_requests"), nil
+			}
+			if params.Limit <= 0 {
+				params.Limit = defaultCheckFailureSampleSize
+			}
+			if params.Limit > maxCheckFailureSampleSize {
+				return mcp.NewToolResultError(fmt.Sprintf("limit must be at most %d", maxCheckFailureSampleSize)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var refs []analyzedRef
+			if params.Source == "commits" {
+				if params.Branch == "" {
+					repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get repository: %w", err)
+					}
+					_ = resp.Body.Close()
+					params.Branch = repository.GetDefaultBranch()
+				}
+				commits, resp, err := client.Repositories.ListCommits(ctx, params.Owner, params.Repo, &github.CommitsListOptions{
+					SHA:         params.Branch,
+					ListOptions: github.ListOptions{PerPage: params.Limit},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list commits of %s: %w", params.Branch, err)
+				}
+				_ = resp.Body.Close()
+				for _, commit := range commits {
+					refs = append(refs, analyzedRef{SHA: commit.GetSHA()})
+				}
+			} else {
+				pulls, resp, err := client.PullRequests.List(ctx, params.Owner, params.Repo, &github.PullRequestListOptions{
+					State:       "all",
+					Base:        params.Branch,
+					Sort:        "updated",
+					Direction:   "desc",
+					ListOptions: github.ListOptions{PerPage: params.Limit},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list pull requests: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, pr := range pulls {
+					refs = append(refs, analyzedRef{SHA: pr.GetHead().GetSHA(), PullRequest: pr.GetNumber()})
+				}
+			}
+
+			analysis := checkFailureAnalysis{}
+			for _, ref := range refs {
+				// Every run is listed, not only the latest, so that reruns are seen
+				checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, params.Owner, params.Repo, ref.SHA, &github.ListCheckRunsOptions{
+					Filter:      github.Ptr("all"),
+					ListOptions: github.ListOptions{PerPage: 100},
+				})
+				if err != nil {
+					if ref.PullRequest != 0 {
+						return nil, fmt.Errorf("failed to list check runs of pull request #%d: %w", ref.PullRequest, err)
+					}
+					return nil, fmt.Errorf("failed to list check runs of %s: %w", ref.SHA, err)
+				}
+				_ = resp.Body.Close()
+				runs := checkRuns.CheckRuns
+				if len(params.Checks) > 0 {
+					runs = nil
+					for _, run := range checkRuns.CheckRuns {
+						for _, name := range params.Checks {
+							if strings.EqualFold(run.GetName(), name) {
+								runs = append(runs, run)
+								break
+							}
+						}
+					}
+				}
+				analysis.add(runs)
+			}
+
+			report := checkFailuresReport{Source: params.Source, Branch: params.Branch, CommitsAnalyzed: len(refs)}
+			report.Checks, report.Flaky, report.Failing = analysis.report()
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// completedCheckRun returns a check run that completed with conclusion, minutes after an arbitrary time.
+func completedCheckRun(name, conclusion string, minutes int) *github.CheckRun {
+	completedAt := time.Date(2025, 1, 1, 0, minutes, 0, 0, time.UTC)
+	return &github.CheckRun{
+		Name:        github.Ptr(name),
+		Status:      github.Ptr("completed"),
+		Conclusion:  github.Ptr(conclusion),
+		CompletedAt: &github.Timestamp{Time: completedAt},
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/runs/" + name + "-" + conclusion),
+	}
+}
+
+func Test_CheckFailureAnalysis(t *testing.T) {
+	analysis := checkFailureAnalysis{}
+	analysis.add([]*github.CheckRun{
+		completedCheckRun("build", "success", 1),
+		completedCheckRun("e2e", "success", 3),
+		completedCheckRun("e2e", "failure", 2),
+		completedCheckRun("docs", "failure", 1),
+		{Name: github.Ptr("lint"), Status: github.Ptr("in_progress")},
+	})
+	analysis.add([]*github.CheckRun{
+		completedCheckRun("build", "success", 1),
+		completedCheckRun("e2e", "success", 1),
+		completedCheckRun("docs", "failure", 1),
+		completedCheckRun("lint", "cancelled", 1),
+	})
+	analysis.add([]*github.CheckRun{
+		completedCheckRun("build", "failure", 1),
+		completedCheckRun("e2e", "success", 1),
+		completedCheckRun("docs", "timed_out", 1),
+	})
+
+	checks, flaky, failing := analysis.report()
+	assert.Equal(t, []string{"e2e"}, flaky)
+	assert.Equal(t, []string{"docs"}, failing)
+	require.Len(t, checks, 3)
+	assert.Equal(t, checkFailureStats{
+		Name:          "e2e",
+		Pattern:       "flaky",
+		Runs:          4,
+		Passed:        3,
+		Failed:        1,
+		FailureRate:   0.25,
+		Commits:       3,
+		PassedOnRerun: 1,
+		LastFailure:   "https://github.com/owner/repo/runs/e2e-failure",
+		lastOutcome:   "passed",
+	}, checks[0])
+	assert.Equal(t, "docs", checks[1].Name)
+	assert.Equal(t, 1.0, checks[1].FailureRate)
+	assert.Equal(t, "build", checks[2].Name)
+	assert.Equal(t, "occasional", checks[2].Pattern)
+	assert.Equal(t, 1, checks[2].Flips)
+}
+
+func Test_AnalyzeCheckFailures(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AnalyzeCheckFailures(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "analyze_check_failures", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "source")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "checks")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// integration flips between commits, unit always passes
+	checkRuns := map[string][]*github.CheckRun{
+		"c3": {completedCheckRun("integration", "failure", 1), completedCheckRun("unit", "success", 1)},
+		"c2": {completedCheckRun("integration", "success", 1), completedCheckRun("unit", "success", 1)},
+		"c1": {completedCheckRun("integration", "failure", 1), completedCheckRun("unit", "success", 1)},
+	}
+	options := []mock.MockBackendOption{
+		mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"sha": "main", "per_page": "30"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.RepositoryCommit{
+					{SHA: github.Ptr("c3")},
+					{SHA: github.Ptr("c2")},
+					{SHA: github.Ptr("c1")},
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"state": "all", "base": "main", "sort": "updated", "direction": "desc", "per_page": "2"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.PullRequest{
+					{Number: github.Ptr(7), Head: &github.PullRequestBranch{SHA: github.Ptr("c3")}},
+					{Number: github.Ptr(6), Head: &github.PullRequestBranch{SHA: github.Ptr("c2")}},
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "all", r.URL.Query().Get("filter"))
+				sha := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/commits/"), "/")[0]
+				mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{CheckRuns: checkRuns[sha]})(w, r)
+			}),
+		),
+	}
+	_, handler := AnalyzeCheckFailures(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+	t.Run("analyzes the commits of the default branch", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report checkFailuresReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, "commits", report.Source)
+		assert.Equal(t, "main", report.Branch)
+		assert.Equal(t, 3, report.CommitsAnalyzed)
+		assert.Equal(t, []string{"integration"}, report.Flaky)
+		assert.Empty(t, report.Failing)
+		require.Len(t, report.Checks, 2)
+		assert.Equal(t, 2, report.Checks[0].Flips)
+		assert.Equal(t, "passing", report.Checks[1].Pattern)
+	})
+
+	t.Run("analyzes the heads of pull requests", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"source": "pull_requests",
+			"branch": "main",
+			"checks": []any{"Integration"},
+			"limit":  float64(2),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report checkFailuresReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, 2, report.CommitsAnalyzed)
+		assert.Empty(t, report.Flaky)
+		require.Len(t, report.Checks, 1)
+		assert.Equal(t, "occasional", report.Checks[0].Pattern)
+	})
+
+	t.Run("rejects an unknown source", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"source": "workflows",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "source must be")
+	})
+}
@@ -0,0 +1,472 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultCodeSnippetLines is the default number of lines get_code_snippet returns at most.
+	defaultCodeSnippetLines = 200
+	// maxCodeSnippetLines bounds the lines get_code_snippet returns.
+	maxCodeSnippetLines = 1000
+	// codeSnippetContextLines is the number of lines get_code_snippet shows around the requested lines when they are
+	// not expanded to a function or type.
+	codeSnippetContextLines = 5
+)
+
+// Methods of finding the unit of code enclosing the requested lines of get_code_snippet.
+const (
+	snippetMethodGoParser    = "go_parser"
+	snippetMethodIndentation = "indentation"
+	snippetMethodBraces      = "braces"
+	snippetMethodLines       = "lines"
+)
+
+var (
+	// pythonDefinitionPattern matches the first line of a Python function or class.
+	pythonDefinitionPattern = regexp.MustCompile(`^\s*(async\s+def|def|class)\s+\w+`)
+	// braceDefinitionPattern matches the keywords introducing a function, type or module in the languages with braces.
+	braceDefinitionPattern = regexp.MustCompile(`\b(func|function|class|interface|struct|enum|trait|impl|fn|fun|namespace|module|object|record|protocol|extension|union|type)\b`)
+	// braceSignaturePattern matches the end of the signature of a method without a keyword, such as a Java method, with
+	// its optional return type, or of a JavaScript arrow function.
+	braceSignaturePattern = regexp.MustCompile(`(\)\s*(const\s*)?(:\s*[^{;=]+|->\s*[^{;]+|throws\s+[^{;]+|override|noexcept)?|=>)\s*$`)
+	// braceControlPattern matches the statements with blocks that are not definitions.
+	braceControlPattern = regexp.MustCompile(`^\s*(\}\s*)?(else\b\s*)?(if|for|foreach|while|switch|catch|try|do|finally|with|using|lock|synchronized|unsafe|loop|match|select|return|else|case|default)\b`)
+)
+
+// codeUnit is the range of lines of a function, type or other definition, or of the requested lines when none was
+// found, numbered from 1.
+type codeUnit struct {
+	start, end int
+	// declaration is the line the definition starts on, after its comments, or 0.
+	declaration int
+	method      string
+}
+
+// codeSnippet is the result of get_code_snippet.
+type codeSnippet struct {
+	Path      string `json:"path"`
+	Ref       string `json:"ref,omitempty"`
+	SHA       string `json:"sha"`
+	Language  string `json:"language"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	// Method is how the unit of code was found: go_parser, indentation, braces, or lines when the requested lines
+	// were returned with some context as no enclosing function or type was found.
+	Method string `json:"method"`
+	// Declaration is the first line of the enclosing function or type.
+	Declaration string `json:"declaration,omitempty"`
+	Truncated   bool   `json:"truncated,omitempty"`
+	Snippet     string `json:"snippet"`
+}
+
+// enclosingCodeUnit finds the innermost function or type of a file enclosing the lines from start to end, with the
+// comments and decorators before it.
+func enclosingCodeUnit(content, language string, start, end int) (codeUnit, bool) {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	switch language {
+	case "Go":
+		if unit, ok := enclosingGoDeclaration(content, start, end); ok {
+			return unit, true
+		}
+		// Files that do not parse are still matched by their braces
+		return enclosingBraceBlock(lines, start, end)
+	case "Python":
+		return enclosingPythonBlock(lines, start, end)
+	default:
+		return enclosingBraceBlock(lines, start, end)
+	}
+}
+
+// enclosingGoDeclaration finds the top-level declaration of a Go file enclosing the lines, or the declaration of a
+// group, such as a type in a type ( ... ) block, with its doc comment.
+func enclosingGoDeclaration(content string, start, end int) (codeUnit, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return codeUnit{}, false
+	}
+	unit := func(doc *ast.CommentGroup, node ast.Node) codeUnit {
+		u := codeUnit{
+			start:       fset.Position(node.Pos()).Line,
+			end:         fset.Position(node.End()).Line,
+			declaration: fset.Position(node.Pos()).Line,
+			method:      snippetMethodGoParser,
+		}
+		if doc != nil {
+			u.start = fset.Position(doc.Pos()).Line
+		}
+		return u
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if u := unit(decl.Doc, decl); u.start <= start && end <= u.end {
+				return u, true
+			}
+		case *ast.GenDecl:
+			u := unit(decl.Doc, decl)
+			if u.start > start || end > u.end {
+				continue
+			}
+			if decl.Lparen.IsValid() {
+				for _, spec := range decl.Specs {
+					var doc *ast.CommentGroup
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						doc = spec.Doc
+					case *ast.ValueSpec:
+						doc = spec.Doc
+					case *ast.ImportSpec:
+						doc = spec.Doc
+					}
+					if s := unit(doc, spec); s.start <= start && end <= s.end {
+						return s, true
+					}
+				}
+			}
+			return u, true
+		}
+	}
+	return codeUnit{}, false
+}
+
+// enclosingPythonBlock finds the innermost function or class of a Python file enclosing the lines by their
+// indentation, with its decorators and the comments before it.
+func enclosingPythonBlock(lines []string, start, end int) (codeUnit, bool) {
+	for header := min(start, len(lines)); header >= 1; header-- {
+		if !pythonDefinitionPattern.MatchString(lines[header-1]) {
+			continue
+		}
+		indent := indentation(lines[header-1])
+
+		// The signature may span several lines, the body starts after the colon closing it
+		last, depth := header, 0
+		for i := header; i <= len(lines); i++ {
+			line := lines[i-1]
+			if hash := strings.Index(line, "#"); hash >= 0 {
+				line = line[:hash]
+			}
+			depth += strings.Count(line, "(") + strings.Count(line, "[") - strings.Count(line, ")") - strings.Count(line, "]")
+			last = i
+			if depth <= 0 && strings.HasSuffix(strings.TrimSpace(line), ":") {
+				break
+			}
+		}
+		for i := last + 1; i <= len(lines); i++ {
+			if strings.TrimSpace(lines[i-1]) == "" {
+				continue
+			}
+			if indentation(lines[i-1]) <= indent {
+				break
+			}
+			last = i
+		}
+		if last < end {
+			continue
+		}
+
+		first := header
+		for first > 1 {
+			// A decorator may span several lines, it is followed back to its opening parenthesis
+			top, text := first-1, lines[first-2]
+			for top > 1 && strings.Count(text, ")") > strings.Count(text, "(") {
+				top--
+				text = lines[top-1] + "\n" + text
+			}
+			previous := strings.TrimSpace(text)
+			if !strings.HasPrefix(previous, "@") && !strings.HasPrefix(previous, "#") {
+				break
+			}
+			first = top
+		}
+		return codeUnit{start: first, end: last, declaration: header, method: snippetMethodIndentation}, true
+	}
+	return codeUnit{}, false
+}
+
+// indentation returns the width of the leading whitespace of a line, tabs counting as 8 columns as in Python.
+func indentation(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 8 - width%8
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// braceBlock is a block between matching braces, by the lines of its braces.
+type braceBlock struct {
+	open, close int
+	// column is the byte offset of the opening brace in its line.
+	column int
+}
+
+// braceBlocks matches the braces of a file, skipping those in strings and comments.
+func braceBlocks(lines []string) []braceBlock {
+	var blocks []braceBlock
+	var open []braceBlock
+	inBlockComment, inTemplate := false, false
+	for n, line := range lines {
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			switch {
+			case inBlockComment:
+				if c == '*' && i+1 < len(line) && line[i+1] == '/' {
+					inBlockComment = false
+					i++
+				}
+			case inTemplate:
+				if c == '\\' {
+					i++
+				} else if c == '`' {
+					inTemplate = false
+				}
+			case c == '/' && i+1 < len(line) && line[i+1] == '/':
+				i = len(line)
+			case c == '/' && i+1 < len(line) && line[i+1] == '*':
+				inBlockComment = true
+				i++
+			case c == '`':
+				inTemplate = true
+			case c == '"' || c == '\'':
+				// Strings end with their line. A quote without a closing one, such as a Rust lifetime, is not a string
+				for j := i + 1; j < len(line); j++ {
+					if line[j] == '\\' {
+						j++
+					} else if line[j] == c {
+						i = j
+						break
+					}
+				}
+			case c == '{':
+				open = append(open, braceBlock{open: n + 1, column: i})
+			case c == '}':
+				if len(open) > 0 {
+					block := open[len(open)-1]
+					open = open[:len(open)-1]
+					block.close = n + 1
+					blocks = append(blocks, block)
+				}
+			}
+		}
+	}
+	return blocks
+}
+
+// enclosingBraceBlock finds the innermost function or type enclosing the lines in a language with braces, its
+// signature being the lines before its opening brace, with the comments and annotations before it.
+func enclosingBraceBlock(lines []string, start, end int) (codeUnit, bool) {
+	var best codeUnit
+	found := false
+	for _, block := range braceBlocks(lines) {
+		if block.close < end {
+			continue
+		}
+		header, signature := blockSignature(lines, block)
+		if header > start || !isDefinitionSignature(signature) {
+			continue
+		}
+		if found && block.close-header >= best.end-best.declaration {
+			continue
+		}
+		best = codeUnit{start: header, end: block.close, declaration: header, method: snippetMethodBraces}
+		found = true
+	}
+	if !found {
+		return codeUnit{}, false
+	}
+	for best.start > 1 {
+		previous := strings.TrimSpace(lines[best.start-2])
+		if !strings.HasPrefix(previous, "//") && !strings.HasPrefix(previous, "/*") && !strings.HasPrefix(previous, "*") &&
+			!strings.HasPrefix(previous, "@") && !strings.HasPrefix(previous, "#[") {
+			break
+		}
+		best.start--
+	}
+	return best, true
+}
+
+// blockSignature returns the line a block's signature starts on and the signature, the text before its opening
+// brace. A brace on a line of its own belongs to the line before it, and signatures spanning several lines are
+// followed back to their opening parenthesis.
+func blockSignature(lines []string, block braceBlock) (int, string) {
+	header := block.open
+	signature := lines[header-1][:block.column]
+	if strings.TrimSpace(signature) == "" && header > 1 {
+		header--
+		signature = lines[header-1]
+	}
+	for header > 1 && strings.Count(signature, ")") > strings.Count(signature, "(") {
+		header--
+		signature = lines[header-1] + "\n" + signature
+	}
+	return header, signature
+}
+
+// isDefinitionSignature reports whether the text before a brace looks like the signature of a function or type rather
+// than a control statement or a literal.
+func isDefinitionSignature(signature string) bool {
+	if braceControlPattern.MatchString(signature) {
+		return false
+	}
+	return braceDefinitionPattern.MatchString(signature) || braceSignaturePattern.MatchString(signature)
+}
+
+// numberedLines returns lines from to to of a file, numbered, with the lines from markFrom to markTo marked.
+func numberedLines(lines []string, from, to, markFrom, markTo int) string {
+	width := len(strconv.Itoa(to))
+	var sb strings.Builder
+	for i := from; i <= to; i++ {
+		marker := " "
+		if i >= markFrom && i <= markTo {
+			marker = ">"
+		}
+		fmt.Fprintf(&sb, "%s %*d | %s\n", marker, width, i, lines[i-1])
+	}
+	return sb.String()
+}
+
+// GetCodeSnippet creates a tool to get the function or type enclosing lines of a file.
+func GetCodeSnippet(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_code_snippet",
+			mcp.WithDescription(t("TOOL_GET_CODE_SNIPPET_DESCRIPTION", "Get lines of a file expanded to the whole function, method or type enclosing them, with its doc comment, so that complete units of code are returned rather than arbitrary line windows. Go files are parsed, Python blocks are found by indentation and other languages by matching braces. When no enclosing definition is found, the lines are returned with some context. The requested lines are marked with >.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODE_SNIPPET_USER_TITLE", "Get code snippet"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Required(),
+				mcp.Description("First line of interest, numbered from 1"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line of interest, defaults to start_line"),
+				mcp.Min(1),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA, defaults to the default branch"),
+			),
+			mcp.WithBoolean("lines_only",
+				mcp.Description("Return the lines with some context without expanding them to the enclosing function or type"),
+			),
+			mcp.WithNumber("max_lines",
+				mcp.Description(fmt.Sprintf("Maximum number of lines to return, defaults to %d, at most %d. Larger units are cut around the requested lines", defaultCodeSnippetLines, maxCodeSnippetLines)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetCodeSnippetParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.StartLine < 1 {
+				return mcp.NewToolResultError("start_line must be at least 1"), nil
+			}
+			if params.EndLine == 0 {
+				params.EndLine = params.StartLine
+			}
+			if params.EndLine < params.StartLine {
+				return mcp.NewToolResultError("end_line must not be before start_line"), nil
+			}
+			if params.MaxLines <= 0 {
+				params.MaxLines = defaultCodeSnippetLines
+			}
+			if params.MaxLines > maxCodeSnippetLines {
+				return mcp.NewToolResultError(fmt.Sprintf("max_lines must be at most %d", maxCodeSnippetLines)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			data, sha, found, err := getFileAtRef(ctx, client, params.Owner, params.Repo, params.Path, params.Ref)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return mcp.NewToolResultError(fmt.Sprintf("%s not found", params.Path)), nil
+			}
+			if isBinaryContent(data) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a binary file", params.Path)), nil
+			}
+
+			content := string(data)
+			lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+			if params.StartLine > len(lines) {
+				return mcp.NewToolResultError(fmt.Sprintf("start_line %d is past the end of the file (%d lines)", params.StartLine, len(lines))), nil
+			}
+			params.EndLine = min(params.EndLine, len(lines))
+
+			language := fileLanguage(params.Path)
+			unit, ok := codeUnit{}, false
+			if !params.LinesOnly {
+				unit, ok = enclosingCodeUnit(content, language, params.StartLine, params.EndLine)
+			}
+			if !ok {
+				unit = codeUnit{
+					start:  max(params.StartLine-codeSnippetContextLines, 1),
+					end:    min(params.EndLine+codeSnippetContextLines, len(lines)),
+					method: snippetMethodLines,
+				}
+			}
+
+			result := codeSnippet{
+				Path:     params.Path,
+				Ref:      params.Ref,
+				SHA:      sha,
+				Language: language,
+				Method:   unit.method,
+			}
+			if unit.declaration > 0 {
+				result.Declaration = strings.TrimSpace(lines[unit.declaration-1])
+			}
+			if unit.end-unit.start+1 > params.MaxLines {
+				// Keep the requested lines, and as much of the unit around them as fits
+				result.Truncated = true
+				extra := max(params.MaxLines-(params.EndLine-params.StartLine+1), 0)
+				unit.start = max(params.StartLine-extra/2, unit.start)
+				unit.end = min(unit.start+params.MaxLines-1, unit.end)
+				unit.start = max(unit.end-params.MaxLines+1, unit.start)
+			}
+			result.StartLine, result.EndLine = unit.start, unit.end
+			result.Snippet = numberedLines(lines, unit.start, unit.end, params.StartLine, params.EndLine)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,237 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EnclosingCodeUnit(t *testing.T) {
+	goSource := `package server
+
+import "fmt"
+
+// Server serves requests.
+type Server struct {
+	name string
+}
+
+// Run starts the server.
+// It blocks until stopped.
+func (s *Server) Run() error {
+	if s.name == "" {
+		return fmt.Errorf("no name")
+	}
+	return nil
+}
+
+type (
+	// Option configures a server.
+	Option func(*Server)
+	Handler interface {
+		Serve()
+	}
+)
+`
+	pythonSource := `import os
+
+
+class Repo:
+    """A repository."""
+
+    @property
+    def name(self):
+        return self._name
+
+    # Fetches the repository.
+    @retry(
+        times=3,
+    )
+    async def fetch(
+        self,
+        ref: str = "main",
+    ) -> bytes:
+        data = os.read(ref)
+
+        return data
+
+
+def main():
+    Repo().fetch()
+`
+	tsSource := `import { x } from "y";
+
+/**
+ * Client calls the API.
+ */
+export class Client {
+  @cached()
+  async get(path: string): Promise<string> {
+    if (path === "}") {
+      return "{";
+    }
+    return fetch(path).then((r) => {
+      return r.text();
+    });
+  }
+}
+
+const handler = async (event) => {
+  return event;
+};
+`
+	javaSource := `public class Service
+{
+    public String call(
+        String name)
+    {
+        for (int i = 0; i < 3; i++) {
+            log(name);
+        }
+        return name;
+    }
+}
+`
+
+	tests := []struct {
+		name        string
+		content     string
+		language    string
+		start, end  int
+		expected    codeUnit
+		declaration int
+		notFound    bool
+	}{
+		{name: "go function with its doc comment", content: goSource, language: "Go", start: 14, end: 14, expected: codeUnit{start: 10, end: 17, declaration: 12, method: snippetMethodGoParser}},
+		{name: "go type", content: goSource, language: "Go", start: 7, end: 7, expected: codeUnit{start: 5, end: 8, declaration: 6, method: snippetMethodGoParser}},
+		{name: "go type of a group", content: goSource, language: "Go", start: 23, end: 23, expected: codeUnit{start: 22, end: 24, declaration: 22, method: snippetMethodGoParser}},
+		{name: "go range across declarations", content: goSource, language: "Go", start: 7, end: 14, notFound: true},
+		{name: "go that does not parse falls back to braces", content: "package x\n\nfunc f() {\n\tg(\n}\n", language: "Go", start: 4, end: 4, expected: codeUnit{start: 3, end: 5, declaration: 3, method: snippetMethodBraces}},
+		{name: "python method with decorators and signature over lines", content: pythonSource, language: "Python", start: 19, end: 19, expected: codeUnit{start: 11, end: 21, declaration: 15, method: snippetMethodIndentation}},
+		{name: "python method", content: pythonSource, language: "Python", start: 9, end: 9, expected: codeUnit{start: 7, end: 9, declaration: 8, method: snippetMethodIndentation}},
+		{name: "python class enclosing two methods", content: pythonSource, language: "Python", start: 9, end: 19, expected: codeUnit{start: 4, end: 21, declaration: 4, method: snippetMethodIndentation}},
+		{name: "python function", content: pythonSource, language: "Python", start: 25, end: 25, expected: codeUnit{start: 24, end: 25, declaration: 24, method: snippetMethodIndentation}},
+		{name: "python module level", content: pythonSource, language: "Python", start: 1, end: 1, notFound: true},
+		{name: "typescript method skips blocks and braces in strings", content: tsSource, language: "TypeScript", start: 10, end: 10, expected: codeUnit{start: 7, end: 15, declaration: 8, method: snippetMethodBraces}},
+		{name: "typescript class with its doc comment", content: tsSource, language: "TypeScript", start: 6, end: 6, expected: codeUnit{start: 3, end: 16, declaration: 6, method: snippetMethodBraces}},
+		{name: "javascript arrow function", content: tsSource, language: "TypeScript", start: 19, end: 19, expected: codeUnit{start: 18, end: 20, declaration: 18, method: snippetMethodBraces}},
+		{name: "java method with allman braces", content: javaSource, language: "Java", start: 7, end: 7, expected: codeUnit{start: 3, end: 10, declaration: 3, method: snippetMethodBraces}},
+		{name: "no definition", content: "a: 1\nb: 2\n", language: "YAML", start: 1, end: 1, notFound: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			unit, ok := enclosingCodeUnit(tc.content, tc.language, tc.start, tc.end)
+			if tc.notFound {
+				assert.False(t, ok, "%+v", unit)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, unit)
+		})
+	}
+}
+
+func Test_GetCodeSnippet(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeSnippet(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_code_snippet", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "lines_only")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "start_line"})
+
+	source := "package main\n\nimport \"fmt\"\n\n// main prints.\nfunc main() {\n\tfmt.Println(\"a\")\n\tfmt.Println(\"b\")\n\tfmt.Println(\"c\")\n}\n"
+	contents := mock.WithRequestMatchHandler(
+		mock.GetReposContentsByOwnerByRepoByPath,
+		expectPath(t, "/repos/owner/repo/contents/main.go").andThen(mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			SHA:      github.Ptr("abc"),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(source))),
+		})),
+	)
+
+	call := func(t *testing.T, client *github.Client, args map[string]any) (codeSnippet, string, bool) {
+		_, handler := GetCodeSnippet(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		text := getTextResult(t, result).Text
+		var snippet codeSnippet
+		if !result.IsError {
+			require.NoError(t, json.Unmarshal([]byte(text), &snippet))
+		}
+		return snippet, text, result.IsError
+	}
+
+	t.Run("expands to the enclosing function", func(t *testing.T) {
+		snippet, _, isError := call(t, github.NewClient(mock.NewMockedHTTPClient(contents)), map[string]any{
+			"owner": "owner", "repo": "repo", "path": "main.go", "start_line": float64(8),
+		})
+		require.False(t, isError)
+		assert.Equal(t, "abc", snippet.SHA)
+		assert.Equal(t, "Go", snippet.Language)
+		assert.Equal(t, snippetMethodGoParser, snippet.Method)
+		assert.Equal(t, "func main() {", snippet.Declaration)
+		assert.Equal(t, 5, snippet.StartLine)
+		assert.Equal(t, 10, snippet.EndLine)
+		assert.Equal(t, "   5 | // main prints.\n   6 | func main() {\n   7 | \tfmt.Println(\"a\")\n>  8 | \tfmt.Println(\"b\")\n   9 | \tfmt.Println(\"c\")\n  10 | }\n", snippet.Snippet)
+		assert.False(t, snippet.Truncated)
+	})
+
+	t.Run("cuts units longer than max_lines around the requested lines", func(t *testing.T) {
+		snippet, _, isError := call(t, github.NewClient(mock.NewMockedHTTPClient(contents)), map[string]any{
+			"owner": "owner", "repo": "repo", "path": "main.go", "start_line": float64(8), "max_lines": float64(3),
+		})
+		require.False(t, isError)
+		assert.True(t, snippet.Truncated)
+		assert.Equal(t, 7, snippet.StartLine)
+		assert.Equal(t, 9, snippet.EndLine)
+	})
+
+	t.Run("returns the lines with context when asked", func(t *testing.T) {
+		snippet, _, isError := call(t, github.NewClient(mock.NewMockedHTTPClient(contents)), map[string]any{
+			"owner": "owner", "repo": "repo", "path": "main.go", "start_line": float64(7), "end_line": float64(8), "lines_only": true,
+		})
+		require.False(t, isError)
+		assert.Equal(t, snippetMethodLines, snippet.Method)
+		assert.Empty(t, snippet.Declaration)
+		assert.Equal(t, 2, snippet.StartLine)
+		assert.Equal(t, 10, snippet.EndLine)
+		assert.Equal(t, 2, strings.Count(snippet.Snippet, ">"))
+	})
+
+	t.Run("lines past the end of the file", func(t *testing.T) {
+		_, text, isError := call(t, github.NewClient(mock.NewMockedHTTPClient(contents)), map[string]any{
+			"owner": "owner", "repo": "repo", "path": "main.go", "start_line": float64(20),
+		})
+		require.True(t, isError)
+		assert.Contains(t, text, "past the end of the file (10 lines)")
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		_, text, isError := call(t, mockClient, map[string]any{
+			"owner": "owner", "repo": "repo", "path": "main.go", "start_line": float64(8), "end_line": float64(2),
+		})
+		require.True(t, isError)
+		assert.Contains(t, text, "end_line must not be before start_line")
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		notFound := mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`))
+		_, text, isError := call(t, github.NewClient(mock.NewMockedHTTPClient(notFound)), map[string]any{
+			"owner": "owner", "repo": "repo", "path": "missing.go", "start_line": float64(1),
+		})
+		require.True(t, isError)
+		assert.Contains(t, text, "missing.go not found")
+	})
+}
@@ -0,0 +1,279 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultCodebaseStatsTop is the default number of extensions and largest files listed by get_codebase_stats.
+const defaultCodebaseStatsTop = 10
+
+// languageByExtension maps the extensions of source files to the language they are written in. Files with other
+// extensions are counted as "Other".
+var languageByExtension = map[string]string{
+	".c":      "C",
+	".h":      "C",
+	".cs":     "C#",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".cxx":    "C++",
+	".hh":     "C++",
+	".hpp":    "C++",
+	".css":    "CSS",
+	".dart":   "Dart",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".go":     "Go",
+	".gradle": "Gradle",
+	".groovy": "Groovy",
+	".hcl":    "HCL",
+	".tf":     "HCL",
+	".htm":    "HTML",
+	".html":   "HTML",
+	".hs":     "Haskell",
+	".json":   "JSON",
+	".java":   "Java",
+	".cjs":    "JavaScript",
+	".js":     "JavaScript",
+	".jsx":    "JavaScript",
+	".mjs":    "JavaScript",
+	".kt":     "Kotlin",
+	".kts":    "Kotlin",
+	".lua":    "Lua",
+	".md":     "Markdown",
+	".mdx":    "Markdown",
+	".m":      "Objective-C",
+	".php":    "PHP",
+	".pl":     "Perl",
+	".ps1":    "PowerShell",
+	".proto":  "Protocol Buffers",
+	".py":     "Python",
+	".pyi":    "Python",
+	".r":      "R",
+	".rb":     "Ruby",
+	".rs":     "Rust",
+	".scss":   "SCSS",
+	".sql":    "SQL",
+	".scala":  "Scala",
+	".bash":   "Shell",
+	".sh":     "Shell",
+	".zsh":    "Shell",
+	".swift":  "Swift",
+	".toml":   "TOML",
+	".cts":    "TypeScript",
+	".mts":    "TypeScript",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".vue":    "Vue",
+	".xml":    "XML",
+	".yaml":   "YAML",
+	".yml":    "YAML",
+}
+
+// languageByFileName maps the names of files without a telling extension to their language.
+var languageByFileName = map[string]string{
+	"Dockerfile":  "Dockerfile",
+	"Makefile":    "Makefile",
+	"Gemfile":     "Ruby",
+	"Rakefile":    "Ruby",
+	"Jenkinsfile": "Groovy",
+}
+
+// fileLanguage returns the language of a file from its name.
+func fileLanguage(filePath string) string {
+	name := path.Base(filePath)
+	if language, ok := languageByFileName[name]; ok {
+		return language
+	}
+	if language, ok := languageByExtension[strings.ToLower(path.Ext(name))]; ok {
+		return language
+	}
+	return "Other"
+}
+
+// inExcludedDirectory reports whether a file is in one of the directories. Directories without a slash match a
+// directory of that name anywhere, others the directory at that path.
+func inExcludedDirectory(filePath string, dirs []string) bool {
+	for _, dir := range dirs {
+		dir = strings.Trim(dir, "/")
+		if dir == "" {
+			continue
+		}
+		if strings.Contains(dir, "/") {
+			if strings.HasPrefix(filePath, dir+"/") {
+				return true
+			}
+			continue
+		}
+		segments := strings.Split(filePath, "/")
+		for _, segment := range segments[:len(segments)-1] {
+			if segment == dir {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// codebaseCount is the number and total size of the files in a group.
+type codebaseCount struct {
+	Name    string  `json:"name"`
+	Files   int     `json:"files"`
+	Bytes   int64   `json:"bytes"`
+	Percent float64 `json:"percent"`
+}
+
+// codebaseFile is a file of the repository with its size.
+type codebaseFile struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// codebaseStats is the result of get_codebase_stats.
+type codebaseStats struct {
+	Ref          string          `json:"ref"`
+	Files        int             `json:"files"`
+	Bytes        int64           `json:"bytes"`
+	Languages    []codebaseCount `json:"languages"`
+	Extensions   []codebaseCount `json:"extensions"`
+	LargestFiles []codebaseFile  `json:"largest_files"`
+	Truncated    bool            `json:"truncated,omitempty"`
+}
+
+// sortedCounts returns the counts sorted by size, largest first, with their share of the total size.
+func sortedCounts(counts map[string]*codebaseCount, totalBytes int64) []codebaseCount {
+	sorted := make([]codebaseCount, 0, len(counts))
+	for _, c := range counts {
+		if totalBytes > 0 {
+			c.Percent = math.Round(float64(c.Bytes)*1000/float64(totalBytes)) / 10
+		}
+		sorted = append(sorted, *c)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Bytes != sorted[j].Bytes {
+			return sorted[i].Bytes > sorted[j].Bytes
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// GetCodebaseStats creates a tool to count the files and bytes of a repository by language and extension.
+func GetCodebaseStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codebase_stats",
+			mcp.WithDescription(t("TOOL_GET_CODEBASE_STATS_DESCRIPTION", "Count the files and bytes of a repository by language and by file extension, and list its largest files, from the git tree at a ref. Gives a quantitative picture of a codebase before analyzing it, without fetching any file.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODEBASE_STATS_USER_TITLE", "Get codebase statistics"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit, defaults to the default branch"),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Directories to leave out, e.g. vendor or node_modules. Names without a slash match a directory of that name anywhere, others the directory at that path"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("top",
+				mcp.Description(fmt.Sprintf("Number of extensions and largest files to list, defaults to %d", defaultCodebaseStatsTop)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetCodebaseStatsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			top := params.Top
+			if top <= 0 {
+				top = defaultCodebaseStatsTop
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref := params.Ref
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, ref, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree of %s: %w", ref, err)
+			}
+			_ = resp.Body.Close()
+
+			stats := codebaseStats{Ref: ref, Truncated: tree.GetTruncated()}
+			languages := make(map[string]*codebaseCount)
+			extensions := make(map[string]*codebaseCount)
+			var files []codebaseFile
+			for _, entry := range tree.Entries {
+				// Submodules are commits, and symlinks blobs holding the path they point to
+				if entry.GetType() != "blob" || entry.GetMode() == "120000" || inExcludedDirectory(entry.GetPath(), params.Exclude) {
+					continue
+				}
+				size := int64(entry.GetSize())
+				stats.Files++
+				stats.Bytes += size
+
+				language := fileLanguage(entry.GetPath())
+				if languages[language] == nil {
+					languages[language] = &codebaseCount{Name: language}
+				}
+				languages[language].Files++
+				languages[language].Bytes += size
+
+				ext := strings.ToLower(path.Ext(entry.GetPath()))
+				if ext == "" {
+					ext = "(none)"
+				}
+				if extensions[ext] == nil {
+					extensions[ext] = &codebaseCount{Name: ext}
+				}
+				extensions[ext].Files++
+				extensions[ext].Bytes += size
+
+				files = append(files, codebaseFile{Path: entry.GetPath(), Bytes: size})
+			}
+
+			stats.Languages = sortedCounts(languages, stats.Bytes)
+			stats.Extensions = sortedCounts(extensions, stats.Bytes)
+			if len(stats.Extensions) > top {
+				stats.Extensions = stats.Extensions[:top]
+			}
+			sort.SliceStable(files, func(i, j int) bool { return files[i].Bytes > files[j].Bytes })
+			if len(files) > top {
+				files = files[:top]
+			}
+			stats.LargestFiles = append([]codebaseFile{}, files...)
+
+			r, err := json.Marshal(stats)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
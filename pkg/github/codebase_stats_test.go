@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FileLanguage(t *testing.T) {
+	assert.Equal(t, "Go", fileLanguage("pkg/github/server.go"))
+	assert.Equal(t, "TypeScript", fileLanguage("web/App.TSX"))
+	assert.Equal(t, "Dockerfile", fileLanguage("build/Dockerfile"))
+	assert.Equal(t, "Other", fileLanguage("LICENSE"))
+}
+
+func Test_InExcludedDirectory(t *testing.T) {
+	assert.True(t, inExcludedDirectory("vendor/github.com/x/y.go", []string{"vendor"}))
+	assert.True(t, inExcludedDirectory("web/node_modules/react/index.js", []string{"node_modules"}))
+	assert.True(t, inExcludedDirectory("third_party/proto/a.proto", []string{"/third_party/proto/"}))
+	assert.False(t, inExcludedDirectory("pkg/proto/a.proto", []string{"third_party/proto"}))
+	assert.False(t, inExcludedDirectory("docs/vendor.md", []string{"vendor"}))
+	assert.False(t, inExcludedDirectory("main.go", nil))
+}
+
+func Test_GetCodebaseStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodebaseStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_codebase_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude")
+	assert.Contains(t, tool.InputSchema.Properties, "top")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// TreeEntry does not marshal its size, the tree is given as JSON
+	tree := `{"truncated": false, "tree": [
+		{"path": "cmd", "type": "tree", "mode": "040000"},
+		{"path": "cmd/main.go", "type": "blob", "mode": "100644", "size": 600},
+		{"path": "pkg/server.go", "type": "blob", "mode": "100644", "size": 1400},
+		{"path": "web/app.ts", "type": "blob", "mode": "100644", "size": 1500},
+		{"path": "README.md", "type": "blob", "mode": "100644", "size": 500},
+		{"path": "vendor/lib/lib.go", "type": "blob", "mode": "100644", "size": 9000},
+		{"path": "latest", "type": "blob", "mode": "120000", "size": 10},
+		{"path": "extern/sdk", "type": "commit", "mode": "160000"}
+	]}`
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			expectPath(t, "/repos/owner/repo/git/trees/main").andThen(
+				expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(mockResponse(t, http.StatusOK, tree)),
+			),
+		),
+	)
+	_, handler := GetCodebaseStats(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "owner",
+		"repo":    "repo",
+		"exclude": []any{"vendor"},
+		"top":     float64(2),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var stats codebaseStats
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &stats))
+	assert.Equal(t, codebaseStats{
+		Ref:   "main",
+		Files: 4,
+		Bytes: 4000,
+		Languages: []codebaseCount{
+			{Name: "Go", Files: 2, Bytes: 2000, Percent: 50},
+			{Name: "TypeScript", Files: 1, Bytes: 1500, Percent: 37.5},
+			{Name: "Markdown", Files: 1, Bytes: 500, Percent: 12.5},
+		},
+		Extensions: []codebaseCount{
+			{Name: ".go", Files: 2, Bytes: 2000, Percent: 50},
+			{Name: ".ts", Files: 1, Bytes: 1500, Percent: 37.5},
+		},
+		LargestFiles: []codebaseFile{
+			{Path: "web/app.ts", Bytes: 1500},
+			{Path: "pkg/server.go", Bytes: 1400},
+		},
+	}, stats)
+}
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v69/github"
+)
+
+// codeownersLocations are the paths GitHub looks for a CODEOWNERS file in, in order of precedence.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule is a single line of a CODEOWNERS file.
+type codeownersRule struct {
+	Pattern string
+	Owners  []string
+	Line    int
+	re      *regexp.Regexp
+}
+
+// codeowners is a parsed CODEOWNERS file.
+type codeowners struct {
+	Path  string
+	Rules []codeownersRule
+}
+
+// parseCodeowners parses the contents of a CODEOWNERS file. Lines that cannot be parsed are skipped,
+// which matches GitHub's behaviour of ignoring invalid entries.
+func parseCodeowners(path, content string) *codeowners {
+	co := &codeowners{Path: path}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		// Strip trailing comments
+		if i := strings.Index(text, " #"); i != -1 {
+			text = strings.TrimSpace(text[:i])
+		}
+		fields := strings.Fields(text)
+		re, err := codeownersPatternToRegexp(fields[0])
+		if err != nil {
+			continue
+		}
+		co.Rules = append(co.Rules, codeownersRule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			Line:    line,
+			re:      re,
+		})
+	}
+	return co
+}
+
+// codeownersPatternToRegexp converts a CODEOWNERS pattern, which follows most gitignore rules, into a regular expression
+// matching repository relative file paths.
+func codeownersPatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	// Patterns with a leading or inner slash are relative to the repository root, others match at any depth.
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if dirOnly {
+		sb.WriteString("/.*$")
+	} else {
+		// A pattern matching a directory also matches everything inside it.
+		sb.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(sb.String())
+}
+
+// Match returns the rule that applies to the given path, following the CODEOWNERS rule that the last matching
+// pattern takes precedence. It returns nil if no rule matches.
+func (co *codeowners) Match(path string) *codeownersRule {
+	if co == nil {
+		return nil
+	}
+	path = strings.TrimPrefix(path, "/")
+	for i := len(co.Rules) - 1; i >= 0; i-- {
+		if co.Rules[i].re.MatchString(path) {
+			return &co.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Owners returns the owners of the given path, or nil if it has none.
+func (co *codeowners) Owners(path string) []string {
+	if rule := co.Match(path); rule != nil {
+		return rule.Owners
+	}
+	return nil
+}
+
+// getCodeowners fetches and parses the CODEOWNERS file of a repository at the given ref, checking each of the
+// locations GitHub supports. It returns nil, without an error, when the repository has no CODEOWNERS file.
+func getCodeowners(ctx context.Context, client *github.Client, owner, repo, ref string) (*codeowners, error) {
+	for _, path := range codeownersLocations {
+		fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %w", path, err)
+		}
+		if fileContent == nil {
+			continue
+		}
+		content, err := fileContent.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return parseCodeowners(path, content), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CodeownersMatch(t *testing.T) {
+	content := `# Default owners
+*       @org/everyone
+
+# Go code
+*.go    @org/gophers
+/docs/  @org/docs-team
+apps/   @org/apps
+/build/logs/ @org/build
+/scripts/**/deploy.sh @org/ops
+vendor/**  # explicitly unowned
+`
+	co := parseCodeowners(".github/CODEOWNERS", content)
+	assert.Len(t, co.Rules, 7)
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "README.md", expected: []string{"@org/everyone"}},
+		{path: "main.go", expected: []string{"@org/gophers"}},
+		{path: "pkg/github/server.go", expected: []string{"@org/gophers"}},
+		{path: "docs/index.md", expected: []string{"@org/docs-team"}},
+		{path: "docs/api/server.go", expected: []string{"@org/docs-team"}},
+		{path: "nested/docs/index.md", expected: []string{"@org/everyone"}},
+		{path: "apps/web/main.js", expected: []string{"@org/apps"}},
+		{path: "services/apps/main.js", expected: []string{"@org/apps"}},
+		{path: "build/logs/output.log", expected: []string{"@org/build"}},
+		{path: "build/output.log", expected: []string{"@org/everyone"}},
+		{path: "scripts/deploy.sh", expected: []string{"@org/ops"}},
+		{path: "scripts/prod/eu/deploy.sh", expected: []string{"@org/ops"}},
+		{path: "vendor/github.com/foo/bar.go", expected: []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.ElementsMatch(t, tc.expected, co.Owners(tc.path))
+		})
+	}
+}
+
+func Test_CodeownersNil(t *testing.T) {
+	var co *codeowners
+	assert.Nil(t, co.Match("main.go"))
+	assert.Nil(t, co.Owners("main.go"))
+}
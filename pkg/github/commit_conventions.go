@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultMaxSubjectLength is the default maximum length of the subject line of a commit message.
+const defaultMaxSubjectLength = 72
+
+// conventionalCommitTypes are the types allowed in conventional commit subjects unless others are given.
+var conventionalCommitTypes = []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+// conventionalSubjectPattern matches a conventional commit subject, with the groups: the type, the scope and the
+// description.
+var conventionalSubjectPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()]*)\))?!?: (.*)$`)
+
+// commitConventions are the rules commit messages are checked against.
+type commitConventions struct {
+	Conventional     bool
+	Types            []string
+	TicketPattern    *regexp.Regexp
+	MaxSubjectLength int
+}
+
+// commitViolation is a rule a commit message breaks.
+type commitViolation struct {
+	SHA     string `json:"sha"`
+	Subject string `json:"subject"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// commitConventionsReport is the result of check_commit_conventions.
+type commitConventionsReport struct {
+	PullNumber     int               `json:"pull_number"`
+	CommitsChecked int               `json:"commits_checked"`
+	MergesSkipped  int               `json:"merges_skipped,omitempty"`
+	Passed         bool              `json:"passed"`
+	Violations     []commitViolation `json:"violations"`
+	ReviewURL      string            `json:"review_url,omitempty"`
+}
+
+// check returns the rules a commit message breaks.
+func (c commitConventions) check(sha, message string) []commitViolation {
+	subject, body, _ := strings.Cut(message, "\n")
+	subject = strings.TrimRight(subject, " \r")
+	violation := func(rule, format string, args ...any) commitViolation {
+		return commitViolation{SHA: sha, Subject: subject, Rule: rule, Message: fmt.Sprintf(format, args...)}
+	}
+
+	var violations []commitViolation
+	if c.Conventional {
+		m := conventionalSubjectPattern.FindStringSubmatch(subject)
+		switch {
+		case m == nil:
+			violations = append(violations, violation("conventional", "subject is not of the form type(scope): description"))
+		case !slices.Contains(c.Types, strings.ToLower(m[1])):
+			violations = append(violations, violation("conventional", "type %q is not one of %s", m[1], strings.Join(c.Types, ", ")))
+		case strings.TrimSpace(m[3]) == "":
+			violations = append(violations, violation("conventional", "description is empty"))
+		}
+	}
+	if c.TicketPattern != nil && !c.TicketPattern.MatchString(message) {
+		violations = append(violations, violation("ticket", "message does not reference a ticket matching %s", c.TicketPattern))
+	}
+	if length := len([]rune(subject)); c.MaxSubjectLength > 0 && length > c.MaxSubjectLength {
+		violations = append(violations, violation("subject_length", "subject is %d characters long, the maximum is %d", length, c.MaxSubjectLength))
+	}
+	if body != "" && !strings.HasPrefix(body, "\n") && !strings.HasPrefix(body, "\r\n") {
+		violations = append(violations, violation("blank_line", "subject is not followed by a blank line"))
+	}
+	return violations
+}
+
+// commitConventionsReviewBody returns the body of a review comment listing the violations.
+func commitConventionsReviewBody(violations []commitViolation) string {
+	var sb strings.Builder
+	sb.WriteString("Some commit messages do not follow the commit conventions of this repository:\n\n")
+	for _, v := range violations {
+		fmt.Fprintf(&sb, "- %s `%s`: %s\n", v.SHA, v.Subject, v.Message)
+	}
+	sb.WriteString("\nPlease reword these commits, e.g. with `git rebase -i`.")
+	return sb.String()
+}
+
+// CheckCommitConventions creates a tool to check the commit messages of a pull request against the conventions of a
+// repository.
+func CheckCommitConventions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_commit_conventions",
+			mcp.WithDescription(t("TOOL_CHECK_COMMIT_CONVENTIONS_DESCRIPTION", "Check the commit messages of a pull request against commit conventions: conventional commit subjects, a ticket ID pattern, a maximum subject length and a blank line after the subject. Merge commits are skipped. With post_comment, also posts a review comment listing the violations, if any.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_COMMIT_CONVENTIONS_USER_TITLE", "Check commit conventions"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithBoolean("conventional",
+				mcp.Description("Require conventional commit subjects, type(scope): description, defaults to true"),
+			),
+			mcp.WithArray("types",
+				mcp.Description(fmt.Sprintf("Types allowed in conventional commit subjects, defaults to %s", strings.Join(conventionalCommitTypes, ", "))),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("ticket_pattern",
+				mcp.Description("Regular expression the message must match to reference a ticket, e.g. [A-Z]+-[0-9]+"),
+			),
+			mcp.WithNumber("max_subject_length",
+				mcp.Description(fmt.Sprintf("Maximum length of the subject line, defaults to %d", defaultMaxSubjectLength)),
+				mcp.Min(1),
+			),
+			mcp.WithBoolean("post_comment",
+				mcp.Description("Post a review comment on the pull request listing the violations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCheckCommitConventionsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			conventions := commitConventions{
+				Conventional:     params.Conventional,
+				Types:            conventionalCommitTypes,
+				MaxSubjectLength: params.MaxSubjectLength,
+			}
+			if _, ok := request.GetArguments()["conventional"]; !ok {
+				conventions.Conventional = true
+			}
+			if len(params.Types) > 0 {
+				conventions.Types = nil
+				for _, typ := range params.Types {
+					conventions.Types = append(conventions.Types, strings.ToLower(typ))
+				}
+			}
+			if conventions.MaxSubjectLength <= 0 {
+				conventions.MaxSubjectLength = defaultMaxSubjectLength
+			}
+			if params.TicketPattern != "" {
+				if conventions.TicketPattern, err = regexp.Compile(params.TicketPattern); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid ticket_pattern: %s", err)), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			report := commitConventionsReport{PullNumber: params.PullNumber, Violations: []commitViolation{}}
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				commits, resp, err := client.PullRequests.ListCommits(ctx, params.Owner, params.Repo, params.PullNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, commit := range commits {
+					if len(commit.Parents) > 1 {
+						report.MergesSkipped++
+						continue
+					}
+					report.CommitsChecked++
+					report.Violations = append(report.Violations, conventions.check(commit.GetSHA(), commit.GetCommit().GetMessage())...)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			report.Passed = len(report.Violations) == 0
+
+			if params.PostComment && !report.Passed {
+				review, resp, err := client.PullRequests.CreateReview(ctx, params.Owner, params.Repo, params.PullNumber, &github.PullRequestReviewRequest{
+					Body:  github.Ptr(commitConventionsReviewBody(report.Violations)),
+					Event: github.Ptr("COMMENT"),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create review: %w", err)
+				}
+				_ = resp.Body.Close()
+				report.ReviewURL = review.GetHTMLURL()
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
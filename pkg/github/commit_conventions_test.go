@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CommitConventionsCheck(t *testing.T) {
+	conventions := commitConventions{
+		Conventional:     true,
+		Types:            conventionalCommitTypes,
+		TicketPattern:    regexp.MustCompile(`[A-Z]+-[0-9]+`),
+		MaxSubjectLength: 30,
+	}
+	rules := func(message string) []string {
+		var rules []string
+		for _, v := range conventions.check("sha", message) {
+			rules = append(rules, v.Rule)
+		}
+		return rules
+	}
+
+	assert.Empty(t, rules("feat(api): add search\n\nRefs OPS-12"))
+	assert.Empty(t, rules("fix!: drop v1 OPS-3"))
+	assert.Equal(t, []string{"conventional"}, rules("Add search OPS-12"))
+	assert.Equal(t, []string{"conventional"}, rules("feature: add search OPS-12"))
+	assert.Equal(t, []string{"ticket"}, rules("docs: fix typo"))
+	assert.Equal(t, []string{"subject_length"}, rules("refactor: split the very long handler OPS-1"))
+	assert.Equal(t, []string{"blank_line"}, rules("test: cover search OPS-12\nmore details"))
+}
+
+func Test_CheckCommitConventions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CheckCommitConventions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "check_commit_conventions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "conventional")
+	assert.Contains(t, tool.InputSchema.Properties, "types")
+	assert.Contains(t, tool.InputSchema.Properties, "ticket_pattern")
+	assert.Contains(t, tool.InputSchema.Properties, "max_subject_length")
+	assert.Contains(t, tool.InputSchema.Properties, "post_comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	commits := []*github.RepositoryCommit{
+		{SHA: github.Ptr("aaa"), Commit: &github.Commit{Message: github.Ptr("feat: add search")}, Parents: []*github.Commit{{SHA: github.Ptr("base")}}},
+		{SHA: github.Ptr("bbb"), Commit: &github.Commit{Message: github.Ptr("Fix tests")}, Parents: []*github.Commit{{SHA: github.Ptr("aaa")}}},
+		{SHA: github.Ptr("ccc"), Commit: &github.Commit{Message: github.Ptr("Merge branch 'main' into search")}, Parents: []*github.Commit{{SHA: github.Ptr("bbb")}, {SHA: github.Ptr("main")}}},
+	}
+	listCommits := mock.WithRequestMatchHandler(
+		mock.GetReposPullsCommitsByOwnerByRepoByPullNumber,
+		expectPath(t, "/repos/owner/repo/pulls/42/commits").andThen(mockResponse(t, http.StatusOK, commits)),
+	)
+
+	t.Run("reports violations", func(t *testing.T) {
+		_, handler := CheckCommitConventions(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(listCommits))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+			"types":      []any{"feat", "fix"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report commitConventionsReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, commitConventionsReport{
+			PullNumber:     42,
+			CommitsChecked: 2,
+			MergesSkipped:  1,
+			Violations: []commitViolation{
+				{SHA: "bbb", Subject: "Fix tests", Rule: "conventional", Message: "subject is not of the form type(scope): description"},
+			},
+		}, report)
+	})
+
+	t.Run("posts a review comment", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			listCommits,
+			mock.WithRequestMatchHandler(
+				mock.PostReposPullsReviewsByOwnerByRepoByPullNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var review github.PullRequestReviewRequest
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+					assert.Equal(t, "COMMENT", review.GetEvent())
+					assert.Contains(t, review.GetBody(), "- aaa `feat: add search`: message does not reference a ticket matching JIRA-[0-9]+\n")
+					assert.Equal(t, 2, strings.Count(review.GetBody(), "JIRA-[0-9]+"))
+					mockResponse(t, http.StatusOK, &github.PullRequestReview{
+						HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-1"),
+					})(w, r)
+				}),
+			),
+		)
+		_, handler := CheckCommitConventions(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"pullNumber":     float64(42),
+			"conventional":   false,
+			"ticket_pattern": "JIRA-[0-9]+",
+			"post_comment":   true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report commitConventionsReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.False(t, report.Passed)
+		assert.Len(t, report.Violations, 2)
+		assert.Equal(t, "https://github.com/owner/repo/pull/42#pullrequestreview-1", report.ReviewURL)
+	})
+
+	t.Run("rejects an invalid ticket pattern", func(t *testing.T) {
+		_, handler := CheckCommitConventions(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"pullNumber":     float64(42),
+			"ticket_pattern": "[A-Z",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "invalid ticket_pattern")
+	})
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"bytes"
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// binarySniffLength is how much of a file is inspected to tell text from binary content.
+const binarySniffLength = 8000
+
+// detectMIMEType returns the MIME type of a file, preferring the Content-Type reported by GitHub, then the file
+// extension, then sniffing the data itself.
+func detectMIMEType(name string, data []byte, contentType string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == ".md" {
+		return "text/markdown"
+	}
+	// Raw content is often served as text/plain or application/octet-stream whatever the file is
+	if contentType != "" && !strings.HasPrefix(contentType, "text/plain") && contentType != "application/octet-stream" {
+		return contentType
+	}
+	if byExt := mime.TypeByExtension(ext); byExt != "" {
+		return byExt
+	}
+	if contentType != "" && !isBinaryContent(data) {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
+
+// isBinaryContent reports whether data looks like a binary file rather than text, the same way git does: by
+// looking for a NUL byte near the start. Invalid UTF-8 is treated as binary too, as it cannot be returned as text.
+func isBinaryContent(data []byte) bool {
+	sniff := data[:min(len(data), binarySniffLength)]
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return true
+	}
+	if len(sniff) < len(data) {
+		// The prefix may end in the middle of a multi-byte character, which is not a sign of binary content
+		for i := len(sniff) - 1; i >= 0 && i >= len(sniff)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(sniff[i]) {
+				sniff = sniff[:i]
+				break
+			}
+		}
+	}
+	return !utf8.Valid(sniff)
+}
+
+// isTextMIMEType reports whether the given MIME type describes text.
+func isTextMIMEType(mimeType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		mediaType = mimeType
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json", mediaType == "application/xml", mediaType == "application/javascript",
+		mediaType == "application/x-yaml", mediaType == "application/yaml", mediaType == "image/svg+xml":
+		return true
+	case strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	return false
+}
+
+// newResourceContents returns file data as text resource contents when it is text, and as base64 encoded blob
+// contents otherwise, with its detected MIME type. A specific Content-Type reported by GitHub is trusted, otherwise
+// whether data is text is decided by its content, as extensions are ambiguous (.ts is both TypeScript and an MPEG
+// transport stream).
+func newResourceContents(uri, name string, data []byte, contentType string) mcp.ResourceContents {
+	mimeType := detectMIMEType(name, data, contentType)
+	text := !isBinaryContent(data)
+	if mimeType == contentType {
+		text = isTextMIMEType(mimeType)
+	}
+	if text {
+		if !isTextMIMEType(mimeType) {
+			mimeType = "text/plain"
+		}
+		return mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: mimeType,
+			Text:     string(data),
+		}
+	}
+	return mcp.BlobResourceContents{
+		URI:      uri,
+		MIMEType: mimeType,
+		Blob:     base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+// newFileContent returns file data as tool result content: text as text, images as image content that multimodal
+// clients can display, and any other binary data as an embedded base64 blob resource.
+func newFileContent(uri, name string, data []byte, contentType string) mcp.Content {
+	return resourceToolContent(newResourceContents(uri, name, data, contentType))
+}
+
+// resourceToolContent converts resource contents created by newResourceContents to tool result content.
+func resourceToolContent(resource mcp.ResourceContents) mcp.Content {
+	switch resource := resource.(type) {
+	case mcp.TextResourceContents:
+		return mcp.NewTextContent(resource.Text)
+	case mcp.BlobResourceContents:
+		if strings.HasPrefix(resource.MIMEType, "image/") {
+			return mcp.NewImageContent(resource.Blob, resource.MIMEType)
+		}
+		return mcp.NewEmbeddedResource(resource)
+	default:
+		return nil
+	}
+}
+
+// resourceMIMEType returns the MIME type of resource contents.
+func resourceMIMEType(resource mcp.ResourceContents) string {
+	switch resource := resource.(type) {
+	case mcp.TextResourceContents:
+		return resource.MIMEType
+	case mcp.BlobResourceContents:
+		return resource.MIMEType
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func Test_IsBinaryContent(t *testing.T) {
+	assert.False(t, isBinaryContent([]byte("package main\n")))
+	assert.False(t, isBinaryContent([]byte("héllo wörld")))
+	assert.True(t, isBinaryContent(pngHeader))
+	assert.True(t, isBinaryContent([]byte{0xff, 0xfe, 0xfd}))
+
+	// A multi-byte character cut at the end of the sniffed prefix is still text
+	long := strings.Repeat("a", binarySniffLength-1) + "é"
+	assert.False(t, isBinaryContent([]byte(long)))
+}
+
+func Test_NewResourceContents(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileName     string
+		data         []byte
+		contentType  string
+		expectedMIME string
+		expectedText bool
+	}{
+		{
+			name:         "markdown",
+			fileName:     "README.md",
+			data:         []byte("# Title"),
+			contentType:  "text/plain; charset=utf-8",
+			expectedMIME: "text/markdown",
+			expectedText: true,
+		},
+		{
+			// The MIME type of .ts depends on the system's MIME tables, so only the kind of contents is checked
+			name:         "typescript is text whatever its extension says",
+			fileName:     "index.ts",
+			data:         []byte("export const a = 1;\n"),
+			contentType:  "text/plain; charset=utf-8",
+			expectedText: true,
+		},
+		{
+			name:         "image detected by extension",
+			fileName:     "logo.png",
+			data:         pngHeader,
+			contentType:  "application/octet-stream",
+			expectedMIME: "image/png",
+		},
+		{
+			name:         "image detected by content",
+			fileName:     "logo",
+			data:         pngHeader,
+			expectedMIME: "image/png",
+		},
+		{
+			name:         "reported content type is trusted",
+			fileName:     "data.json",
+			data:         []byte(`{"a":1}`),
+			contentType:  "application/json",
+			expectedMIME: "application/json",
+			expectedText: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			contents := newResourceContents("repo://file", tc.fileName, tc.data, tc.contentType)
+			if tc.expectedText {
+				text, ok := contents.(mcp.TextResourceContents)
+				require.True(t, ok, "expected text contents, got %T", contents)
+				if tc.expectedMIME != "" {
+					assert.Equal(t, tc.expectedMIME, text.MIMEType)
+				}
+				assert.Equal(t, string(tc.data), text.Text)
+				return
+			}
+			blob, ok := contents.(mcp.BlobResourceContents)
+			require.True(t, ok, "expected blob contents, got %T", contents)
+			assert.Equal(t, tc.expectedMIME, blob.MIMEType)
+			decoded, err := base64.StdEncoding.DecodeString(blob.Blob)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(tc.data, decoded))
+		})
+	}
+}
+
+func Test_NewFileContent(t *testing.T) {
+	text, ok := newFileContent("repo://a", "main.go", []byte("package main"), "").(mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "package main", text.Text)
+
+	image, ok := newFileContent("repo://b", "logo.png", pngHeader, "").(mcp.ImageContent)
+	require.True(t, ok)
+	assert.Equal(t, "image/png", image.MIMEType)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(pngHeader), image.Data)
+
+	archive := []byte("PK\x03\x04\x14\x00\x00\x00")
+	resource, ok := newFileContent("repo://c", "bundle.zip", archive, "").(mcp.EmbeddedResource)
+	require.True(t, ok)
+	blob, ok := resource.Resource.(mcp.BlobResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "repo://c", blob.URI)
+	assert.Equal(t, "application/zip", blob.MIMEType)
+}
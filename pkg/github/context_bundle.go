@@ -0,0 +1,322 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultContextBundleBytes is the default size budget of build_context_bundle, about 25k tokens.
+	defaultContextBundleBytes = 100_000
+
+	// maxContextBundlePaths bounds the files a bundle is built from, after expanding directories and symbols.
+	maxContextBundlePaths = 50
+
+	// contextBundleSymbolFiles is the number of files included for each symbol searched.
+	contextBundleSymbolFiles = 3
+
+	// contextBundleCommitsPerFile is the number of recent commits of each file whose pull requests are included.
+	contextBundleCommitsPerFile = 3
+
+	// maxContextBundlePullRequests bounds the pull requests listed in a bundle.
+	maxContextBundlePullRequests = 10
+)
+
+// isReadme reports whether a file is a README.
+func isReadme(filePath string) bool {
+	name := strings.ToLower(path.Base(filePath))
+	return name == "readme" || strings.HasPrefix(name, "readme.")
+}
+
+// codeFence returns a fence for a Markdown code block holding the content, longer than any backtick run in it.
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// contextBundle accumulates the sections of a bundle within its size budget.
+type contextBundle struct {
+	remaining int
+	files     strings.Builder
+	omitted   []string
+}
+
+// add adds a file to the bundle, truncating it to the remaining budget. Once the budget is spent, files are only
+// listed as omitted.
+func (b *contextBundle) add(filePath, content string) {
+	if b.remaining <= 0 {
+		b.omitted = append(b.omitted, fmt.Sprintf("%s (%d bytes)", filePath, len(content)))
+		return
+	}
+	truncated := false
+	if len(content) > b.remaining {
+		// Cut at the start of a character rather than within it
+		n := b.remaining
+		for n > 0 && !utf8.RuneStart(content[n]) {
+			n--
+		}
+		content = content[:n]
+		truncated = true
+	}
+	b.remaining -= len(content)
+	if truncated {
+		b.remaining = 0
+	}
+
+	fence := codeFence(content)
+	lang := strings.TrimPrefix(path.Ext(filePath), ".")
+	fmt.Fprintf(&b.files, "### %s\n\n%s%s\n%s", filePath, fence, lang, content)
+	if !strings.HasSuffix(content, "\n") {
+		b.files.WriteString("\n")
+	}
+	fmt.Fprintf(&b.files, "%s\n\n", fence)
+	if truncated {
+		fmt.Fprintf(&b.files, "_%s was truncated to fit the size budget._\n\n", filePath)
+	}
+}
+
+// BuildContextBundle creates a tool to gather files, the READMEs of their directories and their recent pull
+// requests into a single document.
+func BuildContextBundle(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("build_context_bundle",
+			mcp.WithDescription(t("TOOL_BUILD_CONTEXT_BUNDLE_DESCRIPTION", "Gather the context needed for a task in one call: the given files, the files of the given directories, the files defining or using the given symbols, the READMEs of their directories, and the recent pull requests that changed them, as a single Markdown document within a size budget. Symbols are found with code search, which only covers the default branch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BUILD_CONTEXT_BUNDLE_USER_TITLE", "Build context bundle"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Files or directories to include, directories with all their files"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("symbols",
+				mcp.Description(fmt.Sprintf("Functions, types or other identifiers, the %d files best matching each one are included", contextBundleSymbolFiles)),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit, defaults to the default branch"),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("Size budget of the file contents, defaults to %d", defaultContextBundleBytes)),
+				mcp.Min(1),
+			),
+			mcp.WithBoolean("include_readmes",
+				mcp.Description("Include the READMEs of the directories of the files, defaults to true"),
+			),
+			mcp.WithBoolean("include_pull_requests",
+				mcp.Description("List the recent pull requests that changed the files, defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseBuildContextBundleParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(params.Paths) == 0 && len(params.Symbols) == 0 {
+				return mcp.NewToolResultError("at least one path or symbol is required"), nil
+			}
+			if params.MaxBytes <= 0 {
+				params.MaxBytes = defaultContextBundleBytes
+			}
+			if _, ok := request.GetArguments()["include_readmes"]; !ok {
+				params.IncludeReadmes = true
+			}
+			if _, ok := request.GetArguments()["include_pull_requests"]; !ok {
+				params.IncludePullRequests = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref := params.Ref
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, ref, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree of %s: %w", ref, err)
+			}
+			_ = resp.Body.Close()
+			blobs := make(map[string]*github.TreeEntry)
+			var blobPaths []string
+			for _, entry := range tree.Entries {
+				if entry.GetType() == "blob" && entry.GetMode() != "120000" {
+					blobs[entry.GetPath()] = entry
+					blobPaths = append(blobPaths, entry.GetPath())
+				}
+			}
+			sort.Strings(blobPaths)
+
+			var notes []string
+			var files []string
+			seen := make(map[string]bool)
+			addFile := func(filePath string) {
+				if !seen[filePath] {
+					seen[filePath] = true
+					files = append(files, filePath)
+				}
+			}
+			for _, p := range params.Paths {
+				p = strings.Trim(p, "/")
+				if _, ok := blobs[p]; ok {
+					addFile(p)
+					continue
+				}
+				found := false
+				for _, blobPath := range blobPaths {
+					if p == "" || strings.HasPrefix(blobPath, p+"/") {
+						addFile(blobPath)
+						found = true
+					}
+				}
+				if !found {
+					notes = append(notes, fmt.Sprintf("%s was not found at %s", p, ref))
+				}
+			}
+			for _, symbol := range params.Symbols {
+				query := fmt.Sprintf("%s repo:%s/%s", symbol, params.Owner, params.Repo)
+				results, resp, err := client.Search.Code(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: contextBundleSymbolFiles}})
+				if err != nil {
+					return nil, fmt.Errorf("failed to search code for %s: %w", symbol, err)
+				}
+				_ = resp.Body.Close()
+				if len(results.CodeResults) == 0 {
+					notes = append(notes, fmt.Sprintf("no file matches %s", symbol))
+				}
+				for _, result := range results.CodeResults {
+					if _, ok := blobs[result.GetPath()]; ok {
+						addFile(result.GetPath())
+					}
+				}
+			}
+			if len(files) > maxContextBundlePaths {
+				notes = append(notes, fmt.Sprintf("only the first %d of %d files are included", maxContextBundlePaths, len(files)))
+				files = files[:maxContextBundlePaths]
+			}
+
+			// The READMEs of the directories of the files and of their parents, nearest first
+			var readmes []string
+			if params.IncludeReadmes {
+				for _, filePath := range files {
+					for dir := path.Dir(filePath); ; dir = path.Dir(dir) {
+						for _, blobPath := range blobPaths {
+							if path.Dir(blobPath) == dir && isReadme(blobPath) && !seen[blobPath] {
+								seen[blobPath] = true
+								readmes = append(readmes, blobPath)
+							}
+						}
+						if dir == "." {
+							break
+						}
+					}
+				}
+			}
+
+			bundle := contextBundle{remaining: params.MaxBytes}
+			for _, filePath := range append(files, readmes...) {
+				data, resp, err := client.Git.GetBlobRaw(ctx, params.Owner, params.Repo, blobs[filePath].GetSHA())
+				if err != nil {
+					return nil, fmt.Errorf("failed to get %s: %w", filePath, err)
+				}
+				_ = resp.Body.Close()
+				if isBinaryContent(data) {
+					notes = append(notes, fmt.Sprintf("%s is binary and was left out", filePath))
+					continue
+				}
+				bundle.add(filePath, string(data))
+			}
+
+			var pullRequests []string
+			if params.IncludePullRequests {
+				seenPullRequests := make(map[int]bool)
+			collect:
+				for _, filePath := range files {
+					commits, resp, err := client.Repositories.ListCommits(ctx, params.Owner, params.Repo, &github.CommitsListOptions{
+						SHA:         ref,
+						Path:        filePath,
+						ListOptions: github.ListOptions{PerPage: contextBundleCommitsPerFile},
+					})
+					if err != nil {
+						return nil, fmt.Errorf("failed to list commits of %s: %w", filePath, err)
+					}
+					_ = resp.Body.Close()
+					for _, commit := range commits {
+						prs, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, params.Owner, params.Repo, commit.GetSHA(), nil)
+						if err != nil {
+							return nil, fmt.Errorf("failed to list pull requests of commit %s: %w", commit.GetSHA(), err)
+						}
+						_ = resp.Body.Close()
+						for _, pr := range prs {
+							if seenPullRequests[pr.GetNumber()] {
+								continue
+							}
+							seenPullRequests[pr.GetNumber()] = true
+							line := fmt.Sprintf("- #%d %s (%s", pr.GetNumber(), pr.GetTitle(), pr.GetState())
+							if pr.MergedAt != nil {
+								line += ", merged " + pr.GetMergedAt().Format("2006-01-02")
+							}
+							pullRequests = append(pullRequests, fmt.Sprintf("%s) %s, changed `%s`", line, pr.GetHTMLURL(), filePath))
+							if len(pullRequests) == maxContextBundlePullRequests {
+								break collect
+							}
+						}
+					}
+				}
+			}
+
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "# Context bundle for %s/%s at %s\n\n", params.Owner, params.Repo, ref)
+			if tree.GetTruncated() {
+				notes = append(notes, "the repository tree is too large to list completely, some files may be missing")
+			}
+			if len(bundle.omitted) > 0 {
+				notes = append(notes, "over the size budget, left out: "+strings.Join(bundle.omitted, ", "))
+			}
+			for _, note := range notes {
+				fmt.Fprintf(&sb, "> Note: %s\n", note)
+			}
+			if len(notes) > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("## Files\n\n")
+			sb.WriteString(bundle.files.String())
+			if len(pullRequests) > 0 {
+				sb.WriteString("## Recent pull requests\n\n")
+				sb.WriteString(strings.Join(pullRequests, "\n"))
+				sb.WriteString("\n")
+			}
+			return mcp.NewToolResultText(sb.String()), nil
+		}
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CodeFence(t *testing.T) {
+	assert.Equal(t, "```", codeFence("func main() {}"))
+	assert.Equal(t, "````", codeFence("Run:\n```sh\nmake\n```\n"))
+	assert.Equal(t, "`````", codeFence("a ```` b ` c"))
+}
+
+func Test_BuildContextBundle(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BuildContextBundle(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "build_context_bundle", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "symbols")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "max_bytes")
+	assert.Contains(t, tool.InputSchema.Properties, "include_readmes")
+	assert.Contains(t, tool.InputSchema.Properties, "include_pull_requests")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tree := &github.Tree{Entries: []*github.TreeEntry{
+		{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("readme-sha")},
+		{Path: github.Ptr("logo.png"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("logo-sha")},
+		{Path: github.Ptr("pkg"), Type: github.Ptr("tree"), Mode: github.Ptr("040000"), SHA: github.Ptr("pkg-sha")},
+		{Path: github.Ptr("pkg/a/README.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("a-readme-sha")},
+		{Path: github.Ptr("pkg/a/a.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("a-sha")},
+		{Path: github.Ptr("pkg/a/logo.png"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("logo-sha")},
+		{Path: github.Ptr("pkg/b/b.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("b-sha")},
+	}}
+	blobs := map[string]string{
+		"readme-sha":   "# Project\n",
+		"logo-sha":     "\x89PNG\r\n\x1a\n\x00\x00",
+		"a-readme-sha": "# Package a\n\n```go\na.Run()\n```\n",
+		"a-sha":        "package a\n\nfunc Run() {}\n",
+		"b-sha":        "package b\n\ntype Handler struct{}\n",
+	}
+	options := []mock.MockBackendOption{
+		mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			expectPath(t, "/repos/owner/repo/git/trees/main").andThen(
+				expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(mockResponse(t, http.StatusOK, tree)),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, blobs[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/git/blobs/")])(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetSearchCode,
+			expectQueryParams(t, map[string]string{"q": "Handler repo:owner/repo", "per_page": "3"}).andThen(
+				mockResponse(t, http.StatusOK, &github.CodeSearchResult{CodeResults: []*github.CodeResult{
+					{Path: github.Ptr("pkg/b/b.go")},
+				}}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "main", r.URL.Query().Get("sha"))
+				mockResponse(t, http.StatusOK, []*github.RepositoryCommit{
+					{SHA: github.Ptr(strings.ReplaceAll(r.URL.Query().Get("path"), "/", "-"))},
+				})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				number := 7
+				if strings.HasSuffix(r.URL.Path, "/commits/pkg-b-b.go/pulls") {
+					number = 8
+				}
+				mockResponse(t, http.StatusOK, []*github.PullRequest{{
+					Number:   github.Ptr(number),
+					Title:    github.Ptr("Change things"),
+					State:    github.Ptr("closed"),
+					MergedAt: &github.Timestamp{Time: time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)},
+					HTMLURL:  github.Ptr(fmt.Sprintf("https://github.com/owner/repo/pull/%d", number)),
+				}})(w, r)
+			}),
+		),
+	}
+
+	t.Run("bundles files, readmes and pull requests", func(t *testing.T) {
+		_, handler := BuildContextBundle(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"paths":   []any{"pkg/a/", "missing"},
+			"symbols": []any{"Handler"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		assert.Equal(t, "# Context bundle for owner/repo at main\n\n"+
+			"> Note: missing was not found at main\n"+
+			"> Note: pkg/a/logo.png is binary and was left out\n\n"+
+			"## Files\n\n"+
+			"### pkg/a/README.md\n\n````md\n# Package a\n\n```go\na.Run()\n```\n````\n\n"+
+			"### pkg/a/a.go\n\n```go\npackage a\n\nfunc Run() {}\n```\n\n"+
+			"### pkg/b/b.go\n\n```go\npackage b\n\ntype Handler struct{}\n```\n\n"+
+			"### README.md\n\n```md\n# Project\n```\n\n"+
+			"## Recent pull requests\n\n"+
+			"- #7 Change things (closed, merged 2026-09-01) https://github.com/owner/repo/pull/7, changed `pkg/a/README.md`\n"+
+			"- #8 Change things (closed, merged 2026-09-01) https://github.com/owner/repo/pull/8, changed `pkg/b/b.go`\n",
+			textContent.Text)
+	})
+
+	t.Run("keeps to the size budget", func(t *testing.T) {
+		_, handler := BuildContextBundle(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":                 "owner",
+			"repo":                  "repo",
+			"ref":                   "main",
+			"paths":                 []any{"pkg/a/a.go", "pkg/b/b.go"},
+			"max_bytes":             float64(20),
+			"include_readmes":       false,
+			"include_pull_requests": false,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		assert.Equal(t, "# Context bundle for owner/repo at main\n\n"+
+			"> Note: over the size budget, left out: pkg/b/b.go (33 bytes)\n\n"+
+			"## Files\n\n"+
+			"### pkg/a/a.go\n\n```go\npackage a\n\nfunc Run(\n```\n\n"+
+			"_pkg/a/a.go was truncated to fit the size budget._\n\n",
+			textContent.Text)
+	})
+
+	t.Run("requires a path or symbol", func(t *testing.T) {
+		_, handler := BuildContextBundle(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "at least one path or symbol is required", getTextResult(t, result).Text)
+	})
+}
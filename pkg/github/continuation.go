@@ -0,0 +1,312 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultMaxResultBytes is the size above which tool results are split into pages by default.
+	DefaultMaxResultBytes = 100_000
+	// continuationTTL is how long the full result of a call stays available to fetch_more.
+	continuationTTL = time.Hour
+	// maxContinuations bounds the results kept for fetch_more, the oldest being dropped beyond it.
+	maxContinuations = 100
+)
+
+type continuation struct {
+	session string
+	payload string
+	// contents are the images and other content of the result that are not text, read after the text.
+	contents []mcp.Content
+	expires  time.Time
+}
+
+// size is the size of the whole result: the text, then the other contents.
+func (c *continuation) size() int {
+	size := len(c.payload)
+	for _, content := range c.contents {
+		size += contentSize(content)
+	}
+	return size
+}
+
+// page returns the contents of the page starting at offset, at most size bytes long, and the offset of the next
+// page, 0 when it is the last. Offsets past the text address the other contents, which are not split: a page holds
+// at least one of them, and the others that fit.
+func (c *continuation) page(offset, size int) ([]mcp.Content, int) {
+	var page []mcp.Content
+	used := 0
+	if offset < len(c.payload) {
+		text, next := resultPage(c.payload, offset, size)
+		page = append(page, mcp.NewTextContent(text))
+		if next != 0 {
+			return page, next
+		}
+		used = len(text)
+	}
+
+	pos := len(c.payload)
+	for _, content := range c.contents {
+		n := contentSize(content)
+		if pos+n <= offset {
+			pos += n
+			continue
+		}
+		if len(page) > 0 && used+n > size {
+			return page, pos
+		}
+		page = append(page, content)
+		used += n
+		pos += n
+	}
+	return page, 0
+}
+
+// ContinuationStore keeps the full results of tool calls that were too large to return at once, so that the rest
+// can be read with fetch_more instead of being lost.
+type ContinuationStore struct {
+	mu       sync.Mutex
+	maxBytes int
+	entries  map[string]*continuation
+	// order holds the IDs of the entries from the oldest to the newest.
+	order []string
+	now   func() time.Time
+}
+
+// NewContinuationStore creates an in-memory store splitting results larger than maxBytes. A size of 0 or less
+// disables splitting.
+func NewContinuationStore(maxBytes int) *ContinuationStore {
+	return &ContinuationStore{
+		maxBytes: maxBytes,
+		entries:  make(map[string]*continuation),
+		now:      time.Now,
+	}
+}
+
+// SetMaxBytes changes the size above which results are split. Results stored already keep their pages.
+func (s *ContinuationStore) SetMaxBytes(maxBytes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxBytes = maxBytes
+}
+
+func (s *ContinuationStore) pageSize() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxBytes
+}
+
+// put stores the full result of a call by the session of ctx and returns its continuation ID. Expired entries, and
+// the oldest ones beyond maxContinuations, are evicted as a side effect.
+func (s *ContinuationStore) put(ctx context.Context, payload string, contents []mcp.Content) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	kept := s.order[:0]
+	for _, k := range s.order {
+		if now.After(s.entries[k].expires) {
+			delete(s.entries, k)
+			continue
+		}
+		kept = append(kept, k)
+	}
+	s.order = kept
+	for len(s.order) >= maxContinuations {
+		delete(s.entries, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.entries[id] = &continuation{session: sessionKey(ctx), payload: payload, contents: contents, expires: now.Add(continuationTTL)}
+	s.order = append(s.order, id)
+	return id, nil
+}
+
+// get returns the full result stored under an ID for the session of ctx.
+func (s *ContinuationStore) get(ctx context.Context, id string) (*continuation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[id]
+	if !ok || entry.session != sessionKey(ctx) || s.now().After(entry.expires) {
+		return nil, false
+	}
+	return entry, true
+}
+
+// resultPage returns the page of a payload starting at offset, at most size bytes long and not splitting a
+// character, and the offset of the next page, 0 when it is the last.
+func resultPage(payload string, offset, size int) (string, int) {
+	end := offset + size
+	if end >= len(payload) {
+		return payload[offset:], 0
+	}
+	for end > offset+1 && !utf8.RuneStart(payload[end]) {
+		end--
+	}
+	return payload[offset:end], end
+}
+
+// contentSize is the size a content counts for in a page: its text, or its base64 encoded data.
+func contentSize(content mcp.Content) int {
+	switch content := content.(type) {
+	case mcp.TextContent:
+		return len(content.Text)
+	case mcp.ImageContent:
+		return len(content.Data)
+	case mcp.AudioContent:
+		return len(content.Data)
+	case mcp.EmbeddedResource:
+		switch resource := content.Resource.(type) {
+		case mcp.TextResourceContents:
+			return len(resource.Text)
+		case mcp.BlobResourceContents:
+			return len(resource.Blob)
+		}
+	}
+	return 0
+}
+
+// oversizedContent replaces a content larger than a page with a note describing it, pointing to its resource when
+// it has one.
+func oversizedContent(content mcp.Content, size int) mcp.Content {
+	var what string
+	switch content := content.(type) {
+	case mcp.ImageContent:
+		what = fmt.Sprintf("A %s image", content.MIMEType)
+	case mcp.AudioContent:
+		what = fmt.Sprintf("A %s audio clip", content.MIMEType)
+	case mcp.EmbeddedResource:
+		switch resource := content.Resource.(type) {
+		case mcp.TextResourceContents:
+			what = fmt.Sprintf("The resource %s (%s)", resource.URI, resource.MIMEType)
+		case mcp.BlobResourceContents:
+			what = fmt.Sprintf("The resource %s (%s)", resource.URI, resource.MIMEType)
+		}
+	default:
+		what = "A content"
+	}
+	return mcp.NewTextContent(fmt.Sprintf("[%s of %d bytes was left out of the result, as it is larger than the %d bytes of a page.]", what, contentSize(content), size))
+}
+
+// continuationNote tells the model how to read the rest of a result.
+func continuationNote(id string, next, total int) string {
+	return fmt.Sprintf("[The result is %d bytes long and was cut at byte %d. Call fetch_more with continuation_id %q and offset %d to read the rest.]", total, next, id, next)
+}
+
+// WithContinuation returns a middleware splitting the results larger than the maximum size of the store: the first
+// page is returned with a note telling how to read the rest with fetch_more, the full result being kept in the
+// store. Images and other content count towards the size after the text, and are left out when larger than a page.
+// Error results are returned as they are.
+func WithContinuation(store *ContinuationStore) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			size := store.pageSize()
+			if err != nil || result == nil || result.IsError || size <= 0 {
+				return result, err
+			}
+
+			var texts []string
+			var contents []mcp.Content
+			oversized := false
+			for _, content := range result.Content {
+				if text, ok := content.(mcp.TextContent); ok {
+					texts = append(texts, text.Text)
+					continue
+				}
+				if contentSize(content) > size {
+					content = oversizedContent(content, size)
+					oversized = true
+				}
+				contents = append(contents, content)
+			}
+			entry := &continuation{payload: strings.Join(texts, "\n"), contents: contents}
+			total := entry.size()
+			if total <= size {
+				if !oversized {
+					return result, nil
+				}
+				trimmed := *result
+				trimmed.Content = make([]mcp.Content, 0, len(result.Content))
+				for _, content := range result.Content {
+					if contentSize(content) > size {
+						content = oversizedContent(content, size)
+					}
+					trimmed.Content = append(trimmed.Content, content)
+				}
+				return &trimmed, nil
+			}
+
+			id, err := store.put(ctx, entry.payload, entry.contents)
+			if err != nil {
+				return nil, fmt.Errorf("failed to store the result: %w", err)
+			}
+			page, nextOffset := entry.page(0, size)
+			truncated := *result
+			truncated.Content = append(page, mcp.NewTextContent(continuationNote(id, nextOffset, total)))
+			return &truncated, nil
+		}
+		return st
+	}
+}
+
+// FetchMore creates a tool to read the rest of a result that was too large to return at once.
+func FetchMore(store *ContinuationStore, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("fetch_more",
+			mcp.WithDescription(t("TOOL_FETCH_MORE_DESCRIPTION", fmt.Sprintf("Read the rest of a tool result that was too large to return at once, a page at a time, using the continuation ID and offset given in the note of the cut result. The full results are kept for %s, for the session that made the call.", continuationTTL))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FETCH_MORE_USER_TITLE", "Fetch more of a result"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("continuation_id",
+				mcp.Required(),
+				mcp.Description("Continuation ID given in the note of the cut result"),
+			),
+			mcp.WithNumber("offset",
+				mcp.Description("Byte offset to read from, given in the note of the previous page, defaults to the start of the result"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseFetchMoreParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			id, offset := params.ContinuationID, params.Offset
+
+			entry, ok := store.get(ctx, id)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("continuation %s was not found, it may have expired: call the tool again", id)), nil
+			}
+			total := entry.size()
+			if offset < 0 || offset >= total {
+				return mcp.NewToolResultError(fmt.Sprintf("offset must be between 0 and %d", total-1)), nil
+			}
+			// Results stored before splitting was disabled can still be read
+			size := store.pageSize()
+			if size <= 0 {
+				size = DefaultMaxResultBytes
+			}
+
+			page, next := entry.page(offset, size)
+			if next != 0 {
+				page = append(page, mcp.NewTextContent(continuationNote(id, next, total)))
+			}
+			return &mcp.CallToolResult{Content: page}, nil
+		}
+}
@@ -0,0 +1,199 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is a client session with a fixed ID.
+type testSession string
+
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) SessionID() string                                   { return string(s) }
+
+// continuationNotePattern extracts the continuation ID and next offset of a note.
+var continuationNotePattern = regexp.MustCompile(`continuation_id "([0-9a-f]+)" and offset (\d+)`)
+
+func Test_resultPage(t *testing.T) {
+	page, next := resultPage("abcdef", 0, 4)
+	assert.Equal(t, "abcd", page)
+	assert.Equal(t, 4, next)
+
+	page, next = resultPage("abcdef", 4, 4)
+	assert.Equal(t, "ef", page)
+	assert.Zero(t, next)
+
+	// Characters are not split across pages
+	page, next = resultPage("aé€b", 0, 3)
+	assert.Equal(t, "aé", page)
+	assert.Equal(t, 3, next)
+	page, next = resultPage("aé€b", next, 3)
+	assert.Equal(t, "€", page)
+	assert.Equal(t, 6, next)
+}
+
+func Test_WithContinuation(t *testing.T) {
+	payload := strings.Repeat("0123456789", 25)
+	store := NewContinuationStore(100)
+	wrapped := WithContinuation(store)(server.ServerTool{
+		Tool: mcp.NewTool("list_commits"),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if request.GetArguments()["small"] == true {
+				return mcp.NewToolResultText("small"), nil
+			}
+			return mcp.NewToolResultText(payload), nil
+		},
+	})
+	_, fetchMore := FetchMore(store, translations.NullTranslationHelper)
+
+	result, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{"small": true}))
+	require.NoError(t, err)
+	assert.Equal(t, "small", getTextResult(t, result).Text)
+
+	result, err = wrapped.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	read := result.Content[0].(mcp.TextContent).Text
+	note := result.Content[1].(mcp.TextContent).Text
+	assert.Contains(t, note, "The result is 250 bytes long and was cut at byte 100")
+
+	// Follow the notes until the whole result is read
+	for {
+		match := continuationNotePattern.FindStringSubmatch(note)
+		if match == nil {
+			break
+		}
+		offset, err := strconv.Atoi(match[2])
+		require.NoError(t, err)
+		result, err := fetchMore(context.Background(), createMCPRequest(map[string]any{
+			"continuation_id": match[1],
+			"offset":          float64(offset),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		read += result.Content[0].(mcp.TextContent).Text
+		note = ""
+		if len(result.Content) > 1 {
+			note = result.Content[1].(mcp.TextContent).Text
+		}
+	}
+	assert.Equal(t, payload, read)
+
+	t.Run("continuations belong to their session", func(t *testing.T) {
+		id := store.order[len(store.order)-1]
+		ctx := server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), testSession("other"))
+		result, err := fetchMore(ctx, createMCPRequest(map[string]any{"continuation_id": id, "offset": float64(100)}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, fmt.Sprintf("continuation %s was not found", id))
+	})
+
+	t.Run("offsets out of the result are rejected", func(t *testing.T) {
+		id := store.order[len(store.order)-1]
+		result, err := fetchMore(context.Background(), createMCPRequest(map[string]any{"continuation_id": id, "offset": float64(250)}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "offset must be between 0 and 249")
+	})
+
+	t.Run("continuations expire", func(t *testing.T) {
+		id := store.order[len(store.order)-1]
+		store.now = func() time.Time { return time.Now().Add(continuationTTL + time.Minute) }
+		defer func() { store.now = time.Now }()
+		result, err := fetchMore(context.Background(), createMCPRequest(map[string]any{"continuation_id": id, "offset": float64(100)}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "it may have expired")
+	})
+
+	t.Run("splitting can be disabled", func(t *testing.T) {
+		store.SetMaxBytes(-1)
+		defer store.SetMaxBytes(100)
+		result, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Equal(t, payload, getTextResult(t, result).Text)
+	})
+}
+
+func Test_WithContinuation_NonTextContent(t *testing.T) {
+	small := mcp.NewImageContent(strings.Repeat("a", 40), "image/png")
+	medium := mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "https://example.com/log.zip", MIMEType: "application/zip", Blob: strings.Repeat("b", 80)})
+	large := mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "https://example.com/video.mp4", MIMEType: "video/mp4", Blob: strings.Repeat("c", 500)})
+	store := NewContinuationStore(100)
+	wrapped := WithContinuation(store)(server.ServerTool{
+		Tool: mcp.NewTool("get_issue_attachments"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent(strings.Repeat("t", 30)), small, medium, large}}, nil
+		},
+	})
+	_, fetchMore := FetchMore(store, translations.NullTranslationHelper)
+
+	// The text and the small image fit in the first page, the blob larger than a page is replaced by a note
+	result, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.Len(t, result.Content, 3)
+	assert.Equal(t, strings.Repeat("t", 30), result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, small, result.Content[1])
+	note := result.Content[2].(mcp.TextContent).Text
+	match := continuationNotePattern.FindStringSubmatch(note)
+	require.NotNil(t, match, note)
+	assert.Equal(t, "70", match[2])
+
+	// The rest is returned a page at a time
+	result, err = fetchMore(context.Background(), createMCPRequest(map[string]any{"continuation_id": match[1], "offset": float64(70)}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, medium, result.Content[0])
+	match = continuationNotePattern.FindStringSubmatch(result.Content[1].(mcp.TextContent).Text)
+	require.NotNil(t, match)
+
+	result, err = fetchMore(context.Background(), createMCPRequest(map[string]any{"continuation_id": match[1], "offset": float64(150)}))
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "The resource https://example.com/video.mp4 (video/mp4) of 500 bytes was left out of the result")
+
+	t.Run("small results keep their contents", func(t *testing.T) {
+		wrapped := WithContinuation(store)(server.ServerTool{
+			Tool: mcp.NewTool("get_issue_attachments"),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent("[]"), small}}, nil
+			},
+		})
+		result, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Equal(t, []mcp.Content{mcp.NewTextContent("[]"), small}, result.Content)
+	})
+}
+
+func Test_ContinuationStoreEviction(t *testing.T) {
+	store := NewContinuationStore(10)
+	first, err := store.put(context.Background(), "first", nil)
+	require.NoError(t, err)
+	for i := 1; i < maxContinuations; i++ {
+		_, err := store.put(context.Background(), "more", nil)
+		require.NoError(t, err)
+	}
+	_, ok := store.get(context.Background(), first)
+	assert.True(t, ok)
+
+	// The oldest continuation is dropped to make room
+	_, err = store.put(context.Background(), "last", nil)
+	require.NoError(t, err)
+	_, ok = store.get(context.Background(), first)
+	assert.False(t, ok)
+	assert.Len(t, store.entries, maxContinuations)
+}
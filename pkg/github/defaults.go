@@ -0,0 +1,35 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WithArgumentDefaults returns a function that fills in arguments the caller left out, using the defaults
+// configured for each tool by name, e.g. {"list_issues": {"perPage": 50}}. Values must have the types produced by
+// decoding JSON, such as float64 for numbers. Tools without defaults are unchanged.
+func WithArgumentDefaults(defaults map[string]map[string]any) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		toolDefaults := defaults[st.Tool.Name]
+		if len(toolDefaults) == 0 {
+			return st
+		}
+
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := request.GetArguments()
+			merged := make(map[string]any, len(args)+len(toolDefaults))
+			for k, v := range toolDefaults {
+				merged[k] = v
+			}
+			for k, v := range args {
+				merged[k] = v
+			}
+			request.Params.Arguments = merged
+			return next(ctx, request)
+		}
+		return st
+	}
+}
@@ -0,0 +1,38 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithArgumentDefaults(t *testing.T) {
+	var got map[string]any
+	st := server.ServerTool{
+		Tool: mcp.NewTool("list_issues"),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			got = request.GetArguments()
+			return mcp.NewToolResultText("ok"), nil
+		},
+	}
+
+	wrapped := WithArgumentDefaults(map[string]map[string]any{
+		"list_issues": {"perPage": float64(50), "state": "open"},
+	})(st)
+
+	_, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{"state": "closed"}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"perPage": float64(50), "state": "closed"}, got)
+
+	// Tools without defaults are left as they are
+	other := st
+	other.Tool = mcp.NewTool("get_issue")
+	unwrapped := WithArgumentDefaults(map[string]map[string]any{"list_issues": {"perPage": float64(50)}})(other)
+	_, err = unwrapped.Handler(context.Background(), createMCPRequest(map[string]any{"issue_number": float64(1)}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"issue_number": float64(1)}, got)
+}
@@ -0,0 +1,473 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// dependabotConfigPaths are the locations Dependabot reads its configuration from, in order of precedence.
+var dependabotConfigPaths = []string{".github/dependabot.yml", ".github/dependabot.yaml"}
+
+// dependabotEcosystems are the values accepted for package-ecosystem.
+var dependabotEcosystems = []string{
+	"bun", "bundler", "cargo", "composer", "devcontainers", "docker", "docker-compose", "dotnet-sdk", "elm",
+	"github-actions", "gitsubmodule", "gomod", "gradle", "helm", "maven", "mix", "npm", "nuget", "pip", "pub",
+	"swift", "terraform", "uv",
+}
+
+// dependabotIntervals are the values accepted for schedule.interval.
+var dependabotIntervals = []string{"daily", "weekly", "monthly", "quarterly", "semiannually", "yearly", "cron"}
+
+// dependabotTimePattern matches the hh:mm format of schedule.time.
+var dependabotTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// dependabotConfig is a parsed .github/dependabot.yml, holding the parts that are validated.
+type dependabotConfig struct {
+	Version    int                       `yaml:"version" json:"version"`
+	Registries map[string]map[string]any `yaml:"registries,omitempty" json:"registries,omitempty"`
+	Updates    []dependabotUpdate        `yaml:"updates" json:"updates"`
+}
+
+// dependabotUpdate is an entry of the updates list of a Dependabot configuration.
+type dependabotUpdate struct {
+	PackageEcosystem      string                     `yaml:"package-ecosystem" json:"package-ecosystem"`
+	Directory             string                     `yaml:"directory,omitempty" json:"directory,omitempty"`
+	Directories           []string                   `yaml:"directories,omitempty" json:"directories,omitempty"`
+	TargetBranch          string                     `yaml:"target-branch,omitempty" json:"target-branch,omitempty"`
+	Schedule              dependabotSchedule         `yaml:"schedule" json:"schedule"`
+	OpenPullRequestsLimit *int                       `yaml:"open-pull-requests-limit,omitempty" json:"open-pull-requests-limit,omitempty"`
+	Registries            any                        `yaml:"registries,omitempty" json:"registries,omitempty"`
+	Groups                map[string]dependabotGroup `yaml:"groups,omitempty" json:"groups,omitempty"`
+}
+
+// dependabotSchedule is the schedule of an update entry.
+type dependabotSchedule struct {
+	Interval string `yaml:"interval" json:"interval"`
+	Day      string `yaml:"day,omitempty" json:"day,omitempty"`
+	Time     string `yaml:"time,omitempty" json:"time,omitempty"`
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	Cronjob  string `yaml:"cronjob,omitempty" json:"cronjob,omitempty"`
+}
+
+// dependabotGroup is a rule grouping the updates of several dependencies in a single pull request.
+type dependabotGroup struct {
+	AppliesTo       string   `yaml:"applies-to,omitempty" json:"applies-to,omitempty"`
+	DependencyType  string   `yaml:"dependency-type,omitempty" json:"dependency-type,omitempty"`
+	Patterns        []string `yaml:"patterns,omitempty" json:"patterns,omitempty"`
+	ExcludePatterns []string `yaml:"exclude-patterns,omitempty" json:"exclude-patterns,omitempty"`
+	UpdateTypes     []string `yaml:"update-types,omitempty" json:"update-types,omitempty"`
+}
+
+// validateDependabotConfig parses a Dependabot configuration and reports the problems Dependabot would reject it
+// for. A configuration that cannot be parsed has a single problem.
+func validateDependabotConfig(content string) (dependabotConfig, []string) {
+	var config dependabotConfig
+	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+		return dependabotConfig{}, []string{fmt.Sprintf("invalid YAML: %s", err)}
+	}
+
+	var problems []string
+	if config.Version != 2 {
+		problems = append(problems, "version must be 2")
+	}
+	if len(config.Updates) == 0 {
+		problems = append(problems, "updates must list at least one package ecosystem")
+	}
+
+	seen := make(map[string]int)
+	for i, update := range config.Updates {
+		prefix := fmt.Sprintf("updates[%d]", i)
+		switch {
+		case update.PackageEcosystem == "":
+			problems = append(problems, prefix+": package-ecosystem is required")
+		case !slices.Contains(dependabotEcosystems, update.PackageEcosystem):
+			problems = append(problems, fmt.Sprintf("%s: unknown package-ecosystem %q", prefix, update.PackageEcosystem))
+		}
+
+		directories := update.Directories
+		switch {
+		case update.Directory != "" && len(update.Directories) > 0:
+			problems = append(problems, prefix+": directory and directories cannot both be set")
+		case update.Directory != "":
+			directories = []string{update.Directory}
+		case len(update.Directories) == 0:
+			problems = append(problems, prefix+": directory or directories is required")
+		}
+		for _, dir := range directories {
+			if !strings.HasPrefix(dir, "/") {
+				problems = append(problems, fmt.Sprintf("%s: directory %q must be relative to the repository root and start with /", prefix, dir))
+			}
+			// Dependabot refuses two entries updating the same ecosystem, directory and target branch
+			key := update.PackageEcosystem + "\x00" + dir + "\x00" + update.TargetBranch
+			if j, ok := seen[key]; ok {
+				problems = append(problems, fmt.Sprintf("%s: duplicates updates[%d] for %s in %s", prefix, j, update.PackageEcosystem, dir))
+			} else {
+				seen[key] = i
+			}
+		}
+
+		problems = append(problems, validateDependabotSchedule(prefix, update.Schedule)...)
+
+		if update.OpenPullRequestsLimit != nil && *update.OpenPullRequestsLimit < 0 {
+			problems = append(problems, prefix+": open-pull-requests-limit must not be negative")
+		}
+
+		var registries []any
+		switch r := update.Registries.(type) {
+		case nil:
+		case string:
+			if r != "*" {
+				problems = append(problems, prefix+`: registries must be "*" or a list of registry names`)
+			}
+		case []any:
+			registries = r
+		default:
+			problems = append(problems, prefix+`: registries must be "*" or a list of registry names`)
+		}
+		for _, registry := range registries {
+			name, _ := registry.(string)
+			if _, ok := config.Registries[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: registry %v is not defined in the top-level registries", prefix, registry))
+			}
+		}
+
+		groupNames := make([]string, 0, len(update.Groups))
+		for name := range update.Groups {
+			groupNames = append(groupNames, name)
+		}
+		sort.Strings(groupNames)
+		for _, name := range groupNames {
+			problems = append(problems, validateDependabotGroup(fmt.Sprintf("%s.groups.%s", prefix, name), update.Groups[name])...)
+		}
+	}
+	return config, problems
+}
+
+// validateDependabotSchedule reports the problems of the schedule of an update entry.
+func validateDependabotSchedule(prefix string, schedule dependabotSchedule) []string {
+	var problems []string
+	switch {
+	case schedule.Interval == "":
+		problems = append(problems, prefix+": schedule.interval is required")
+	case !slices.Contains(dependabotIntervals, schedule.Interval):
+		problems = append(problems, fmt.Sprintf("%s: schedule.interval must be one of %s", prefix, strings.Join(dependabotIntervals, ", ")))
+	}
+	if schedule.Day != "" {
+		days := []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+		if !slices.Contains(days, schedule.Day) {
+			problems = append(problems, fmt.Sprintf("%s: schedule.day must be a day of the week, not %q", prefix, schedule.Day))
+		} else if schedule.Interval != "weekly" {
+			problems = append(problems, prefix+": schedule.day is only used with a weekly interval")
+		}
+	}
+	if schedule.Time != "" && !dependabotTimePattern.MatchString(schedule.Time) {
+		problems = append(problems, fmt.Sprintf("%s: schedule.time must be in hh:mm format, not %q", prefix, schedule.Time))
+	}
+	if schedule.Interval == "cron" && schedule.Cronjob == "" {
+		problems = append(problems, prefix+": schedule.cronjob is required with a cron interval")
+	}
+	if schedule.Interval != "cron" && schedule.Cronjob != "" {
+		problems = append(problems, prefix+": schedule.cronjob is only used with a cron interval")
+	}
+	return problems
+}
+
+// validateDependabotGroup reports the problems of a group rule.
+func validateDependabotGroup(prefix string, group dependabotGroup) []string {
+	var problems []string
+	if len(group.Patterns) == 0 && len(group.ExcludePatterns) == 0 && group.DependencyType == "" && len(group.UpdateTypes) == 0 {
+		problems = append(problems, prefix+": a group needs at least one of patterns, exclude-patterns, dependency-type or update-types")
+	}
+	if group.AppliesTo != "" && group.AppliesTo != "version-updates" && group.AppliesTo != "security-updates" {
+		problems = append(problems, fmt.Sprintf("%s: applies-to must be version-updates or security-updates, not %q", prefix, group.AppliesTo))
+	}
+	if group.DependencyType != "" && group.DependencyType != "development" && group.DependencyType != "production" {
+		problems = append(problems, fmt.Sprintf("%s: dependency-type must be development or production, not %q", prefix, group.DependencyType))
+	}
+	for _, updateType := range group.UpdateTypes {
+		if updateType != "major" && updateType != "minor" && updateType != "patch" {
+			problems = append(problems, fmt.Sprintf("%s: update-types must contain major, minor or patch, not %q", prefix, updateType))
+		}
+	}
+	return problems
+}
+
+// dependabotConfigFile is a Dependabot configuration as stored in a repository.
+type dependabotConfigFile struct {
+	Path     string           `json:"path"`
+	SHA      string           `json:"sha"`
+	Content  string           `json:"content"`
+	Config   dependabotConfig `json:"config"`
+	Valid    bool             `json:"valid"`
+	Problems []string         `json:"problems,omitempty"`
+}
+
+// getDependabotConfigFile fetches the Dependabot configuration of a repository. It returns nil, without an error,
+// when the repository has none.
+func getDependabotConfigFile(ctx context.Context, client *github.Client, owner, repo, ref string) (*dependabotConfigFile, error) {
+	for _, configPath := range dependabotConfigPaths {
+		content, sha, found, err := getFileAtRef(ctx, client, owner, repo, configPath, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		config, problems := validateDependabotConfig(string(content))
+		return &dependabotConfigFile{
+			Path:     configPath,
+			SHA:      sha,
+			Content:  string(content),
+			Config:   config,
+			Valid:    len(problems) == 0,
+			Problems: problems,
+		}, nil
+	}
+	return nil, nil
+}
+
+// GetDependabotConfig creates a tool to read and validate the Dependabot configuration of a repository.
+func GetDependabotConfig(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependabot_config",
+			mcp.WithDescription(t("TOOL_GET_DEPENDABOT_CONFIG_DESCRIPTION", "Get the Dependabot configuration (.github/dependabot.yml) of a repository: its raw content, the parsed package ecosystems, schedules and groups, and any problems that would make Dependabot reject it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPENDABOT_CONFIG_USER_TITLE", "Get Dependabot configuration"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to read the configuration from, defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetDependabotConfigParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			file, err := getDependabotConfigFile(ctx, client, params.Owner, params.Repo, params.Ref)
+			if err != nil {
+				return nil, err
+			}
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s/%s has no Dependabot configuration", params.Owner, params.Repo)), nil
+			}
+
+			r, err := json.Marshal(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateDependabotConfig creates a tool to validate and commit a new Dependabot configuration.
+func UpdateDependabotConfig(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_dependabot_config",
+			mcp.WithDescription(t("TOOL_UPDATE_DEPENDABOT_CONFIG_DESCRIPTION", "Validate a Dependabot configuration and commit it as .github/dependabot.yml, creating the file if the repository has none. Configurations with problems are rejected without committing. Dependabot checks for version updates again once the new configuration is on the default branch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_DEPENDABOT_CONFIG_USER_TITLE", "Update Dependabot configuration"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Complete YAML content of the new configuration"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to commit to, defaults to the default branch"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Commit message"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseUpdateDependabotConfigParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, problems := validateDependabotConfig(params.Content); len(problems) > 0 {
+				return mcp.NewToolResultError("the configuration is invalid:\n- " + strings.Join(problems, "\n- ")), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Update the file in place if it exists under either name
+			existing, err := getDependabotConfigFile(ctx, client, params.Owner, params.Repo, params.Branch)
+			if err != nil {
+				return nil, err
+			}
+			message := params.Message
+			if message == "" {
+				message = "Update Dependabot configuration"
+			}
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: []byte(params.Content),
+			}
+			if params.Branch != "" {
+				opts.Branch = github.Ptr(params.Branch)
+			}
+			configPath := dependabotConfigPaths[0]
+			if existing != nil {
+				if existing.Content == params.Content {
+					return mcp.NewToolResultText(fmt.Sprintf("%s is already up to date", existing.Path)), nil
+				}
+				configPath = existing.Path
+				opts.SHA = github.Ptr(existing.SHA)
+			}
+
+			result, resp, err := client.Repositories.CreateFile(ctx, params.Owner, params.Repo, configPath, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update %s: %w", configPath, err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// dependabotPullRequest is an open pull request of Dependabot.
+type dependabotPullRequest struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Branch    string `json:"branch"`
+	Ecosystem string `json:"ecosystem,omitempty"`
+	Draft     bool   `json:"draft,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// dependabotLogin is the login of the Dependabot app in pull requests.
+const dependabotLogin = "dependabot[bot]"
+
+// ListDependabotPullRequests creates a tool to list the open pull requests of Dependabot in a repository.
+func ListDependabotPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_dependabot_pull_requests",
+			mcp.WithDescription(t("TOOL_LIST_DEPENDABOT_PULL_REQUESTS_DESCRIPTION", "List the open pull requests of Dependabot in a repository, with the package ecosystem each one updates.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPENDABOT_PULL_REQUESTS_USER_TITLE", "List Dependabot pull requests"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Only list pull requests updating this package ecosystem, e.g. npm or github-actions"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListDependabotPullRequestsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pullRequests := []dependabotPullRequest{}
+			opts := &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				prs, resp, err := client.PullRequests.List(ctx, params.Owner, params.Repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list pull requests: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				for _, pr := range prs {
+					if pr.GetUser().GetLogin() != dependabotLogin {
+						continue
+					}
+					// Dependabot names its branches dependabot/<ecosystem>/<directory>/<dependency>
+					branch := pr.GetHead().GetRef()
+					var ecosystem string
+					if parts := strings.Split(branch, "/"); len(parts) > 2 && parts[0] == "dependabot" {
+						ecosystem = parts[1]
+					}
+					if params.Ecosystem != "" && !dependabotBranchEcosystemMatches(ecosystem, params.Ecosystem) {
+						continue
+					}
+					pullRequests = append(pullRequests, dependabotPullRequest{
+						Number:    pr.GetNumber(),
+						Title:     pr.GetTitle(),
+						URL:       pr.GetHTMLURL(),
+						Branch:    branch,
+						Ecosystem: ecosystem,
+						Draft:     pr.GetDraft(),
+						CreatedAt: pr.GetCreatedAt().Format(time.RFC3339),
+					})
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(pullRequests)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// dependabotBranchEcosystems are the names Dependabot uses in its branch names for the package ecosystems whose
+// package manager is named differently.
+var dependabotBranchEcosystems = map[string]string{
+	"github-actions": "github_actions",
+	"gitsubmodule":   "submodules",
+	"gomod":          "go_modules",
+	"mix":            "hex",
+	"npm":            "npm_and_yarn",
+}
+
+// dependabotBranchEcosystemMatches reports whether the ecosystem in the name of a Dependabot branch is the given
+// package-ecosystem.
+func dependabotBranchEcosystemMatches(branchEcosystem, ecosystem string) bool {
+	return branchEcosystem == ecosystem || branchEcosystem == dependabotBranchEcosystems[ecosystem]
+}
@@ -0,0 +1,324 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validDependabotConfig = `version: 2
+registries:
+  npm-internal:
+    type: npm-registry
+    url: https://npm.example.com
+updates:
+  - package-ecosystem: npm
+    directory: /
+    registries:
+      - npm-internal
+    schedule:
+      interval: weekly
+      day: monday
+      time: "09:00"
+    groups:
+      dev-dependencies:
+        dependency-type: development
+        update-types: [minor, patch]
+  - package-ecosystem: github-actions
+    directories: ["/", "/.github/actions/setup"]
+    schedule:
+      interval: cron
+      cronjob: "0 6 * * 1"
+`
+
+func Test_ValidateDependabotConfig(t *testing.T) {
+	config, problems := validateDependabotConfig(validDependabotConfig)
+	assert.Empty(t, problems)
+	require.Len(t, config.Updates, 2)
+	assert.Equal(t, "npm", config.Updates[0].PackageEcosystem)
+	assert.Equal(t, "weekly", config.Updates[0].Schedule.Interval)
+	assert.Equal(t, []string{"minor", "patch"}, config.Updates[0].Groups["dev-dependencies"].UpdateTypes)
+	assert.Equal(t, []string{"/", "/.github/actions/setup"}, config.Updates[1].Directories)
+
+	tests := []struct {
+		name    string
+		content string
+		problem string
+	}{
+		{
+			name:    "invalid YAML",
+			content: "version: [2",
+			problem: "invalid YAML",
+		},
+		{
+			name:    "wrong version",
+			content: "version: 1\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: daily\n",
+			problem: "version must be 2",
+		},
+		{
+			name:    "unknown ecosystem",
+			content: "version: 2\nupdates:\n  - package-ecosystem: yarn\n    directory: /\n    schedule:\n      interval: daily\n",
+			problem: `updates[0]: unknown package-ecosystem "yarn"`,
+		},
+		{
+			name:    "relative directory",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: web\n    schedule:\n      interval: daily\n",
+			problem: `updates[0]: directory "web" must be relative to the repository root and start with /`,
+		},
+		{
+			name:    "duplicate entry",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: daily\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: weekly\n",
+			problem: "updates[1]: duplicates updates[0] for npm in /",
+		},
+		{
+			name:    "missing interval",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      day: monday\n",
+			problem: "updates[0]: schedule.interval is required",
+		},
+		{
+			name:    "day with a daily interval",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: daily\n      day: monday\n",
+			problem: "updates[0]: schedule.day is only used with a weekly interval",
+		},
+		{
+			name:    "invalid time",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: daily\n      time: 9am\n",
+			problem: `updates[0]: schedule.time must be in hh:mm format, not "9am"`,
+		},
+		{
+			name:    "cron without cronjob",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: cron\n",
+			problem: "updates[0]: schedule.cronjob is required with a cron interval",
+		},
+		{
+			name:    "undefined registry",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    registries: [npm-internal]\n    schedule:\n      interval: daily\n",
+			problem: "updates[0]: registry npm-internal is not defined in the top-level registries",
+		},
+		{
+			name:    "empty group",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: daily\n    groups:\n      all: {}\n",
+			problem: "updates[0].groups.all: a group needs at least one of patterns, exclude-patterns, dependency-type or update-types",
+		},
+		{
+			name:    "invalid update type",
+			content: "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n    schedule:\n      interval: daily\n    groups:\n      all:\n        update-types: [breaking]\n",
+			problem: `updates[0].groups.all: update-types must contain major, minor or patch, not "breaking"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, problems := validateDependabotConfig(tc.content)
+			require.NotEmpty(t, problems)
+			found := false
+			for _, problem := range problems {
+				found = found || strings.HasPrefix(problem, tc.problem)
+			}
+			assert.True(t, found, "%q not in %q", tc.problem, problems)
+		})
+	}
+}
+
+func Test_GetDependabotConfig(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependabotConfig(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_dependabot_config", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	t.Run("falls back to dependabot.yaml", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/repos/owner/repo/contents/.github/dependabot.yaml" {
+						mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						SHA:      github.Ptr("config-sha"),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(validDependabotConfig))),
+					})(w, r)
+				}),
+			),
+		)
+		_, handler := GetDependabotConfig(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var file dependabotConfigFile
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &file))
+		assert.Equal(t, ".github/dependabot.yaml", file.Path)
+		assert.Equal(t, "config-sha", file.SHA)
+		assert.True(t, file.Valid)
+		assert.Empty(t, file.Problems)
+		assert.Len(t, file.Config.Updates, 2)
+	})
+
+	t.Run("no configuration", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		)
+		_, handler := GetDependabotConfig(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "owner/repo has no Dependabot configuration", getTextResult(t, result).Text)
+	})
+}
+
+func Test_UpdateDependabotConfig(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateDependabotConfig(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_dependabot_config", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "content"})
+
+	t.Run("updates the existing file", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				expectPath(t, "/repos/owner/repo/contents/.github/dependabot.yml").andThen(
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						SHA:      github.Ptr("old-sha"),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("version: 2\nupdates: []\n"))),
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PutReposContentsByOwnerByRepoByPath,
+				expectRequestBody(t, map[string]any{
+					"message": "Update Dependabot configuration",
+					"content": base64.StdEncoding.EncodeToString([]byte(validDependabotConfig)),
+					"sha":     "old-sha",
+				}).andThen(mockResponse(t, http.StatusOK, &github.RepositoryContentResponse{
+					Commit: github.Commit{SHA: github.Ptr("new-commit")},
+				})),
+			),
+		)
+		_, handler := UpdateDependabotConfig(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"content": validDependabotConfig,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Contains(t, textContent.Text, "new-commit")
+	})
+
+	t.Run("rejects an invalid configuration", func(t *testing.T) {
+		_, handler := UpdateDependabotConfig(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"content": "version: 2\nupdates:\n  - package-ecosystem: npm\n    directory: /\n",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "the configuration is invalid:\n- updates[0]: schedule.interval is required", getTextResult(t, result).Text)
+	})
+}
+
+func Test_ListDependabotPullRequests(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDependabotPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_dependabot_pull_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	created := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposPullsByOwnerByRepo,
+			[]*github.PullRequest{
+				{
+					Number:    github.Ptr(3),
+					Title:     github.Ptr("Bump actions/checkout from 3 to 4"),
+					HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/3"),
+					User:      &github.User{Login: github.Ptr("dependabot[bot]")},
+					Head:      &github.PullRequestBranch{Ref: github.Ptr("dependabot/github_actions/actions/checkout-4")},
+					CreatedAt: &github.Timestamp{Time: created},
+				},
+				{
+					Number: github.Ptr(4),
+					Title:  github.Ptr("Add feature"),
+					User:   &github.User{Login: github.Ptr("octocat")},
+					Head:   &github.PullRequestBranch{Ref: github.Ptr("feature")},
+				},
+				{
+					Number:    github.Ptr(5),
+					Title:     github.Ptr("Bump lodash from 4.17.20 to 4.17.21"),
+					HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/5"),
+					User:      &github.User{Login: github.Ptr("dependabot[bot]")},
+					Head:      &github.PullRequestBranch{Ref: github.Ptr("dependabot/npm_and_yarn/lodash-4.17.21")},
+					CreatedAt: &github.Timestamp{Time: created},
+				},
+			},
+		),
+	)
+	_, handler := ListDependabotPullRequests(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":     "owner",
+		"repo":      "repo",
+		"ecosystem": "npm",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var pullRequests []dependabotPullRequest
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &pullRequests))
+	assert.Equal(t, []dependabotPullRequest{{
+		Number:    5,
+		Title:     "Bump lodash from 4.17.20 to 4.17.21",
+		URL:       "https://github.com/owner/repo/pull/5",
+		Branch:    "dependabot/npm_and_yarn/lodash-4.17.21",
+		Ecosystem: "npm_and_yarn",
+		CreatedAt: "2025-05-01T08:00:00Z",
+	}}, pullRequests)
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// pendingDeployment is a deployment of a workflow run waiting for the protection rules of its environment.
+type pendingDeployment struct {
+	RunID                 int64                      `json:"run_id"`
+	RunName               string                     `json:"run_name,omitempty"`
+	RunURL                string                     `json:"run_url,omitempty"`
+	HeadBranch            string                     `json:"head_branch,omitempty"`
+	HeadSHA               string                     `json:"head_sha,omitempty"`
+	Environment           string                     `json:"environment"`
+	WaitTimer             int64                      `json:"wait_timer,omitempty"`
+	WaitTimerStartedAt    *github.Timestamp          `json:"wait_timer_started_at,omitempty"`
+	CurrentUserCanApprove bool                       `json:"current_user_can_approve"`
+	Reviewers             []*github.RequiredReviewer `json:"reviewers,omitempty"`
+}
+
+// ListPendingDeployments creates a tool to list the deployments waiting for the protection rules of their
+// environment.
+func ListPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_deployments",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_DEPLOYMENTS_DESCRIPTION", "List the deployments of workflow runs waiting for the protection rules of their environment, such as required reviewers or custom deployment protection rules. Without a run ID, the latest 100 waiting runs of the repository are inspected.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PENDING_DEPLOYMENTS_USER_TITLE", "List pending deployments"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Only list deployments to this environment"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Description("Only list the deployments of this workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListPendingDeploymentsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var runs []*github.WorkflowRun
+			if params.RunID != 0 {
+				run, resp, err := client.Actions.GetWorkflowRunByID(ctx, params.Owner, params.Repo, int64(params.RunID))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get workflow run: %w", err)
+				}
+				_ = resp.Body.Close()
+				runs = append(runs, run)
+			} else {
+				waiting, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, params.Owner, params.Repo, &github.ListWorkflowRunsOptions{
+					Status:      "waiting",
+					ListOptions: github.ListOptions{PerPage: 100},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list waiting workflow runs: %w", err)
+				}
+				_ = resp.Body.Close()
+				runs = waiting.WorkflowRuns
+			}
+
+			deployments := []pendingDeployment{}
+			for _, run := range runs {
+				pending, resp, err := client.Actions.GetPendingDeployments(ctx, params.Owner, params.Repo, run.GetID())
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pending deployments of run %d: %w", run.GetID(), err)
+				}
+				_ = resp.Body.Close()
+
+				for _, deployment := range pending {
+					environment := deployment.GetEnvironment().GetName()
+					if params.Environment != "" && environment != params.Environment {
+						continue
+					}
+					deployments = append(deployments, pendingDeployment{
+						RunID:                 run.GetID(),
+						RunName:               run.GetName(),
+						RunURL:                run.GetHTMLURL(),
+						HeadBranch:            run.GetHeadBranch(),
+						HeadSHA:               run.GetHeadSHA(),
+						Environment:           environment,
+						WaitTimer:             deployment.GetWaitTimer(),
+						WaitTimerStartedAt:    deployment.WaitTimerStartedAt,
+						CurrentUserCanApprove: deployment.GetCurrentUserCanApprove(),
+						Reviewers:             deployment.Reviewers,
+					})
+				}
+			}
+
+			r, err := json.Marshal(deployments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReviewDeploymentProtectionRule creates a tool to approve or reject a deployment gated by a custom deployment
+// protection rule.
+func ReviewDeploymentProtectionRule(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_deployment_protection_rule",
+			mcp.WithDescription(t("TOOL_REVIEW_DEPLOYMENT_PROTECTION_RULE_DESCRIPTION", "Approve or reject the deployment of a workflow run to an environment gated by a custom deployment protection rule. Only the GitHub App providing the rule can review it, so the server must be authenticated as that app.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_DEPLOYMENT_PROTECTION_RULE_USER_TITLE", "Review deployment protection rule"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("ID of the workflow run waiting for the deployment"),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("Name of the environment the run deploys to"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Review of the deployment"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Description("Comment explaining the review, shown on the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseReviewDeploymentProtectionRuleParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.State != "approved" && params.State != "rejected" {
+				return mcp.NewToolResultError("state must be approved or rejected"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.ReviewCustomDeploymentProtectionRule(ctx, params.Owner, params.Repo, int64(params.RunID), &github.ReviewCustomDeploymentProtectionRuleRequest{
+				EnvironmentName: params.EnvironmentName,
+				State:           params.State,
+				Comment:         params.Comment,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to review deployment protection rule: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to review deployment protection rule: unexpected status %d", resp.StatusCode)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Deployment of run %d to %s %s", params.RunID, params.EnvironmentName, params.State)), nil
+		}
+}
@@ -0,0 +1,169 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "environment")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// pendingByRun returns the environments each run waits for
+	pendingByRun := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pending []*github.PendingDeployment
+		switch {
+		case strings.Contains(r.URL.Path, "/runs/101/"):
+			pending = []*github.PendingDeployment{
+				{Environment: &github.PendingDeploymentEnvironment{Name: github.Ptr("production")}, CurrentUserCanApprove: github.Ptr(true)},
+			}
+		case strings.Contains(r.URL.Path, "/runs/102/"):
+			pending = []*github.PendingDeployment{
+				{Environment: &github.PendingDeploymentEnvironment{Name: github.Ptr("staging")}, WaitTimer: github.Ptr(int64(30))},
+			}
+		}
+		mockResponse(t, http.StatusOK, pending)(w, r)
+	})
+
+	t.Run("waiting runs of the repository", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"status": "waiting", "per_page": "100"}).andThen(
+					mockResponse(t, http.StatusOK, &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{
+						{ID: github.Ptr(int64(101)), Name: github.Ptr("Deploy"), HeadBranch: github.Ptr("main"), HeadSHA: github.Ptr("abc123"), HTMLURL: github.Ptr("https://github.com/owner/repo/actions/runs/101")},
+						{ID: github.Ptr(int64(102)), Name: github.Ptr("Deploy"), HeadBranch: github.Ptr("feature")},
+					}}),
+				),
+			),
+			mock.WithRequestMatchHandler(mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId, pendingByRun),
+		)
+		_, handler := ListPendingDeployments(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"environment": "production",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var deployments []pendingDeployment
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &deployments))
+		assert.Equal(t, []pendingDeployment{{
+			RunID:                 101,
+			RunName:               "Deploy",
+			RunURL:                "https://github.com/owner/repo/actions/runs/101",
+			HeadBranch:            "main",
+			HeadSHA:               "abc123",
+			Environment:           "production",
+			CurrentUserCanApprove: true,
+		}}, deployments)
+	})
+
+	t.Run("single run", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepoByRunId,
+				expectPath(t, "/repos/owner/repo/actions/runs/102").andThen(
+					mockResponse(t, http.StatusOK, &github.WorkflowRun{ID: github.Ptr(int64(102)), Name: github.Ptr("Deploy"), HeadBranch: github.Ptr("feature")}),
+				),
+			),
+			mock.WithRequestMatchHandler(mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId, pendingByRun),
+		)
+		_, handler := ListPendingDeployments(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"run_id": float64(102),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var deployments []pendingDeployment
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &deployments))
+		require.Len(t, deployments, 1)
+		assert.Equal(t, "staging", deployments[0].Environment)
+		assert.Equal(t, int64(30), deployments[0].WaitTimer)
+		assert.False(t, deployments[0].CurrentUserCanApprove)
+	})
+}
+
+func Test_ReviewDeploymentProtectionRule(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewDeploymentProtectionRule(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_deployment_protection_rule", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "environment_name")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "environment_name", "state"})
+
+	t.Run("approves a deployment", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposActionsRunsDeploymentProtectionRuleByOwnerByRepoByRunId,
+				expectPath(t, "/repos/owner/repo/actions/runs/101/deployment_protection_rule").andThen(
+					expectRequestBody(t, map[string]any{
+						"environment_name": "production",
+						"state":            "approved",
+						"comment":          "Change CHG-42 approved",
+					}).andThen(mockResponse(t, http.StatusNoContent, nil)),
+				),
+			),
+		)
+		_, handler := ReviewDeploymentProtectionRule(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"run_id":           float64(101),
+			"environment_name": "production",
+			"state":            "approved",
+			"comment":          "Change CHG-42 approved",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Equal(t, "Deployment of run 101 to production approved", textContent.Text)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		_, handler := ReviewDeploymentProtectionRule(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"run_id":           float64(101),
+			"environment_name": "production",
+			"state":            "approve",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "state must be approved or rejected", getTextResult(t, result).Text)
+	})
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiffEdits bounds the number of edits searched for by diffLines. The memory of the search grows with the square
+// of the number of edits, so past it the changed region is reported as a whole instead of line by line.
+const maxDiffEdits = 2000
+
+// lineEdit is a line of an edit script: kept (' '), deleted ('-') or inserted ('+').
+type lineEdit struct {
+	op   byte
+	line string
+}
+
+// splitLines splits text into lines, each keeping its trailing newline so that a missing newline at the end of the
+// text is a difference like any other.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns an edit script turning a into b.
+func diffLines(a, b []string) []lineEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]lineEdit, 0, len(a)+len(b)-prefix-suffix)
+	for _, line := range a[:prefix] {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	edits = append(edits, myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	return edits
+}
+
+// myersDiff returns a shortest edit script turning a into b, using Myers' algorithm. When more than maxDiffEdits
+// edits are needed it gives up and replaces all of a by all of b.
+func myersDiff(a, b []string) []lineEdit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	// v[offset+k] is the furthest x reached on diagonal k, trace[d] the part of v the search for d edits started from.
+	offset := n + m
+	v := make([]int, 2*(n+m)+2)
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		if d > maxDiffEdits {
+			return replaceLines(a, b)
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackEdits(a, b, trace)
+			}
+		}
+	}
+	return replaceLines(a, b)
+}
+
+// backtrackEdits walks the trace of myersDiff back from the end of both inputs to build the edit script.
+func backtrackEdits(a, b []string, trace [][]int) []lineEdit {
+	var reversed []lineEdit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = v[prevK+d]
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, lineEdit{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, lineEdit{'+', b[y-1]})
+			} else {
+				reversed = append(reversed, lineEdit{'-', a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	edits := make([]lineEdit, len(reversed))
+	for i, edit := range reversed {
+		edits[len(reversed)-1-i] = edit
+	}
+	return edits
+}
+
+// replaceLines returns the edit script deleting all of a and inserting all of b.
+func replaceLines(a, b []string) []lineEdit {
+	edits := make([]lineEdit, 0, len(a)+len(b))
+	for _, line := range a {
+		edits = append(edits, lineEdit{'-', line})
+	}
+	for _, line := range b {
+		edits = append(edits, lineEdit{'+', line})
+	}
+	return edits
+}
+
+// unifiedDiff returns the hunks of the unified diff turning a into b, with the given number of unchanged lines of
+// context around each change, or an empty string when a and b are equal.
+func unifiedDiff(a, b string, contextLines int) string {
+	edits := diffLines(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	aLine, bLine := 1, 1
+	for start := 0; start < len(edits); {
+		// Find the next change, the hunk starts context lines before it
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+		hunkStart := max(first-contextLines, start)
+
+		// Extend the hunk over changes separated by at most twice the context
+		end := first
+		for i := first; i < len(edits); i++ {
+			if edits[i].op != ' ' {
+				end = i + 1
+			} else if i-end >= 2*contextLines {
+				break
+			}
+		}
+		hunkEnd := min(end+contextLines, len(edits))
+
+		// Lines between hunks are unchanged
+		aLine += hunkStart - start
+		bLine += hunkStart - start
+		var aCount, bCount int
+		for _, edit := range edits[hunkStart:hunkEnd] {
+			if edit.op != '+' {
+				aCount++
+			}
+			if edit.op != '-' {
+				bCount++
+			}
+		}
+
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(aLine, aCount), hunkRange(bLine, bCount))
+		for _, edit := range edits[hunkStart:hunkEnd] {
+			sb.WriteByte(edit.op)
+			sb.WriteString(edit.line)
+			if !strings.HasSuffix(edit.line, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		aLine += aCount
+		bLine += bCount
+		start = hunkEnd
+	}
+	return sb.String()
+}
+
+// hunkRange formats the range of a hunk header. An empty range is numbered after the line preceding it.
+func hunkRange(line, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", line-1)
+	case 1:
+		return fmt.Sprintf("%d", line)
+	default:
+		return fmt.Sprintf("%d,%d", line, count)
+	}
+}
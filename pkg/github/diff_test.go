@@ -0,0 +1,120 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name         string
+		a            string
+		b            string
+		contextLines int
+		expected     string
+	}{
+		{
+			name:         "equal",
+			a:            "a\nb\n",
+			b:            "a\nb\n",
+			contextLines: 3,
+			expected:     "",
+		},
+		{
+			name:         "changed line with context",
+			a:            "1\n2\n3\n4\n5\n6\n7\n",
+			b:            "1\n2\n3\nfour\n5\n6\n7\n",
+			contextLines: 2,
+			expected:     "@@ -2,5 +2,5 @@\n 2\n 3\n-4\n+four\n 5\n 6\n",
+		},
+		{
+			name:         "distant changes make separate hunks",
+			a:            "1\n2\n3\n4\n5\n6\n7\n8\n",
+			b:            "one\n2\n3\n4\n5\n6\n7\neight\n",
+			contextLines: 1,
+			expected:     "@@ -1,2 +1,2 @@\n-1\n+one\n 2\n@@ -7,2 +7,2 @@\n 7\n-8\n+eight\n",
+		},
+		{
+			name:         "close changes share a hunk",
+			a:            "1\n2\n3\n4\n",
+			b:            "one\n2\n3\nfour\n",
+			contextLines: 1,
+			expected:     "@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n-4\n+four\n",
+		},
+		{
+			name:         "no context",
+			a:            "1\n2\n3\n",
+			b:            "1\n3\n",
+			contextLines: 0,
+			expected:     "@@ -2 +1,0 @@\n-2\n",
+		},
+		{
+			name:         "added file",
+			a:            "",
+			b:            "a\nb\n",
+			contextLines: 3,
+			expected:     "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:         "missing newline at end of file",
+			a:            "a\nb",
+			b:            "a\nb\n",
+			contextLines: 3,
+			expected:     "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name:         "insertions and deletions are interleaved minimally",
+			a:            "a\nb\nc\na\nb\nb\na\n",
+			b:            "c\nb\na\nb\na\nc\n",
+			contextLines: 0,
+			expected:     "@@ -1,2 +0,0 @@\n-a\n-b\n@@ -3,0 +2 @@\n+b\n@@ -6 +4,0 @@\n-b\n@@ -7,0 +6 @@\n+c\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, unifiedDiff(tc.a, tc.b, tc.contextLines))
+		})
+	}
+}
+
+func Test_DiffLines_ShortestScript(t *testing.T) {
+	a := splitLines("a\nb\nc\na\nb\nb\na\n")
+	b := splitLines("c\nb\na\nb\na\nc\n")
+	edits := diffLines(a, b)
+
+	// The shortest edit script of this classic example has five edits
+	var changes int
+	var fromA, fromB []string
+	for _, edit := range edits {
+		if edit.op != ' ' {
+			changes++
+		}
+		if edit.op != '+' {
+			fromA = append(fromA, edit.line)
+		}
+		if edit.op != '-' {
+			fromB = append(fromB, edit.line)
+		}
+	}
+	assert.Equal(t, 5, changes)
+	assert.Equal(t, a, fromA)
+	assert.Equal(t, b, fromB)
+}
+
+func Test_DiffLines_TooManyEdits(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < maxDiffEdits; i++ {
+		fmt.Fprintf(&a, "a%d\n", i)
+		fmt.Fprintf(&b, "b%d\n", i)
+	}
+	edits := diffLines(splitLines("same\n"+a.String()), splitLines("same\n"+b.String()))
+
+	assert.Len(t, edits, 2*maxDiffEdits+1)
+	assert.Equal(t, lineEdit{' ', "same\n"}, edits[0])
+	assert.Equal(t, byte('-'), edits[1].op)
+	assert.Equal(t, byte('+'), edits[len(edits)-1].op)
+}
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultDuplicateIssues is the default number of candidates find_duplicate_issues returns.
+	defaultDuplicateIssues = 5
+	// maxDuplicateIssues bounds the candidates find_duplicate_issues returns.
+	maxDuplicateIssues = 20
+	// defaultDuplicateMinScore is the default similarity below which issues are not considered duplicates.
+	defaultDuplicateMinScore = 0.3
+	// duplicateTitleWeight is how much more a term of a title counts than a term of a body when comparing issues.
+	duplicateTitleWeight = 3
+)
+
+// termPattern matches the words compared between issues.
+var termPattern = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}_.-]*[\p{L}\p{N}]|[\p{L}\p{N}]`)
+
+// stopWords are the words left out when comparing issues, too common to tell them apart.
+var stopWords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "also": true, "an": true, "and": true, "any": true,
+	"are": true, "as": true, "at": true, "be": true, "been": true, "but": true, "by": true, "can": true,
+	"cannot": true, "could": true, "did": true, "do": true, "does": true, "doesn": true, "don": true, "for": true,
+	"from": true, "get": true, "gets": true, "had": true, "has": true, "have": true, "how": true, "i": true,
+	"if": true, "in": true, "into": true, "is": true, "it": true, "its": true, "me": true, "my": true, "no": true,
+	"not": true, "of": true, "on": true, "or": true, "should": true, "so": true, "some": true, "than": true,
+	"that": true, "the": true, "their": true, "then": true, "there": true, "this": true, "to": true, "up": true,
+	"use": true, "using": true, "was": true, "we": true, "what": true, "when": true, "where": true, "which": true,
+	"while": true, "will": true, "with": true, "would": true, "you": true,
+}
+
+// issueTerms returns the distinct terms of a text, lower-cased and without stop words, in the order they appear,
+// with their number of occurrences.
+func issueTerms(text string) ([]string, map[string]int) {
+	var order []string
+	counts := map[string]int{}
+	for _, term := range termPattern.FindAllString(strings.ToLower(text), -1) {
+		if stopWords[term] || len(term) < 2 {
+			continue
+		}
+		if counts[term] == 0 {
+			order = append(order, term)
+		}
+		counts[term]++
+	}
+	return order, counts
+}
+
+// termVector weighs the terms of an issue, those of its title counting more than those of its body.
+func termVector(title, body string) map[string]float64 {
+	vector := map[string]float64{}
+	_, titleCounts := issueTerms(title)
+	for term, n := range titleCounts {
+		vector[term] += float64(n * duplicateTitleWeight)
+	}
+	_, bodyCounts := issueTerms(body)
+	for term, n := range bodyCounts {
+		vector[term] += float64(n)
+	}
+	return vector
+}
+
+// cosineSimilarity returns the similarity of two term vectors, from 0 when they share no term to 1 when they are
+// proportional, and the terms they share.
+func cosineSimilarity(a, b map[string]float64) (float64, []string) {
+	var dot, normA, normB float64
+	var shared []string
+	for term, weight := range a {
+		normA += weight * weight
+		if other, ok := b[term]; ok {
+			dot += weight * other
+			shared = append(shared, term)
+		}
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+	sort.Strings(shared)
+	return dot / math.Sqrt(normA*normB), shared
+}
+
+// duplicateSearchQueries returns the searches finding candidate duplicates: the most specific terms of the title
+// all together, fewer of them so that issues worded differently are found too, and the most frequent terms of the
+// body. Longer terms are taken as the more specific.
+func duplicateSearchQueries(title, body string) []string {
+	titleTerms, _ := issueTerms(title)
+	sort.SliceStable(titleTerms, func(i, j int) bool { return len(titleTerms[i]) > len(titleTerms[j]) })
+	bodyTerms, bodyCounts := issueTerms(body)
+	sort.SliceStable(bodyTerms, func(i, j int) bool { return bodyCounts[bodyTerms[i]] > bodyCounts[bodyTerms[j]] })
+
+	var queries []string
+	seen := map[string]bool{}
+	add := func(terms []string, n int) {
+		query := strings.Join(terms[:min(n, len(terms))], " ")
+		if query != "" && !seen[query] {
+			seen[query] = true
+			queries = append(queries, query)
+		}
+	}
+	add(titleTerms, 5)
+	add(titleTerms, 2)
+	add(bodyTerms, 3)
+	return queries
+}
+
+// duplicateCandidate is an existing issue find_duplicate_issues found similar to the new one.
+type duplicateCandidate struct {
+	Number   int     `json:"number"`
+	Title    string  `json:"title"`
+	State    string  `json:"state"`
+	HTMLURL  string  `json:"html_url"`
+	Comments int     `json:"comments"`
+	Score    float64 `json:"score"`
+	// MatchedTerms are the terms the issue shares with the new one.
+	MatchedTerms []string `json:"matched_terms"`
+}
+
+// duplicateIssues is the result of find_duplicate_issues.
+type duplicateIssues struct {
+	Queries           []string             `json:"queries"`
+	CandidatesScanned int                  `json:"candidates_scanned"`
+	Duplicates        []duplicateCandidate `json:"duplicates"`
+}
+
+// FindDuplicateIssues creates a tool to find existing issues similar to a new one, before filing it.
+func FindDuplicateIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_duplicate_issues",
+			mcp.WithDescription(t("TOOL_FIND_DUPLICATE_ISSUES_DESCRIPTION", "Find existing issues of a repository that are likely duplicates of a new one, given its title and body, to check before filing it. Searches the issues with the distinctive terms of the new issue and ranks the candidates by the similarity of their words, titles counting more than bodies, from 0 to 1. The ranking compares words, not meaning: review the candidates before closing anything as a duplicate.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_DUPLICATE_ISSUES_USER_TITLE", "Find duplicate issues"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the new issue"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Body of the new issue"),
+			),
+			mcp.WithBoolean("include_closed",
+				mcp.Description("Also look at closed issues, which only finds open ones by default"),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description(fmt.Sprintf("Maximum number of candidates to return, defaults to %d, at most %d", defaultDuplicateIssues, maxDuplicateIssues)),
+			),
+			mcp.WithNumber("min_score_percent",
+				mcp.Description(fmt.Sprintf("Similarity, in percent, below which issues are left out, defaults to %d", int(defaultDuplicateMinScore*100))),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseFindDuplicateIssuesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxResults <= 0 {
+				params.MaxResults = defaultDuplicateIssues
+			}
+			if params.MaxResults > maxDuplicateIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("max_results must be at most %d", maxDuplicateIssues)), nil
+			}
+			minScore := defaultDuplicateMinScore
+			if params.MinScorePercent > 0 {
+				minScore = float64(params.MinScorePercent) / 100
+			}
+
+			queries := duplicateSearchQueries(params.Title, params.Body)
+			if len(queries) == 0 {
+				return mcp.NewToolResultError("title has no words to search for"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			scope := fmt.Sprintf("repo:%s/%s is:issue", params.Owner, params.Repo)
+			if !params.IncludeClosed {
+				scope += " is:open"
+			}
+			target := termVector(params.Title, params.Body)
+			result := duplicateIssues{Queries: queries, Duplicates: []duplicateCandidate{}}
+			seen := map[int]bool{}
+			for _, query := range queries {
+				found, resp, err := client.Search.Issues(ctx, query+" "+scope, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 30}})
+				if err != nil {
+					return nil, fmt.Errorf("failed to search issues: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, issue := range found.Issues {
+					if seen[issue.GetNumber()] {
+						continue
+					}
+					seen[issue.GetNumber()] = true
+					result.CandidatesScanned++
+
+					score, shared := cosineSimilarity(target, termVector(issue.GetTitle(), issue.GetBody()))
+					if score < minScore {
+						continue
+					}
+					result.Duplicates = append(result.Duplicates, duplicateCandidate{
+						Number:       issue.GetNumber(),
+						Title:        issue.GetTitle(),
+						State:        issue.GetState(),
+						HTMLURL:      issue.GetHTMLURL(),
+						Comments:     issue.GetComments(),
+						Score:        math.Round(score*100) / 100,
+						MatchedTerms: shared,
+					})
+				}
+			}
+
+			sort.SliceStable(result.Duplicates, func(i, j int) bool {
+				if result.Duplicates[i].Score != result.Duplicates[j].Score {
+					return result.Duplicates[i].Score > result.Duplicates[j].Score
+				}
+				return result.Duplicates[i].Number < result.Duplicates[j].Number
+			})
+			if len(result.Duplicates) > params.MaxResults {
+				result.Duplicates = result.Duplicates[:params.MaxResults]
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_duplicateSearchQueries(t *testing.T) {
+	queries := duplicateSearchQueries(
+		"Crash when uploading large attachments",
+		"Uploading a 50MB attachment crashes the server. The attachment is lost.",
+	)
+	assert.Equal(t, []string{
+		"attachments uploading crash large",
+		"attachments uploading",
+		"attachment uploading 50mb",
+	}, queries)
+
+	assert.Equal(t, []string{"timeout"}, duplicateSearchQueries("The timeout", ""))
+	assert.Empty(t, duplicateSearchQueries("it is not the", ""))
+}
+
+func Test_cosineSimilarity(t *testing.T) {
+	same, shared := cosineSimilarity(termVector("Login fails", "SSO"), termVector("login fails", "sso"))
+	assert.InDelta(t, 1, same, 0.0001)
+	assert.Equal(t, []string{"fails", "login", "sso"}, shared)
+
+	unrelated, shared := cosineSimilarity(termVector("Login fails", ""), termVector("Dark mode", ""))
+	assert.Zero(t, unrelated)
+	assert.Empty(t, shared)
+
+	// A shared title term weighs more than a shared body term
+	titleMatch, _ := cosineSimilarity(termVector("Login fails", "browser"), termVector("Login broken", "mobile"))
+	bodyMatch, _ := cosineSimilarity(termVector("Login fails", "browser"), termVector("Dark mode", "browser"))
+	assert.Greater(t, titleMatch, bodyMatch)
+}
+
+func Test_FindDuplicateIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindDuplicateIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "find_duplicate_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "include_closed")
+	assert.Contains(t, tool.InputSchema.Properties, "max_results")
+	assert.Contains(t, tool.InputSchema.Properties, "min_score_percent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+
+	issue := func(number int, title, body string) *github.Issue {
+		return &github.Issue{
+			Number:  github.Ptr(number),
+			Title:   github.Ptr(title),
+			Body:    github.Ptr(body),
+			State:   github.Ptr("open"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/issues/" + title),
+		}
+	}
+	results := map[string][]*github.Issue{
+		"login fails repo:owner/repo is:issue is:open": {
+			issue(3, "Login fails with SSO", "SSO login fails since the upgrade"),
+			issue(7, "Login page typo", "The login page says logn"),
+		},
+		"sso login fails repo:owner/repo is:issue is:open": {
+			issue(3, "Login fails with SSO", "SSO login fails since the upgrade"),
+			issue(9, "Dark mode", "Add a dark mode"),
+		},
+	}
+	var queries []string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchIssues,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query().Get("q")
+				queries = append(queries, q)
+				mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(len(results[q])), Issues: results[q]})(w, r)
+			}),
+		),
+	))
+	_, handler := FindDuplicateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+		"title": "Login fails",
+		"body":  "SSO login fails after the upgrade, SSO only",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var duplicates duplicateIssues
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &duplicates))
+	assert.Equal(t, []string{"login fails", "sso login fails"}, duplicates.Queries)
+	assert.Len(t, queries, 2)
+	assert.Equal(t, 3, duplicates.CandidatesScanned)
+	require.Len(t, duplicates.Duplicates, 2)
+	assert.Equal(t, 3, duplicates.Duplicates[0].Number)
+	assert.Equal(t, []string{"fails", "login", "sso", "upgrade"}, duplicates.Duplicates[0].MatchedTerms)
+	assert.Equal(t, 7, duplicates.Duplicates[1].Number)
+	assert.Greater(t, duplicates.Duplicates[0].Score, duplicates.Duplicates[1].Score)
+
+	t.Run("min score and closed issues", func(t *testing.T) {
+		queries = nil
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":             "owner",
+			"repo":              "repo",
+			"title":             "Login fails",
+			"include_closed":    true,
+			"min_score_percent": float64(90),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Equal(t, []string{"login fails repo:owner/repo is:issue"}, queries)
+	})
+
+	t.Run("titles without terms are rejected", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"title": "it is not",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "title has no words to search for")
+	})
+}
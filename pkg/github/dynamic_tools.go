@@ -35,16 +35,16 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 		),
 		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsets back to a map for JSON serialization
-			toolsetName, err := requiredParam[string](request, "toolset")
+			params, err := parseEnableToolsetParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			toolset := toolsetGroup.Toolsets[toolsetName]
+			toolset := toolsetGroup.Toolsets[params.Toolset]
 			if toolset == nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
+				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", params.Toolset)), nil
 			}
 			if toolset.Enabled {
-				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
+				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", params.Toolset)), nil
 			}
 
 			toolset.Enabled = true
@@ -55,7 +55,7 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
 			s.AddTools(toolset.GetActiveTools()...)
 
-			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", params.Toolset)), nil
 		}
 }
 
@@ -108,13 +108,13 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 		),
 		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
-			toolsetName, err := requiredParam[string](request, "toolset")
+			params, err := parseGetToolsetToolsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			toolset := toolsetGroup.Toolsets[toolsetName]
+			toolset := toolsetGroup.Toolsets[params.Toolset]
 			if toolset == nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
+				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", params.Toolset)), nil
 			}
 			payload := []map[string]string{}
 
@@ -123,7 +123,7 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 					"name":        st.Tool.Name,
 					"description": st.Tool.Description,
 					"can_enable":  "true",
-					"toolset":     toolsetName,
+					"toolset":     params.Toolset,
 				}
 				payload = append(payload, tool)
 			}
@@ -0,0 +1,412 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxInlineExportBytes bounds the exports returned in the result of export_issues, larger ones must be written
+	// to a file.
+	maxInlineExportBytes = 1_000_000
+	// maxExportIssues bounds the issues exported at once.
+	maxExportIssues = 10_000
+	// maxExportSearchResults is how many results the search API returns at most for a query.
+	maxExportSearchResults = 1000
+)
+
+// errExportTooLarge is returned when an inline export grows beyond maxInlineExportBytes.
+var errExportTooLarge = errors.New("export too large")
+
+// exportColumns are the columns of issues exported as CSV, and the fields of issues exported as NDJSON.
+var exportColumns = []string{"number", "title", "state", "state_reason", "author", "assignees", "labels", "milestone", "comments", "created_at", "updated_at", "closed_at", "html_url", "body"}
+
+// exportedIssue is an issue as export_issues writes it.
+type exportedIssue struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	State       string     `json:"state"`
+	StateReason string     `json:"state_reason,omitempty"`
+	Author      string     `json:"author"`
+	Assignees   []string   `json:"assignees"`
+	Labels      []string   `json:"labels"`
+	Milestone   string     `json:"milestone,omitempty"`
+	Comments    int        `json:"comments"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	HTMLURL     string     `json:"html_url"`
+	Body        string     `json:"body,omitempty"`
+}
+
+func newExportedIssue(issue *github.Issue, includeBody bool) exportedIssue {
+	exported := exportedIssue{
+		Number:      issue.GetNumber(),
+		Title:       issue.GetTitle(),
+		State:       issue.GetState(),
+		StateReason: issue.GetStateReason(),
+		Author:      issue.GetUser().GetLogin(),
+		Assignees:   []string{},
+		Labels:      []string{},
+		Milestone:   issue.GetMilestone().GetTitle(),
+		Comments:    issue.GetComments(),
+		CreatedAt:   issue.GetCreatedAt().Time,
+		UpdatedAt:   issue.GetUpdatedAt().Time,
+		HTMLURL:     issue.GetHTMLURL(),
+	}
+	for _, assignee := range issue.Assignees {
+		exported.Assignees = append(exported.Assignees, assignee.GetLogin())
+	}
+	for _, label := range issue.Labels {
+		exported.Labels = append(exported.Labels, label.GetName())
+	}
+	if issue.ClosedAt != nil {
+		exported.ClosedAt = &issue.ClosedAt.Time
+	}
+	if includeBody {
+		exported.Body = issue.GetBody()
+	}
+	return exported
+}
+
+// issueWriter writes exported issues in one format.
+type issueWriter interface {
+	write(issue exportedIssue) error
+	flush() error
+}
+
+// csvIssueWriter writes issues as CSV rows, lists being joined with semicolons.
+type csvIssueWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (w *csvIssueWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.w.Write(exportColumns)
+}
+
+func (w *csvIssueWriter) write(issue exportedIssue) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	closedAt := ""
+	if issue.ClosedAt != nil {
+		closedAt = issue.ClosedAt.Format(time.RFC3339)
+	}
+	return w.w.Write([]string{
+		strconv.Itoa(issue.Number),
+		issue.Title,
+		issue.State,
+		issue.StateReason,
+		issue.Author,
+		strings.Join(issue.Assignees, ";"),
+		strings.Join(issue.Labels, ";"),
+		issue.Milestone,
+		strconv.Itoa(issue.Comments),
+		issue.CreatedAt.Format(time.RFC3339),
+		issue.UpdatedAt.Format(time.RFC3339),
+		closedAt,
+		issue.HTMLURL,
+		issue.Body,
+	})
+}
+
+func (w *csvIssueWriter) flush() error {
+	// Exports without issues still have their header
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// ndjsonIssueWriter writes issues as JSON objects, one per line.
+type ndjsonIssueWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonIssueWriter) write(issue exportedIssue) error {
+	return w.enc.Encode(issue)
+}
+
+func (w *ndjsonIssueWriter) flush() error {
+	return nil
+}
+
+func newIssueWriter(format string, out io.Writer) issueWriter {
+	if format == "ndjson" {
+		enc := json.NewEncoder(out)
+		enc.SetEscapeHTML(false)
+		return &ndjsonIssueWriter{enc: enc}
+	}
+	return &csvIssueWriter{w: csv.NewWriter(out)}
+}
+
+// cappedBuffer is a buffer failing with errExportTooLarge once it holds more than max bytes.
+type cappedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.max {
+		return 0, errExportTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// exportPath resolves the path an export is written to, which must stay in the export directory.
+func exportPath(exportDir, path string) (string, error) {
+	if exportDir == "" {
+		return "", errors.New("writing exports to files is disabled, start the server with --export-dir to enable it, or leave out path to return the export")
+	}
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf("path %q must be relative to the export directory, without ..", path)
+	}
+	return filepath.Join(exportDir, path), nil
+}
+
+// exportedIssues is the result of export_issues when the export is written to a file.
+type exportedIssues struct {
+	Path   string `json:"path"`
+	Format string `json:"format"`
+	Issues int    `json:"issues"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ExportIssues creates a tool to export the issues of a repository matching a filter as CSV or NDJSON, returned in
+// the result or, when exportDir is set, written to a file in it.
+func ExportIssues(getClient GetClientFn, exportDir string, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("export_issues",
+			mcp.WithDescription(t("TOOL_EXPORT_ISSUES_DESCRIPTION", fmt.Sprintf("Export all the issues of a repository matching a filter as CSV or NDJSON, for reports and offline analysis. Pull requests are left out. The export is returned in the result up to %d bytes, or written to a file in the export directory of the server when path is given. At most %d issues are exported, %d with a search query.", maxInlineExportBytes, maxExportIssues, maxExportSearchResults))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EXPORT_ISSUES_USER_TITLE", "Export issues"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("format",
+				mcp.Description("Format of the export, csv by default. CSV joins assignees and labels with semicolons"),
+				mcp.Enum("csv", "ndjson"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state, all by default"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			mcp.WithArray("labels",
+				mcp.Description("Filter by labels, issues having all of them"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only issues updated at or after this time (ISO 8601 timestamp)"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Search query selecting the issues instead of state, labels and since, e.g. is:open author:octocat. It is limited to the repository, without repo:, org: or user: qualifiers"),
+			),
+			mcp.WithBoolean("include_body",
+				mcp.Description("Include the body of the issues, left out by default"),
+			),
+			mcp.WithString("path",
+				mcp.Description("File to write the export to, relative to the export directory of the server. The export is returned in the result when left out"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseExportIssuesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Format == "" {
+				params.Format = "csv"
+			}
+			if params.Format != "csv" && params.Format != "ndjson" {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown format %q, supported formats are csv and ndjson", params.Format)), nil
+			}
+			if params.State == "" {
+				params.State = "all"
+			}
+
+			if params.Query != "" && (len(params.Labels) > 0 || params.Since != "" || request.GetArguments()["state"] != nil) {
+				return mcp.NewToolResultError("query cannot be combined with state, labels or since, add them to the query instead"), nil
+			}
+			if scopeQualifierPattern.MatchString(params.Query) {
+				return mcp.NewToolResultError("query must not contain repo:, org: or user: qualifiers, it is limited to the repository"), nil
+			}
+			opts := &github.IssueListByRepoOptions{
+				State:       params.State,
+				Labels:      params.Labels,
+				Sort:        "created",
+				Direction:   "asc",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			if params.Since != "" {
+				timestamp, err := parseISOTimestamp(params.Since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to export issues: %s", err.Error())), nil
+				}
+				opts.Since = timestamp
+			}
+
+			var out io.Writer
+			var buf *cappedBuffer
+			var file *os.File
+			var counter *countingWriter
+			var bw *bufio.Writer
+			if params.Path != "" {
+				target, err := exportPath(exportDir, params.Path)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+					return nil, fmt.Errorf("failed to create export directory: %w", err)
+				}
+				file, err = os.Create(target)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create export file: %w", err)
+				}
+				defer func() { _ = file.Close() }()
+				counter = &countingWriter{w: file}
+				bw = bufio.NewWriter(counter)
+				out = bw
+			} else {
+				buf = &cappedBuffer{max: maxInlineExportBytes}
+				out = buf
+			}
+			writer := newIssueWriter(params.Format, out)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Issues are written as each page arrives, so that exports to files do not hold them all in memory
+			exported := 0
+			progress := newProgressReporter(ctx, request, 0)
+			write := func(issues []*github.Issue) (bool, error) {
+				before := exported
+				defer func() {
+					if exported > before {
+						progress.step(ctx, exported-before, fmt.Sprintf("exported %d issues", exported))
+					}
+				}()
+				for _, issue := range issues {
+					if issue.IsPullRequest() {
+						continue
+					}
+					if exported == maxExportIssues {
+						return false, nil
+					}
+					if err := writer.write(newExportedIssue(issue, params.IncludeBody)); err != nil {
+						return false, err
+					}
+					exported++
+				}
+				return true, nil
+			}
+
+			var writeErr error
+			if params.Query != "" {
+				searchOpts := &github.SearchOptions{Sort: "created", Order: "asc", ListOptions: github.ListOptions{PerPage: 100}}
+				q := fmt.Sprintf("%s repo:%s/%s is:issue", params.Query, params.Owner, params.Repo)
+				// The search API stops paginating after maxExportSearchResults results
+				for {
+					found, resp, err := client.Search.Issues(ctx, q, searchOpts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to search issues: %w", err)
+					}
+					_ = resp.Body.Close()
+					more, err := write(found.Issues)
+					if err != nil {
+						writeErr = err
+						break
+					}
+					if !more || resp.NextPage == 0 {
+						break
+					}
+					searchOpts.Page = resp.NextPage
+				}
+			} else {
+				for {
+					issues, resp, err := client.Issues.ListByRepo(ctx, params.Owner, params.Repo, opts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list issues: %w", err)
+					}
+					_ = resp.Body.Close()
+					more, err := write(issues)
+					if err != nil {
+						writeErr = err
+						break
+					}
+					if !more || resp.NextPage == 0 {
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+			}
+			if writeErr == nil {
+				writeErr = writer.flush()
+			}
+			if errors.Is(writeErr, errExportTooLarge) {
+				return mcp.NewToolResultError(fmt.Sprintf("the export is larger than %d bytes: narrow the filter down, or write it to a file with path", maxInlineExportBytes)), nil
+			}
+			if writeErr != nil {
+				return nil, fmt.Errorf("failed to write export: %w", writeErr)
+			}
+
+			if file == nil {
+				return mcp.NewToolResultText(buf.String()), nil
+			}
+			if err := bw.Flush(); err != nil {
+				return nil, fmt.Errorf("failed to write export: %w", err)
+			}
+			if err := file.Close(); err != nil {
+				return nil, fmt.Errorf("failed to write export: %w", err)
+			}
+			r, err := json.Marshal(exportedIssues{Path: file.Name(), Format: params.Format, Issues: exported, Bytes: counter.n})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,206 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExportIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ExportIssues(stubGetClientFn(mockClient), "", translations.NullTranslationHelper)
+
+	assert.Equal(t, "export_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "include_body")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	created := github.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	pages := map[string][]*github.Issue{
+		"": {
+			{
+				Number:    github.Ptr(1),
+				Title:     github.Ptr("Crash, on start"),
+				State:     github.Ptr("open"),
+				User:      &github.User{Login: github.Ptr("octocat")},
+				Assignees: []*github.User{{Login: github.Ptr("hubot")}},
+				Labels:    []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("p1")}},
+				Comments:  github.Ptr(2),
+				CreatedAt: &created,
+				UpdatedAt: &created,
+				HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/1"),
+				Body:      github.Ptr("It crashes"),
+			},
+			{
+				Number:           github.Ptr(2),
+				Title:            github.Ptr("A pull request"),
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/2")},
+			},
+		},
+		"2": {
+			{
+				Number:      github.Ptr(3),
+				Title:       github.Ptr("Docs"),
+				State:       github.Ptr("closed"),
+				StateReason: github.Ptr("completed"),
+				User:        &github.User{Login: github.Ptr("hubot")},
+				Milestone:   &github.Milestone{Title: github.Ptr("v1")},
+				CreatedAt:   &created,
+				UpdatedAt:   &created,
+				ClosedAt:    &created,
+				HTMLURL:     github.Ptr("https://github.com/owner/repo/issues/3"),
+			},
+		},
+	}
+	paged := func(w http.ResponseWriter, r *http.Request) []*github.Issue {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues?page=2>; rel="next"`)
+		}
+		return pages[page]
+	}
+	var listQuery, searchQuery string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				listQuery = r.URL.Query().Encode()
+				issues := paged(w, r)
+				mockResponse(t, http.StatusOK, issues)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetSearchIssues,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				searchQuery = r.URL.Query().Get("q")
+				issues := paged(w, r)
+				mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(2), Issues: issues})(w, r)
+			}),
+		),
+	))
+	exportDir := t.TempDir()
+	_, handler := ExportIssues(stubGetClientFn(client), exportDir, translations.NullTranslationHelper)
+
+	t.Run("csv", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"labels": []any{"bug"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Equal(t, `number,title,state,state_reason,author,assignees,labels,milestone,comments,created_at,updated_at,closed_at,html_url,body
+1,"Crash, on start",open,,octocat,hubot,bug;p1,,2,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z,,https://github.com/owner/repo/issues/1,
+3,Docs,closed,completed,hubot,,,v1,0,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z,https://github.com/owner/repo/issues/3,
+`, textContent.Text)
+		assert.Contains(t, listQuery, "labels=bug")
+		assert.Contains(t, listQuery, "state=all")
+	})
+
+	t.Run("ndjson from a search", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"format":       "ndjson",
+			"query":        "is:open author:octocat",
+			"include_body": true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Equal(t, "is:open author:octocat repo:owner/repo is:issue", searchQuery)
+
+		lines := strings.Split(strings.TrimSuffix(textContent.Text, "\n"), "\n")
+		require.Len(t, lines, 2)
+		var first exportedIssue
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, 1, first.Number)
+		assert.Equal(t, []string{"bug", "p1"}, first.Labels)
+		assert.Equal(t, "It crashes", first.Body)
+	})
+
+	t.Run("written to a file", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"path":  "reports/issues.csv",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var exported exportedIssues
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &exported))
+		assert.Equal(t, filepath.Join(exportDir, "reports", "issues.csv"), exported.Path)
+		assert.Equal(t, 2, exported.Issues)
+		data, err := os.ReadFile(exported.Path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), exported.Bytes)
+		assert.True(t, strings.HasPrefix(string(data), "number,title,"))
+	})
+
+	t.Run("paths outside the export directory are rejected", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"path":  "../issues.csv",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "must be relative to the export directory")
+	})
+
+	t.Run("files need an export directory", func(t *testing.T) {
+		_, noDir := ExportIssues(stubGetClientFn(client), "", translations.NullTranslationHelper)
+		result, err := noDir(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"path":  "issues.csv",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "writing exports to files is disabled")
+	})
+
+	t.Run("query cannot be combined with filters", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"query": "is:open",
+			"state": "open",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "query cannot be combined")
+	})
+}
+
+func Test_cappedBuffer(t *testing.T) {
+	buf := &cappedBuffer{max: 5}
+	_, err := buf.Write([]byte("abc"))
+	require.NoError(t, err)
+	_, err = buf.Write([]byte("def"))
+	assert.ErrorIs(t, err, errExportTooLarge)
+	assert.Equal(t, "abc", buf.String())
+}
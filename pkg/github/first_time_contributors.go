@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxFirstTimeContributorItems bounds the open issues and pull requests identify_first_time_contributors looks at.
+	maxFirstTimeContributorItems = 500
+	// defaultWelcomeMessage is the comment posted to first-time contributors when no message is given.
+	defaultWelcomeMessage = "Thanks for your first contribution, @{author}! A maintainer will take a look soon."
+	// welcomeMarker is appended to welcome comments so that nobody is welcomed twice.
+	welcomeMarker = "<!-- first-time-contributor-welcome -->"
+)
+
+// firstTimeAssociations are the author associations of people who never contributed to a repository.
+var firstTimeAssociations = map[string]bool{
+	"FIRST_TIMER":            true,
+	"FIRST_TIME_CONTRIBUTOR": true,
+}
+
+// firstTimeContribution is an open issue or pull request by a first-time contributor.
+type firstTimeContribution struct {
+	Number int `json:"number"`
+	// Kind is "issue" or "pull_request".
+	Kind        string    `json:"kind"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Author      string    `json:"author"`
+	Association string    `json:"association"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	AlreadyWelcomed bool   `json:"already_welcomed,omitempty"`
+	CommentID       int64  `json:"comment_id,omitempty"`
+	Labeled         bool   `json:"labeled,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// firstTimeContributorsReport is the result of identify_first_time_contributors.
+type firstTimeContributorsReport struct {
+	ItemsChecked  int                     `json:"items_checked"`
+	Contributions []firstTimeContribution `json:"contributions"`
+	Warnings      []string                `json:"warnings,omitempty"`
+}
+
+// welcome posts the welcome comment on a contribution, unless one was posted already, and applies the label.
+func welcome(ctx context.Context, client *github.Client, owner, repo string, issue *github.Issue, contribution *firstTimeContribution, message, label string) error {
+	if issue.GetComments() > 0 {
+		opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for !contribution.AlreadyWelcomed {
+			comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issue.GetNumber(), opts)
+			if err != nil {
+				return fmt.Errorf("failed to list comments: %w", err)
+			}
+			_ = resp.Body.Close()
+			for _, comment := range comments {
+				if strings.Contains(comment.GetBody(), welcomeMarker) {
+					contribution.AlreadyWelcomed = true
+					break
+				}
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	}
+	if !contribution.AlreadyWelcomed {
+		body := strings.ReplaceAll(message, "{author}", contribution.Author) + "\n\n" + welcomeMarker
+		comment, resp, err := client.Issues.CreateComment(ctx, owner, repo, issue.GetNumber(), &github.IssueComment{Body: github.Ptr(body)})
+		if err != nil {
+			return fmt.Errorf("failed to comment: %w", err)
+		}
+		_ = resp.Body.Close()
+		contribution.CommentID = comment.GetID()
+	}
+
+	if label == "" {
+		return nil
+	}
+	for _, l := range issue.Labels {
+		if strings.EqualFold(l.GetName(), label) {
+			return nil
+		}
+	}
+	_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, issue.GetNumber(), []string{label})
+	if err != nil {
+		return fmt.Errorf("failed to add label: %w", err)
+	}
+	_ = resp.Body.Close()
+	contribution.Labeled = true
+	return nil
+}
+
+// IdentifyFirstTimeContributors creates a tool to find the open issues and pull requests of first-time contributors,
+// and optionally welcome them.
+func IdentifyFirstTimeContributors(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("identify_first_time_contributors",
+			mcp.WithDescription(t("TOOL_IDENTIFY_FIRST_TIME_CONTRIBUTORS_DESCRIPTION", "Find the open issues and pull requests of a repository opened by first-time contributors, people who never contributed to the repository before according to their author association. With welcome, also posts a welcome comment on each, skipping those already welcomed by this tool, and applies the label if one is given.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_IDENTIFY_FIRST_TIME_CONTRIBUTORS_USER_TITLE", "Identify first-time contributors"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("kind",
+				mcp.Description("Kind of contributions to look at, defaults to all"),
+				mcp.Enum("all", "issues", "pull_requests"),
+			),
+			mcp.WithBoolean("welcome",
+				mcp.Description("Post a welcome comment on each contribution found, and apply the label"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Welcome comment, where {author} is replaced by the login of the contributor. Defaults to: "+defaultWelcomeMessage),
+			),
+			mcp.WithString("label",
+				mcp.Description("Label to apply to the contributions when welcoming, e.g. first-time-contributor"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseIdentifyFirstTimeContributorsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch params.Kind {
+			case "":
+				params.Kind = "all"
+			case "all", "issues", "pull_requests":
+			default:
+				return mcp.NewToolResultError("kind must be all, issues or pull_requests"), nil
+			}
+			if params.Message == "" {
+				params.Message = defaultWelcomeMessage
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			report := firstTimeContributorsReport{Contributions: []firstTimeContribution{}}
+			var issues []*github.Issue
+			opts := &github.IssueListByRepoOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				page, resp, err := client.Issues.ListByRepo(ctx, params.Owner, params.Repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list issues: %w", err)
+				}
+				_ = resp.Body.Close()
+				issues = append(issues, page...)
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(issues) >= maxFirstTimeContributorItems {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("only the latest %d open issues and pull requests were checked", maxFirstTimeContributorItems))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			for _, issue := range issues {
+				kind := "issue"
+				if issue.IsPullRequest() {
+					kind = "pull_request"
+				}
+				if params.Kind != "all" && params.Kind != kind+"s" {
+					continue
+				}
+				report.ItemsChecked++
+				if !firstTimeAssociations[issue.GetAuthorAssociation()] || issue.GetUser().GetType() == "Bot" {
+					continue
+				}
+				contribution := firstTimeContribution{
+					Number:      issue.GetNumber(),
+					Kind:        kind,
+					Title:       issue.GetTitle(),
+					URL:         issue.GetHTMLURL(),
+					Author:      issue.GetUser().GetLogin(),
+					Association: issue.GetAuthorAssociation(),
+					CreatedAt:   issue.GetCreatedAt().Time,
+				}
+				if params.Welcome {
+					if err := welcome(ctx, client, params.Owner, params.Repo, issue, &contribution, params.Message, params.Label); err != nil {
+						contribution.Error = err.Error()
+					}
+				}
+				report.Contributions = append(report.Contributions, contribution)
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// firstTimeContributorsOptions mocks a repository with a first-time contribution to welcome, one already welcomed,
+// and contributions from a member and a bot.
+func firstTimeContributorsOptions(t *testing.T) []mock.MockBackendOption {
+	return []mock.MockBackendOption{
+		mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepo, []*github.Issue{
+			{
+				Number:            github.Ptr(1),
+				Title:             github.Ptr("Fix typo"),
+				User:              &github.User{Login: github.Ptr("newcomer")},
+				AuthorAssociation: github.Ptr("FIRST_TIME_CONTRIBUTOR"),
+				PullRequestLinks:  &github.PullRequestLinks{},
+			},
+			{
+				Number:            github.Ptr(2),
+				Title:             github.Ptr("Crash on start"),
+				User:              &github.User{Login: github.Ptr("reporter")},
+				AuthorAssociation: github.Ptr("FIRST_TIMER"),
+				Comments:          github.Ptr(1),
+				Labels:            []*github.Label{{Name: github.Ptr("good first contribution")}},
+			},
+			{
+				Number:            github.Ptr(3),
+				User:              &github.User{Login: github.Ptr("maintainer")},
+				AuthorAssociation: github.Ptr("MEMBER"),
+			},
+			{
+				Number:            github.Ptr(4),
+				User:              &github.User{Login: github.Ptr("renovate[bot]"), Type: github.Ptr("Bot")},
+				AuthorAssociation: github.Ptr("FIRST_TIME_CONTRIBUTOR"),
+				PullRequestLinks:  &github.PullRequestLinks{},
+			},
+		}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			expectPath(t, "/repos/owner/repo/issues/2/comments").andThen(
+				mockResponse(t, http.StatusOK, []*github.IssueComment{{Body: github.Ptr("Welcome!\n\n" + welcomeMarker)}}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			expectRequestBody(t, map[string]any{
+				"body": "Welcome @newcomer!\n\n" + welcomeMarker,
+			}).andThen(mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(101))})),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+			expectPath(t, "/repos/owner/repo/issues/1/labels").andThen(
+				mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("good first contribution")}}),
+			),
+		),
+	}
+}
+
+func Test_IdentifyFirstTimeContributors(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := IdentifyFirstTimeContributors(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "identify_first_time_contributors", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "kind")
+	assert.Contains(t, tool.InputSchema.Properties, "welcome")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "label")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	run := func(t *testing.T, args map[string]any) firstTimeContributorsReport {
+		_, handler := IdentifyFirstTimeContributors(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(firstTimeContributorsOptions(t)...))), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report firstTimeContributorsReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		return report
+	}
+
+	t.Run("lists first-time contributions", func(t *testing.T) {
+		report := run(t, map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		})
+		assert.Equal(t, 4, report.ItemsChecked)
+		require.Len(t, report.Contributions, 2)
+		assert.Equal(t, firstTimeContribution{Number: 1, Kind: "pull_request", Title: "Fix typo", Author: "newcomer", Association: "FIRST_TIME_CONTRIBUTOR"}, report.Contributions[0])
+		assert.Equal(t, "issue", report.Contributions[1].Kind)
+		assert.Equal(t, "FIRST_TIMER", report.Contributions[1].Association)
+	})
+
+	t.Run("filters by kind", func(t *testing.T) {
+		report := run(t, map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"kind":  "issues",
+		})
+		assert.Equal(t, 2, report.ItemsChecked)
+		require.Len(t, report.Contributions, 1)
+		assert.Equal(t, 2, report.Contributions[0].Number)
+	})
+
+	t.Run("welcomes new contributors once", func(t *testing.T) {
+		report := run(t, map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"welcome": true,
+			"message": "Welcome @{author}!",
+			"label":   "good first contribution",
+		})
+		require.Len(t, report.Contributions, 2)
+		assert.Empty(t, report.Contributions[0].Error)
+		assert.Equal(t, int64(101), report.Contributions[0].CommentID)
+		assert.True(t, report.Contributions[0].Labeled)
+		assert.True(t, report.Contributions[1].AlreadyWelcomed)
+		assert.Zero(t, report.Contributions[1].CommentID)
+		assert.False(t, report.Contributions[1].Labeled, "the label was already applied")
+	})
+}
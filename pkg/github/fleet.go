@@ -0,0 +1,304 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxFleetRepositories bounds the number of repositories changed by a single open_prs_across_repos call.
+const maxFleetRepositories = 50
+
+// fleetFileChange is a change applied to a file of every repository: either its new content, or a regular
+// expression replacement applied to its current content.
+type fleetFileChange struct {
+	Path        string
+	Content     *string
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// fleetRepositoryResult is the outcome of a fleet change in one repository.
+type fleetRepositoryResult struct {
+	Repository string `json:"repository"`
+	// Status is created, unchanged, failed, or cancelled when the call was cancelled before reaching the repository.
+	Status       string   `json:"status"`
+	Branch       string   `json:"branch,omitempty"`
+	Number       int      `json:"number,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Error        string   `json:"error,omitempty"`
+	ChangedPaths []string `json:"changed_paths,omitempty"`
+}
+
+// parseFleetFileChanges validates the files parameter of open_prs_across_repos.
+func parseFleetFileChanges(files []any) ([]fleetFileChange, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("files must not be empty")
+	}
+	changes := make([]fleetFileChange, 0, len(files))
+	for i, file := range files {
+		fileMap, ok := file.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("files[%d] must be an object", i)
+		}
+		filePath, _ := fileMap["path"].(string)
+		if filePath == "" {
+			return nil, fmt.Errorf("files[%d] must have a path", i)
+		}
+		change := fleetFileChange{Path: filePath}
+		content, hasContent := fileMap["content"].(string)
+		pattern, hasPattern := fileMap["pattern"].(string)
+		switch {
+		case hasContent && hasPattern:
+			return nil, fmt.Errorf("files[%d] must have either content or pattern, not both", i)
+		case hasContent:
+			change.Content = &content
+		case hasPattern:
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("files[%d] has an invalid pattern: %w", i, err)
+			}
+			change.Pattern = re
+			change.Replacement, _ = fileMap["replacement"].(string)
+		default:
+			return nil, fmt.Errorf("files[%d] must have content or pattern", i)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// applyFleetChange makes the changes in a repository: it commits them on a new branch from base and opens a pull
+// request. Repositories the changes leave as they are get no branch.
+func applyFleetChange(ctx context.Context, client *github.Client, owner, repo string, changes []fleetFileChange, params OpenPrsAcrossReposParams) fleetRepositoryResult {
+	result := fleetRepositoryResult{Repository: owner + "/" + repo}
+	fail := func(err error) fleetRepositoryResult {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	base := params.Base
+	if base == "" {
+		repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			return fail(fmt.Errorf("failed to get repository: %w", err))
+		}
+		_ = resp.Body.Close()
+		base = repository.GetDefaultBranch()
+	}
+
+	baseRef, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+base)
+	if err != nil {
+		return fail(fmt.Errorf("failed to get branch reference: %w", err))
+	}
+	_ = resp.Body.Close()
+	baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, baseRef.GetObject().GetSHA())
+	if err != nil {
+		return fail(fmt.Errorf("failed to get base commit: %w", err))
+	}
+	_ = resp.Body.Close()
+
+	// The tree gives the current content and mode of the files, so that executable scripts stay executable
+	tree, resp, err := client.Git.GetTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), true)
+	if err != nil {
+		return fail(fmt.Errorf("failed to get tree: %w", err))
+	}
+	_ = resp.Body.Close()
+	if tree.GetTruncated() {
+		return fail(fmt.Errorf("the repository tree is too large to list completely"))
+	}
+	existing := make(map[string]*github.TreeEntry)
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" {
+			existing[entry.GetPath()] = entry
+		}
+	}
+
+	var entries []*github.TreeEntry
+	for _, change := range changes {
+		entry := existing[change.Path]
+		var current string
+		if entry != nil {
+			data, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, entry.GetSHA())
+			if err != nil {
+				return fail(fmt.Errorf("failed to get %s: %w", change.Path, err))
+			}
+			_ = resp.Body.Close()
+			current = string(data)
+		}
+
+		var updated string
+		switch {
+		case change.Content != nil:
+			updated = *change.Content
+		case entry == nil:
+			return fail(fmt.Errorf("%s not found on %s", change.Path, base))
+		default:
+			updated = change.Pattern.ReplaceAllString(current, change.Replacement)
+		}
+		if entry != nil && updated == current {
+			continue
+		}
+
+		mode := "100644"
+		if entry != nil {
+			mode = entry.GetMode()
+		}
+		entries = append(entries, &github.TreeEntry{
+			Path:    github.Ptr(change.Path),
+			Mode:    github.Ptr(mode),
+			Type:    github.Ptr("blob"),
+			Content: github.Ptr(updated),
+		})
+		result.ChangedPaths = append(result.ChangedPaths, change.Path)
+	}
+	if len(entries) == 0 {
+		result.Status = "unchanged"
+		return result
+	}
+
+	message := params.CommitMessage
+	if message == "" {
+		message = params.Title
+	}
+	if _, err := commitToNewBranch(ctx, client, owner, repo, baseCommit, entries, message, params.Branch); err != nil {
+		return fail(err)
+	}
+	result.Branch = params.Branch
+
+	pr, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.Ptr(params.Title),
+		Head:  github.Ptr(params.Branch),
+		Base:  github.Ptr(base),
+		Body:  github.Ptr(params.Body),
+		Draft: github.Ptr(params.Draft),
+	})
+	if err != nil {
+		return fail(fmt.Errorf("failed to create pull request: %w", err))
+	}
+	_ = resp.Body.Close()
+
+	result.Status = "created"
+	result.Number = pr.GetNumber()
+	result.URL = pr.GetHTMLURL()
+	return result
+}
+
+// OpenPRsAcrossRepos creates a tool to make the same file changes in several repositories, with a pull request in
+// each.
+func OpenPRsAcrossRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("open_prs_across_repos",
+			mcp.WithDescription(t("TOOL_OPEN_PRS_ACROSS_REPOS_DESCRIPTION", "Make the same file changes in several repositories, e.g. to bump a configuration value everywhere: creates a branch, a commit and a pull request with a shared title and body in each repository, and returns the link to each pull request. Repositories already up to date are skipped, failures in one repository do not stop the others.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_OPEN_PRS_ACROSS_REPOS_USER_TITLE", "Open pull requests across repositories"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Repositories to change, as owner/repo (at most %d)", maxFleetRepositories)),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("files",
+				mcp.Required(),
+				mcp.Items(
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path"},
+						"properties": map[string]any{
+							"path": map[string]any{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]any{
+								"type":        "string",
+								"description": "new content of the file, created if it does not exist",
+							},
+							"pattern": map[string]any{
+								"type":        "string",
+								"description": "regular expression to replace in the existing file, instead of content",
+							},
+							"replacement": map[string]any{
+								"type":        "string",
+								"description": "replacement of the matches of pattern, where $1 inserts the first group",
+							},
+						},
+					}),
+				mcp.Description("Changes to make in each repository, each with a path and either the new content or a pattern and its replacement"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name of the branch to create in each repository"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the pull requests"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Body of the pull requests"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Commit message, defaults to the title"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to open the pull requests against, defaults to the default branch of each repository"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Open the pull requests as drafts"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseOpenPrsAcrossReposParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(params.Repositories) == 0 {
+				return mcp.NewToolResultError("repositories must not be empty"), nil
+			}
+			if len(params.Repositories) > maxFleetRepositories {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be changed at once", maxFleetRepositories)), nil
+			}
+			for _, fullName := range params.Repositories {
+				if owner, repo, ok := strings.Cut(fullName, "/"); !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid repository %q, expected owner/repo", fullName)), nil
+				}
+			}
+			changes, err := parseFleetFileChanges(params.Files)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			progress := newProgressReporter(ctx, request, len(params.Repositories))
+			results := make([]fleetRepositoryResult, 0, len(params.Repositories))
+			for _, fullName := range params.Repositories {
+				// Once the call is cancelled, the repositories left are reported so that a retry can target them
+				if ctx.Err() != nil {
+					results = append(results, fleetRepositoryResult{Repository: fullName, Status: "cancelled"})
+					continue
+				}
+				owner, repo, _ := strings.Cut(fullName, "/")
+				result := applyFleetChange(ctx, client, owner, repo, changes, params)
+				results = append(results, result)
+				progress.step(ctx, 1, fmt.Sprintf("%s: %s", fullName, result.Status))
+			}
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
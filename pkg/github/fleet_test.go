@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseFleetFileChanges(t *testing.T) {
+	changes, err := parseFleetFileChanges([]any{
+		map[string]any{"path": "a.txt", "content": ""},
+		map[string]any{"path": "b.yml", "pattern": `version: \d+`, "replacement": "version: 2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "", *changes[0].Content)
+	assert.Nil(t, changes[0].Pattern)
+	assert.Nil(t, changes[1].Content)
+	assert.Equal(t, "version: 2", changes[1].Replacement)
+
+	for _, files := range [][]any{
+		{},
+		{"a.txt"},
+		{map[string]any{"content": "x"}},
+		{map[string]any{"path": "a.txt"}},
+		{map[string]any{"path": "a.txt", "content": "x", "pattern": "x"}},
+		{map[string]any{"path": "a.txt", "pattern": "("}},
+	} {
+		_, err := parseFleetFileChanges(files)
+		assert.Error(t, err, "%v", files)
+	}
+}
+
+func Test_OpenPRsAcrossRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := OpenPRsAcrossRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "open_prs_across_repos", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "draft")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"repositories", "files", "branch", "title"})
+
+	// repoOf returns the name of the repository a request is about
+	repoOf := func(r *http.Request) string {
+		return strings.Split(r.URL.Path, "/")[3]
+	}
+	trees := map[string]*github.Tree{
+		"api": {Entries: []*github.TreeEntry{
+			{Path: github.Ptr(".tool-versions"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("api-versions")},
+			{Path: github.Ptr("scripts/setup.sh"), Type: github.Ptr("blob"), Mode: github.Ptr("100755"), SHA: github.Ptr("api-setup")},
+		}},
+		"web": {Entries: []*github.TreeEntry{
+			{Path: github.Ptr(".tool-versions"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("web-versions")},
+			{Path: github.Ptr("scripts/setup.sh"), Type: github.Ptr("blob"), Mode: github.Ptr("100755"), SHA: github.Ptr("web-setup")},
+		}},
+	}
+	blobs := map[string]string{
+		"api-versions": "golang 1.22.1\nnodejs 20.1.0\n",
+		"api-setup":    "#!/bin/sh\ngo install ./...\n",
+		"web-versions": "golang 1.23.7\nnodejs 20.1.0\n",
+		"web-setup":    "#!/bin/sh\nset -e\ngo install ./...\n",
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if repoOf(r) == "missing" {
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, &github.Repository{DefaultBranch: github.Ptr("main")})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.True(t, strings.HasSuffix(r.URL.Path, "/git/ref/heads/main"))
+				mockResponse(t, http.StatusOK, &github.Reference{Object: &github.GitObject{SHA: github.Ptr(repoOf(r) + "-base")}})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, &github.Commit{
+					SHA:  github.Ptr(repoOf(r) + "-base"),
+					Tree: &github.Tree{SHA: github.Ptr(repoOf(r) + "-tree")},
+				})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, trees[repoOf(r)])(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(blobs[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposGitTreesByOwnerByRepo,
+			expectPath(t, "/repos/octo-org/api/git/trees").andThen(
+				expectRequestBody(t, map[string]any{
+					"base_tree": "api-tree",
+					"tree": []any{
+						map[string]any{"path": ".tool-versions", "mode": "100644", "type": "blob", "content": "golang 1.23.7\nnodejs 20.1.0\n"},
+						map[string]any{"path": "scripts/setup.sh", "mode": "100755", "type": "blob", "content": "#!/bin/sh\nset -e\ngo install ./...\n"},
+					},
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("api-new-tree")})),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposGitCommitsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"message": "Bump Go to 1.23.7",
+				"tree":    "api-new-tree",
+				"parents": []any{"api-base"},
+			}).andThen(mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr("api-commit")})),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposGitRefsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"ref": "refs/heads/bump-go",
+				"sha": "api-commit",
+			}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/heads/bump-go")})),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposPullsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"title": "Bump Go to 1.23.7",
+				"head":  "bump-go",
+				"base":  "main",
+				"body":  "Keeps the toolchain in sync across services.",
+				"draft": false,
+			}).andThen(mockResponse(t, http.StatusCreated, &github.PullRequest{
+				Number:  github.Ptr(12),
+				HTMLURL: github.Ptr("https://github.com/octo-org/api/pull/12"),
+			})),
+		),
+	)
+
+	_, handler := OpenPRsAcrossRepos(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"repositories": []any{"octo-org/api", "octo-org/web", "octo-org/missing"},
+		"files": []any{
+			map[string]any{"path": ".tool-versions", "pattern": `(?m)^golang .*$`, "replacement": "golang 1.23.7"},
+			map[string]any{"path": "scripts/setup.sh", "content": "#!/bin/sh\nset -e\ngo install ./...\n"},
+		},
+		"branch": "bump-go",
+		"title":  "Bump Go to 1.23.7",
+		"body":   "Keeps the toolchain in sync across services.",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var results []fleetRepositoryResult
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &results))
+	require.Len(t, results, 3)
+	assert.Equal(t, fleetRepositoryResult{
+		Repository:   "octo-org/api",
+		Status:       "created",
+		Branch:       "bump-go",
+		Number:       12,
+		URL:          "https://github.com/octo-org/api/pull/12",
+		ChangedPaths: []string{".tool-versions", "scripts/setup.sh"},
+	}, results[0])
+
+	// web is already up to date
+	assert.Equal(t, fleetRepositoryResult{Repository: "octo-org/web", Status: "unchanged"}, results[1])
+
+	assert.Equal(t, "octo-org/missing", results[2].Repository)
+	assert.Equal(t, "failed", results[2].Status)
+	assert.Contains(t, results[2].Error, "failed to get repository")
+
+	// Once the call is cancelled, the repositories left are not changed
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = handler(ctx, createMCPRequest(map[string]any{
+		"repositories": []any{"octo-org/api", "octo-org/web"},
+		"files":        []any{map[string]any{"path": "scripts/setup.sh", "content": "#!/bin/sh\n"}},
+		"branch":       "bump-go",
+		"title":        "Bump Go to 1.23.7",
+	}))
+	require.NoError(t, err)
+	var cancelled []fleetRepositoryResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &cancelled))
+	assert.Equal(t, []fleetRepositoryResult{
+		{Repository: "octo-org/api", Status: "cancelled"},
+		{Repository: "octo-org/web", Status: "cancelled"},
+	}, cancelled)
+}
@@ -0,0 +1,267 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultForkNetworkForks is the default number of forks analyze_fork_network scans, the most starred first.
+	defaultForkNetworkForks = 100
+	// maxForkNetworkForks bounds the forks analyze_fork_network scans.
+	maxForkNetworkForks = 500
+	// maxForkNetworkComparisons bounds the forks compared with the repository, one request each. The forks pushed
+	// to most recently are compared.
+	maxForkNetworkComparisons = 30
+	// defaultForkNetworkTop is the default number of forks and of stargazers analyze_fork_network returns.
+	defaultForkNetworkTop = 10
+	// maxForkNetworkTop bounds the forks and the stargazers analyze_fork_network returns.
+	maxForkNetworkTop = 50
+	// defaultForkNetworkStargazers is the default number of recent stargazers analyze_fork_network looks up.
+	defaultForkNetworkStargazers = 50
+	// maxForkNetworkStargazers bounds the stargazers analyze_fork_network looks up, one request each.
+	maxForkNetworkStargazers = 100
+)
+
+// activeFork is a fork with changes of its own.
+type activeFork struct {
+	FullName      string            `json:"full_name"`
+	HTMLURL       string            `json:"html_url"`
+	DefaultBranch string            `json:"default_branch"`
+	Stars         int               `json:"stars"`
+	CreatedAt     *github.Timestamp `json:"created_at,omitempty"`
+	PushedAt      *github.Timestamp `json:"pushed_at,omitempty"`
+	// AheadBy and BehindBy compare the default branch of the fork with the one of the repository, they are nil when
+	// the fork was not compared.
+	AheadBy  *int   `json:"ahead_by,omitempty"`
+	BehindBy *int   `json:"behind_by,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// notableStargazer is a recent stargazer of a repository with their audience.
+type notableStargazer struct {
+	Login     string            `json:"login"`
+	Type      string            `json:"type"`
+	Name      string            `json:"name,omitempty"`
+	Company   string            `json:"company,omitempty"`
+	Followers int               `json:"followers"`
+	StarredAt *github.Timestamp `json:"starred_at,omitempty"`
+}
+
+// forkNetworkReport is the result of analyze_fork_network.
+type forkNetworkReport struct {
+	Repository   string `json:"repository"`
+	Stars        int    `json:"stars"`
+	Forks        int    `json:"forks"`
+	ForksScanned int    `json:"forks_scanned"`
+	// ForksWithPushes counts the scanned forks pushed to after they were created.
+	ForksWithPushes   int                `json:"forks_with_pushes"`
+	ActiveForks       []activeFork       `json:"active_forks"`
+	NotableStargazers []notableStargazer `json:"notable_stargazers,omitempty"`
+	Warnings          []string           `json:"warnings,omitempty"`
+}
+
+// pushedAfterCreation reports whether a fork was pushed to after it was created. A fork that was never pushed to
+// keeps the push date of the repository when it was forked, a little before its creation.
+func pushedAfterCreation(fork *github.Repository) bool {
+	return fork.GetPushedAt().After(fork.GetCreatedAt().Add(time.Minute))
+}
+
+// rankActiveForks orders forks by the commits they are ahead, then by their latest push. Forks not compared come
+// after the compared ones.
+func rankActiveForks(forks []activeFork) {
+	sort.SliceStable(forks, func(i, j int) bool {
+		a, b := forks[i], forks[j]
+		if (a.AheadBy != nil) != (b.AheadBy != nil) {
+			return a.AheadBy != nil
+		}
+		if a.AheadBy != nil && *a.AheadBy != *b.AheadBy {
+			return *a.AheadBy > *b.AheadBy
+		}
+		return a.PushedAt.After(b.PushedAt.Time)
+	})
+}
+
+// AnalyzeForkNetwork creates a tool to find the most active forks and the notable recent stargazers of a repository.
+func AnalyzeForkNetwork(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("analyze_fork_network",
+			mcp.WithDescription(t("TOOL_ANALYZE_FORK_NETWORK_DESCRIPTION", fmt.Sprintf("Gauge the community momentum of a repository: the most active forks, those pushed to after forking ranked by the commits their default branch is ahead of the repository, and the notable recent stargazers, ranked by followers. Forks are scanned most starred first, and the %d pushed to most recently are compared with the repository.", maxForkNetworkComparisons))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ANALYZE_FORK_NETWORK_USER_TITLE", "Analyze fork network"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("max_forks",
+				mcp.Description(fmt.Sprintf("Number of forks to scan, defaults to %d, at most %d", defaultForkNetworkForks, maxForkNetworkForks)),
+			),
+			mcp.WithNumber("top",
+				mcp.Description(fmt.Sprintf("Number of forks and of stargazers to return, defaults to %d, at most %d", defaultForkNetworkTop, maxForkNetworkTop)),
+			),
+			mcp.WithBoolean("include_stargazers",
+				mcp.Description("Look up the recent stargazers, defaults to true"),
+			),
+			mcp.WithNumber("max_stargazers",
+				mcp.Description(fmt.Sprintf("Number of recent stargazers to look up, defaults to %d, at most %d", defaultForkNetworkStargazers, maxForkNetworkStargazers)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseAnalyzeForkNetworkParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["include_stargazers"]; !ok {
+				params.IncludeStargazers = true
+			}
+			if params.MaxForks <= 0 {
+				params.MaxForks = defaultForkNetworkForks
+			}
+			if params.Top <= 0 {
+				params.Top = defaultForkNetworkTop
+			}
+			if params.MaxStargazers <= 0 {
+				params.MaxStargazers = defaultForkNetworkStargazers
+			}
+			switch {
+			case params.MaxForks > maxForkNetworkForks:
+				return mcp.NewToolResultError(fmt.Sprintf("max_forks must be at most %d", maxForkNetworkForks)), nil
+			case params.Top > maxForkNetworkTop:
+				return mcp.NewToolResultError(fmt.Sprintf("top must be at most %d", maxForkNetworkTop)), nil
+			case params.MaxStargazers > maxForkNetworkStargazers:
+				return mcp.NewToolResultError(fmt.Sprintf("max_stargazers must be at most %d", maxForkNetworkStargazers)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			report := forkNetworkReport{
+				Repository:  repository.GetFullName(),
+				Stars:       repository.GetStargazersCount(),
+				Forks:       repository.GetForksCount(),
+				ActiveForks: []activeFork{},
+			}
+			if report.Repository == "" {
+				report.Repository = fmt.Sprintf("%s/%s", params.Owner, params.Repo)
+			}
+
+			var pushed []*github.Repository
+			opts := &github.RepositoryListForksOptions{Sort: "stargazers", ListOptions: github.ListOptions{PerPage: min(100, params.MaxForks)}}
+			for report.ForksScanned < params.MaxForks {
+				forks, resp, err := client.Repositories.ListForks(ctx, params.Owner, params.Repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list forks: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, fork := range forks {
+					if report.ForksScanned == params.MaxForks {
+						break
+					}
+					report.ForksScanned++
+					if pushedAfterCreation(fork) {
+						pushed = append(pushed, fork)
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			report.ForksWithPushes = len(pushed)
+
+			sort.SliceStable(pushed, func(i, j int) bool { return pushed[i].GetPushedAt().After(pushed[j].GetPushedAt().Time) })
+			for i, fork := range pushed {
+				active := activeFork{
+					FullName:      fork.GetFullName(),
+					HTMLURL:       fork.GetHTMLURL(),
+					DefaultBranch: fork.GetDefaultBranch(),
+					Stars:         fork.GetStargazersCount(),
+					CreatedAt:     fork.CreatedAt,
+					PushedAt:      fork.PushedAt,
+				}
+				if i < maxForkNetworkComparisons {
+					head := fmt.Sprintf("%s:%s", fork.GetOwner().GetLogin(), fork.GetDefaultBranch())
+					comparison, resp, err := client.Repositories.CompareCommits(ctx, params.Owner, params.Repo, repository.GetDefaultBranch(), head, &github.ListOptions{PerPage: 1})
+					if resp != nil {
+						_ = resp.Body.Close()
+					}
+					if err != nil {
+						active.Error = fmt.Sprintf("failed to compare: %v", err)
+					} else {
+						active.AheadBy, active.BehindBy = comparison.AheadBy, comparison.BehindBy
+					}
+				}
+				// Forks pushed to without commits of their own, e.g. to sync with the repository, are not active
+				if active.AheadBy != nil && *active.AheadBy == 0 {
+					continue
+				}
+				report.ActiveForks = append(report.ActiveForks, active)
+			}
+			if len(pushed) > maxForkNetworkComparisons {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("only the %d forks pushed to most recently were compared with the repository", maxForkNetworkComparisons))
+			}
+			rankActiveForks(report.ActiveForks)
+			if len(report.ActiveForks) > params.Top {
+				report.ActiveForks = report.ActiveForks[:params.Top]
+			}
+
+			if params.IncludeStargazers && report.Stars > 0 {
+				stargazers, err := listRecentStargazers(ctx, client, params.Owner, params.Repo, report.Stars, (params.MaxStargazers+99)/100+1)
+				if err != nil {
+					return nil, err
+				}
+				if len(stargazers) > params.MaxStargazers {
+					stargazers = stargazers[len(stargazers)-params.MaxStargazers:]
+				}
+				for _, stargazer := range stargazers {
+					user, resp, err := client.Users.Get(ctx, stargazer.GetUser().GetLogin())
+					if err != nil {
+						report.Warnings = append(report.Warnings, fmt.Sprintf("failed to get user %s: %v", stargazer.GetUser().GetLogin(), err))
+						continue
+					}
+					_ = resp.Body.Close()
+					report.NotableStargazers = append(report.NotableStargazers, notableStargazer{
+						Login:     user.GetLogin(),
+						Type:      user.GetType(),
+						Name:      user.GetName(),
+						Company:   user.GetCompany(),
+						Followers: user.GetFollowers(),
+						StarredAt: stargazer.StarredAt,
+					})
+				}
+				sort.SliceStable(report.NotableStargazers, func(i, j int) bool {
+					return report.NotableStargazers[i].Followers > report.NotableStargazers[j].Followers
+				})
+				if len(report.NotableStargazers) > params.Top {
+					report.NotableStargazers = report.NotableStargazers[:params.Top]
+				}
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
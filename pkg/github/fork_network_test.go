@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AnalyzeForkNetwork(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AnalyzeForkNetwork(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "analyze_fork_network", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "max_forks")
+	assert.Contains(t, tool.InputSchema.Properties, "top")
+	assert.Contains(t, tool.InputSchema.Properties, "include_stargazers")
+	assert.Contains(t, tool.InputSchema.Properties, "max_stargazers")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fork := func(owner string, pushed time.Time) *github.Repository {
+		return &github.Repository{
+			FullName:      github.Ptr(owner + "/repo"),
+			Owner:         &github.User{Login: github.Ptr(owner)},
+			DefaultBranch: github.Ptr("main"),
+			CreatedAt:     &github.Timestamp{Time: created},
+			PushedAt:      &github.Timestamp{Time: pushed},
+		}
+	}
+	// alice and bob pushed to their forks, bob only to sync it, carol never did
+	aheadBy := map[string]int{"alice": 5, "bob": 0, "dave": 2}
+	options := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{
+				FullName:        github.Ptr("owner/repo"),
+				DefaultBranch:   github.Ptr("main"),
+				StargazersCount: github.Ptr(2),
+				ForksCount:      github.Ptr(4),
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposForksByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"sort": "stargazers", "per_page": "100"}).andThen(
+					mockResponse(t, http.StatusOK, []*github.Repository{
+						fork("alice", created.AddDate(0, 1, 0)),
+						fork("bob", created.AddDate(0, 3, 0)),
+						fork("carol", created.Add(-time.Second)),
+						fork("dave", created.AddDate(0, 2, 0)),
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCompareByOwnerByRepoByBasehead,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					owner := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/compare/main..."), ":main")
+					ahead, ok := aheadBy[owner]
+					if !ok {
+						t.Errorf("unexpected comparison %s", r.URL.Path)
+					}
+					mockResponse(t, http.StatusOK, &github.CommitsComparison{AheadBy: github.Ptr(ahead), BehindBy: github.Ptr(1)})(w, r)
+				}),
+			),
+			mock.WithRequestMatch(mock.GetReposStargazersByOwnerByRepo, []*github.Stargazer{
+				{User: &github.User{Login: github.Ptr("erin")}},
+				{User: &github.User{Login: github.Ptr("frank")}},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetUsersByUsername,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					login := strings.TrimPrefix(r.URL.Path, "/users/")
+					followers := map[string]int{"erin": 12, "frank": 3400}[login]
+					mockResponse(t, http.StatusOK, &github.User{Login: github.Ptr(login), Type: github.Ptr("User"), Followers: github.Ptr(followers)})(w, r)
+				}),
+			),
+		}
+	}
+
+	run := func(t *testing.T, args map[string]any) forkNetworkReport {
+		client := github.NewClient(mock.NewMockedHTTPClient(options()...))
+		_, handler := AnalyzeForkNetwork(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report forkNetworkReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		return report
+	}
+
+	t.Run("forks and stargazers", func(t *testing.T) {
+		report := run(t, map[string]any{"owner": "owner", "repo": "repo"})
+		assert.Equal(t, "owner/repo", report.Repository)
+		assert.Equal(t, 4, report.ForksScanned)
+		assert.Equal(t, 3, report.ForksWithPushes)
+
+		require.Len(t, report.ActiveForks, 2)
+		assert.Equal(t, "alice/repo", report.ActiveForks[0].FullName)
+		assert.Equal(t, 5, *report.ActiveForks[0].AheadBy)
+		assert.Equal(t, "dave/repo", report.ActiveForks[1].FullName)
+
+		require.Len(t, report.NotableStargazers, 2)
+		assert.Equal(t, "frank", report.NotableStargazers[0].Login)
+		assert.Equal(t, 3400, report.NotableStargazers[0].Followers)
+	})
+
+	t.Run("top without stargazers", func(t *testing.T) {
+		report := run(t, map[string]any{"owner": "owner", "repo": "repo", "top": float64(1), "include_stargazers": false})
+		require.Len(t, report.ActiveForks, 1)
+		assert.Equal(t, "alice/repo", report.ActiveForks[0].FullName)
+		assert.Empty(t, report.NotableStargazers)
+	})
+
+	t.Run("too many forks", func(t *testing.T) {
+		_, handler := AnalyzeForkNetwork(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "max_forks": float64(501)}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "max_forks must be at most 500", getTextResult(t, result).Text)
+	})
+}
+
+func Test_RankActiveForks(t *testing.T) {
+	at := func(day int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)}
+	}
+	forks := []activeFork{
+		{FullName: "uncompared", PushedAt: at(9)},
+		{FullName: "older", AheadBy: github.Ptr(3), PushedAt: at(1)},
+		{FullName: "newer", AheadBy: github.Ptr(3), PushedAt: at(2)},
+		{FullName: "ahead", AheadBy: github.Ptr(8), PushedAt: at(1)},
+	}
+	rankActiveForks(forks)
+
+	names := make([]string, 0, len(forks))
+	for _, fork := range forks {
+		names = append(names, fork.FullName)
+	}
+	assert.Equal(t, []string{"ahead", "newer", "older", "uncompared"}, names)
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// maxGraphQLPageSize is the largest page the GraphQL API returns for a connection.
+const maxGraphQLPageSize = 100
+
+// GraphQLRateLimit is the rate limit status returned with a query. Add it to a query struct as
+//
+//	RateLimit GraphQLRateLimit `graphql:"rateLimit"`
+//
+// to learn how many points the query cost and how many are left.
+type GraphQLRateLimit struct {
+	Cost      githubv4.Int
+	Remaining githubv4.Int
+}
+
+// GraphQLPageInfo is the pagination state of a connection.
+type GraphQLPageInfo struct {
+	HasNextPage githubv4.Boolean
+	EndCursor   githubv4.String
+}
+
+// GraphQLPage is one page of a connection, as returned by the fetch function of PaginateGraphQL.
+type GraphQLPage[T any] struct {
+	Nodes     []T
+	PageInfo  GraphQLPageInfo
+	RateLimit GraphQLRateLimit
+}
+
+// GraphQLPaginationOptions bounds the cost of paginating a connection.
+type GraphQLPaginationOptions struct {
+	// PageSize is the number of nodes requested per page, at most 100. Zero means 100.
+	PageSize int
+
+	// MaxNodes is the total number of nodes to collect, zero means no limit. The last page is shrunk so that no
+	// more nodes than needed are requested, since the cost of a query grows with the page size.
+	MaxNodes int
+
+	// MinRemaining stops pagination once fewer rate limit points than this are left, zero means no limit. It only
+	// applies to queries that select GraphQLRateLimit.
+	MinRemaining int
+}
+
+// GraphQLPaginationResult holds the nodes collected by PaginateGraphQL.
+type GraphQLPaginationResult[T any] struct {
+	Nodes []T `json:"nodes"`
+
+	// Truncated is set when more nodes were available than were collected, because of MaxNodes or MinRemaining.
+	Truncated bool `json:"truncated"`
+
+	// EndCursor is the cursor to continue from when truncated.
+	EndCursor string `json:"endCursor,omitempty"`
+
+	// Cost is the total rate limit cost of the queries made.
+	Cost int `json:"cost"`
+}
+
+// PaginateGraphQL follows the cursor of a connection, calling fetch with the page size and cursor of each page until
+// all nodes are collected or a limit of opts is reached. The first call gets a nil cursor.
+func PaginateGraphQL[T any](ctx context.Context, opts GraphQLPaginationOptions, fetch func(ctx context.Context, first githubv4.Int, after *githubv4.String) (GraphQLPage[T], error)) (GraphQLPaginationResult[T], error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 || pageSize > maxGraphQLPageSize {
+		pageSize = maxGraphQLPageSize
+	}
+
+	result := GraphQLPaginationResult[T]{Nodes: []T{}}
+	var cursor *githubv4.String
+	for pages := 1; ; pages++ {
+		first := pageSize
+		if opts.MaxNodes > 0 {
+			first = min(first, opts.MaxNodes-len(result.Nodes))
+		}
+
+		page, err := fetch(ctx, githubv4.Int(int32(first)), cursor) // #nosec G115 - first is at most 100
+		if err != nil {
+			return result, fmt.Errorf("failed to fetch page %d: %w", pages, err)
+		}
+		result.Nodes = append(result.Nodes, page.Nodes...)
+		result.Cost += int(page.RateLimit.Cost)
+
+		if !page.PageInfo.HasNextPage {
+			return result, nil
+		}
+		result.EndCursor = string(page.PageInfo.EndCursor)
+
+		outOfNodes := opts.MaxNodes > 0 && len(result.Nodes) >= opts.MaxNodes
+		// A query without a rate limit selection reports zero cost, its remaining points are unknown
+		outOfPoints := opts.MinRemaining > 0 && page.RateLimit.Cost > 0 && int(page.RateLimit.Remaining) < opts.MinRemaining
+		if outOfNodes || outOfPoints {
+			result.Truncated = true
+			return result, nil
+		}
+		cursor = githubv4.NewString(page.PageInfo.EndCursor)
+	}
+}
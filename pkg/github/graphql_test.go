@@ -0,0 +1,115 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConnection serves total nodes in pages, recording the page sizes requested.
+type fakeConnection struct {
+	total     int
+	remaining int
+	requested []int
+}
+
+func (c *fakeConnection) fetch(_ context.Context, first githubv4.Int, after *githubv4.String) (GraphQLPage[int], error) {
+	c.requested = append(c.requested, int(first))
+
+	start := 0
+	if after != nil {
+		if _, err := fmt.Sscanf(string(*after), "cursor-%d", &start); err != nil {
+			return GraphQLPage[int]{}, err
+		}
+	}
+	end := min(start+int(first), c.total)
+
+	page := GraphQLPage[int]{Nodes: []int{}}
+	for i := start; i < end; i++ {
+		page.Nodes = append(page.Nodes, i)
+	}
+	page.PageInfo = GraphQLPageInfo{
+		HasNextPage: githubv4.Boolean(end < c.total),
+		EndCursor:   githubv4.String(fmt.Sprintf("cursor-%d", end)),
+	}
+	c.remaining--
+	page.RateLimit = GraphQLRateLimit{Cost: 1, Remaining: githubv4.Int(c.remaining)} // #nosec G115 - small test values
+	return page, nil
+}
+
+func Test_PaginateGraphQL(t *testing.T) {
+	tests := []struct {
+		name              string
+		total             int
+		opts              GraphQLPaginationOptions
+		expectedNodes     int
+		expectedRequested []int
+		expectedTruncated bool
+		expectedCursor    string
+	}{
+		{
+			name:              "collects all pages",
+			total:             250,
+			expectedNodes:     250,
+			expectedRequested: []int{100, 100, 100},
+		},
+		{
+			name:              "shrinks the last page to the node budget",
+			total:             250,
+			opts:              GraphQLPaginationOptions{PageSize: 50, MaxNodes: 120},
+			expectedNodes:     120,
+			expectedRequested: []int{50, 50, 20},
+			expectedTruncated: true,
+			expectedCursor:    "cursor-120",
+		},
+		{
+			name:              "node budget larger than the connection",
+			total:             30,
+			opts:              GraphQLPaginationOptions{MaxNodes: 500},
+			expectedNodes:     30,
+			expectedRequested: []int{100},
+		},
+		{
+			name:              "stops when rate limit points run low",
+			total:             1000,
+			opts:              GraphQLPaginationOptions{MinRemaining: 8},
+			expectedNodes:     300,
+			expectedRequested: []int{100, 100, 100},
+			expectedTruncated: true,
+			expectedCursor:    "cursor-300",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := &fakeConnection{total: tc.total, remaining: 10}
+			result, err := PaginateGraphQL(context.Background(), tc.opts, conn.fetch)
+			require.NoError(t, err)
+
+			assert.Len(t, result.Nodes, tc.expectedNodes)
+			assert.Equal(t, tc.expectedRequested, conn.requested)
+			assert.Equal(t, tc.expectedTruncated, result.Truncated)
+			assert.Equal(t, len(tc.expectedRequested), result.Cost)
+			if tc.expectedTruncated {
+				assert.Equal(t, tc.expectedCursor, result.EndCursor)
+			}
+		})
+	}
+}
+
+func Test_PaginateGraphQL_Error(t *testing.T) {
+	calls := 0
+	_, err := PaginateGraphQL(context.Background(), GraphQLPaginationOptions{}, func(_ context.Context, _ githubv4.Int, _ *githubv4.String) (GraphQLPage[int], error) {
+		calls++
+		if calls == 2 {
+			return GraphQLPage[int]{}, errors.New("boom")
+		}
+		return GraphQLPage[int]{Nodes: []int{1}, PageInfo: GraphQLPageInfo{HasNextPage: true, EndCursor: "next"}}, nil
+	})
+	require.EqualError(t, err, "failed to fetch page 2: boom")
+}
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultGreenCommitSearchDepth is the default number of commits find_latest_green_commit walks back.
+	defaultGreenCommitSearchDepth = 20
+	// maxGreenCommitSearchDepth bounds the commits find_latest_green_commit walks back, as each one costs two requests.
+	maxGreenCommitSearchDepth = 100
+)
+
+// passingCheckConclusions are the check run conclusions that do not block a commit.
+var passingCheckConclusions = map[string]bool{
+	"success": true,
+	"neutral": true,
+	"skipped": true,
+}
+
+// commitCheckResults are the outcomes of the checks reported on a commit, by check name: "passed", "failed" or
+// "pending". Check runs and commit statuses of the same name are merged, the worse outcome winning.
+type commitCheckResults map[string]string
+
+// add records the outcome of a check.
+func (r commitCheckResults) add(name, outcome string) {
+	rank := map[string]int{"passed": 1, "pending": 2, "failed": 3}
+	if rank[outcome] > rank[r[name]] {
+		r[name] = outcome
+	}
+}
+
+// getCommitCheckResults collects the check runs and commit statuses reported on a commit.
+func getCommitCheckResults(ctx context.Context, client *github.Client, owner, repo, sha string) (commitCheckResults, error) {
+	results := commitCheckResults{}
+	// Only the latest run of each check is listed, so reruns replace earlier failures
+	checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, &github.ListCheckRunsOptions{
+		Filter:      github.Ptr("latest"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check runs of %s: %w", sha, err)
+	}
+	_ = resp.Body.Close()
+	for _, run := range checkRuns.CheckRuns {
+		switch {
+		case run.GetStatus() != "completed":
+			results.add(run.GetName(), "pending")
+		case passingCheckConclusions[run.GetConclusion()]:
+			results.add(run.GetName(), "passed")
+		default:
+			results.add(run.GetName(), "failed")
+		}
+	}
+
+	status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, sha, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses of %s: %w", sha, err)
+	}
+	_ = resp.Body.Close()
+	for _, s := range status.Statuses {
+		switch s.GetState() {
+		case "success":
+			results.add(s.GetContext(), "passed")
+		case "pending":
+			results.add(s.GetContext(), "pending")
+		default:
+			results.add(s.GetContext(), "failed")
+		}
+	}
+	return results, nil
+}
+
+// blockingChecks returns why a commit is not green: the required checks that did not pass, or, when no check is
+// required, every check that did not pass. A commit without any check is not green either.
+func (r commitCheckResults) blockingChecks(required []string) []string {
+	var blocking []string
+	if len(required) == 0 {
+		if len(r) == 0 {
+			return []string{"no checks reported"}
+		}
+		for name, outcome := range r {
+			if outcome != "passed" {
+				blocking = append(blocking, fmt.Sprintf("%s: %s", name, outcome))
+			}
+		}
+		sort.Strings(blocking)
+		return blocking
+	}
+	for _, name := range required {
+		switch outcome, ok := r[name]; {
+		case !ok:
+			blocking = append(blocking, fmt.Sprintf("%s: missing", name))
+		case outcome != "passed":
+			blocking = append(blocking, fmt.Sprintf("%s: %s", name, outcome))
+		}
+	}
+	return blocking
+}
+
+// greenCommit is a commit whose checks passed.
+type greenCommit struct {
+	SHA     string    `json:"sha"`
+	Subject string    `json:"subject"`
+	Date    time.Time `json:"date"`
+	URL     string    `json:"url"`
+	Checks  []string  `json:"checks"`
+}
+
+// skippedCommit is a commit newer than the green one, and why it is not green.
+type skippedCommit struct {
+	SHA      string   `json:"sha"`
+	Subject  string   `json:"subject"`
+	Blocking []string `json:"blocking"`
+}
+
+// latestGreenCommit is the result of find_latest_green_commit.
+type latestGreenCommit struct {
+	Branch         string          `json:"branch"`
+	RequiredChecks []string        `json:"required_checks"`
+	Commit         *greenCommit    `json:"commit"`
+	Skipped        []skippedCommit `json:"skipped"`
+	CommitsChecked int             `json:"commits_checked"`
+}
+
+// FindLatestGreenCommit creates a tool to find the newest commit of a branch whose required checks all passed.
+func FindLatestGreenCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_latest_green_commit",
+			mcp.WithDescription(t("TOOL_FIND_LATEST_GREEN_COMMIT_DESCRIPTION", "Find the newest commit of a branch whose required checks all passed, walking back from its head, e.g. to choose a safe commit to deploy. The required checks are those of the branch protection and rulesets of the branch, or the given checks; when none are required, every check reported on a commit must pass. Newer commits are listed with the checks that kept them from being green.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_LATEST_GREEN_COMMIT_USER_TITLE", "Find latest green commit"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to walk, defaults to the default branch"),
+			),
+			mcp.WithArray("checks",
+				mcp.Description("Names of the check runs and statuses that must pass, defaults to the required checks of the branch"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("max_commits",
+				mcp.Description(fmt.Sprintf("Number of commits to walk back, defaults to %d", defaultGreenCommitSearchDepth)),
+				mcp.Min(1),
+				mcp.Max(maxGreenCommitSearchDepth),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseFindLatestGreenCommitParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxCommits <= 0 {
+				params.MaxCommits = defaultGreenCommitSearchDepth
+			}
+			if params.MaxCommits > maxGreenCommitSearchDepth {
+				return mcp.NewToolResultError(fmt.Sprintf("max_commits must be at most %d", maxGreenCommitSearchDepth)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if params.Branch == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				params.Branch = repository.GetDefaultBranch()
+			}
+
+			required := params.Checks
+			if len(required) == 0 {
+				if required, err = classicRequiredChecks(ctx, client, params.Owner, params.Repo, params.Branch); err != nil {
+					return nil, err
+				}
+				rules, resp, err := client.Repositories.GetRulesForBranch(ctx, params.Owner, params.Repo, params.Branch)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get rules of %s: %w", params.Branch, err)
+				}
+				_ = resp.Body.Close()
+				_, rulesetChecks := rulesetProtection(rules)
+				for _, check := range rulesetChecks {
+					if !slices.Contains(required, check) {
+						required = append(required, check)
+					}
+				}
+			}
+
+			commits, resp, err := client.Repositories.ListCommits(ctx, params.Owner, params.Repo, &github.CommitsListOptions{
+				SHA:         params.Branch,
+				ListOptions: github.ListOptions{PerPage: params.MaxCommits},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commits of %s: %w", params.Branch, err)
+			}
+			_ = resp.Body.Close()
+
+			result := latestGreenCommit{Branch: params.Branch, RequiredChecks: append([]string{}, required...), Skipped: []skippedCommit{}}
+			for _, commit := range commits {
+				result.CommitsChecked++
+				checks, err := getCommitCheckResults(ctx, client, params.Owner, params.Repo, commit.GetSHA())
+				if err != nil {
+					return nil, err
+				}
+				subject, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+				if blocking := checks.blockingChecks(required); len(blocking) > 0 {
+					result.Skipped = append(result.Skipped, skippedCommit{SHA: commit.GetSHA(), Subject: subject, Blocking: blocking})
+					continue
+				}
+				green := &greenCommit{
+					SHA:     commit.GetSHA(),
+					Subject: subject,
+					Date:    commit.GetCommit().GetCommitter().GetDate().Time,
+					URL:     commit.GetHTMLURL(),
+					Checks:  []string{},
+				}
+				for name := range checks {
+					green.Checks = append(green.Checks, name)
+				}
+				sort.Strings(green.Checks)
+				result.Commit = green
+				break
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
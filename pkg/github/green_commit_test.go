@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BlockingChecks(t *testing.T) {
+	results := commitCheckResults{}
+	results.add("build", "passed")
+	results.add("lint", "failed")
+	results.add("lint", "passed")
+	results.add("deploy", "pending")
+
+	assert.Equal(t, "failed", results["lint"], "the worse outcome wins")
+	assert.Empty(t, results.blockingChecks([]string{"build"}))
+	assert.Equal(t, []string{"lint: failed", "e2e: missing"}, results.blockingChecks([]string{"build", "lint", "e2e"}))
+	assert.Equal(t, []string{"deploy: pending", "lint: failed"}, results.blockingChecks(nil))
+	assert.Equal(t, []string{"no checks reported"}, commitCheckResults{}.blockingChecks(nil))
+}
+
+func Test_FindLatestGreenCommit(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindLatestGreenCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "find_latest_green_commit", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "checks")
+	assert.Contains(t, tool.InputSchema.Properties, "max_commits")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// c3 is the head, with build still running, c2 failed the ruleset check, c1 is green
+	checkRuns := map[string][]*github.CheckRun{
+		"c3": {
+			{Name: github.Ptr("build"), Status: github.Ptr("in_progress")},
+			{Name: github.Ptr("e2e"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		},
+		"c2": {
+			{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			{Name: github.Ptr("e2e"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+		},
+		"c1": {
+			{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			{Name: github.Ptr("e2e"), Status: github.Ptr("completed"), Conclusion: github.Ptr("skipped")},
+			{Name: github.Ptr("flaky"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+		},
+	}
+	statuses := map[string][]*github.RepoStatus{
+		"c1": {{Context: github.Ptr("ci/legacy"), State: github.Ptr("success")}},
+	}
+	options := []mock.MockBackendOption{
+		mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+		mock.WithRequestMatch(
+			mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+			&github.RequiredStatusChecks{Contexts: &[]string{"build"}},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+			expectPath(t, "/repos/owner/repo/rules/branches/main").andThen(
+				mockResponse(t, http.StatusOK, `[
+					{"type": "required_status_checks", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 1, "parameters": {"required_status_checks": [{"context": "build"}, {"context": "e2e"}], "strict_required_status_checks_policy": false}}
+				]`),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"sha": "main", "per_page": "20"}).andThen(
+				mockResponse(t, http.StatusOK, []*github.RepositoryCommit{
+					{SHA: github.Ptr("c3"), Commit: &github.Commit{Message: github.Ptr("Add search")}},
+					{SHA: github.Ptr("c2"), Commit: &github.Commit{Message: github.Ptr("Fix login\n\nDetails")}},
+					{SHA: github.Ptr("c1"), Commit: &github.Commit{Message: github.Ptr("Bump deps")}, HTMLURL: github.Ptr("https://github.com/owner/repo/commit/c1")},
+					{SHA: github.Ptr("c0"), Commit: &github.Commit{Message: github.Ptr("Initial commit")}},
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "latest", r.URL.Query().Get("filter"))
+				sha := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/commits/"), "/")[0]
+				mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{CheckRuns: checkRuns[sha]})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsStatusByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sha := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/commits/"), "/")[0]
+				mockResponse(t, http.StatusOK, &github.CombinedStatus{Statuses: statuses[sha]})(w, r)
+			}),
+		),
+	}
+
+	t.Run("finds the newest commit passing the required checks", func(t *testing.T) {
+		_, handler := FindLatestGreenCommit(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var green latestGreenCommit
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &green))
+		assert.Equal(t, "main", green.Branch)
+		assert.Equal(t, []string{"build", "e2e"}, green.RequiredChecks)
+		assert.Equal(t, 3, green.CommitsChecked)
+		require.NotNil(t, green.Commit)
+		assert.Equal(t, "c1", green.Commit.SHA)
+		assert.Equal(t, "Bump deps", green.Commit.Subject)
+		assert.Equal(t, "https://github.com/owner/repo/commit/c1", green.Commit.URL)
+		assert.Equal(t, []string{"build", "ci/legacy", "e2e", "flaky"}, green.Commit.Checks)
+		assert.Equal(t, []skippedCommit{
+			{SHA: "c3", Subject: "Add search", Blocking: []string{"build: pending"}},
+			{SHA: "c2", Subject: "Fix login", Blocking: []string{"e2e: failed"}},
+		}, green.Skipped)
+	})
+
+	t.Run("checks the given checks", func(t *testing.T) {
+		_, handler := FindLatestGreenCommit(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"branch": "main",
+			"checks": []any{"flaky"},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var green latestGreenCommit
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &green))
+		assert.Nil(t, green.Commit)
+		assert.Equal(t, 4, green.CommitsChecked)
+		require.Len(t, green.Skipped, 4)
+		assert.Equal(t, []string{"flaky: failed"}, green.Skipped[2].Blocking)
+		assert.Equal(t, []string{"flaky: missing"}, green.Skipped[3].Blocking)
+	})
+}
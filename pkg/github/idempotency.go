@@ -0,0 +1,159 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// IdempotencyKeyParam is the parameter added to write tools to deduplicate retried calls.
+const IdempotencyKeyParam = "idempotency_key"
+
+// DefaultIdempotencyTTL is how long the result of a call is remembered for its idempotency key.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+type idempotencyEntry struct {
+	argsHash string
+	// done is closed once the first call with the key has finished.
+	done    chan struct{}
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+// IdempotencyStore remembers the results of write tool calls by idempotency key, so that a retried call returns the
+// original result instead of performing the mutation again.
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*idempotencyEntry
+	now     func() time.Time
+}
+
+// NewIdempotencyStore creates an in-memory store that remembers results for the given duration.
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// SetTTL changes how long results are remembered. Results already stored keep their expiry.
+func (s *IdempotencyStore) SetTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ttl = ttl
+}
+
+// hashArguments returns a stable digest of the tool arguments, ignoring the idempotency key itself.
+func hashArguments(args map[string]any) (string, error) {
+	filtered := make(map[string]any, len(args))
+	for k, v := range args {
+		if k != IdempotencyKeyParam {
+			filtered[k] = v
+		}
+	}
+	// encoding/json sorts map keys, which makes the encoding deterministic.
+	b, err := json.Marshal(filtered)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// claim returns the entry for the key and whether the caller owns it and must perform the call. Expired entries
+// are evicted as a side effect.
+func (s *IdempotencyStore) claim(key, argsHash string) (*idempotencyEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, e := range s.entries {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+
+	if e, ok := s.entries[key]; ok {
+		return e, false
+	}
+	e := &idempotencyEntry{argsHash: argsHash, done: make(chan struct{})}
+	s.entries[key] = e
+	return e, true
+}
+
+// complete records the outcome of a claimed call. Failed calls are forgotten so that they can be retried.
+func (s *IdempotencyStore) complete(key string, e *idempotencyEntry, result *mcp.CallToolResult, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil || result == nil || result.IsError {
+		delete(s.entries, key)
+	} else {
+		e.result = result
+		e.expires = s.now().Add(s.ttl)
+	}
+	close(e.done)
+}
+
+// WithIdempotency returns a function that adds an optional idempotency_key parameter to a write tool. Repeated
+// calls to the same tool with the same key, by the same session, return the result of the first successful call
+// without calling the GitHub API again.
+func WithIdempotency(store *IdempotencyStore) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		mcp.WithString(IdempotencyKeyParam,
+			mcp.Description("Optional unique key for this operation. Retrying a call with the same key returns the original result instead of repeating the change"),
+		)(&st.Tool)
+
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			key, err := OptionalParam[string](request, IdempotencyKeyParam)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if key == "" {
+				return next(ctx, request)
+			}
+
+			argsHash, err := hashArguments(request.GetArguments())
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash arguments: %w", err)
+			}
+
+			// Keys are scoped to the session so that a session cannot read the results of another one
+			storeKey := sessionKey(ctx) + "\x00" + st.Tool.Name + "\x00" + key
+			for {
+				entry, owner := store.claim(storeKey, argsHash)
+				if owner {
+					result, err := next(ctx, request)
+					store.complete(storeKey, entry, result, err)
+					return result, err
+				}
+
+				if entry.argsHash != argsHash {
+					return mcp.NewToolResultError(fmt.Sprintf("idempotency key %q was already used with different arguments", key)), nil
+				}
+
+				// Another call with this key may still be in flight, wait for it to finish.
+				select {
+				case <-entry.done:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+				if entry.result != nil {
+					return entry.result, nil
+				}
+				// The earlier call failed and was forgotten, so try to claim the key again.
+			}
+		}
+		return st
+	}
+}
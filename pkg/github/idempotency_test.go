@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countingTool(calls *atomic.Int32, fail func(n int32) (*mcp.CallToolResult, error)) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("create_thing", mcp.WithString("title", mcp.Required())),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			n := calls.Add(1)
+			if fail != nil {
+				if result, err := fail(n); result != nil || err != nil {
+					return result, err
+				}
+			}
+			return mcp.NewToolResultText("created"), nil
+		},
+	}
+}
+
+func Test_WithIdempotency(t *testing.T) {
+	t.Run("adds the parameter", func(t *testing.T) {
+		var calls atomic.Int32
+		st := WithIdempotency(NewIdempotencyStore(time.Hour))(countingTool(&calls, nil))
+		assert.Contains(t, st.Tool.InputSchema.Properties, IdempotencyKeyParam)
+		assert.ElementsMatch(t, st.Tool.InputSchema.Required, []string{"title"})
+	})
+
+	t.Run("calls without a key are not deduplicated", func(t *testing.T) {
+		var calls atomic.Int32
+		st := WithIdempotency(NewIdempotencyStore(time.Hour))(countingTool(&calls, nil))
+		request := createMCPRequest(map[string]any{"title": "a"})
+
+		for i := 0; i < 2; i++ {
+			_, err := st.Handler(context.Background(), request)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("retries return the original result", func(t *testing.T) {
+		var calls atomic.Int32
+		st := WithIdempotency(NewIdempotencyStore(time.Hour))(countingTool(&calls, nil))
+		request := createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"})
+
+		for i := 0; i < 3; i++ {
+			result, err := st.Handler(context.Background(), request)
+			require.NoError(t, err)
+			assert.Equal(t, "created", getTextResult(t, result).Text)
+		}
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("concurrent retries wait for the first call", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		st := WithIdempotency(NewIdempotencyStore(time.Hour))(countingTool(&calls, func(_ int32) (*mcp.CallToolResult, error) {
+			<-release
+			return nil, nil
+		}))
+		request := createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := st.Handler(context.Background(), request)
+				assert.NoError(t, err)
+				assert.False(t, result.IsError)
+			}()
+		}
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("reusing a key with different arguments is rejected", func(t *testing.T) {
+		var calls atomic.Int32
+		st := WithIdempotency(NewIdempotencyStore(time.Hour))(countingTool(&calls, nil))
+
+		_, err := st.Handler(context.Background(), createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"}))
+		require.NoError(t, err)
+		result, err := st.Handler(context.Background(), createMCPRequest(map[string]any{"title": "b", IdempotencyKeyParam: "key-1"}))
+		require.NoError(t, err)
+
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "idempotency key \"key-1\" was already used with different arguments")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("keys are scoped to the session", func(t *testing.T) {
+		var calls atomic.Int32
+		st := WithIdempotency(NewIdempotencyStore(time.Hour))(countingTool(&calls, nil))
+		srv := server.NewMCPServer("test", "1.0.0")
+		first := srv.WithContext(context.Background(), testSession("first"))
+		second := srv.WithContext(context.Background(), testSession("second"))
+
+		_, err := st.Handler(first, createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"}))
+		require.NoError(t, err)
+
+		// The same key and arguments from another session are a new call
+		result, err := st.Handler(second, createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, int32(2), calls.Load())
+
+		// and so are other arguments, which are not a conflict
+		result, err = st.Handler(second, createMCPRequest(map[string]any{"title": "b", IdempotencyKeyParam: "key-2"}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		result, err = st.Handler(first, createMCPRequest(map[string]any{"title": "c", IdempotencyKeyParam: "key-2"}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, int32(4), calls.Load())
+
+		// while retries within a session are still deduplicated
+		_, err = st.Handler(first, createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"}))
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+
+	t.Run("failed calls can be retried", func(t *testing.T) {
+		var calls atomic.Int32
+		st := WithIdempotency(NewIdempotencyStore(time.Hour))(countingTool(&calls, func(n int32) (*mcp.CallToolResult, error) {
+			switch n {
+			case 1:
+				return nil, errors.New("timeout")
+			case 2:
+				return mcp.NewToolResultError("validation failed"), nil
+			}
+			return nil, nil
+		}))
+		request := createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"})
+
+		_, err := st.Handler(context.Background(), request)
+		require.Error(t, err)
+		result, err := st.Handler(context.Background(), request)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		result, err = st.Handler(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, "created", getTextResult(t, result).Text)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("results expire", func(t *testing.T) {
+		var calls atomic.Int32
+		store := NewIdempotencyStore(time.Hour)
+		now := time.Now()
+		store.now = func() time.Time { return now }
+		st := WithIdempotency(store)(countingTool(&calls, nil))
+		request := createMCPRequest(map[string]any{"title": "a", IdempotencyKeyParam: "key-1"})
+
+		_, err := st.Handler(context.Background(), request)
+		require.NoError(t, err)
+		now = now.Add(2 * time.Hour)
+		_, err = st.Handler(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxIssueEvents bounds the events list_issue_events reads, the oldest first.
+const maxIssueEvents = 1000
+
+// issueEventTypes are the event types list_issue_events can filter on.
+var issueEventTypes = []string{
+	"closed", "reopened", "assigned", "unassigned", "labeled", "unlabeled", "milestoned", "demilestoned",
+	"renamed", "locked", "unlocked", "referenced", "merged", "mentioned", "subscribed", "unsubscribed",
+	"pinned", "unpinned", "transferred", "converted_to_discussion", "marked_as_duplicate", "unmarked_as_duplicate",
+	"head_ref_deleted", "head_ref_restored", "review_requested", "review_request_removed", "review_dismissed",
+}
+
+// issueEvent is an event of an issue as list_issue_events returns it, with only the fields of its type set.
+type issueEvent struct {
+	ID        int64     `json:"id"`
+	Event     string    `json:"event"`
+	Actor     string    `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Assignee  string    `json:"assignee,omitempty"`
+	Label     string    `json:"label,omitempty"`
+	Milestone string    `json:"milestone,omitempty"`
+	// RenamedFrom and RenamedTo are the titles of renamed events.
+	RenamedFrom string `json:"renamed_from,omitempty"`
+	RenamedTo   string `json:"renamed_to,omitempty"`
+	// CommitID is the commit that closed or referenced the issue.
+	CommitID          string `json:"commit_id,omitempty"`
+	LockReason        string `json:"lock_reason,omitempty"`
+	RequestedReviewer string `json:"requested_reviewer,omitempty"`
+	// App is the GitHub App that performed the event on behalf of the actor.
+	App string `json:"app,omitempty"`
+}
+
+func newIssueEvent(event *github.IssueEvent) issueEvent {
+	e := issueEvent{
+		ID:                event.GetID(),
+		Event:             event.GetEvent(),
+		Actor:             event.GetActor().GetLogin(),
+		CreatedAt:         event.GetCreatedAt().Time,
+		Assignee:          event.GetAssignee().GetLogin(),
+		Label:             event.GetLabel().GetName(),
+		Milestone:         event.GetMilestone().GetTitle(),
+		CommitID:          event.GetCommitID(),
+		LockReason:        event.GetLockReason(),
+		RequestedReviewer: event.GetRequestedReviewer().GetLogin(),
+		App:               event.GetPerformedViaGithubApp().GetSlug(),
+	}
+	if event.Rename != nil {
+		e.RenamedFrom = event.Rename.GetFrom()
+		e.RenamedTo = event.Rename.GetTo()
+	}
+	return e
+}
+
+// issueEvents is the result of list_issue_events.
+type issueEvents struct {
+	// Scanned is the number of events read before filtering.
+	Scanned int          `json:"scanned"`
+	Events  []issueEvent `json:"events"`
+}
+
+// ListIssueEvents creates a tool to list the events of an issue, such as who closed, reopened or assigned it and
+// when.
+func ListIssueEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_events",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_EVENTS_DESCRIPTION", fmt.Sprintf("List the events of an issue or pull request, oldest first, to audit who closed, reopened, assigned, labeled or renamed it and when. Events can be filtered by type and actor. At most the first %d events are read.", maxIssueEvents))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ISSUE_EVENTS_USER_TITLE", "List issue events"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+			mcp.WithArray("event_types",
+				mcp.Description("Only return events of these types, e.g. closed, reopened, assigned. All events by default"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+						"enum": issueEventTypes,
+					},
+				),
+			),
+			mcp.WithString("actor",
+				mcp.Description("Only return events performed by this user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListIssueEventsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			types := make(map[string]bool, len(params.EventTypes))
+			for _, eventType := range params.EventTypes {
+				if !slices.Contains(issueEventTypes, eventType) {
+					return mcp.NewToolResultError(fmt.Sprintf("unknown event type %q, supported types are %s", eventType, strings.Join(issueEventTypes, ", "))), nil
+				}
+				types[eventType] = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := issueEvents{Events: []issueEvent{}}
+			opts := &github.ListOptions{PerPage: 100}
+			for result.Scanned < maxIssueEvents {
+				events, resp, err := client.Issues.ListIssueEvents(ctx, params.Owner, params.Repo, params.IssueNumber, opts)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("issue %d not found in %s/%s", params.IssueNumber, params.Owner, params.Repo)), nil
+					}
+					return nil, fmt.Errorf("failed to list issue events: %w", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					body, err := io.ReadAll(resp.Body)
+					_ = resp.Body.Close()
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list issue events: %s", string(body))), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, event := range events[:min(len(events), maxIssueEvents-result.Scanned)] {
+					result.Scanned++
+					if len(types) > 0 && !types[event.GetEvent()] {
+						continue
+					}
+					if params.Actor != "" && !strings.EqualFold(event.GetActor().GetLogin(), params.Actor) {
+						continue
+					}
+					result.Events = append(result.Events, newIssueEvent(event))
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
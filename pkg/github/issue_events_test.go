@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListIssueEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_issue_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "event_types")
+	assert.Contains(t, tool.InputSchema.Properties, "actor")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	at := &github.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	pages := map[string][]*github.IssueEvent{
+		"": {
+			{ID: github.Ptr(int64(1)), Event: github.Ptr("assigned"), Actor: &github.User{Login: github.Ptr("octocat")}, Assignee: &github.User{Login: github.Ptr("hubot")}, CreatedAt: at},
+			{ID: github.Ptr(int64(2)), Event: github.Ptr("labeled"), Actor: &github.User{Login: github.Ptr("octocat")}, Label: &github.Label{Name: github.Ptr("bug")}, CreatedAt: at},
+		},
+		"2": {
+			{ID: github.Ptr(int64(3)), Event: github.Ptr("closed"), Actor: &github.User{Login: github.Ptr("Hubot")}, CommitID: github.Ptr("abc123"), CreatedAt: at},
+			{ID: github.Ptr(int64(4)), Event: github.Ptr("reopened"), Actor: &github.User{Login: github.Ptr("octocat")}, CreatedAt: at},
+		},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesEventsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page := r.URL.Query().Get("page")
+				if page == "" {
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues/42/events?page=2>; rel="next"`)
+				}
+				mockResponse(t, http.StatusOK, pages[page])(w, r)
+			}),
+		),
+	))
+	_, handler := ListIssueEvents(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	call := func(t *testing.T, args map[string]any) issueEvents {
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		var events issueEvents
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &events))
+		return events
+	}
+
+	t.Run("all events", func(t *testing.T) {
+		events := call(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)})
+		assert.Equal(t, 4, events.Scanned)
+		require.Len(t, events.Events, 4)
+		assert.Equal(t, "hubot", events.Events[0].Assignee)
+		assert.Equal(t, "bug", events.Events[1].Label)
+		assert.Equal(t, "abc123", events.Events[2].CommitID)
+	})
+
+	t.Run("filtered by type and actor", func(t *testing.T) {
+		events := call(t, map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(42),
+			"event_types":  []any{"closed", "reopened"},
+			"actor":        "hubot",
+		})
+		assert.Equal(t, 4, events.Scanned)
+		require.Len(t, events.Events, 1)
+		assert.Equal(t, "closed", events.Events[0].Event)
+		assert.Equal(t, "Hubot", events.Events[0].Actor)
+	})
+
+	t.Run("unknown event types are rejected", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(42),
+			"event_types":  []any{"deleted"},
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `unknown event type "deleted"`)
+	})
+}
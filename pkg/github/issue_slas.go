@@ -0,0 +1,258 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxSLAIssues bounds the open issues check_issue_slas evaluates.
+const maxSLAIssues = 500
+
+// responderAssociations are the author associations of the comments that count as a response to an issue.
+var responderAssociations = map[string]bool{
+	"OWNER":        true,
+	"MEMBER":       true,
+	"COLLABORATOR": true,
+}
+
+// slaRule is the first response time required for issues with a label.
+type slaRule struct {
+	Label string
+	Hours float64
+}
+
+// parseSLARules validates the rules argument of check_issue_slas.
+func parseSLARules(rules []any) ([]slaRule, error) {
+	parsed := make([]slaRule, 0, len(rules))
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("rules[%d] must be an object", i)
+		}
+		label, _ := ruleMap["label"].(string)
+		if label == "" {
+			return nil, fmt.Errorf("rules[%d] must have a label", i)
+		}
+		hours, ok := ruleMap["first_response_hours"].(float64)
+		if !ok || hours <= 0 {
+			return nil, fmt.Errorf("rules[%d] must have a positive first_response_hours", i)
+		}
+		parsed = append(parsed, slaRule{Label: label, Hours: hours})
+	}
+	return parsed, nil
+}
+
+// issueSLA returns the strictest rule applying to an issue, falling back to the default, and whether one applies.
+func issueSLA(issue *github.Issue, rules []slaRule, defaultHours float64) (slaRule, bool) {
+	var sla slaRule
+	found := false
+	for _, rule := range rules {
+		for _, label := range issue.Labels {
+			if strings.EqualFold(label.GetName(), rule.Label) && (!found || rule.Hours < sla.Hours) {
+				sla, found = rule, true
+			}
+		}
+	}
+	if !found && defaultHours > 0 {
+		return slaRule{Hours: defaultHours}, true
+	}
+	return sla, found
+}
+
+// slaIssue is an open issue awaiting a first response.
+type slaIssue struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Labels []string `json:"labels"`
+	// Rule is the label whose rule applies, empty for the default.
+	Rule     string    `json:"rule,omitempty"`
+	SLAHours float64   `json:"sla_hours"`
+	AgeHours float64   `json:"age_hours"`
+	DueAt    time.Time `json:"due_at"`
+}
+
+// issueSLAReport is the result of check_issue_slas.
+type issueSLAReport struct {
+	IssuesChecked int        `json:"issues_checked"`
+	WithoutSLA    int        `json:"without_sla"`
+	Responded     int        `json:"responded"`
+	OnTrack       int        `json:"on_track"`
+	Breached      []slaIssue `json:"breached"`
+	AtRisk        []slaIssue `json:"at_risk"`
+	Warnings      []string   `json:"warnings,omitempty"`
+}
+
+// CheckIssueSLAs creates a tool to evaluate the open issues of a repository against first response SLAs.
+func CheckIssueSLAs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_issue_slas",
+			mcp.WithDescription(t("TOOL_CHECK_ISSUE_SLAS_DESCRIPTION", "Evaluate the open issues of a repository against first response SLAs set per label, e.g. priority or support tier, and return the issues past their SLA without a response, and those at risk of it. An issue gets the strictest SLA of its labels, or the default one. A response is a comment from an owner, member or collaborator other than the author of the issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_ISSUE_SLAS_USER_TITLE", "Check issue SLAs"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("rules",
+				mcp.Items(
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"label", "first_response_hours"},
+						"properties": map[string]any{
+							"label": map[string]any{
+								"type":        "string",
+								"description": "label of the issues the rule applies to",
+							},
+							"first_response_hours": map[string]any{
+								"type":        "number",
+								"description": "hours within which the issues must get a first response",
+							},
+						},
+					}),
+				mcp.Description("First response SLAs by label, e.g. {\"label\": \"P1\", \"first_response_hours\": 4}"),
+			),
+			mcp.WithNumber("default_first_response_hours",
+				mcp.Description("First response SLA in hours of the issues no rule applies to. Without it, those issues are not evaluated"),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("at_risk_percent",
+				mcp.Description("Share of the SLA elapsed from which an issue is at risk, defaults to 75"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCheckIssueSlasParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rules, err := parseSLARules(params.Rules)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(rules) == 0 && params.DefaultFirstResponseHours <= 0 {
+				return mcp.NewToolResultError("at least one of rules and default_first_response_hours is required"), nil
+			}
+			if params.AtRiskPercent <= 0 {
+				params.AtRiskPercent = 75
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			report := issueSLAReport{Breached: []slaIssue{}, AtRisk: []slaIssue{}}
+			var issues []*github.Issue
+			opts := &github.IssueListByRepoOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				page, resp, err := client.Issues.ListByRepo(ctx, params.Owner, params.Repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list issues: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, issue := range page {
+					if !issue.IsPullRequest() {
+						issues = append(issues, issue)
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(issues) >= maxSLAIssues {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("only the latest %d open issues were checked", maxSLAIssues))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			now := time.Now()
+			for _, issue := range issues {
+				report.IssuesChecked++
+				sla, ok := issueSLA(issue, rules, float64(params.DefaultFirstResponseHours))
+				if !ok {
+					report.WithoutSLA++
+					continue
+				}
+
+				responded := false
+				if issue.GetComments() > 0 {
+					commentOpts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+				pages:
+					for {
+						comments, resp, err := client.Issues.ListComments(ctx, params.Owner, params.Repo, issue.GetNumber(), commentOpts)
+						if err != nil {
+							return nil, fmt.Errorf("failed to list comments of issue #%d: %w", issue.GetNumber(), err)
+						}
+						_ = resp.Body.Close()
+						for _, comment := range comments {
+							if responderAssociations[comment.GetAuthorAssociation()] &&
+								comment.GetUser().GetLogin() != issue.GetUser().GetLogin() &&
+								comment.GetUser().GetType() != "Bot" {
+								responded = true
+								break pages
+							}
+						}
+						if resp.NextPage == 0 {
+							break
+						}
+						commentOpts.Page = resp.NextPage
+					}
+				}
+				if responded {
+					report.Responded++
+					continue
+				}
+
+				createdAt := issue.GetCreatedAt().Time
+				age := now.Sub(createdAt).Hours()
+				item := slaIssue{
+					Number:   issue.GetNumber(),
+					Title:    issue.GetTitle(),
+					URL:      issue.GetHTMLURL(),
+					Labels:   []string{},
+					Rule:     sla.Label,
+					SLAHours: sla.Hours,
+					AgeHours: math.Round(age*10) / 10,
+					DueAt:    createdAt.Add(time.Duration(sla.Hours * float64(time.Hour))),
+				}
+				for _, label := range issue.Labels {
+					item.Labels = append(item.Labels, label.GetName())
+				}
+				switch {
+				case age >= sla.Hours:
+					report.Breached = append(report.Breached, item)
+				case age >= sla.Hours*float64(params.AtRiskPercent)/100:
+					report.AtRisk = append(report.AtRisk, item)
+				default:
+					report.OnTrack++
+				}
+			}
+			// Most overdue first, then nearest to breaching first
+			sort.Slice(report.Breached, func(i, j int) bool { return report.Breached[i].DueAt.Before(report.Breached[j].DueAt) })
+			sort.Slice(report.AtRisk, func(i, j int) bool { return report.AtRisk[i].DueAt.Before(report.AtRisk[j].DueAt) })
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
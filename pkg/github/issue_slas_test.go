@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueSLA(t *testing.T) {
+	rules := []slaRule{{Label: "P1", Hours: 4}, {Label: "customer", Hours: 24}}
+	issue := func(labels ...string) *github.Issue {
+		i := &github.Issue{}
+		for _, label := range labels {
+			i.Labels = append(i.Labels, &github.Label{Name: github.Ptr(label)})
+		}
+		return i
+	}
+
+	sla, ok := issueSLA(issue("customer", "p1"), rules, 0)
+	assert.True(t, ok)
+	assert.Equal(t, slaRule{Label: "P1", Hours: 4}, sla, "the strictest rule applies")
+
+	sla, ok = issueSLA(issue("bug"), rules, 72)
+	assert.True(t, ok)
+	assert.Equal(t, slaRule{Hours: 72}, sla)
+
+	_, ok = issueSLA(issue("bug"), rules, 0)
+	assert.False(t, ok)
+}
+
+func Test_ParseSLARules(t *testing.T) {
+	rules, err := parseSLARules([]any{map[string]any{"label": "P1", "first_response_hours": float64(4)}})
+	require.NoError(t, err)
+	assert.Equal(t, []slaRule{{Label: "P1", Hours: 4}}, rules)
+
+	_, err = parseSLARules([]any{"P1"})
+	assert.EqualError(t, err, "rules[0] must be an object")
+	_, err = parseSLARules([]any{map[string]any{"label": "P1"}})
+	assert.EqualError(t, err, "rules[0] must have a positive first_response_hours")
+}
+
+func Test_CheckIssueSLAs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CheckIssueSLAs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "check_issue_slas", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "rules")
+	assert.Contains(t, tool.InputSchema.Properties, "default_first_response_hours")
+	assert.Contains(t, tool.InputSchema.Properties, "at_risk_percent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	hoursAgo := func(hours float64) *github.Timestamp {
+		return &github.Timestamp{Time: time.Now().Add(-time.Duration(hours * float64(time.Hour)))}
+	}
+	labels := func(names ...string) []*github.Label {
+		var l []*github.Label
+		for _, name := range names {
+			l = append(l, &github.Label{Name: github.Ptr(name)})
+		}
+		return l
+	}
+	reporter := &github.User{Login: github.Ptr("reporter")}
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepo, []*github.Issue{
+			// Breached: only the author and a bot commented
+			{Number: github.Ptr(1), Title: github.Ptr("Outage"), Labels: labels("P1"), CreatedAt: hoursAgo(10), Comments: github.Ptr(2), User: reporter},
+			// Responded by a maintainer
+			{Number: github.Ptr(2), Title: github.Ptr("Crash"), Labels: labels("P1"), CreatedAt: hoursAgo(10), Comments: github.Ptr(1), User: reporter},
+			// At risk: 20 of 24 hours elapsed
+			{Number: github.Ptr(3), Title: github.Ptr("Question"), Labels: labels("customer"), CreatedAt: hoursAgo(20), User: reporter},
+			// On track
+			{Number: github.Ptr(4), Title: github.Ptr("Typo"), Labels: labels("customer"), CreatedAt: hoursAgo(1), User: reporter},
+			// No SLA
+			{Number: github.Ptr(5), Title: github.Ptr("Idea"), Labels: labels("enhancement"), CreatedAt: hoursAgo(100), User: reporter},
+			// Pull requests are left out
+			{Number: github.Ptr(6), Labels: labels("P1"), CreatedAt: hoursAgo(100), PullRequestLinks: &github.PullRequestLinks{}},
+		}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				comments := []*github.IssueComment{
+					{User: reporter, AuthorAssociation: github.Ptr("OWNER")},
+					{User: &github.User{Login: github.Ptr("ci-bot"), Type: github.Ptr("Bot")}, AuthorAssociation: github.Ptr("MEMBER")},
+				}
+				if r.URL.Path == "/repos/owner/repo/issues/2/comments" {
+					comments = []*github.IssueComment{{User: &github.User{Login: github.Ptr("maintainer")}, AuthorAssociation: github.Ptr("MEMBER")}}
+				}
+				mockResponse(t, http.StatusOK, comments)(w, r)
+			}),
+		),
+	)
+	_, handler := CheckIssueSLAs(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	t.Run("reports breached and at risk issues", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"rules": []any{
+				map[string]any{"label": "P1", "first_response_hours": float64(4)},
+				map[string]any{"label": "customer", "first_response_hours": float64(24)},
+			},
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var report issueSLAReport
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+		assert.Equal(t, 5, report.IssuesChecked)
+		assert.Equal(t, 1, report.WithoutSLA)
+		assert.Equal(t, 1, report.Responded)
+		assert.Equal(t, 1, report.OnTrack)
+		require.Len(t, report.Breached, 1)
+		assert.Equal(t, 1, report.Breached[0].Number)
+		assert.Equal(t, "P1", report.Breached[0].Rule)
+		assert.Equal(t, 4.0, report.Breached[0].SLAHours)
+		assert.Equal(t, 10.0, report.Breached[0].AgeHours)
+		require.Len(t, report.AtRisk, 1)
+		assert.Equal(t, 3, report.AtRisk[0].Number)
+		assert.Equal(t, []string{"customer"}, report.AtRisk[0].Labels)
+	})
+
+	t.Run("requires an SLA", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "at least one of rules and default_first_response_hours is required")
+	})
+}
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultIssueTimelineEvents is the default number of events get_issue_timeline returns.
+	defaultIssueTimelineEvents = 100
+	// maxIssueTimelineEvents bounds the events get_issue_timeline returns.
+	maxIssueTimelineEvents = 500
+	// maxIssueTimelinePages bounds the pages of 100 events get_issue_timeline reads looking for matching events.
+	maxIssueTimelinePages = 10
+)
+
+// timelineReference is an issue or pull request referencing the issue in a cross-referenced event.
+type timelineReference struct {
+	Repository  string `json:"repository,omitempty"`
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	HTMLURL     string `json:"html_url"`
+	PullRequest bool   `json:"pull_request,omitempty"`
+}
+
+// issueTimelineEvent is an event of the timeline of an issue, with the users, labels and issues it involves reduced
+// to their names.
+type issueTimelineEvent struct {
+	Event     string             `json:"event"`
+	Actor     string             `json:"actor,omitempty"`
+	CreatedAt *github.Timestamp  `json:"created_at,omitempty"`
+	Label     string             `json:"label,omitempty"`
+	Assignee  string             `json:"assignee,omitempty"`
+	Reviewer  string             `json:"requested_reviewer,omitempty"`
+	Milestone string             `json:"milestone,omitempty"`
+	Rename    *github.Rename     `json:"rename,omitempty"`
+	CommitID  string             `json:"commit_id,omitempty"`
+	State     string             `json:"state,omitempty"`
+	Body      string             `json:"body,omitempty"`
+	Source    *timelineReference `json:"source,omitempty"`
+}
+
+// issueTimeline is the result of get_issue_timeline.
+type issueTimeline struct {
+	IssueNumber int                  `json:"issue_number"`
+	Events      []issueTimelineEvent `json:"events"`
+	// LinkedPullRequests are the pull requests cross-referencing the issue among the events read.
+	LinkedPullRequests []timelineReference `json:"linked_pull_requests"`
+	Truncated          bool                `json:"truncated,omitempty"`
+}
+
+// newIssueTimelineEvent reduces a timeline event to an issueTimelineEvent.
+func newIssueTimelineEvent(event *github.Timeline) issueTimelineEvent {
+	e := issueTimelineEvent{
+		Event:     event.GetEvent(),
+		CreatedAt: event.CreatedAt,
+		Label:     event.GetLabel().GetName(),
+		Assignee:  event.GetAssignee().GetLogin(),
+		Reviewer:  event.GetReviewer().GetLogin(),
+		Milestone: event.GetMilestone().GetTitle(),
+		Rename:    event.Rename,
+		CommitID:  event.GetCommitID(),
+		State:     event.GetState(),
+		Body:      event.GetBody(),
+	}
+	// Comments and reviews have a user rather than an actor
+	e.Actor = event.GetActor().GetLogin()
+	if e.Actor == "" {
+		e.Actor = event.GetUser().GetLogin()
+	}
+	if e.CreatedAt == nil {
+		e.CreatedAt = event.SubmittedAt
+	}
+	if issue := event.GetSource().GetIssue(); issue != nil {
+		owner, repo := issueRepository(issue, "", "")
+		e.Source = &timelineReference{
+			Number:      issue.GetNumber(),
+			Title:       issue.GetTitle(),
+			State:       issue.GetState(),
+			HTMLURL:     issue.GetHTMLURL(),
+			PullRequest: issue.IsPullRequest(),
+		}
+		if owner != "" {
+			e.Source.Repository = owner + "/" + repo
+		}
+	}
+	return e
+}
+
+// GetIssueTimeline creates a tool to get the timeline of an issue.
+func GetIssueTimeline(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_timeline",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TIMELINE_DESCRIPTION", "Get the timeline of an issue or pull request, oldest first: comments, cross-references from other issues and pull requests, assignments, label, milestone and title changes, state changes and commits, optionally filtered by event type. The pull requests cross-referencing the issue are also listed on their own.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TIMELINE_USER_TITLE", "Get issue timeline"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number"),
+			),
+			mcp.WithArray("event_types",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Event types to return, e.g. cross-referenced, commented, assigned, unassigned, labeled, unlabeled, milestoned, renamed, closed, reopened, referenced, connected. Defaults to all"),
+			),
+			mcp.WithNumber("max_events",
+				mcp.Description(fmt.Sprintf("Number of events to return, defaults to %d, at most %d", defaultIssueTimelineEvents, maxIssueTimelineEvents)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetIssueTimelineParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxEvents <= 0 {
+				params.MaxEvents = defaultIssueTimelineEvents
+			}
+			if params.MaxEvents > maxIssueTimelineEvents {
+				return mcp.NewToolResultError(fmt.Sprintf("max_events must be at most %d", maxIssueTimelineEvents)), nil
+			}
+			eventTypes := make(map[string]bool, len(params.EventTypes))
+			for _, eventType := range params.EventTypes {
+				eventTypes[strings.ToLower(strings.TrimSpace(eventType))] = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := issueTimeline{
+				IssueNumber:        params.IssueNumber,
+				Events:             []issueTimelineEvent{},
+				LinkedPullRequests: []timelineReference{},
+			}
+			linked := map[string]bool{}
+			opts := &github.ListOptions{PerPage: 100}
+		pages:
+			for pages := 1; ; pages++ {
+				events, resp, err := client.Issues.ListIssueTimeline(ctx, params.Owner, params.Repo, params.IssueNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get issue timeline: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, event := range events {
+					e := newIssueTimelineEvent(event)
+					if e.Event == "cross-referenced" && e.Source != nil && e.Source.PullRequest && !linked[e.Source.HTMLURL] {
+						linked[e.Source.HTMLURL] = true
+						result.LinkedPullRequests = append(result.LinkedPullRequests, *e.Source)
+					}
+					if len(eventTypes) > 0 && !eventTypes[e.Event] {
+						continue
+					}
+					if len(result.Events) == params.MaxEvents {
+						result.Truncated = true
+						break pages
+					}
+					result.Events = append(result.Events, e)
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if pages == maxIssueTimelinePages {
+					result.Truncated = true
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIssueTimeline(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTimeline(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_timeline", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "event_types")
+	assert.Contains(t, tool.InputSchema.Properties, "max_events")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	pullRequest := &github.Issue{
+		Number:           github.Ptr(7),
+		Title:            github.Ptr("Fix the crash"),
+		State:            github.Ptr("open"),
+		HTMLURL:          github.Ptr("https://github.com/other/repo/pull/7"),
+		RepositoryURL:    github.Ptr("https://api.github.com/repos/other/repo"),
+		PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/other/repo/pulls/7")},
+	}
+	events := []*github.Timeline{
+		{Event: github.Ptr("labeled"), Actor: &github.User{Login: github.Ptr("alice")}, Label: &github.Label{Name: github.Ptr("bug")}},
+		{Event: github.Ptr("commented"), User: &github.User{Login: github.Ptr("bob")}, Body: github.Ptr("Same here")},
+		{Event: github.Ptr("cross-referenced"), Actor: &github.User{Login: github.Ptr("carol")}, Source: &github.Source{Issue: pullRequest}},
+		{Event: github.Ptr("assigned"), Actor: &github.User{Login: github.Ptr("alice")}, Assignee: &github.User{Login: github.Ptr("carol")}},
+	}
+
+	run := func(t *testing.T, args map[string]any) issueTimeline {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+				expectPath(t, "/repos/owner/repo/issues/42/timeline").andThen(
+					mockResponse(t, http.StatusOK, events),
+				),
+			),
+		))
+		_, handler := GetIssueTimeline(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var timeline issueTimeline
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &timeline))
+		return timeline
+	}
+
+	linked := timelineReference{
+		Repository:  "other/repo",
+		Number:      7,
+		Title:       "Fix the crash",
+		State:       "open",
+		HTMLURL:     "https://github.com/other/repo/pull/7",
+		PullRequest: true,
+	}
+
+	t.Run("all events", func(t *testing.T) {
+		timeline := run(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)})
+		require.Len(t, timeline.Events, 4)
+		assert.Equal(t, issueTimelineEvent{Event: "labeled", Actor: "alice", Label: "bug"}, timeline.Events[0])
+		assert.Equal(t, issueTimelineEvent{Event: "commented", Actor: "bob", Body: "Same here"}, timeline.Events[1])
+		assert.Equal(t, &linked, timeline.Events[2].Source)
+		assert.Equal(t, []timelineReference{linked}, timeline.LinkedPullRequests)
+		assert.False(t, timeline.Truncated)
+	})
+
+	t.Run("filtered by type", func(t *testing.T) {
+		timeline := run(t, map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(42),
+			"event_types":  []any{"Assigned", "labeled"},
+			"max_events":   float64(1),
+		})
+		assert.Equal(t, []issueTimelineEvent{{Event: "labeled", Actor: "alice", Label: "bug"}}, timeline.Events)
+		assert.Equal(t, []timelineReference{linked}, timeline.LinkedPullRequests)
+		assert.True(t, timeline.Truncated)
+	})
+
+	t.Run("too many events", func(t *testing.T) {
+		_, handler := GetIssueTimeline(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(42),
+			"max_events":   float64(501),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "max_events must be at most 500", getTextResult(t, result).Text)
+	})
+}
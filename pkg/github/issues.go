@@ -39,15 +39,7 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			issueNumber, err := RequiredInt(request, "issue_number")
+			params, err := parseGetIssueParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -56,7 +48,7 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
+			issue, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, params.IssueNumber)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get issue: %w", err)
 			}
@@ -105,32 +97,20 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			issueNumber, err := RequiredInt(request, "issue_number")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			body, err := requiredParam[string](request, "body")
+			params, err := parseAddIssueCommentParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			comment := &github.IssueComment{
-				Body: github.Ptr(body),
+				Body: github.Ptr(params.Body),
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			createdComment, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, comment)
+			createdComment, resp, err := client.Issues.CreateComment(ctx, params.Owner, params.Repo, params.IssueNumber, comment)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create comment: %w", err)
 			}
@@ -188,29 +168,17 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			query, err := requiredParam[string](request, "q")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			sort, err := OptionalParam[string](request, "sort")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			order, err := OptionalParam[string](request, "order")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
+			params, err := parseSearchIssuesParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.SearchOptions{
-				Sort:  sort,
-				Order: order,
+				Sort:  params.Sort,
+				Order: params.Order,
 				ListOptions: github.ListOptions{
-					PerPage: pagination.perPage,
-					Page:    pagination.page,
+					PerPage: params.PerPage,
+					Page:    params.Page,
 				},
 			}
 
@@ -218,7 +186,7 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			result, resp, err := client.Search.Issues(ctx, query, opts)
+			result, resp, err := client.Search.Issues(ctx, params.Q, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to search issues: %w", err)
 			}
@@ -285,54 +253,22 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			title, err := requiredParam[string](request, "title")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Optional parameters
-			body, err := OptionalParam[string](request, "body")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Get assignees
-			assignees, err := OptionalStringArrayParam(request, "assignees")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Get labels
-			labels, err := OptionalStringArrayParam(request, "labels")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Get optional milestone
-			milestone, err := OptionalIntParam(request, "milestone")
+			params, err := parseCreateIssueParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			var milestoneNum *int
-			if milestone != 0 {
-				milestoneNum = &milestone
+			if params.Milestone != 0 {
+				milestoneNum = &params.Milestone
 			}
 
 			// Create the issue request
 			issueRequest := &github.IssueRequest{
-				Title:     github.Ptr(title),
-				Body:      github.Ptr(body),
-				Assignees: &assignees,
-				Labels:    &labels,
+				Title:     github.Ptr(params.Title),
+				Body:      github.Ptr(params.Body),
+				Assignees: &params.Assignees,
+				Labels:    &params.Labels,
 				Milestone: milestoneNum,
 			}
 
@@ -340,7 +276,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			issue, resp, err := client.Issues.Create(ctx, owner, repo, issueRequest)
+			issue, resp, err := client.Issues.Create(ctx, params.Owner, params.Repo, issueRequest)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create issue: %w", err)
 			}
@@ -405,64 +341,34 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			opts := &github.IssueListByRepoOptions{}
-
-			// Set optional parameters if provided
-			opts.State, err = OptionalParam[string](request, "state")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Get labels
-			opts.Labels, err = OptionalStringArrayParam(request, "labels")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			opts.Sort, err = OptionalParam[string](request, "sort")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			opts.Direction, err = OptionalParam[string](request, "direction")
+			params, err := parseListIssuesParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			since, err := OptionalParam[string](request, "since")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			opts := &github.IssueListByRepoOptions{
+				State:     params.State,
+				Labels:    params.Labels,
+				Sort:      params.Sort,
+				Direction: params.Direction,
+				ListOptions: github.ListOptions{
+					Page:    params.Page,
+					PerPage: params.PerPage,
+				},
 			}
-			if since != "" {
-				timestamp, err := parseISOTimestamp(since)
+			if params.Since != "" {
+				timestamp, err := parseISOTimestamp(params.Since)
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("failed to list issues: %s", err.Error())), nil
 				}
 				opts.Since = timestamp
 			}
 
-			if page, ok := request.GetArguments()["page"].(float64); ok {
-				opts.Page = int(page)
-			}
-
-			if perPage, ok := request.GetArguments()["perPage"].(float64); ok {
-				opts.PerPage = int(perPage)
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+			issues, resp, err := client.Issues.ListByRepo(ctx, params.Owner, params.Repo, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list issues: %w", err)
 			}
@@ -534,81 +440,62 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithNumber("milestone",
 				mcp.Description("New milestone number"),
 			),
+			mcp.WithString("expected_updated_at",
+				mcp.Description("The issue's updated_at timestamp (ISO 8601) when it was last read. The update is refused if the issue has changed since"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			issueNumber, err := RequiredInt(request, "issue_number")
+			params, err := parseUpdateIssueParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			// Create the issue request with only provided fields
 			issueRequest := &github.IssueRequest{}
-
-			// Set optional parameters if provided
-			title, err := OptionalParam[string](request, "title")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if params.Title != "" {
+				issueRequest.Title = github.Ptr(params.Title)
 			}
-			if title != "" {
-				issueRequest.Title = github.Ptr(title)
+			if params.Body != "" {
+				issueRequest.Body = github.Ptr(params.Body)
 			}
-
-			body, err := OptionalParam[string](request, "body")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if params.State != "" {
+				issueRequest.State = github.Ptr(params.State)
 			}
-			if body != "" {
-				issueRequest.Body = github.Ptr(body)
+			if len(params.Labels) > 0 {
+				issueRequest.Labels = &params.Labels
 			}
-
-			state, err := OptionalParam[string](request, "state")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if len(params.Assignees) > 0 {
+				issueRequest.Assignees = &params.Assignees
 			}
-			if state != "" {
-				issueRequest.State = github.Ptr(state)
+			if params.Milestone != 0 {
+				issueRequest.Milestone = &params.Milestone
 			}
 
-			// Get labels
-			labels, err := OptionalStringArrayParam(request, "labels")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if len(labels) > 0 {
-				issueRequest.Labels = &labels
+			var expectedUpdatedAt time.Time
+			if params.ExpectedUpdatedAt != "" {
+				expectedUpdatedAt, err = time.Parse(time.RFC3339, params.ExpectedUpdatedAt)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to parse expected_updated_at: %s, expected format is ISO 8601", params.ExpectedUpdatedAt)), nil
+				}
 			}
 
-			// Get assignees
-			assignees, err := OptionalStringArrayParam(request, "assignees")
+			client, err := getClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if len(assignees) > 0 {
-				issueRequest.Assignees = &assignees
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			milestone, err := OptionalIntParam(request, "milestone")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if milestone != 0 {
-				milestoneNum := milestone
-				issueRequest.Milestone = &milestoneNum
+			if !expectedUpdatedAt.IsZero() {
+				current, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, params.IssueNumber)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get issue: %w", err)
+				}
+				_ = resp.Body.Close()
+				if updatedAt := current.GetUpdatedAt().Time; !updatedAt.Equal(expectedUpdatedAt) {
+					return conflictResult(fmt.Sprintf("issue #%d", params.IssueNumber), expectedUpdatedAt.Format(time.RFC3339), updatedAt.Format(time.RFC3339)), nil
+				}
 			}
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
-			updatedIssue, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, issueRequest)
+			updatedIssue, resp, err := client.Issues.Edit(ctx, params.Owner, params.Repo, params.IssueNumber, issueRequest)
 			if err != nil {
 				return nil, fmt.Errorf("failed to update issue: %w", err)
 			}
@@ -659,31 +546,15 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			issueNumber, err := RequiredInt(request, "issue_number")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			page, err := OptionalIntParamWithDefault(request, "page", 1)
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			perPage, err := OptionalIntParamWithDefault(request, "per_page", 30)
+			params, err := parseGetIssueCommentsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.IssueListCommentsOptions{
 				ListOptions: github.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					Page:    params.Page,
+					PerPage: params.PerPage,
 				},
 			}
 
@@ -691,7 +562,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
+			comments, resp, err := client.Issues.ListComments(ctx, params.Owner, params.Repo, params.IssueNumber, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get issue comments: %w", err)
 			}
@@ -903,6 +774,263 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 		}
 }
 
+// ConvertIssueToDiscussion creates a tool to move an issue into a repository discussion.
+// The GraphQL API does not expose GitHub's native conversion, so the discussion is created from the
+// issue contents, after which the issue is closed with a link to the new discussion.
+func ConvertIssueToDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("convert_issue_to_discussion",
+			mcp.WithDescription(t("TOOL_CONVERT_ISSUE_TO_DISCUSSION_DESCRIPTION", "Convert an issue into a discussion in the given category of the same repository. The issue is closed as not planned, optionally with a closing comment linking to the new discussion.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CONVERT_ISSUE_TO_DISCUSSION_USER_TITLE", "Convert issue to discussion"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to convert"),
+			),
+			mcp.WithString("category",
+				mcp.Required(),
+				mcp.Description("Name or slug of the discussion category to create the discussion in"),
+			),
+			mcp.WithString("closing_comment",
+				mcp.Description("Comment to leave on the issue before closing it, a link to the discussion is appended"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseConvertIssueToDiscussionParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query struct {
+				Repository struct {
+					ID    githubv4.ID
+					Issue struct {
+						ID     githubv4.ID
+						Title  githubv4.String
+						Body   githubv4.String
+						URL    githubv4.URI
+						State  githubv4.IssueState
+						Author struct {
+							Login githubv4.String
+						}
+					} `graphql:"issue(number: $number)"`
+					DiscussionCategories struct {
+						Nodes []struct {
+							ID   githubv4.ID
+							Name githubv4.String
+							Slug githubv4.String
+						}
+					} `graphql:"discussionCategories(first: 100)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":  githubv4.String(params.Owner),
+				"repo":   githubv4.String(params.Repo),
+				"number": githubv4.Int(int32(params.IssueNumber)), // #nosec G115 - issue numbers are always small enough
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			issue := query.Repository.Issue
+			if issue.State != githubv4.IssueStateOpen {
+				return mcp.NewToolResultError(fmt.Sprintf("issue #%d is not open", params.IssueNumber)), nil
+			}
+
+			var categoryID githubv4.ID
+			available := make([]string, 0, len(query.Repository.DiscussionCategories.Nodes))
+			for _, c := range query.Repository.DiscussionCategories.Nodes {
+				if strings.EqualFold(string(c.Name), params.Category) || strings.EqualFold(string(c.Slug), params.Category) {
+					categoryID = c.ID
+					break
+				}
+				available = append(available, string(c.Name))
+			}
+			if categoryID == nil {
+				if len(available) == 0 {
+					return mcp.NewToolResultError("discussions are not enabled for this repository or it has no categories"), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("discussion category %q not found, available categories: %s", params.Category, strings.Join(available, ", "))), nil
+			}
+
+			body := fmt.Sprintf("%s\n\n---\n_Converted from issue #%d (%s), originally opened by @%s._", issue.Body, params.IssueNumber, issue.URL.String(), issue.Author.Login)
+
+			var createDiscussion struct {
+				CreateDiscussion struct {
+					Discussion struct {
+						ID     githubv4.ID
+						Number githubv4.Int
+						URL    githubv4.URI
+					}
+				} `graphql:"createDiscussion(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &createDiscussion, githubv4.CreateDiscussionInput{
+				RepositoryID: query.Repository.ID,
+				Title:        issue.Title,
+				Body:         githubv4.String(body),
+				CategoryID:   categoryID,
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create discussion: %s", err.Error())), nil
+			}
+			discussion := createDiscussion.CreateDiscussion.Discussion
+
+			if params.ClosingComment != "" {
+				var addComment struct {
+					AddComment struct {
+						Typename string `graphql:"__typename"`
+					} `graphql:"addComment(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &addComment, githubv4.AddCommentInput{
+					SubjectID: issue.ID,
+					Body:      githubv4.String(fmt.Sprintf("%s\n\n%s", params.ClosingComment, discussion.URL.String())),
+				}, nil); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("discussion %s was created but commenting on the issue failed: %s", discussion.URL.String(), err.Error())), nil
+				}
+			}
+
+			var closeIssue struct {
+				CloseIssue struct {
+					Typename string `graphql:"__typename"`
+				} `graphql:"closeIssue(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &closeIssue, githubv4.CloseIssueInput{
+				IssueID:     issue.ID,
+				StateReason: newGQLStringlike[githubv4.IssueClosedStateReason](string(githubv4.IssueClosedStateReasonNotPlanned)),
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("discussion %s was created but closing the issue failed: %s", discussion.URL.String(), err.Error())), nil
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"discussion_number": discussion.Number,
+				"discussion_url":    discussion.URL.String(),
+				"issue_closed":      true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// transferIssueQuery looks up the issue to transfer and the repository to transfer it to.
+type transferIssueQuery struct {
+	Repository struct {
+		Issue struct {
+			ID githubv4.ID
+		} `graphql:"issue(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+	Target struct {
+		ID githubv4.ID
+	} `graphql:"target: repository(owner: $owner, name: $targetRepo)"`
+}
+
+// transferIssueMutation transfers an issue and returns it as it is in its new repository.
+type transferIssueMutation struct {
+	TransferIssue struct {
+		Issue struct {
+			Number     githubv4.Int
+			URL        githubv4.URI
+			Repository struct {
+				NameWithOwner githubv4.String
+			}
+		}
+	} `graphql:"transferIssue(input: $input)"`
+}
+
+// TransferIssue creates a tool to move an issue to another repository of the same owner.
+func TransferIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_issue",
+			mcp.WithDescription(t("TOOL_TRANSFER_ISSUE_DESCRIPTION", "Transfer an issue to another repository of the same owner. The issue gets a new number in the target repository, its old URL redirects to the new one. Returns the new number and URL.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_TRANSFER_ISSUE_USER_TITLE", "Transfer issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to transfer"),
+			),
+			mcp.WithString("target_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to transfer the issue to, owned by the same owner"),
+			),
+			mcp.WithBoolean("create_labels_if_missing",
+				mcp.Description("Create the labels of the issue missing from the target repository, instead of dropping them"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseTransferIssueParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if strings.EqualFold(params.TargetRepo, params.Repo) {
+				return mcp.NewToolResultError("target_repo must be another repository than repo"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query transferIssueQuery
+			vars := map[string]any{
+				"owner":      githubv4.String(params.Owner),
+				"repo":       githubv4.String(params.Repo),
+				"number":     githubv4.Int(int32(params.IssueNumber)), // #nosec G115 - issue numbers are always small enough
+				"targetRepo": githubv4.String(params.TargetRepo),
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var mutation transferIssueMutation
+			input := githubv4.TransferIssueInput{
+				IssueID:      query.Repository.Issue.ID,
+				RepositoryID: query.Target.ID,
+			}
+			if params.CreateLabelsIfMissing {
+				input.CreateLabelsIfMissing = githubv4.NewBoolean(true)
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to transfer issue: %s", err.Error())), nil
+			}
+			issue := mutation.TransferIssue.Issue
+
+			r, err := json.Marshal(map[string]any{
+				"issue_number": issue.Number,
+				"issue_url":    issue.URL.String(),
+				"repository":   issue.Repository.NameWithOwner,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 type ReplaceActorsForAssignableInput struct {
 	AssignableID githubv4.ID   `json:"assignableId"`
 	ActorIDs     []githubv4.ID `json:"actorIds"`
@@ -939,6 +939,81 @@ func Test_UpdateIssue(t *testing.T) {
 	}
 }
 
+func Test_UpdateIssue_ExpectedUpdatedAt(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockIssue := &github.Issue{
+		Number:    github.Ptr(123),
+		UpdatedAt: &github.Timestamp{Time: updatedAt},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectedUpdatedAt  string
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "unchanged issue is updated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockIssue,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					mockIssue,
+				),
+			),
+			expectedUpdatedAt: "2024-05-01T12:00:00Z",
+		},
+		{
+			name: "changed issue is refused",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockIssue,
+				),
+			),
+			expectedUpdatedAt:  "2024-04-30T08:00:00Z",
+			expectToolError:    true,
+			expectedToolErrMsg: "conflict: issue #123 has changed since it was read (expected 2024-04-30T08:00:00Z, found 2024-05-01T12:00:00Z)",
+		},
+		{
+			name:               "invalid timestamp",
+			mockedClient:       mock.NewMockedHTTPClient(),
+			expectedUpdatedAt:  "yesterday",
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to parse expected_updated_at",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			tool, handler := UpdateIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+			assert.Contains(t, tool.InputSchema.Properties, "expected_updated_at")
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"issue_number":        float64(123),
+				"title":               "New title",
+				"expected_updated_at": tc.expectedUpdatedAt,
+			}))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+		})
+	}
+}
+
 func Test_ParseISOTimestamp(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1541,3 +1616,339 @@ func TestAssignCopilotToIssue(t *testing.T) {
 		})
 	}
 }
+
+func Test_ConvertIssueToDiscussion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ConvertIssueToDiscussion(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "convert_issue_to_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "category")
+	assert.Contains(t, tool.InputSchema.Properties, "closing_comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "category"})
+
+	issueQuery := struct {
+		Repository struct {
+			ID    githubv4.ID
+			Issue struct {
+				ID     githubv4.ID
+				Title  githubv4.String
+				Body   githubv4.String
+				URL    githubv4.URI
+				State  githubv4.IssueState
+				Author struct {
+					Login githubv4.String
+				}
+			} `graphql:"issue(number: $number)"`
+			DiscussionCategories struct {
+				Nodes []struct {
+					ID   githubv4.ID
+					Name githubv4.String
+					Slug githubv4.String
+				}
+			} `graphql:"discussionCategories(first: 100)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	issueVars := map[string]any{
+		"owner":  githubv4.String("owner"),
+		"repo":   githubv4.String("repo"),
+		"number": githubv4.Int(42),
+	}
+	issueResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"id": "R_1",
+			"issue": map[string]any{
+				"id":     "I_42",
+				"title":  "How do I configure X?",
+				"body":   "I can't work it out",
+				"url":    "https://github.com/owner/repo/issues/42",
+				"state":  "OPEN",
+				"author": map[string]any{"login": "octocat"},
+			},
+			"discussionCategories": map[string]any{
+				"nodes": []any{
+					map[string]any{"id": "DIC_1", "name": "Announcements", "slug": "announcements"},
+					map[string]any{"id": "DIC_2", "name": "Q&A", "slug": "q-a"},
+				},
+			},
+		},
+	})
+
+	createDiscussionMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			CreateDiscussion struct {
+				Discussion struct {
+					ID     githubv4.ID
+					Number githubv4.Int
+					URL    githubv4.URI
+				}
+			} `graphql:"createDiscussion(input: $input)"`
+		}{},
+		githubv4.CreateDiscussionInput{
+			RepositoryID: githubv4.ID("R_1"),
+			Title:        githubv4.String("How do I configure X?"),
+			Body:         githubv4.String("I can't work it out\n\n---\n_Converted from issue #42 (https://github.com/owner/repo/issues/42), originally opened by @octocat._"),
+			CategoryID:   githubv4.ID("DIC_2"),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"createDiscussion": map[string]any{
+				"discussion": map[string]any{
+					"id":     "D_7",
+					"number": 7,
+					"url":    "https://github.com/owner/repo/discussions/7",
+				},
+			},
+		}),
+	)
+
+	addCommentMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			AddComment struct {
+				Typename string `graphql:"__typename"`
+			} `graphql:"addComment(input: $input)"`
+		}{},
+		githubv4.AddCommentInput{
+			SubjectID: githubv4.ID("I_42"),
+			Body:      githubv4.String("Moving this to discussions\n\nhttps://github.com/owner/repo/discussions/7"),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{}),
+	)
+
+	closeIssueMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			CloseIssue struct {
+				Typename string `graphql:"__typename"`
+			} `graphql:"closeIssue(input: $input)"`
+		}{},
+		githubv4.CloseIssueInput{
+			IssueID:     githubv4.ID("I_42"),
+			StateReason: githubv4mock.Ptr(githubv4.IssueClosedStateReasonNotPlanned),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{}),
+	)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "successful conversion with closing comment",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(issueQuery, issueVars, issueResponse),
+				createDiscussionMatcher,
+				addCommentMatcher,
+				closeIssueMatcher,
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"issue_number":    float64(42),
+				"category":        "q-a",
+				"closing_comment": "Moving this to discussions",
+			},
+		},
+		{
+			name: "unknown category",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(issueQuery, issueVars, issueResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"category":     "Ideas",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "discussion category \"Ideas\" not found, available categories: Announcements, Q&A",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ConvertIssueToDiscussion(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, float64(7), returned["discussion_number"])
+			assert.Equal(t, "https://github.com/owner/repo/discussions/7", returned["discussion_url"])
+			assert.Equal(t, true, returned["issue_closed"])
+		})
+	}
+}
+
+func Test_TransferIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := TransferIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "transfer_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "target_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "create_labels_if_missing")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "target_repo"})
+
+	queryMatcher := githubv4mock.NewQueryMatcher(
+		transferIssueQuery{},
+		map[string]any{
+			"owner":      githubv4.String("owner"),
+			"repo":       githubv4.String("repo"),
+			"number":     githubv4.Int(42),
+			"targetRepo": githubv4.String("other"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"issue": map[string]any{"id": "I_42"}},
+			"target":     map[string]any{"id": "R_2"},
+		}),
+	)
+	transferResponse := githubv4mock.DataResponse(map[string]any{
+		"transferIssue": map[string]any{
+			"issue": map[string]any{
+				"number":     7,
+				"url":        "https://github.com/owner/other/issues/7",
+				"repository": map[string]any{"nameWithOwner": "owner/other"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "transfers the issue",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				queryMatcher,
+				githubv4mock.NewMutationMatcher(
+					transferIssueMutation{},
+					githubv4.TransferIssueInput{
+						IssueID:      githubv4.ID("I_42"),
+						RepositoryID: githubv4.ID("R_2"),
+					},
+					nil,
+					transferResponse,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "other",
+			},
+		},
+		{
+			name: "transfers the issue creating missing labels",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				queryMatcher,
+				githubv4mock.NewMutationMatcher(
+					transferIssueMutation{},
+					githubv4.TransferIssueInput{
+						IssueID:               githubv4.ID("I_42"),
+						RepositoryID:          githubv4.ID("R_2"),
+						CreateLabelsIfMissing: githubv4.NewBoolean(true),
+					},
+					nil,
+					transferResponse,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"issue_number":             float64(42),
+				"target_repo":              "other",
+				"create_labels_if_missing": true,
+			},
+		},
+		{
+			name:         "same repository",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "Repo",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "target_repo must be another repository than repo",
+		},
+		{
+			name: "issue not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					transferIssueQuery{},
+					map[string]any{
+						"owner":      githubv4.String("owner"),
+						"repo":       githubv4.String("repo"),
+						"number":     githubv4.Int(404),
+						"targetRepo": githubv4.String("other"),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to an Issue with the number of 404."),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(404),
+				"target_repo":  "other",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "Could not resolve to an Issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := TransferIssue(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, float64(7), returned["issue_number"])
+			assert.Equal(t, "https://github.com/owner/other/issues/7", returned["issue_url"])
+			assert.Equal(t, "owner/other", returned["repository"])
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultStepTimingRuns is the default number of recent runs get_job_step_timings compares.
+	defaultStepTimingRuns = 10
+	// maxStepTimingRuns bounds the recent runs get_job_step_timings compares, one request each.
+	maxStepTimingRuns = 30
+	// defaultSlowestSteps is the default number of slowest steps get_job_step_timings returns.
+	defaultSlowestSteps = 10
+	// maxSlowestSteps bounds the slowest steps get_job_step_timings returns.
+	maxSlowestSteps = 50
+)
+
+// stepTiming is the duration of a step of a job.
+type stepTiming struct {
+	Number          int64   `json:"number"`
+	Name            string  `json:"name"`
+	Conclusion      string  `json:"conclusion,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// jobTimings is a job with the durations of its steps.
+type jobTimings struct {
+	ID              int64        `json:"id"`
+	Name            string       `json:"name"`
+	RunID           int64        `json:"run_id"`
+	HTMLURL         string       `json:"html_url"`
+	Conclusion      string       `json:"conclusion,omitempty"`
+	DurationSeconds float64      `json:"duration_seconds"`
+	Steps           []stepTiming `json:"steps"`
+}
+
+// stepStats are the durations of a step across the runs it ran in.
+type stepStats struct {
+	Job        string  `json:"job"`
+	Step       string  `json:"step"`
+	Runs       int     `json:"runs"`
+	P50Seconds float64 `json:"p50_seconds"`
+	MaxSeconds float64 `json:"max_seconds"`
+	// JobSharePercent is the median duration of the step relative to the median duration of its job.
+	JobSharePercent float64 `json:"job_share_percent"`
+
+	durations []float64
+}
+
+// jobStepTimings is the result of get_job_step_timings.
+type jobStepTimings struct {
+	Job          *jobTimings `json:"job,omitempty"`
+	Workflow     string      `json:"workflow,omitempty"`
+	RunsAnalyzed int         `json:"runs_analyzed"`
+	SlowestSteps []stepStats `json:"slowest_steps"`
+	Warnings     []string    `json:"warnings,omitempty"`
+}
+
+// seconds returns the time between two timestamps in seconds, or false when either is missing.
+func seconds(start, end *github.Timestamp) (float64, bool) {
+	if start == nil || end == nil || start.IsZero() || end.IsZero() || end.Before(start.Time) {
+		return 0, false
+	}
+	return end.Sub(start.Time).Seconds(), true
+}
+
+// newJobTimings returns the durations of the steps of a job that ran.
+func newJobTimings(job *github.WorkflowJob) *jobTimings {
+	timings := &jobTimings{
+		ID:         job.GetID(),
+		Name:       job.GetName(),
+		RunID:      job.GetRunID(),
+		HTMLURL:    job.GetHTMLURL(),
+		Conclusion: job.GetConclusion(),
+		Steps:      []stepTiming{},
+	}
+	timings.DurationSeconds, _ = seconds(job.StartedAt, job.CompletedAt)
+	for _, step := range job.Steps {
+		duration, ok := seconds(step.StartedAt, step.CompletedAt)
+		if !ok {
+			continue
+		}
+		timings.Steps = append(timings.Steps, stepTiming{
+			Number:          step.GetNumber(),
+			Name:            step.GetName(),
+			Conclusion:      step.GetConclusion(),
+			DurationSeconds: duration,
+		})
+	}
+	return timings
+}
+
+// slowestSteps aggregates the durations of the steps of completed jobs, by job and step name, and returns the top
+// steps with the longest median duration. Skipped steps are left out.
+func slowestSteps(jobs []*github.WorkflowJob, top int) []stepStats {
+	type stepKey struct{ job, step string }
+	byStep := map[stepKey]*stepStats{}
+	jobDurations := map[string][]float64{}
+	for _, job := range jobs {
+		if job.GetStatus() != "completed" {
+			continue
+		}
+		if duration, ok := seconds(job.StartedAt, job.CompletedAt); ok {
+			jobDurations[job.GetName()] = append(jobDurations[job.GetName()], duration)
+		}
+		for _, step := range job.Steps {
+			duration, ok := seconds(step.StartedAt, step.CompletedAt)
+			if !ok || step.GetConclusion() == "skipped" {
+				continue
+			}
+			key := stepKey{job.GetName(), step.GetName()}
+			if byStep[key] == nil {
+				byStep[key] = &stepStats{Job: key.job, Step: key.step}
+			}
+			byStep[key].durations = append(byStep[key].durations, duration)
+		}
+	}
+
+	jobP50 := make(map[string]float64, len(jobDurations))
+	for name, durations := range jobDurations {
+		sort.Float64s(durations)
+		jobP50[name] = percentile(durations, 50)
+	}
+	steps := make([]stepStats, 0, len(byStep))
+	for _, stats := range byStep {
+		sort.Float64s(stats.durations)
+		stats.Runs = len(stats.durations)
+		stats.P50Seconds = percentile(stats.durations, 50)
+		stats.MaxSeconds = stats.durations[len(stats.durations)-1]
+		if p50 := jobP50[stats.Job]; p50 > 0 {
+			stats.JobSharePercent = math.Round(stats.P50Seconds/p50*1000) / 10
+		}
+		steps = append(steps, *stats)
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		if steps[i].P50Seconds != steps[j].P50Seconds {
+			return steps[i].P50Seconds > steps[j].P50Seconds
+		}
+		if steps[i].Job != steps[j].Job {
+			return steps[i].Job < steps[j].Job
+		}
+		return steps[i].Step < steps[j].Step
+	})
+	if len(steps) > top {
+		steps = steps[:top]
+	}
+	return steps
+}
+
+// GetJobStepTimings creates a tool to break the duration of GitHub Actions jobs down by step.
+func GetJobStepTimings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_job_step_timings",
+			mcp.WithDescription(t("TOOL_GET_JOB_STEP_TIMINGS_DESCRIPTION", "Break the duration of GitHub Actions jobs down by step to guide CI optimization: the duration of each step of a job, and the slowest steps across the recent completed runs of its workflow, by median duration and share of their job. Give a job_id, a workflow, or both.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_JOB_STEP_TIMINGS_USER_TITLE", "Get job step timings"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("job_id",
+				mcp.Description("ID of a job to break down. Its workflow is used for the recent runs when workflow is not given"),
+			),
+			mcp.WithString("workflow",
+				mcp.Description("Workflow whose recent runs to compare, by file name (e.g. ci.yml) or ID"),
+			),
+			mcp.WithString("job_name",
+				mcp.Description("Only compare the jobs of this name, defaults to the name of job_id when given"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Only compare the runs of this branch"),
+			),
+			mcp.WithNumber("runs",
+				mcp.Description(fmt.Sprintf("Number of recent completed runs to compare, defaults to %d, at most %d", defaultStepTimingRuns, maxStepTimingRuns)),
+			),
+			mcp.WithNumber("top",
+				mcp.Description(fmt.Sprintf("Number of slowest steps to return, defaults to %d, at most %d", defaultSlowestSteps, maxSlowestSteps)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetJobStepTimingsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.JobID <= 0 && params.Workflow == "" {
+				return mcp.NewToolResultError("job_id or workflow is required"), nil
+			}
+			if params.Runs <= 0 {
+				params.Runs = defaultStepTimingRuns
+			}
+			if params.Top <= 0 {
+				params.Top = defaultSlowestSteps
+			}
+			switch {
+			case params.Runs > maxStepTimingRuns:
+				return mcp.NewToolResultError(fmt.Sprintf("runs must be at most %d", maxStepTimingRuns)), nil
+			case params.Top > maxSlowestSteps:
+				return mcp.NewToolResultError(fmt.Sprintf("top must be at most %d", maxSlowestSteps)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := jobStepTimings{Workflow: params.Workflow, SlowestSteps: []stepStats{}}
+			if params.JobID > 0 {
+				job, resp, err := client.Actions.GetWorkflowJobByID(ctx, params.Owner, params.Repo, int64(params.JobID))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get job: %w", err)
+				}
+				_ = resp.Body.Close()
+				result.Job = newJobTimings(job)
+				if params.JobName == "" {
+					params.JobName = job.GetName()
+				}
+				if result.Workflow == "" {
+					run, resp, err := client.Actions.GetWorkflowRunByID(ctx, params.Owner, params.Repo, job.GetRunID())
+					if err != nil {
+						return nil, fmt.Errorf("failed to get workflow run: %w", err)
+					}
+					_ = resp.Body.Close()
+					result.Workflow = strconv.FormatInt(run.GetWorkflowID(), 10)
+				}
+			}
+
+			runs, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, params.Owner, params.Repo, result.Workflow, &github.ListWorkflowRunsOptions{
+				Branch:      params.Branch,
+				Status:      "completed",
+				ListOptions: github.ListOptions{PerPage: params.Runs},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			var jobs []*github.WorkflowJob
+			for _, run := range runs.WorkflowRuns {
+				// Cancelled runs stop their steps early, their durations would skew the comparison
+				if run.GetConclusion() == "cancelled" || run.GetConclusion() == "skipped" {
+					continue
+				}
+				runJobs, resp, err := client.Actions.ListWorkflowJobs(ctx, params.Owner, params.Repo, run.GetID(), &github.ListWorkflowJobsOptions{
+					Filter:      "latest",
+					ListOptions: github.ListOptions{PerPage: 100},
+				})
+				if err != nil {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("failed to list the jobs of run %d: %v", run.GetID(), err))
+					continue
+				}
+				_ = resp.Body.Close()
+				result.RunsAnalyzed++
+				for _, job := range runJobs.Jobs {
+					if params.JobName == "" || job.GetName() == params.JobName {
+						jobs = append(jobs, job)
+					}
+				}
+			}
+			result.SlowestSteps = slowestSteps(jobs, params.Top)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
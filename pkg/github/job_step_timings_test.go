@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timedJob returns a completed job of runID whose steps took the given seconds, one after the other.
+func timedJob(id, runID int64, name string, stepSeconds map[string]int, order ...string) *github.WorkflowJob {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	job := &github.WorkflowJob{
+		ID:        github.Ptr(id),
+		RunID:     github.Ptr(runID),
+		Name:      github.Ptr(name),
+		Status:    github.Ptr("completed"),
+		StartedAt: &github.Timestamp{Time: start},
+	}
+	at := start
+	for i, step := range order {
+		end := at.Add(time.Duration(stepSeconds[step]) * time.Second)
+		job.Steps = append(job.Steps, &github.TaskStep{
+			Number:      github.Ptr(int64(i + 1)),
+			Name:        github.Ptr(step),
+			Conclusion:  github.Ptr("success"),
+			StartedAt:   &github.Timestamp{Time: at},
+			CompletedAt: &github.Timestamp{Time: end},
+		})
+		at = end
+	}
+	job.CompletedAt = &github.Timestamp{Time: at}
+	return job
+}
+
+func Test_SlowestSteps(t *testing.T) {
+	skipped := timedJob(3, 3, "build", map[string]int{"checkout": 5, "test": 0}, "checkout", "test")
+	skipped.Steps[1].Conclusion = github.Ptr("skipped")
+	inProgress := timedJob(4, 4, "build", map[string]int{"test": 1000}, "test")
+	inProgress.Status = github.Ptr("in_progress")
+
+	steps := slowestSteps([]*github.WorkflowJob{
+		timedJob(1, 1, "build", map[string]int{"checkout": 10, "test": 90}, "checkout", "test"),
+		timedJob(2, 2, "build", map[string]int{"checkout": 20, "test": 110}, "checkout", "test"),
+		skipped,
+		inProgress,
+		timedJob(5, 1, "lint", map[string]int{"golangci": 40}, "golangci"),
+	}, 2)
+
+	require.Len(t, steps, 2)
+	assert.Equal(t, "build", steps[0].Job)
+	assert.Equal(t, "test", steps[0].Step)
+	assert.Equal(t, 2, steps[0].Runs)
+	assert.Equal(t, 90.0, steps[0].P50Seconds)
+	assert.Equal(t, 110.0, steps[0].MaxSeconds)
+	// The median build took 100 seconds
+	assert.Equal(t, 90.0, steps[0].JobSharePercent)
+	assert.Equal(t, "lint", steps[1].Job)
+	assert.Equal(t, 100.0, steps[1].JobSharePercent)
+}
+
+func Test_GetJobStepTimings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetJobStepTimings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_job_step_timings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "job_id")
+	assert.Contains(t, tool.InputSchema.Properties, "workflow")
+	assert.Contains(t, tool.InputSchema.Properties, "job_name")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "runs")
+	assert.Contains(t, tool.InputSchema.Properties, "top")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	job := timedJob(7, 1, "build", map[string]int{"checkout": 10, "test": 90}, "checkout", "test")
+	runs := &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{
+		{ID: github.Ptr(int64(1)), Conclusion: github.Ptr("success")},
+		{ID: github.Ptr(int64(2)), Conclusion: github.Ptr("failure")},
+		{ID: github.Ptr(int64(3)), Conclusion: github.Ptr("cancelled")},
+	}}
+	jobsOfRun := map[string]*github.Jobs{
+		"/repos/owner/repo/actions/runs/1/jobs": {Jobs: []*github.WorkflowJob{
+			job,
+			timedJob(8, 1, "lint", map[string]int{"golangci": 300}, "golangci"),
+		}},
+		"/repos/owner/repo/actions/runs/2/jobs": {Jobs: []*github.WorkflowJob{
+			timedJob(9, 2, "build", map[string]int{"checkout": 20, "test": 110}, "checkout", "test"),
+		}},
+	}
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsByOwnerByRepoByJobId,
+			expectPath(t, "/repos/owner/repo/actions/jobs/7").andThen(
+				mockResponse(t, http.StatusOK, job),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsByOwnerByRepoByRunId,
+			expectPath(t, "/repos/owner/repo/actions/runs/1").andThen(
+				mockResponse(t, http.StatusOK, &github.WorkflowRun{ID: github.Ptr(int64(1)), WorkflowID: github.Ptr(int64(42))}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+			expectQueryParams(t, map[string]string{"status": "completed", "per_page": "10"}).andThen(
+				mockResponse(t, http.StatusOK, runs),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "latest", r.URL.Query().Get("filter"))
+				jobs, ok := jobsOfRun[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				mockResponse(t, http.StatusOK, jobs)(w, r)
+			}),
+		),
+	)
+	_, handler := GetJobStepTimings(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	t.Run("breaks a job down and compares its steps across the runs of its workflow", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"job_id": float64(7),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var timings jobStepTimings
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &timings))
+		require.NotNil(t, timings.Job)
+		assert.Equal(t, 100.0, timings.Job.DurationSeconds)
+		require.Len(t, timings.Job.Steps, 2)
+		assert.Equal(t, "test", timings.Job.Steps[1].Name)
+		assert.Equal(t, 90.0, timings.Job.Steps[1].DurationSeconds)
+		assert.Equal(t, "42", timings.Workflow)
+		assert.Equal(t, 2, timings.RunsAnalyzed)
+		// Only the steps of the build job are compared
+		require.Len(t, timings.SlowestSteps, 2)
+		assert.Equal(t, "test", timings.SlowestSteps[0].Step)
+		assert.Equal(t, 2, timings.SlowestSteps[0].Runs)
+		assert.Equal(t, 110.0, timings.SlowestSteps[0].MaxSeconds)
+	})
+
+	t.Run("compares every job of a workflow", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":    "owner",
+			"repo":     "repo",
+			"workflow": "ci.yml",
+			"top":      float64(1),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var timings jobStepTimings
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &timings))
+		assert.Nil(t, timings.Job)
+		require.Len(t, timings.SlowestSteps, 1)
+		assert.Equal(t, "lint", timings.SlowestSteps[0].Job)
+		assert.Equal(t, 300.0, timings.SlowestSteps[0].P50Seconds)
+	})
+
+	t.Run("requires a job or a workflow", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "job_id or workflow is required")
+	})
+
+	t.Run("rejects too many runs", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":    "owner",
+			"repo":     "repo",
+			"workflow": "ci.yml",
+			"runs":     float64(31),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "runs must be at most 30")
+	})
+}
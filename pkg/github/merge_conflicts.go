@@ -0,0 +1,506 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultMergeConflictsWait is how long list_merge_conflicts waits for GitHub to test merge a pull request by
+	// default.
+	defaultMergeConflictsWait = 10 * time.Second
+	// maxMergeConflictsWait bounds how long list_merge_conflicts waits for the test merge.
+	maxMergeConflictsWait = time.Minute
+	// maxCompareFiles is the number of files the compare API lists at most.
+	maxCompareFiles = 300
+	// maxConflictRiskFiles bounds the changed files of each pull request detect_conflict_risk compares, the API lists
+	// at most 3000.
+	maxConflictRiskFiles = 3000
+)
+
+// Reasons two changes to the same file collide.
+const (
+	conflictOverlappingLines = "overlapping_lines"
+	conflictModifyDelete     = "modify_delete"
+	conflictAddAdd           = "add_add"
+	// conflictNoPatch is reported for binary files and diffs too large for the API to return.
+	conflictNoPatch = "no_patch"
+)
+
+// hunkHeaderPattern matches the header of a hunk of a patch, capturing its range of old lines.
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+\d+(?:,\d+)? @@`)
+
+// lineRange is a range of lines of the common ancestor a change replaces. A range ending before it starts is an
+// insertion before its start line.
+type lineRange struct {
+	start, end int
+}
+
+func (r lineRange) String() string {
+	switch {
+	case r.end < r.start:
+		return fmt.Sprintf("before %d", r.start)
+	case r.end == r.start:
+		return strconv.Itoa(r.start)
+	default:
+		return fmt.Sprintf("%d-%d", r.start, r.end)
+	}
+}
+
+// touches reports whether two changes overlap or are adjacent, which git does not merge on its own.
+func (r lineRange) touches(o lineRange) bool {
+	return r.start <= o.end+1 && o.start <= r.end+1
+}
+
+// patchRanges returns the ranges of old lines the changes of a patch replace, leaving out its context lines.
+func patchRanges(patch string) []lineRange {
+	var ranges []lineRange
+	var current lineRange
+	open := false
+	flush := func() {
+		if open {
+			ranges = append(ranges, current)
+			open = false
+		}
+	}
+	line := 0
+	for _, text := range strings.Split(patch, "\n") {
+		if m := hunkHeaderPattern.FindStringSubmatch(text); m != nil {
+			flush()
+			line, _ = strconv.Atoi(m[1])
+			// An empty range is numbered after the line preceding it
+			if m[2] == "0" {
+				line++
+			}
+			continue
+		}
+		if text == "" {
+			continue
+		}
+		switch text[0] {
+		case '-':
+			if !open {
+				current, open = lineRange{start: line, end: line - 1}, true
+			}
+			current.end = line
+			line++
+		case '+':
+			if !open {
+				current, open = lineRange{start: line, end: line - 1}, true
+			}
+		case ' ':
+			flush()
+			line++
+		}
+	}
+	flush()
+	return ranges
+}
+
+// fileConflict is how the changes two sides make to the same file collide.
+type fileConflict struct {
+	reason string
+	// lines and otherLines are the changes of each side touching a change of the other, for overlapping_lines.
+	lines, otherLines []lineRange
+}
+
+// compareFileChanges compares the changes two sides make to the same file, from the same common ancestor. It returns
+// false when git would merge them on its own.
+func compareFileChanges(file, other *github.CommitFile) (fileConflict, bool) {
+	switch {
+	case file.GetStatus() == "removed" && other.GetStatus() == "removed":
+		return fileConflict{}, false
+	case file.GetSHA() != "" && file.GetSHA() == other.GetSHA():
+		// Both sides made the same change
+		return fileConflict{}, false
+	case file.GetStatus() == "removed" || other.GetStatus() == "removed":
+		return fileConflict{reason: conflictModifyDelete}, true
+	case file.GetStatus() == "added" && other.GetStatus() == "added":
+		return fileConflict{reason: conflictAddAdd}, true
+	case file.GetPatch() == "" || other.GetPatch() == "":
+		return fileConflict{reason: conflictNoPatch}, true
+	}
+
+	var conflict fileConflict
+	ranges, otherRanges := patchRanges(file.GetPatch()), patchRanges(other.GetPatch())
+	touched := make(map[int]bool)
+	for _, r := range ranges {
+		found := false
+		for i, o := range otherRanges {
+			if r.touches(o) {
+				found = true
+				touched[i] = true
+			}
+		}
+		if found {
+			conflict.lines = append(conflict.lines, r)
+		}
+	}
+	for i, o := range otherRanges {
+		if touched[i] {
+			conflict.otherLines = append(conflict.otherLines, o)
+		}
+	}
+	if len(conflict.lines) == 0 {
+		return fileConflict{}, false
+	}
+	conflict.reason = conflictOverlappingLines
+	return conflict, true
+}
+
+// formatLineRanges formats line ranges for a result, nil when there are none.
+func formatLineRanges(ranges []lineRange) []string {
+	if len(ranges) == 0 {
+		return nil
+	}
+	formatted := make([]string, len(ranges))
+	for i, r := range ranges {
+		formatted[i] = r.String()
+	}
+	return formatted
+}
+
+// changedFilesByPath indexes changed files by their path, and by their previous path when renamed.
+func changedFilesByPath(files []*github.CommitFile) map[string]*github.CommitFile {
+	byPath := make(map[string]*github.CommitFile, len(files))
+	for _, file := range files {
+		byPath[file.GetFilename()] = file
+		if previous := file.GetPreviousFilename(); previous != "" {
+			if _, ok := byPath[previous]; !ok {
+				byPath[previous] = file
+			}
+		}
+	}
+	return byPath
+}
+
+// listPullRequestFiles lists the changed files of a pull request, with their patches, up to maxConflictRiskFiles.
+// It reports whether more files changed.
+func listPullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, number int) ([]*github.CommitFile, bool, error) {
+	var files []*github.CommitFile
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list files of pull request #%d: %w", number, err)
+		}
+		_ = resp.Body.Close()
+		files = append(files, page...)
+		if resp.NextPage == 0 {
+			return files, false, nil
+		}
+		if len(files) >= maxConflictRiskFiles {
+			return files, true, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// mergeConflict is a file a pull request and its base branch both change in ways git cannot merge.
+type mergeConflict struct {
+	Path string `json:"path"`
+	// Reason is overlapping_lines, modify_delete, add_add, or no_patch for binary files and diffs too large to compare.
+	Reason string `json:"reason"`
+	// HeadLines and BaseLines are the lines of the merge base each side changes where they collide.
+	HeadLines []string `json:"head_lines,omitempty"`
+	BaseLines []string `json:"base_lines,omitempty"`
+}
+
+// mergeConflicts is the result of list_merge_conflicts.
+type mergeConflicts struct {
+	PullNumber   int    `json:"pull_number"`
+	Base         string `json:"base"`
+	HeadSHA      string `json:"head_sha"`
+	MergeBaseSHA string `json:"merge_base_sha,omitempty"`
+	// Mergeable is the result of the test merge of GitHub, null when it was still being computed.
+	Mergeable      *bool           `json:"mergeable"`
+	MergeableState string          `json:"mergeable_state"`
+	Conflicts      []mergeConflict `json:"conflicts"`
+	Warnings       []string        `json:"warnings,omitempty"`
+}
+
+// ListMergeConflicts creates a tool to list the files conflicting between a pull request and its base branch.
+func ListMergeConflicts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_merge_conflicts",
+			mcp.WithDescription(t("TOOL_LIST_MERGE_CONFLICTS_DESCRIPTION", "List the files of a pull request that conflict with its base branch. Waits for GitHub to test merge the pull request, and when it does not merge cleanly, compares the changes of both sides since their merge base to report each conflicting file with the lines both sides change. The lines are computed from the diffs of both sides, like git does, so rare conflicts such as rename conflicts may be missed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MERGE_CONFLICTS_USER_TITLE", "List merge conflicts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("wait_seconds",
+				mcp.Description(fmt.Sprintf("Seconds to wait for GitHub to test merge the pull request, defaults to %d, at most %d", int(defaultMergeConflictsWait.Seconds()), int(maxMergeConflictsWait.Seconds()))),
+				mcp.Min(0),
+				mcp.Max(maxMergeConflictsWait.Seconds()),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListMergeConflictsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			wait := defaultMergeConflictsWait
+			if _, ok := request.GetArguments()["wait_seconds"]; ok {
+				wait = time.Duration(params.WaitSeconds) * time.Second
+			}
+			if wait < 0 || wait > maxMergeConflictsWait {
+				return mcp.NewToolResultError(fmt.Sprintf("wait_seconds must be between 0 and %d", int(maxMergeConflictsWait.Seconds()))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub test merges the pull request in the background after each push, mergeable is null until it is done
+			var pr *github.PullRequest
+			deadline := time.Now().Add(wait)
+			for {
+				var resp *github.Response
+				pr, resp, err = client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request: %w", err)
+				}
+				_ = resp.Body.Close()
+				remaining := time.Until(deadline)
+				if pr.Mergeable != nil || pr.GetState() != "open" || remaining <= 0 {
+					break
+				}
+				if err := waitOrCancel(ctx, min(mergeableStatePollInterval, remaining)); err != nil {
+					return nil, err
+				}
+			}
+
+			result := mergeConflicts{
+				PullNumber:     params.PullNumber,
+				Base:           pr.GetBase().GetRef(),
+				HeadSHA:        pr.GetHead().GetSHA(),
+				Mergeable:      pr.Mergeable,
+				MergeableState: pr.GetMergeableState(),
+				Conflicts:      []mergeConflict{},
+			}
+			if pr.GetState() != "open" {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("the pull request is %s, GitHub only test merges open pull requests", pr.GetState()))
+			}
+			if pr.GetMergeable() {
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+			if pr.Mergeable == nil {
+				result.Warnings = append(result.Warnings, "GitHub has not finished the test merge, the conflicts are predicted from the changes of both sides")
+			}
+
+			head, resp, err := client.Repositories.CompareCommits(ctx, params.Owner, params.Repo, result.Base, result.HeadSHA, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare the pull request with its base: %w", err)
+			}
+			_ = resp.Body.Close()
+			result.MergeBaseSHA = head.GetMergeBaseCommit().GetSHA()
+
+			base, resp, err := client.Repositories.CompareCommits(ctx, params.Owner, params.Repo, result.MergeBaseSHA, result.Base, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare the base branch with the merge base: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			baseFiles := changedFilesByPath(base.Files)
+			for _, file := range head.Files {
+				other, ok := baseFiles[file.GetFilename()]
+				if !ok && file.GetPreviousFilename() != "" {
+					other, ok = baseFiles[file.GetPreviousFilename()]
+				}
+				if !ok {
+					continue
+				}
+				if conflict, ok := compareFileChanges(file, other); ok {
+					result.Conflicts = append(result.Conflicts, mergeConflict{
+						Path:      file.GetFilename(),
+						Reason:    conflict.reason,
+						HeadLines: formatLineRanges(conflict.lines),
+						BaseLines: formatLineRanges(conflict.otherLines),
+					})
+				}
+			}
+
+			if len(head.Files) >= maxCompareFiles || len(base.Files) >= maxCompareFiles {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("only the first %d changed files of each side were compared", maxCompareFiles))
+			}
+			if pr.Mergeable != nil && len(result.Conflicts) == 0 {
+				result.Warnings = append(result.Warnings, "GitHub reports conflicts the changed lines do not explain, such as a file renamed differently on both sides")
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// Risks of two pull requests colliding.
+const (
+	conflictRiskHigh = "high"
+	conflictRiskLow  = "low"
+	conflictRiskNone = "none"
+)
+
+// overlappingFile is a file two pull requests both change.
+type overlappingFile struct {
+	Path string `json:"path"`
+	// Risk is high when the changes collide, low when they change different parts of the file.
+	Risk string `json:"risk"`
+	// Reason is why the changes collide: overlapping_lines, modify_delete, add_add, or no_patch for binary files and
+	// diffs too large to compare.
+	Reason string `json:"reason,omitempty"`
+	// Lines and OtherLines are the lines of the base each pull request changes where they collide.
+	Lines      []string `json:"lines,omitempty"`
+	OtherLines []string `json:"other_lines,omitempty"`
+}
+
+// conflictRisk is the result of detect_conflict_risk.
+type conflictRisk struct {
+	PullNumber      int `json:"pull_number"`
+	OtherPullNumber int `json:"other_pull_number"`
+	// Risk is high when a file collides, low when the pull requests only change the same files, none otherwise.
+	Risk             string            `json:"risk"`
+	OverlappingFiles []overlappingFile `json:"overlapping_files"`
+	Warnings         []string          `json:"warnings,omitempty"`
+}
+
+// DetectConflictRisk creates a tool to compare the changes of two pull requests for edits that will collide.
+func DetectConflictRisk(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("detect_conflict_risk",
+			mcp.WithDescription(t("TOOL_DETECT_CONFLICT_RISK_DESCRIPTION", "Compare the changes of two open pull requests of a repository to find the files both change, before merging one makes the other conflict. Each overlapping file has a high risk when both change the same or adjacent lines, or one deletes a file the other changes, and a low risk when they change different parts of it. Lines are those of the base each pull request started from, so they are approximate when the bases differ.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DETECT_CONFLICT_RISK_USER_TITLE", "Detect conflict risk between pull requests"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("other_pull_number",
+				mcp.Required(),
+				mcp.Description("Number of the pull request to compare with"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDetectConflictRiskParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.PullNumber == params.OtherPullNumber {
+				return mcp.NewToolResultError("other_pull_number must be a different pull request"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := conflictRisk{
+				PullNumber:       params.PullNumber,
+				OtherPullNumber:  params.OtherPullNumber,
+				OverlappingFiles: []overlappingFile{},
+			}
+			var bases []string
+			var changes [][]*github.CommitFile
+			for _, number := range []int{params.PullNumber, params.OtherPullNumber} {
+				pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, number)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request #%d: %w", number, err)
+				}
+				_ = resp.Body.Close()
+				if pr.GetState() != "open" {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("pull request #%d is %s", number, pr.GetState()))
+				}
+				bases = append(bases, pr.GetBase().GetRef())
+
+				files, truncated, err := listPullRequestFiles(ctx, client, params.Owner, params.Repo, number)
+				if err != nil {
+					return nil, err
+				}
+				if truncated {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("only the first %d changed files of pull request #%d were compared", len(files), number))
+				}
+				changes = append(changes, files)
+			}
+			if bases[0] != bases[1] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("the pull requests target different branches, %s and %s, they only collide once the changes of one reach the branch of the other", bases[0], bases[1]))
+			}
+
+			otherFiles := changedFilesByPath(changes[1])
+			for _, file := range changes[0] {
+				other, ok := otherFiles[file.GetFilename()]
+				if !ok && file.GetPreviousFilename() != "" {
+					other, ok = otherFiles[file.GetPreviousFilename()]
+				}
+				if !ok {
+					continue
+				}
+				overlap := overlappingFile{Path: file.GetFilename(), Risk: conflictRiskLow}
+				if conflict, ok := compareFileChanges(file, other); ok {
+					overlap.Risk = conflictRiskHigh
+					overlap.Reason = conflict.reason
+					overlap.Lines = formatLineRanges(conflict.lines)
+					overlap.OtherLines = formatLineRanges(conflict.otherLines)
+				}
+				result.OverlappingFiles = append(result.OverlappingFiles, overlap)
+			}
+
+			sort.SliceStable(result.OverlappingFiles, func(i, j int) bool {
+				a, b := result.OverlappingFiles[i], result.OverlappingFiles[j]
+				if a.Risk != b.Risk {
+					return a.Risk == conflictRiskHigh
+				}
+				return a.Path < b.Path
+			})
+			switch {
+			case len(result.OverlappingFiles) == 0:
+				result.Risk = conflictRiskNone
+			case result.OverlappingFiles[0].Risk == conflictRiskHigh:
+				result.Risk = conflictRiskHigh
+			default:
+				result.Risk = conflictRiskLow
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
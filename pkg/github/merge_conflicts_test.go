@@ -0,0 +1,342 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PatchRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected []lineRange
+	}{
+		{
+			name:     "changed line within context",
+			patch:    "@@ -10,7 +10,7 @@ func a() {\n a\n b\n c\n-d\n+D\n e\n f\n g",
+			expected: []lineRange{{13, 13}},
+		},
+		{
+			name:     "insertion and deletion in two hunks",
+			patch:    "@@ -1,3 +1,4 @@\n a\n+new\n b\n c\n@@ -20,4 +21,2 @@\n x\n-y\n-z\n w\n\\ No newline at end of file",
+			expected: []lineRange{{2, 1}, {21, 22}},
+		},
+		{
+			name:     "insertion after a line without context",
+			patch:    "@@ -40,0 +41,2 @@\n+x\n+y",
+			expected: []lineRange{{41, 40}},
+		},
+		{
+			name:     "new file",
+			patch:    "@@ -0,0 +1,2 @@\n+a\n+b",
+			expected: []lineRange{{1, 0}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, patchRanges(tc.patch))
+		})
+	}
+
+	assert.Equal(t, "before 2", lineRange{2, 1}.String())
+	assert.Equal(t, "21-22", lineRange{21, 22}.String())
+}
+
+func Test_CompareFileChanges(t *testing.T) {
+	modified := func(patch string) *github.CommitFile {
+		return &github.CommitFile{Filename: github.Ptr("a.go"), Status: github.Ptr("modified"), Patch: github.Ptr(patch)}
+	}
+	tests := []struct {
+		name     string
+		file     *github.CommitFile
+		other    *github.CommitFile
+		conflict bool
+		expected fileConflict
+	}{
+		{
+			name:     "same line",
+			file:     modified("@@ -5,3 +5,3 @@\n a\n-b\n+B\n c"),
+			other:    modified("@@ -5,3 +5,3 @@\n a\n-b\n+X\n c"),
+			conflict: true,
+			expected: fileConflict{reason: conflictOverlappingLines, lines: []lineRange{{6, 6}}, otherLines: []lineRange{{6, 6}}},
+		},
+		{
+			name:     "adjacent lines",
+			file:     modified("@@ -5,3 +5,3 @@\n a\n-b\n+B\n c"),
+			other:    modified("@@ -6,3 +6,3 @@\n b\n-c\n+C\n d"),
+			conflict: true,
+			expected: fileConflict{reason: conflictOverlappingLines, lines: []lineRange{{6, 6}}, otherLines: []lineRange{{7, 7}}},
+		},
+		{
+			name:  "separate lines",
+			file:  modified("@@ -5,3 +5,3 @@\n a\n-b\n+B\n c"),
+			other: modified("@@ -7,3 +7,3 @@\n c\n-d\n+D\n e"),
+		},
+		{
+			name:  "same change",
+			file:  &github.CommitFile{Status: github.Ptr("modified"), SHA: github.Ptr("abc"), Patch: github.Ptr("@@ -1 +1 @@\n-a\n+b")},
+			other: &github.CommitFile{Status: github.Ptr("modified"), SHA: github.Ptr("abc"), Patch: github.Ptr("@@ -1 +1 @@\n-a\n+b")},
+		},
+		{
+			name:     "modified and deleted",
+			file:     modified("@@ -1 +1 @@\n-a\n+b"),
+			other:    &github.CommitFile{Status: github.Ptr("removed")},
+			conflict: true,
+			expected: fileConflict{reason: conflictModifyDelete},
+		},
+		{
+			name:     "added on both sides",
+			file:     &github.CommitFile{Status: github.Ptr("added"), SHA: github.Ptr("abc"), Patch: github.Ptr("@@ -0,0 +1 @@\n+a")},
+			other:    &github.CommitFile{Status: github.Ptr("added"), SHA: github.Ptr("def"), Patch: github.Ptr("@@ -0,0 +1 @@\n+b")},
+			conflict: true,
+			expected: fileConflict{reason: conflictAddAdd},
+		},
+		{
+			name:     "binary",
+			file:     &github.CommitFile{Status: github.Ptr("modified"), SHA: github.Ptr("abc")},
+			other:    &github.CommitFile{Status: github.Ptr("modified"), SHA: github.Ptr("def")},
+			conflict: true,
+			expected: fileConflict{reason: conflictNoPatch},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conflict, ok := compareFileChanges(tc.file, tc.other)
+			assert.Equal(t, tc.conflict, ok)
+			assert.Equal(t, tc.expected, conflict)
+		})
+	}
+}
+
+func Test_ListMergeConflicts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListMergeConflicts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_merge_conflicts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "wait_seconds")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pullRequest := func(mergeable bool, state string) *github.PullRequest {
+		return &github.PullRequest{
+			Number:         github.Ptr(42),
+			State:          github.Ptr("open"),
+			Mergeable:      github.Ptr(mergeable),
+			MergeableState: github.Ptr(state),
+			Base:           &github.PullRequestBranch{Ref: github.Ptr("main")},
+			Head:           &github.PullRequestBranch{SHA: github.Ptr("abc123")},
+		}
+	}
+	headComparison := &github.CommitsComparison{
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base000")},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("app.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -10,7 +10,7 @@\n a\n b\n c\n-d\n+D\n e\n f\n g")},
+			{Filename: github.Ptr("README.md"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -1,3 +1,3 @@\n a\n-b\n+B\n c")},
+			{Filename: github.Ptr("old.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -1 +1 @@\n-a\n+b")},
+			{Filename: github.Ptr("new.go"), Status: github.Ptr("added"), Patch: github.Ptr("@@ -0,0 +1 @@\n+a")},
+		},
+	}
+	baseComparison := &github.CommitsComparison{
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("app.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -11,7 +11,7 @@\n b\n c\n d\n-e\n+E\n f\n g\n h")},
+			{Filename: github.Ptr("README.md"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -40,0 +41,2 @@\n+x\n+y")},
+			{Filename: github.Ptr("old.go"), Status: github.Ptr("removed")},
+		},
+	}
+	compare := mock.WithRequestMatchHandler(
+		mock.GetReposCompareByOwnerByRepoByBasehead,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/owner/repo/compare/main...abc123":
+				mockResponse(t, http.StatusOK, headComparison)(w, r)
+			case "/repos/owner/repo/compare/base000...main":
+				mockResponse(t, http.StatusOK, baseComparison)(w, r)
+			default:
+				t.Errorf("unexpected comparison %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       mergeConflicts
+	}{
+		{
+			name: "lists the conflicting files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pullRequest(false, "dirty")),
+				compare,
+			),
+			expected: mergeConflicts{
+				PullNumber:     42,
+				Base:           "main",
+				HeadSHA:        "abc123",
+				MergeBaseSHA:   "base000",
+				Mergeable:      github.Ptr(false),
+				MergeableState: "dirty",
+				Conflicts: []mergeConflict{
+					{Path: "app.go", Reason: conflictOverlappingLines, HeadLines: []string{"13"}, BaseLines: []string{"14"}},
+					{Path: "old.go", Reason: conflictModifyDelete},
+				},
+			},
+		},
+		{
+			name: "mergeable pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pullRequest(true, "clean")),
+			),
+			expected: mergeConflicts{
+				PullNumber:     42,
+				Base:           "main",
+				HeadSHA:        "abc123",
+				Mergeable:      github.Ptr(true),
+				MergeableState: "clean",
+				Conflicts:      []mergeConflict{},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListMergeConflicts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned mergeConflicts
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+
+	t.Run("wait too long", func(t *testing.T) {
+		_, handler := ListMergeConflicts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"pullNumber":   float64(42),
+			"wait_seconds": float64(120),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "wait_seconds must be between 0 and 60")
+	})
+}
+
+func Test_DetectConflictRisk(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DetectConflictRisk(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "detect_conflict_risk", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "other_pull_number"})
+
+	pullRequests := mock.WithRequestMatchHandler(
+		mock.GetReposPullsByOwnerByRepoByPullNumber,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			base := "main"
+			if r.URL.Path == "/repos/owner/repo/pulls/43" {
+				base = "release"
+			}
+			mockResponse(t, http.StatusOK, &github.PullRequest{State: github.Ptr("open"), Base: &github.PullRequestBranch{Ref: github.Ptr(base)}})(w, r)
+		}),
+	)
+	files := map[string][]*github.CommitFile{
+		"/repos/owner/repo/pulls/42/files": {
+			{Filename: github.Ptr("app.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -5,3 +5,3 @@\n a\n-b\n+B\n c")},
+			{Filename: github.Ptr("docs/guide.md"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -1,2 +1,2 @@\n-a\n+A\n b")},
+			{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -1,2 +1,2 @@\n-a\n+A\n b")},
+		},
+		"/repos/owner/repo/pulls/43/files": {
+			{Filename: github.Ptr("app.go"), Status: github.Ptr("modified"), Patch: github.Ptr("@@ -5,3 +5,4 @@\n a\n-b\n+X\n+Y\n c")},
+			{Filename: github.Ptr("guide.md"), PreviousFilename: github.Ptr("docs/guide.md"), Status: github.Ptr("renamed"), Patch: github.Ptr("@@ -30,2 +30,2 @@\n x\n-y\n+Y")},
+			{Filename: github.Ptr("other.go"), Status: github.Ptr("added"), Patch: github.Ptr("@@ -0,0 +1 @@\n+a")},
+		},
+	}
+	listFiles := mock.WithRequestMatchHandler(
+		mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockResponse(t, http.StatusOK, files[r.URL.Path])(w, r)
+		}),
+	)
+
+	t.Run("finds overlapping files", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(pullRequests, listFiles))
+		_, handler := DetectConflictRisk(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":             "owner",
+			"repo":              "repo",
+			"pullNumber":        float64(42),
+			"other_pull_number": float64(43),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var returned conflictRisk
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+		assert.Equal(t, conflictRisk{
+			PullNumber:      42,
+			OtherPullNumber: 43,
+			Risk:            conflictRiskHigh,
+			OverlappingFiles: []overlappingFile{
+				{Path: "app.go", Risk: conflictRiskHigh, Reason: conflictOverlappingLines, Lines: []string{"6"}, OtherLines: []string{"6"}},
+				{Path: "docs/guide.md", Risk: conflictRiskLow},
+			},
+			Warnings: []string{"the pull requests target different branches, main and release, they only collide once the changes of one reach the branch of the other"},
+		}, returned)
+	})
+
+	t.Run("same pull request", func(t *testing.T) {
+		_, handler := DetectConflictRisk(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":             "owner",
+			"repo":              "repo",
+			"pullNumber":        float64(42),
+			"other_pull_number": float64(42),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "must be a different pull request")
+	})
+}
@@ -0,0 +1,310 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// mergeQueueEntryNode is an entry of a merge queue, as returned by the merge queue query and mutations.
+type mergeQueueEntryNode struct {
+	Position             githubv4.Int
+	State                githubv4.MergeQueueEntryState
+	EnqueuedAt           githubv4.DateTime
+	EstimatedTimeToMerge *githubv4.Int
+	Jump                 githubv4.Boolean
+	Solo                 githubv4.Boolean
+	Enqueuer             struct {
+		Login githubv4.String
+	}
+	PullRequest struct {
+		Number githubv4.Int
+		Title  githubv4.String
+		URL    githubv4.URI
+	}
+}
+
+// mergeQueueEntry is a pull request in a merge queue, with its position and state.
+type mergeQueueEntry struct {
+	PullNumber int    `json:"pull_number"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	// Position is 1 for the pull request merged next.
+	Position int `json:"position"`
+	// State is queued, awaiting_checks, mergeable, unmergeable or locked.
+	State      string `json:"state"`
+	EnqueuedAt string `json:"enqueued_at"`
+	EnqueuedBy string `json:"enqueued_by,omitempty"`
+	// EstimatedSecondsToMerge is the estimate of GitHub, left out when it has none.
+	EstimatedSecondsToMerge *int `json:"estimated_seconds_to_merge,omitempty"`
+	// Jump is set for pull requests added to the front of the queue.
+	Jump bool `json:"jump,omitempty"`
+	// Solo is set for pull requests merged on their own rather than grouped with others.
+	Solo bool `json:"solo,omitempty"`
+}
+
+func newMergeQueueEntry(node mergeQueueEntryNode) mergeQueueEntry {
+	entry := mergeQueueEntry{
+		PullNumber: int(node.PullRequest.Number),
+		Title:      string(node.PullRequest.Title),
+		URL:        node.PullRequest.URL.String(),
+		Position:   int(node.Position),
+		State:      strings.ToLower(string(node.State)),
+		EnqueuedAt: node.EnqueuedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		EnqueuedBy: string(node.Enqueuer.Login),
+		Jump:       bool(node.Jump),
+		Solo:       bool(node.Solo),
+	}
+	if node.EstimatedTimeToMerge != nil {
+		seconds := int(*node.EstimatedTimeToMerge)
+		entry.EstimatedSecondsToMerge = &seconds
+	}
+	return entry
+}
+
+// mergeQueueResult is the result of get_merge_queue.
+type mergeQueueResult struct {
+	URL     string            `json:"url"`
+	Total   int               `json:"total"`
+	Entries []mergeQueueEntry `json:"entries"`
+}
+
+// dequeuedPullRequest is the result of dequeue_pull_request.
+type dequeuedPullRequest struct {
+	PullNumber int    `json:"pull_number"`
+	URL        string `json:"url"`
+	// Position is the position the pull request had in the queue.
+	Position int `json:"position"`
+}
+
+// EnqueuePullRequest creates a tool to add a pull request to the merge queue of its base branch.
+func EnqueuePullRequest(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("enqueue_pull_request",
+			mcp.WithDescription(t("TOOL_ENQUEUE_PULL_REQUEST_DESCRIPTION", "Add a pull request to the merge queue of its base branch, which merges it once the checks of the queue pass. Use it instead of merge_pull_request when the base branch requires a merge queue. Returns the position and state of the pull request in the queue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ENQUEUE_PULL_REQUEST_USER_TITLE", "Add pull request to merge queue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithBoolean("jump",
+				mcp.Description("Add the pull request to the front of the queue, which needs admin permission"),
+			),
+			mcp.WithString("expected_sha",
+				mcp.Description("SHA the pull request head must match. The pull request is not queued if new commits were pushed since it was reviewed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseEnqueuePullRequestParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := pullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", err.Error())), nil
+			}
+
+			input := githubv4.EnqueuePullRequestInput{
+				PullRequestID: id,
+			}
+			if params.Jump {
+				input.Jump = githubv4.NewBoolean(true)
+			}
+			if params.ExpectedSHA != "" {
+				input.ExpectedHeadOid = githubv4.NewGitObjectID(githubv4.GitObjectID(params.ExpectedSHA))
+			}
+
+			var enqueue struct {
+				EnqueuePullRequest struct {
+					MergeQueueEntry mergeQueueEntryNode
+				} `graphql:"enqueuePullRequest(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &enqueue, input, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add pull request to the merge queue: %s", err.Error())), nil
+			}
+
+			r, err := json.Marshal(newMergeQueueEntry(enqueue.EnqueuePullRequest.MergeQueueEntry))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DequeuePullRequest creates a tool to remove a pull request from the merge queue.
+func DequeuePullRequest(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("dequeue_pull_request",
+			mcp.WithDescription(t("TOOL_DEQUEUE_PULL_REQUEST_DESCRIPTION", "Remove a pull request from the merge queue, so that it is not merged. The pull request stays open.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DEQUEUE_PULL_REQUEST_USER_TITLE", "Remove pull request from merge queue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDequeuePullRequestParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := pullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", err.Error())), nil
+			}
+
+			var dequeue struct {
+				DequeuePullRequest struct {
+					MergeQueueEntry struct {
+						Position    githubv4.Int
+						PullRequest struct {
+							Number githubv4.Int
+							URL    githubv4.URI
+						}
+					}
+				} `graphql:"dequeuePullRequest(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &dequeue, githubv4.DequeuePullRequestInput{
+				ID: id,
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove pull request from the merge queue: %s", err.Error())), nil
+			}
+
+			entry := dequeue.DequeuePullRequest.MergeQueueEntry
+			r, err := json.Marshal(dequeuedPullRequest{
+				PullNumber: int(entry.PullRequest.Number),
+				URL:        entry.PullRequest.URL.String(),
+				Position:   int(entry.Position),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetMergeQueue creates a tool to list the pull requests in the merge queue of a branch.
+func GetMergeQueue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_merge_queue",
+			mcp.WithDescription(t("TOOL_GET_MERGE_QUEUE_DESCRIPTION", "Get the pull requests in the merge queue of a branch, in the order they will be merged, with the state of each, such as awaiting_checks or unmergeable, and the estimated time to merge.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MERGE_QUEUE_USER_TITLE", "Get merge queue"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch of the merge queue, defaults to the default branch"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Number of entries to return, from the front of the queue (max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetMergeQueueParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.PerPage < 1 || params.PerPage > 100 {
+				return mcp.NewToolResultError("perPage must be between 1 and 100"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query struct {
+				Repository struct {
+					MergeQueue *struct {
+						URL     githubv4.URI
+						Entries struct {
+							TotalCount githubv4.Int
+							Nodes      []mergeQueueEntryNode
+						} `graphql:"entries(first: $first)"`
+					} `graphql:"mergeQueue(branch: $branch)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			var branch *githubv4.String
+			if params.Branch != "" {
+				branch = githubv4.NewString(githubv4.String(params.Branch))
+			}
+			if err := client.Query(ctx, &query, map[string]any{
+				"owner":  githubv4.String(params.Owner),
+				"repo":   githubv4.String(params.Repo),
+				"branch": branch,
+				"first":  githubv4.Int(int32(params.PerPage)), // #nosec G115 - perPage is at most 100
+			}); err != nil {
+				return nil, fmt.Errorf("failed to get merge queue: %w", err)
+			}
+
+			queue := query.Repository.MergeQueue
+			if queue == nil {
+				name := params.Branch
+				if name == "" {
+					name = "the default branch"
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("%s of %s/%s has no merge queue", name, params.Owner, params.Repo)), nil
+			}
+
+			result := mergeQueueResult{
+				URL:     queue.URL.String(),
+				Total:   int(queue.Entries.TotalCount),
+				Entries: make([]mergeQueueEntry, 0, len(queue.Entries.Nodes)),
+			}
+			for _, node := range queue.Entries.Nodes {
+				result.Entries = append(result.Entries, newMergeQueueEntry(node))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,293 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeQueueEntryResponse(position int, state string) map[string]any {
+	return map[string]any{
+		"position":             position,
+		"state":                state,
+		"enqueuedAt":           "2024-05-01T10:00:00Z",
+		"estimatedTimeToMerge": 600,
+		"jump":                 false,
+		"solo":                 false,
+		"enqueuer":             map[string]any{"login": "octocat"},
+		"pullRequest": map[string]any{
+			"number": 42,
+			"title":  "Add feature",
+			"url":    "https://github.com/owner/repo/pull/42",
+		},
+	}
+}
+
+func Test_EnqueuePullRequest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := EnqueuePullRequest(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "enqueue_pull_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "jump")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	enqueueMutation := struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry mergeQueueEntryNode
+		} `graphql:"enqueuePullRequest(input: $input)"`
+	}{}
+	seconds := 600
+
+	tests := []struct {
+		name           string
+		input          githubv4.EnqueuePullRequestInput
+		response       githubv4mock.GQLResponse
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "enqueue at the front",
+			input: githubv4.EnqueuePullRequestInput{
+				PullRequestID:   githubv4.ID("PR_42"),
+				Jump:            githubv4.NewBoolean(true),
+				ExpectedHeadOid: githubv4.NewGitObjectID("abc123"),
+			},
+			response: githubv4mock.DataResponse(map[string]any{
+				"enqueuePullRequest": map[string]any{"mergeQueueEntry": mergeQueueEntryResponse(1, "AWAITING_CHECKS")},
+			}),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"jump":         true,
+				"expected_sha": "abc123",
+			},
+		},
+		{
+			name:           "branch without merge queue",
+			input:          githubv4.EnqueuePullRequestInput{PullRequestID: githubv4.ID("PR_42")},
+			response:       githubv4mock.ErrorResponse("Merge queue is not enabled for this branch"),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectError:    true,
+			expectedErrMsg: "failed to add pull request to the merge queue: Merge queue is not enabled",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				pullRequestNodeIDQuery(),
+				githubv4mock.NewMutationMatcher(enqueueMutation, tc.input, nil, tc.response),
+			))
+			_, handler := EnqueuePullRequest(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned mergeQueueEntry
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, mergeQueueEntry{
+				PullNumber:              42,
+				Title:                   "Add feature",
+				URL:                     "https://github.com/owner/repo/pull/42",
+				Position:                1,
+				State:                   "awaiting_checks",
+				EnqueuedAt:              "2024-05-01T10:00:00Z",
+				EnqueuedBy:              "octocat",
+				EstimatedSecondsToMerge: &seconds,
+			}, returned)
+		})
+	}
+}
+
+func Test_DequeuePullRequest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := DequeuePullRequest(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "dequeue_pull_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		pullRequestNodeIDQuery(),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DequeuePullRequest struct {
+					MergeQueueEntry struct {
+						Position    githubv4.Int
+						PullRequest struct {
+							Number githubv4.Int
+							URL    githubv4.URI
+						}
+					}
+				} `graphql:"dequeuePullRequest(input: $input)"`
+			}{},
+			githubv4.DequeuePullRequestInput{ID: githubv4.ID("PR_42")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"dequeuePullRequest": map[string]any{
+					"mergeQueueEntry": map[string]any{
+						"position":    3,
+						"pullRequest": map[string]any{"number": 42, "url": "https://github.com/owner/repo/pull/42"},
+					},
+				},
+			}),
+		),
+	))
+	_, handler := DequeuePullRequest(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var returned dequeuedPullRequest
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, dequeuedPullRequest{PullNumber: 42, URL: "https://github.com/owner/repo/pull/42", Position: 3}, returned)
+}
+
+func Test_GetMergeQueue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetMergeQueue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_merge_queue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	var query struct {
+		Repository struct {
+			MergeQueue *struct {
+				URL     githubv4.URI
+				Entries struct {
+					TotalCount githubv4.Int
+					Nodes      []mergeQueueEntryNode
+				} `graphql:"entries(first: $first)"`
+			} `graphql:"mergeQueue(branch: $branch)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	first := mergeQueueEntryResponse(1, "MERGEABLE")
+	second := mergeQueueEntryResponse(2, "QUEUED")
+	second["pullRequest"] = map[string]any{"number": 43, "title": "Fix bug", "url": "https://github.com/owner/repo/pull/43"}
+	second["estimatedTimeToMerge"] = nil
+	second["solo"] = true
+	seconds := 600
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		variables      map[string]any
+		response       githubv4mock.GQLResponse
+		expectError    bool
+		expectedErrMsg string
+		expected       mergeQueueResult
+	}{
+		{
+			name:        "lists the entries of the queue",
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "branch": "main", "perPage": float64(2)},
+			variables: map[string]any{
+				"owner":  githubv4.String("owner"),
+				"repo":   githubv4.String("repo"),
+				"branch": githubv4.NewString("main"),
+				"first":  githubv4.Int(2),
+			},
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"mergeQueue": map[string]any{
+						"url": "https://github.com/owner/repo/queue/main",
+						"entries": map[string]any{
+							"totalCount": 5,
+							"nodes":      []any{first, second},
+						},
+					},
+				},
+			}),
+			expected: mergeQueueResult{
+				URL:   "https://github.com/owner/repo/queue/main",
+				Total: 5,
+				Entries: []mergeQueueEntry{
+					{
+						PullNumber:              42,
+						Title:                   "Add feature",
+						URL:                     "https://github.com/owner/repo/pull/42",
+						Position:                1,
+						State:                   "mergeable",
+						EnqueuedAt:              "2024-05-01T10:00:00Z",
+						EnqueuedBy:              "octocat",
+						EstimatedSecondsToMerge: &seconds,
+					},
+					{
+						PullNumber: 43,
+						Title:      "Fix bug",
+						URL:        "https://github.com/owner/repo/pull/43",
+						Position:   2,
+						State:      "queued",
+						EnqueuedAt: "2024-05-01T10:00:00Z",
+						EnqueuedBy: "octocat",
+						Solo:       true,
+					},
+				},
+			},
+		},
+		{
+			name:        "default branch without merge queue",
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo"},
+			variables: map[string]any{
+				"owner":  githubv4.String("owner"),
+				"repo":   githubv4.String("repo"),
+				"branch": (*githubv4.String)(nil),
+				"first":  githubv4.Int(30),
+			},
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"mergeQueue": nil},
+			}),
+			expectError:    true,
+			expectedErrMsg: "the default branch of owner/repo has no merge queue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(query, tc.variables, tc.response),
+			))
+			_, handler := GetMergeQueue(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned mergeQueueResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// ToolMiddleware wraps a tool, usually by wrapping its handler. Unlike server.ToolHandlerMiddleware it can see the
+// tool definition, so it can depend on the tool name, annotations or input schema.
+type ToolMiddleware func(server.ServerTool) server.ServerTool
+
+// Chain combines middleware into one. The first middleware is the outermost, so it sees a call first and its
+// result last.
+func Chain(middleware ...ToolMiddleware) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			st = middleware[i](st)
+		}
+		return st
+	}
+}
+
+// WriteOnly applies middleware to write tools only, passing tools annotated as read-only through unchanged.
+func WriteOnly(mw ToolMiddleware) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		if hint := st.Tool.Annotations.ReadOnlyHint; hint != nil && *hint {
+			return st
+		}
+		return mw(st)
+	}
+}
+
+// WithRecovery turns a panic in a tool handler into an error, so that a bug in one tool does not take down the
+// server.
+func WithRecovery(logger *logrus.Logger) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.WithField("tool", st.Tool.Name).Errorf("panic in tool handler: %v\n%s", r, debug.Stack())
+					result, err = nil, fmt.Errorf("internal error in tool %s", st.Tool.Name)
+				}
+			}()
+			return next(ctx, request)
+		}
+		return st
+	}
+}
+
+// WithLogging logs each tool call at debug level, with its duration, outcome and the GitHub request IDs of the API
+// calls it made when the clients use RequestIDTransport, so that calls can be matched with the audit log.
+func WithLogging(logger *logrus.Logger) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			ctx, ids := withRequestIDs(ctx)
+			result, err := next(ctx, request)
+
+			entry := logger.WithFields(logrus.Fields{
+				"tool":     st.Tool.Name,
+				"duration": time.Since(start).String(),
+			})
+			if requestIDs := ids.list(); len(requestIDs) > 0 {
+				entry = entry.WithField("github_request_ids", requestIDs)
+			}
+			switch {
+			case err != nil:
+				entry.WithError(err).Debug("tool call failed")
+			case result != nil && result.IsError:
+				entry.Debug("tool call returned an error result")
+			default:
+				entry.Debug("tool call succeeded")
+			}
+			return result, err
+		}
+		return st
+	}
+}
+
+// ValidateArguments checks calls against the tool's input schema before the handler runs, rejecting missing
+// required parameters and parameters of the wrong type with the same errors the handlers would return.
+func ValidateArguments(st server.ServerTool) server.ServerTool {
+	schema := st.Tool.InputSchema
+	next := st.Handler
+	st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		for _, name := range schema.Required {
+			if args[name] == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("missing required parameter: %s", name)), nil
+			}
+		}
+		for name, value := range args {
+			if value == nil {
+				continue
+			}
+			property, ok := schema.Properties[name].(map[string]any)
+			if !ok {
+				continue
+			}
+			expected, _ := property["type"].(string)
+			if expected != "" && !hasSchemaType(value, expected) {
+				return mcp.NewToolResultError(fmt.Sprintf("parameter %s is not of type %s", name, expected)), nil
+			}
+		}
+		return next(ctx, request)
+	}
+	return st
+}
+
+// hasSchemaType reports whether a decoded JSON value matches a JSON Schema type.
+func hasSchemaType(value any, schemaType string) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number", "integer":
+		switch value.(type) {
+		case float64, float32, int, int64, int32, json.Number:
+			return true
+		}
+		return false
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		switch value.(type) {
+		case []any, []string:
+			return true
+		}
+		return false
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	default:
+		return true
+	}
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func discardLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func Test_Chain(t *testing.T) {
+	var calls []string
+	label := func(name string) ToolMiddleware {
+		return func(st server.ServerTool) server.ServerTool {
+			next := st.Handler
+			st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				calls = append(calls, name)
+				return next(ctx, request)
+			}
+			return st
+		}
+	}
+
+	st := Chain(label("outer"), WriteOnly(label("write")), label("inner"))(customTool("tool", false))
+	_, err := st.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "write", "inner"}, calls)
+
+	// Write-only middleware is skipped for read tools
+	calls = nil
+	st = Chain(label("outer"), WriteOnly(label("write")), label("inner"))(customTool("tool", true))
+	_, err = st.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+}
+
+func Test_WithRecovery(t *testing.T) {
+	st := WithRecovery(discardLogger())(server.ServerTool{
+		Tool: mcp.NewTool("broken"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			panic("boom")
+		},
+	})
+
+	result, err := st.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.EqualError(t, err, "internal error in tool broken")
+	assert.Nil(t, result)
+}
+
+// roundTripFunc is an HTTP transport answering requests with a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_WithLoggingRequestIDs(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	n := 0
+	client := &http.Client{Transport: RequestIDTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n++
+		header := http.Header{}
+		header.Set(requestIDHeader, strings.Repeat(string(rune('A'+n-1)), 4))
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+	}))}
+
+	st := WithLogging(logger)(server.ServerTool{
+		Tool: mcp.NewTool("get_me"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			for i := 0; i < 2; i++ {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+				require.NoError(t, err)
+				resp, err := client.Do(req)
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+			}
+			return mcp.NewToolResultText("{}"), nil
+		},
+	})
+	_, err := st.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+
+	require.Len(t, hook.Entries, 1)
+	assert.Equal(t, "get_me", hook.LastEntry().Data["tool"])
+	assert.Equal(t, []string{"AAAA", "BBBB"}, hook.LastEntry().Data["github_request_ids"])
+
+	// Requests made outside of a tool call are not attributed to any
+	resp, err := client.Get("https://api.github.com/user")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	_, err = st.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DDDD", "EEEE"}, hook.LastEntry().Data["github_request_ids"])
+}
+
+func Test_ValidateArguments(t *testing.T) {
+	var called bool
+	st := ValidateArguments(server.ServerTool{
+		Tool: mcp.NewTool("validated",
+			mcp.WithString("owner", mcp.Required()),
+			mcp.WithNumber("perPage"),
+			mcp.WithBoolean("draft"),
+			mcp.WithArray("labels", mcp.Items(map[string]any{"type": "string"})),
+		),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			called = true
+			return mcp.NewToolResultText("ok"), nil
+		},
+	})
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		expectedError string
+	}{
+		{
+			name: "valid arguments",
+			args: map[string]any{"owner": "octo", "perPage": float64(10), "draft": true, "labels": []any{"bug"}},
+		},
+		{
+			name: "null optional argument",
+			args: map[string]any{"owner": "octo", "perPage": nil},
+		},
+		{
+			name:          "missing required argument",
+			args:          map[string]any{"perPage": float64(10)},
+			expectedError: "missing required parameter: owner",
+		},
+		{
+			name:          "wrong type",
+			args:          map[string]any{"owner": "octo", "perPage": "10"},
+			expectedError: "parameter perPage is not of type number",
+		},
+		{
+			name:          "wrong array type",
+			args:          map[string]any{"owner": "octo", "labels": "bug"},
+			expectedError: "parameter labels is not of type array",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called = false
+			result, err := st.Handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectedError != "" {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedError, getTextResult(t, result).Text)
+				assert.False(t, called)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.True(t, called)
+		})
+	}
+}
@@ -53,56 +53,32 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			filter, err := OptionalParam[string](request, "filter")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			since, err := OptionalParam[string](request, "since")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			before, err := OptionalParam[string](request, "before")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			owner, err := OptionalParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := OptionalParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			paginationParams, err := OptionalPaginationParams(request)
+			params, err := parseListNotificationsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			// Build options
 			opts := &github.NotificationListOptions{
-				All:           filter == FilterIncludeRead,
-				Participating: filter == FilterOnlyParticipating,
+				All:           params.Filter == FilterIncludeRead,
+				Participating: params.Filter == FilterOnlyParticipating,
 				ListOptions: github.ListOptions{
-					Page:    paginationParams.page,
-					PerPage: paginationParams.perPage,
+					Page:    params.Page,
+					PerPage: params.PerPage,
 				},
 			}
 
 			// Parse time parameters if provided
-			if since != "" {
-				sinceTime, err := time.Parse(time.RFC3339, since)
+			if params.Since != "" {
+				sinceTime, err := time.Parse(time.RFC3339, params.Since)
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("invalid since time format, should be RFC3339/ISO8601: %v", err)), nil
 				}
 				opts.Since = sinceTime
 			}
 
-			if before != "" {
-				beforeTime, err := time.Parse(time.RFC3339, before)
+			if params.Before != "" {
+				beforeTime, err := time.Parse(time.RFC3339, params.Before)
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("invalid before time format, should be RFC3339/ISO8601: %v", err)), nil
 				}
@@ -112,8 +88,8 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 			var notifications []*github.Notification
 			var resp *github.Response
 
-			if owner != "" && repo != "" {
-				notifications, resp, err = client.Activity.ListRepositoryNotifications(ctx, owner, repo, opts)
+			if params.Owner != "" && params.Repo != "" {
+				notifications, resp, err = client.Activity.ListRepositoryNotifications(ctx, params.Owner, params.Repo, opts)
 			} else {
 				notifications, resp, err = client.Activity.ListNotifications(ctx, opts)
 			}
@@ -160,34 +136,33 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			threadID, err := requiredParam[string](request, "threadID")
+			params, err := parseDismissNotificationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			state, err := requiredParam[string](request, "state")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			// state is left optional in the schema for compatibility, but must be given
+			if params.State == "" {
+				return mcp.NewToolResultError("missing required parameter: state"), nil
 			}
 
 			var resp *github.Response
-			switch state {
+			switch params.State {
 			case "done":
 				// for some inexplicable reason, the API seems to have threadID as int64 and string depending on the endpoint
 				var threadIDInt int64
-				threadIDInt, err = strconv.ParseInt(threadID, 10, 64)
+				threadIDInt, err = strconv.ParseInt(params.ThreadID, 10, 64)
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("invalid threadID format: %v", err)), nil
 				}
 				resp, err = client.Activity.MarkThreadDone(ctx, threadIDInt)
 			case "read":
-				resp, err = client.Activity.MarkThreadRead(ctx, threadID)
+				resp, err = client.Activity.MarkThreadRead(ctx, params.ThreadID)
 			default:
 				return mcp.NewToolResultError("Invalid state. Must be one of: read, done."), nil
 			}
 
 			if err != nil {
-				return nil, fmt.Errorf("failed to mark notification as %s: %w", state, err)
+				return nil, fmt.Errorf("failed to mark notification as %s: %w", params.State, err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -196,10 +171,10 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to mark notification as %s: %s", state, string(body))), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to mark notification as %s: %s", params.State, string(body))), nil
 			}
 
-			return mcp.NewToolResultText(fmt.Sprintf("Notification marked as %s", state)), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Notification marked as %s", params.State)), nil
 		}
 }
 
@@ -227,23 +202,14 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			lastReadAt, err := OptionalParam[string](request, "lastReadAt")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			owner, err := OptionalParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := OptionalParam[string](request, "repo")
+			params, err := parseMarkAllNotificationsReadParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			var lastReadTime time.Time
-			if lastReadAt != "" {
-				lastReadTime, err = time.Parse(time.RFC3339, lastReadAt)
+			if params.LastReadAt != "" {
+				lastReadTime, err = time.Parse(time.RFC3339, params.LastReadAt)
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("invalid lastReadAt time format, should be RFC3339/ISO8601: %v", err)), nil
 				}
@@ -256,8 +222,8 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 			}
 
 			var resp *github.Response
-			if owner != "" && repo != "" {
-				resp, err = client.Activity.MarkRepositoryNotificationsRead(ctx, owner, repo, markReadOptions)
+			if params.Owner != "" && params.Repo != "" {
+				resp, err = client.Activity.MarkRepositoryNotificationsRead(ctx, params.Owner, params.Repo, markReadOptions)
 			} else {
 				resp, err = client.Activity.MarkNotificationsRead(ctx, markReadOptions)
 			}
@@ -297,12 +263,12 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			notificationID, err := requiredParam[string](request, "notificationID")
+			params, err := parseGetNotificationDetailsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			thread, resp, err := client.Activity.GetThread(ctx, notificationID)
+			thread, resp, err := client.Activity.GetThread(ctx, params.NotificationID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get notification details: %w", err)
 			}
@@ -356,11 +322,7 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			notificationID, err := requiredParam[string](request, "notificationID")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			action, err := requiredParam[string](request, "action")
+			params, err := parseManageNotificationSubscriptionParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -371,30 +333,30 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 				apiErr error
 			)
 
-			switch action {
+			switch params.Action {
 			case NotificationActionIgnore:
 				sub := &github.Subscription{Ignored: toBoolPtr(true)}
-				result, resp, apiErr = client.Activity.SetThreadSubscription(ctx, notificationID, sub)
+				result, resp, apiErr = client.Activity.SetThreadSubscription(ctx, params.NotificationID, sub)
 			case NotificationActionWatch:
 				sub := &github.Subscription{Ignored: toBoolPtr(false), Subscribed: toBoolPtr(true)}
-				result, resp, apiErr = client.Activity.SetThreadSubscription(ctx, notificationID, sub)
+				result, resp, apiErr = client.Activity.SetThreadSubscription(ctx, params.NotificationID, sub)
 			case NotificationActionDelete:
-				resp, apiErr = client.Activity.DeleteThreadSubscription(ctx, notificationID)
+				resp, apiErr = client.Activity.DeleteThreadSubscription(ctx, params.NotificationID)
 			default:
 				return mcp.NewToolResultError("Invalid action. Must be one of: ignore, watch, delete."), nil
 			}
 
 			if apiErr != nil {
-				return nil, fmt.Errorf("failed to %s notification subscription: %w", action, apiErr)
+				return nil, fmt.Errorf("failed to %s notification subscription: %w", params.Action, apiErr)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 				body, _ := io.ReadAll(resp.Body)
-				return mcp.NewToolResultError(fmt.Sprintf("failed to %s notification subscription: %s", action, string(body))), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to %s notification subscription: %s", params.Action, string(body))), nil
 			}
 
-			if action == NotificationActionDelete {
+			if params.Action == NotificationActionDelete {
 				// Special case for delete as there is no response body
 				return mcp.NewToolResultText("Notification subscription deleted"), nil
 			}
@@ -441,15 +403,7 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			action, err := requiredParam[string](request, "action")
+			params, err := parseManageRepositoryNotificationSubscriptionParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -460,21 +414,21 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 				apiErr error
 			)
 
-			switch action {
+			switch params.Action {
 			case RepositorySubscriptionActionIgnore:
 				sub := &github.Subscription{Ignored: toBoolPtr(true)}
-				result, resp, apiErr = client.Activity.SetRepositorySubscription(ctx, owner, repo, sub)
+				result, resp, apiErr = client.Activity.SetRepositorySubscription(ctx, params.Owner, params.Repo, sub)
 			case RepositorySubscriptionActionWatch:
 				sub := &github.Subscription{Ignored: toBoolPtr(false), Subscribed: toBoolPtr(true)}
-				result, resp, apiErr = client.Activity.SetRepositorySubscription(ctx, owner, repo, sub)
+				result, resp, apiErr = client.Activity.SetRepositorySubscription(ctx, params.Owner, params.Repo, sub)
 			case RepositorySubscriptionActionDelete:
-				resp, apiErr = client.Activity.DeleteRepositorySubscription(ctx, owner, repo)
+				resp, apiErr = client.Activity.DeleteRepositorySubscription(ctx, params.Owner, params.Repo)
 			default:
 				return mcp.NewToolResultError("Invalid action. Must be one of: ignore, watch, delete."), nil
 			}
 
 			if apiErr != nil {
-				return nil, fmt.Errorf("failed to %s repository subscription: %w", action, apiErr)
+				return nil, fmt.Errorf("failed to %s repository subscription: %w", params.Action, apiErr)
 			}
 			if resp != nil {
 				defer func() { _ = resp.Body.Close() }()
@@ -483,10 +437,10 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 			// Handle non-2xx status codes
 			if resp != nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
 				body, _ := io.ReadAll(resp.Body)
-				return mcp.NewToolResultError(fmt.Sprintf("failed to %s repository subscription: %s", action, string(body))), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to %s repository subscription: %s", params.Action, string(body))), nil
 			}
 
-			if action == RepositorySubscriptionActionDelete {
+			if params.Action == RepositorySubscriptionActionDelete {
 				// Special case for delete as there is no response body
 				return mcp.NewToolResultText("Repository subscription deleted"), nil
 			}
@@ -0,0 +1,271 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxSearchQueryLength is the longest query the code search API accepts.
+	maxSearchQueryLength = 256
+	// maxSearchResultsPerQuery is how many results the search API returns for a query at most, whatever its total.
+	maxSearchResultsPerQuery = 1000
+	// orgCodeSearchConcurrency bounds the shards search_code_in_org searches at once, code search having a low
+	// secondary rate limit.
+	orgCodeSearchConcurrency = 3
+	// maxOrgCodeSearchRepositories bounds the repositories search_code_in_org shards a query across.
+	maxOrgCodeSearchRepositories = 1000
+	// defaultOrgCodeSearchResults is the default number of results search_code_in_org returns.
+	defaultOrgCodeSearchResults = 100
+)
+
+// scopeQualifierPattern matches the search qualifiers that set the repositories searched, which
+// search_code_in_org sets itself for each shard.
+var scopeQualifierPattern = regexp.MustCompile(`(?i)(^|\s)-?(repo|org|user):`)
+
+// orgCodeMatch is a file matching the query of search_code_in_org.
+type orgCodeMatch struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	SHA        string `json:"sha"`
+	HTMLURL    string `json:"html_url"`
+}
+
+// orgCodeSearchResult is the result of search_code_in_org.
+type orgCodeSearchResult struct {
+	Org          string `json:"org"`
+	Query        string `json:"query"`
+	Repositories int    `json:"repositories"`
+	Shards       int    `json:"shards"`
+	// TotalCount is the sum of the total counts reported for each shard.
+	TotalCount int            `json:"total_count"`
+	Items      []orgCodeMatch `json:"items"`
+	// Truncated is set when more files match than were returned.
+	Truncated bool     `json:"truncated,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// shardCodeSearchQuery splits a query across repositories, adding as many repo: qualifiers to each shard as fit in
+// the maximum query length. A repository whose qualifier alone does not fit gets a shard of its own.
+func shardCodeSearchQuery(query string, repositories []string) []string {
+	var shards []string
+	shard := query
+	for _, repo := range repositories {
+		qualifier := " repo:" + repo
+		if shard != query && len(shard)+len(qualifier) > maxSearchQueryLength {
+			shards = append(shards, shard)
+			shard = query
+		}
+		shard += qualifier
+	}
+	if shard != query {
+		shards = append(shards, shard)
+	}
+	return shards
+}
+
+// codeSearchShard is the outcome of searching one shard.
+type codeSearchShard struct {
+	total int
+	items []orgCodeMatch
+	err   error
+}
+
+// searchCodeShard reads the results of a shard until limit results are read or the results run out.
+func searchCodeShard(ctx context.Context, client *github.Client, query string, limit int) codeSearchShard {
+	var shard codeSearchShard
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		result, resp, err := client.Search.Code(ctx, query, opts)
+		if err != nil {
+			shard.err = err
+			return shard
+		}
+		_ = resp.Body.Close()
+		shard.total = result.GetTotal()
+		for _, item := range result.CodeResults {
+			shard.items = append(shard.items, orgCodeMatch{
+				Repository: item.GetRepository().GetFullName(),
+				Path:       item.GetPath(),
+				SHA:        item.GetSHA(),
+				HTMLURL:    item.GetHTMLURL(),
+			})
+		}
+		if resp.NextPage == 0 || len(shard.items) >= limit {
+			return shard
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// isRateLimitError reports whether err is GitHub refusing a request because of its primary or secondary rate limit.
+func isRateLimitError(err error) bool {
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	return errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr)
+}
+
+// SearchCodeInOrg creates a tool to search code across the repositories of an organization, beyond the result cap
+// of a single search.
+func SearchCodeInOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_code_in_org",
+			mcp.WithDescription(t("TOOL_SEARCH_CODE_IN_ORG_DESCRIPTION", fmt.Sprintf("Search code across the repositories of an organization. The query is split in shards of repositories searched concurrently, each returning up to %d results, so that broad queries are not capped at %d results overall; the results are merged without duplicates. Shards not searched because of the rate limit are reported in the warnings.", maxSearchResultsPerQuery, maxSearchResultsPerQuery))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_CODE_IN_ORG_USER_TITLE", "Search code in organization"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization to search"),
+			),
+			mcp.WithString("q",
+				mcp.Required(),
+				mcp.Description("Search query using GitHub code search syntax, without repo:, org: or user: qualifiers"),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Search the archived repositories too"),
+			),
+			mcp.WithBoolean("include_forks",
+				mcp.Description("Search the forks too"),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description(fmt.Sprintf("Maximum number of results to return, defaults to %d, at most %d", defaultOrgCodeSearchResults, maxSearchResultsPerQuery*10)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseSearchCodeInOrgParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if scopeQualifierPattern.MatchString(params.Q) {
+				return mcp.NewToolResultError("q must not contain repo:, org: or user: qualifiers, the repositories of org are added to each shard"), nil
+			}
+			if params.MaxResults <= 0 {
+				params.MaxResults = defaultOrgCodeSearchResults
+			}
+			if params.MaxResults > maxSearchResultsPerQuery*10 {
+				return mcp.NewToolResultError(fmt.Sprintf("max_results must be at most %d", maxSearchResultsPerQuery*10)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := orgCodeSearchResult{Org: params.Org, Query: params.Q, Items: []orgCodeMatch{}}
+			var repositories []string
+			opts := &github.RepositoryListByOrgOptions{Type: "all", ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				repos, resp, err := client.Repositories.ListByOrg(ctx, params.Org, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list repositories: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, repo := range repos {
+					if (repo.GetArchived() && !params.IncludeArchived) || (repo.GetFork() && !params.IncludeForks) {
+						continue
+					}
+					repositories = append(repositories, repo.GetFullName())
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(repositories) >= maxOrgCodeSearchRepositories {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("only the first %d repositories were searched", maxOrgCodeSearchRepositories))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if len(repositories) > maxOrgCodeSearchRepositories {
+				repositories = repositories[:maxOrgCodeSearchRepositories]
+			}
+			result.Repositories = len(repositories)
+
+			queries := shardCodeSearchQuery(params.Q, repositories)
+			result.Shards = len(queries)
+			shards := make([]codeSearchShard, len(queries))
+
+			// Once a shard hits the rate limit, the shards not started yet are skipped
+			searchCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			progress := newProgressReporter(ctx, request, len(queries))
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, orgCodeSearchConcurrency)
+			for i, query := range queries {
+				sem <- struct{}{}
+				if searchCtx.Err() != nil {
+					<-sem
+					shards[i].err = searchCtx.Err()
+					continue
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+					shards[i] = searchCodeShard(searchCtx, client, query, params.MaxResults)
+					if isRateLimitError(shards[i].err) {
+						cancel()
+					}
+					progress.step(ctx, 1, fmt.Sprintf("searched shard %d of %d", i+1, len(queries)))
+				}()
+			}
+			wg.Wait()
+			// The shards skipped because the call itself was cancelled are not rate limited
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			seen := map[orgCodeMatch]bool{}
+			rateLimited := 0
+			for i, shard := range shards {
+				switch {
+				case shard.err == nil:
+				case isRateLimitError(shard.err) || errors.Is(shard.err, context.Canceled):
+					rateLimited++
+				default:
+					result.Warnings = append(result.Warnings, fmt.Sprintf("shard %d failed: %v", i+1, shard.err))
+				}
+				result.TotalCount += shard.total
+				if shard.total > len(shard.items) {
+					result.Truncated = true
+				}
+				for _, item := range shard.items {
+					if !seen[item] {
+						seen[item] = true
+						result.Items = append(result.Items, item)
+					}
+				}
+			}
+			if rateLimited > 0 {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%d of %d shards were not searched because of the rate limit, retry later", rateLimited, len(shards)))
+			}
+
+			sort.Slice(result.Items, func(i, j int) bool {
+				if result.Items[i].Repository != result.Items[j].Repository {
+					return strings.ToLower(result.Items[i].Repository) < strings.ToLower(result.Items[j].Repository)
+				}
+				return result.Items[i].Path < result.Items[j].Path
+			})
+			if len(result.Items) > params.MaxResults {
+				result.Items = result.Items[:params.MaxResults]
+				result.Truncated = true
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShardCodeSearchQuery(t *testing.T) {
+	assert.Empty(t, shardCodeSearchQuery("TODO", nil))
+	assert.Equal(t, []string{"TODO repo:o/a repo:o/b"}, shardCodeSearchQuery("TODO", []string{"o/a", "o/b"}))
+
+	long := strings.Repeat("x", 120)
+	shards := shardCodeSearchQuery("TODO", []string{"o/" + long, "o/" + long, "o/a", "o/" + strings.Repeat("y", 300)})
+	require.Len(t, shards, 3)
+	assert.Equal(t, "TODO repo:o/"+long, shards[0])
+	assert.Equal(t, "TODO repo:o/"+long+" repo:o/a", shards[1])
+	assert.Equal(t, "TODO repo:o/"+strings.Repeat("y", 300), shards[2])
+	for _, shard := range shards[:2] {
+		assert.LessOrEqual(t, len(shard), maxSearchQueryLength)
+	}
+}
+
+func Test_SearchCodeInOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchCodeInOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "search_code_in_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "q")
+	assert.Contains(t, tool.InputSchema.Properties, "include_archived")
+	assert.Contains(t, tool.InputSchema.Properties, "include_forks")
+	assert.Contains(t, tool.InputSchema.Properties, "max_results")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "q"})
+
+	// Names long enough for the repositories to be split in several shards
+	var repos []*github.Repository
+	for i := 0; i < 12; i++ {
+		repos = append(repos, &github.Repository{FullName: github.Ptr(fmt.Sprintf("octo/service-with-a-rather-long-name-%02d", i))})
+	}
+	repos = append(repos,
+		&github.Repository{FullName: github.Ptr("octo/archived"), Archived: github.Ptr(true)},
+		&github.Repository{FullName: github.Ptr("octo/fork"), Fork: github.Ptr(true)},
+	)
+
+	// searchCode returns a match in each repository of the query, and the same match of the first one twice
+	searchCode := func(rateLimited string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query().Get("q")
+			assert.LessOrEqual(t, len(query), maxSearchQueryLength)
+			if rateLimited != "" && strings.Contains(query, rateLimited) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+				return
+			}
+			result := &github.CodeSearchResult{}
+			for _, field := range strings.Fields(query) {
+				if repo, ok := strings.CutPrefix(field, "repo:"); ok {
+					result.CodeResults = append(result.CodeResults, &github.CodeResult{
+						Path:       github.Ptr("main.go"),
+						SHA:        github.Ptr("abc"),
+						Repository: &github.Repository{FullName: github.Ptr(repo)},
+					})
+				}
+			}
+			result.CodeResults = append(result.CodeResults, result.CodeResults[0])
+			result.Total = github.Ptr(len(result.CodeResults))
+			mockResponse(t, http.StatusOK, result)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedItems  int
+		expectedShards int
+		truncated      bool
+		warning        string
+	}{
+		{
+			name: "merges the results of every shard",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, repos),
+				mock.WithRequestMatchHandler(mock.GetSearchCode, searchCode("")),
+			),
+			requestArgs:    map[string]any{"org": "octo", "q": "TODO language:go"},
+			expectedItems:  12,
+			expectedShards: 3,
+		},
+		{
+			name: "includes archived repositories and forks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, repos),
+				mock.WithRequestMatchHandler(mock.GetSearchCode, searchCode("")),
+			),
+			requestArgs:    map[string]any{"org": "octo", "q": "TODO", "include_archived": true, "include_forks": true},
+			expectedItems:  14,
+			expectedShards: 3,
+		},
+		{
+			name: "caps the results",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, repos),
+				mock.WithRequestMatchHandler(mock.GetSearchCode, searchCode("")),
+			),
+			requestArgs:    map[string]any{"org": "octo", "q": "TODO", "max_results": float64(5)},
+			expectedItems:  5,
+			expectedShards: 3,
+			truncated:      true,
+		},
+		{
+			name: "reports the shards not searched because of the rate limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, repos),
+				mock.WithRequestMatchHandler(mock.GetSearchCode, searchCode("name-00")),
+			),
+			requestArgs:    map[string]any{"org": "octo", "q": "TODO"},
+			expectedShards: 3,
+			warning:        "because of the rate limit",
+		},
+		{
+			name:           "rejects scope qualifiers",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"org": "octo", "q": "TODO repo:octo/api"},
+			expectError:    true,
+			expectedErrMsg: "q must not contain repo:, org: or user: qualifiers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchCodeInOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned orgCodeSearchResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedShards, returned.Shards)
+			assert.Equal(t, tc.truncated, returned.Truncated)
+			if tc.warning != "" {
+				require.NotEmpty(t, returned.Warnings)
+				assert.Contains(t, strings.Join(returned.Warnings, "\n"), tc.warning)
+				return
+			}
+			assert.Empty(t, returned.Warnings)
+			require.Len(t, returned.Items, tc.expectedItems)
+			assert.True(t, returned.Items[0].Repository < returned.Items[len(returned.Items)-1].Repository, "items are sorted by repository")
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package github
+
+// The tools' parameter structs and their parse functions are generated from the input schemas of the tools, so that
+// handlers receive typed inputs that always match the schema advertised to clients.
+//go:generate go run ../../cmd/generate-params -o params_gen.go
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// pullRequestTemplatePaths are the locations GitHub reads the default pull request template from.
+var pullRequestTemplatePaths = []string{
+	".github/pull_request_template.md", ".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md", "PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md", "docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+var (
+	// markdownHeadingPattern matches a Markdown ATX heading, with the heading text as group.
+	markdownHeadingPattern = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	// checklistItemPattern matches a task list item, with the groups: the check mark and the item text.
+	checklistItemPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.*?)\s*$`)
+	// htmlCommentPattern matches HTML comments, which templates use for instructions.
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// closingIssuePattern matches the references to the issues a pull request closes, with the reference as group.
+	closingIssuePattern = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s*:?\s+((?:[\w.-]+/[\w.-]+)?#\d+|https://github\.com/[\w.-]+/[\w.-]+/issues/\d+)`)
+)
+
+// markdownSection is a heading of a Markdown document with the lines up to the next heading.
+type markdownSection struct {
+	Heading string
+	Lines   []string
+}
+
+// markdownSections splits a Markdown document by headings, leaving out HTML comments, blank lines and any text
+// before the first heading.
+func markdownSections(content string) []markdownSection {
+	content = htmlCommentPattern.ReplaceAllString(strings.ReplaceAll(content, "\r\n", "\n"), "")
+	var sections []markdownSection
+	for _, line := range strings.Split(content, "\n") {
+		if m := markdownHeadingPattern.FindStringSubmatch(line); m != nil {
+			sections = append(sections, markdownSection{Heading: m[1]})
+			continue
+		}
+		if line = strings.TrimSpace(line); line != "" && len(sections) > 0 {
+			sections[len(sections)-1].Lines = append(sections[len(sections)-1].Lines, line)
+		}
+	}
+	return sections
+}
+
+// descriptionSection is a section of a pull request template and whether the description fills it.
+type descriptionSection struct {
+	Heading string `json:"heading"`
+	Present bool   `json:"present"`
+	Filled  bool   `json:"filled"`
+}
+
+// descriptionChecklistItem is a task list item of a pull request description.
+type descriptionChecklistItem struct {
+	Text     string `json:"text"`
+	Checked  bool   `json:"checked"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// descriptionEvaluation is the result of evaluate_pr_description.
+type descriptionEvaluation struct {
+	PullNumber   int                        `json:"pull_number"`
+	Template     string                     `json:"template,omitempty"`
+	Passed       bool                       `json:"passed"`
+	Sections     []descriptionSection       `json:"sections,omitempty"`
+	Checklist    []descriptionChecklistItem `json:"checklist,omitempty"`
+	LinkedIssues []string                   `json:"linked_issues"`
+	Missing      []string                   `json:"missing"`
+}
+
+// sectionFilled reports whether a section of a description holds more than the placeholder text of the template.
+// Sections that are only a checklist in the template are filled when an item is checked.
+func sectionFilled(template, description markdownSection) bool {
+	checklistOnly := len(template.Lines) > 0
+	for _, line := range template.Lines {
+		if !checklistItemPattern.MatchString(line) {
+			checklistOnly = false
+		}
+	}
+	for _, line := range description.Lines {
+		if m := checklistItemPattern.FindStringSubmatch(line); m != nil {
+			if checklistOnly && m[1] != " " {
+				return true
+			}
+			continue
+		}
+		if !checklistOnly && !slices.Contains(template.Lines, line) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateDescription checks a pull request description against a template, required checklist items and linked
+// issues. Sections, required items and optional sections are matched case-insensitively, items by substring.
+func evaluateDescription(body, template string, optionalSections, requiredItems []string, requireLinkedIssue bool) descriptionEvaluation {
+	evaluation := descriptionEvaluation{LinkedIssues: []string{}, Missing: []string{}}
+	descriptionSections := markdownSections(body)
+
+	for _, templateSection := range markdownSections(template) {
+		section := descriptionSection{Heading: templateSection.Heading}
+		for _, s := range descriptionSections {
+			if strings.EqualFold(s.Heading, templateSection.Heading) {
+				section.Present = true
+				section.Filled = sectionFilled(templateSection, s)
+				break
+			}
+		}
+		evaluation.Sections = append(evaluation.Sections, section)
+		if slices.ContainsFunc(optionalSections, func(s string) bool { return strings.EqualFold(s, section.Heading) }) {
+			continue
+		}
+		switch {
+		case !section.Present:
+			evaluation.Missing = append(evaluation.Missing, fmt.Sprintf("section %q is missing", section.Heading))
+		case !section.Filled:
+			evaluation.Missing = append(evaluation.Missing, fmt.Sprintf("section %q is not filled in", section.Heading))
+		}
+	}
+
+	for _, line := range strings.Split(htmlCommentPattern.ReplaceAllString(body, ""), "\n") {
+		if m := checklistItemPattern.FindStringSubmatch(line); m != nil {
+			evaluation.Checklist = append(evaluation.Checklist, descriptionChecklistItem{Text: m[2], Checked: m[1] != " "})
+		}
+	}
+	for _, required := range requiredItems {
+		found := false
+		for i, item := range evaluation.Checklist {
+			if strings.Contains(strings.ToLower(item.Text), strings.ToLower(required)) {
+				evaluation.Checklist[i].Required = true
+				if !item.Checked {
+					evaluation.Missing = append(evaluation.Missing, fmt.Sprintf("checklist item %q is not checked", item.Text))
+				}
+				found = true
+			}
+		}
+		if !found {
+			evaluation.Missing = append(evaluation.Missing, fmt.Sprintf("checklist item %q is missing", required))
+		}
+	}
+
+	for _, m := range closingIssuePattern.FindAllStringSubmatch(body, -1) {
+		if !slices.Contains(evaluation.LinkedIssues, m[1]) {
+			evaluation.LinkedIssues = append(evaluation.LinkedIssues, m[1])
+		}
+	}
+	if requireLinkedIssue && len(evaluation.LinkedIssues) == 0 {
+		evaluation.Missing = append(evaluation.Missing, "no linked issue, e.g. Fixes #123")
+	}
+
+	evaluation.Passed = len(evaluation.Missing) == 0
+	return evaluation
+}
+
+// EvaluatePRDescription creates a tool to check the description of a pull request against the pull request template
+// of its repository.
+func EvaluatePRDescription(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("evaluate_pr_description",
+			mcp.WithDescription(t("TOOL_EVALUATE_PR_DESCRIPTION_DESCRIPTION", "Check the description of a pull request: whether it fills in every section of the repository's pull request template rather than leaving the placeholder text, whether the required checklist items are checked, and whether it links an issue with a closing keyword such as Fixes #123. Returns pass or fail with the missing items.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EVALUATE_PR_DESCRIPTION_USER_TITLE", "Evaluate pull request description"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("template_path",
+				mcp.Description("Path of the template to check against, defaults to the default pull request template of the repository"),
+			),
+			mcp.WithArray("optional_sections",
+				mcp.Description("Headings of the template sections that may be left out or empty"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("required_checklist_items",
+				mcp.Description("Checklist items that must be checked, matched by part of their text"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("require_linked_issue",
+				mcp.Description("Require a linked issue, defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseEvaluatePrDescriptionParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["require_linked_issue"]; !ok {
+				params.RequireLinkedIssue = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// The template is read from the base branch, the one the pull request was opened with
+			templatePaths := pullRequestTemplatePaths
+			if params.TemplatePath != "" {
+				templatePaths = []string{params.TemplatePath}
+			}
+			var templatePath, template string
+			for _, p := range templatePaths {
+				content, _, found, err := getFileAtRef(ctx, client, params.Owner, params.Repo, p, pr.GetBase().GetRef())
+				if err != nil {
+					return nil, err
+				}
+				if found {
+					templatePath, template = p, string(content)
+					break
+				}
+			}
+			if params.TemplatePath != "" && templatePath == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s was not found on %s", params.TemplatePath, pr.GetBase().GetRef())), nil
+			}
+
+			evaluation := evaluateDescription(pr.GetBody(), template, params.OptionalSections, params.RequiredChecklistItems, params.RequireLinkedIssue)
+			evaluation.PullNumber = params.PullNumber
+			evaluation.Template = templatePath
+
+			r, err := json.Marshal(evaluation)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
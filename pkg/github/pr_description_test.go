@@ -0,0 +1,127 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pullRequestTemplate = `## Summary
+
+<!-- What does this change and why? -->
+
+## Testing
+
+Describe how you tested the change.
+
+## Checklist
+
+- [ ] Tests added
+- [ ] Docs updated
+`
+
+func Test_EvaluateDescription(t *testing.T) {
+	t.Run("passes a filled in description", func(t *testing.T) {
+		body := "## Summary\r\n\r\nAdds search.\r\n\r\n## Testing\r\n\r\nDescribe how you tested the change.\r\nUnit tests.\r\n\r\n" +
+			"## Checklist\r\n\r\n- [x] Tests added\r\n- [ ] Docs updated\r\n\r\nFixes #12, resolves octo/other#3"
+		evaluation := evaluateDescription(body, pullRequestTemplate, nil, []string{"tests"}, true)
+		assert.Equal(t, descriptionEvaluation{
+			Passed: true,
+			Sections: []descriptionSection{
+				{Heading: "Summary", Present: true, Filled: true},
+				{Heading: "Testing", Present: true, Filled: true},
+				{Heading: "Checklist", Present: true, Filled: true},
+			},
+			Checklist: []descriptionChecklistItem{
+				{Text: "Tests added", Checked: true, Required: true},
+				{Text: "Docs updated"},
+			},
+			LinkedIssues: []string{"#12", "octo/other#3"},
+			Missing:      []string{},
+		}, evaluation)
+	})
+
+	t.Run("reports what is missing", func(t *testing.T) {
+		body := "## Summary\n\n<!-- What does this change and why? -->\n\n## Testing\n\nDescribe how you tested the change.\n\n" +
+			"- [ ] Tests added\n- [ ] Docs updated\n\nSee #12"
+		evaluation := evaluateDescription(body, pullRequestTemplate, []string{"testing"}, []string{"Tests added", "Changelog"}, true)
+		assert.False(t, evaluation.Passed)
+		assert.Equal(t, []string{
+			`section "Summary" is not filled in`,
+			`section "Checklist" is missing`,
+			`checklist item "Tests added" is not checked`,
+			`checklist item "Changelog" is missing`,
+			"no linked issue, e.g. Fixes #123",
+		}, evaluation.Missing)
+	})
+
+	t.Run("only checks linked issues without a template", func(t *testing.T) {
+		evaluation := evaluateDescription("Closes https://github.com/octo/repo/issues/7", "", nil, nil, true)
+		assert.True(t, evaluation.Passed)
+		assert.Empty(t, evaluation.Sections)
+		assert.Equal(t, []string{"https://github.com/octo/repo/issues/7"}, evaluation.LinkedIssues)
+	})
+}
+
+func Test_EvaluatePRDescription(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := EvaluatePRDescription(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "evaluate_pr_description", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "template_path")
+	assert.Contains(t, tool.InputSchema.Properties, "optional_sections")
+	assert.Contains(t, tool.InputSchema.Properties, "required_checklist_items")
+	assert.Contains(t, tool.InputSchema.Properties, "require_linked_issue")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{
+			Number: github.Ptr(42),
+			Body:   github.Ptr("## Summary\n\nAdds search.\n\n## Testing\n\nDescribe how you tested the change.\n"),
+			Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+		}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/repos/owner/repo/contents/.github/PULL_REQUEST_TEMPLATE.md" {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+						return
+					}
+					mockResponse(t, http.StatusOK, fileContent(".github/PULL_REQUEST_TEMPLATE.md", pullRequestTemplate))(w, r)
+				}),
+			),
+		),
+	)
+	_, handler := EvaluatePRDescription(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":                "owner",
+		"repo":                 "repo",
+		"pullNumber":           float64(42),
+		"require_linked_issue": false,
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var evaluation descriptionEvaluation
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &evaluation))
+	assert.Equal(t, 42, evaluation.PullNumber)
+	assert.Equal(t, ".github/PULL_REQUEST_TEMPLATE.md", evaluation.Template)
+	assert.False(t, evaluation.Passed)
+	assert.Equal(t, []string{`section "Testing" is not filled in`, `section "Checklist" is missing`}, evaluation.Missing)
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter sends MCP progress notifications while a long running tool call works through its steps, such
+// as the repositories of a fan out or the pages of a listing, so that clients don't take it for hung. It only
+// notifies clients that asked for progress by passing a progress token with the call.
+type progressReporter struct {
+	server *server.MCPServer
+	token  mcp.ProgressToken
+	// total is the number of steps of the call, 0 when it is not known upfront.
+	total int
+
+	mu   sync.Mutex
+	done int
+}
+
+// newProgressReporter returns the progress reporter of a tool call made of total steps, 0 if unknown.
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest, total int) *progressReporter {
+	p := &progressReporter{total: total}
+	if request.Params.Meta != nil && request.Params.Meta.ProgressToken != nil {
+		p.server = server.ServerFromContext(ctx)
+		p.token = request.Params.Meta.ProgressToken
+	}
+	return p
+}
+
+// step records n more steps done, describing the last one in message. It is safe to call from concurrent steps.
+func (p *progressReporter) step(ctx context.Context, n int, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	if p.server == nil {
+		return
+	}
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      p.done,
+		"message":       message,
+	}
+	if p.total > 0 {
+		params["total"] = p.total
+	}
+	// Notifications are best effort, a client not reading them must not fail the call
+	_ = p.server.SendNotificationToClient(ctx, "notifications/progress", params)
+}
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notifiedSession is a client session keeping the notifications sent to it.
+type notifiedSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *notifiedSession) Initialize()       {}
+func (s *notifiedSession) Initialized() bool { return true }
+func (s *notifiedSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+func (s *notifiedSession) SessionID() string { return "notified" }
+
+func Test_progressReporter(t *testing.T) {
+	srv := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	srv.AddTool(mcp.NewTool("count"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		progress := newProgressReporter(ctx, request, 3)
+		progress.step(ctx, 2, "counted to 2")
+		progress.step(ctx, 1, "counted to 3")
+		return mcp.NewToolResultText("done"), nil
+	})
+	session := &notifiedSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := srv.WithContext(context.Background(), session)
+
+	call := func(t *testing.T, message string) {
+		response, err := json.Marshal(srv.HandleMessage(ctx, []byte(message)))
+		require.NoError(t, err)
+		assert.Contains(t, string(response), `"text":"done"`)
+	}
+
+	t.Run("progress is notified with the token of the call", func(t *testing.T) {
+		call(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"count","_meta":{"progressToken":"count-1"}}}`)
+		require.Len(t, session.notifications, 2)
+		for _, expected := range []map[string]any{
+			{"progressToken": "count-1", "progress": 2, "total": 3, "message": "counted to 2"},
+			{"progressToken": "count-1", "progress": 3, "total": 3, "message": "counted to 3"},
+		} {
+			notification := <-session.notifications
+			assert.Equal(t, "notifications/progress", notification.Method)
+			assert.Equal(t, expected, notification.Params.AdditionalFields)
+		}
+	})
+
+	t.Run("calls without a progress token are not notified", func(t *testing.T) {
+		call(t, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"count"}}`)
+		assert.Empty(t, session.notifications)
+	})
+}
@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// projectParam is the parameter WithProjection adds to every tool.
+const projectParam = "project"
+
+// WithProjection returns a middleware adding a project parameter to each tool, a JMESPath expression selecting the
+// parts of its JSON result to return, such as items[*].{number: number, title: title}. Results that are not JSON
+// and error results are returned as they are. An invalid expression is rejected before the tool is called.
+func WithProjection() ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		properties := make(map[string]any, len(st.Tool.InputSchema.Properties)+1)
+		for k, v := range st.Tool.InputSchema.Properties {
+			properties[k] = v
+		}
+		properties[projectParam] = map[string]any{
+			"type":        "string",
+			"description": "JMESPath expression selecting the parts of the JSON result to return, to keep results small, e.g. [*].{number: number, title: title}, items[?state=='open'].html_url or length(items). Paths may also start with $ as in JSONPath. Leave out to get the whole result",
+		}
+		st.Tool.InputSchema.Properties = properties
+
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := request.GetArguments()
+			if args[projectParam] == nil {
+				return next(ctx, request)
+			}
+			expression, ok := args[projectParam].(string)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("parameter %s is not of type string", projectParam)), nil
+			}
+			stripped := make(map[string]any, len(args))
+			for k, v := range args {
+				if k != projectParam {
+					stripped[k] = v
+				}
+			}
+			request.Params.Arguments = stripped
+			if strings.TrimSpace(expression) == "" {
+				return next(ctx, request)
+			}
+			projection, err := compileProjection(expression)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid %s expression: %v", projectParam, err)), nil
+			}
+
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+			projected := *result
+			projected.Content = make([]mcp.Content, 0, len(result.Content))
+			for _, content := range result.Content {
+				text, ok := content.(mcp.TextContent)
+				if !ok {
+					projected.Content = append(projected.Content, content)
+					continue
+				}
+				var data any
+				if err := json.Unmarshal([]byte(text.Text), &data); err != nil {
+					projected.Content = append(projected.Content, content)
+					continue
+				}
+				value, err := projection.Search(data)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to apply %s expression: %v", projectParam, err)), nil
+				}
+				rendered, err := json.Marshal(value)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal projected result: %w", err)
+				}
+				text.Text = string(rendered)
+				projected.Content = append(projected.Content, text)
+				if value == nil {
+					projected.Content = append(projected.Content, mcp.NewTextContent(fmt.Sprintf("The %s expression matched nothing in the result, check the field names against the result without it.", projectParam)))
+				}
+			}
+			return &projected, nil
+		}
+		return st
+	}
+}
+
+// compileProjection compiles a JMESPath expression. A leading $ is read as the current node, so that JSONPath paths
+// such as $.items[*].title work.
+func compileProjection(expression string) (*jmespath.JMESPath, error) {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "$") {
+		expression = "@" + expression[1:]
+	}
+	return jmespath.Compile(expression)
+}
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_compileProjection(t *testing.T) {
+	data := `{"total_count":3,"items":[` +
+		`{"number":1,"title":"Crash","state":"open","comments":7,"user":{"login":"octocat"},"labels":[{"name":"bug"},{"name":"p1"}]},` +
+		`{"number":2,"title":"Docs","state":"closed","comments":0,"user":{"login":"hubot"},"labels":[]},` +
+		`{"number":3,"title":"Flaky test","state":"open","comments":2.5,"user":null,"labels":[{"name":"ci"}]}]}`
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{name: "field", expression: "total_count", expected: `3`},
+		{name: "missing field", expression: "missing", expected: `null`},
+		{name: "sub-expression", expression: "items[0].user.login", expected: `"octocat"`},
+		{name: "negative index", expression: "items[-1].number", expected: `3`},
+		{name: "index out of range", expression: "items[5]", expected: `null`},
+		{name: "list projection", expression: "items[*].number", expected: `[1,2,3]`},
+		{name: "projection drops nulls", expression: "items[*].user.login", expected: `["octocat","hubot"]`},
+		{name: "slice", expression: "items[:2].number", expected: `[1,2]`},
+		{name: "reversed slice", expression: "items[::-1].number", expected: `[3,2,1]`},
+		{name: "multi-select hash", expression: "items[*].{title: title, n: number}", expected: `[{"title":"Crash","n":1},{"title":"Docs","n":2},{"title":"Flaky test","n":3}]`},
+		{name: "multi-select list", expression: "items[0].[number, state]", expected: `[1,"open"]`},
+		{name: "filter on raw string", expression: "items[?state=='open'].number", expected: `[1,3]`},
+		{name: "filter on number", expression: "items[?comments > `1`].number", expected: `[1,3]`},
+		{name: "filter with or", expression: "items[?comments >= `2.5` || number == `2`].number", expected: `[1,2,3]`},
+		{name: "filter with and and not", expression: "items[?state=='open' && !user].title", expected: `["Flaky test"]`},
+		{name: "filter on truthiness", expression: "items[?labels].number", expected: `[1,3]`},
+		{name: "flatten", expression: "items[].labels[].name", expected: `["bug","p1","ci"]`},
+		{name: "object projection", expression: "items[0].user.*", expected: `["octocat"]`},
+		{name: "pipe stops the projection", expression: "items[*].number | [0]", expected: `1`},
+		{name: "or falls back", expression: "missing || total_count", expected: `3`},
+		{name: "parentheses", expression: "(items[0]).title", expected: `"Crash"`},
+		{name: "quoted identifier", expression: `"total_count"`, expected: `3`},
+		{name: "json literal", expression: "items[?user == `{\"login\": \"hubot\"}`].number", expected: `[2]`},
+		{name: "current node", expression: "@.total_count", expected: `3`},
+		{name: "jsonpath root", expression: "$.items[*].title", expected: `["Crash","Docs","Flaky test"]`},
+		{name: "function", expression: "length(items[?state=='open'])", expected: `2`},
+		{name: "sort", expression: "sort_by(items, &comments)[*].number", expected: `[2,3,1]`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var value any
+			require.NoError(t, json.Unmarshal([]byte(data), &value))
+			projection, err := compileProjection(tc.expression)
+			require.NoError(t, err)
+			projected, err := projection.Search(value)
+			require.NoError(t, err)
+			got, err := json.Marshal(projected)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(got))
+		})
+	}
+
+	for _, expression := range []string{"items[", "items[?state=='open'", "{title}", "items.[", "'open", "items ^ 1", "a b"} {
+		t.Run("rejects "+expression, func(t *testing.T) {
+			_, err := compileProjection(expression)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_WithProjection(t *testing.T) {
+	var got map[string]any
+	result := `[{"number":1,"title":"Crash","html_url":"https://github.com/owner/repo/issues/1"},{"number":2,"title":"Docs","html_url":"https://github.com/owner/repo/issues/2"}]`
+	calls := 0
+	wrapped := WithProjection()(server.ServerTool{
+		Tool: mcp.NewTool("list_issues", mcp.WithString("owner", mcp.Required())),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			got = request.GetArguments()
+			if got["owner"] == "text" {
+				return mcp.NewToolResultText("not json"), nil
+			}
+			if got["owner"] == "error" {
+				return mcp.NewToolResultError("failed to list issues"), nil
+			}
+			return mcp.NewToolResultText(result), nil
+		},
+	})
+
+	assert.Contains(t, wrapped.Tool.InputSchema.Properties, "owner")
+	require.Contains(t, wrapped.Tool.InputSchema.Properties, "project")
+
+	call := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		res, err := wrapped.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("the result is returned as it is without an expression", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner"})
+		assert.Equal(t, result, getTextResult(t, res).Text)
+	})
+
+	t.Run("the expression is applied and stripped from the arguments", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner", "project": "[*].{number: number, url: html_url}"})
+		assert.Equal(t, `[{"number":1,"url":"https://github.com/owner/repo/issues/1"},{"number":2,"url":"https://github.com/owner/repo/issues/2"}]`, getTextResult(t, res).Text)
+		assert.Equal(t, map[string]any{"owner": "owner"}, got)
+	})
+
+	t.Run("a note is added when nothing matches", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner", "project": "[0].url"})
+		require.Len(t, res.Content, 2)
+		assert.Equal(t, "null", res.Content[0].(mcp.TextContent).Text)
+		assert.Contains(t, res.Content[1].(mcp.TextContent).Text, "matched nothing")
+	})
+
+	t.Run("results that are not json are returned as they are", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "text", "project": "title"})
+		assert.Equal(t, "not json", getTextResult(t, res).Text)
+	})
+
+	t.Run("error results are returned as they are", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "error", "project": "title"})
+		assert.True(t, res.IsError)
+		assert.Equal(t, "failed to list issues", getTextResult(t, res).Text)
+	})
+
+	t.Run("invalid expressions are rejected before the call", func(t *testing.T) {
+		before := calls
+		res := call(t, map[string]any{"owner": "owner", "project": "[*].{number"})
+		assert.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "invalid project expression")
+		assert.Equal(t, before, calls)
+	})
+
+	t.Run("expressions failing on the result are reported", func(t *testing.T) {
+		res := call(t, map[string]any{"owner": "owner", "project": "[1:2:0]"})
+		assert.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, "failed to apply project expression")
+	})
+}
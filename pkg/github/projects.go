@@ -0,0 +1,613 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// projectV2Iteration is a single iteration of a Projects v2 iteration field.
+type projectV2Iteration struct {
+	ID        githubv4.String
+	Title     githubv4.String
+	StartDate githubv4.String
+	Duration  githubv4.Int
+}
+
+// start returns the parsed start date of the iteration, or the zero time if it cannot be parsed.
+func (i projectV2Iteration) start() time.Time {
+	t, err := time.Parse("2006-01-02", string(i.StartDate))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// end returns the (exclusive) end date of the iteration.
+func (i projectV2Iteration) end() time.Time {
+	return i.start().AddDate(0, 0, int(i.Duration))
+}
+
+type projectV2WithIterationField struct {
+	ID    githubv4.ID
+	Title githubv4.String
+	Field struct {
+		ProjectV2IterationField struct {
+			ID            githubv4.ID
+			Name          githubv4.String
+			Configuration struct {
+				Iterations          []projectV2Iteration
+				CompletedIterations []projectV2Iteration
+			}
+		} `graphql:"... on ProjectV2IterationField"`
+	} `graphql:"field(name: $fieldName)"`
+}
+
+type orgProjectV2IterationFieldQuery struct {
+	Organization struct {
+		ProjectV2 projectV2WithIterationField `graphql:"projectV2(number: $number)"`
+	} `graphql:"organization(login: $owner)"`
+}
+
+type userProjectV2IterationFieldQuery struct {
+	User struct {
+		ProjectV2 projectV2WithIterationField `graphql:"projectV2(number: $number)"`
+	} `graphql:"user(login: $owner)"`
+}
+
+type projectV2ItemNode struct {
+	ID         githubv4.ID
+	IsArchived githubv4.Boolean
+	Content    struct {
+		Issue struct {
+			Title  githubv4.String
+			Number githubv4.Int
+			URL    githubv4.URI
+			State  githubv4.String
+		} `graphql:"... on Issue"`
+		PullRequest struct {
+			Title  githubv4.String
+			Number githubv4.Int
+			URL    githubv4.URI
+			State  githubv4.String
+		} `graphql:"... on PullRequest"`
+		DraftIssue struct {
+			Title githubv4.String
+		} `graphql:"... on DraftIssue"`
+	}
+	Iteration struct {
+		ProjectV2ItemFieldIterationValue struct {
+			IterationID githubv4.String
+		} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+	} `graphql:"iteration: fieldValueByName(name: $iterationField)"`
+	Status struct {
+		ProjectV2ItemFieldSingleSelectValue struct {
+			Name githubv4.String
+		} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	} `graphql:"status: fieldValueByName(name: $statusField)"`
+}
+
+type projectV2ItemsQuery struct {
+	Node struct {
+		ProjectV2 struct {
+			Items struct {
+				Nodes    []projectV2ItemNode
+				PageInfo GraphQLPageInfo
+			} `graphql:"items(first: $first, after: $cursor)"`
+		} `graphql:"... on ProjectV2"`
+	} `graphql:"node(id: $projectId)"`
+	RateLimit GraphQLRateLimit `graphql:"rateLimit"`
+}
+
+// title returns the title of whatever content backs the project item.
+func (n projectV2ItemNode) title() string {
+	switch {
+	case n.Content.Issue.Title != "":
+		return string(n.Content.Issue.Title)
+	case n.Content.PullRequest.Title != "":
+		return string(n.Content.PullRequest.Title)
+	default:
+		return string(n.Content.DraftIssue.Title)
+	}
+}
+
+// url returns the URL of the content backing the project item, draft issues have none.
+func (n projectV2ItemNode) url() string {
+	switch {
+	case n.Content.Issue.URL.URL != nil:
+		return n.Content.Issue.URL.String()
+	case n.Content.PullRequest.URL.URL != nil:
+		return n.Content.PullRequest.URL.String()
+	default:
+		return ""
+	}
+}
+
+// contentClosed reports whether the issue or pull request backing the item is closed or merged.
+func (n projectV2ItemNode) contentClosed() bool {
+	for _, state := range []githubv4.String{n.Content.Issue.State, n.Content.PullRequest.State} {
+		if state == "CLOSED" || state == "MERGED" {
+			return true
+		}
+	}
+	return false
+}
+
+// selectRolloverIterations works out which iteration is ending and which one items should be moved to.
+// When fromID is empty, the iteration containing now is used, falling back to the most recently completed one.
+// When toID is empty, the first upcoming iteration that starts after the ending one is used.
+func selectRolloverIterations(iterations, completed []projectV2Iteration, fromID, toID string, now time.Time) (from, to projectV2Iteration, err error) {
+	all := append(append([]projectV2Iteration{}, completed...), iterations...)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].start().Before(all[j].start()) })
+
+	find := func(id string) (projectV2Iteration, bool) {
+		for _, it := range all {
+			if string(it.ID) == id {
+				return it, true
+			}
+		}
+		return projectV2Iteration{}, false
+	}
+
+	var ok bool
+	if fromID != "" {
+		if from, ok = find(fromID); !ok {
+			return from, to, fmt.Errorf("iteration %s not found", fromID)
+		}
+	} else {
+		for _, it := range all {
+			if !now.Before(it.start()) && now.Before(it.end()) {
+				from, ok = it, true
+				break
+			}
+		}
+		if !ok {
+			for _, it := range all {
+				if it.end().After(now) {
+					break
+				}
+				from, ok = it, true
+			}
+		}
+		if !ok {
+			return from, to, fmt.Errorf("could not determine the ending iteration, please provide from_iteration_id")
+		}
+	}
+
+	if toID != "" {
+		if to, ok = find(toID); !ok {
+			return from, to, fmt.Errorf("iteration %s not found", toID)
+		}
+	} else {
+		ok = false
+		for _, it := range all {
+			if it.start().After(from.start()) {
+				to, ok = it, true
+				break
+			}
+		}
+		if !ok {
+			return from, to, fmt.Errorf("no iteration found after %s, please create the next iteration first", from.Title)
+		}
+	}
+
+	if from.ID == to.ID {
+		return from, to, fmt.Errorf("source and target iterations are the same")
+	}
+
+	return from, to, nil
+}
+
+type rolledOverItem struct {
+	ItemID string `json:"item_id"`
+	Title  string `json:"title"`
+	URL    string `json:"url,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type iterationRolloverResult struct {
+	FromIteration string           `json:"from_iteration"`
+	ToIteration   string           `json:"to_iteration"`
+	DryRun        bool             `json:"dry_run"`
+	Moved         []rolledOverItem `json:"moved"`
+	Failed        []rolledOverItem `json:"failed,omitempty"`
+	Finished      int              `json:"finished"`
+	SummaryItemID string           `json:"summary_item_id,omitempty"`
+}
+
+// RolloverProjectIteration creates a tool to move unfinished items from the ending iteration of a project to the next one.
+func RolloverProjectIteration(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("rollover_project_iteration",
+			mcp.WithDescription(t("TOOL_ROLLOVER_PROJECT_ITERATION_DESCRIPTION", "Move unfinished items from the ending iteration of a GitHub Project (v2) to the next iteration, optionally adding a summary draft issue to the project.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ROLLOVER_PROJECT_ITERATION_USER_TITLE", "Roll over project iteration"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the organization or user that owns the project"),
+			),
+			mcp.WithString("owner_type",
+				mcp.Description("Whether the owner is an organization or a user, defaults to org"),
+				mcp.Enum("org", "user"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("The project number"),
+			),
+			mcp.WithString("iteration_field",
+				mcp.Description("Name of the iteration field, defaults to \"Iteration\""),
+			),
+			mcp.WithString("status_field",
+				mcp.Description("Name of the single select status field, defaults to \"Status\""),
+			),
+			mcp.WithArray("done_statuses",
+				mcp.Description("Status values that mark an item as finished, defaults to [\"Done\"]"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("from_iteration_id",
+				mcp.Description("ID of the iteration to move items from, defaults to the current iteration"),
+			),
+			mcp.WithString("to_iteration_id",
+				mcp.Description("ID of the iteration to move items to, defaults to the iteration following from_iteration_id"),
+			),
+			mcp.WithBoolean("post_summary",
+				mcp.Description("Add a draft issue to the project summarising the rolled over items"),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Report which items would be moved without changing anything"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseRolloverProjectIterationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.IterationField == "" {
+				params.IterationField = "Iteration"
+			}
+			if params.StatusField == "" {
+				params.StatusField = "Status"
+			}
+			if len(params.DoneStatuses) == 0 {
+				params.DoneStatuses = []string{"Done"}
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner":     githubv4.String(params.Owner),
+				"number":    githubv4.Int(int32(params.ProjectNumber)), // #nosec G115 - project numbers are small
+				"fieldName": githubv4.String(params.IterationField),
+			}
+
+			var project projectV2WithIterationField
+			if params.OwnerType == "user" {
+				var query userProjectV2IterationFieldQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				project = query.User.ProjectV2
+			} else {
+				var query orgProjectV2IterationFieldQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				project = query.Organization.ProjectV2
+			}
+
+			field := project.Field.ProjectV2IterationField
+			if field.ID == nil || field.ID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("iteration field %q not found in project %d", params.IterationField, params.ProjectNumber)), nil
+			}
+
+			from, to, err := selectRolloverIterations(
+				field.Configuration.Iterations,
+				field.Configuration.CompletedIterations,
+				params.FromIterationID,
+				params.ToIterationID,
+				time.Now(),
+			)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			done := make(map[string]bool, len(params.DoneStatuses))
+			for _, s := range params.DoneStatuses {
+				done[strings.ToLower(s)] = true
+			}
+
+			// Collect every unfinished item that is in the ending iteration.
+			items, err := PaginateGraphQL(ctx, GraphQLPaginationOptions{}, func(ctx context.Context, first githubv4.Int, after *githubv4.String) (GraphQLPage[projectV2ItemNode], error) {
+				var query projectV2ItemsQuery
+				err := client.Query(ctx, &query, map[string]any{
+					"projectId":      project.ID,
+					"iterationField": githubv4.String(params.IterationField),
+					"statusField":    githubv4.String(params.StatusField),
+					"first":          first,
+					"cursor":         after,
+				})
+				return GraphQLPage[projectV2ItemNode]{
+					Nodes:     query.Node.ProjectV2.Items.Nodes,
+					PageInfo:  query.Node.ProjectV2.Items.PageInfo,
+					RateLimit: query.RateLimit,
+				}, err
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var unfinished []projectV2ItemNode
+			finished := 0
+			for _, item := range items.Nodes {
+				if item.IsArchived || item.Iteration.ProjectV2ItemFieldIterationValue.IterationID != from.ID {
+					continue
+				}
+				status := string(item.Status.ProjectV2ItemFieldSingleSelectValue.Name)
+				if done[strings.ToLower(status)] || item.contentClosed() {
+					finished++
+					continue
+				}
+				unfinished = append(unfinished, item)
+			}
+
+			result := iterationRolloverResult{
+				FromIteration: string(from.Title),
+				ToIteration:   string(to.Title),
+				DryRun:        params.DryRun,
+				Moved:         []rolledOverItem{},
+				Finished:      finished,
+			}
+
+			for _, item := range unfinished {
+				moved := rolledOverItem{
+					ItemID: fmt.Sprintf("%v", item.ID),
+					Title:  item.title(),
+					URL:    item.url(),
+					Status: string(item.Status.ProjectV2ItemFieldSingleSelectValue.Name),
+				}
+
+				if params.DryRun {
+					result.Moved = append(result.Moved, moved)
+					continue
+				}
+
+				var mutation struct {
+					UpdateProjectV2ItemFieldValue struct {
+						ProjectV2Item struct {
+							ID githubv4.ID
+						}
+					} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &mutation, githubv4.UpdateProjectV2ItemFieldValueInput{
+					ProjectID: project.ID,
+					ItemID:    item.ID,
+					FieldID:   field.ID,
+					Value: githubv4.ProjectV2FieldValue{
+						IterationID: githubv4.NewString(to.ID),
+					},
+				}, nil); err != nil {
+					moved.Error = err.Error()
+					result.Failed = append(result.Failed, moved)
+					continue
+				}
+				result.Moved = append(result.Moved, moved)
+			}
+
+			if params.PostSummary && !params.DryRun {
+				var sb strings.Builder
+				sb.WriteString(fmt.Sprintf("%d unfinished item(s) were rolled over from %s to %s, %d item(s) were finished.\n\n", len(result.Moved), from.Title, to.Title, finished))
+				for _, item := range result.Moved {
+					if item.URL != "" {
+						sb.WriteString(fmt.Sprintf("- [%s](%s)\n", item.Title, item.URL))
+					} else {
+						sb.WriteString(fmt.Sprintf("- %s\n", item.Title))
+					}
+				}
+
+				var mutation struct {
+					AddProjectV2DraftIssue struct {
+						ProjectItem struct {
+							ID githubv4.ID
+						}
+					} `graphql:"addProjectV2DraftIssue(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &mutation, githubv4.AddProjectV2DraftIssueInput{
+					ProjectID: project.ID,
+					Title:     githubv4.String(fmt.Sprintf("Iteration rollover: %s → %s", from.Title, to.Title)),
+					Body:      githubv4.NewString(githubv4.String(sb.String())),
+				}, nil); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("items were rolled over but the summary could not be added: %s", err.Error())), nil
+				}
+				result.SummaryItemID = fmt.Sprintf("%v", mutation.AddProjectV2DraftIssue.ProjectItem.ID)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// projectV2Template is the project cloned by clone_project_v2, with the ID of its owner.
+type projectV2Template struct {
+	ID        githubv4.ID
+	ProjectV2 struct {
+		ID    githubv4.ID
+		Title githubv4.String
+	} `graphql:"projectV2(number: $number)"`
+}
+
+type orgProjectV2TemplateQuery struct {
+	Organization projectV2Template `graphql:"organization(login: $owner)"`
+}
+
+type userProjectV2TemplateQuery struct {
+	User projectV2Template `graphql:"user(login: $owner)"`
+}
+
+type repositoryOwnerIDQuery struct {
+	RepositoryOwner struct {
+		ID githubv4.ID
+	} `graphql:"repositoryOwner(login: $owner)"`
+}
+
+type copyProjectV2Mutation struct {
+	CopyProjectV2 struct {
+		ProjectV2 struct {
+			ID     githubv4.ID
+			Number githubv4.Int
+			Title  githubv4.String
+			URL    githubv4.URI
+			Fields struct {
+				TotalCount githubv4.Int
+			} `graphql:"fields(first: 1)"`
+			Views struct {
+				TotalCount githubv4.Int
+			} `graphql:"views(first: 1)"`
+			Workflows struct {
+				TotalCount githubv4.Int
+			} `graphql:"workflows(first: 1)"`
+		}
+	} `graphql:"copyProjectV2(input: $input)"`
+}
+
+// clonedProjectV2 is the result of clone_project_v2.
+type clonedProjectV2 struct {
+	SourceID    string `json:"source_id"`
+	SourceTitle string `json:"source_title"`
+	ID          string `json:"id"`
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Fields      int    `json:"fields"`
+	Views       int    `json:"views"`
+	Workflows   int    `json:"workflows"`
+}
+
+// CloneProjectV2 creates a tool to create a GitHub Project (v2) as a copy of another, used as a template.
+func CloneProjectV2(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("clone_project_v2",
+			mcp.WithDescription(t("TOOL_CLONE_PROJECT_V2_DESCRIPTION", "Create a GitHub Project (v2) as a copy of another one used as a template: its fields, views and workflows, and optionally its draft issues, are copied. Items linked to issues and pull requests are not.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLONE_PROJECT_V2_USER_TITLE", "Clone project"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the organization or user that owns the project to copy"),
+			),
+			mcp.WithString("owner_type",
+				mcp.Description("Whether the owner is an organization or a user, defaults to org"),
+				mcp.Enum("org", "user"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Number of the project to copy"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the new project"),
+			),
+			mcp.WithString("target_owner",
+				mcp.Description("Login of the organization or user to own the new project, defaults to owner"),
+			),
+			mcp.WithBoolean("include_draft_issues",
+				mcp.Description("Copy the draft issues of the project too"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCloneProjectV2Params(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner":  githubv4.String(params.Owner),
+				"number": githubv4.Int(int32(params.ProjectNumber)), // #nosec G115 - project numbers are small
+			}
+			var template projectV2Template
+			if params.OwnerType == "user" {
+				var query userProjectV2TemplateQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				template = query.User
+			} else {
+				var query orgProjectV2TemplateQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				template = query.Organization
+			}
+			if template.ProjectV2.ID == nil || template.ProjectV2.ID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("project %d not found for %s", params.ProjectNumber, params.Owner)), nil
+			}
+
+			ownerID := template.ID
+			if params.TargetOwner != "" && !strings.EqualFold(params.TargetOwner, params.Owner) {
+				var query repositoryOwnerIDQuery
+				if err := client.Query(ctx, &query, map[string]any{"owner": githubv4.String(params.TargetOwner)}); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if query.RepositoryOwner.ID == nil || query.RepositoryOwner.ID == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("no user or organization %s", params.TargetOwner)), nil
+				}
+				ownerID = query.RepositoryOwner.ID
+			}
+
+			var mutation copyProjectV2Mutation
+			if err := client.Mutate(ctx, &mutation, githubv4.CopyProjectV2Input{
+				ProjectID:          template.ProjectV2.ID,
+				OwnerID:            ownerID,
+				Title:              githubv4.String(params.Title),
+				IncludeDraftIssues: githubv4.NewBoolean(githubv4.Boolean(params.IncludeDraftIssues)),
+			}, nil); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			project := mutation.CopyProjectV2.ProjectV2
+			result := clonedProjectV2{
+				SourceID:    fmt.Sprint(template.ProjectV2.ID),
+				SourceTitle: string(template.ProjectV2.Title),
+				ID:          fmt.Sprint(project.ID),
+				Number:      int(project.Number),
+				Title:       string(project.Title),
+				Fields:      int(project.Fields.TotalCount),
+				Views:       int(project.Views.TotalCount),
+				Workflows:   int(project.Workflows.TotalCount),
+			}
+			if project.URL.URL != nil {
+				result.URL = project.URL.String()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
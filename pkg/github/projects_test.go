@@ -0,0 +1,440 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_selectRolloverIterations(t *testing.T) {
+	completed := []projectV2Iteration{
+		{ID: "it1", Title: "Sprint 1", StartDate: "2024-01-01", Duration: 14},
+	}
+	iterations := []projectV2Iteration{
+		{ID: "it2", Title: "Sprint 2", StartDate: "2024-01-15", Duration: 14},
+		{ID: "it3", Title: "Sprint 3", StartDate: "2024-01-29", Duration: 14},
+	}
+
+	tests := []struct {
+		name           string
+		fromID         string
+		toID           string
+		now            time.Time
+		expectedFrom   string
+		expectedTo     string
+		expectedErrMsg string
+	}{
+		{
+			name:         "current iteration rolls into next",
+			now:          time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			expectedFrom: "it2",
+			expectedTo:   "it3",
+		},
+		{
+			name:         "between iterations uses most recently completed",
+			now:          time.Date(2024, 1, 14, 12, 0, 0, 0, time.UTC),
+			expectedFrom: "it1",
+			expectedTo:   "it2",
+		},
+		{
+			name:         "explicit iterations",
+			fromID:       "it1",
+			toID:         "it3",
+			now:          time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			expectedFrom: "it1",
+			expectedTo:   "it3",
+		},
+		{
+			name:           "no next iteration",
+			fromID:         "it3",
+			now:            time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			expectedErrMsg: "no iteration found after Sprint 3",
+		},
+		{
+			name:           "unknown iteration",
+			fromID:         "missing",
+			now:            time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			expectedErrMsg: "iteration missing not found",
+		},
+		{
+			name:           "before any iteration",
+			now:            time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedErrMsg: "could not determine the ending iteration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := selectRolloverIterations(iterations, completed, tc.fromID, tc.toID, tc.now)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFrom, string(from.ID))
+			assert.Equal(t, tc.expectedTo, string(to.ID))
+		})
+	}
+}
+
+func Test_RolloverProjectIteration(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := RolloverProjectIteration(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rollover_project_iteration", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "project_number")
+	assert.Contains(t, tool.InputSchema.Properties, "from_iteration_id")
+	assert.Contains(t, tool.InputSchema.Properties, "to_iteration_id")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	fieldQueryMatcher := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			orgProjectV2IterationFieldQuery{},
+			map[string]any{
+				"owner":     githubv4.String("octo-org"),
+				"number":    githubv4.Int(1),
+				"fieldName": githubv4.String("Iteration"),
+			},
+			response,
+		)
+	}
+
+	projectResponse := githubv4mock.DataResponse(map[string]any{
+		"organization": map[string]any{
+			"projectV2": map[string]any{
+				"id":    "PVT_1",
+				"title": "Roadmap",
+				"field": map[string]any{
+					"id":   "PVTIF_1",
+					"name": "Iteration",
+					"configuration": map[string]any{
+						"iterations": []any{
+							map[string]any{"id": "it2", "title": "Sprint 2", "startDate": "2024-01-15", "duration": 14},
+						},
+						"completedIterations": []any{
+							map[string]any{"id": "it1", "title": "Sprint 1", "startDate": "2024-01-01", "duration": 14},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	itemsMatcher := githubv4mock.NewQueryMatcher(
+		projectV2ItemsQuery{},
+		map[string]any{
+			"projectId":      githubv4.ID("PVT_1"),
+			"iterationField": githubv4.String("Iteration"),
+			"statusField":    githubv4.String("Status"),
+			"first":          githubv4.Int(100),
+			"cursor":         (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"node": map[string]any{
+				"items": map[string]any{
+					"nodes": []any{
+						map[string]any{
+							"id":         "PVTI_unfinished",
+							"isArchived": false,
+							"content": map[string]any{
+								"title":  "Unfinished issue",
+								"number": 1,
+								"url":    "https://github.com/octo-org/repo/issues/1",
+								"state":  "OPEN",
+							},
+							"iteration": map[string]any{"iterationId": "it1"},
+							"status":    map[string]any{"name": "In Progress"},
+						},
+						map[string]any{
+							"id":         "PVTI_done",
+							"isArchived": false,
+							"content": map[string]any{
+								"title":  "Done issue",
+								"number": 2,
+								"url":    "https://github.com/octo-org/repo/issues/2",
+								"state":  "OPEN",
+							},
+							"iteration": map[string]any{"iterationId": "it1"},
+							"status":    map[string]any{"name": "Done"},
+						},
+						map[string]any{
+							"id":         "PVTI_other",
+							"isArchived": false,
+							"content":    map[string]any{"title": "Draft in next sprint"},
+							"iteration":  map[string]any{"iterationId": "it2"},
+							"status":     map[string]any{"name": "Todo"},
+						},
+					},
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				},
+			},
+			"rateLimit": map[string]any{"cost": 1, "remaining": 4999},
+		}),
+	)
+
+	updateMatcher := githubv4mock.NewMutationMatcher(
+		struct {
+			UpdateProjectV2ItemFieldValue struct {
+				ProjectV2Item struct {
+					ID githubv4.ID
+				}
+			} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+		}{},
+		githubv4.UpdateProjectV2ItemFieldValueInput{
+			ProjectID: githubv4.ID("PVT_1"),
+			ItemID:    githubv4.ID("PVTI_unfinished"),
+			FieldID:   githubv4.ID("PVTIF_1"),
+			Value: githubv4.ProjectV2FieldValue{
+				IterationID: githubv4.NewString("it2"),
+			},
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{}),
+	)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedMoved      []string
+		expectedFinished   int
+	}{
+		{
+			name: "rolls over unfinished items",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				fieldQueryMatcher(projectResponse),
+				itemsMatcher,
+				updateMatcher,
+			),
+			requestArgs: map[string]any{
+				"owner":             "octo-org",
+				"project_number":    float64(1),
+				"from_iteration_id": "it1",
+			},
+			expectedMoved:    []string{"Unfinished issue"},
+			expectedFinished: 1,
+		},
+		{
+			name: "dry run does not mutate",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				fieldQueryMatcher(projectResponse),
+				itemsMatcher,
+			),
+			requestArgs: map[string]any{
+				"owner":             "octo-org",
+				"project_number":    float64(1),
+				"from_iteration_id": "it1",
+				"dry_run":           true,
+			},
+			expectedMoved:    []string{"Unfinished issue"},
+			expectedFinished: 1,
+		},
+		{
+			name: "iteration field not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				fieldQueryMatcher(githubv4mock.DataResponse(map[string]any{
+					"organization": map[string]any{
+						"projectV2": map[string]any{
+							"id":    "PVT_1",
+							"title": "Roadmap",
+							"field": nil,
+						},
+					},
+				})),
+			),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(1),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "iteration field \"Iteration\" not found in project 1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := RolloverProjectIteration(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned iterationRolloverResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "Sprint 1", returned.FromIteration)
+			assert.Equal(t, "Sprint 2", returned.ToIteration)
+			assert.Equal(t, tc.expectedFinished, returned.Finished)
+			assert.Empty(t, returned.Failed)
+
+			moved := make([]string, 0, len(returned.Moved))
+			for _, item := range returned.Moved {
+				moved = append(moved, item.Title)
+			}
+			assert.Equal(t, tc.expectedMoved, moved)
+		})
+	}
+}
+
+func Test_CloneProjectV2(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CloneProjectV2(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "clone_project_v2", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "owner_type")
+	assert.Contains(t, tool.InputSchema.Properties, "project_number")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "target_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "include_draft_issues")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number", "title"})
+
+	templateMatcher := func(project any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			orgProjectV2TemplateQuery{},
+			map[string]any{
+				"owner":  githubv4.String("octo-org"),
+				"number": githubv4.Int(3),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"organization": map[string]any{
+					"id":        "O_1",
+					"projectV2": project,
+				},
+			}),
+		)
+	}
+	template := templateMatcher(map[string]any{"id": "PVT_template", "title": "Team board template"})
+	copyMatcher := func(ownerID string, drafts bool) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			copyProjectV2Mutation{},
+			githubv4.CopyProjectV2Input{
+				ProjectID:          githubv4.ID("PVT_template"),
+				OwnerID:            githubv4.ID(ownerID),
+				Title:              githubv4.String("Platform team"),
+				IncludeDraftIssues: githubv4.NewBoolean(githubv4.Boolean(drafts)),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"copyProjectV2": map[string]any{
+					"projectV2": map[string]any{
+						"id":        "PVT_new",
+						"number":    12,
+						"title":     "Platform team",
+						"url":       "https://github.com/orgs/octo-org/projects/12",
+						"fields":    map[string]any{"totalCount": 9},
+						"views":     map[string]any{"totalCount": 3},
+						"workflows": map[string]any{"totalCount": 2},
+					},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name:         "clones within the owner",
+			mockedClient: githubv4mock.NewMockedHTTPClient(template, copyMatcher("O_1", true)),
+			requestArgs: map[string]any{
+				"owner":                "octo-org",
+				"project_number":       float64(3),
+				"title":                "Platform team",
+				"include_draft_issues": true,
+			},
+		},
+		{
+			name: "clones for another owner",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				template,
+				githubv4mock.NewQueryMatcher(
+					repositoryOwnerIDQuery{},
+					map[string]any{"owner": githubv4.String("octocat")},
+					githubv4mock.DataResponse(map[string]any{"repositoryOwner": map[string]any{"id": "U_1"}}),
+				),
+				copyMatcher("U_1", false),
+			),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(3),
+				"title":          "Platform team",
+				"target_owner":   "octocat",
+			},
+		},
+		{
+			name:         "project not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(templateMatcher(nil)),
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(3),
+				"title":          "Platform team",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "project 3 not found for octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := CloneProjectV2(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var cloned clonedProjectV2
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &cloned))
+			assert.Equal(t, clonedProjectV2{
+				SourceID:    "PVT_template",
+				SourceTitle: "Team board template",
+				ID:          "PVT_new",
+				Number:      12,
+				Title:       "Platform team",
+				URL:         "https://github.com/orgs/octo-org/projects/12",
+				Fields:      9,
+				Views:       3,
+				Workflows:   2,
+			}, cloned)
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultReleaseBranchPatterns are the branch patterns audited, besides the default branch, when the caller does
+// not give any.
+var defaultReleaseBranchPatterns = []string{"release/*", "release-*", "releases/*"}
+
+// maxAuditedBranches bounds the number of branches listed by audit_protection_coverage.
+const maxAuditedBranches = 1000
+
+// protectedBranchCoverage describes a protected branch matching the audited patterns.
+type protectedBranchCoverage struct {
+	Branch         string   `json:"branch"`
+	ProtectedBy    []string `json:"protected_by"`
+	RequiredChecks []string `json:"required_checks,omitempty"`
+	// StaleChecks are required checks that no workflow job produces and that have not reported on the branch head,
+	// so they can never pass.
+	StaleChecks []string `json:"stale_checks,omitempty"`
+}
+
+// codeownersFinding is a problem with a CODEOWNERS entry.
+type codeownersFinding struct {
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+	Owner   string `json:"owner,omitempty"`
+	Problem string `json:"problem"`
+}
+
+// protectionCoverageReport is the result of audit_protection_coverage.
+type protectionCoverageReport struct {
+	Repository          string                    `json:"repository"`
+	DefaultBranch       string                    `json:"default_branch"`
+	BranchPatterns      []string                  `json:"branch_patterns"`
+	UnprotectedBranches []string                  `json:"unprotected_branches"`
+	ProtectedBranches   []protectedBranchCoverage `json:"protected_branches"`
+	CodeownersFile      string                    `json:"codeowners_file,omitempty"`
+	CodeownersFindings  []codeownersFinding       `json:"codeowners_findings"`
+	Warnings            []string                  `json:"warnings,omitempty"`
+}
+
+// matchesBranchPattern reports whether a branch matches one of the glob patterns, where * does not match a slash.
+func matchesBranchPattern(branch string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesetProtection returns the kinds of ruleset rules that apply to a branch, and the status checks they require.
+func rulesetProtection(rules *github.BranchRules) (kinds []string, checks []string) {
+	if rules == nil {
+		return nil, nil
+	}
+	add := func(kind string, n int) {
+		if n > 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+	add("pull_request", len(rules.PullRequest))
+	add("required_status_checks", len(rules.RequiredStatusChecks))
+	add("non_fast_forward", len(rules.NonFastForward))
+	add("deletion", len(rules.Deletion))
+	add("update", len(rules.Update))
+	add("required_signatures", len(rules.RequiredSignatures))
+	add("required_linear_history", len(rules.RequiredLinearHistory))
+	add("merge_queue", len(rules.MergeQueue))
+	for _, rule := range rules.RequiredStatusChecks {
+		for _, check := range rule.Parameters.RequiredStatusChecks {
+			checks = append(checks, check.Context)
+		}
+	}
+	return kinds, checks
+}
+
+// classicRequiredChecks returns the status checks required by the branch protection of a branch.
+func classicRequiredChecks(ctx context.Context, client *github.Client, owner, repo, branch string) ([]string, error) {
+	required, resp, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get required status checks of %s: %w", branch, err)
+	}
+
+	var checks []string
+	if required.Checks != nil {
+		for _, check := range *required.Checks {
+			checks = append(checks, check.Context)
+		}
+	} else if required.Contexts != nil {
+		checks = append(checks, *required.Contexts...)
+	}
+	return checks, nil
+}
+
+// reportedChecks returns the names of the check runs and commit statuses reported on the head of a branch.
+func reportedChecks(ctx context.Context, client *github.Client, owner, repo, branch string) (map[string]bool, error) {
+	names := map[string]bool{}
+	checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, branch, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list check runs of %s: %w", branch, err)
+	}
+	_ = resp.Body.Close()
+	for _, run := range checkRuns.CheckRuns {
+		names[run.GetName()] = true
+	}
+
+	status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses of %s: %w", branch, err)
+	}
+	_ = resp.Body.Close()
+	for _, s := range status.Statuses {
+		names[s.GetContext()] = true
+	}
+	return names, nil
+}
+
+// auditCodeowners reports CODEOWNERS entries that match no file of the tree, and owners that do not exist.
+func auditCodeowners(ctx context.Context, client *github.Client, co *codeowners, tree *github.Tree) []codeownersFinding {
+	findings := []codeownersFinding{}
+	for _, rule := range co.Rules {
+		matched := false
+		for _, entry := range tree.Entries {
+			if rule.re.MatchString(entry.GetPath()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			findings = append(findings, codeownersFinding{Line: rule.Line, Pattern: rule.Pattern, Problem: "pattern matches no file"})
+		}
+	}
+
+	// Owners are looked up once each, as they usually appear on many lines
+	problems := map[string]string{}
+	for _, rule := range co.Rules {
+		for _, o := range rule.Owners {
+			problem, checked := problems[o]
+			if !checked {
+				problem = codeownerProblem(ctx, client, o)
+				problems[o] = problem
+			}
+			if problem != "" {
+				findings = append(findings, codeownersFinding{Line: rule.Line, Pattern: rule.Pattern, Owner: o, Problem: problem})
+			}
+		}
+	}
+	return findings
+}
+
+// codeownerProblem checks that a CODEOWNERS owner exists, returning what is wrong with it or an empty string.
+// Email owners cannot be checked through the API and are assumed to be valid.
+func codeownerProblem(ctx context.Context, client *github.Client, o string) string {
+	name, ok := strings.CutPrefix(o, "@")
+	if !ok {
+		return ""
+	}
+
+	var resp *github.Response
+	var err error
+	kind := "user"
+	if org, slug, isTeam := strings.Cut(name, "/"); isTeam {
+		kind = "team"
+		_, resp, err = client.Teams.GetTeamBySlug(ctx, org, slug)
+	} else {
+		_, resp, err = client.Users.Get(ctx, name)
+	}
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	switch {
+	case err == nil:
+		return ""
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		return kind + " not found"
+	default:
+		return fmt.Sprintf("could not verify %s: %v", kind, err)
+	}
+}
+
+// AuditProtectionCoverage creates a tool to report gaps in the branch protection and code ownership of a repository.
+func AuditProtectionCoverage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("audit_protection_coverage",
+			mcp.WithDescription(t("TOOL_AUDIT_PROTECTION_COVERAGE_DESCRIPTION", "Audit the protection of a repository: which release branches are not protected by branch protection or rulesets, which required status checks no workflow produces, and which CODEOWNERS entries match no file or name users and teams that do not exist.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_AUDIT_PROTECTION_COVERAGE_USER_TITLE", "Audit protection coverage"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("branch_patterns",
+				mcp.Description(fmt.Sprintf("Glob patterns of the release branches to audit besides the default branch, where * does not match a slash (default %s)", strings.Join(defaultReleaseBranchPatterns, ", "))),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseAuditProtectionCoverageParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(params.BranchPatterns) == 0 {
+				params.BranchPatterns = defaultReleaseBranchPatterns
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+			defaultBranch := repository.GetDefaultBranch()
+
+			report := protectionCoverageReport{
+				Repository:          repository.GetFullName(),
+				DefaultBranch:       defaultBranch,
+				BranchPatterns:      params.BranchPatterns,
+				UnprotectedBranches: []string{},
+				ProtectedBranches:   []protectedBranchCoverage{},
+			}
+
+			// Branches
+			var branches []*github.Branch
+			opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				page, resp, err := client.Repositories.ListBranches(ctx, params.Owner, params.Repo, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list branches: %w", err)
+				}
+				_ = resp.Body.Close()
+				branches = append(branches, page...)
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(branches) >= maxAuditedBranches {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("only the first %d branches were audited", maxAuditedBranches))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			workflows, err := getWorkflowFiles(ctx, client, params.Owner, params.Repo, defaultBranch)
+			if err != nil {
+				return nil, err
+			}
+			var jobCheckNames []string
+			for _, wf := range workflows {
+				if wf.ParseError != "" {
+					report.Warnings = append(report.Warnings, fmt.Sprintf("could not parse %s: %s", wf.Path, wf.ParseError))
+					continue
+				}
+				for id, job := range wf.Jobs {
+					jobCheckNames = append(jobCheckNames, job.checkName(id))
+				}
+			}
+
+			for _, branch := range branches {
+				name := branch.GetName()
+				if name != defaultBranch && !matchesBranchPattern(name, params.BranchPatterns) {
+					continue
+				}
+
+				rules, resp, err := client.Repositories.GetRulesForBranch(ctx, params.Owner, params.Repo, name)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get rules of %s: %w", name, err)
+				}
+				_ = resp.Body.Close()
+				ruleKinds, rulesetChecks := rulesetProtection(rules)
+
+				coverage := protectedBranchCoverage{Branch: name}
+				var checks []string
+				if branch.GetProtected() {
+					coverage.ProtectedBy = append(coverage.ProtectedBy, "branch_protection")
+					if checks, err = classicRequiredChecks(ctx, client, params.Owner, params.Repo, name); err != nil {
+						return nil, err
+					}
+				}
+				checks = append(checks, rulesetChecks...)
+				if len(ruleKinds) > 0 {
+					coverage.ProtectedBy = append(coverage.ProtectedBy, "ruleset")
+				}
+				if len(coverage.ProtectedBy) == 0 {
+					report.UnprotectedBranches = append(report.UnprotectedBranches, name)
+					continue
+				}
+
+				var reported map[string]bool
+				seen := map[string]bool{}
+				for _, check := range checks {
+					if seen[check] {
+						continue
+					}
+					seen[check] = true
+					coverage.RequiredChecks = append(coverage.RequiredChecks, check)
+
+					producedByWorkflow := false
+					for _, jobCheckName := range jobCheckNames {
+						if jobMatchesCheck(jobCheckName, check) {
+							producedByWorkflow = true
+							break
+						}
+					}
+					if producedByWorkflow {
+						continue
+					}
+					// Checks may come from other CI systems and apps, which report on the commits themselves
+					if reported == nil {
+						if reported, err = reportedChecks(ctx, client, params.Owner, params.Repo, name); err != nil {
+							return nil, err
+						}
+					}
+					if !reported[check] {
+						coverage.StaleChecks = append(coverage.StaleChecks, check)
+					}
+				}
+				report.ProtectedBranches = append(report.ProtectedBranches, coverage)
+			}
+
+			// Code owners
+			report.CodeownersFindings = []codeownersFinding{}
+			co, err := getCodeowners(ctx, client, params.Owner, params.Repo, defaultBranch)
+			if err != nil {
+				return nil, err
+			}
+			if co == nil {
+				report.Warnings = append(report.Warnings, "the repository has no CODEOWNERS file")
+			} else {
+				report.CodeownersFile = co.Path
+				tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, defaultBranch, true)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get tree of %s: %w", defaultBranch, err)
+				}
+				_ = resp.Body.Close()
+				if tree.GetTruncated() {
+					report.Warnings = append(report.Warnings, "the repository tree is too large to list completely, patterns reported as matching no file may match files that were not listed")
+				}
+				report.CodeownersFindings = auditCodeowners(ctx, client, co, tree)
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
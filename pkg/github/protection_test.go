@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_JobMatchesCheck(t *testing.T) {
+	assert.True(t, jobMatchesCheck("build", "build"))
+	assert.True(t, jobMatchesCheck("test", "test (ubuntu-latest, 1.23)"))
+	assert.True(t, jobMatchesCheck("release", "release / publish"))
+	assert.True(t, jobMatchesCheck("test on ${{ matrix.os }}", "test on windows"))
+	assert.False(t, jobMatchesCheck("build", "build-docs"))
+	assert.False(t, jobMatchesCheck("lint", "golangci"))
+}
+
+// fileContent returns the contents API response for a file.
+func fileContent(path, content string) *github.RepositoryContent {
+	return &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Name:     github.Ptr(path[strings.LastIndex(path, "/")+1:]),
+		Path:     github.Ptr(path),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+	}
+}
+
+func Test_AuditProtectionCoverage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AuditProtectionCoverage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "audit_protection_coverage", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch_patterns")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	workflow := `
+name: CI
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  lint:
+    name: lint
+    strategy:
+      matrix:
+        os: [ubuntu]
+`
+	codeownersFile := "* @octocat\n*.go @octo-org/backend\n/docs/ @ghost\n/old/ @octocat\n"
+
+	contents := map[string]any{
+		".github/workflows": []*github.RepositoryContent{
+			{Type: github.Ptr("file"), Name: github.Ptr("ci.yml"), Path: github.Ptr(".github/workflows/ci.yml")},
+			{Type: github.Ptr("file"), Name: github.Ptr("README.md"), Path: github.Ptr(".github/workflows/README.md")},
+		},
+		".github/workflows/ci.yml": fileContent(".github/workflows/ci.yml", workflow),
+		".github/CODEOWNERS":       fileContent(".github/CODEOWNERS", codeownersFile),
+	}
+	rules := map[string]string{
+		"main":        `[]`,
+		"release-1.0": `[]`,
+		"release-2.0": `[
+			{"type": "pull_request", "ruleset_source_type": "Repository", "ruleset_source": "octo-org/repo", "ruleset_id": 1, "parameters": {"dismiss_stale_reviews_on_push": false, "require_code_owner_review": true, "require_last_push_approval": false, "required_approving_review_count": 1, "required_review_thread_resolution": false}},
+			{"type": "required_status_checks", "ruleset_source_type": "Repository", "ruleset_source": "octo-org/repo", "ruleset_id": 1, "parameters": {"required_status_checks": [{"context": "build"}, {"context": "deploy-check"}], "strict_required_status_checks_policy": false}}
+		]`,
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{
+			FullName:      github.Ptr("octo-org/repo"),
+			DefaultBranch: github.Ptr("main"),
+		}),
+		mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, []*github.Branch{
+			{Name: github.Ptr("main"), Protected: github.Ptr(true)},
+			{Name: github.Ptr("release-1.0"), Protected: github.Ptr(false)},
+			{Name: github.Ptr("release-2.0"), Protected: github.Ptr(false)},
+			{Name: github.Ptr("feature-x"), Protected: github.Ptr(false)},
+		}),
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				p := strings.TrimPrefix(r.URL.Path, "/repos/octo-org/repo/contents/")
+				if content, ok := contents[p]; ok {
+					mockResponse(t, http.StatusOK, content)(w, r)
+					return
+				}
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				branch := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				mockResponse(t, http.StatusOK, rules[branch])(w, r)
+			}),
+		),
+		mock.WithRequestMatch(
+			mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+			&github.RequiredStatusChecks{
+				Checks: &[]*github.RequiredStatusCheck{
+					{Context: "build"},
+					{Context: "lint (ubuntu)"},
+					{Context: "legacy-ci"},
+				},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{
+					CheckRuns: []*github.CheckRun{{Name: github.Ptr("build")}},
+				})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsStatusByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := &github.CombinedStatus{}
+				if strings.Contains(r.URL.Path, "release-2.0") {
+					status.Statuses = []*github.RepoStatus{{Context: github.Ptr("deploy-check")}}
+				}
+				mockResponse(t, http.StatusOK, status)(w, r)
+			}),
+		),
+		mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, &github.Tree{
+			Entries: []*github.TreeEntry{
+				{Path: github.Ptr("main.go"), Type: github.Ptr("blob")},
+				{Path: github.Ptr("docs"), Type: github.Ptr("tree")},
+				{Path: github.Ptr("docs/index.md"), Type: github.Ptr("blob")},
+			},
+		}),
+		mock.WithRequestMatch(mock.GetOrgsTeamsByOrgByTeamSlug, &github.Team{Slug: github.Ptr("backend")}),
+		mock.WithRequestMatchHandler(
+			mock.GetUsersByUsername,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/ghost") {
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, &github.User{Login: github.Ptr("octocat")})(w, r)
+			}),
+		),
+	)
+
+	_, handler := AuditProtectionCoverage(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "octo-org",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var report protectionCoverageReport
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+
+	assert.Equal(t, "main", report.DefaultBranch)
+	assert.Equal(t, []string{"release-1.0"}, report.UnprotectedBranches)
+	assert.Equal(t, []protectedBranchCoverage{
+		{
+			Branch:         "main",
+			ProtectedBy:    []string{"branch_protection"},
+			RequiredChecks: []string{"build", "lint (ubuntu)", "legacy-ci"},
+			StaleChecks:    []string{"legacy-ci"},
+		},
+		{
+			Branch:         "release-2.0",
+			ProtectedBy:    []string{"ruleset"},
+			RequiredChecks: []string{"build", "deploy-check"},
+		},
+	}, report.ProtectedBranches)
+
+	assert.Equal(t, ".github/CODEOWNERS", report.CodeownersFile)
+	assert.Equal(t, []codeownersFinding{
+		{Line: 4, Pattern: "/old/", Problem: "pattern matches no file"},
+		{Line: 3, Pattern: "/docs/", Owner: "@ghost", Problem: "user not found"},
+	}, report.CodeownersFindings)
+	assert.Empty(t, report.Warnings)
+}
@@ -0,0 +1,254 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxPullRequestCheckPages bounds the pages of check runs get_pull_request_checks lists.
+const maxPullRequestCheckPages = 5
+
+// States of the checks of a pull request, and of the pull request as a whole.
+const (
+	checkStatePending = "pending"
+	checkStateSuccess = "success"
+	checkStateFailure = "failure"
+)
+
+// pullRequestCheck is a check run, a check suite without runs yet, or a commit status, normalized.
+type pullRequestCheck struct {
+	Name string `json:"name"`
+	// Kind is check_run, check_suite or status.
+	Kind  string `json:"kind"`
+	State string `json:"state"`
+	// Conclusion is the conclusion of a completed check run, or the state of a commit status, such as timed_out or error.
+	Conclusion  string `json:"conclusion,omitempty"`
+	App         string `json:"app,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	URL         string `json:"url,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+// pullRequestChecks is the result of get_pull_request_checks.
+type pullRequestChecks struct {
+	PullNumber int    `json:"pull_number"`
+	HeadSHA    string `json:"head_sha"`
+	// State is failure when a check failed, pending when a check has not completed, success when all passed, and none
+	// when no check was reported.
+	State   string             `json:"state"`
+	Total   int                `json:"total"`
+	Success int                `json:"success"`
+	Failure int                `json:"failure"`
+	Pending int                `json:"pending"`
+	Checks  []pullRequestCheck `json:"checks"`
+	// Truncated is set when the head has more check runs than were listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// add records a check and counts it in the state of the pull request.
+func (c *pullRequestChecks) add(check pullRequestCheck) {
+	c.Checks = append(c.Checks, check)
+	c.Total++
+	switch check.State {
+	case checkStateSuccess:
+		c.Success++
+	case checkStateFailure:
+		c.Failure++
+	default:
+		c.Pending++
+	}
+}
+
+// finish sets the overall state and sorts the checks, failures first, then pending checks, by name.
+func (c *pullRequestChecks) finish() {
+	switch {
+	case c.Failure > 0:
+		c.State = checkStateFailure
+	case c.Pending > 0:
+		c.State = checkStatePending
+	case c.Success > 0:
+		c.State = checkStateSuccess
+	default:
+		c.State = "none"
+	}
+	rank := map[string]int{checkStateFailure: 0, checkStatePending: 1, checkStateSuccess: 2}
+	sort.SliceStable(c.Checks, func(i, j int) bool {
+		if rank[c.Checks[i].State] != rank[c.Checks[j].State] {
+			return rank[c.Checks[i].State] < rank[c.Checks[j].State]
+		}
+		return c.Checks[i].Name < c.Checks[j].Name
+	})
+}
+
+// formatCheckTime formats the time a check started or completed, empty when it did not.
+func formatCheckTime(ts *github.Timestamp) string {
+	if ts == nil || ts.IsZero() {
+		return ""
+	}
+	return ts.UTC().Format(time.RFC3339)
+}
+
+// newCheckRunCheck normalizes a check run.
+func newCheckRunCheck(run *github.CheckRun) pullRequestCheck {
+	check := pullRequestCheck{
+		Name:        run.GetName(),
+		Kind:        "check_run",
+		State:       checkStatePending,
+		App:         run.GetApp().GetName(),
+		Summary:     run.GetOutput().GetTitle(),
+		URL:         run.GetDetailsURL(),
+		StartedAt:   formatCheckTime(run.StartedAt),
+		CompletedAt: formatCheckTime(run.CompletedAt),
+	}
+	if check.URL == "" {
+		check.URL = run.GetHTMLURL()
+	}
+	if run.GetStatus() == "completed" {
+		check.Conclusion = run.GetConclusion()
+		check.State = checkStateFailure
+		if passingCheckConclusions[check.Conclusion] {
+			check.State = checkStateSuccess
+		}
+	}
+	return check
+}
+
+// newStatusCheck normalizes a commit status.
+func newStatusCheck(status *github.RepoStatus) pullRequestCheck {
+	check := pullRequestCheck{
+		Name:       status.GetContext(),
+		Kind:       "status",
+		Conclusion: status.GetState(),
+		Summary:    status.GetDescription(),
+		URL:        status.GetTargetURL(),
+	}
+	switch status.GetState() {
+	case "success":
+		check.State = checkStateSuccess
+	case "pending":
+		check.State = checkStatePending
+	default:
+		check.State = checkStateFailure
+	}
+	return check
+}
+
+// GetPullRequestChecks creates a tool to summarize the check runs, check suites and commit statuses of the head of a
+// pull request.
+func GetPullRequestChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_checks",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_CHECKS_DESCRIPTION", "Get every check of the head commit of a pull request in one normalized summary: the check runs of GitHub Apps such as Actions, the check suites still waiting for runs, and the legacy commit statuses. Each check has a pending, success or failure state and a link to its details or logs, failures first, with an overall state and counts.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_CHECKS_USER_TITLE", "Get pull request checks"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetPullRequestChecksParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			sha := pr.GetHead().GetSHA()
+
+			result := pullRequestChecks{
+				PullNumber: params.PullNumber,
+				HeadSHA:    sha,
+				Checks:     []pullRequestCheck{},
+			}
+
+			// Only the latest run of each check is listed, so reruns replace earlier failures
+			runsBySuite := make(map[int64]int)
+			opts := &github.ListCheckRunsOptions{
+				Filter:      github.Ptr("latest"),
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for page := 1; ; page++ {
+				checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, params.Owner, params.Repo, sha, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list check runs: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, run := range checkRuns.CheckRuns {
+					runsBySuite[run.GetCheckSuite().GetID()]++
+					result.add(newCheckRunCheck(run))
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if page == maxPullRequestCheckPages {
+					result.Truncated = true
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			// Suites that are queued or running without any run yet, such as workflows waiting for a runner, are
+			// pending checks too. Completed suites without runs are left out, apps create them for every push.
+			suites, resp, err := client.Checks.ListCheckSuitesForRef(ctx, params.Owner, params.Repo, sha, &github.ListCheckSuiteOptions{
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list check suites: %w", err)
+			}
+			_ = resp.Body.Close()
+			for _, suite := range suites.CheckSuites {
+				if suite.GetStatus() == "completed" || runsBySuite[suite.GetID()] > 0 {
+					continue
+				}
+				result.add(pullRequestCheck{
+					Name:  suite.GetApp().GetName(),
+					Kind:  "check_suite",
+					State: checkStatePending,
+					App:   suite.GetApp().GetName(),
+				})
+			}
+
+			status, resp, err := client.Repositories.GetCombinedStatus(ctx, params.Owner, params.Repo, sha, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get commit statuses: %w", err)
+			}
+			_ = resp.Body.Close()
+			for _, s := range status.Statuses {
+				result.add(newStatusCheck(s))
+			}
+
+			result.finish()
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_pull_request_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	started := &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+	completed := &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC)}
+	actions := &github.App{Name: github.Ptr("GitHub Actions")}
+	checkRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(3),
+		CheckRuns: []*github.CheckRun{
+			{
+				Name:        github.Ptr("test"),
+				Status:      github.Ptr("completed"),
+				Conclusion:  github.Ptr("failure"),
+				App:         actions,
+				CheckSuite:  &github.CheckSuite{ID: github.Ptr(int64(1))},
+				DetailsURL:  github.Ptr("https://github.com/owner/repo/actions/runs/1/job/2"),
+				Output:      &github.CheckRunOutput{Title: github.Ptr("2 tests failed")},
+				StartedAt:   started,
+				CompletedAt: completed,
+			},
+			{
+				Name:       github.Ptr("lint"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("skipped"),
+				App:        actions,
+				CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(1))},
+				HTMLURL:    github.Ptr("https://github.com/owner/repo/runs/3"),
+			},
+			{
+				Name:       github.Ptr("build"),
+				Status:     github.Ptr("in_progress"),
+				App:        actions,
+				CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(1))},
+				StartedAt:  started,
+			},
+		},
+	}
+	checkSuites := &github.ListCheckSuiteResults{
+		Total: github.Ptr(3),
+		CheckSuites: []*github.CheckSuite{
+			{ID: github.Ptr(int64(1)), Status: github.Ptr("in_progress"), App: actions},
+			{ID: github.Ptr(int64(2)), Status: github.Ptr("queued"), App: &github.App{Name: github.Ptr("Deploy Bot")}},
+			{ID: github.Ptr(int64(3)), Status: github.Ptr("completed"), App: &github.App{Name: github.Ptr("Idle App")}},
+		},
+	}
+	combinedStatus := &github.CombinedStatus{
+		Statuses: []*github.RepoStatus{
+			{
+				Context:     github.Ptr("ci/jenkins"),
+				State:       github.Ptr("success"),
+				Description: github.Ptr("Build passed"),
+				TargetURL:   github.Ptr("https://jenkins.example.com/job/1"),
+			},
+			{Context: github.Ptr("coverage"), State: github.Ptr("error")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       pullRequestChecks
+	}{
+		{
+			name: "aggregates check runs, pending suites and statuses",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Number: github.Ptr(42), Head: &github.PullRequestBranch{SHA: github.Ptr("abc123")}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{"filter": "latest", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, checkRuns),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef, checkSuites),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, combinedStatus),
+			),
+			expected: pullRequestChecks{
+				PullNumber: 42,
+				HeadSHA:    "abc123",
+				State:      checkStateFailure,
+				Total:      6,
+				Success:    2,
+				Failure:    2,
+				Pending:    2,
+				Checks: []pullRequestCheck{
+					{Name: "coverage", Kind: "status", State: checkStateFailure, Conclusion: "error"},
+					{
+						Name:        "test",
+						Kind:        "check_run",
+						State:       checkStateFailure,
+						Conclusion:  "failure",
+						App:         "GitHub Actions",
+						Summary:     "2 tests failed",
+						URL:         "https://github.com/owner/repo/actions/runs/1/job/2",
+						StartedAt:   "2024-05-01T10:00:00Z",
+						CompletedAt: "2024-05-01T10:05:00Z",
+					},
+					{Name: "Deploy Bot", Kind: "check_suite", State: checkStatePending, App: "Deploy Bot"},
+					{Name: "build", Kind: "check_run", State: checkStatePending, App: "GitHub Actions", StartedAt: "2024-05-01T10:00:00Z"},
+					{Name: "ci/jenkins", Kind: "status", State: checkStateSuccess, Conclusion: "success", Summary: "Build passed", URL: "https://jenkins.example.com/job/1"},
+					{Name: "lint", Kind: "check_run", State: checkStateSuccess, Conclusion: "skipped", App: "GitHub Actions", URL: "https://github.com/owner/repo/runs/3"},
+				},
+			},
+		},
+		{
+			name: "no checks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Number: github.Ptr(42), Head: &github.PullRequestBranch{SHA: github.Ptr("abc123")}},
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, &github.ListCheckRunsResults{}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef, &github.ListCheckSuiteResults{}),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{}),
+			),
+			expected: pullRequestChecks{
+				PullNumber: 42,
+				HeadSHA:    "abc123",
+				State:      "none",
+				Checks:     []pullRequestCheck{},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned pullRequestChecks
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultDiffMaxBytes is the size get_pull_request_diff cuts diffs at by default, below the size at which results
+	// are split for fetch_more so that the cut falls between files.
+	defaultDiffMaxBytes = 50_000
+	// minDiffMaxBytes keeps the pages of get_pull_request_diff large enough to hold the headers and a few hunks.
+	minDiffMaxBytes = 1000
+	// maxDiffMaxBytes bounds the size of the diff get_pull_request_diff returns at once.
+	maxDiffMaxBytes = 1_000_000
+)
+
+// generatedFileNames are the lock files and other files written by tools, whose diffs are noise to a reviewer.
+var generatedFileNames = map[string]bool{
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"go.sum":              true,
+	"Cargo.lock":          true,
+	"Gemfile.lock":        true,
+	"poetry.lock":         true,
+	"Pipfile.lock":        true,
+	"composer.lock":       true,
+}
+
+// generatedFileSuffixes are the name endings of generated and minified files.
+var generatedFileSuffixes = []string{".min.js", ".min.css", ".js.map", ".css.map", ".pb.go", "_pb2.py", ".generated.go", "_generated.go"}
+
+// generatedMarkerPattern finds the header of generated files, such as the "Code generated ... DO NOT EDIT." line of
+// Go, among the lines a diff adds.
+var generatedMarkerPattern = regexp.MustCompile(`(?m)^\+.*(Code generated .* DO NOT EDIT|@generated\b)`)
+
+// diffFile is the part of a unified diff changing one file.
+type diffFile struct {
+	Path string
+	Text string
+}
+
+// splitDiffFiles splits a unified diff into its files.
+func splitDiffFiles(diff string) []diffFile {
+	var starts []int
+	for offset := 0; offset < len(diff); {
+		if offset == 0 || strings.HasPrefix(diff[offset:], "diff --git ") {
+			starts = append(starts, offset)
+		}
+		next := strings.IndexByte(diff[offset:], '\n')
+		if next < 0 {
+			break
+		}
+		offset += next + 1
+	}
+	files := make([]diffFile, 0, len(starts))
+	for i, start := range starts {
+		end := len(diff)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		text := diff[start:end]
+		files = append(files, diffFile{Path: diffFilePath(text), Text: text})
+	}
+	return files
+}
+
+// diffFilePath returns the path of the file a part of a diff changes, its old path when the file is deleted.
+func diffFilePath(text string) string {
+	var header, oldPath string
+	for rest := text; rest != ""; {
+		var line string
+		line, rest, _ = strings.Cut(rest, "\n")
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			header = line
+		case strings.HasPrefix(line, "--- a/"):
+			oldPath = strings.TrimPrefix(line, "--- a/")
+		case strings.HasPrefix(line, "+++ b/"):
+			return strings.TrimPrefix(line, "+++ b/")
+		case strings.HasPrefix(line, "@@"):
+			// The file headers come before the first hunk
+			return oldPath
+		}
+	}
+	if oldPath != "" {
+		return oldPath
+	}
+	// Files without content changes, such as renames and binary files, only have the diff --git header
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		return header[i+len(" b/"):]
+	}
+	return ""
+}
+
+// isGeneratedDiffFile reports whether a file of a diff is generated or vendored, from its path or the generated
+// file header it adds.
+func isGeneratedDiffFile(file diffFile) bool {
+	if isVendoredPath(file.Path) || generatedFileNames[path.Base(file.Path)] {
+		return true
+	}
+	for _, suffix := range generatedFileSuffixes {
+		if strings.HasSuffix(file.Path, suffix) {
+			return true
+		}
+	}
+	return generatedMarkerPattern.MatchString(file.Text)
+}
+
+// diffCursor is the position get_pull_request_diff continues from: the index of a file among the selected ones of
+// the diff with the given hash, so that a diff changed by a push in between isn't continued at the wrong file.
+type diffCursor struct {
+	Hash string
+	File int
+}
+
+func (c diffCursor) String() string {
+	return fmt.Sprintf("%s:%d", c.Hash, c.File)
+}
+
+// diffHash identifies the content of a diff.
+func diffHash(diff string) string {
+	sum := sha256.Sum256([]byte(diff))
+	return hex.EncodeToString(sum[:8])
+}
+
+// parseDiffCursor parses a cursor returned by get_pull_request_diff.
+func parseDiffCursor(cursor string) (diffCursor, error) {
+	hash, file, ok := strings.Cut(cursor, ":")
+	index, err := strconv.Atoi(file)
+	if !ok || err != nil || index < 0 || hash == "" {
+		return diffCursor{}, fmt.Errorf("invalid cursor %q, pass the cursor of the previous call as it is", cursor)
+	}
+	return diffCursor{Hash: hash, File: index}, nil
+}
+
+// diffPage is the part of a diff get_pull_request_diff returns.
+type diffPage struct {
+	Text string
+	// Next is the index of the first selected file left out, 0 when the page reaches the end of the diff.
+	Next int
+	// Cut is the path of a file longer than the page on its own, whose diff was cut.
+	Cut string
+}
+
+// pageDiffFiles returns the files from index start on, stopping before the first file that would take the page
+// over maxBytes. A first file over maxBytes on its own is cut at the last line fitting.
+func pageDiffFiles(files []diffFile, start, maxBytes int) diffPage {
+	var page diffPage
+	var b strings.Builder
+	for i := start; i < len(files); i++ {
+		text := files[i].Text
+		if b.Len()+len(text) <= maxBytes {
+			b.WriteString(text)
+			continue
+		}
+		if b.Len() == 0 {
+			cut, _ := resultPage(text, 0, maxBytes)
+			if end := strings.LastIndex(cut, "\n"); end > 0 {
+				cut = cut[:end+1]
+			}
+			b.WriteString(cut)
+			page.Cut = files[i].Path
+			i++
+		}
+		if i < len(files) {
+			page.Next = i
+		}
+		break
+	}
+	page.Text = b.String()
+	return page
+}
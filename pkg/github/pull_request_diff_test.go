@@ -0,0 +1,58 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_splitDiffFiles(t *testing.T) {
+	modified := "diff --git a/main.go b/main.go\nindex 1..2 100644\n--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-a\n+b\n"
+	deleted := "diff --git a/old.go b/old.go\ndeleted file mode 100644\n--- a/old.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-a\n"
+	renamed := "diff --git a/docs/a.md b/docs/b.md\nsimilarity index 100%\nrename from docs/a.md\nrename to docs/b.md\n"
+	binary := "diff --git a/logo.png b/logo.png\nindex 1..2 100644\nBinary files a/logo.png and b/logo.png differ\n"
+
+	files := splitDiffFiles(modified + deleted + renamed + binary)
+	require.Len(t, files, 4)
+	assert.Equal(t, []diffFile{
+		{Path: "main.go", Text: modified},
+		{Path: "old.go", Text: deleted},
+		{Path: "docs/b.md", Text: renamed},
+		{Path: "logo.png", Text: binary},
+	}, files)
+	assert.Empty(t, splitDiffFiles(""))
+}
+
+func Test_isGeneratedDiffFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      diffFile
+		generated bool
+	}{
+		{name: "source file", file: diffFile{Path: "pkg/server.go", Text: "+package server\n"}},
+		{name: "lock file", file: diffFile{Path: "web/package-lock.json"}, generated: true},
+		{name: "vendored file", file: diffFile{Path: "vendor/github.com/x/y.go"}, generated: true},
+		{name: "minified file", file: diffFile{Path: "static/app.min.js"}, generated: true},
+		{name: "protobuf file", file: diffFile{Path: "api/api.pb.go"}, generated: true},
+		{name: "generated header", file: diffFile{Path: "mocks/store.go", Text: "+// Code generated by MockGen. DO NOT EDIT.\n+package mocks\n"}, generated: true},
+		{name: "generated tag", file: diffFile{Path: "schema.ts", Text: "+/* @generated */\n"}, generated: true},
+		{name: "removed header", file: diffFile{Path: "mocks/store.go", Text: "-// Code generated by MockGen. DO NOT EDIT.\n"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.generated, isGeneratedDiffFile(tc.file))
+		})
+	}
+}
+
+func Test_parseDiffCursor(t *testing.T) {
+	cursor, err := parseDiffCursor(diffCursor{Hash: "abc", File: 3}.String())
+	require.NoError(t, err)
+	assert.Equal(t, diffCursor{Hash: "abc", File: 3}, cursor)
+
+	for _, invalid := range []string{"abc", "abc:x", ":3", "abc:-1"} {
+		_, err := parseDiffCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// draftPullRequestNode is the pull request returned by the mutations changing its draft state.
+type draftPullRequestNode struct {
+	Number  githubv4.Int
+	URL     githubv4.URI
+	IsDraft githubv4.Boolean
+}
+
+func marshalDraftPullRequest(node draftPullRequestNode) (*mcp.CallToolResult, error) {
+	r, err := json.Marshal(map[string]any{
+		"pull_number": node.Number,
+		"url":         node.URL.String(),
+		"draft":       node.IsDraft,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// MarkPullRequestReadyForReview creates a tool to take a draft pull request out of draft.
+func MarkPullRequestReadyForReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_pr_ready_for_review",
+			mcp.WithDescription(t("TOOL_MARK_PR_READY_FOR_REVIEW_DESCRIPTION", "Mark a draft pull request as ready for review, which notifies the code owners and requested reviewers. Does nothing to pull requests that aren't drafts.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_PR_READY_FOR_REVIEW_USER_TITLE", "Mark pull request ready for review"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseMarkPrReadyForReviewParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := pullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", err.Error())), nil
+			}
+
+			var ready struct {
+				MarkPullRequestReadyForReview struct {
+					PullRequest draftPullRequestNode
+				} `graphql:"markPullRequestReadyForReview(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &ready, githubv4.MarkPullRequestReadyForReviewInput{
+				PullRequestID: id,
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to mark pull request ready for review: %s", err.Error())), nil
+			}
+			return marshalDraftPullRequest(ready.MarkPullRequestReadyForReview.PullRequest)
+		}
+}
+
+// ConvertPullRequestToDraft creates a tool to turn a pull request back into a draft.
+func ConvertPullRequestToDraft(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("convert_pr_to_draft",
+			mcp.WithDescription(t("TOOL_CONVERT_PR_TO_DRAFT_DESCRIPTION", "Convert a pull request to a draft, so that it can't be merged and reviewers know it isn't ready, e.g. while its checks are being fixed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CONVERT_PR_TO_DRAFT_USER_TITLE", "Convert pull request to draft"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseConvertPrToDraftParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := pullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", err.Error())), nil
+			}
+
+			var draft struct {
+				ConvertPullRequestToDraft struct {
+					PullRequest draftPullRequestNode
+				} `graphql:"convertPullRequestToDraft(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &draft, githubv4.ConvertPullRequestToDraftInput{
+				PullRequestID: id,
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to convert pull request to draft: %s", err.Error())), nil
+			}
+			return marshalDraftPullRequest(draft.ConvertPullRequestToDraft.PullRequest)
+		}
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MarkPullRequestReadyForReview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := MarkPullRequestReadyForReview(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "mark_pr_ready_for_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	readyMutation := struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest draftPullRequestNode
+		} `graphql:"markPullRequestReadyForReview(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name           string
+		response       githubv4mock.GQLResponse
+		expectError    bool
+		expectedResult map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "ready for review",
+			response: githubv4mock.DataResponse(map[string]any{
+				"markPullRequestReadyForReview": map[string]any{
+					"pullRequest": map[string]any{"number": 42, "url": "https://github.com/owner/repo/pull/42", "isDraft": false},
+				},
+			}),
+			expectedResult: map[string]any{"pull_number": float64(42), "url": "https://github.com/owner/repo/pull/42", "draft": false},
+		},
+		{
+			name:           "mutation fails",
+			response:       githubv4mock.ErrorResponse("Resource not accessible by integration"),
+			expectError:    true,
+			expectedErrMsg: "failed to mark pull request ready for review: Resource not accessible by integration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				pullRequestNodeIDQuery(),
+				githubv4mock.NewMutationMatcher(readyMutation, githubv4.MarkPullRequestReadyForReviewInput{PullRequestID: githubv4.ID("PR_42")}, nil, tc.response),
+			))
+			_, handler := MarkPullRequestReadyForReview(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_ConvertPullRequestToDraft(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ConvertPullRequestToDraft(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "convert_pr_to_draft", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		pullRequestNodeIDQuery(),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				ConvertPullRequestToDraft struct {
+					PullRequest draftPullRequestNode
+				} `graphql:"convertPullRequestToDraft(input: $input)"`
+			}{},
+			githubv4.ConvertPullRequestToDraftInput{PullRequestID: githubv4.ID("PR_42")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"convertPullRequestToDraft": map[string]any{
+					"pullRequest": map[string]any{"number": 42, "url": "https://github.com/owner/repo/pull/42", "isDraft": true},
+				},
+			}),
+		),
+	))
+	_, handler := ConvertPullRequestToDraft(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, map[string]any{"pull_number": float64(42), "url": "https://github.com/owner/repo/pull/42", "draft": true}, returned)
+}
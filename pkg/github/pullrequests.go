@@ -3,9 +3,14 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v69/github"
@@ -38,15 +43,7 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
+			params, err := parseGetPullRequestParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -55,7 +52,7 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request: %w", err)
 			}
@@ -117,60 +114,29 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			title, err := requiredParam[string](request, "title")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			head, err := requiredParam[string](request, "head")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			base, err := requiredParam[string](request, "base")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			body, err := OptionalParam[string](request, "body")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			draft, err := OptionalParam[bool](request, "draft")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			maintainerCanModify, err := OptionalParam[bool](request, "maintainer_can_modify")
+			params, err := parseCreatePullRequestParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			newPR := &github.NewPullRequest{
-				Title: github.Ptr(title),
-				Head:  github.Ptr(head),
-				Base:  github.Ptr(base),
+				Title: github.Ptr(params.Title),
+				Head:  github.Ptr(params.Head),
+				Base:  github.Ptr(params.Base),
 			}
 
-			if body != "" {
-				newPR.Body = github.Ptr(body)
+			if params.Body != "" {
+				newPR.Body = github.Ptr(params.Body)
 			}
 
-			newPR.Draft = github.Ptr(draft)
-			newPR.MaintainerCanModify = github.Ptr(maintainerCanModify)
+			newPR.Draft = github.Ptr(params.Draft)
+			newPR.MaintainerCanModify = github.Ptr(params.MaintainerCanModify)
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, newPR)
+			pr, resp, err := client.PullRequests.Create(ctx, params.Owner, params.Repo, newPR)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create pull request: %w", err)
 			}
@@ -231,15 +197,7 @@ func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
+			params, err := parseUpdatePullRequestParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -291,7 +249,7 @@ func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			pr, resp, err := client.PullRequests.Edit(ctx, owner, repo, pullNumber, update)
+			pr, resp, err := client.PullRequests.Edit(ctx, params.Owner, params.Repo, params.PullNumber, update)
 			if err != nil {
 				return nil, fmt.Errorf("failed to update pull request: %w", err)
 			}
@@ -351,48 +309,20 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			state, err := OptionalParam[string](request, "state")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			head, err := OptionalParam[string](request, "head")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			base, err := OptionalParam[string](request, "base")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			sort, err := OptionalParam[string](request, "sort")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			direction, err := OptionalParam[string](request, "direction")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
+			params, err := parseListPullRequestsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.PullRequestListOptions{
-				State:     state,
-				Head:      head,
-				Base:      base,
-				Sort:      sort,
-				Direction: direction,
+				State:     params.State,
+				Head:      params.Head,
+				Base:      params.Base,
+				Sort:      params.Sort,
+				Direction: params.Direction,
 				ListOptions: github.ListOptions{
-					PerPage: pagination.perPage,
-					Page:    pagination.page,
+					PerPage: params.PerPage,
+					Page:    params.Page,
 				},
 			}
 
@@ -400,7 +330,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			prs, resp, err := client.PullRequests.List(ctx, params.Owner, params.Repo, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list pull requests: %w", err)
 			}
@@ -453,44 +383,44 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Merge method"),
 				mcp.Enum("merge", "squash", "rebase"),
 			),
+			mcp.WithString("expected_sha",
+				mcp.Description("SHA the pull request head must match. The merge is refused if new commits were pushed since it was reviewed"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			commitTitle, err := OptionalParam[string](request, "commit_title")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			commitMessage, err := OptionalParam[string](request, "commit_message")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			mergeMethod, err := OptionalParam[string](request, "merge_method")
+			params, err := parseMergePullRequestParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			options := &github.PullRequestOptions{
-				CommitTitle: commitTitle,
-				MergeMethod: mergeMethod,
+				CommitTitle: params.CommitTitle,
+				MergeMethod: params.MergeMethod,
+				// The API also enforces the head SHA, which closes the gap between our check and the merge.
+				SHA: params.ExpectedSHA,
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			result, resp, err := client.PullRequests.Merge(ctx, owner, repo, pullNumber, commitMessage, options)
+
+			if params.ExpectedSHA != "" {
+				pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request: %w", err)
+				}
+				_ = resp.Body.Close()
+				if headSHA := pr.GetHead().GetSHA(); headSHA != params.ExpectedSHA {
+					return conflictResult(fmt.Sprintf("pull request #%d", params.PullNumber), params.ExpectedSHA, headSHA), nil
+				}
+			}
+			result, resp, err := client.PullRequests.Merge(ctx, params.Owner, params.Repo, params.PullNumber, params.CommitMessage, options)
 			if err != nil {
+				// Branches protected by a merge queue refuse direct merges
+				if strings.Contains(strings.ToLower(err.Error()), "merge queue") {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to merge pull request: %s. The base branch requires a merge queue, use enqueue_pull_request", err.Error())), nil
+				}
 				return nil, fmt.Errorf("failed to merge pull request: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
@@ -534,15 +464,7 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
+			params, err := parseGetPullRequestFilesParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -552,7 +474,7 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			opts := &github.ListOptions{}
-			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+			files, resp, err := client.PullRequests.ListFiles(ctx, params.Owner, params.Repo, params.PullNumber, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request files: %w", err)
 			}
@@ -597,15 +519,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
+			params, err := parseGetPullRequestStatusParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -614,7 +528,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request: %w", err)
 			}
@@ -629,7 +543,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			}
 
 			// Get combined status for the head SHA
-			status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, *pr.Head.SHA, nil)
+			status, resp, err := client.Repositories.GetCombinedStatus(ctx, params.Owner, params.Repo, *pr.Head.SHA, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get combined status: %w", err)
 			}
@@ -652,10 +566,17 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 		}
 }
 
+const (
+	// maxBranchUpdateWait bounds how long update_pull_request_branch waits for the mergeable state of the pull request.
+	maxBranchUpdateWait = 2 * time.Minute
+	// mergeableStatePollInterval is how often update_pull_request_branch checks the pull request while waiting.
+	mergeableStatePollInterval = 2 * time.Second
+)
+
 // UpdatePullRequestBranch creates a tool to update a pull request branch with the latest changes from the base branch.
-func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func UpdatePullRequestBranch(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("update_pull_request_branch",
-			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch.")),
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch, by merging the base branch into it or rebasing it on the base branch. Can wait until GitHub has computed whether the updated pull request can be merged, to unblock stale pull requests in one call.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_BRANCH_USER_TITLE", "Update pull request branch"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -675,53 +596,117 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			mcp.WithString("expectedHeadSha",
 				mcp.Description("The expected SHA of the pull request's HEAD ref"),
 			),
+			mcp.WithString("update_method",
+				mcp.Description("merge to merge the base branch into the pull request branch, the default, or rebase to rebase it on the base branch, which rewrites its commits"),
+				mcp.Enum("merge", "rebase"),
+			),
+			mcp.WithNumber("wait_seconds",
+				mcp.Description(fmt.Sprintf("Seconds to wait for the update to land and GitHub to compute the mergeable state of the pull request, at most %d. Defaults to 0, returning once the update is requested", int(maxBranchUpdateWait.Seconds()))),
+				mcp.Min(0),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
+			params, err := parseUpdatePullRequestBranchParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if params.UpdateMethod == "" {
+				params.UpdateMethod = "merge"
 			}
-			expectedHeadSHA, err := OptionalParam[string](request, "expectedHeadSha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if params.UpdateMethod != "merge" && params.UpdateMethod != "rebase" {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown update_method %q, use merge or rebase", params.UpdateMethod)), nil
 			}
-			opts := &github.PullRequestBranchUpdateOptions{}
-			if expectedHeadSHA != "" {
-				opts.ExpectedHeadSHA = github.Ptr(expectedHeadSHA)
+			wait := time.Duration(params.WaitSeconds) * time.Second
+			if wait < 0 || wait > maxBranchUpdateWait {
+				return mcp.NewToolResultError(fmt.Sprintf("wait_seconds must be between 0 and %d", int(maxBranchUpdateWait.Seconds()))), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			result, resp, err := client.PullRequests.UpdateBranch(ctx, owner, repo, pullNumber, opts)
-			if err != nil {
-				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
-				// and it's not a real error.
-				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Pull request branch update is in progress"), nil
+
+			// The head before the update tells when the update has landed
+			var previousHead string
+			if wait > 0 {
+				pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, params.PullNumber)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get pull request: %w", err)
 				}
-				return nil, fmt.Errorf("failed to update pull request branch: %w", err)
+				_ = resp.Body.Close()
+				previousHead = pr.GetHead().GetSHA()
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusAccepted {
-				body, err := io.ReadAll(resp.Body)
+			update := pullRequestBranchUpdate{UpdateMethod: params.UpdateMethod}
+			if params.UpdateMethod == "rebase" {
+				// The REST endpoint only merges, rebasing is only offered by GraphQL
+				gqlClient, err := getGQLClient(ctx)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				id, err := pullRequestNodeID(ctx, gqlClient, params.Owner, params.Repo, params.PullNumber)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", err.Error())), nil
+				}
+				method := githubv4.PullRequestBranchUpdateMethodRebase
+				input := githubv4.UpdatePullRequestBranchInput{
+					PullRequestID: id,
+					UpdateMethod:  &method,
+				}
+				if params.ExpectedHeadSHA != "" {
+					input.ExpectedHeadOid = githubv4.NewGitObjectID(githubv4.GitObjectID(params.ExpectedHeadSHA))
+				}
+				var mutation struct {
+					UpdatePullRequestBranch struct {
+						PullRequest struct {
+							HeadRefOid githubv4.GitObjectID
+							URL        githubv4.URI
+						}
+					} `graphql:"updatePullRequestBranch(input: $input)"`
+				}
+				if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to rebase pull request branch: %s", err.Error())), nil
+				}
+				update.Message = "Pull request branch was rebased on the base branch"
+				update.URL = mutation.UpdatePullRequestBranch.PullRequest.URL.String()
+				update.HeadSHA = string(mutation.UpdatePullRequestBranch.PullRequest.HeadRefOid)
+			} else {
+				opts := &github.PullRequestBranchUpdateOptions{}
+				if params.ExpectedHeadSHA != "" {
+					opts.ExpectedHeadSHA = github.Ptr(params.ExpectedHeadSHA)
+				}
+				result, resp, err := client.PullRequests.UpdateBranch(ctx, params.Owner, params.Repo, params.PullNumber, opts)
+				switch {
+				case err != nil && resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err):
+					// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
+					// and it's not a real error.
+					if wait == 0 {
+						return mcp.NewToolResultText("Pull request branch update is in progress"), nil
+					}
+					update.Message = "Pull request branch update is in progress"
+				case err != nil:
+					return nil, fmt.Errorf("failed to update pull request branch: %w", err)
+				default:
+					defer func() { _ = resp.Body.Close() }()
+					if resp.StatusCode != http.StatusAccepted {
+						body, err := io.ReadAll(resp.Body)
+						if err != nil {
+							return nil, fmt.Errorf("failed to read response body: %w", err)
+						}
+						return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request branch: %s", string(body))), nil
+					}
+					update.Message = result.GetMessage()
+					update.URL = result.GetURL()
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request branch: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(result)
+			if wait > 0 {
+				if err := waitForMergeableState(ctx, client, params.Owner, params.Repo, params.PullNumber, previousHead, wait, &update); err != nil {
+					return nil, err
+				}
+			}
+
+			r, err := json.Marshal(update)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -730,6 +715,47 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 		}
 }
 
+// pullRequestBranchUpdate is the result of update_pull_request_branch.
+type pullRequestBranchUpdate struct {
+	Message      string `json:"message"`
+	URL          string `json:"url,omitempty"`
+	UpdateMethod string `json:"update_method"`
+	HeadSHA      string `json:"head_sha,omitempty"`
+	// Mergeable and MergeableState are only set when waiting for the update.
+	Mergeable      *bool  `json:"mergeable,omitempty"`
+	MergeableState string `json:"mergeable_state,omitempty"`
+	// TimedOut is set when the update did not land or its mergeable state was not computed in time.
+	TimedOut bool `json:"timed_out,omitempty"`
+}
+
+// waitForMergeableState polls a pull request until its head moved from previousHead and GitHub computed whether it
+// can be merged, or until wait elapsed, and records its state in update.
+func waitForMergeableState(ctx context.Context, client *github.Client, owner, repo string, number int, previousHead string, wait time.Duration, update *pullRequestBranchUpdate) error {
+	deadline := time.Now().Add(wait)
+	for {
+		pr, resp, err := client.PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+		_ = resp.Body.Close()
+		update.HeadSHA = pr.GetHead().GetSHA()
+		update.Mergeable = pr.Mergeable
+		update.MergeableState = pr.GetMergeableState()
+		if update.HeadSHA != previousHead && pr.Mergeable != nil && update.MergeableState != "unknown" {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			update.TimedOut = true
+			return nil
+		}
+		if err := waitOrCancel(ctx, min(mergeableStatePollInterval, remaining)); err != nil {
+			return err
+		}
+	}
+}
+
 // GetPullRequestComments creates a tool to get the review comments on a pull request.
 func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_comments",
@@ -752,15 +778,7 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
+			params, err := parseGetPullRequestCommentsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -775,7 +793,7 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, pullNumber, opts)
+			comments, resp, err := client.PullRequests.ListComments(ctx, params.Owner, params.Repo, params.PullNumber, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request comments: %w", err)
 			}
@@ -820,15 +838,7 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pullNumber, err := RequiredInt(request, "pullNumber")
+			params, err := parseGetPullRequestReviewsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -837,7 +847,7 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, nil)
+			reviews, resp, err := client.PullRequests.ListReviews(ctx, params.Owner, params.Repo, params.PullNumber, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request reviews: %w", err)
 			}
@@ -1208,6 +1218,240 @@ func AddPullRequestReviewCommentToPendingReview(getGQLClient GetGQLClientFn, t t
 		}
 }
 
+// maxPendingReviewComments bounds the comments add_pull_request_review_comments_to_pending_review adds in one call.
+const maxPendingReviewComments = 50
+
+// pendingReviewComment is a comment of add_pull_request_review_comments_to_pending_review.
+type pendingReviewComment struct {
+	Path        string
+	Body        string
+	SubjectType string
+	Line        *int32
+	Side        *string
+	StartLine   *int32
+	StartSide   *string
+}
+
+// validate checks a comment before any of the batch is added, so that a mistake doesn't leave a review half
+// commented.
+func (c *pendingReviewComment) validate() error {
+	if c.Path == "" {
+		return errors.New("missing path")
+	}
+	if c.Body == "" {
+		return errors.New("missing body")
+	}
+	switch c.SubjectType {
+	case "":
+		c.SubjectType = "LINE"
+	case "FILE", "LINE":
+	default:
+		return fmt.Errorf("subjectType must be FILE or LINE, got %q", c.SubjectType)
+	}
+	if c.SubjectType == "LINE" && c.Line == nil {
+		return errors.New("line is required for LINE comments")
+	}
+	for _, side := range []*string{c.Side, c.StartSide} {
+		if side != nil && *side != "LEFT" && *side != "RIGHT" {
+			return fmt.Errorf("side must be LEFT or RIGHT, got %q", *side)
+		}
+	}
+	if c.StartLine != nil && (c.Line == nil || *c.StartLine > *c.Line) {
+		return errors.New("startLine must be set with line and not be after it")
+	}
+	return nil
+}
+
+// failedReviewComment is a comment of the batch GitHub refused, such as one on a line outside of the diff.
+type failedReviewComment struct {
+	Index int    `json:"index"`
+	Path  string `json:"path"`
+	Line  *int32 `json:"line,omitempty"`
+	Error string `json:"error"`
+}
+
+// pendingReviewComments is the result of add_pull_request_review_comments_to_pending_review.
+type pendingReviewComments struct {
+	Added  int                   `json:"added"`
+	Failed []failedReviewComment `json:"failed,omitempty"`
+}
+
+// AddPullRequestReviewCommentsToPendingReview creates a tool to add several comments to the requester's latest
+// pending pull request review in one call.
+func AddPullRequestReviewCommentsToPendingReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("add_pull_request_review_comments_to_pending_review",
+			mcp.WithDescription(t("TOOL_ADD_PULL_REQUEST_REVIEW_COMMENTS_TO_PENDING_REVIEW_DESCRIPTION", fmt.Sprintf("Add up to %d inline comments at once to the requester's latest pending pull request review, a pending review needs to already exist to call this (check with the user if not sure). All comments are validated before any is added; comments GitHub refuses, e.g. on lines outside of the diff, are reported without stopping the others.", maxPendingReviewComments))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_PULL_REQUEST_REVIEW_COMMENTS_TO_PENDING_REVIEW_USER_TITLE", "Add comments to the requester's latest pending pull request review"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("comments",
+				mcp.Required(),
+				mcp.Description("The review comments to add"),
+				mcp.MinItems(1),
+				mcp.MaxItems(maxPendingReviewComments),
+				mcp.Items(
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path", "body"},
+						"properties": map[string]any{
+							"path": map[string]any{
+								"type":        "string",
+								"description": "The relative path to the file that necessitates a comment",
+							},
+							"body": map[string]any{
+								"type":        "string",
+								"description": "The text of the review comment",
+							},
+							"subjectType": map[string]any{
+								"type":        "string",
+								"description": "The level at which the comment is targeted, defaults to LINE",
+								"enum":        []string{"FILE", "LINE"},
+							},
+							"line": map[string]any{
+								"type":        "number",
+								"description": "The line of the blob in the pull request diff that the comment applies to, required for LINE comments. For multi-line comments, the last line of the range",
+							},
+							"side": map[string]any{
+								"type":        "string",
+								"description": "The side of the diff to comment on. LEFT indicates the previous state, RIGHT indicates the new state",
+								"enum":        []string{"LEFT", "RIGHT"},
+							},
+							"startLine": map[string]any{
+								"type":        "number",
+								"description": "For multi-line comments, the first line of the range that the comment applies to",
+							},
+							"startSide": map[string]any{
+								"type":        "string",
+								"description": "For multi-line comments, the starting side of the diff that the comment applies to",
+								"enum":        []string{"LEFT", "RIGHT"},
+							},
+						},
+					}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+				Comments   []pendingReviewComment
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(params.Comments) == 0 {
+				return mcp.NewToolResultError("at least one comment is required"), nil
+			}
+			if len(params.Comments) > maxPendingReviewComments {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d comments can be added at once, got %d", maxPendingReviewComments, len(params.Comments))), nil
+			}
+			for i := range params.Comments {
+				if err := params.Comments[i].validate(); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("comments[%d]: %s", i, err)), nil
+				}
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var getViewerQuery struct {
+				Viewer struct {
+					Login githubv4.String
+				}
+			}
+			if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var getLatestReviewForViewerQuery struct {
+				Repository struct {
+					PullRequest struct {
+						Reviews struct {
+							Nodes []struct {
+								ID    githubv4.ID
+								State githubv4.PullRequestReviewState
+								URL   githubv4.URI
+							}
+						} `graphql:"reviews(first: 1, author: $author)"`
+					} `graphql:"pullRequest(number: $prNum)"`
+				} `graphql:"repository(owner: $owner, name: $name)"`
+			}
+			vars := map[string]any{
+				"author": githubv4.String(getViewerQuery.Viewer.Login),
+				"owner":  githubv4.String(params.Owner),
+				"name":   githubv4.String(params.Repo),
+				"prNum":  githubv4.Int(params.PullNumber),
+			}
+			if err := client.Query(ctx, &getLatestReviewForViewerQuery, vars); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
+				return mcp.NewToolResultError("No pending review found for the viewer"), nil
+			}
+			review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
+			if review.State != githubv4.PullRequestReviewStatePending {
+				return mcp.NewToolResultError(fmt.Sprintf("The latest review, found at %s is not pending", review.URL)), nil
+			}
+
+			// Each comment is its own thread, added one after the other so that a comment GitHub refuses
+			// doesn't lose the rest of the review.
+			result := pendingReviewComments{}
+			for i, comment := range params.Comments {
+				var addPullRequestReviewThreadMutation struct {
+					AddPullRequestReviewThread struct {
+						Thread struct {
+							ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+						}
+					} `graphql:"addPullRequestReviewThread(input: $input)"`
+				}
+				if err := client.Mutate(
+					ctx,
+					&addPullRequestReviewThreadMutation,
+					githubv4.AddPullRequestReviewThreadInput{
+						Path:                githubv4.String(comment.Path),
+						Body:                githubv4.String(comment.Body),
+						SubjectType:         newGQLStringlikePtr[githubv4.PullRequestReviewThreadSubjectType](&comment.SubjectType),
+						Line:                newGQLIntPtr(comment.Line),
+						Side:                newGQLStringlikePtr[githubv4.DiffSide](comment.Side),
+						StartLine:           newGQLIntPtr(comment.StartLine),
+						StartSide:           newGQLStringlikePtr[githubv4.DiffSide](comment.StartSide),
+						PullRequestReviewID: &review.ID,
+					},
+					nil,
+				); err != nil {
+					result.Failed = append(result.Failed, failedReviewComment{Index: i, Path: comment.Path, Line: comment.Line, Error: err.Error()})
+					continue
+				}
+				result.Added++
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			if result.Added == 0 {
+				return mcp.NewToolResultError(string(r)), nil
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // SubmitPendingPullRequestReview creates a tool to submit a pull request review.
 func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("submit_pending_pull_request_review",
@@ -1449,7 +1693,7 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 
 func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_diff",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request.")),
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the unified diff of a pull request. Generated and vendored files, such as lock files, are left out by default, and the files can be narrowed down with path patterns. Diffs larger than max_bytes are cut between files, with a cursor to read the rest.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_DIFF_USER_TITLE", "Get pull request diff"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -1466,16 +1710,50 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithArray("paths",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Only include the files matching one of these patterns, with the syntax of .gitignore, e.g. *.go or pkg/api/**"),
+			),
+			mcp.WithBoolean("exclude_generated",
+				mcp.Description("Leave out generated and vendored files, such as lock files, minified files and files marked as generated, defaults to true"),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("Size at which the diff is cut, defaults to %d. A token is about 4 bytes", defaultDiffMaxBytes)),
+				mcp.Min(minDiffMaxBytes),
+				mcp.Max(maxDiffMaxBytes),
+			),
+			mcp.WithString("cursor",
+				mcp.Description("Cursor given by a previous call whose diff was cut, to read the rest"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			var params struct {
-				Owner      string
-				Repo       string
-				PullNumber int32
-			}
-			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+			params, err := parseGetPullRequestDiffParams(request)
+			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			if _, ok := request.GetArguments()["exclude_generated"]; !ok {
+				params.ExcludeGenerated = true
+			}
+			switch {
+			case params.MaxBytes == 0:
+				params.MaxBytes = defaultDiffMaxBytes
+			case params.MaxBytes < minDiffMaxBytes || params.MaxBytes > maxDiffMaxBytes:
+				return mcp.NewToolResultError(fmt.Sprintf("max_bytes must be between %d and %d", minDiffMaxBytes, maxDiffMaxBytes)), nil
+			}
+			patterns := make([]*regexp.Regexp, 0, len(params.Paths))
+			for _, p := range params.Paths {
+				re, err := codeownersPatternToRegexp(p)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid path pattern %q: %v", p, err)), nil
+				}
+				patterns = append(patterns, re)
+			}
+			var cursor diffCursor
+			if params.Cursor != "" {
+				if cursor, err = parseDiffCursor(params.Cursor); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -1486,7 +1764,7 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				ctx,
 				params.Owner,
 				params.Repo,
-				int(params.PullNumber),
+				params.PullNumber,
 				github.RawOptions{Type: github.Diff},
 			)
 			if err != nil {
@@ -1503,8 +1781,45 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 
 			defer func() { _ = resp.Body.Close() }()
 
-			// Return the raw response
-			return mcp.NewToolResultText(string(raw)), nil
+			hash := diffHash(raw)
+			if params.Cursor != "" && cursor.Hash != hash {
+				return mcp.NewToolResultError("the diff of the pull request changed since the cursor was given, call again without a cursor"), nil
+			}
+
+			var selected []diffFile
+			var excluded []string
+			for _, file := range splitDiffFiles(raw) {
+				if params.ExcludeGenerated && isGeneratedDiffFile(file) {
+					excluded = append(excluded, file.Path)
+					continue
+				}
+				if len(patterns) > 0 && !slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool { return re.MatchString(file.Path) }) {
+					continue
+				}
+				selected = append(selected, file)
+			}
+			if cursor.File > len(selected) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid cursor %q, the diff has %d files", params.Cursor, len(selected))), nil
+			}
+
+			page := pageDiffFiles(selected, cursor.File, params.MaxBytes)
+			var notes []string
+			// Only the first page lists the files left out, the following ones would repeat it
+			if len(excluded) > 0 && cursor.File == 0 {
+				notes = append(notes, fmt.Sprintf("[%d generated or vendored files were left out, pass exclude_generated false to include them: %s]", len(excluded), strings.Join(excluded, ", ")))
+			}
+			if page.Cut != "" {
+				notes = append(notes, fmt.Sprintf("[The diff of %s is longer than max_bytes and was cut, narrow paths down to it and raise max_bytes to read it whole.]", page.Cut))
+			}
+			if page.Next > 0 {
+				notes = append(notes, fmt.Sprintf("[The diff was cut after %d of %d files to stay within max_bytes. Call get_pull_request_diff again with cursor %q to read the rest.]", page.Next, len(selected), diffCursor{Hash: hash, File: page.Next}))
+			}
+			if len(notes) == 0 {
+				return mcp.NewToolResultText(page.Text), nil
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{mcp.NewTextContent(page.Text), mcp.NewTextContent(strings.Join(notes, "\n"))},
+			}, nil
 		}
 }
 
@@ -1532,17 +1847,7 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			pullNumber, err := RequiredInt(request, "pullNumber")
+			params, err := parseRequestCopilotReviewParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -1554,9 +1859,9 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 
 			_, resp, err := client.PullRequests.RequestReviewers(
 				ctx,
-				owner,
-				repo,
-				pullNumber,
+				params.Owner,
+				params.Repo,
+				params.PullNumber,
 				github.ReviewersRequest{
 					// The login name of the copilot reviewer bot
 					Reviewers: []string{"copilot-pull-request-reviewer[bot]"},
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/shurcooL/githubv4"
 
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -552,6 +554,95 @@ func Test_MergePullRequest(t *testing.T) {
 	}
 }
 
+func Test_MergePullRequest_MergeQueue(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+			mockResponse(t, http.StatusMethodNotAllowed, `{"message": "Changes must be made through the merge queue"}`),
+		),
+	))
+	_, handler := MergePullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "use enqueue_pull_request")
+}
+
+func Test_MergePullRequest_ExpectedSHA(t *testing.T) {
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Head:   &github.PullRequestBranch{SHA: github.Ptr("abc123")},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectedSHA        string
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "head matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.PullRequestMergeResult{Merged: github.Ptr(true)}),
+					),
+				),
+			),
+			expectedSHA: "abc123",
+		},
+		{
+			name: "head moved",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+			),
+			expectedSHA:        "def456",
+			expectToolError:    true,
+			expectedToolErrMsg: "conflict: pull request #42 has changed since it was read (expected def456, found abc123)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			tool, handler := MergePullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+			assert.Contains(t, tool.InputSchema.Properties, "expected_sha")
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"expected_sha": tc.expectedSHA,
+			}))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+		})
+	}
+}
+
 func Test_GetPullRequestFiles(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -832,7 +923,7 @@ func Test_GetPullRequestStatus(t *testing.T) {
 func Test_UpdatePullRequestBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 
 	assert.Equal(t, "update_pull_request_branch", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -920,7 +1011,7 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -945,6 +1036,120 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 	}
 }
 
+func Test_UpdatePullRequestBranch_WaitAndRebase(t *testing.T) {
+	pullRequest := func(sha string, mergeable *bool, state string) *github.PullRequest {
+		return &github.PullRequest{
+			Number:         github.Ptr(42),
+			Head:           &github.PullRequestBranch{SHA: github.Ptr(sha)},
+			Mergeable:      mergeable,
+			MergeableState: github.Ptr(state),
+		}
+	}
+	updateBranch := mock.WithRequestMatchHandler(
+		mock.PutReposPullsUpdateBranchByOwnerByRepoByPullNumber,
+		mockResponse(t, http.StatusAccepted, &github.PullRequestBranchUpdateResponse{Message: github.Ptr("Updating pull request branch.")}),
+	)
+
+	call := func(t *testing.T, client *github.Client, gqlClient *githubv4.Client, args map[string]any) pullRequestBranchUpdate {
+		_, handler := UpdatePullRequestBranch(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		var update pullRequestBranchUpdate
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &update))
+		return update
+	}
+
+	t.Run("waits for the mergeable state of the updated head", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposPullsByOwnerByRepoByPullNumber,
+				pullRequest("old", github.Ptr(false), "behind"),
+				pullRequest("new", github.Ptr(true), "clean"),
+			),
+			updateBranch,
+		))
+		update := call(t, client, githubv4.NewClient(nil), map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"pullNumber":   float64(42),
+			"wait_seconds": float64(30),
+		})
+		assert.Equal(t, pullRequestBranchUpdate{
+			Message:        "Pull request branch update is in progress",
+			UpdateMethod:   "merge",
+			HeadSHA:        "new",
+			Mergeable:      github.Ptr(true),
+			MergeableState: "clean",
+		}, update)
+	})
+
+	t.Run("times out when the update does not land", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposPullsByOwnerByRepoByPullNumber,
+				pullRequest("old", github.Ptr(false), "behind"),
+				pullRequest("old", github.Ptr(false), "behind"),
+				pullRequest("old", nil, "unknown"),
+			),
+			updateBranch,
+		))
+		update := call(t, client, githubv4.NewClient(nil), map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"pullNumber":   float64(42),
+			"wait_seconds": float64(1),
+		})
+		assert.True(t, update.TimedOut)
+		assert.Equal(t, "unknown", update.MergeableState)
+	})
+
+	t.Run("rebases through graphql", func(t *testing.T) {
+		rebase := githubv4.PullRequestBranchUpdateMethodRebase
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			pullRequestNodeIDQuery(),
+			githubv4mock.NewMutationMatcher(
+				struct {
+					UpdatePullRequestBranch struct {
+						PullRequest struct {
+							HeadRefOid githubv4.GitObjectID
+							URL        githubv4.URI
+						}
+					} `graphql:"updatePullRequestBranch(input: $input)"`
+				}{},
+				githubv4.UpdatePullRequestBranchInput{
+					PullRequestID:   githubv4.ID("PR_42"),
+					UpdateMethod:    &rebase,
+					ExpectedHeadOid: githubv4.NewGitObjectID("abc"),
+				},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"updatePullRequestBranch": map[string]any{
+						"pullRequest": map[string]any{
+							"headRefOid": "def",
+							"url":        "https://github.com/owner/repo/pull/42",
+						},
+					},
+				}),
+			),
+		))
+		update := call(t, github.NewClient(nil), gqlClient, map[string]any{
+			"owner":           "owner",
+			"repo":            "repo",
+			"pullNumber":      float64(42),
+			"expectedHeadSha": "abc",
+			"update_method":   "rebase",
+		})
+		assert.Equal(t, pullRequestBranchUpdate{
+			Message:      "Pull request branch was rebased on the base branch",
+			URL:          "https://github.com/owner/repo/pull/42",
+			UpdateMethod: "rebase",
+			HeadSHA:      "def",
+		}, update)
+	})
+}
+
 func Test_GetPullRequestComments(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1949,6 +2154,140 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 	}
 }
 
+func TestAddPullRequestReviewCommentsToPendingReview(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := AddPullRequestReviewCommentsToPendingReview(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_pull_request_review_comments_to_pending_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "comments")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "comments"})
+
+	pendingReview := func(state string) githubv4mock.Matcher {
+		return getLatestPendingReviewQuery(getLatestPendingReviewQueryParams{
+			author: "williammartin",
+			owner:  "owner",
+			repo:   "repo",
+			prNum:  42,
+
+			reviews: []getLatestPendingReviewQueryReview{
+				{
+					id:    "PR_kwDODKw3uc6WYN1T",
+					state: state,
+					url:   "https://github.com/owner/repo/pull/42",
+				},
+			},
+		})
+	}
+	// The mock only accepts the thread on file.go, any other thread is refused
+	addThread := githubv4mock.NewMutationMatcher(
+		struct {
+			AddPullRequestReviewThread struct {
+				Thread struct {
+					ID githubv4.String
+				}
+			} `graphql:"addPullRequestReviewThread(input: $input)"`
+		}{},
+		githubv4.AddPullRequestReviewThreadInput{
+			Path:                githubv4.String("file.go"),
+			Body:                githubv4.String("Handle the error"),
+			SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeLine),
+			Line:                githubv4.NewInt(10),
+			Side:                githubv4mock.Ptr(githubv4.DiffSideRight),
+			StartLine:           githubv4.NewInt(5),
+			StartSide:           githubv4mock.Ptr(githubv4.DiffSideRight),
+			PullRequestReviewID: githubv4.NewID("PR_kwDODKw3uc6WYN1T"),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{}),
+	)
+	comment := map[string]any{
+		"path":      "file.go",
+		"body":      "Handle the error",
+		"line":      float64(10),
+		"side":      "RIGHT",
+		"startLine": float64(5),
+		"startSide": "RIGHT",
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		comments           []any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResult     pendingReviewComments
+	}{
+		{
+			name:         "comments refused by GitHub are reported",
+			mockedClient: githubv4mock.NewMockedHTTPClient(viewerQuery("williammartin"), pendingReview("PENDING"), addThread),
+			comments: []any{
+				comment,
+				map[string]any{"path": "README.md", "body": "Document the flag", "subjectType": "FILE"},
+			},
+			expectedResult: pendingReviewComments{
+				Added:  1,
+				Failed: []failedReviewComment{{Index: 1, Path: "README.md", Error: "non-200 OK status code: 400 Bad Request body: \"variable does not match\\n\""}},
+			},
+		},
+		{
+			name:               "invalid comments are rejected before any is added",
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			comments:           []any{comment, map[string]any{"path": "file.go", "body": "Rename this"}},
+			expectToolError:    true,
+			expectedToolErrMsg: "comments[1]: line is required for LINE comments",
+		},
+		{
+			name:               "latest review is not pending",
+			mockedClient:       githubv4mock.NewMockedHTTPClient(viewerQuery("williammartin"), pendingReview("COMMENTED")),
+			comments:           []any{comment},
+			expectToolError:    true,
+			expectedToolErrMsg: "The latest review, found at https://github.com/owner/repo/pull/42 is not pending",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := AddPullRequestReviewCommentsToPendingReview(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"comments":   tc.comments,
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var returned pendingReviewComments
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
 func TestSubmitPendingPullRequestReview(t *testing.T) {
 	t.Parallel()
 
@@ -2220,6 +2559,88 @@ index 5d6e7b2..8a4f5c3 100644
 	}
 }
 
+func TestGetPullRequestDiffFiltering(t *testing.T) {
+	goFile := "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-package old\n+package main\n"
+	lockFile := "diff --git a/go.sum b/go.sum\n--- a/go.sum\n+++ b/go.sum\n@@ -1 +1 @@\n-a v1 h1:x\n+a v2 h1:y\n"
+	generatedFile := "diff --git a/api/api.gen.go b/api/api.gen.go\nnew file mode 100644\n--- /dev/null\n+++ b/api/api.gen.go\n@@ -0,0 +1,2 @@\n+// Code generated by oapi-codegen. DO NOT EDIT.\n+package api\n"
+	docFile := "diff --git a/docs/guide.md b/docs/guide.md\n--- a/docs/guide.md\n+++ b/docs/guide.md\n@@ -1 +1 @@\n-Old\n+" + strings.Repeat("x", 1500) + "\n"
+	diff := goFile + lockFile + generatedFile + docFile
+
+	call := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsByOwnerByRepoByPullNumber,
+				mockResponse(t, http.StatusOK, diff),
+			),
+		))
+		_, handler := GetPullRequestDiff(stubGetClientFn(client), translations.NullTranslationHelper)
+		args["owner"] = "owner"
+		args["repo"] = "repo"
+		args["pullNumber"] = float64(42)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		return result
+	}
+	texts := func(result *mcp.CallToolResult) []string {
+		var texts []string
+		for _, content := range result.Content {
+			texts = append(texts, content.(mcp.TextContent).Text)
+		}
+		return texts
+	}
+
+	t.Run("generated files are left out", func(t *testing.T) {
+		result := call(t, map[string]any{"max_bytes": float64(maxDiffMaxBytes)})
+		require.False(t, result.IsError)
+		got := texts(result)
+		require.Len(t, got, 2)
+		assert.Equal(t, goFile+docFile, got[0])
+		assert.Contains(t, got[1], "2 generated or vendored files were left out")
+		assert.Contains(t, got[1], "go.sum, api/api.gen.go")
+	})
+
+	t.Run("generated files can be included", func(t *testing.T) {
+		result := call(t, map[string]any{"exclude_generated": false, "max_bytes": float64(maxDiffMaxBytes)})
+		require.False(t, result.IsError)
+		assert.Equal(t, []string{diff}, texts(result))
+	})
+
+	t.Run("files are filtered by path", func(t *testing.T) {
+		result := call(t, map[string]any{"paths": []any{"*.go"}})
+		require.False(t, result.IsError)
+		got := texts(result)
+		require.Len(t, got, 2)
+		assert.Equal(t, goFile, got[0])
+	})
+
+	t.Run("large diffs are cut between files and continued", func(t *testing.T) {
+		result := call(t, map[string]any{"max_bytes": float64(minDiffMaxBytes)})
+		require.False(t, result.IsError)
+		got := texts(result)
+		require.Len(t, got, 2)
+		assert.Equal(t, goFile, got[0])
+		assert.Contains(t, got[1], "cut after 1 of 2 files")
+		cursor := diffCursor{Hash: diffHash(diff), File: 1}.String()
+		assert.Contains(t, got[1], cursor)
+
+		// The last file is longer than max_bytes on its own
+		result = call(t, map[string]any{"max_bytes": float64(minDiffMaxBytes), "cursor": cursor})
+		require.False(t, result.IsError)
+		got = texts(result)
+		require.Len(t, got, 2)
+		assert.True(t, strings.HasPrefix(docFile, got[0]))
+		assert.Less(t, len(got[0]), len(docFile))
+		assert.Contains(t, got[1], "The diff of docs/guide.md is longer than max_bytes")
+		assert.NotContains(t, got[1], "cursor")
+	})
+
+	t.Run("cursor of a changed diff", func(t *testing.T) {
+		result := call(t, map[string]any{"cursor": "0123456789abcdef:1"})
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "changed since the cursor was given")
+	})
+}
+
 func viewerQuery(login string) githubv4mock.Matcher {
 	return githubv4mock.NewQueryMatcher(
 		struct {
@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The push protection bypass request API is not covered by go-github, so its requests are built by hand and its
+// responses decoded into the types below.
+
+// bypassActor is the user requesting or reviewing a push protection bypass.
+type bypassActor struct {
+	ActorID   int64  `json:"actor_id"`
+	ActorName string `json:"actor_name"`
+}
+
+// bypassRequestData is a secret blocked by push protection, for which a bypass is requested.
+type bypassRequestData struct {
+	SecretType   string `json:"secret_type"`
+	BypassReason string `json:"bypass_reason"`
+	Path         string `json:"path,omitempty"`
+	Branch       string `json:"branch,omitempty"`
+}
+
+// bypassResponse is a review of a push protection bypass request.
+type bypassResponse struct {
+	ID        int64             `json:"id"`
+	Reviewer  *bypassActor      `json:"reviewer,omitempty"`
+	Status    string            `json:"status"`
+	CreatedAt *github.Timestamp `json:"created_at,omitempty"`
+}
+
+// bypassRequest is a request to push secrets blocked by push protection.
+type bypassRequest struct {
+	ID                 int64                `json:"id"`
+	Number             int                  `json:"number"`
+	Repository         *github.Repository   `json:"repository,omitempty"`
+	Organization       *github.Organization `json:"organization,omitempty"`
+	Requester          *bypassActor         `json:"requester,omitempty"`
+	RequesterComment   string               `json:"requester_comment,omitempty"`
+	ExpiresAt          *github.Timestamp    `json:"expires_at,omitempty"`
+	Data               []bypassRequestData  `json:"data,omitempty"`
+	ResourceIdentifier string               `json:"resource_identifier,omitempty"`
+	Status             string               `json:"status"`
+	Responses          []bypassResponse     `json:"responses,omitempty"`
+	HTMLURL            string               `json:"html_url,omitempty"`
+	CreatedAt          *github.Timestamp    `json:"created_at,omitempty"`
+}
+
+// ListPushProtectionBypassRequests creates a tool to list the push protection bypass requests of a repository or an
+// organization.
+func ListPushProtectionBypassRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_push_protection_bypass_requests",
+			mcp.WithDescription(t("TOOL_LIST_PUSH_PROTECTION_BYPASS_REQUESTS_DESCRIPTION", "List the requests to bypass secret scanning push protection in a repository, or in all repositories of an organization when no repository is given. By default only the requests waiting for a review are listed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PUSH_PROTECTION_BYPASS_REQUESTS_USER_TITLE", "List push protection bypass requests"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, or the organization when repo is not given"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Status of the requests to list, defaults to open"),
+				mcp.Enum("open", "completed", "cancelled", "expired", "denied", "all"),
+			),
+			mcp.WithString("time_period",
+				mcp.Description("Only list the requests created within this period"),
+				mcp.Enum("hour", "day", "week", "month"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListPushProtectionBypassRequestsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status := params.Status
+			if status == "" {
+				status = "open"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("orgs/%s/bypass-requests/secret-scanning", params.Owner)
+			if params.Repo != "" {
+				u = fmt.Sprintf("repos/%s/%s/bypass-requests/secret-scanning", params.Owner, params.Repo)
+			}
+			query := url.Values{}
+			query.Set("request_status", status)
+			if params.TimePeriod != "" {
+				query.Set("time_period", params.TimePeriod)
+			}
+			query.Set("page", strconv.Itoa(params.Page))
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+
+			req, err := client.NewRequest("GET", u+"?"+query.Encode(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var requests []*bypassRequest
+			resp, err := client.Do(ctx, req, &requests)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list bypass requests: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(requests)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReviewPushProtectionBypassRequest creates a tool to approve or deny a push protection bypass request.
+func ReviewPushProtectionBypassRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_push_protection_bypass_request",
+			mcp.WithDescription(t("TOOL_REVIEW_PUSH_PROTECTION_BYPASS_REQUEST_DESCRIPTION", "Approve or deny a request to bypass secret scanning push protection. Once approved, the requester can push the commits containing the secrets.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_PUSH_PROTECTION_BYPASS_REQUEST_USER_TITLE", "Review push protection bypass request"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("bypass_request_number",
+				mcp.Required(),
+				mcp.Description("Number of the bypass request"),
+			),
+			mcp.WithString("status",
+				mcp.Required(),
+				mcp.Description("Review of the request"),
+				mcp.Enum("approve", "deny"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Message explaining the review to the requester"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseReviewPushProtectionBypassRequestParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Status != "approve" && params.Status != "deny" {
+				return mcp.NewToolResultError("status must be approve or deny"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/bypass-responses/secret-scanning/%d", params.Owner, params.Repo, params.BypassRequestNumber)
+			body := map[string]string{"status": params.Status}
+			if params.Message != "" {
+				body["message"] = params.Message
+			}
+			req, err := client.NewRequest("POST", u, body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var review json.RawMessage
+			resp, err := client.Do(ctx, req, &review)
+			if err != nil {
+				return nil, fmt.Errorf("failed to review bypass request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(string(review)), nil
+		}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPushProtectionBypassRequests(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPushProtectionBypassRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_push_protection_bypass_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "time_period")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	requests := []*bypassRequest{
+		{
+			ID:               11,
+			Number:           3,
+			Repository:       &github.Repository{FullName: github.Ptr("octo-org/api")},
+			Requester:        &bypassActor{ActorID: 7, ActorName: "octocat"},
+			RequesterComment: "Test fixture, not a real key",
+			Data:             []bypassRequestData{{SecretType: "aws_access_key_id", BypassReason: "used_in_tests", Path: "testdata/creds.txt", Branch: "refs/heads/main"}},
+			Status:           "pending",
+			HTMLURL:          "https://github.com/octo-org/api/exemptions/3",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		expectedPath  string
+		expectedQuery map[string]string
+	}{
+		{
+			name:          "repository requests waiting for a review",
+			args:          map[string]any{"owner": "octo-org", "repo": "api"},
+			expectedPath:  "/repos/octo-org/api/bypass-requests/secret-scanning",
+			expectedQuery: map[string]string{"request_status": "open", "page": "1", "per_page": "30"},
+		},
+		{
+			name:          "organization requests",
+			args:          map[string]any{"owner": "octo-org", "status": "denied", "time_period": "week", "perPage": float64(50)},
+			expectedPath:  "/orgs/octo-org/bypass-requests/secret-scanning",
+			expectedQuery: map[string]string{"request_status": "denied", "time_period": "week", "page": "1", "per_page": "50"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: tc.expectedPath, Method: http.MethodGet},
+					expectQueryParams(t, tc.expectedQuery).andThen(mockResponse(t, http.StatusOK, requests)),
+				),
+			)
+			_, handler := ListPushProtectionBypassRequests(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned []*bypassRequest
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, requests, returned)
+		})
+	}
+}
+
+func Test_ReviewPushProtectionBypassRequest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPushProtectionBypassRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_push_protection_bypass_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "bypass_request_number")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "bypass_request_number", "status"})
+
+	t.Run("approves a request", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.EndpointPattern{Pattern: "/repos/octo-org/api/bypass-responses/secret-scanning/3", Method: http.MethodPost},
+				expectRequestBody(t, map[string]any{
+					"status":  "approve",
+					"message": "Test fixture",
+				}).andThen(mockResponse(t, http.StatusOK, `{"bypass_review_id":21,"status":"approved"}`)),
+			),
+		)
+		_, handler := ReviewPushProtectionBypassRequest(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":                 "octo-org",
+			"repo":                  "api",
+			"bypass_request_number": float64(3),
+			"status":                "approve",
+			"message":               "Test fixture",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.JSONEq(t, `{"bypass_review_id":21,"status":"approved"}`, textContent.Text)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, handler := ReviewPushProtectionBypassRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":                 "octo-org",
+			"repo":                  "api",
+			"bypass_request_number": float64(3),
+			"status":                "approved",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "status must be approve or deny", getTextResult(t, result).Text)
+	})
+}
@@ -0,0 +1,286 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// reactionContents are the reactions GitHub supports, as named by the API.
+var reactionContents = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// reactionSubjectTypes are the kinds of items the reaction tools work on.
+var reactionSubjectTypes = []string{"issue", "issue_comment", "pull_request_review_comment", "commit_comment"}
+
+// maxReactionPages bounds the pages of 100 reactions list_reactions reads.
+const maxReactionPages = 10
+
+// reactionSubject is the issue, pull request or comment reactions are listed, added or deleted on. Issues and pull
+// requests are identified by number, comments by ID.
+type reactionSubject struct {
+	owner, repo string
+	kind        string
+	number      int
+	commentID   int64
+}
+
+// newReactionSubject validates that the identifier the kind of subject needs is given.
+func newReactionSubject(owner, repo, kind string, number, commentID int) (reactionSubject, error) {
+	subject := reactionSubject{owner: owner, repo: repo, kind: kind, number: number, commentID: int64(commentID)}
+	switch kind {
+	case "issue":
+		if number <= 0 {
+			return subject, fmt.Errorf("issue_number is required for reactions on an issue or pull request")
+		}
+	case "issue_comment", "pull_request_review_comment", "commit_comment":
+		if commentID <= 0 {
+			return subject, fmt.Errorf("comment_id is required for subject_type %s", kind)
+		}
+	default:
+		return subject, fmt.Errorf("subject_type must be one of %v, not %q", reactionSubjectTypes, kind)
+	}
+	return subject, nil
+}
+
+func (s reactionSubject) list(ctx context.Context, client *github.Client, opts *github.ListOptions) ([]*github.Reaction, *github.Response, error) {
+	switch s.kind {
+	case "issue":
+		return client.Reactions.ListIssueReactions(ctx, s.owner, s.repo, s.number, opts)
+	case "issue_comment":
+		return client.Reactions.ListIssueCommentReactions(ctx, s.owner, s.repo, s.commentID, opts)
+	case "pull_request_review_comment":
+		return client.Reactions.ListPullRequestCommentReactions(ctx, s.owner, s.repo, s.commentID, opts)
+	default:
+		return client.Reactions.ListCommentReactions(ctx, s.owner, s.repo, s.commentID, &github.ListCommentReactionOptions{ListOptions: *opts})
+	}
+}
+
+func (s reactionSubject) create(ctx context.Context, client *github.Client, content string) (*github.Reaction, *github.Response, error) {
+	switch s.kind {
+	case "issue":
+		return client.Reactions.CreateIssueReaction(ctx, s.owner, s.repo, s.number, content)
+	case "issue_comment":
+		return client.Reactions.CreateIssueCommentReaction(ctx, s.owner, s.repo, s.commentID, content)
+	case "pull_request_review_comment":
+		return client.Reactions.CreatePullRequestCommentReaction(ctx, s.owner, s.repo, s.commentID, content)
+	default:
+		return client.Reactions.CreateCommentReaction(ctx, s.owner, s.repo, s.commentID, content)
+	}
+}
+
+func (s reactionSubject) delete(ctx context.Context, client *github.Client, reactionID int64) (*github.Response, error) {
+	switch s.kind {
+	case "issue":
+		return client.Reactions.DeleteIssueReaction(ctx, s.owner, s.repo, s.number, reactionID)
+	case "issue_comment":
+		return client.Reactions.DeleteIssueCommentReaction(ctx, s.owner, s.repo, s.commentID, reactionID)
+	case "pull_request_review_comment":
+		return client.Reactions.DeletePullRequestCommentReaction(ctx, s.owner, s.repo, s.commentID, reactionID)
+	default:
+		return client.Reactions.DeleteCommentReaction(ctx, s.owner, s.repo, s.commentID, reactionID)
+	}
+}
+
+// withReactionSubject adds the parameters identifying a reactionSubject to a tool.
+func withReactionSubject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithString("subject_type",
+			mcp.Required(),
+			mcp.Description("Kind of item the reactions are on. Use issue for pull requests too"),
+			mcp.Enum(reactionSubjectTypes...),
+		)(tool)
+		mcp.WithNumber("issue_number",
+			mcp.Description("Number of the issue or pull request, for subject_type issue"),
+		)(tool)
+		mcp.WithNumber("comment_id",
+			mcp.Description("ID of the comment, for the comment subject types"),
+		)(tool)
+	}
+}
+
+// reactionEntry is a reaction in the result of list_reactions.
+type reactionEntry struct {
+	ID      int64  `json:"id"`
+	Content string `json:"content"`
+	User    string `json:"user"`
+}
+
+// reactionsList is the result of list_reactions.
+type reactionsList struct {
+	Total     int             `json:"total"`
+	Counts    map[string]int  `json:"counts"`
+	Reactions []reactionEntry `json:"reactions"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// ListReactions creates a tool to list the reactions on an issue, pull request or comment.
+func ListReactions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_reactions",
+			mcp.WithDescription(t("TOOL_LIST_REACTIONS_DESCRIPTION", "List the reactions on an issue, pull request, issue comment, pull request review comment or commit comment, with their count by content, e.g. to gauge the sentiment of the community or check whether a comment was already acknowledged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REACTIONS_USER_TITLE", "List reactions"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Description("Only list reactions of this content"),
+				mcp.Enum(reactionContents...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListReactionsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subject, err := newReactionSubject(params.Owner, params.Repo, params.SubjectType, params.IssueNumber, params.CommentID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := reactionsList{Counts: map[string]int{}, Reactions: []reactionEntry{}}
+			opts := &github.ListOptions{PerPage: 100}
+			for pages := 1; ; pages++ {
+				reactions, resp, err := subject.list(ctx, client, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list reactions: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, reaction := range reactions {
+					if params.Content != "" && reaction.GetContent() != params.Content {
+						continue
+					}
+					result.Total++
+					result.Counts[reaction.GetContent()]++
+					result.Reactions = append(result.Reactions, reactionEntry{
+						ID:      reaction.GetID(),
+						Content: reaction.GetContent(),
+						User:    reaction.GetUser().GetLogin(),
+					})
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if pages == maxReactionPages {
+					result.Truncated = true
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// addedReaction is the result of add_reaction.
+type addedReaction struct {
+	*github.Reaction
+	// Created is false when the authenticated user had already reacted with the same content.
+	Created bool `json:"created"`
+}
+
+// AddReaction creates a tool to react to an issue, pull request or comment.
+func AddReaction(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_reaction",
+			mcp.WithDescription(t("TOOL_ADD_REACTION_DESCRIPTION", "Add a reaction of the authenticated user to an issue, pull request, issue comment, pull request review comment or commit comment, e.g. +1 to acknowledge a comment. Adding a reaction the user already made returns the existing one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_REACTION_USER_TITLE", "Add reaction"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Reaction to add"),
+				mcp.Enum(reactionContents...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseAddReactionParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subject, err := newReactionSubject(params.Owner, params.Repo, params.SubjectType, params.IssueNumber, params.CommentID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reaction, resp, err := subject.create(ctx, client, params.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add reaction: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			r, err := json.Marshal(addedReaction{Reaction: reaction, Created: resp.StatusCode == http.StatusCreated})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteReaction creates a tool to delete a reaction from an issue, pull request or comment.
+func DeleteReaction(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_reaction",
+			mcp.WithDescription(t("TOOL_DELETE_REACTION_DESCRIPTION", "Delete a reaction from an issue, pull request, issue comment, pull request review comment or commit comment. Get the ID of the reaction with list_reactions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REACTION_USER_TITLE", "Delete reaction"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithNumber("reaction_id",
+				mcp.Required(),
+				mcp.Description("ID of the reaction to delete"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDeleteReactionParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subject, err := newReactionSubject(params.Owner, params.Repo, params.SubjectType, params.IssueNumber, params.CommentID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := subject.delete(ctx, client, int64(params.ReactionID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete reaction: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Deleted reaction %d", params.ReactionID)), nil
+		}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reaction(id int64, content, login string) *github.Reaction {
+	return &github.Reaction{ID: github.Ptr(id), Content: github.Ptr(content), User: &github.User{Login: github.Ptr(login)}}
+}
+
+func Test_ListReactions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListReactions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_reactions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "subject_type")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type"})
+
+	reactions := []*github.Reaction{
+		reaction(1, "+1", "alice"),
+		reaction(2, "+1", "bob"),
+		reaction(3, "heart", "alice"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedList   reactionsList
+	}{
+		{
+			name: "issue reactions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/reactions").andThen(
+						mockResponse(t, http.StatusOK, reactions),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "subject_type": "issue", "issue_number": float64(42)},
+			expectedList: reactionsList{
+				Total:  3,
+				Counts: map[string]int{"+1": 2, "heart": 1},
+				Reactions: []reactionEntry{
+					{ID: 1, Content: "+1", User: "alice"},
+					{ID: 2, Content: "+1", User: "bob"},
+					{ID: 3, Content: "heart", User: "alice"},
+				},
+			},
+		},
+		{
+			name: "commit comment reactions of a content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommentsReactionsByOwnerByRepoByCommentId,
+					expectPath(t, "/repos/owner/repo/comments/7/reactions").andThen(
+						mockResponse(t, http.StatusOK, reactions),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "subject_type": "commit_comment", "comment_id": float64(7), "content": "heart"},
+			expectedList: reactionsList{
+				Total:     1,
+				Counts:    map[string]int{"heart": 1},
+				Reactions: []reactionEntry{{ID: 3, Content: "heart", User: "alice"}},
+			},
+		},
+		{
+			name:           "missing comment ID",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "subject_type": "issue_comment", "issue_number": float64(42)},
+			expectError:    true,
+			expectedErrMsg: "comment_id is required for subject_type issue_comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListReactions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var list reactionsList
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &list))
+			assert.Equal(t, tc.expectedList, list)
+		})
+	}
+}
+
+func Test_AddReaction(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddReaction(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+
+	for _, tc := range []struct {
+		name    string
+		status  int
+		created bool
+	}{
+		{name: "new reaction", status: http.StatusCreated, created: true},
+		{name: "existing reaction", status: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					expectPath(t, "/repos/owner/repo/pulls/comments/9/reactions").andThen(
+						expectRequestBody(t, map[string]any{"content": "+1"}).andThen(
+							mockResponse(t, tc.status, reaction(5, "+1", "octocat")),
+						),
+					),
+				),
+			))
+			_, handler := AddReaction(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"comment_id":   float64(9),
+				"content":      "+1",
+			}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var added addedReaction
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &added))
+			assert.Equal(t, int64(5), added.GetID())
+			assert.Equal(t, tc.created, added.Created)
+		})
+	}
+}
+
+func Test_DeleteReaction(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteReaction(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "reaction_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposIssuesCommentsReactionsByOwnerByRepoByCommentIdByReactionId,
+			expectPath(t, "/repos/owner/repo/issues/comments/8/reactions/5").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := DeleteReaction(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"subject_type": "issue_comment",
+		"comment_id":   float64(8),
+		"reaction_id":  float64(5),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+	assert.Equal(t, "Deleted reaction 5", textContent.Text)
+}
@@ -0,0 +1,202 @@
+package github
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolRegistry is the extension point for programs embedding the server. It lets them add their own tools next to
+// the built-in toolsets and wrap every tool handler with middleware, using the GitHub clients they injected.
+type ToolRegistry interface {
+	// AddToolset adds a toolset, replacing any toolset with the same name. Like the built-in toolsets, it is only
+	// served once enabled.
+	AddToolset(ts *toolsets.Toolset)
+
+	// RegisterTool adds a tool to the named toolset, creating the toolset if it does not exist. Tools annotated
+	// with ReadOnlyHint are read tools, all others are write tools and are left out in read-only mode. In anonymous
+	// mode, only the built-in tools of public data are served.
+	RegisterTool(toolset string, tool server.ServerTool)
+
+	// Use wraps the handler of every tool with middleware, including tools registered afterwards. Middleware added
+	// later wraps middleware added earlier.
+	Use(middleware ...server.ToolHandlerMiddleware)
+
+	// Clients returns the functions the tools use to get the REST and GraphQL clients.
+	Clients() (GetClientFn, GetGQLClientFn)
+}
+
+// RegistryConfig configures a Registry.
+type RegistryConfig struct {
+	// GetClient and GetGQLClient provide the GitHub clients to the tools
+	GetClient    GetClientFn
+	GetGQLClient GetGQLClientFn
+
+	// GetAppClient provides a client authenticated as a GitHub App, to the tools managing its installations. The
+	// apps toolset is only available when it is set.
+	GetAppClient GetClientFn
+
+	// Translator provides translated text for the tools
+	Translator translations.TranslationHelperFunc
+
+	// ReadOnly leaves out all write tools, including those registered later
+	ReadOnly bool
+
+	// WebhookSecrets are the secrets of webhooks, by name, the webhooks toolset verifies deliveries with. The
+	// webhooks toolset is only available when some are set.
+	WebhookSecrets map[string]string
+
+	// ExportDir is the directory export_issues can write exports to. Exports are only returned in the result when
+	// it is empty.
+	ExportDir string
+
+	// ReviewChecklists are the checklists get_review_checklist maps the changed paths of pull requests to. The tool is
+	// only available when some are set.
+	ReviewChecklists []ReviewChecklist
+
+	// Anonymous serves only the read tools of public data that work without a token, including among the tools
+	// registered later. It implies ReadOnly.
+	Anonymous bool
+}
+
+// Registry is the ToolRegistry holding the built-in toolsets and the always enabled context toolset.
+type Registry struct {
+	cfg        RegistryConfig
+	toolsets   *toolsets.ToolsetGroup
+	context    *toolsets.Toolset
+	middleware []server.ToolHandlerMiddleware
+}
+
+var _ ToolRegistry = (*Registry)(nil)
+
+// NewRegistry creates a registry with the built-in toolsets, all disabled until enabled with EnableToolsets.
+func NewRegistry(cfg RegistryConfig) *Registry {
+	if cfg.Translator == nil {
+		cfg.Translator = translations.NullTranslationHelper
+	}
+	if cfg.Anonymous {
+		cfg.ReadOnly = true
+	}
+	r := &Registry{
+		cfg:      cfg,
+		toolsets: DefaultToolsetGroup(cfg.ReadOnly, cfg.GetClient, cfg.GetGQLClient, cfg.Translator),
+		context:  InitContextToolset(cfg.GetClient, cfg.Translator),
+	}
+	r.toolsets.Toolsets["issues"].AddReadTools(toolsets.NewServerTool(ExportIssues(cfg.GetClient, cfg.ExportDir, cfg.Translator)))
+	if len(cfg.ReviewChecklists) > 0 {
+		r.toolsets.Toolsets["pull_requests"].AddReadTools(toolsets.NewServerTool(GetReviewChecklist(cfg.GetClient, cfg.ReviewChecklists, cfg.Translator)))
+	}
+	if cfg.GetAppClient != nil {
+		r.toolsets.AddToolset(InitAppToolset(cfg.GetAppClient, cfg.Translator))
+	}
+	if len(cfg.WebhookSecrets) > 0 {
+		r.toolsets.AddToolset(InitWebhookToolset(cfg.WebhookSecrets, cfg.Translator))
+	}
+	if cfg.Anonymous {
+		r.toolsets.KeepTools(isPublicDataTool)
+		r.context.KeepTools(isPublicDataTool)
+	}
+	return r
+}
+
+// Toolsets returns the group of toolsets, for example to offer them with dynamic tool discovery.
+func (r *Registry) Toolsets() *toolsets.ToolsetGroup {
+	return r.toolsets
+}
+
+// ToolsetsByTool returns the name of the toolset of each tool, by tool name, whether the toolset is enabled or not.
+func (r *Registry) ToolsetsByTool() map[string]string {
+	names := make(map[string]string)
+	for _, ts := range append([]*toolsets.Toolset{r.context}, slices.Collect(maps.Values(r.toolsets.Toolsets))...) {
+		for _, tool := range ts.GetAvailableTools() {
+			names[tool.Tool.Name] = ts.Name
+		}
+	}
+	return names
+}
+
+// EnableToolsets enables the named toolsets, or all of them if the names include "all".
+func (r *Registry) EnableToolsets(names []string) error {
+	return r.toolsets.EnableToolsets(names)
+}
+
+// AddToolset adds a toolset, replacing any toolset with the same name.
+func (r *Registry) AddToolset(ts *toolsets.Toolset) {
+	if r.cfg.Anonymous {
+		ts.KeepTools(isPublicDataTool)
+	}
+	for _, mw := range r.middleware {
+		ts.WrapTools(withMiddleware(mw))
+	}
+	r.toolsets.AddToolset(ts)
+}
+
+// RegisterTool adds a tool to the named toolset, creating the toolset if it does not exist.
+func (r *Registry) RegisterTool(toolset string, tool server.ServerTool) {
+	if r.cfg.Anonymous && !isPublicDataTool(tool) {
+		return
+	}
+	ts, ok := r.toolsets.Toolsets[toolset]
+	if !ok {
+		ts = toolsets.NewToolset(toolset, toolset+" tools")
+		r.toolsets.AddToolset(ts)
+	}
+
+	for _, mw := range r.middleware {
+		tool = withMiddleware(mw)(tool)
+	}
+	if tool.Tool.Annotations.ReadOnlyHint != nil && *tool.Tool.Annotations.ReadOnlyHint {
+		ts.AddReadTools(tool)
+		return
+	}
+	tool.Tool.Annotations.ReadOnlyHint = toBoolPtr(false)
+	ts.AddWriteTools(tool)
+}
+
+// Use wraps the handler of every tool with middleware, including tools registered afterwards.
+func (r *Registry) Use(middleware ...server.ToolHandlerMiddleware) {
+	for _, mw := range middleware {
+		r.WrapTools(withMiddleware(mw))
+		r.middleware = append(r.middleware, mw)
+	}
+}
+
+// Clients returns the functions the tools use to get the REST and GraphQL clients.
+func (r *Registry) Clients() (GetClientFn, GetGQLClientFn) {
+	return r.cfg.GetClient, r.cfg.GetGQLClient
+}
+
+// WrapTools applies wrap to the read and write tools registered so far.
+func (r *Registry) WrapTools(wrap func(server.ServerTool) server.ServerTool) {
+	r.toolsets.WrapTools(wrap)
+	r.context.WrapTools(wrap)
+}
+
+// WrapWriteTools applies wrap to the write tools registered so far.
+func (r *Registry) WrapWriteTools(wrap func(server.ServerTool) server.ServerTool) {
+	r.toolsets.WrapWriteTools(wrap)
+	r.context.WrapWriteTools(wrap)
+}
+
+// Tools returns the tools of the context toolset and of every enabled toolset.
+func (r *Registry) Tools() []server.ServerTool {
+	tools := make([]server.ServerTool, 0)
+	tools = append(tools, r.context.GetActiveTools()...)
+	return append(tools, r.toolsets.GetActiveTools()...)
+}
+
+// RegisterTools adds the tools returned by Tools to the server.
+func (r *Registry) RegisterTools(s *server.MCPServer) {
+	s.AddTools(r.Tools()...)
+}
+
+// withMiddleware adapts a handler middleware to wrap a tool.
+func withMiddleware(mw server.ToolHandlerMiddleware) func(server.ServerTool) server.ServerTool {
+	return func(st server.ServerTool) server.ServerTool {
+		st.Handler = mw(st.Handler)
+		return st
+	}
+}
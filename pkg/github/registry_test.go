@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func customTool(name string, readOnly bool) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: toBoolPtr(readOnly)})),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(name), nil
+		},
+	}
+}
+
+func toolNames(tools []server.ServerTool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Tool.Name)
+	}
+	return names
+}
+
+func Test_Registry(t *testing.T) {
+	client := github.NewClient(nil)
+	registry := NewRegistry(RegistryConfig{
+		GetClient:  stubGetClientFn(client),
+		Translator: translations.NullTranslationHelper,
+	})
+
+	registry.RegisterTool("acme", customTool("acme_lookup", true))
+	registry.RegisterTool("acme", customTool("acme_deploy", false))
+
+	// Only the context toolset is served until toolsets are enabled
+	assert.Equal(t, []string{"get_me"}, toolNames(registry.Tools()))
+
+	require.NoError(t, registry.EnableToolsets([]string{"acme"}))
+	assert.ElementsMatch(t, []string{"get_me", "acme_lookup", "acme_deploy"}, toolNames(registry.Tools()))
+
+	getClient, _ := registry.Clients()
+	got, err := getClient(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, client, got)
+
+	require.Error(t, registry.EnableToolsets([]string{"unknown"}))
+}
+
+func Test_Registry_ReadOnly(t *testing.T) {
+	registry := NewRegistry(RegistryConfig{ReadOnly: true})
+
+	registry.RegisterTool("acme", customTool("acme_lookup", true))
+	// Tools without annotations are treated as write tools
+	registry.RegisterTool("acme", server.ServerTool{
+		Tool:    mcp.NewTool("acme_unannotated"),
+		Handler: customTool("acme_unannotated", false).Handler,
+	})
+	require.NoError(t, registry.EnableToolsets([]string{"all"}))
+
+	names := toolNames(registry.Tools())
+	assert.Contains(t, names, "acme_lookup")
+	assert.NotContains(t, names, "acme_unannotated")
+	assert.NotContains(t, names, "create_issue")
+	assert.Contains(t, names, "get_issue")
+}
+
+func Test_Registry_Anonymous(t *testing.T) {
+	registry := NewRegistry(RegistryConfig{Anonymous: true})
+	registry.RegisterTool("acme", customTool("acme_lookup", true))
+	registry.AddToolset(toolsets.NewToolset("other", "Other tools").AddReadTools(customTool("other_lookup", true)))
+	require.NoError(t, registry.EnableToolsets([]string{"all"}))
+
+	names := toolNames(registry.Tools())
+	assert.Contains(t, names, "get_file_contents")
+	assert.Contains(t, names, "list_issues")
+	// Tools needing a token, writing, or registered from outside are left out
+	assert.NotContains(t, names, "get_me")
+	assert.NotContains(t, names, "search_code")
+	assert.NotContains(t, names, "create_issue")
+	assert.NotContains(t, names, "acme_lookup")
+	assert.NotContains(t, names, "other_lookup")
+	for _, name := range names {
+		assert.True(t, publicDataTools[name], name)
+	}
+	// Every public data tool exists
+	for name := range publicDataTools {
+		assert.Contains(t, names, name)
+	}
+}
+
+func Test_Registry_Use(t *testing.T) {
+	registry := NewRegistry(RegistryConfig{})
+
+	var calls []string
+	middleware := func(label string) server.ToolHandlerMiddleware {
+		return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				calls = append(calls, label+":"+request.Params.Name)
+				return next(ctx, request)
+			}
+		}
+	}
+
+	registry.RegisterTool("acme", customTool("before", true))
+	registry.Use(middleware("first"))
+	registry.AddToolset(toolsets.NewToolset("other", "Other tools").AddReadTools(customTool("toolset", true)))
+	registry.Use(middleware("second"))
+	registry.RegisterTool("acme", customTool("after", true))
+	require.NoError(t, registry.EnableToolsets([]string{"acme", "other"}))
+
+	tools := map[string]server.ServerTool{}
+	for _, tool := range registry.Tools() {
+		tools[tool.Tool.Name] = tool
+	}
+
+	for _, name := range []string{"before", "toolset", "after"} {
+		calls = nil
+		request := createMCPRequest(map[string]any{})
+		request.Params.Name = name
+		_, err := tools[name].Handler(context.Background(), request)
+		require.NoError(t, err)
+		// Middleware added later wraps middleware added earlier, whenever the tool was registered
+		assert.Equal(t, []string{"second:" + name, "first:" + name}, calls)
+	}
+}
+
+func Test_Registry_AppToolset(t *testing.T) {
+	registry := NewRegistry(RegistryConfig{})
+	require.Error(t, registry.EnableToolsets([]string{"apps"}), "the apps toolset needs App credentials")
+
+	registry = NewRegistry(RegistryConfig{GetAppClient: stubGetClientFn(github.NewClient(nil))})
+	require.NoError(t, registry.EnableToolsets([]string{"apps"}))
+	assert.Contains(t, toolNames(registry.Tools()), "create_scoped_installation_token")
+}
+
+func Test_Registry_WebhookToolset(t *testing.T) {
+	registry := NewRegistry(RegistryConfig{})
+	require.Error(t, registry.EnableToolsets([]string{"webhooks"}), "the webhooks toolset needs webhook secrets")
+
+	registry = NewRegistry(RegistryConfig{WebhookSecrets: map[string]string{"issues": "s3cret"}})
+	require.NoError(t, registry.EnableToolsets([]string{"webhooks"}))
+	assert.Contains(t, toolNames(registry.Tools()), "verify_webhook_signature")
+}
+
+func Test_Registry_ReviewChecklist(t *testing.T) {
+	registry := NewRegistry(RegistryConfig{})
+	require.NoError(t, registry.EnableToolsets([]string{"pull_requests"}))
+	assert.NotContains(t, toolNames(registry.Tools()), "get_review_checklist", "the tool needs configured checklists")
+
+	registry = NewRegistry(RegistryConfig{ReviewChecklists: []ReviewChecklist{{Name: "security", Paths: []string{"auth/"}, Items: []string{"Threat model updated"}}}})
+	require.NoError(t, registry.EnableToolsets([]string{"pull_requests"}))
+	assert.Contains(t, toolNames(registry.Tools()), "get_review_checklist")
+}
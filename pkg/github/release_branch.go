@@ -0,0 +1,264 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// releaseVersionPattern matches the major.minor versions release branches are cut for.
+var releaseVersionPattern = regexp.MustCompile(`^v?(\d+\.\d+)$`)
+
+// defaultReleaseChecklist is the checklist of the tracking issue when none is given, where {branch} is replaced by the
+// release branch.
+var defaultReleaseChecklist = []string{
+	"Announce the cut of `{branch}` and the fixes-only policy",
+	"Backport the fixes targeting the release",
+	"Update the changelog and release notes",
+	"Run the release tests against `{branch}`",
+	"Tag the release and publish it",
+	"Merge the release notes back into the default branch",
+}
+
+// protectionRequestFrom builds the request applying the classic protection of a branch to another branch. Locking
+// settings are not copied, so that the new branch stays open for fixes.
+func protectionRequestFrom(p *github.Protection) *github.ProtectionRequest {
+	req := &github.ProtectionRequest{
+		EnforceAdmins:                  p.EnforceAdmins != nil && p.EnforceAdmins.Enabled,
+		RequireLinearHistory:           github.Ptr(p.RequireLinearHistory != nil && p.RequireLinearHistory.Enabled),
+		AllowForcePushes:               github.Ptr(p.AllowForcePushes != nil && p.AllowForcePushes.Enabled),
+		AllowDeletions:                 github.Ptr(p.AllowDeletions != nil && p.AllowDeletions.Enabled),
+		RequiredConversationResolution: github.Ptr(p.RequiredConversationResolution != nil && p.RequiredConversationResolution.Enabled),
+	}
+	if checks := p.RequiredStatusChecks; checks != nil {
+		req.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: checks.Strict, Checks: checks.Checks}
+		if checks.Checks == nil {
+			req.RequiredStatusChecks.Contexts = checks.Contexts
+		}
+	}
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		req.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      github.Ptr(reviews.RequireLastPushApproval),
+		}
+	}
+	if restrictions := p.Restrictions; restrictions != nil {
+		req.Restrictions = &github.BranchRestrictionsRequest{Users: []string{}, Teams: []string{}, Apps: []string{}}
+		for _, user := range restrictions.Users {
+			req.Restrictions.Users = append(req.Restrictions.Users, user.GetLogin())
+		}
+		for _, team := range restrictions.Teams {
+			req.Restrictions.Teams = append(req.Restrictions.Teams, team.GetSlug())
+		}
+		for _, app := range restrictions.Apps {
+			req.Restrictions.Apps = append(req.Restrictions.Apps, app.GetSlug())
+		}
+	}
+	return req
+}
+
+// releaseCut is the result of cut_release_branch.
+type releaseCut struct {
+	Branch string `json:"branch"`
+	SHA    string `json:"sha"`
+	// ProtectionTemplate is the branch whose protection was applied, empty when it has none.
+	ProtectionTemplate string `json:"protection_template,omitempty"`
+	Milestone          int    `json:"milestone,omitempty"`
+	MilestoneURL       string `json:"milestone_url,omitempty"`
+	ChecklistIssue     int    `json:"checklist_issue,omitempty"`
+	ChecklistIssueURL  string `json:"checklist_issue_url,omitempty"`
+	// Failed are the steps that failed after the branch was created, with the reason.
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// releaseMilestone returns the milestone with a title, creating it if there is none.
+func releaseMilestone(ctx context.Context, client *github.Client, owner, repo, title string, dueOn *github.Timestamp) (*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestones: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, milestone := range milestones {
+			if milestone.GetTitle() == title {
+				return milestone, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	milestone, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, &github.Milestone{
+		Title: github.Ptr(title),
+		DueOn: dueOn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create milestone: %w", err)
+	}
+	_ = resp.Body.Close()
+	return milestone, nil
+}
+
+// CutReleaseBranch creates a tool to create a release branch with its protection, milestone and checklist issue.
+func CutReleaseBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cut_release_branch",
+			mcp.WithDescription(t("TOOL_CUT_RELEASE_BRANCH_DESCRIPTION", "Cut a release branch release/x.y from a commit, apply to it the branch protection of a template branch, create a tracking milestone for the release and open a checklist issue in it. The steps after the branch creation are independent, and the ones that fail are reported.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CUT_RELEASE_BRANCH_USER_TITLE", "Cut release branch"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("version",
+				mcp.Required(),
+				mcp.Description("Major and minor version of the release, e.g. 1.4"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Commit to cut the branch from, defaults to the head of the default branch"),
+			),
+			mcp.WithString("protection_template",
+				mcp.Description("Branch whose protection is applied to the release branch, defaults to the default branch"),
+			),
+			mcp.WithArray("checklist",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Items of the checklist issue, defaults to a standard release checklist"),
+			),
+			mcp.WithString("due_date",
+				mcp.Description("Due date of the milestone, in YYYY-MM-DD format"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCutReleaseBranchParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			match := releaseVersionPattern.FindStringSubmatch(params.Version)
+			if match == nil {
+				return mcp.NewToolResultError("version must be a major and minor version, e.g. 1.4"), nil
+			}
+			version := match[1]
+			var dueOn *github.Timestamp
+			if params.DueDate != "" {
+				due, err := time.Parse("2006-01-02", params.DueDate)
+				if err != nil {
+					return mcp.NewToolResultError("due_date must be in YYYY-MM-DD format"), nil
+				}
+				dueOn = &github.Timestamp{Time: due}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if params.SHA == "" || params.ProtectionTemplate == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				if params.SHA == "" {
+					params.SHA = repository.GetDefaultBranch()
+				}
+				if params.ProtectionTemplate == "" {
+					params.ProtectionTemplate = repository.GetDefaultBranch()
+				}
+			}
+			sha, resp, err := client.Repositories.GetCommitSHA1(ctx, params.Owner, params.Repo, params.SHA, "")
+			if err != nil {
+				if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("commit %s was not found", params.SHA)), nil
+				}
+				return nil, fmt.Errorf("failed to get commit: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			cut := releaseCut{Branch: "release/" + version, SHA: sha, Failed: map[string]string{}}
+			_, resp, err = client.Git.CreateRef(ctx, params.Owner, params.Repo, &github.Reference{
+				Ref:    github.Ptr("refs/heads/" + cut.Branch),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s already exists", cut.Branch)), nil
+				}
+				return nil, fmt.Errorf("failed to create branch: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, params.Owner, params.Repo, params.ProtectionTemplate)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				_, resp, err = client.Repositories.UpdateBranchProtection(ctx, params.Owner, params.Repo, cut.Branch, protectionRequestFrom(protection))
+				if err != nil {
+					cut.Failed["protection"] = fmt.Sprintf("failed to protect the branch: %v", err)
+					break
+				}
+				_ = resp.Body.Close()
+				cut.ProtectionTemplate = params.ProtectionTemplate
+			case resp == nil || resp.StatusCode != http.StatusNotFound:
+				cut.Failed["protection"] = fmt.Sprintf("failed to get the protection of %s: %v", params.ProtectionTemplate, err)
+			}
+
+			milestone, err := releaseMilestone(ctx, client, params.Owner, params.Repo, "Release "+version, dueOn)
+			if err != nil {
+				cut.Failed["milestone"] = err.Error()
+			} else {
+				cut.Milestone = milestone.GetNumber()
+				cut.MilestoneURL = milestone.GetHTMLURL()
+			}
+
+			checklist := params.Checklist
+			if len(checklist) == 0 {
+				for _, item := range defaultReleaseChecklist {
+					checklist = append(checklist, strings.ReplaceAll(item, "{branch}", cut.Branch))
+				}
+			}
+			var body strings.Builder
+			fmt.Fprintf(&body, "Tracking the %s release, cut as `%s` from %s.\n\n", version, cut.Branch, sha)
+			for _, item := range checklist {
+				fmt.Fprintf(&body, "- [ ] %s\n", item)
+			}
+			issue := &github.IssueRequest{
+				Title: github.Ptr(fmt.Sprintf("Release %s checklist", version)),
+				Body:  github.Ptr(body.String()),
+			}
+			if cut.Milestone != 0 {
+				issue.Milestone = github.Ptr(cut.Milestone)
+			}
+			created, resp, err := client.Issues.Create(ctx, params.Owner, params.Repo, issue)
+			if err != nil {
+				cut.Failed["checklist_issue"] = fmt.Sprintf("failed to create the checklist issue: %v", err)
+			} else {
+				_ = resp.Body.Close()
+				cut.ChecklistIssue = created.GetNumber()
+				cut.ChecklistIssueURL = created.GetHTMLURL()
+			}
+
+			r, err := json.Marshal(cut)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProtectionRequestFrom(t *testing.T) {
+	req := protectionRequestFrom(&github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict:      true,
+			Contexts:    &[]string{"build"},
+			Checks:      &[]*github.RequiredStatusCheck{{Context: "build"}},
+			ContextsURL: github.Ptr("https://api.github.com/contexts"),
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequireCodeOwnerReviews:      true,
+			RequiredApprovingReviewCount: 2,
+		},
+		EnforceAdmins: &github.AdminEnforcement{Enabled: true},
+		Restrictions: &github.BranchRestrictions{
+			Teams: []*github.Team{{Slug: github.Ptr("release-managers")}},
+		},
+		LockBranch: &github.LockBranch{Enabled: github.Ptr(true)},
+	})
+
+	assert.Equal(t, &github.ProtectionRequest{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+			Checks: &[]*github.RequiredStatusCheck{{Context: "build"}},
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+			RequireCodeOwnerReviews:      true,
+			RequiredApprovingReviewCount: 2,
+			RequireLastPushApproval:      github.Ptr(false),
+		},
+		EnforceAdmins:                  true,
+		Restrictions:                   &github.BranchRestrictionsRequest{Users: []string{}, Teams: []string{"release-managers"}, Apps: []string{}},
+		RequireLinearHistory:           github.Ptr(false),
+		AllowForcePushes:               github.Ptr(false),
+		AllowDeletions:                 github.Ptr(false),
+		RequiredConversationResolution: github.Ptr(false),
+	}, req)
+}
+
+func Test_CutReleaseBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CutReleaseBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "cut_release_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "version")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "protection_template")
+	assert.Contains(t, tool.InputSchema.Properties, "checklist")
+	assert.Contains(t, tool.InputSchema.Properties, "due_date")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "version"})
+
+	t.Run("cuts the branch", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/commits/main").andThen(mockResponse(t, http.StatusOK, "0123456789abcdef")),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"ref": "refs/heads/release/1.4",
+					"sha": "0123456789abcdef",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{})),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+				expectPath(t, "/repos/owner/repo/branches/main/protection").andThen(
+					mockResponse(t, http.StatusOK, &github.Protection{
+						RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 1},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				// The branch name is escaped, release%2F1.4, and the mock matches the unescaped path
+				mock.EndpointPattern{Pattern: "/repos/{owner}/{repo}/branches/release/{version}/protection", Method: "PUT"},
+				expectRequestBody(t, map[string]any{
+					"required_status_checks": nil,
+					"required_pull_request_reviews": map[string]any{
+						"dismiss_stale_reviews":           false,
+						"require_code_owner_reviews":      false,
+						"required_approving_review_count": float64(1),
+						"require_last_push_approval":      false,
+					},
+					"enforce_admins":                   false,
+					"restrictions":                     nil,
+					"required_linear_history":          false,
+					"allow_force_pushes":               false,
+					"allow_deletions":                  false,
+					"required_conversation_resolution": false,
+				}).andThen(mockResponse(t, http.StatusOK, &github.Protection{})),
+			),
+			mock.WithRequestMatch(mock.GetReposMilestonesByOwnerByRepo, []*github.Milestone{
+				{Number: github.Ptr(3), Title: github.Ptr("Release 1.3")},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposMilestonesByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"title":  "Release 1.4",
+					"due_on": "2026-11-02T00:00:00Z",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Milestone{
+					Number:  github.Ptr(4),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/milestone/4"),
+				})),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"title":     "Release 1.4 checklist",
+					"body":      "Tracking the 1.4 release, cut as `release/1.4` from 0123456789abcdef.\n\n- [ ] Freeze `release/1.4`\n- [ ] Publish\n",
+					"milestone": float64(4),
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Issue{
+					Number:  github.Ptr(40),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/issues/40"),
+				})),
+			),
+		)
+		_, handler := CutReleaseBranch(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":     "owner",
+			"repo":      "repo",
+			"version":   "v1.4",
+			"checklist": []any{"Freeze `release/1.4`", "Publish"},
+			"due_date":  "2026-11-02",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var cut releaseCut
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &cut))
+		assert.Equal(t, releaseCut{
+			Branch:             "release/1.4",
+			SHA:                "0123456789abcdef",
+			ProtectionTemplate: "main",
+			Milestone:          4,
+			MilestoneURL:       "https://github.com/owner/repo/milestone/4",
+			ChecklistIssue:     40,
+			ChecklistIssueURL:  "https://github.com/owner/repo/issues/40",
+		}, cut)
+	})
+
+	t.Run("reports failed steps", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepoByRef, mockResponse(t, http.StatusOK, "0123456789abcdef")),
+			mock.WithRequestMatch(mock.PostReposGitRefsByOwnerByRepo, &github.Reference{}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not protected"}),
+			),
+			mock.WithRequestMatch(mock.GetReposMilestonesByOwnerByRepo, []*github.Milestone{}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposMilestonesByOwnerByRepo,
+				mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+			),
+			mock.WithRequestMatch(mock.PostReposIssuesByOwnerByRepo, &github.Issue{Number: github.Ptr(41)}),
+		)
+		_, handler := CutReleaseBranch(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":               "owner",
+			"repo":                "repo",
+			"version":             "2.0",
+			"sha":                 "0123456",
+			"protection_template": "main",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var cut releaseCut
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &cut))
+		assert.Equal(t, "release/2.0", cut.Branch)
+		assert.Empty(t, cut.ProtectionTemplate, "the template is not protected")
+		assert.Equal(t, 41, cut.ChecklistIssue)
+		require.Len(t, cut.Failed, 1)
+		assert.Contains(t, cut.Failed["milestone"], "failed to create milestone")
+	})
+
+	t.Run("rejects an existing branch", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepoByRef, mockResponse(t, http.StatusOK, "0123456789abcdef")),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Reference already exists"}),
+			),
+		)
+		_, handler := CutReleaseBranch(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":               "owner",
+			"repo":                "repo",
+			"version":             "1.4",
+			"sha":                 "0123456",
+			"protection_template": "main",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "branch release/1.4 already exists")
+	})
+
+	t.Run("rejects an invalid version", func(t *testing.T) {
+		_, handler := CutReleaseBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"version": "1.4.2",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "version must be a major and minor version")
+	})
+}
@@ -0,0 +1,291 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultMaxReplacementFiles is the number of files a replacement may change when the caller does not say.
+	defaultMaxReplacementFiles = 20
+
+	// maxReplacementFiles is the most files a single replacement may change, larger refactors are split.
+	maxReplacementFiles = 100
+
+	// maxReplacementScannedFiles bounds the files read to look for the pattern, one request each.
+	maxReplacementScannedFiles = 500
+
+	// maxReplacementFileSize is the largest file searched, larger ones are skipped.
+	maxReplacementFileSize = 1 << 20
+)
+
+// textReplacementFile is a file changed by a text replacement.
+type textReplacementFile struct {
+	Path    string `json:"path"`
+	Matches int    `json:"matches"`
+	Diff    string `json:"diff"`
+}
+
+// textReplacementPullRequest is the pull request opened with a text replacement.
+type textReplacementPullRequest struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// textReplacementResult is the result of the propose_text_replacement tool: the plan of the replacement, and what
+// was created for it unless it was a dry run.
+type textReplacementResult struct {
+	Base         string                      `json:"base"`
+	BaseSHA      string                      `json:"base_sha"`
+	Branch       string                      `json:"branch"`
+	ScannedFiles int                         `json:"scanned_files"`
+	Files        []textReplacementFile       `json:"files"`
+	DryRun       bool                        `json:"dry_run"`
+	Commit       string                      `json:"commit,omitempty"`
+	PullRequest  *textReplacementPullRequest `json:"pull_request,omitempty"`
+}
+
+// matchesPathPatterns reports whether a file matches one of the glob patterns. Patterns without a slash match the
+// name of the file in any directory, others the whole path. No patterns match every file.
+func matchesPathPatterns(filePath string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		name := filePath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(filePath)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ProposeTextReplacement creates a tool to apply a regular expression replacement across a repository and open a
+// draft pull request with the result.
+func ProposeTextReplacement(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("propose_text_replacement",
+			mcp.WithDescription(t("TOOL_PROPOSE_TEXT_REPLACEMENT_DESCRIPTION", "Search the files of a repository for a regular expression, replace its matches and open a draft pull request with the changes, for mechanical refactors. Use dry_run first to preview the diff of every file that would change.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PROPOSE_TEXT_REPLACEMENT_USER_TITLE", "Propose text replacement"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("pattern",
+				mcp.Required(),
+				mcp.Description("Regular expression to search for, in RE2 syntax; (?m) makes ^ and $ match at line boundaries"),
+			),
+			mcp.WithString("replacement",
+				mcp.Description("Replacement text, where $1 or ${name} insert the text matched by a group. Defaults to nothing, deleting the matches"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Glob patterns of the files to search, e.g. *.go or docs/*.md; patterns without a slash match file names in any directory. Defaults to all files"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to change and open the pull request against, defaults to the repository's default branch"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Name of the branch to create for the changes, defaults to one derived from the pattern and replacement"),
+			),
+			mcp.WithString("title",
+				mcp.Description("Title of the pull request and commit message, defaults to a description of the replacement"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Body of the pull request, defaults to a list of the changed files"),
+			),
+			mcp.WithNumber("max_files",
+				mcp.Description(fmt.Sprintf("Maximum number of files the replacement may change, nothing is changed beyond it (default %d, at most %d)", defaultMaxReplacementFiles, maxReplacementFiles)),
+				mcp.Min(1),
+				mcp.Max(maxReplacementFiles),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Only report the files that would change and their diffs, without creating a branch or pull request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseProposeTextReplacementParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			re, err := regexp.Compile(params.Pattern)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid pattern: %s", err)), nil
+			}
+			for _, pattern := range params.Paths {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid path pattern %q: %s", pattern, err)), nil
+				}
+			}
+			if params.MaxFiles == 0 {
+				params.MaxFiles = defaultMaxReplacementFiles
+			}
+			if params.MaxFiles < 0 || params.MaxFiles > maxReplacementFiles {
+				return mcp.NewToolResultError(fmt.Sprintf("max_files must be between 1 and %d", maxReplacementFiles)), nil
+			}
+			if params.Branch == "" {
+				sum := sha256.Sum256([]byte(params.Pattern + "\x00" + params.Replacement))
+				params.Branch = "text-replacement-" + hex.EncodeToString(sum[:4])
+			}
+			if params.Title == "" {
+				params.Title = fmt.Sprintf("Replace %q with %q", params.Pattern, params.Replacement)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if params.Base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				params.Base = repository.GetDefaultBranch()
+			}
+
+			baseRef, resp, err := client.Git.GetRef(ctx, params.Owner, params.Repo, "refs/heads/"+params.Base)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+			}
+			_ = resp.Body.Close()
+			baseCommit, resp, err := client.Git.GetCommit(ctx, params.Owner, params.Repo, baseRef.GetObject().GetSHA())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get base commit: %w", err)
+			}
+			_ = resp.Body.Close()
+			tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, baseCommit.GetTree().GetSHA(), true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree: %w", err)
+			}
+			_ = resp.Body.Close()
+			if tree.GetTruncated() {
+				return mcp.NewToolResultError("the repository tree is too large to list completely, the replacement could miss files"), nil
+			}
+
+			var candidates []*github.TreeEntry
+			for _, entry := range tree.Entries {
+				// Symbolic links are blobs too, holding the path they point to
+				if entry.GetType() != "blob" || entry.GetMode() == "120000" || entry.GetSize() > maxReplacementFileSize {
+					continue
+				}
+				if matchesPathPatterns(entry.GetPath(), params.Paths) {
+					candidates = append(candidates, entry)
+				}
+			}
+			if len(candidates) > maxReplacementScannedFiles {
+				return mcp.NewToolResultError(fmt.Sprintf("%d files would have to be searched, at most %d can be: narrow them down with paths", len(candidates), maxReplacementScannedFiles)), nil
+			}
+
+			result := textReplacementResult{
+				Base:         params.Base,
+				BaseSHA:      baseCommit.GetSHA(),
+				Branch:       params.Branch,
+				ScannedFiles: len(candidates),
+				Files:        []textReplacementFile{},
+				DryRun:       params.DryRun,
+			}
+			var entries []*github.TreeEntry
+			for _, entry := range candidates {
+				data, resp, err := client.Git.GetBlobRaw(ctx, params.Owner, params.Repo, entry.GetSHA())
+				if err != nil {
+					return nil, fmt.Errorf("failed to get %s: %w", entry.GetPath(), err)
+				}
+				_ = resp.Body.Close()
+				if isBinaryContent(data) {
+					continue
+				}
+
+				content := string(data)
+				matches := len(re.FindAllStringIndex(content, -1))
+				if matches == 0 {
+					continue
+				}
+				replaced := re.ReplaceAllString(content, params.Replacement)
+				if replaced == content {
+					continue
+				}
+				if len(result.Files) == params.MaxFiles {
+					return mcp.NewToolResultError(fmt.Sprintf("the replacement would change more than %d files: raise max_files or narrow down the files with paths", params.MaxFiles)), nil
+				}
+
+				result.Files = append(result.Files, textReplacementFile{
+					Path:    entry.GetPath(),
+					Matches: matches,
+					Diff:    unifiedDiff(content, replaced, defaultDiffContextLines),
+				})
+				entries = append(entries, &github.TreeEntry{
+					Path:    github.Ptr(entry.GetPath()),
+					Mode:    github.Ptr(entry.GetMode()),
+					Type:    github.Ptr("blob"),
+					Content: github.Ptr(replaced),
+				})
+			}
+
+			if params.DryRun || len(entries) == 0 {
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			newCommit, err := commitToNewBranch(ctx, client, params.Owner, params.Repo, baseCommit, entries, params.Title, params.Branch)
+			if err != nil {
+				return nil, err
+			}
+			result.Commit = newCommit.GetSHA()
+
+			body := params.Body
+			if body == "" {
+				var sb strings.Builder
+				fmt.Fprintf(&sb, "Replaces the matches of `%s` with `%s` in %d file(s):\n\n", params.Pattern, params.Replacement, len(result.Files))
+				for _, file := range result.Files {
+					fmt.Fprintf(&sb, "- `%s` (%d match(es))\n", file.Path, file.Matches)
+				}
+				body = sb.String()
+			}
+			pr, resp, err := client.PullRequests.Create(ctx, params.Owner, params.Repo, &github.NewPullRequest{
+				Title: github.Ptr(params.Title),
+				Head:  github.Ptr(params.Branch),
+				Base:  github.Ptr(params.Base),
+				Body:  github.Ptr(body),
+				Draft: github.Ptr(true),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+			result.PullRequest = &textReplacementPullRequest{
+				Number: pr.GetNumber(),
+				URL:    pr.GetHTMLURL(),
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
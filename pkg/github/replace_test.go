@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MatchesPathPatterns(t *testing.T) {
+	assert.True(t, matchesPathPatterns("pkg/server.go", nil))
+	assert.True(t, matchesPathPatterns("pkg/server.go", []string{"*.go"}))
+	assert.True(t, matchesPathPatterns("docs/guide.md", []string{"*.go", "docs/*.md"}))
+	assert.False(t, matchesPathPatterns("docs/api/guide.md", []string{"docs/*.md"}))
+	assert.False(t, matchesPathPatterns("README.md", []string{"*.go"}))
+}
+
+func Test_ProposeTextReplacement(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ProposeTextReplacement(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "propose_text_replacement", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pattern")
+	assert.Contains(t, tool.InputSchema.Properties, "replacement")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "max_files")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pattern"})
+
+	blobs := map[string]string{
+		"sha-a": "package a\n\nvar c = ioutil.ReadAll(r)\n",
+		"sha-b": "package b\n\nfunc f() { ioutil.ReadAll(r); ioutil.ReadAll(w) }\n",
+		"sha-c": "package c\n",
+		"sha-d": "Use ioutil.ReadAll to read everything.\n",
+		"sha-e": "\x00ioutil.ReadAll",
+	}
+	tree := &github.Tree{
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("a/a.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-a")},
+			{Path: github.Ptr("b/b.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100755"), SHA: github.Ptr("sha-b")},
+			{Path: github.Ptr("c/c.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-c")},
+			{Path: github.Ptr("docs/io.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-d")},
+			{Path: github.Ptr("e/e.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("sha-e")},
+			{Path: github.Ptr("link.go"), Type: github.Ptr("blob"), Mode: github.Ptr("120000"), SHA: github.Ptr("sha-link")},
+			{Path: github.Ptr("a"), Type: github.Ptr("tree"), Mode: github.Ptr("040000"), SHA: github.Ptr("sha-tree")},
+		},
+	}
+	readOptions := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+					mockResponse(t, http.StatusOK, &github.Reference{Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+				),
+			),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, &github.Commit{
+				SHA:  github.Ptr("base-sha"),
+				Tree: &github.Tree{SHA: github.Ptr("base-tree")},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				expectPath(t, "/repos/owner/repo/git/trees/base-tree").andThen(mockResponse(t, http.StatusOK, tree)),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					sha := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+					content, ok := blobs[sha]
+					require.True(t, ok, "unexpected blob %s", sha)
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(content))
+				}),
+			),
+		}
+	}
+
+	t.Run("dry run previews the changes", func(t *testing.T) {
+		_, handler := ProposeTextReplacement(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(readOptions()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pattern":     `ioutil\.ReadAll`,
+			"replacement": "io.ReadAll",
+			"paths":       []any{"*.go"},
+			"dry_run":     true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var plan textReplacementResult
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &plan))
+		assert.True(t, plan.DryRun)
+		assert.Equal(t, "main", plan.Base)
+		assert.Equal(t, "base-sha", plan.BaseSHA)
+		assert.True(t, strings.HasPrefix(plan.Branch, "text-replacement-"))
+		assert.Equal(t, 4, plan.ScannedFiles)
+		assert.Equal(t, []textReplacementFile{
+			{Path: "a/a.go", Matches: 1, Diff: "@@ -1,3 +1,3 @@\n package a\n \n-var c = ioutil.ReadAll(r)\n+var c = io.ReadAll(r)\n"},
+			{Path: "b/b.go", Matches: 2, Diff: "@@ -1,3 +1,3 @@\n package b\n \n-func f() { ioutil.ReadAll(r); ioutil.ReadAll(w) }\n+func f() { io.ReadAll(r); io.ReadAll(w) }\n"},
+		}, plan.Files)
+		assert.Empty(t, plan.Commit)
+		assert.Nil(t, plan.PullRequest)
+	})
+
+	t.Run("opens a draft pull request", func(t *testing.T) {
+		options := append(readOptions(),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"base_tree": "base-tree",
+					"tree": []any{
+						map[string]any{"path": "a/a.go", "mode": "100644", "type": "blob", "content": "package a\n\nvar c = io.ReadAll(r)\n"},
+						map[string]any{"path": "b/b.go", "mode": "100755", "type": "blob", "content": "package b\n\nfunc f() { io.ReadAll(r); io.ReadAll(w) }\n"},
+					},
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("new-tree")})),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"message": "Use io.ReadAll",
+					"tree":    "new-tree",
+					"parents": []any{"base-sha"},
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr("new-commit")})),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"ref": "refs/heads/use-io-readall",
+					"sha": "new-commit",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/heads/use-io-readall")})),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposPullsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"title": "Use io.ReadAll",
+					"head":  "use-io-readall",
+					"base":  "main",
+					"body":  "Replaces the matches of `ioutil\\.ReadAll` with `io.ReadAll` in 2 file(s):\n\n- `a/a.go` (1 match(es))\n- `b/b.go` (2 match(es))\n",
+					"draft": true,
+				}).andThen(mockResponse(t, http.StatusCreated, &github.PullRequest{
+					Number:  github.Ptr(7),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/pull/7"),
+				})),
+			),
+		)
+		_, handler := ProposeTextReplacement(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pattern":     `ioutil\.ReadAll`,
+			"replacement": "io.ReadAll",
+			"paths":       []any{"*.go"},
+			"branch":      "use-io-readall",
+			"title":       "Use io.ReadAll",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var returned textReplacementResult
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+		assert.False(t, returned.DryRun)
+		assert.Len(t, returned.Files, 2)
+		assert.Equal(t, "new-commit", returned.Commit)
+		assert.Equal(t, &textReplacementPullRequest{Number: 7, URL: "https://github.com/owner/repo/pull/7"}, returned.PullRequest)
+	})
+
+	t.Run("refuses to change more files than allowed", func(t *testing.T) {
+		_, handler := ProposeTextReplacement(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(readOptions()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pattern":     `ioutil\.ReadAll`,
+			"replacement": "io.ReadAll",
+			"max_files":   float64(2),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "more than 2 files")
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, handler := ProposeTextReplacement(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"pattern": `ioutil\.(`,
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "invalid pattern")
+	})
+}
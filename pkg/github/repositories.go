@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
@@ -35,33 +37,21 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			sha, err := requiredParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
+			params, err := parseGetCommitParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.ListOptions{
-				Page:    pagination.page,
-				PerPage: pagination.perPage,
+				Page:    params.Page,
+				PerPage: params.PerPage,
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
+			commit, resp, err := client.Repositories.GetCommit(ctx, params.Owner, params.Repo, params.SHA, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get commit: %w", err)
 			}
@@ -106,28 +96,16 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			sha, err := OptionalParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
+			params, err := parseListCommitsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.CommitsListOptions{
-				SHA: sha,
+				SHA: params.SHA,
 				ListOptions: github.ListOptions{
-					Page:    pagination.page,
-					PerPage: pagination.perPage,
+					Page:    params.Page,
+					PerPage: params.PerPage,
 				},
 			}
 
@@ -135,7 +113,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+			commits, resp, err := client.Repositories.ListCommits(ctx, params.Owner, params.Repo, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list commits: %w", err)
 			}
@@ -177,23 +155,15 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
+			params, err := parseListBranchesParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.BranchListOptions{
 				ListOptions: github.ListOptions{
-					Page:    pagination.page,
-					PerPage: pagination.perPage,
+					Page:    params.Page,
+					PerPage: params.PerPage,
 				},
 			}
 
@@ -202,7 +172,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+			branches, resp, err := client.Repositories.ListBranches(ctx, params.Owner, params.Repo, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list branches: %w", err)
 			}
@@ -260,50 +230,32 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			mcp.WithString("sha",
 				mcp.Description("SHA of file being replaced (for updates)"),
 			),
+			mcp.WithString("expected_sha",
+				mcp.Description("Blob SHA the file on the branch must currently have. The update is refused if the file has changed since it was read"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			path, err := requiredParam[string](request, "path")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			content, err := requiredParam[string](request, "content")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			message, err := requiredParam[string](request, "message")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			branch, err := requiredParam[string](request, "branch")
+			params, err := parseCreateOrUpdateFileParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			// json.Marshal encodes byte arrays with base64, which is required for the API.
-			contentBytes := []byte(content)
+			contentBytes := []byte(params.Content)
 
 			// Create the file options
 			opts := &github.RepositoryContentFileOptions{
-				Message: github.Ptr(message),
+				Message: github.Ptr(params.Message),
 				Content: contentBytes,
-				Branch:  github.Ptr(branch),
+				Branch:  github.Ptr(params.Branch),
 			}
 
 			// If SHA is provided, set it (for updates)
-			sha, err := OptionalParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if params.SHA == "" {
+				params.SHA = params.ExpectedSHA
 			}
-			if sha != "" {
-				opts.SHA = github.Ptr(sha)
+			if params.SHA != "" {
+				opts.SHA = github.Ptr(params.SHA)
 			}
 
 			// Create or update the file
@@ -311,7 +263,20 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+
+			if params.ExpectedSHA != "" {
+				currentSHA, err := getFileSHA(ctx, client, params.Owner, params.Repo, params.Path, params.Branch)
+				if err != nil {
+					return nil, err
+				}
+				if currentSHA != params.ExpectedSHA {
+					if currentSHA == "" {
+						currentSHA = "no file"
+					}
+					return conflictResult(params.Path, params.ExpectedSHA, currentSHA), nil
+				}
+			}
+			fileContent, resp, err := client.Repositories.CreateFile(ctx, params.Owner, params.Repo, params.Path, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create/update file: %w", err)
 			}
@@ -334,6 +299,15 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
+// getFileSHA returns the blob SHA of a file on a branch, or an empty string if the file does not exist.
+func getFileSHA(ctx context.Context, client *github.Client, owner, repo, path, branch string) (string, error) {
+	fileContent, err := getFile(ctx, client, owner, repo, path, branch)
+	if err != nil {
+		return "", err
+	}
+	return fileContent.GetSHA(), nil
+}
+
 // CreateRepository creates a tool to create a new GitHub repository.
 func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_repository",
@@ -357,28 +331,16 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			name, err := requiredParam[string](request, "name")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			description, err := OptionalParam[string](request, "description")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			private, err := OptionalParam[bool](request, "private")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			autoInit, err := OptionalParam[bool](request, "autoInit")
+			params, err := parseCreateRepositoryParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			repo := &github.Repository{
-				Name:        github.Ptr(name),
-				Description: github.Ptr(description),
-				Private:     github.Ptr(private),
-				AutoInit:    github.Ptr(autoInit),
+				Name:        github.Ptr(params.Name),
+				Description: github.Ptr(params.Description),
+				Private:     github.Ptr(params.Private),
+				AutoInit:    github.Ptr(params.AutoInit),
 			}
 
 			client, err := getClient(ctx)
@@ -411,7 +373,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 // GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
 func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_file_contents",
-			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository. Files are returned as their metadata followed by the content, as text, an image or a base64 encoded blob.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -433,19 +395,7 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			path, err := requiredParam[string](request, "path")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			branch, err := OptionalParam[string](request, "branch")
+			params, err := parseGetFileContentsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -454,8 +404,8 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			opts := &github.RepositoryContentGetOptions{Ref: branch}
-			fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+			opts := &github.RepositoryContentGetOptions{Ref: params.Branch}
+			fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, params.Owner, params.Repo, params.Path, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get file contents: %w", err)
 			}
@@ -469,19 +419,39 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get file contents: %s", string(body))), nil
 			}
 
-			var result interface{}
-			if fileContent != nil {
-				result = fileContent
-			} else {
-				result = dirContent
+			if fileContent == nil {
+				r, err := json.Marshal(dirContent)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
 			}
 
-			r, err := json.Marshal(result)
+			// Files over 1MB come without content, only their metadata and download URL are returned
+			data, err := fileContent.GetContent()
+			if err != nil {
+				r, err := json.Marshal(fileContent)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			// Return the metadata, then the decoded content as text, an image or a binary blob
+			metadata := *fileContent
+			metadata.Content = nil
+			metadata.Encoding = nil
+			r, err := json.Marshal(metadata)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(string(r)),
+					newFileContent(fileContent.GetHTMLURL(), fileContent.GetName(), []byte(data), ""),
+				},
+			}, nil
 		}
 }
 
@@ -506,29 +476,21 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			org, err := OptionalParam[string](request, "organization")
+			params, err := parseForkRepositoryParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.RepositoryCreateForkOptions{}
-			if org != "" {
-				opts.Organization = org
+			if params.Organization != "" {
+				opts.Organization = params.Organization
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, params.Owner, params.Repo, opts)
 			if err != nil {
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
@@ -592,23 +554,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			path, err := requiredParam[string](request, "path")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			message, err := requiredParam[string](request, "message")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			branch, err := requiredParam[string](request, "branch")
+			params, err := parseDeleteFileParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -619,14 +565,14 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 
 			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			ref, resp, err := client.Git.GetRef(ctx, params.Owner, params.Repo, "refs/heads/"+params.Branch)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get branch reference: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			baseCommit, resp, err := client.Git.GetCommit(ctx, params.Owner, params.Repo, *ref.Object.SHA)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get base commit: %w", err)
 			}
@@ -643,7 +589,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			// Create a tree entry for the file deletion by setting SHA to nil
 			treeEntries := []*github.TreeEntry{
 				{
-					Path: github.Ptr(path),
+					Path: github.Ptr(params.Path),
 					Mode: github.Ptr("100644"), // Regular file mode
 					Type: github.Ptr("blob"),
 					SHA:  nil, // Setting SHA to nil deletes the file
@@ -651,7 +597,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 
 			// Create a new tree with the deletion
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+			newTree, resp, err := client.Git.CreateTree(ctx, params.Owner, params.Repo, *baseCommit.Tree.SHA, treeEntries)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create tree: %w", err)
 			}
@@ -667,11 +613,11 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 
 			// Create a new commit with the new tree
 			commit := &github.Commit{
-				Message: github.Ptr(message),
+				Message: github.Ptr(params.Message),
 				Tree:    newTree,
 				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
 			}
-			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			newCommit, resp, err := client.Git.CreateCommit(ctx, params.Owner, params.Repo, commit, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create commit: %w", err)
 			}
@@ -687,7 +633,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 
 			// Update the branch reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
-			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			_, resp, err = client.Git.UpdateRef(ctx, params.Owner, params.Repo, ref, false)
 			if err != nil {
 				return nil, fmt.Errorf("failed to update reference: %w", err)
 			}
@@ -741,19 +687,7 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			branch, err := requiredParam[string](request, "branch")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			fromBranch, err := OptionalParam[string](request, "from_branch")
+			params, err := parseCreateBranchParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -766,19 +700,19 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			// Get the source branch SHA
 			var ref *github.Reference
 
-			if fromBranch == "" {
+			if params.FromBranch == "" {
 				// Get default branch if from_branch not specified
-				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
 				if err != nil {
 					return nil, fmt.Errorf("failed to get repository: %w", err)
 				}
 				defer func() { _ = resp.Body.Close() }()
 
-				fromBranch = *repository.DefaultBranch
+				params.FromBranch = *repository.DefaultBranch
 			}
 
 			// Get SHA of source branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+			ref, resp, err := client.Git.GetRef(ctx, params.Owner, params.Repo, "refs/heads/"+params.FromBranch)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get reference: %w", err)
 			}
@@ -786,11 +720,11 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 
 			// Create new branch
 			newRef := &github.Reference{
-				Ref:    github.Ptr("refs/heads/" + branch),
+				Ref:    github.Ptr("refs/heads/" + params.Branch),
 				Object: &github.GitObject{SHA: ref.Object.SHA},
 			}
 
-			createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, newRef)
+			createdRef, resp, err := client.Git.CreateRef(ctx, params.Owner, params.Repo, newRef)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create branch: %w", err)
 			}
@@ -851,43 +785,29 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			branch, err := requiredParam[string](request, "branch")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			// The files parameter should be an array of objects with path and content
+			if _, ok := request.GetArguments()["files"].([]interface{}); !ok {
+				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
 			}
-			message, err := requiredParam[string](request, "message")
+			params, err := parsePushFilesParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Parse files parameter - this should be an array of objects with path and content
-			filesObj, ok := request.GetArguments()["files"].([]interface{})
-			if !ok {
-				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
 			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			ref, resp, err := client.Git.GetRef(ctx, params.Owner, params.Repo, "refs/heads/"+params.Branch)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get branch reference: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			baseCommit, resp, err := client.Git.GetCommit(ctx, params.Owner, params.Repo, *ref.Object.SHA)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get base commit: %w", err)
 			}
@@ -896,7 +816,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			// Create tree entries for all files
 			var entries []*github.TreeEntry
 
-			for _, file := range filesObj {
+			for _, file := range params.Files {
 				fileMap, ok := file.(map[string]interface{})
 				if !ok {
 					return mcp.NewToolResultError("each file must be an object with path and content"), nil
@@ -922,7 +842,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 
 			// Create a new tree with the file entries
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
+			newTree, resp, err := client.Git.CreateTree(ctx, params.Owner, params.Repo, *baseCommit.Tree.SHA, entries)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create tree: %w", err)
 			}
@@ -930,11 +850,11 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 
 			// Create a new commit
 			commit := &github.Commit{
-				Message: github.Ptr(message),
+				Message: github.Ptr(params.Message),
 				Tree:    newTree,
 				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
 			}
-			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			newCommit, resp, err := client.Git.CreateCommit(ctx, params.Owner, params.Repo, commit, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create commit: %w", err)
 			}
@@ -942,7 +862,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 
 			// Update the reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
-			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			updatedRef, resp, err := client.Git.UpdateRef(ctx, params.Owner, params.Repo, ref, false)
 			if err != nil {
 				return nil, fmt.Errorf("failed to update reference: %w", err)
 			}
@@ -976,22 +896,14 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
+			params, err := parseListTagsParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.ListOptions{
-				Page:    pagination.page,
-				PerPage: pagination.perPage,
+				Page:    params.Page,
+				PerPage: params.PerPage,
 			}
 
 			client, err := getClient(ctx)
@@ -999,7 +911,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, opts)
+			tags, resp, err := client.Repositories.ListTags(ctx, params.Owner, params.Repo, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list tags: %w", err)
 			}
@@ -1044,15 +956,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := requiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			tag, err := requiredParam[string](request, "tag")
+			params, err := parseGetTagParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -1063,7 +967,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			}
 
 			// First get the tag reference
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/tags/"+tag)
+			ref, resp, err := client.Git.GetRef(ctx, params.Owner, params.Repo, "refs/tags/"+params.Tag)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get tag reference: %w", err)
 			}
@@ -1078,7 +982,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			}
 
 			// Then get the tag object
-			tagObj, resp, err := client.Git.GetTag(ctx, owner, repo, *ref.Object.SHA)
+			tagObj, resp, err := client.Git.GetTag(ctx, params.Owner, params.Repo, *ref.Object.SHA)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get tag object: %w", err)
 			}
@@ -1100,3 +1004,337 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+type changeGroup struct {
+	Name      string   `json:"name"`
+	Files     int      `json:"files"`
+	Additions int      `json:"additions"`
+	Deletions int      `json:"deletions"`
+	Paths     []string `json:"paths,omitempty"`
+}
+
+type branchChangeSummary struct {
+	Base           string         `json:"base"`
+	Head           string         `json:"head"`
+	AheadBy        int            `json:"ahead_by"`
+	BehindBy       int            `json:"behind_by"`
+	TotalFiles     int            `json:"total_files"`
+	TotalAdditions int            `json:"total_additions"`
+	TotalDeletions int            `json:"total_deletions"`
+	FilesTruncated bool           `json:"files_truncated,omitempty"`
+	CodeownersFile string         `json:"codeowners_file,omitempty"`
+	ByOwner        []*changeGroup `json:"by_owner"`
+	ByDirectory    []*changeGroup `json:"by_directory"`
+}
+
+// compareFilesLimit is the maximum number of files the compare API returns.
+const compareFilesLimit = 300
+
+// unownedGroup is the name used for files that have no code owner.
+const unownedGroup = "(unowned)"
+
+// sortedChangeGroups returns the groups ordered by the number of changed lines, largest first.
+func sortedChangeGroups(groups map[string]*changeGroup) []*changeGroup {
+	sorted := make([]*changeGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		ci, cj := sorted[i].Additions+sorted[i].Deletions, sorted[j].Additions+sorted[j].Deletions
+		if ci != cj {
+			return ci > cj
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// SummarizeBranchChanges creates a tool to summarize the changes on a branch grouped by code owner and directory.
+func SummarizeBranchChanges(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("summarize_branch_changes",
+			mcp.WithDescription(t("TOOL_SUMMARIZE_BRANCH_CHANGES_DESCRIPTION", "Compare a branch to its base and summarize the changed files grouped by CODEOWNERS owner and top-level directory, with addition and deletion counts. Useful to plan review routing for large branches.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUMMARIZE_BRANCH_CHANGES_USER_TITLE", "Summarize branch changes by owner"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Branch, tag or commit SHA containing the changes"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to compare against, defaults to the repository's default branch"),
+			),
+			mcp.WithBoolean("include_paths",
+				mcp.Description("Include the list of changed paths in each group"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseSummarizeBranchChangesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if params.Base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				params.Base = repository.GetDefaultBranch()
+			}
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, params.Owner, params.Repo, params.Base, params.Head, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare commits: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to compare commits: %s", string(body))), nil
+			}
+
+			// Reviews are requested from the owners defined on the base branch.
+			co, err := getCodeowners(ctx, client, params.Owner, params.Repo, params.Base)
+			if err != nil {
+				return nil, err
+			}
+
+			summary := branchChangeSummary{
+				Base:           params.Base,
+				Head:           params.Head,
+				AheadBy:        comparison.GetAheadBy(),
+				BehindBy:       comparison.GetBehindBy(),
+				TotalFiles:     len(comparison.Files),
+				FilesTruncated: len(comparison.Files) >= compareFilesLimit,
+			}
+			if co != nil {
+				summary.CodeownersFile = co.Path
+			}
+
+			byOwner := map[string]*changeGroup{}
+			byDirectory := map[string]*changeGroup{}
+			add := func(groups map[string]*changeGroup, name string, file *github.CommitFile) {
+				g, ok := groups[name]
+				if !ok {
+					g = &changeGroup{Name: name}
+					groups[name] = g
+				}
+				g.Files++
+				g.Additions += file.GetAdditions()
+				g.Deletions += file.GetDeletions()
+				if params.IncludePaths {
+					g.Paths = append(g.Paths, file.GetFilename())
+				}
+			}
+
+			for _, file := range comparison.Files {
+				summary.TotalAdditions += file.GetAdditions()
+				summary.TotalDeletions += file.GetDeletions()
+
+				owners := co.Owners(file.GetFilename())
+				if len(owners) == 0 {
+					owners = []string{unownedGroup}
+				}
+				for _, o := range owners {
+					add(byOwner, o, file)
+				}
+
+				directory := "/"
+				if i := strings.Index(file.GetFilename(), "/"); i != -1 {
+					directory = file.GetFilename()[:i]
+				}
+				add(byDirectory, directory, file)
+			}
+
+			summary.ByOwner = sortedChangeGroups(byOwner)
+			summary.ByDirectory = sortedChangeGroups(byDirectory)
+
+			r, err := json.Marshal(summary)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// defaultDiffContextLines is the number of unchanged lines shown around changes, as git does by default.
+	defaultDiffContextLines = 3
+
+	// maxDiffFileSize is the largest file diff_file_between_refs compares.
+	maxDiffFileSize = 10 << 20
+)
+
+// getFileAtRef returns the content and blob SHA of a file at a ref. A file missing at that ref is reported as not
+// found rather than as an error, it was added or deleted in between.
+func getFileAtRef(ctx context.Context, client *github.Client, owner, repo, filePath, ref string) (content []byte, sha string, found bool, err error) {
+	fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, filePath, &github.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, "", false, nil
+		}
+		return nil, "", false, fmt.Errorf("failed to get %s at %s: %w", filePath, ref, err)
+	}
+	if fileContent == nil || dirContent != nil {
+		return nil, "", false, fmt.Errorf("%s is a directory at %s", filePath, ref)
+	}
+	if fileContent.GetSize() > maxDiffFileSize {
+		return nil, "", false, fmt.Errorf("%s is too large to diff at %s (%d bytes)", filePath, ref, fileContent.GetSize())
+	}
+
+	// Files over 1MB come without content, their blob is fetched instead
+	if fileContent.GetEncoding() == "none" {
+		data, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, fileContent.GetSHA())
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			return nil, "", false, fmt.Errorf("failed to get blob of %s at %s: %w", filePath, ref, err)
+		}
+		return data, fileContent.GetSHA(), true, nil
+	}
+	data, err := fileContent.GetContent()
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to decode %s at %s: %w", filePath, ref, err)
+	}
+	return []byte(data), fileContent.GetSHA(), true, nil
+}
+
+// commitToNewBranch commits tree entries on top of a commit and creates a branch pointing to the new commit.
+func commitToNewBranch(ctx context.Context, client *github.Client, owner, repo string, parent *github.Commit, entries []*github.TreeEntry, message, branch string) (*github.Commit, error) {
+	newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, parent.GetTree().GetSHA(), entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tree: %w", err)
+	}
+	_ = resp.Body.Close()
+	newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.Ptr(message),
+		Tree:    newTree,
+		Parents: []*github.Commit{{SHA: parent.SHA}},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
+	}
+	_ = resp.Body.Close()
+	_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.Ptr("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: newCommit.SHA},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	_ = resp.Body.Close()
+	return newCommit, nil
+}
+
+// DiffFileBetweenRefs creates a tool to get the unified diff of a single file between two refs.
+func DiffFileBetweenRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("diff_file_between_refs",
+			mcp.WithDescription(t("TOOL_DIFF_FILE_BETWEEN_REFS_DESCRIPTION", "Get the unified diff of a single file between two branches, tags or commits, e.g. to see what changed in a file since a release. Cheaper than comparing the whole refs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DIFF_FILE_BETWEEN_REFS_USER_TITLE", "Diff file between refs"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Branch, tag or commit SHA to diff from"),
+			),
+			mcp.WithString("head",
+				mcp.Description("Branch, tag or commit SHA to diff to, defaults to the repository's default branch"),
+			),
+			mcp.WithNumber("context_lines",
+				mcp.Description(fmt.Sprintf("Number of unchanged lines shown around each change (default %d)", defaultDiffContextLines)),
+				mcp.Min(0),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDiffFileBetweenRefsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Zero context lines is a valid choice, only a missing parameter gets the default
+			if _, ok := request.GetArguments()["context_lines"]; !ok {
+				params.ContextLines = defaultDiffContextLines
+			}
+			if params.ContextLines < 0 {
+				return mcp.NewToolResultError("context_lines must not be negative"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			baseContent, baseSHA, baseFound, err := getFileAtRef(ctx, client, params.Owner, params.Repo, params.Path, params.Base)
+			if err != nil {
+				return nil, err
+			}
+			headContent, headSHA, headFound, err := getFileAtRef(ctx, client, params.Owner, params.Repo, params.Path, params.Head)
+			if err != nil {
+				return nil, err
+			}
+
+			head := params.Head
+			if head == "" {
+				head = "the default branch"
+			}
+			if !baseFound && !headFound {
+				return mcp.NewToolResultError(fmt.Sprintf("%s exists neither at %s nor at %s", params.Path, params.Base, head)), nil
+			}
+			if baseFound && headFound && baseSHA == headSHA {
+				return mcp.NewToolResultText(fmt.Sprintf("No changes to %s between %s and %s", params.Path, params.Base, head)), nil
+			}
+
+			fromName, toName := "a/"+params.Path, "b/"+params.Path
+			if !baseFound {
+				fromName = "/dev/null"
+			}
+			if !headFound {
+				toName = "/dev/null"
+			}
+
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", params.Path, params.Path)
+			if (baseFound && isBinaryContent(baseContent)) || (headFound && isBinaryContent(headContent)) {
+				fmt.Fprintf(&sb, "Binary files %s and %s differ\n", fromName, toName)
+				return mcp.NewToolResultText(sb.String()), nil
+			}
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+			sb.WriteString(unifiedDiff(string(baseContent), string(headContent), params.ContextLines))
+
+			return mcp.NewToolResultText(sb.String()), nil
+		}
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +35,7 @@ func Test_GetFileContents(t *testing.T) {
 		Name:        github.Ptr("README.md"),
 		Path:        github.Ptr("README.md"),
 		Content:     github.Ptr("IyBUZXN0IFJlcG9zaXRvcnkKClRoaXMgaXMgYSB0ZXN0IHJlcG9zaXRvcnku"), // Base64 encoded "# Test Repository\n\nThis is a test repository."
+		Encoding:    github.Ptr("base64"),
 		SHA:         github.Ptr("abc123"),
 		Size:        github.Ptr(42),
 		HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/README.md"),
@@ -148,19 +151,26 @@ func Test_GetFileContents(t *testing.T) {
 
 			require.NoError(t, err)
 
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
 			// Verify based on expected type
 			switch expected := tc.expectedResult.(type) {
 			case *github.RepositoryContent:
+				// Files are returned as their metadata followed by the decoded content
+				require.Len(t, result.Content, 2)
+				metadata, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok)
 				var returnedContent github.RepositoryContent
-				err = json.Unmarshal([]byte(textContent.Text), &returnedContent)
+				err = json.Unmarshal([]byte(metadata.Text), &returnedContent)
 				require.NoError(t, err)
 				assert.Equal(t, *expected.Name, *returnedContent.Name)
 				assert.Equal(t, *expected.Path, *returnedContent.Path)
 				assert.Equal(t, *expected.Type, *returnedContent.Type)
+				assert.Nil(t, returnedContent.Content)
+
+				content, ok := result.Content[1].(mcp.TextContent)
+				require.True(t, ok)
+				assert.Equal(t, "# Test Repository\n\nThis is a test repository.", content.Text)
 			case []*github.RepositoryContent:
+				textContent := getTextResult(t, result)
 				var returnedContents []*github.RepositoryContent
 				err = json.Unmarshal([]byte(textContent.Text), &returnedContents)
 				require.NoError(t, err)
@@ -926,6 +936,85 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 	}
 }
 
+func Test_CreateOrUpdateFile_ExpectedSHA(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name: "unchanged file is updated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("README.md"), SHA: github.Ptr("abc123")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update README",
+						"content": "IyBVcGRhdGVk",
+						"branch":  "main",
+						"sha":     "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryContentResponse{}),
+					),
+				),
+			),
+		},
+		{
+			name: "changed file is refused",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{Type: github.Ptr("file"), Path: github.Ptr("README.md"), SHA: github.Ptr("def456")},
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "conflict: README.md has changed since it was read (expected abc123, found def456)",
+		},
+		{
+			name: "deleted file is refused",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "(expected abc123, found no file)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			tool, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+			assert.Contains(t, tool.InputSchema.Properties, "expected_sha")
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"path":         "README.md",
+				"content":      "# Updated",
+				"message":      "Update README",
+				"branch":       "main",
+				"expected_sha": "abc123",
+			}))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+		})
+	}
+}
+
 func Test_CreateRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1963,3 +2052,387 @@ func Test_GetTag(t *testing.T) {
 		})
 	}
 }
+
+func Test_SummarizeBranchChanges(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SummarizeBranchChanges(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "summarize_branch_changes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "include_paths")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "head"})
+
+	mockComparison := &github.CommitsComparison{
+		AheadBy:  github.Ptr(3),
+		BehindBy: github.Ptr(1),
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("pkg/server.go"), Additions: github.Ptr(10), Deletions: github.Ptr(2)},
+			{Filename: github.Ptr("pkg/server_test.go"), Additions: github.Ptr(20), Deletions: github.Ptr(0)},
+			{Filename: github.Ptr("docs/README.md"), Additions: github.Ptr(5), Deletions: github.Ptr(5)},
+			{Filename: github.Ptr("Makefile"), Additions: github.Ptr(1), Deletions: github.Ptr(1)},
+		},
+	}
+
+	codeownersHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/contents/.github/CODEOWNERS" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		assert.Equal(t, "main", r.URL.Query().Get("ref"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(mock.MustMarshal(&github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("*.go @org/gophers\n/docs/ @org/docs\n"))),
+		}))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedOwners map[string]int
+		expectedDirs   map[string]int
+		expectedFile   string
+	}{
+		{
+			name: "groups changes by owner and directory using the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/main...feature").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					codeownersHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"head":  "feature",
+			},
+			expectedOwners: map[string]int{"@org/gophers": 2, "@org/docs": 1, "(unowned)": 1},
+			expectedDirs:   map[string]int{"pkg": 2, "docs": 1, "/": 1},
+			expectedFile:   ".github/CODEOWNERS",
+		},
+		{
+			name: "no codeowners file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockComparison,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"head":  "feature",
+				"base":  "develop",
+			},
+			expectedOwners: map[string]int{"(unowned)": 4},
+			expectedDirs:   map[string]int{"pkg": 2, "docs": 1, "/": 1},
+		},
+		{
+			name: "comparison fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"head":  "missing",
+				"base":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SummarizeBranchChanges(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var summary branchChangeSummary
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summary))
+			assert.Equal(t, 3, summary.AheadBy)
+			assert.Equal(t, 4, summary.TotalFiles)
+			assert.Equal(t, 36, summary.TotalAdditions)
+			assert.Equal(t, 8, summary.TotalDeletions)
+			assert.Equal(t, tc.expectedFile, summary.CodeownersFile)
+
+			owners := map[string]int{}
+			for _, g := range summary.ByOwner {
+				owners[g.Name] = g.Files
+			}
+			assert.Equal(t, tc.expectedOwners, owners)
+
+			dirs := map[string]int{}
+			for _, g := range summary.ByDirectory {
+				dirs[g.Name] = g.Files
+			}
+			assert.Equal(t, tc.expectedDirs, dirs)
+		})
+	}
+}
+
+func Test_DiffFileBetweenRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DiffFileBetweenRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "diff_file_between_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "context_lines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "base"})
+
+	// versions maps refs to the file at that ref, refs not in the map do not have the file
+	contentsHandler := func(versions map[string]*github.RepositoryContent) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/contents/pkg/main.go", r.URL.Path)
+			file, ok := versions[r.URL.Query().Get("ref")]
+			if !ok {
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, file)(w, r)
+		}
+	}
+	file := func(sha, content string) *github.RepositoryContent {
+		return &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Name:     github.Ptr("main.go"),
+			Path:     github.Ptr("pkg/main.go"),
+			SHA:      github.Ptr(sha),
+			Size:     github.Ptr(len(content)),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedDiff   string
+	}{
+		{
+			name: "changed file against the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(map[string]*github.RepositoryContent{
+						"v1.2": file("aaa", "package main\n\nfunc main() {}\n"),
+						"":     file("bbb", "package main\n\nfunc main() {\n\tprintln(1)\n}\n"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/main.go",
+				"base":  "v1.2",
+			},
+			expectedDiff: "diff --git a/pkg/main.go b/pkg/main.go\n--- a/pkg/main.go\n+++ b/pkg/main.go\n" +
+				"@@ -1,3 +1,5 @@\n package main\n \n-func main() {}\n+func main() {\n+\tprintln(1)\n+}\n",
+		},
+		{
+			name: "no context lines",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(map[string]*github.RepositoryContent{
+						"v1.2": file("aaa", "package main\n\nfunc main() {}\n"),
+						"main": file("bbb", "package main\n\nfunc main() { println(1) }\n"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"path":          "pkg/main.go",
+				"base":          "v1.2",
+				"head":          "main",
+				"context_lines": float64(0),
+			},
+			expectedDiff: "diff --git a/pkg/main.go b/pkg/main.go\n--- a/pkg/main.go\n+++ b/pkg/main.go\n" +
+				"@@ -3 +3 @@\n-func main() {}\n+func main() { println(1) }\n",
+		},
+		{
+			name: "file added since base",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(map[string]*github.RepositoryContent{
+						"main": file("bbb", "package main\n"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/main.go",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectedDiff: "diff --git a/pkg/main.go b/pkg/main.go\n--- /dev/null\n+++ b/pkg/main.go\n" +
+				"@@ -0,0 +1 @@\n+package main\n",
+		},
+		{
+			name: "large file is fetched as a blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(map[string]*github.RepositoryContent{
+						"v1.2": file("aaa", "a\n"),
+						"main": {
+							Type:     github.Ptr("file"),
+							Path:     github.Ptr("pkg/main.go"),
+							SHA:      github.Ptr("bbb"),
+							Size:     github.Ptr(2 << 20),
+							Encoding: github.Ptr("none"),
+							Content:  github.Ptr(""),
+						},
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					expectPath(t, "/repos/owner/repo/git/blobs/bbb").andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte("b\n"))
+						},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/main.go",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectedDiff: "diff --git a/pkg/main.go b/pkg/main.go\n--- a/pkg/main.go\n+++ b/pkg/main.go\n" +
+				"@@ -1 +1 @@\n-a\n+b\n",
+		},
+		{
+			name: "binary file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(map[string]*github.RepositoryContent{
+						"v1.2": file("aaa", "\x00\x01"),
+						"main": file("bbb", "\x00\x02"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/main.go",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectedDiff: "diff --git a/pkg/main.go b/pkg/main.go\nBinary files a/pkg/main.go and b/pkg/main.go differ\n",
+		},
+		{
+			name: "unchanged file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(map[string]*github.RepositoryContent{
+						"v1.2": file("aaa", "package main\n"),
+						"main": file("aaa", "package main\n"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/main.go",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectedDiff: "No changes to pkg/main.go between v1.2 and main",
+		},
+		{
+			name: "file exists at neither ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentsHandler(map[string]*github.RepositoryContent{}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/main.go",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "pkg/main.go exists neither at v1.2 nor at main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DiffFileBetweenRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+			assert.Equal(t, tc.expectedDiff, textContent.Text)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -141,7 +140,7 @@ func RepositoryResourceContentsHandler(getClient GetClientFn) func(ctx context.C
 		if fileContent != nil {
 			if fileContent.Content != nil {
 				// download the file content from fileContent.GetDownloadURL() and use the content-type header to determine the MIME type
-				// and return the content as a blob unless it is a text file, where you can return the content as text
+				// and return the content as a blob unless it is text
 				req, err := http.NewRequest("GET", fileContent.GetDownloadURL(), nil)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create request: %w", err)
@@ -161,42 +160,13 @@ func RepositoryResourceContentsHandler(getClient GetClientFn) func(ctx context.C
 					return nil, fmt.Errorf("failed to fetch file content: %s", string(body))
 				}
 
-				ext := filepath.Ext(fileContent.GetName())
-				mimeType := resp.Header.Get("Content-Type")
-				if ext == ".md" {
-					mimeType = "text/markdown"
-				} else if mimeType == "" {
-					// backstop to the file extension if the content type is not set
-					mimeType = mime.TypeByExtension(filepath.Ext(fileContent.GetName()))
-				}
-
-				// if the content is a string, return it as text
-				if strings.HasPrefix(mimeType, "text") {
-					content, err := io.ReadAll(resp.Body)
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse the response body: %w", err)
-					}
-
-					return []mcp.ResourceContents{
-						mcp.TextResourceContents{
-							URI:      request.Params.URI,
-							MIMEType: mimeType,
-							Text:     string(content),
-						},
-					}, nil
-				}
-				// otherwise, read the content and encode it as base64
-				decodedContent, err := io.ReadAll(resp.Body)
+				content, err := io.ReadAll(resp.Body)
 				if err != nil {
 					return nil, fmt.Errorf("failed to parse the response body: %w", err)
 				}
 
 				return []mcp.ResourceContents{
-					mcp.BlobResourceContents{
-						URI:      request.Params.URI,
-						MIMEType: mimeType,
-						Blob:     base64.StdEncoding.EncodeToString(decodedContent), // Encode content as Base64
-					},
+					newResourceContents(request.Params.URI, fileContent.GetName(), content, resp.Header.Get("Content-Type")),
 				}, nil
 			}
 		}
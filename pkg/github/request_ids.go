@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// requestIDHeader is the header GitHub identifies each API request with, as found in its audit logs.
+const requestIDHeader = "X-GitHub-Request-Id"
+
+type requestIDsKey struct{}
+
+// requestIDs collects the GitHub request IDs of the API calls made while handling a tool call.
+type requestIDs struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *requestIDs) add(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, id)
+}
+
+func (r *requestIDs) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+// withRequestIDs returns a context collecting the request IDs of the API calls made with it.
+func withRequestIDs(ctx context.Context) (context.Context, *requestIDs) {
+	ids := &requestIDs{}
+	return context.WithValue(ctx, requestIDsKey{}, ids), ids
+}
+
+// RequestIDTransport wraps an HTTP transport so that the GitHub request ID of each API response is collected for
+// the tool call that made the request, for WithLogging to log along with the call.
+func RequestIDTransport(next http.RoundTripper) http.RoundTripper {
+	return &requestIDTransport{next: next}
+}
+
+type requestIDTransport struct {
+	next http.RoundTripper
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if resp != nil {
+		if ids, ok := req.Context().Value(requestIDsKey{}).(*requestIDs); ok {
+			if id := resp.Header.Get(requestIDHeader); id != "" {
+				ids.add(id)
+			}
+		}
+	}
+	return resp, err
+}
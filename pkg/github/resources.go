@@ -11,4 +11,5 @@ func RegisterResources(s *server.MCPServer, getClient GetClientFn, t translation
 	s.AddResourceTemplate(GetRepositoryResourceCommitContent(getClient, t))
 	s.AddResourceTemplate(GetRepositoryResourceTagContent(getClient, t))
 	s.AddResourceTemplate(GetRepositoryResourcePrContent(getClient, t))
+	s.AddResourceTemplate(GetRepositoryStatsResource(getClient, t))
 }
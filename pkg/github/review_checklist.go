@@ -0,0 +1,246 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxReviewChecklistFiles bounds the changed files of a pull request get_review_checklist matches, the API lists
+	// at most 3000.
+	maxReviewChecklistFiles = 3000
+	// maxReviewChecklistMatchedFiles bounds the matched files listed for each checklist.
+	maxReviewChecklistMatchedFiles = 20
+)
+
+// ReviewChecklist is a checklist reviewers go through when a pull request changes some paths, such as a security
+// review for auth/ or a schema review for migrations/.
+type ReviewChecklist struct {
+	Name string `mapstructure:"name"`
+	// Team owns the checklist, e.g. @org/security, and is reported to the reviewer.
+	Team string `mapstructure:"team"`
+	// Paths are the patterns of the files the checklist applies to, with the syntax of CODEOWNERS patterns.
+	Paths []string `mapstructure:"paths"`
+	Items []string `mapstructure:"items"`
+}
+
+// ValidateReviewChecklists checks that the checklists are named, have items, and that their path patterns are valid.
+func ValidateReviewChecklists(checklists []ReviewChecklist) error {
+	names := make(map[string]bool)
+	for i, checklist := range checklists {
+		if checklist.Name == "" {
+			return fmt.Errorf("review checklist %d has no name", i+1)
+		}
+		if names[checklist.Name] {
+			return fmt.Errorf("review checklist %s is defined twice", checklist.Name)
+		}
+		names[checklist.Name] = true
+		if len(checklist.Paths) == 0 {
+			return fmt.Errorf("review checklist %s has no paths", checklist.Name)
+		}
+		if len(checklist.Items) == 0 {
+			return fmt.Errorf("review checklist %s has no items", checklist.Name)
+		}
+		if _, err := checklist.matchers(); err != nil {
+			return fmt.Errorf("review checklist %s: %w", checklist.Name, err)
+		}
+	}
+	return nil
+}
+
+// matchers compiles the path patterns of the checklist.
+func (c ReviewChecklist) matchers() ([]*regexp.Regexp, error) {
+	matchers := make([]*regexp.Regexp, 0, len(c.Paths))
+	for _, pattern := range c.Paths {
+		if strings.TrimSpace(pattern) == "" {
+			return nil, errors.New("empty path pattern")
+		}
+		re, err := codeownersPatternToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+		matchers = append(matchers, re)
+	}
+	return matchers, nil
+}
+
+// matchedReviewChecklist is a checklist applying to a pull request, with the changed files it applies to.
+type matchedReviewChecklist struct {
+	Name      string   `json:"name"`
+	Team      string   `json:"team,omitempty"`
+	FileCount int      `json:"file_count"`
+	Files     []string `json:"files"`
+	Items     []string `json:"items"`
+}
+
+// reviewChecklistItem is an item of the merged checklist, with the checklists requiring it.
+type reviewChecklistItem struct {
+	Item       string   `json:"item"`
+	Checklists []string `json:"checklists"`
+}
+
+// pullRequestReviewChecklist is the result of get_review_checklist.
+type pullRequestReviewChecklist struct {
+	PullNumber   int                      `json:"pull_number"`
+	ChangedFiles int                      `json:"changed_files"`
+	Checklists   []matchedReviewChecklist `json:"checklists"`
+	// Items merges the items of the checklists, each item once, in the order the checklists are configured.
+	Items []reviewChecklistItem `json:"items"`
+	// Markdown renders the merged checklist as task list items, to post in a review.
+	Markdown string   `json:"markdown,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// matchReviewChecklists returns the checklists applying to the changed files, and their merged items.
+func matchReviewChecklists(checklists []ReviewChecklist, files []string) ([]matchedReviewChecklist, []reviewChecklistItem, error) {
+	matched := []matchedReviewChecklist{}
+	items := []reviewChecklistItem{}
+	itemIndex := make(map[string]int)
+	for _, checklist := range checklists {
+		matchers, err := checklist.matchers()
+		if err != nil {
+			return nil, nil, fmt.Errorf("review checklist %s: %w", checklist.Name, err)
+		}
+		m := matchedReviewChecklist{Name: checklist.Name, Team: checklist.Team, Files: []string{}, Items: checklist.Items}
+		for _, file := range files {
+			for _, re := range matchers {
+				if re.MatchString(file) {
+					m.FileCount++
+					if len(m.Files) < maxReviewChecklistMatchedFiles {
+						m.Files = append(m.Files, file)
+					}
+					break
+				}
+			}
+		}
+		if m.FileCount == 0 {
+			continue
+		}
+		matched = append(matched, m)
+		for _, item := range checklist.Items {
+			if i, ok := itemIndex[item]; ok {
+				items[i].Checklists = append(items[i].Checklists, checklist.Name)
+				continue
+			}
+			itemIndex[item] = len(items)
+			items = append(items, reviewChecklistItem{Item: item, Checklists: []string{checklist.Name}})
+		}
+	}
+	return matched, items, nil
+}
+
+// reviewChecklistMarkdown renders the checklists as a task list per checklist.
+func reviewChecklistMarkdown(checklists []matchedReviewChecklist) string {
+	var b strings.Builder
+	seen := make(map[string]bool)
+	for _, checklist := range checklists {
+		var items []string
+		for _, item := range checklist.Items {
+			if !seen[item] {
+				seen[item] = true
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "### %s", checklist.Name)
+		if checklist.Team != "" {
+			fmt.Fprintf(&b, " (%s)", checklist.Team)
+		}
+		b.WriteString("\n\n")
+		for _, item := range items {
+			fmt.Fprintf(&b, "- [ ] %s\n", item)
+		}
+	}
+	return b.String()
+}
+
+// GetReviewChecklist creates a tool to get the review checklists applying to the files changed by a pull request.
+func GetReviewChecklist(getClient GetClientFn, checklists []ReviewChecklist, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	names := make([]string, len(checklists))
+	for i, checklist := range checklists {
+		names[i] = checklist.Name
+	}
+	return mcp.NewTool("get_review_checklist",
+			mcp.WithDescription(t("TOOL_GET_REVIEW_CHECKLIST_DESCRIPTION", fmt.Sprintf("Get the review checklists configured for the paths a pull request changes, merged into one list of items to check, with the files each checklist applies to and the team owning it. Configured checklists: %s.", strings.Join(names, ", ")))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REVIEW_CHECKLIST_USER_TITLE", "Get review checklist"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetReviewChecklistParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := pullRequestReviewChecklist{PullNumber: params.PullNumber}
+			var files []string
+			opts := &github.ListOptions{PerPage: 100}
+			for {
+				page, resp, err := client.PullRequests.ListFiles(ctx, params.Owner, params.Repo, params.PullNumber, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list pull request files: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, file := range page {
+					files = append(files, file.GetFilename())
+					// Moving a file out of a path still needs the review of that path
+					if file.GetPreviousFilename() != "" {
+						files = append(files, file.GetPreviousFilename())
+					}
+					result.ChangedFiles++
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				if result.ChangedFiles >= maxReviewChecklistFiles {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("only the first %d changed files were matched", result.ChangedFiles))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			result.Checklists, result.Items, err = matchReviewChecklists(checklists, files)
+			if err != nil {
+				return nil, err
+			}
+			result.Markdown = reviewChecklistMarkdown(result.Checklists)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
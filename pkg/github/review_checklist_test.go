@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateReviewChecklists(t *testing.T) {
+	valid := ReviewChecklist{Name: "security", Paths: []string{"/auth/"}, Items: []string{"Threat model updated"}}
+	require.NoError(t, ValidateReviewChecklists([]ReviewChecklist{valid}))
+
+	tests := []struct {
+		name        string
+		checklists  []ReviewChecklist
+		expectedErr string
+	}{
+		{name: "no name", checklists: []ReviewChecklist{{Paths: valid.Paths, Items: valid.Items}}, expectedErr: "review checklist 1 has no name"},
+		{name: "defined twice", checklists: []ReviewChecklist{valid, valid}, expectedErr: "review checklist security is defined twice"},
+		{name: "no paths", checklists: []ReviewChecklist{{Name: "security", Items: valid.Items}}, expectedErr: "review checklist security has no paths"},
+		{name: "no items", checklists: []ReviewChecklist{{Name: "security", Paths: valid.Paths}}, expectedErr: "review checklist security has no items"},
+		{name: "empty path", checklists: []ReviewChecklist{{Name: "security", Paths: []string{" "}, Items: valid.Items}}, expectedErr: "empty path pattern"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateReviewChecklists(tc.checklists)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func Test_GetReviewChecklist(t *testing.T) {
+	checklists := []ReviewChecklist{
+		{
+			Name:  "security",
+			Team:  "@acme/security",
+			Paths: []string{"/auth/", "*.pem"},
+			Items: []string{"Secrets are not logged", "Tests cover the change"},
+		},
+		{
+			Name:  "schema",
+			Paths: []string{"/migrations/"},
+			Items: []string{"Migration is reversible", "Tests cover the change"},
+		},
+		{
+			Name:  "docs",
+			Paths: []string{"/docs/"},
+			Items: []string{"Screenshots are updated"},
+		},
+	}
+
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetReviewChecklist(stubGetClientFn(mockClient), checklists, translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_review_checklist", tool.Name)
+	assert.Contains(t, tool.Description, "security, schema, docs")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("auth/session.go")},
+		{Filename: github.Ptr("config/server.pem")},
+		{Filename: github.Ptr("db/001_users.sql"), PreviousFilename: github.Ptr("migrations/001_users.sql")},
+		{Filename: github.Ptr("README.md")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       pullRequestReviewChecklist
+	}{
+		{
+			name: "merges the checklists of the changed paths",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					expectPath(t, "/repos/owner/repo/pulls/42/files").andThen(mockResponse(t, http.StatusOK, files)),
+				),
+			),
+			expected: pullRequestReviewChecklist{
+				PullNumber:   42,
+				ChangedFiles: 4,
+				Checklists: []matchedReviewChecklist{
+					{
+						Name:      "security",
+						Team:      "@acme/security",
+						FileCount: 2,
+						Files:     []string{"auth/session.go", "config/server.pem"},
+						Items:     []string{"Secrets are not logged", "Tests cover the change"},
+					},
+					{
+						Name:      "schema",
+						FileCount: 1,
+						Files:     []string{"migrations/001_users.sql"},
+						Items:     []string{"Migration is reversible", "Tests cover the change"},
+					},
+				},
+				Items: []reviewChecklistItem{
+					{Item: "Secrets are not logged", Checklists: []string{"security"}},
+					{Item: "Tests cover the change", Checklists: []string{"security", "schema"}},
+					{Item: "Migration is reversible", Checklists: []string{"schema"}},
+				},
+				Markdown: "### security (@acme/security)\n\n- [ ] Secrets are not logged\n- [ ] Tests cover the change\n\n### schema\n\n- [ ] Migration is reversible\n",
+			},
+		},
+		{
+			name: "no checklist applies",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					[]*github.CommitFile{{Filename: github.Ptr("main.go")}},
+				),
+			),
+			expected: pullRequestReviewChecklist{
+				PullNumber:   42,
+				ChangedFiles: 1,
+				Checklists:   []matchedReviewChecklist{},
+				Items:        []reviewChecklistItem{},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list pull request files",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetReviewChecklist(stubGetClientFn(client), checklists, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned pullRequestReviewChecklist
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
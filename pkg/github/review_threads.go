@@ -0,0 +1,315 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// maxReviewThreads bounds the review threads list_pull_request_review_threads collects from a pull request.
+	maxReviewThreads = 500
+	// maxReviewThreadComments bounds the comments returned per review thread, the first ones carrying the
+	// discussion.
+	maxReviewThreadComments = 50
+)
+
+// reviewThreadNode is a review thread of a pull request as queried by list_pull_request_review_threads.
+type reviewThreadNode struct {
+	ID         githubv4.ID
+	IsResolved githubv4.Boolean
+	IsOutdated githubv4.Boolean
+	Path       githubv4.String
+	Line       *githubv4.Int
+	StartLine  *githubv4.Int
+	DiffSide   githubv4.String
+	ResolvedBy struct {
+		Login githubv4.String
+	}
+	Comments struct {
+		TotalCount githubv4.Int
+		Nodes      []struct {
+			DatabaseID githubv4.Int `graphql:"databaseId"`
+			Author     struct {
+				Login githubv4.String
+			}
+			Body      githubv4.String
+			CreatedAt githubv4.DateTime
+			URL       githubv4.URI
+		}
+	} `graphql:"comments(first: 50)"`
+}
+
+// reviewThreadsQuery is a page of the review threads of a pull request.
+type reviewThreadsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes    []reviewThreadNode
+				PageInfo GraphQLPageInfo
+			} `graphql:"reviewThreads(first: $first, after: $cursor)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// reviewThreadComment is a comment of a review thread.
+type reviewThreadComment struct {
+	ID        int64  `json:"id"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	URL       string `json:"url"`
+}
+
+// reviewThread is a review thread as returned by list_pull_request_review_threads.
+type reviewThread struct {
+	// ID is the node ID resolve_review_thread and unresolve_review_thread take.
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	Line       int    `json:"line,omitempty"`
+	StartLine  int    `json:"start_line,omitempty"`
+	Side       string `json:"side,omitempty"`
+	Resolved   bool   `json:"resolved"`
+	ResolvedBy string `json:"resolved_by,omitempty"`
+	// Outdated threads are on lines the pull request has changed since.
+	Outdated     bool                  `json:"outdated"`
+	CommentCount int                   `json:"comment_count"`
+	Comments     []reviewThreadComment `json:"comments"`
+}
+
+// newReviewThread converts a queried review thread.
+func newReviewThread(node reviewThreadNode) reviewThread {
+	thread := reviewThread{
+		ID:           fmt.Sprintf("%v", node.ID),
+		Path:         string(node.Path),
+		Side:         string(node.DiffSide),
+		Resolved:     bool(node.IsResolved),
+		ResolvedBy:   string(node.ResolvedBy.Login),
+		Outdated:     bool(node.IsOutdated),
+		CommentCount: int(node.Comments.TotalCount),
+		Comments:     make([]reviewThreadComment, 0, len(node.Comments.Nodes)),
+	}
+	if node.Line != nil {
+		thread.Line = int(*node.Line)
+	}
+	if node.StartLine != nil {
+		thread.StartLine = int(*node.StartLine)
+	}
+	for _, c := range node.Comments.Nodes {
+		thread.Comments = append(thread.Comments, reviewThreadComment{
+			ID:        int64(c.DatabaseID),
+			Author:    string(c.Author.Login),
+			Body:      string(c.Body),
+			CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
+			URL:       c.URL.String(),
+		})
+	}
+	return thread
+}
+
+// reviewThreads is the result of list_pull_request_review_threads.
+type reviewThreads struct {
+	Threads []reviewThread `json:"threads"`
+	// Truncated is set when the pull request has more review threads than were collected.
+	Truncated bool `json:"truncated"`
+}
+
+// ListPullRequestReviewThreads creates a tool to list the review threads of a pull request with their resolution.
+func ListPullRequestReviewThreads(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pull_request_review_threads",
+			mcp.WithDescription(t("TOOL_LIST_PULL_REQUEST_REVIEW_THREADS_DESCRIPTION", fmt.Sprintf("List the review threads of a pull request, with whether each is resolved or outdated, its location in the diff and its comments (the first %d per thread). The thread IDs are the ones resolve_review_thread and unresolve_review_thread take.", maxReviewThreadComments))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PULL_REQUEST_REVIEW_THREADS_USER_TITLE", "List pull request review threads"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter the threads by resolution, defaults to all"),
+				mcp.Enum("all", "resolved", "unresolved"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListPullRequestReviewThreadsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			nodes, err := PaginateGraphQL(ctx, GraphQLPaginationOptions{MaxNodes: maxReviewThreads}, func(ctx context.Context, first githubv4.Int, after *githubv4.String) (GraphQLPage[reviewThreadNode], error) {
+				var query reviewThreadsQuery
+				err := client.Query(ctx, &query, map[string]any{
+					"owner":  githubv4.String(params.Owner),
+					"name":   githubv4.String(params.Repo),
+					"prNum":  githubv4.Int(int32(params.PullNumber)), // #nosec G115 - pull request numbers are well below 2^31
+					"first":  first,
+					"cursor": after,
+				})
+				return GraphQLPage[reviewThreadNode]{
+					Nodes:    query.Repository.PullRequest.ReviewThreads.Nodes,
+					PageInfo: query.Repository.PullRequest.ReviewThreads.PageInfo,
+				}, err
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			result := reviewThreads{Threads: []reviewThread{}, Truncated: nodes.Truncated}
+			for _, node := range nodes.Nodes {
+				if (params.State == "resolved" && !node.IsResolved) || (params.State == "unresolved" && node.IsResolved) {
+					continue
+				}
+				result.Threads = append(result.Threads, newReviewThread(node))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ResolveReviewThread creates a tool to resolve a review thread of a pull request, optionally replying to it first.
+func ResolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("resolve_review_thread",
+			mcp.WithDescription(t("TOOL_RESOLVE_REVIEW_THREAD_DESCRIPTION", "Resolve a review thread of a pull request, e.g. once the change it asks for is made. A reply, such as the commit addressing it, can be posted to the thread before it is resolved. Get the thread ID from list_pull_request_review_threads.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESOLVE_REVIEW_THREAD_USER_TITLE", "Resolve pull request review thread"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("threadID",
+				mcp.Required(),
+				mcp.Description("Node ID of the review thread"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Reply to post to the thread before resolving it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseResolveReviewThreadParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var replyURL string
+			if strings.TrimSpace(params.Body) != "" {
+				var addReply struct {
+					AddPullRequestReviewThreadReply struct {
+						Comment struct {
+							URL githubv4.URI
+						}
+					} `graphql:"addPullRequestReviewThreadReply(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &addReply, githubv4.AddPullRequestReviewThreadReplyInput{
+					PullRequestReviewThreadID: githubv4.ID(params.ThreadID),
+					Body:                      githubv4.String(params.Body),
+				}, nil); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to reply to the thread: %s", err.Error())), nil
+				}
+				replyURL = addReply.AddPullRequestReviewThreadReply.Comment.URL.String()
+			}
+
+			var resolve struct {
+				ResolveReviewThread struct {
+					Thread struct {
+						ID         githubv4.ID
+						IsResolved githubv4.Boolean
+					}
+				} `graphql:"resolveReviewThread(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &resolve, githubv4.ResolveReviewThreadInput{
+				ThreadID: githubv4.ID(params.ThreadID),
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve the thread: %s", err.Error())), nil
+			}
+
+			result := map[string]any{
+				"id":       resolve.ResolveReviewThread.Thread.ID,
+				"resolved": resolve.ResolveReviewThread.Thread.IsResolved,
+			}
+			if replyURL != "" {
+				result["reply_url"] = replyURL
+			}
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UnresolveReviewThread creates a tool to reopen a resolved review thread of a pull request.
+func UnresolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unresolve_review_thread",
+			mcp.WithDescription(t("TOOL_UNRESOLVE_REVIEW_THREAD_DESCRIPTION", "Reopen a resolved review thread of a pull request. Get the thread ID from list_pull_request_review_threads.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNRESOLVE_REVIEW_THREAD_USER_TITLE", "Unresolve pull request review thread"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("threadID",
+				mcp.Required(),
+				mcp.Description("Node ID of the review thread"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseUnresolveReviewThreadParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var unresolve struct {
+				UnresolveReviewThread struct {
+					Thread struct {
+						ID         githubv4.ID
+						IsResolved githubv4.Boolean
+					}
+				} `graphql:"unresolveReviewThread(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &unresolve, githubv4.UnresolveReviewThreadInput{
+				ThreadID: githubv4.ID(params.ThreadID),
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to unresolve the thread: %s", err.Error())), nil
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"id":       unresolve.UnresolveReviewThread.Thread.ID,
+				"resolved": unresolve.UnresolveReviewThread.Thread.IsResolved,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,286 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPullRequestReviewThreads(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListPullRequestReviewThreads(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pull_request_review_threads", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	threadsQuery := func(nodes ...any) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			reviewThreadsQuery{},
+			map[string]any{
+				"owner":  githubv4.String("owner"),
+				"name":   githubv4.String("repo"),
+				"prNum":  githubv4.Int(42),
+				"first":  githubv4.Int(100),
+				"cursor": (*githubv4.String)(nil),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"reviewThreads": map[string]any{
+							"nodes":    nodes,
+							"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "c1"},
+						},
+					},
+				},
+			}),
+		)
+	}
+	resolvedThread := map[string]any{
+		"id":         "PRRT_1",
+		"isResolved": true,
+		"isOutdated": true,
+		"path":       "main.go",
+		"line":       nil,
+		"startLine":  nil,
+		"diffSide":   "RIGHT",
+		"resolvedBy": map[string]any{"login": "octocat"},
+		"comments": map[string]any{
+			"totalCount": 1,
+			"nodes": []any{
+				map[string]any{"databaseId": 11, "author": map[string]any{"login": "reviewer"}, "body": "Typo", "createdAt": "2024-05-01T10:00:00Z", "url": "https://github.com/owner/repo/pull/42#discussion_r11"},
+			},
+		},
+	}
+	openThread := map[string]any{
+		"id":         "PRRT_2",
+		"isResolved": false,
+		"isOutdated": false,
+		"path":       "server.go",
+		"line":       20,
+		"startLine":  18,
+		"diffSide":   "RIGHT",
+		"resolvedBy": nil,
+		"comments": map[string]any{
+			"totalCount": 2,
+			"nodes": []any{
+				map[string]any{"databaseId": 21, "author": map[string]any{"login": "reviewer"}, "body": "Handle the error", "createdAt": "2024-05-01T10:05:00Z", "url": "https://github.com/owner/repo/pull/42#discussion_r21"},
+				map[string]any{"databaseId": 22, "author": nil, "body": "Agreed", "createdAt": "2024-05-01T11:00:00Z", "url": "https://github.com/owner/repo/pull/42#discussion_r22"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		expectedThreads []string
+	}{
+		{
+			name:            "all threads",
+			requestArgs:     map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectedThreads: []string{"PRRT_1", "PRRT_2"},
+		},
+		{
+			name:            "unresolved threads",
+			requestArgs:     map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42), "state": "unresolved"},
+			expectedThreads: []string{"PRRT_2"},
+		},
+		{
+			name:            "resolved threads",
+			requestArgs:     map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42), "state": "resolved"},
+			expectedThreads: []string{"PRRT_1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(threadsQuery(resolvedThread, openThread)))
+			_, handler := ListPullRequestReviewThreads(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned reviewThreads
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.False(t, returned.Truncated)
+			ids := make([]string, 0, len(returned.Threads))
+			for _, thread := range returned.Threads {
+				ids = append(ids, thread.ID)
+			}
+			assert.Equal(t, tc.expectedThreads, ids)
+		})
+	}
+
+	t.Run("threads are converted", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(threadsQuery(resolvedThread, openThread)))
+		_, handler := ListPullRequestReviewThreads(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)}))
+		require.NoError(t, err)
+		var returned reviewThreads
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		require.Len(t, returned.Threads, 2)
+		assert.Equal(t, reviewThread{
+			ID:           "PRRT_1",
+			Path:         "main.go",
+			Side:         "RIGHT",
+			Resolved:     true,
+			ResolvedBy:   "octocat",
+			Outdated:     true,
+			CommentCount: 1,
+			Comments: []reviewThreadComment{
+				{ID: 11, Author: "reviewer", Body: "Typo", CreatedAt: "2024-05-01T10:00:00Z", URL: "https://github.com/owner/repo/pull/42#discussion_r11"},
+			},
+		}, returned.Threads[0])
+		assert.Equal(t, 20, returned.Threads[1].Line)
+		assert.Equal(t, 18, returned.Threads[1].StartLine)
+		assert.Equal(t, 2, returned.Threads[1].CommentCount)
+		assert.Empty(t, returned.Threads[1].Comments[1].Author)
+	})
+}
+
+func Test_ResolveReviewThread(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ResolveReviewThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "resolve_review_thread", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "threadID")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"threadID"})
+
+	addReply := githubv4mock.NewMutationMatcher(
+		struct {
+			AddPullRequestReviewThreadReply struct {
+				Comment struct {
+					URL githubv4.URI
+				}
+			} `graphql:"addPullRequestReviewThreadReply(input: $input)"`
+		}{},
+		githubv4.AddPullRequestReviewThreadReplyInput{
+			PullRequestReviewThreadID: githubv4.ID("PRRT_2"),
+			Body:                      githubv4.String("Fixed in abc123"),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"addPullRequestReviewThreadReply": map[string]any{
+				"comment": map[string]any{"url": "https://github.com/owner/repo/pull/42#discussion_r23"},
+			},
+		}),
+	)
+	resolve := githubv4mock.NewMutationMatcher(
+		struct {
+			ResolveReviewThread struct {
+				Thread struct {
+					ID         githubv4.ID
+					IsResolved githubv4.Boolean
+				}
+			} `graphql:"resolveReviewThread(input: $input)"`
+		}{},
+		githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_2")},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"resolveReviewThread": map[string]any{
+				"thread": map[string]any{"id": "PRRT_2", "isResolved": true},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedResult map[string]any
+	}{
+		{
+			name:           "resolve",
+			requestArgs:    map[string]any{"threadID": "PRRT_2"},
+			expectedResult: map[string]any{"id": "PRRT_2", "resolved": true},
+		},
+		{
+			name:           "reply and resolve",
+			requestArgs:    map[string]any{"threadID": "PRRT_2", "body": "Fixed in abc123"},
+			expectedResult: map[string]any{"id": "PRRT_2", "resolved": true, "reply_url": "https://github.com/owner/repo/pull/42#discussion_r23"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(addReply, resolve))
+			_, handler := ResolveReviewThread(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+
+	t.Run("failed reply leaves the thread unresolved", func(t *testing.T) {
+		// Only the resolution is mocked, the reply fails
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(resolve))
+		_, handler := ResolveReviewThread(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"threadID": "PRRT_2", "body": "Fixed in abc123"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "failed to reply to the thread")
+	})
+}
+
+func Test_UnresolveReviewThread(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := UnresolveReviewThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "unresolve_review_thread", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "threadID")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"threadID"})
+
+	unresolve := githubv4mock.NewMutationMatcher(
+		struct {
+			UnresolveReviewThread struct {
+				Thread struct {
+					ID         githubv4.ID
+					IsResolved githubv4.Boolean
+				}
+			} `graphql:"unresolveReviewThread(input: $input)"`
+		}{},
+		githubv4.UnresolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"unresolveReviewThread": map[string]any{
+				"thread": map[string]any{"id": "PRRT_1", "isResolved": false},
+			},
+		}),
+	)
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(unresolve))
+	_, handler := UnresolveReviewThread(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"threadID": "PRRT_1"}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var returned map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, map[string]any{"id": "PRRT_1", "resolved": false}, returned)
+}
@@ -0,0 +1,282 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// configEntry is a secret or variable available to the GitHub Actions workflows of a repository. Values are never
+// included.
+type configEntry struct {
+	Name string `json:"name"`
+	// Scope is "repository", "environment:<name>" or "organization".
+	Scope      string    `json:"scope"`
+	Visibility string    `json:"visibility,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// shadowedConfig is a secret or variable name defined in several scopes, the first scope taking precedence.
+type shadowedConfig struct {
+	Name   string   `json:"name"`
+	Kind   string   `json:"kind"`
+	Scopes []string `json:"scopes"`
+}
+
+// secretsInventory is the result of inventory_secrets_and_vars.
+type secretsInventory struct {
+	Repository   string           `json:"repository"`
+	Environments []string         `json:"environments"`
+	Secrets      []configEntry    `json:"secrets"`
+	Variables    []configEntry    `json:"variables"`
+	Shadowed     []shadowedConfig `json:"shadowed"`
+
+	// Unavailable holds, by scope, why its secrets or variables could not be listed, e.g. because the token lacks
+	// the permission.
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+}
+
+// scopePrecedence orders scopes as GitHub Actions resolves names: environment first, then repository, then
+// organization.
+func scopePrecedence(scope string) int {
+	switch scope {
+	case "organization":
+		return 2
+	case "repository":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// listConfigPages fetches the pages of a secret or variable listing.
+func listConfigPages(list func(opts *github.ListOptions) ([]configEntry, *github.Response, error)) ([]configEntry, error) {
+	var all []configEntry
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		entries, resp, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		all = append(all, entries...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listEnvSecrets lists the secrets of an environment. The client only has the legacy endpoint addressing the
+// repository by ID, so the current one is called directly.
+func listEnvSecrets(ctx context.Context, client *github.Client, owner, repo, env string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	u := fmt.Sprintf("repos/%s/%s/environments/%s/secrets?per_page=%d&page=%d", owner, repo, url.PathEscape(env), opts.PerPage, opts.Page)
+	req, err := client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	secrets := new(github.Secrets)
+	resp, err := client.Do(ctx, req, secrets)
+	if err != nil {
+		return nil, resp, err
+	}
+	return secrets, resp, nil
+}
+
+// secretEntries converts listed secrets for a scope.
+func secretEntries(scope string, secrets *github.Secrets) []configEntry {
+	var entries []configEntry
+	for _, s := range secrets.Secrets {
+		entries = append(entries, configEntry{
+			Name:       s.Name,
+			Scope:      scope,
+			Visibility: s.Visibility,
+			CreatedAt:  s.CreatedAt.Time,
+			UpdatedAt:  s.UpdatedAt.Time,
+		})
+	}
+	return entries
+}
+
+// variableEntries converts listed variables for a scope, dropping their values.
+func variableEntries(scope string, variables *github.ActionsVariables) []configEntry {
+	var entries []configEntry
+	for _, v := range variables.Variables {
+		entries = append(entries, configEntry{
+			Name:       v.Name,
+			Scope:      scope,
+			Visibility: v.GetVisibility(),
+			CreatedAt:  v.GetCreatedAt().Time,
+			UpdatedAt:  v.GetUpdatedAt().Time,
+		})
+	}
+	return entries
+}
+
+// shadowedConfigs finds the names defined in several scopes.
+func shadowedConfigs(kind string, entries []configEntry) []shadowedConfig {
+	scopes := make(map[string][]string)
+	for _, e := range entries {
+		scopes[e.Name] = append(scopes[e.Name], e.Scope)
+	}
+	var shadowed []shadowedConfig
+	for name, s := range scopes {
+		if len(s) < 2 {
+			continue
+		}
+		sort.SliceStable(s, func(i, j int) bool { return scopePrecedence(s[i]) < scopePrecedence(s[j]) })
+		shadowed = append(shadowed, shadowedConfig{Name: name, Kind: kind, Scopes: s})
+	}
+	sort.Slice(shadowed, func(i, j int) bool { return shadowed[i].Name < shadowed[j].Name })
+	return shadowed
+}
+
+// InventorySecretsAndVars creates a tool to list the names of the secrets and variables available to the workflows of
+// a repository.
+func InventorySecretsAndVars(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("inventory_secrets_and_vars",
+			mcp.WithDescription(t("TOOL_INVENTORY_SECRETS_AND_VARS_DESCRIPTION", "List the names of the GitHub Actions secrets and variables available to a repository, defined in the repository, its environments or its organization, with when they were created and last updated. Values are never returned. Names defined in several scopes are listed as shadowed, the environment taking precedence over the repository, and the repository over the organization. Scopes the token cannot read are listed as unavailable.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_INVENTORY_SECRETS_AND_VARS_USER_TITLE", "Inventory secrets and variables"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("include_environments",
+				mcp.Description("Include the secrets and variables of the environments of the repository, defaults to true"),
+			),
+			mcp.WithBoolean("include_organization",
+				mcp.Description("Include the organization secrets and variables shared with the repository, defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseInventorySecretsAndVarsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["include_environments"]; !ok {
+				params.IncludeEnvironments = true
+			}
+			if _, ok := request.GetArguments()["include_organization"]; !ok {
+				params.IncludeOrganization = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			inventory := secretsInventory{
+				Repository:   params.Owner + "/" + params.Repo,
+				Environments: []string{},
+				Secrets:      []configEntry{},
+				Variables:    []configEntry{},
+				Unavailable:  map[string]string{},
+			}
+			collect := func(scope string, secrets, variables func(opts *github.ListOptions) ([]configEntry, *github.Response, error)) {
+				s, err := listConfigPages(secrets)
+				if err != nil {
+					inventory.Unavailable[scope+" secrets"] = err.Error()
+				}
+				inventory.Secrets = append(inventory.Secrets, s...)
+				v, err := listConfigPages(variables)
+				if err != nil {
+					inventory.Unavailable[scope+" variables"] = err.Error()
+				}
+				inventory.Variables = append(inventory.Variables, v...)
+			}
+
+			collect("repository",
+				func(opts *github.ListOptions) ([]configEntry, *github.Response, error) {
+					secrets, resp, err := client.Actions.ListRepoSecrets(ctx, params.Owner, params.Repo, opts)
+					if err != nil {
+						return nil, resp, err
+					}
+					return secretEntries("repository", secrets), resp, nil
+				},
+				func(opts *github.ListOptions) ([]configEntry, *github.Response, error) {
+					variables, resp, err := client.Actions.ListRepoVariables(ctx, params.Owner, params.Repo, opts)
+					if err != nil {
+						return nil, resp, err
+					}
+					return variableEntries("repository", variables), resp, nil
+				},
+			)
+
+			if params.IncludeEnvironments {
+				environments, resp, err := client.Repositories.ListEnvironments(ctx, params.Owner, params.Repo, &github.EnvironmentListOptions{ListOptions: github.ListOptions{PerPage: 100}})
+				if err != nil {
+					inventory.Unavailable["environments"] = err.Error()
+				} else {
+					_ = resp.Body.Close()
+					for _, env := range environments.Environments {
+						name := env.GetName()
+						scope := "environment:" + name
+						inventory.Environments = append(inventory.Environments, name)
+						collect(scope,
+							func(opts *github.ListOptions) ([]configEntry, *github.Response, error) {
+								secrets, resp, err := listEnvSecrets(ctx, client, params.Owner, params.Repo, name, opts)
+								if err != nil {
+									return nil, resp, err
+								}
+								return secretEntries(scope, secrets), resp, nil
+							},
+							func(opts *github.ListOptions) ([]configEntry, *github.Response, error) {
+								variables, resp, err := client.Actions.ListEnvVariables(ctx, params.Owner, params.Repo, name, opts)
+								if err != nil {
+									return nil, resp, err
+								}
+								return variableEntries(scope, variables), resp, nil
+							},
+						)
+					}
+				}
+			}
+
+			if params.IncludeOrganization {
+				collect("organization",
+					func(opts *github.ListOptions) ([]configEntry, *github.Response, error) {
+						secrets, resp, err := client.Actions.ListRepoOrgSecrets(ctx, params.Owner, params.Repo, opts)
+						if err != nil {
+							return nil, resp, err
+						}
+						return secretEntries("organization", secrets), resp, nil
+					},
+					func(opts *github.ListOptions) ([]configEntry, *github.Response, error) {
+						variables, resp, err := client.Actions.ListRepoOrgVariables(ctx, params.Owner, params.Repo, opts)
+						if err != nil {
+							return nil, resp, err
+						}
+						return variableEntries("organization", variables), resp, nil
+					},
+				)
+			}
+
+			inventory.Shadowed = append(shadowedConfigs("secret", inventory.Secrets), shadowedConfigs("variable", inventory.Variables)...)
+			if inventory.Shadowed == nil {
+				inventory.Shadowed = []shadowedConfig{}
+			}
+
+			r, err := json.Marshal(inventory)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InventorySecretsAndVars(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := InventorySecretsAndVars(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "inventory_secrets_and_vars", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "include_environments")
+	assert.Contains(t, tool.InputSchema.Properties, "include_organization")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	updatedAt := github.Timestamp{Time: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)}
+	options := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposActionsSecretsByOwnerByRepo, &github.Secrets{Secrets: []*github.Secret{
+				{Name: "DEPLOY_KEY", UpdatedAt: updatedAt},
+				{Name: "NPM_TOKEN", UpdatedAt: updatedAt},
+			}}),
+			mock.WithRequestMatch(mock.GetReposActionsVariablesByOwnerByRepo, &github.ActionsVariables{Variables: []*github.ActionsVariable{
+				{Name: "REGION", Value: "eu-west-1", UpdatedAt: &updatedAt},
+			}}),
+			mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepo, &github.EnvResponse{Environments: []*github.Environment{
+				{Name: github.Ptr("production")},
+			}}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposEnvironmentsSecretsByOwnerByRepoByEnvironmentName,
+				expectPath(t, "/repos/owner/repo/environments/production/secrets").andThen(
+					mockResponse(t, http.StatusOK, &github.Secrets{Secrets: []*github.Secret{{Name: "DEPLOY_KEY"}}}),
+				),
+			),
+			mock.WithRequestMatch(mock.GetReposEnvironmentsVariablesByOwnerByRepoByEnvironmentName, &github.ActionsVariables{Variables: []*github.ActionsVariable{
+				{Name: "REGION", Value: "us-east-1"},
+				{Name: "URL", Value: "https://example.com"},
+			}}),
+			mock.WithRequestMatch(mock.GetReposActionsOrganizationSecretsByOwnerByRepo, &github.Secrets{Secrets: []*github.Secret{
+				{Name: "NPM_TOKEN", Visibility: "all"},
+			}}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsOrganizationVariablesByOwnerByRepo,
+				mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+			),
+		}
+	}
+
+	t.Run("lists secrets and variables of every scope", func(t *testing.T) {
+		_, handler := InventorySecretsAndVars(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.NotContains(t, textContent.Text, "eu-west-1", "variable values are never returned")
+
+		var inventory secretsInventory
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &inventory))
+		assert.Equal(t, "owner/repo", inventory.Repository)
+		assert.Equal(t, []string{"production"}, inventory.Environments)
+		require.Len(t, inventory.Secrets, 4)
+		assert.Equal(t, configEntry{Name: "DEPLOY_KEY", Scope: "repository", UpdatedAt: updatedAt.Time}, inventory.Secrets[0])
+		assert.Equal(t, "environment:production", inventory.Secrets[2].Scope)
+		assert.Equal(t, configEntry{Name: "NPM_TOKEN", Scope: "organization", Visibility: "all"}, inventory.Secrets[3])
+		require.Len(t, inventory.Variables, 3)
+		assert.Equal(t, []shadowedConfig{
+			{Name: "DEPLOY_KEY", Kind: "secret", Scopes: []string{"environment:production", "repository"}},
+			{Name: "NPM_TOKEN", Kind: "secret", Scopes: []string{"repository", "organization"}},
+			{Name: "REGION", Kind: "variable", Scopes: []string{"environment:production", "repository"}},
+		}, inventory.Shadowed)
+		require.Len(t, inventory.Unavailable, 1)
+		assert.Contains(t, inventory.Unavailable["organization variables"], "Resource not accessible")
+	})
+
+	t.Run("lists repository secrets and variables only", func(t *testing.T) {
+		_, handler := InventorySecretsAndVars(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":                "owner",
+			"repo":                 "repo",
+			"include_environments": false,
+			"include_organization": false,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var inventory secretsInventory
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &inventory))
+		assert.Empty(t, inventory.Environments)
+		assert.Len(t, inventory.Secrets, 2)
+		assert.Len(t, inventory.Variables, 1)
+		assert.Empty(t, inventory.Shadowed)
+		assert.Empty(t, inventory.Unavailable)
+	})
+}
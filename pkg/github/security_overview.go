@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxSecurityOverviewPages bounds the pages of alerts fetched for each kind of alert by get_org_security_overview.
+const maxSecurityOverviewPages = 50
+
+// alertCounts counts the open alerts of a kind, by severity when the kind has one.
+type alertCounts struct {
+	Total      int            `json:"total"`
+	BySeverity map[string]int `json:"by_severity,omitempty"`
+}
+
+// add counts an alert, under its severity unless it has none.
+func (c *alertCounts) add(severity string) {
+	c.Total++
+	if severity == "" {
+		return
+	}
+	if c.BySeverity == nil {
+		c.BySeverity = make(map[string]int)
+	}
+	c.BySeverity[severity]++
+}
+
+// repositorySecurityOverview holds the open alerts of a repository.
+type repositorySecurityOverview struct {
+	Repository     string      `json:"repository"`
+	Total          int         `json:"total"`
+	CodeScanning   alertCounts `json:"code_scanning"`
+	SecretScanning alertCounts `json:"secret_scanning"`
+	Dependabot     alertCounts `json:"dependabot"`
+}
+
+// orgSecurityOverview is the result of get_org_security_overview.
+type orgSecurityOverview struct {
+	Organization   string                       `json:"organization"`
+	CodeScanning   alertCounts                  `json:"code_scanning"`
+	SecretScanning alertCounts                  `json:"secret_scanning"`
+	Dependabot     alertCounts                  `json:"dependabot"`
+	Repositories   []repositorySecurityOverview `json:"repositories"`
+
+	// Unavailable holds, by kind of alert, why the alerts could not be listed, e.g. because the feature is
+	// disabled or the token lacks the permission.
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+	// Truncated lists the kinds of alerts with more alerts than were counted.
+	Truncated []string `json:"truncated,omitempty"`
+}
+
+// listAllOrgAlerts fetches the pages of an organization alert listing, following cursors or page numbers. It
+// reports whether it stopped before the last page.
+func listAllOrgAlerts[T any](list func(page int, after string) ([]T, *github.Response, error)) ([]T, bool, error) {
+	var all []T
+	page, after := 0, ""
+	for i := 0; i < maxSecurityOverviewPages; i++ {
+		alerts, resp, err := list(page, after)
+		if err != nil {
+			return nil, false, err
+		}
+		_ = resp.Body.Close()
+		all = append(all, alerts...)
+
+		switch {
+		case resp.After != "":
+			after = resp.After
+		case resp.NextPage != 0:
+			page = resp.NextPage
+		default:
+			return all, false, nil
+		}
+	}
+	return all, true, nil
+}
+
+// GetOrgSecurityOverview creates a tool to count the open security alerts of an organization, by repository and
+// severity.
+func GetOrgSecurityOverview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_security_overview",
+			mcp.WithDescription(t("TOOL_GET_ORG_SECURITY_OVERVIEW_DESCRIPTION", "Count the open code scanning, secret scanning and Dependabot alerts of an organization, in total and for each repository, with a breakdown by severity. Repositories are sorted by their number of open alerts, most first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_SECURITY_OVERVIEW_USER_TITLE", "Get organization security overview"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetOrgSecurityOverviewParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			overview := orgSecurityOverview{Organization: params.Org}
+			repositories := make(map[string]*repositorySecurityOverview)
+			repository := func(r *github.Repository) *repositorySecurityOverview {
+				name := r.GetFullName()
+				if repositories[name] == nil {
+					repositories[name] = &repositorySecurityOverview{Repository: name}
+				}
+				repositories[name].Total++
+				return repositories[name]
+			}
+			// Listing a kind of alert fails when the feature is not enabled, the other kinds are still counted
+			unavailable := func(kind string, err error) {
+				if overview.Unavailable == nil {
+					overview.Unavailable = make(map[string]string)
+				}
+				overview.Unavailable[kind] = err.Error()
+			}
+
+			codeScanningAlerts, truncated, err := listAllOrgAlerts(func(page int, after string) ([]*github.Alert, *github.Response, error) {
+				return client.CodeScanning.ListAlertsForOrg(ctx, params.Org, &github.AlertListOptions{
+					State:             "open",
+					ListOptions:       github.ListOptions{Page: page, PerPage: 100},
+					ListCursorOptions: github.ListCursorOptions{After: after},
+				})
+			})
+			if err != nil {
+				unavailable("code_scanning", err)
+			}
+			if truncated {
+				overview.Truncated = append(overview.Truncated, "code_scanning")
+			}
+			for _, alert := range codeScanningAlerts {
+				// Security queries have a security severity, other queries only the severity of their rule
+				severity := alert.GetRule().GetSecuritySeverityLevel()
+				if severity == "" {
+					severity = alert.GetRule().GetSeverity()
+				}
+				overview.CodeScanning.add(severity)
+				repository(alert.GetRepository()).CodeScanning.add(severity)
+			}
+
+			secretScanningAlerts, truncated, err := listAllOrgAlerts(func(page int, after string) ([]*github.SecretScanningAlert, *github.Response, error) {
+				return client.SecretScanning.ListAlertsForOrg(ctx, params.Org, &github.SecretScanningAlertListOptions{
+					State:             "open",
+					ListOptions:       github.ListOptions{Page: page, PerPage: 100},
+					ListCursorOptions: github.ListCursorOptions{After: after},
+				})
+			})
+			if err != nil {
+				unavailable("secret_scanning", err)
+			}
+			if truncated {
+				overview.Truncated = append(overview.Truncated, "secret_scanning")
+			}
+			for _, alert := range secretScanningAlerts {
+				overview.SecretScanning.add("")
+				repository(alert.GetRepository()).SecretScanning.add("")
+			}
+
+			dependabotAlerts, truncated, err := listAllOrgAlerts(func(page int, after string) ([]*github.DependabotAlert, *github.Response, error) {
+				return client.Dependabot.ListOrgAlerts(ctx, params.Org, &github.ListAlertsOptions{
+					State:             github.Ptr("open"),
+					ListOptions:       github.ListOptions{Page: page, PerPage: 100},
+					ListCursorOptions: github.ListCursorOptions{After: after},
+				})
+			})
+			if err != nil {
+				unavailable("dependabot", err)
+			}
+			if truncated {
+				overview.Truncated = append(overview.Truncated, "dependabot")
+			}
+			for _, alert := range dependabotAlerts {
+				severity := alert.GetSecurityAdvisory().GetSeverity()
+				if severity == "" {
+					severity = alert.GetSecurityVulnerability().GetSeverity()
+				}
+				overview.Dependabot.add(severity)
+				repository(alert.GetRepository()).Dependabot.add(severity)
+			}
+
+			overview.Repositories = make([]repositorySecurityOverview, 0, len(repositories))
+			for _, r := range repositories {
+				overview.Repositories = append(overview.Repositories, *r)
+			}
+			sort.Slice(overview.Repositories, func(i, j int) bool {
+				a, b := overview.Repositories[i], overview.Repositories[j]
+				if a.Total != b.Total {
+					return a.Total > b.Total
+				}
+				return a.Repository < b.Repository
+			})
+
+			r, err := json.Marshal(overview)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
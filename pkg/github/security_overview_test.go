@@ -0,0 +1,115 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAllOrgAlerts(t *testing.T) {
+	response := func(nextPage int, after string) *github.Response {
+		return &github.Response{
+			Response: &http.Response{Body: io.NopCloser(strings.NewReader(""))},
+			NextPage: nextPage,
+			After:    after,
+		}
+	}
+
+	var calls []string
+	alerts, truncated, err := listAllOrgAlerts(func(page int, after string) ([]int, *github.Response, error) {
+		calls = append(calls, after)
+		switch after {
+		case "":
+			return []int{1, 2}, response(0, "cursor-1"), nil
+		case "cursor-1":
+			return []int{3}, response(0, "cursor-2"), nil
+		default:
+			return []int{4}, response(0, ""), nil
+		}
+	})
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, []int{1, 2, 3, 4}, alerts)
+	assert.Equal(t, []string{"", "cursor-1", "cursor-2"}, calls)
+
+	alerts, truncated, err = listAllOrgAlerts(func(page int, _ string) ([]int, *github.Response, error) {
+		return []int{page}, response(page+1, ""), nil
+	})
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, alerts, maxSecurityOverviewPages)
+}
+
+func Test_GetOrgSecurityOverview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgSecurityOverview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_security_overview", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	api := &github.Repository{FullName: github.Ptr("octo-org/api")}
+	web := &github.Repository{FullName: github.Ptr("octo-org/web")}
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsCodeScanningAlertsByOrg,
+			[]*github.Alert{
+				{Repository: web, Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("high"), Severity: github.Ptr("error")}},
+				{Repository: web, Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("critical"), Severity: github.Ptr("error")}},
+				{Repository: api, Rule: &github.Rule{Severity: github.Ptr("warning")}},
+			},
+		),
+		mock.WithRequestMatch(
+			mock.GetOrgsSecretScanningAlertsByOrg,
+			[]*github.SecretScanningAlert{
+				{Repository: api},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsDependabotAlertsByOrg,
+			mockResponse(t, http.StatusForbidden, `{"message": "Dependabot alerts are disabled for this organization"}`),
+		),
+	)
+	_, handler := GetOrgSecurityOverview(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org": "octo-org",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var overview orgSecurityOverview
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &overview))
+	assert.Equal(t, "octo-org", overview.Organization)
+	assert.Equal(t, alertCounts{Total: 3, BySeverity: map[string]int{"critical": 1, "high": 1, "warning": 1}}, overview.CodeScanning)
+	assert.Equal(t, alertCounts{Total: 1}, overview.SecretScanning)
+	assert.Equal(t, alertCounts{}, overview.Dependabot)
+	assert.Equal(t, []repositorySecurityOverview{
+		{
+			Repository:     "octo-org/api",
+			Total:          2,
+			CodeScanning:   alertCounts{Total: 1, BySeverity: map[string]int{"warning": 1}},
+			SecretScanning: alertCounts{Total: 1},
+		},
+		{
+			Repository:   "octo-org/web",
+			Total:        2,
+			CodeScanning: alertCounts{Total: 2, BySeverity: map[string]int{"critical": 1, "high": 1}},
+		},
+	}, overview.Repositories)
+	require.Contains(t, overview.Unavailable, "dependabot")
+	assert.Contains(t, overview.Unavailable["dependabot"], "Dependabot alerts are disabled")
+	assert.Empty(t, overview.Truncated)
+}
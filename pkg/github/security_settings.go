@@ -0,0 +1,316 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxSecuritySettingsRepositories bounds the repositories whose security settings are read or changed at once.
+const maxSecuritySettingsRepositories = 100
+
+// The security settings get_security_settings and update_security_settings manage.
+const (
+	settingVulnerabilityAlerts      = "vulnerability_alerts"
+	settingAutomatedSecurityFixes   = "automated_security_fixes"
+	settingCodeScanningDefaultSetup = "code_scanning_default_setup"
+)
+
+// repositorySecuritySettings are the security settings of a repository. A setting is left out when it could not be
+// read, with the reason in Errors.
+type repositorySecuritySettings struct {
+	Repository             string `json:"repository"`
+	VulnerabilityAlerts    *bool  `json:"vulnerability_alerts,omitempty"`
+	AutomatedSecurityFixes *bool  `json:"automated_security_fixes,omitempty"`
+	// CodeScanningDefaultSetup is the state of the default setup, configured or not-configured.
+	CodeScanningDefaultSetup string `json:"code_scanning_default_setup,omitempty"`
+	// Changed are the settings update_security_settings changed.
+	Changed []string          `json:"changed,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// securitySettingsRepositories resolves the repositories a call applies to: the ones given as owner/repo, or else
+// the repositories of the organization that are not archived.
+func securitySettingsRepositories(ctx context.Context, client *github.Client, repositories []string, org string) ([]string, string, error) {
+	if len(repositories) > 0 && org != "" {
+		return nil, "only one of repositories and org can be given", nil
+	}
+	if len(repositories) > 0 {
+		for _, fullName := range repositories {
+			if owner, repo, ok := strings.Cut(fullName, "/"); !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+				return nil, fmt.Sprintf("invalid repository %q, expected owner/repo", fullName), nil
+			}
+		}
+		if len(repositories) > maxSecuritySettingsRepositories {
+			return nil, fmt.Sprintf("at most %d repositories can be managed at once", maxSecuritySettingsRepositories), nil
+		}
+		return repositories, "", nil
+	}
+	if org == "" {
+		return nil, "one of repositories and org is required", nil
+	}
+
+	var names []string
+	opts := &github.RepositoryListByOrgOptions{Type: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		repos, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list repositories: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, repo := range repos {
+			if !repo.GetArchived() {
+				names = append(names, repo.GetFullName())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if len(names) > maxSecuritySettingsRepositories {
+		return nil, fmt.Sprintf("%s has %d repositories, at most %d can be managed at once: pass them with repositories", org, len(names), maxSecuritySettingsRepositories), nil
+	}
+	return names, "", nil
+}
+
+// readSecuritySettings reads the security settings of a repository, recording the ones that could not be read.
+func readSecuritySettings(ctx context.Context, client *github.Client, owner, repo string) repositorySecuritySettings {
+	settings := repositorySecuritySettings{Repository: owner + "/" + repo, Errors: map[string]string{}}
+
+	alerts, resp, err := client.Repositories.GetVulnerabilityAlerts(ctx, owner, repo)
+	if err != nil {
+		settings.Errors[settingVulnerabilityAlerts] = err.Error()
+	} else {
+		_ = resp.Body.Close()
+		settings.VulnerabilityAlerts = github.Ptr(alerts)
+	}
+
+	fixes, resp, err := client.Repositories.GetAutomatedSecurityFixes(ctx, owner, repo)
+	switch {
+	case err == nil:
+		_ = resp.Body.Close()
+		settings.AutomatedSecurityFixes = github.Ptr(fixes.GetEnabled())
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		// Not found when vulnerability alerts are disabled
+		settings.AutomatedSecurityFixes = github.Ptr(false)
+	default:
+		settings.Errors[settingAutomatedSecurityFixes] = err.Error()
+	}
+
+	setup, resp, err := client.CodeScanning.GetDefaultSetupConfiguration(ctx, owner, repo)
+	if err != nil {
+		settings.Errors[settingCodeScanningDefaultSetup] = err.Error()
+	} else {
+		_ = resp.Body.Close()
+		settings.CodeScanningDefaultSetup = setup.GetState()
+	}
+	return settings
+}
+
+// GetSecuritySettings creates a tool to read the security settings of several repositories.
+func GetSecuritySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_security_settings",
+			mcp.WithDescription(t("TOOL_GET_SECURITY_SETTINGS_DESCRIPTION", "Get the security settings of several repositories: whether Dependabot vulnerability alerts and automated security fixes are enabled, and the state of the code scanning default setup. Settings that cannot be read, e.g. code scanning without GitHub Advanced Security, are reported with the reason.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SECURITY_SETTINGS_USER_TITLE", "Get security settings"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithArray("repositories",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description(fmt.Sprintf("Repositories, as owner/repo (at most %d)", maxSecuritySettingsRepositories)),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization whose repositories that are not archived to read, instead of repositories"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetSecuritySettingsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repositories, invalid, err := securitySettingsRepositories(ctx, client, params.Repositories, params.Org)
+			if err != nil {
+				return nil, err
+			}
+			if invalid != "" {
+				return mcp.NewToolResultError(invalid), nil
+			}
+
+			results := make([]repositorySecuritySettings, 0, len(repositories))
+			for _, fullName := range repositories {
+				owner, repo, _ := strings.Cut(fullName, "/")
+				results = append(results, readSecuritySettings(ctx, client, owner, repo))
+			}
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// securitySettingsUpdate are the settings an update_security_settings call changes, nil for the ones it leaves as is.
+type securitySettingsUpdate struct {
+	VulnerabilityAlerts      *bool
+	AutomatedSecurityFixes   *bool
+	CodeScanningDefaultSetup *bool
+	QuerySuite               string
+}
+
+// updateSecuritySettings changes the settings of a repository that differ from the update. Automated security fixes
+// depend on vulnerability alerts, so they are disabled before the alerts and enabled after them.
+func updateSecuritySettings(ctx context.Context, client *github.Client, owner, repo string, update securitySettingsUpdate) repositorySecuritySettings {
+	settings := readSecuritySettings(ctx, client, owner, repo)
+	differs := func(current, wanted *bool) bool {
+		return wanted != nil && current != nil && *current != *wanted
+	}
+	toggle := func(setting string, enable bool, enableFn, disableFn func(context.Context, string, string) (*github.Response, error)) bool {
+		fn := disableFn
+		if enable {
+			fn = enableFn
+		}
+		resp, err := fn(ctx, owner, repo)
+		if err != nil {
+			settings.Errors[setting] = err.Error()
+			return false
+		}
+		_ = resp.Body.Close()
+		settings.Changed = append(settings.Changed, setting)
+		return true
+	}
+
+	if differs(settings.AutomatedSecurityFixes, update.AutomatedSecurityFixes) && !*update.AutomatedSecurityFixes {
+		if toggle(settingAutomatedSecurityFixes, false, client.Repositories.EnableAutomatedSecurityFixes, client.Repositories.DisableAutomatedSecurityFixes) {
+			settings.AutomatedSecurityFixes = github.Ptr(false)
+		}
+	}
+	if differs(settings.VulnerabilityAlerts, update.VulnerabilityAlerts) {
+		if toggle(settingVulnerabilityAlerts, *update.VulnerabilityAlerts, client.Repositories.EnableVulnerabilityAlerts, client.Repositories.DisableVulnerabilityAlerts) {
+			settings.VulnerabilityAlerts = update.VulnerabilityAlerts
+			if !*update.VulnerabilityAlerts {
+				settings.AutomatedSecurityFixes = github.Ptr(false)
+			}
+		}
+	}
+	if differs(settings.AutomatedSecurityFixes, update.AutomatedSecurityFixes) && *update.AutomatedSecurityFixes {
+		if toggle(settingAutomatedSecurityFixes, true, client.Repositories.EnableAutomatedSecurityFixes, client.Repositories.DisableAutomatedSecurityFixes) {
+			settings.AutomatedSecurityFixes = github.Ptr(true)
+		}
+	}
+
+	if update.CodeScanningDefaultSetup != nil && settings.CodeScanningDefaultSetup != "" {
+		state := "not-configured"
+		if *update.CodeScanningDefaultSetup {
+			state = "configured"
+		}
+		if state != settings.CodeScanningDefaultSetup {
+			options := &github.UpdateDefaultSetupConfigurationOptions{State: state}
+			if state == "configured" && update.QuerySuite != "" {
+				options.QuerySuite = github.Ptr(update.QuerySuite)
+			}
+			_, resp, err := client.CodeScanning.UpdateDefaultSetupConfiguration(ctx, owner, repo, options)
+			// The setup runs in the background, the API accepts it with a 202
+			if err != nil && !isAcceptedError(err) {
+				settings.Errors[settingCodeScanningDefaultSetup] = err.Error()
+			} else {
+				_ = resp.Body.Close()
+				settings.CodeScanningDefaultSetup = state
+				settings.Changed = append(settings.Changed, settingCodeScanningDefaultSetup)
+			}
+		}
+	}
+	return settings
+}
+
+// UpdateSecuritySettings creates a tool to enable or disable security settings across several repositories.
+func UpdateSecuritySettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_security_settings",
+			mcp.WithDescription(t("TOOL_UPDATE_SECURITY_SETTINGS_DESCRIPTION", "Enable or disable security settings across several repositories: Dependabot vulnerability alerts, automated security fixes, which need vulnerability alerts, and the code scanning default setup. Only the settings given are changed, in the repositories where they differ. Returns the settings of each repository afterwards, the ones changed, and the failures, which do not stop the other repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_SECURITY_SETTINGS_USER_TITLE", "Update security settings"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithArray("repositories",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description(fmt.Sprintf("Repositories to change, as owner/repo (at most %d)", maxSecuritySettingsRepositories)),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization whose repositories that are not archived to change, instead of repositories"),
+			),
+			mcp.WithBoolean("vulnerability_alerts",
+				mcp.Description("Enable or disable Dependabot vulnerability alerts. Disabling them disables automated security fixes"),
+			),
+			mcp.WithBoolean("automated_security_fixes",
+				mcp.Description("Enable or disable Dependabot automated security fixes"),
+			),
+			mcp.WithBoolean("code_scanning_default_setup",
+				mcp.Description("Enable or disable the code scanning default setup"),
+			),
+			mcp.WithString("query_suite",
+				mcp.Description("Query suite of the code scanning default setup when enabling it"),
+				mcp.Enum("default", "extended"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseUpdateSecuritySettingsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			update := securitySettingsUpdate{QuerySuite: params.QuerySuite}
+			for name, setting := range map[string]**bool{
+				settingVulnerabilityAlerts:      &update.VulnerabilityAlerts,
+				settingAutomatedSecurityFixes:   &update.AutomatedSecurityFixes,
+				settingCodeScanningDefaultSetup: &update.CodeScanningDefaultSetup,
+			} {
+				if value, ok, _ := OptionalParamOK[bool](request, name); ok {
+					*setting = github.Ptr(value)
+				}
+			}
+			if update.VulnerabilityAlerts == nil && update.AutomatedSecurityFixes == nil && update.CodeScanningDefaultSetup == nil {
+				return mcp.NewToolResultError("at least one of vulnerability_alerts, automated_security_fixes and code_scanning_default_setup is required"), nil
+			}
+			if update.VulnerabilityAlerts != nil && update.AutomatedSecurityFixes != nil && !*update.VulnerabilityAlerts && *update.AutomatedSecurityFixes {
+				return mcp.NewToolResultError("automated security fixes need vulnerability alerts"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repositories, invalid, err := securitySettingsRepositories(ctx, client, params.Repositories, params.Org)
+			if err != nil {
+				return nil, err
+			}
+			if invalid != "" {
+				return mcp.NewToolResultError(invalid), nil
+			}
+
+			results := make([]repositorySecuritySettings, 0, len(repositories))
+			for _, fullName := range repositories {
+				owner, repo, _ := strings.Cut(fullName, "/")
+				results = append(results, updateSecuritySettings(ctx, client, owner, repo, update))
+			}
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
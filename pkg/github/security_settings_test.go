@@ -0,0 +1,244 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// securitySettingsOptions mocks two repositories: owner/api with everything enabled, and owner/web with vulnerability
+// alerts disabled and without GitHub Advanced Security.
+func securitySettingsOptions(t *testing.T, extra ...mock.MockBackendOption) []mock.MockBackendOption {
+	byRepo := func(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			repo := strings.Split(r.URL.Path, "/")[3]
+			handlers[repo](w, r)
+		}
+	}
+	return append([]mock.MockBackendOption{
+		mock.WithRequestMatchHandler(
+			mock.GetReposVulnerabilityAlertsByOwnerByRepo,
+			byRepo(map[string]http.HandlerFunc{
+				"api": mockResponse(t, http.StatusNoContent, ""),
+				"web": mockResponse(t, http.StatusNotFound, `{"message": "Vulnerability alerts are disabled."}`),
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+			byRepo(map[string]http.HandlerFunc{
+				"api": mockResponse(t, http.StatusOK, &github.AutomatedSecurityFixes{Enabled: github.Ptr(true)}),
+				"web": mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposCodeScanningDefaultSetupByOwnerByRepo,
+			byRepo(map[string]http.HandlerFunc{
+				"api": mockResponse(t, http.StatusOK, &github.DefaultSetupConfiguration{State: github.Ptr("configured")}),
+				"web": mockResponse(t, http.StatusForbidden, `{"message": "Advanced Security must be enabled for this repository to use code scanning."}`),
+			}),
+		),
+	}, extra...)
+}
+
+func Test_GetSecuritySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetSecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_security_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	t.Run("reads settings of organization repositories", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(securitySettingsOptions(t,
+			mock.WithRequestMatch(mock.GetOrgsReposByOrg, []*github.Repository{
+				{FullName: github.Ptr("owner/api")},
+				{FullName: github.Ptr("owner/legacy"), Archived: github.Ptr(true)},
+				{FullName: github.Ptr("owner/web")},
+			}),
+		)...))
+		_, handler := GetSecuritySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "owner"}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var settings []repositorySecuritySettings
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+		require.Len(t, settings, 2)
+		assert.Equal(t, repositorySecuritySettings{
+			Repository:               "owner/api",
+			VulnerabilityAlerts:      github.Ptr(true),
+			AutomatedSecurityFixes:   github.Ptr(true),
+			CodeScanningDefaultSetup: "configured",
+		}, settings[0])
+		assert.Equal(t, "owner/web", settings[1].Repository)
+		assert.False(t, *settings[1].VulnerabilityAlerts)
+		assert.False(t, *settings[1].AutomatedSecurityFixes)
+		assert.Empty(t, settings[1].CodeScanningDefaultSetup)
+		assert.Contains(t, settings[1].Errors[settingCodeScanningDefaultSetup], "Advanced Security")
+	})
+
+	for name, args := range map[string]map[string]any{
+		"neither repositories nor org": {},
+		"both repositories and org":    {"repositories": []any{"owner/api"}, "org": "owner"},
+		"invalid repository":           {"repositories": []any{"api"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, handler := GetSecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+}
+
+func Test_UpdateSecuritySettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateSecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_security_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "vulnerability_alerts")
+	assert.Contains(t, tool.InputSchema.Properties, "automated_security_fixes")
+	assert.Contains(t, tool.InputSchema.Properties, "code_scanning_default_setup")
+	assert.Contains(t, tool.InputSchema.Properties, "query_suite")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	run := func(t *testing.T, client *github.Client, args map[string]any) []repositorySecuritySettings {
+		_, handler := UpdateSecuritySettings(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var settings []repositorySecuritySettings
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &settings))
+		return settings
+	}
+
+	t.Run("enables alerts before fixes where they differ", func(t *testing.T) {
+		var calls []string
+		record := func(call string) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, call+" "+r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+		client := github.NewClient(mock.NewMockedHTTPClient(securitySettingsOptions(t,
+			mock.WithRequestMatchHandler(mock.PutReposVulnerabilityAlertsByOwnerByRepo, record("alerts")),
+			mock.WithRequestMatchHandler(mock.PutReposAutomatedSecurityFixesByOwnerByRepo, record("fixes")),
+		)...))
+
+		settings := run(t, client, map[string]any{
+			"repositories":             []any{"owner/api", "owner/web"},
+			"vulnerability_alerts":     true,
+			"automated_security_fixes": true,
+		})
+		assert.Equal(t, []string{
+			"alerts /repos/owner/web/vulnerability-alerts",
+			"fixes /repos/owner/web/automated-security-fixes",
+		}, calls)
+		require.Len(t, settings, 2)
+		assert.Empty(t, settings[0].Changed)
+		assert.Equal(t, []string{settingVulnerabilityAlerts, settingAutomatedSecurityFixes}, settings[1].Changed)
+		assert.True(t, *settings[1].VulnerabilityAlerts)
+		assert.True(t, *settings[1].AutomatedSecurityFixes)
+	})
+
+	t.Run("disables fixes before alerts", func(t *testing.T) {
+		var calls []string
+		record := func(call string) http.HandlerFunc {
+			return func(w http.ResponseWriter, _ *http.Request) {
+				calls = append(calls, call)
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+		client := github.NewClient(mock.NewMockedHTTPClient(securitySettingsOptions(t,
+			mock.WithRequestMatchHandler(mock.DeleteReposAutomatedSecurityFixesByOwnerByRepo, record("fixes")),
+			mock.WithRequestMatchHandler(mock.DeleteReposVulnerabilityAlertsByOwnerByRepo, record("alerts")),
+		)...))
+
+		settings := run(t, client, map[string]any{
+			"repositories":         []any{"owner/api"},
+			"vulnerability_alerts": false,
+		})
+		assert.Equal(t, []string{"alerts"}, calls)
+		assert.Equal(t, []string{settingVulnerabilityAlerts}, settings[0].Changed)
+		assert.False(t, *settings[0].AutomatedSecurityFixes)
+
+		calls = nil
+		settings = run(t, client, map[string]any{
+			"repositories":             []any{"owner/api"},
+			"vulnerability_alerts":     false,
+			"automated_security_fixes": false,
+		})
+		assert.Equal(t, []string{"fixes", "alerts"}, calls)
+		assert.Equal(t, []string{settingAutomatedSecurityFixes, settingVulnerabilityAlerts}, settings[0].Changed)
+	})
+
+	t.Run("changes the code scanning default setup and reports failures", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(securitySettingsOptions(t,
+			mock.WithRequestMatchHandler(
+				mock.PatchReposCodeScanningDefaultSetupByOwnerByRepo,
+				expectRequestBody(t, map[string]any{"state": "not-configured"}).andThen(
+					mockResponse(t, http.StatusAccepted, &github.UpdateDefaultSetupConfigurationResponse{RunID: github.Ptr(int64(7))}),
+				),
+			),
+		)...))
+
+		settings := run(t, client, map[string]any{
+			"repositories":                []any{"owner/api", "owner/web"},
+			"code_scanning_default_setup": false,
+		})
+		require.Len(t, settings, 2)
+		assert.Equal(t, []string{settingCodeScanningDefaultSetup}, settings[0].Changed)
+		assert.Equal(t, "not-configured", settings[0].CodeScanningDefaultSetup)
+		assert.Empty(t, settings[1].Changed)
+		assert.Contains(t, settings[1].Errors, settingCodeScanningDefaultSetup)
+	})
+
+	t.Run("enable fails for one repository", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(securitySettingsOptions(t,
+			mock.WithRequestMatchHandler(
+				mock.PutReposVulnerabilityAlertsByOwnerByRepo,
+				mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+			),
+		)...))
+
+		settings := run(t, client, map[string]any{
+			"repositories":         []any{"owner/web"},
+			"vulnerability_alerts": true,
+		})
+		assert.Empty(t, settings[0].Changed)
+		assert.False(t, *settings[0].VulnerabilityAlerts)
+		assert.Contains(t, settings[0].Errors[settingVulnerabilityAlerts], "admin rights")
+	})
+
+	for name, args := range map[string]map[string]any{
+		"no setting":                  {"repositories": []any{"owner/api"}},
+		"fixes without alerts":        {"repositories": []any{"owner/api"}, "vulnerability_alerts": false, "automated_security_fixes": true},
+		"neither repositories or org": {"vulnerability_alerts": true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, handler := UpdateSecuritySettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormatParam is the parameter WithOutputFormat adds to every tool.
+const outputFormatParam = "output_format"
+
+// Serializer renders the result of a tool, decoded from JSON, in another format. Objects are decoded as
+// orderedObject, arrays as []any, and numbers as int64 or float64.
+type Serializer func(data any) (string, error)
+
+// serializers are the formats tool results can be rendered in besides JSON, by name.
+var serializers = map[string]Serializer{
+	"yaml": serializeYAML,
+	"toml": serializeTOML,
+	"csv":  serializeCSV,
+}
+
+// RegisterSerializer adds a format tool results can be rendered in, or replaces one. It must be called before the
+// tools are wrapped with WithOutputFormat.
+func RegisterSerializer(format string, serializer Serializer) {
+	serializers[format] = serializer
+}
+
+// outputFormats returns the formats tool results can be rendered in, json first.
+func outputFormats() []string {
+	return append([]string{"json"}, slices.Sorted(maps.Keys(serializers))...)
+}
+
+// orderedField is a field of an orderedObject.
+type orderedField struct {
+	Key   string
+	Value any
+}
+
+// orderedObject is a JSON object with its fields in their original order, so that the fields of converted results
+// come in the order the tool wrote them.
+type orderedObject []orderedField
+
+// MarshalJSON renders the object keeping the order of its fields.
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
+
+// MarshalYAML renders the object as a YAML mapping keeping the order of its fields.
+func (o orderedObject) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, field := range o {
+		var key, value yaml.Node
+		if err := key.Encode(field.Key); err != nil {
+			return nil, err
+		}
+		if err := value.Encode(field.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &key, &value)
+	}
+	return node, nil
+}
+
+// decodeOrdered decodes the next JSON value of a decoder using UseNumber, keeping the order of object fields.
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := orderedObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, orderedField{Key: key.(string), Value: value})
+		}
+		_, err := dec.Token()
+		return object, err
+	case json.Delim('['):
+		array := []any{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := dec.Token()
+		return array, err
+	}
+	if number, ok := token.(json.Number); ok {
+		// IDs do not fit in a float64 without losing precision
+		if i, err := number.Int64(); err == nil {
+			return i, nil
+		}
+		return number.Float64()
+	}
+	return token, nil
+}
+
+// parseJSONResult decodes a JSON document keeping the order of object fields.
+func parseJSONResult(text string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	data, err := decodeOrdered(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return data, nil
+}
+
+func serializeYAML(data any) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(data); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// plainValue converts a decoded result to maps and slices, leaving out nulls, which TOML cannot represent.
+func plainValue(data any) any {
+	switch v := data.(type) {
+	case orderedObject:
+		m := make(map[string]any, len(v))
+		for _, field := range v {
+			if field.Value != nil {
+				m[field.Key] = plainValue(field.Value)
+			}
+		}
+		return m
+	case []any:
+		s := make([]any, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				s = append(s, plainValue(item))
+			}
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+func serializeTOML(data any) (string, error) {
+	// A TOML document is a table, so other results are put in one
+	document, ok := plainValue(data).(map[string]any)
+	if !ok {
+		document = map[string]any{"result": plainValue(data)}
+	}
+	out, err := toml.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// tableRows returns the rows of a result rendered as a table: the items of a list, or of the only list of objects
+// of an object, such as the items of search results.
+func tableRows(data any) ([]any, error) {
+	if rows, ok := data.([]any); ok {
+		return rows, nil
+	}
+	object, ok := data.(orderedObject)
+	if !ok {
+		return nil, errors.New("the result is not a list")
+	}
+	var rows []any
+	lists := 0
+	for _, field := range object {
+		list, ok := field.Value.([]any)
+		if !ok {
+			continue
+		}
+		if len(list) > 0 {
+			if _, isObject := list[0].(orderedObject); !isObject {
+				continue
+			}
+		}
+		rows = list
+		lists++
+	}
+	if lists != 1 {
+		return nil, errors.New("the result is not a list, nor an object holding a single list")
+	}
+	return rows, nil
+}
+
+// formatCell renders a scalar as a CSV cell.
+func formatCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// listCell renders a list as a CSV cell: its items joined with semicolons when they are scalars, JSON otherwise.
+func listCell(list []any) string {
+	cells := make([]string, len(list))
+	for i, item := range list {
+		switch item.(type) {
+		case orderedObject, []any:
+			encoded, _ := json.Marshal(plainValue(list))
+			return string(encoded)
+		}
+		cells[i] = formatCell(item)
+	}
+	return strings.Join(cells, ";")
+}
+
+// csvTable collects the rows of a CSV rendering, with the union of their columns in the order they first appear.
+type csvTable struct {
+	columns []string
+	known   map[string]bool
+	rows    []map[string]string
+}
+
+// flatten adds the cells of a value to a row, naming nested fields by their path, e.g. user.login.
+func (t *csvTable) flatten(row map[string]string, name string, value any) {
+	if object, ok := value.(orderedObject); ok {
+		for _, field := range object {
+			key := field.Key
+			if name != "" {
+				key = name + "." + key
+			}
+			t.flatten(row, key, field.Value)
+		}
+		return
+	}
+	if name == "" {
+		name = "value"
+	}
+	if !t.known[name] {
+		t.known[name] = true
+		t.columns = append(t.columns, name)
+	}
+	if list, ok := value.([]any); ok {
+		row[name] = listCell(list)
+	} else {
+		row[name] = formatCell(value)
+	}
+}
+
+func serializeCSV(data any) (string, error) {
+	items, err := tableRows(data)
+	if err != nil {
+		return "", err
+	}
+	table := csvTable{known: map[string]bool{}}
+	for _, item := range items {
+		row := map[string]string{}
+		table.flatten(row, "", item)
+		table.rows = append(table.rows, row)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(table.columns); err != nil {
+		return "", err
+	}
+	record := make([]string, len(table.columns))
+	for _, row := range table.rows {
+		for i, column := range table.columns {
+			record[i] = row[column]
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// WithOutputFormat returns a middleware adding an output_format parameter to each tool, rendering its JSON result
+// as YAML, TOML, CSV or any other registered format instead. Results that are not JSON, such as file contents, and
+// error results are returned as they are. When a result cannot be rendered in the format, e.g. CSV for a result
+// that is not a list, it is returned as JSON with a note, as the call may have changed something already.
+func WithOutputFormat() ToolMiddleware {
+	formats := outputFormats()
+	return func(st server.ServerTool) server.ServerTool {
+		properties := make(map[string]any, len(st.Tool.InputSchema.Properties)+1)
+		for k, v := range st.Tool.InputSchema.Properties {
+			properties[k] = v
+		}
+		properties[outputFormatParam] = map[string]any{
+			"type":        "string",
+			"description": "Format of the result, json by default. csv renders lists as a table, with nested fields in columns named by their path, e.g. user.login",
+			"enum":        formats,
+		}
+		st.Tool.InputSchema.Properties = properties
+
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := request.GetArguments()
+			format, ok := args[outputFormatParam].(string)
+			if !ok && args[outputFormatParam] != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("parameter %s is not of type string", outputFormatParam)), nil
+			}
+			if args[outputFormatParam] != nil {
+				stripped := make(map[string]any, len(args))
+				for k, v := range args {
+					if k != outputFormatParam {
+						stripped[k] = v
+					}
+				}
+				request.Params.Arguments = stripped
+			}
+			if format == "" || format == "json" {
+				return next(ctx, request)
+			}
+			serializer, ok := serializers[format]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown output_format %q, supported formats are %s", format, strings.Join(formats, ", "))), nil
+			}
+
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+			converted := *result
+			converted.Content = make([]mcp.Content, 0, len(result.Content))
+			for _, content := range result.Content {
+				text, ok := content.(mcp.TextContent)
+				if !ok {
+					converted.Content = append(converted.Content, content)
+					continue
+				}
+				data, err := parseJSONResult(text.Text)
+				if err != nil {
+					converted.Content = append(converted.Content, content)
+					continue
+				}
+				rendered, err := serializer(data)
+				if err != nil {
+					converted.Content = append(converted.Content, content, mcp.NewTextContent(fmt.Sprintf("The result is returned as JSON, as it cannot be rendered as %s: %v", format, err)))
+					continue
+				}
+				text.Text = rendered
+				converted.Content = append(converted.Content, text)
+			}
+			return &converted, nil
+		}
+		return st
+	}
+}
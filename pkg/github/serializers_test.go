@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithOutputFormat(t *testing.T) {
+	var got map[string]any
+	result := `{"total_count":2,"items":[{"number":1,"title":"Crash, on start","user":{"login":"octocat"},"labels":["bug","p1"],"id":9007199254740993},{"number":2,"title":"Docs","user":{"login":"hubot"},"labels":[],"closed_at":null}]}`
+	wrapped := WithOutputFormat()(server.ServerTool{
+		Tool: mcp.NewTool("search_issues", mcp.WithString("query", mcp.Required())),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			got = request.GetArguments()
+			return mcp.NewToolResultText(result), nil
+		},
+	})
+
+	assert.Contains(t, wrapped.Tool.InputSchema.Properties, "query")
+	require.Contains(t, wrapped.Tool.InputSchema.Properties, "output_format")
+	assert.Equal(t, []string{"json", "csv", "toml", "yaml"}, wrapped.Tool.InputSchema.Properties["output_format"].(map[string]any)["enum"])
+
+	call := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		res, err := wrapped.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("json is returned as it is", func(t *testing.T) {
+		res := call(t, map[string]any{"query": "is:open", "output_format": "json"})
+		assert.Equal(t, result, getTextResult(t, res).Text)
+		assert.Equal(t, map[string]any{"query": "is:open"}, got)
+	})
+
+	t.Run("yaml keeps the order of fields", func(t *testing.T) {
+		res := call(t, map[string]any{"query": "is:open", "output_format": "yaml"})
+		assert.Equal(t, `total_count: 2
+items:
+  - number: 1
+    title: Crash, on start
+    user:
+      login: octocat
+    labels:
+      - bug
+      - p1
+    id: 9007199254740993
+  - number: 2
+    title: Docs
+    user:
+      login: hubot
+    labels: []
+    closed_at: null
+`, getTextResult(t, res).Text)
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		res := call(t, map[string]any{"query": "is:open", "output_format": "toml"})
+		text := getTextResult(t, res).Text
+		assert.Contains(t, text, "total_count = 2")
+		assert.Contains(t, text, "[[items]]")
+		assert.Contains(t, text, "id = 9007199254740993")
+		assert.NotContains(t, text, "closed_at")
+	})
+
+	t.Run("csv flattens the list of an object", func(t *testing.T) {
+		res := call(t, map[string]any{"query": "is:open", "output_format": "csv"})
+		assert.Equal(t, `number,title,user.login,labels,id,closed_at
+1,"Crash, on start",octocat,bug;p1,9007199254740993,
+2,Docs,hubot,,,
+`, getTextResult(t, res).Text)
+	})
+
+	t.Run("unknown formats are rejected", func(t *testing.T) {
+		got = nil
+		res := call(t, map[string]any{"query": "is:open", "output_format": "xml"})
+		require.True(t, res.IsError)
+		assert.Contains(t, getTextResult(t, res).Text, `unknown output_format "xml", supported formats are json, csv, toml, yaml`)
+		assert.Nil(t, got)
+	})
+
+	t.Run("results that are not tables are kept as JSON with a note", func(t *testing.T) {
+		notTable := WithOutputFormat()(server.ServerTool{
+			Tool: mcp.NewTool("get_me"),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText(`{"login":"octocat"}`), nil
+			},
+		})
+		res, err := notTable.Handler(context.Background(), createMCPRequest(map[string]any{"output_format": "csv"}))
+		require.NoError(t, err)
+		require.Len(t, res.Content, 2)
+		assert.Equal(t, `{"login":"octocat"}`, res.Content[0].(mcp.TextContent).Text)
+		assert.Contains(t, res.Content[1].(mcp.TextContent).Text, "cannot be rendered as csv")
+	})
+
+	t.Run("text that is not JSON is returned as it is", func(t *testing.T) {
+		plain := WithOutputFormat()(server.ServerTool{
+			Tool: mcp.NewTool("get_file_contents"),
+			Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("# README"), nil
+			},
+		})
+		res, err := plain.Handler(context.Background(), createMCPRequest(map[string]any{"output_format": "yaml"}))
+		require.NoError(t, err)
+		assert.Equal(t, "# README", getTextResult(t, res).Text)
+	})
+}
+
+func Test_RegisterSerializer(t *testing.T) {
+	RegisterSerializer("count", func(data any) (string, error) {
+		rows, err := tableRows(data)
+		if err != nil {
+			return "", err
+		}
+		return string(rune('0' + len(rows))), nil
+	})
+	defer delete(serializers, "count")
+
+	wrapped := WithOutputFormat()(server.ServerTool{
+		Tool: mcp.NewTool("list_issues"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(`[{"number":1},{"number":2}]`), nil
+		},
+	})
+	assert.Contains(t, wrapped.Tool.InputSchema.Properties["output_format"].(map[string]any)["enum"], "count")
+
+	res, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{"output_format": "count"}))
+	require.NoError(t, err)
+	assert.Equal(t, "2", getTextResult(t, res).Text)
+}
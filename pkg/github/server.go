@@ -59,6 +59,12 @@ func isAcceptedError(err error) bool {
 	return errors.As(err, &acceptedError)
 }
 
+// conflictResult reports that an optimistic concurrency precondition failed because the resource changed since the
+// caller last read it.
+func conflictResult(resource, expected, actual string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("conflict: %s has changed since it was read (expected %s, found %s), fetch it again before retrying", resource, expected, actual))
+}
+
 // requiredParam is a helper function that can be used to fetch a requested parameter from the request.
 // It does the following checks:
 // 1. Checks if the parameter is present in the request.
@@ -85,6 +91,23 @@ func requiredParam[T comparable](r mcp.CallToolRequest, p string) (T, error) {
 	return r.GetArguments()[p].(T), nil
 }
 
+// requiredPresentParam is like requiredParam, but accepts zero values such as false or an empty list, as long as
+// the parameter is present and of the expected type.
+func requiredPresentParam[T any](r mcp.CallToolRequest, p string) (T, error) {
+	var zero T
+
+	val := r.GetArguments()[p]
+	if val == nil {
+		return zero, fmt.Errorf("missing required parameter: %s", p)
+	}
+
+	value, ok := val.(T)
+	if !ok {
+		return zero, fmt.Errorf("parameter %s is not of type %T", p, zero)
+	}
+	return value, nil
+}
+
 // RequiredInt is a helper function that can be used to fetch a requested parameter from the request.
 // It does the following checks:
 // 1. Checks if the parameter is present in the request.
@@ -0,0 +1,225 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// redacted replaces the secrets left out of session logs.
+const redacted = "[REDACTED]"
+
+// secretPattern matches secrets in text: GitHub tokens, private keys and bearer credentials.
+var secretPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b|-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----|(?i:\b(bearer|token)\s+[A-Za-z0-9._~+/-]{16,}=*)`)
+
+// secretArgumentPattern matches the names of arguments whose values are left out of session logs.
+var secretArgumentPattern = regexp.MustCompile(`(?i)token|secret|password|passphrase|private_key|authorization|credential`)
+
+// sessionFileNamePattern matches the characters of session IDs not kept in the names of session log files.
+var sessionFileNamePattern = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// redactSecrets replaces the secrets found in text.
+func redactSecrets(text string) string {
+	return secretPattern.ReplaceAllString(text, redacted)
+}
+
+// redactArguments returns a copy of the arguments of a call without secrets: arguments named like secrets are
+// replaced as a whole, and secrets found in other strings are replaced.
+func redactArguments(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for name, arg := range v {
+			if secretArgumentPattern.MatchString(name) && arg != nil {
+				out[name] = redacted
+				continue
+			}
+			out[name] = redactArguments(arg)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactArguments(item)
+		}
+		return out
+	case string:
+		return redactSecrets(v)
+	default:
+		return v
+	}
+}
+
+// sessionLogRecord is a tool call as recorded in a session log, one per line.
+type sessionLogRecord struct {
+	Time       time.Time `json:"time"`
+	Session    string    `json:"session"`
+	Tool       string    `json:"tool"`
+	Arguments  any       `json:"arguments"`
+	Result     string    `json:"result,omitempty"`
+	IsError    bool      `json:"is_error,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// SessionLog records the tool calls of each session, with their results, to a JSONL file per session so that the
+// actions of agents can be reviewed and reproduced. Secrets are redacted from the arguments and results.
+type SessionLog struct {
+	dir string
+	now func() time.Time
+
+	mu sync.Mutex
+	// files holds the log file of each session, named when the session makes its first call.
+	files map[string]string
+}
+
+// NewSessionLog creates a session log writing to dir, which is created if needed.
+func NewSessionLog(dir string) (*SessionLog, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create session log directory: %w", err)
+	}
+	return &SessionLog{dir: dir, now: time.Now, files: make(map[string]string)}, nil
+}
+
+// file returns the log file of a session. Files are named after the time of the first call and the session ID, so
+// that sessions with a fixed ID, such as stdio ones, get a new file each time the server starts.
+func (l *SessionLog) file(session string) string {
+	if path, ok := l.files[session]; ok {
+		return path
+	}
+	name := sessionFileNamePattern.ReplaceAllString(session, "_")
+	if name == "" {
+		name = "default"
+	}
+	path := filepath.Join(l.dir, fmt.Sprintf("%s-%s.jsonl", l.now().UTC().Format("20060102T150405Z"), name))
+	l.files[session] = path
+	return path
+}
+
+func (l *SessionLog) write(record sessionLogRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.OpenFile(l.file(record.Session), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// read returns the log of a session, nil when it made no call yet.
+func (l *SessionLog) read(session string) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	path, ok := l.files[session]
+	if !ok {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Forget drops the log file name of a session, once it has ended. The file itself is kept.
+func (l *SessionLog) Forget(session string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.files, session)
+}
+
+// Record is a middleware writing each call and its result to the log of its session. A call is still answered
+// when it cannot be logged.
+func (l *SessionLog) Record(st server.ServerTool) server.ServerTool {
+	next := st.Handler
+	st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := l.now()
+		result, err := next(ctx, request)
+
+		record := sessionLogRecord{
+			Time:       start.UTC(),
+			Session:    sessionKey(ctx),
+			Tool:       st.Tool.Name,
+			Arguments:  redactArguments(request.GetArguments()),
+			DurationMS: l.now().Sub(start).Milliseconds(),
+		}
+		if err != nil {
+			record.Error = redactSecrets(err.Error())
+		}
+		if result != nil {
+			var texts []string
+			for _, content := range result.Content {
+				if text, ok := content.(mcp.TextContent); ok {
+					texts = append(texts, text.Text)
+				}
+			}
+			record.Result = redactSecrets(strings.Join(texts, "\n"))
+			record.IsError = result.IsError
+		}
+		// The log is best effort: failing to write it is not the caller's error
+		_ = l.write(record)
+		return result, err
+	}
+	return st
+}
+
+// ExportSession creates a tool to export the log of the calls made so far in the current session.
+func ExportSession(log *SessionLog, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("export_session",
+			mcp.WithDescription(t("TOOL_EXPORT_SESSION_DESCRIPTION", "Export the log of the tool calls made so far in the current session, as JSONL with one call per line: the time, tool, arguments, result and duration of each. Secrets are redacted. Use it to review or reproduce the actions taken.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EXPORT_SESSION_USER_TITLE", "Export session log"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithNumber("last",
+				mcp.Description("Only export the last calls, all of them by default"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseExportSessionParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Last < 0 {
+				return mcp.NewToolResultError("last must be positive"), nil
+			}
+
+			data, err := log.read(sessionKey(ctx))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read session log: %w", err)
+			}
+			if len(data) == 0 {
+				return mcp.NewToolResultText("No tool calls were made in this session yet."), nil
+			}
+			text := string(data)
+			if params.Last > 0 {
+				// Each call is a line ending with a newline
+				lines := strings.SplitAfter(text, "\n")
+				lines = lines[:len(lines)-1]
+				if len(lines) > params.Last {
+					text = strings.Join(lines[len(lines)-params.Last:], "")
+				}
+			}
+			return mcp.NewToolResultText(text), nil
+		}
+}
@@ -0,0 +1,232 @@
+package github
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultSHACacheSize is how many tool results are cached by commit SHA by default.
+	DefaultSHACacheSize = 1000
+	// refResolutionTTL is how long the commit a branch or tag points to is remembered. Branches move, so it is short,
+	// but long enough for the reads of a single task.
+	refResolutionTTL = 30 * time.Second
+)
+
+// shaCachedTool describes a tool whose result only depends on its arguments and the commit it reads.
+type shaCachedTool struct {
+	// refParam is the parameter holding the branch, tag or commit the tool reads, the default branch when empty.
+	refParam string
+	// requiredParam, when set, must be given for the result to be cached, as the result depends on the current
+	// time without it.
+	requiredParam string
+}
+
+// shaCachedTools are the content, tree and blame tools whose results are cached by commit SHA, by name.
+var shaCachedTools = map[string]shaCachedTool{
+	"get_file_contents":    {refParam: "branch"},
+	"get_commit":           {refParam: "sha"},
+	"get_codebase_stats":   {refParam: "ref"},
+	"resolve_stack_trace":  {refParam: "ref"},
+	"find_tests_for_file":  {refParam: "ref"},
+	"find_suspect_commits": {refParam: "ref", requiredParam: "until"},
+}
+
+type shaCacheEntry struct {
+	key    string
+	result *mcp.CallToolResult
+}
+
+type resolvedRef struct {
+	sha     string
+	expires time.Time
+}
+
+// SHACacheStats are the counters of a SHACache.
+type SHACacheStats struct {
+	Hits    int `json:"hits"`
+	Misses  int `json:"misses"`
+	Entries int `json:"entries"`
+}
+
+// SHACache caches the results of content, tree and blame tools by the commit SHA they read, which never changes.
+// Branches and tags are resolved to their commit first, and the resolution is cached separately for a short time,
+// so that repeated reads of a branch hit the cache while still seeing new commits.
+type SHACache struct {
+	mu      sync.Mutex
+	size    int
+	results map[string]*list.Element
+	// lru holds the entries from the most to the least recently used.
+	lru   *list.List
+	refs  map[string]resolvedRef
+	stats SHACacheStats
+	now   func() time.Time
+}
+
+// NewSHACache creates an in-memory cache holding at most size results. A size of 0 or less disables caching.
+func NewSHACache(size int) *SHACache {
+	return &SHACache{
+		size:    size,
+		results: make(map[string]*list.Element),
+		lru:     list.New(),
+		refs:    make(map[string]resolvedRef),
+		now:     time.Now,
+	}
+}
+
+// SetSize changes how many results are cached, evicting the least recently used ones beyond it.
+func (c *SHACache) SetSize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = size
+	c.evict()
+}
+
+// Stats returns the hits and misses of the cache so far, and the number of results it holds.
+func (c *SHACache) Stats() SHACacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+func (c *SHACache) enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size > 0
+}
+
+// evict drops the least recently used results beyond the size of the cache. The caller holds the lock.
+func (c *SHACache) evict() {
+	for c.lru.Len() > max(c.size, 0) {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.results, oldest.Value.(*shaCacheEntry).key)
+	}
+}
+
+func (c *SHACache) get(key string) (*mcp.CallToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.results[key]; ok {
+		c.stats.Hits++
+		c.lru.MoveToFront(element)
+		return element.Value.(*shaCacheEntry).result, true
+	}
+	c.stats.Misses++
+	return nil, false
+}
+
+func (c *SHACache) put(key string, result *mcp.CallToolResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.results[key]; ok {
+		element.Value.(*shaCacheEntry).result = result
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.results[key] = c.lru.PushFront(&shaCacheEntry{key: key, result: result})
+	c.evict()
+}
+
+// resolve returns the commit SHA a ref of a repository points to. Full commit SHAs are returned as they are, other
+// refs are looked up unless they were looked up recently.
+func (c *SHACache) resolve(ctx context.Context, getClient GetClientFn, owner, repo, ref string) (string, error) {
+	if commitSHAPattern.MatchString(ref) {
+		return ref, nil
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	key := owner + "/" + repo + "@" + ref
+	c.mu.Lock()
+	resolved, ok := c.refs[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(resolved.expires) {
+		return resolved.sha, nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	sha, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, r := range c.refs {
+		if !now.Before(r.expires) {
+			delete(c.refs, k)
+		}
+	}
+	c.refs[key] = resolvedRef{sha: sha, expires: now.Add(refResolutionTTL)}
+	return sha, nil
+}
+
+// WithSHACache returns a middleware caching the results of the content, tree and blame tools by the commit SHA they
+// read. The ref argument of a call is resolved to a commit SHA, which the tool is then called with, so that the
+// cached result is the one of that commit even when a branch moves in between. Other tools pass through unchanged.
+func WithSHACache(cache *SHACache, getClient GetClientFn) ToolMiddleware {
+	return func(st server.ServerTool) server.ServerTool {
+		cached, ok := shaCachedTools[st.Tool.Name]
+		if !ok {
+			return st
+		}
+
+		next := st.Handler
+		st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := request.GetArguments()
+			if !cache.enabled() || (cached.requiredParam != "" && args[cached.requiredParam] == nil) {
+				return next(ctx, request)
+			}
+			owner, _ := args["owner"].(string)
+			repo, _ := args["repo"].(string)
+			ref, isString := args[cached.refParam].(string)
+			if owner == "" || repo == "" || (!isString && args[cached.refParam] != nil) {
+				return next(ctx, request)
+			}
+
+			sha, err := cache.resolve(ctx, getClient, owner, repo, ref)
+			if err != nil {
+				// The tool reports a missing repository or ref in its own words
+				return next(ctx, request)
+			}
+
+			resolvedArgs := make(map[string]any, len(args)+1)
+			for k, v := range args {
+				resolvedArgs[k] = v
+			}
+			resolvedArgs[cached.refParam] = sha
+			argsHash, err := hashArguments(resolvedArgs)
+			if err != nil {
+				return next(ctx, request)
+			}
+			key := st.Tool.Name + "\x00" + argsHash
+			if result, ok := cache.get(key); ok {
+				return result, nil
+			}
+
+			request.Params.Arguments = resolvedArgs
+			result, err := next(ctx, request)
+			if err == nil && result != nil && !result.IsError {
+				cache.put(key, result)
+			}
+			return result, err
+		}
+		return st
+	}
+}
@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refReadingTool is a tool named like a cached one, returning the ref it was called with.
+func refReadingTool(name, refParam string, calls *atomic.Int32) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name),
+		Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls.Add(1)
+			ref, _ := request.GetArguments()[refParam].(string)
+			if ref == "missing" {
+				return mcp.NewToolResultError("not found"), nil
+			}
+			return mcp.NewToolResultText("read at " + ref), nil
+		},
+	}
+}
+
+func Test_WithSHACache(t *testing.T) {
+	shaA := strings.Repeat("a", 40)
+	shaB := strings.Repeat("b", 40)
+
+	// newCache returns a cache whose refs resolve to the commits in heads, counting the resolutions
+	newCache := func(size int, heads map[string]string, resolutions *atomic.Int32) (*SHACache, GetClientFn) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepoByRef,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					resolutions.Add(1)
+					sha, ok := heads[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/commits/")]
+					if !ok {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "No commit found"}`))
+						return
+					}
+					_, _ = w.Write([]byte(sha))
+				}),
+			),
+		)
+		return NewSHACache(size), stubGetClientFn(github.NewClient(mockedClient))
+	}
+
+	t.Run("other tools are not wrapped", func(t *testing.T) {
+		var calls, resolutions atomic.Int32
+		cache, getClient := newCache(10, nil, &resolutions)
+		st := refReadingTool("create_branch", "branch", &calls)
+		wrapped := WithSHACache(cache, getClient)(st)
+		for i := 0; i < 2; i++ {
+			_, err := wrapped.Handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "branch": "main"}))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, int32(0), resolutions.Load())
+	})
+
+	t.Run("branches are resolved and results cached by commit", func(t *testing.T) {
+		var calls, resolutions atomic.Int32
+		heads := map[string]string{"main": shaA, "HEAD": shaA}
+		cache, getClient := newCache(10, heads, &resolutions)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		st := WithSHACache(cache, getClient)(refReadingTool("get_file_contents", "branch", &calls))
+
+		read := func(args map[string]any) string {
+			result, err := st.Handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			return getTextResult(t, result).Text
+		}
+		onMain := map[string]any{"owner": "owner", "repo": "repo", "path": "README.md", "branch": "main"}
+
+		// The tool is called with the commit the branch points to
+		assert.Equal(t, "read at "+shaA, read(onMain))
+		assert.Equal(t, "read at "+shaA, read(onMain))
+		// The default branch and the commit itself share the cached result
+		assert.Equal(t, "read at "+shaA, read(map[string]any{"owner": "owner", "repo": "repo", "path": "README.md"}))
+		assert.Equal(t, "read at "+shaA, read(map[string]any{"owner": "owner", "repo": "repo", "path": "README.md", "branch": shaA}))
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, int32(2), resolutions.Load(), "main and HEAD are resolved once each")
+
+		// Once the resolution expires, the new commit of the branch is read
+		heads["main"] = shaB
+		now = now.Add(refResolutionTTL)
+		assert.Equal(t, "read at "+shaB, read(onMain))
+		assert.Equal(t, int32(2), calls.Load())
+
+		stats := cache.Stats()
+		assert.Equal(t, 3, stats.Hits)
+		assert.Equal(t, 2, stats.Misses)
+		assert.Equal(t, 2, stats.Entries)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		var calls, resolutions atomic.Int32
+		cache, getClient := newCache(10, map[string]string{}, &resolutions)
+		st := WithSHACache(cache, getClient)(refReadingTool("get_file_contents", "branch", &calls))
+		for i := 0; i < 2; i++ {
+			// A ref that cannot be resolved is left to the tool
+			result, err := st.Handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "branch": "missing"}))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		}
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, 0, cache.Stats().Entries)
+	})
+
+	t.Run("results depending on the current time are not cached", func(t *testing.T) {
+		var calls, resolutions atomic.Int32
+		cache, getClient := newCache(10, nil, &resolutions)
+		st := WithSHACache(cache, getClient)(refReadingTool("find_suspect_commits", "ref", &calls))
+		args := map[string]any{"owner": "owner", "repo": "repo", "ref": shaA}
+		for i := 0; i < 2; i++ {
+			_, err := st.Handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), calls.Load())
+
+		args["until"] = "2025-01-01"
+		for i := 0; i < 2; i++ {
+			_, err := st.Handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("evicts the least recently used results", func(t *testing.T) {
+		var calls, resolutions atomic.Int32
+		cache, getClient := newCache(2, nil, &resolutions)
+		st := WithSHACache(cache, getClient)(refReadingTool("get_file_contents", "branch", &calls))
+		read := func(path string) {
+			_, err := st.Handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "path": path, "branch": shaA}))
+			require.NoError(t, err)
+		}
+		read("a")
+		read("b")
+		read("a")
+		read("c") // evicts b
+		read("a")
+		assert.Equal(t, int32(3), calls.Load())
+		read("b")
+		assert.Equal(t, int32(4), calls.Load())
+		assert.Equal(t, 2, cache.Stats().Entries)
+
+		cache.SetSize(0)
+		assert.Equal(t, 0, cache.Stats().Entries)
+		read("a")
+		assert.Equal(t, int32(5), calls.Load())
+	})
+}
@@ -0,0 +1,457 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// defaultMaxSponsors is the default number of sponsorships list_sponsors collects.
+	defaultMaxSponsors = 100
+	// maxSponsors bounds the sponsorships list_sponsors collects.
+	maxSponsors = 1000
+)
+
+// Sponsors fields are defined on the Sponsorable interface, which users and organizations implement. Queries go
+// through repositoryOwner, which resolves both, and select the same fields on each with inline fragments.
+
+type sponsorAccount struct {
+	Login githubv4.String
+	Name  githubv4.String
+}
+
+type sponsorsTierNode struct {
+	Name                  githubv4.String
+	MonthlyPriceInDollars githubv4.Int
+	IsOneTime             githubv4.Boolean
+	IsCustomAmount        githubv4.Boolean
+}
+
+type sponsorshipNode struct {
+	CreatedAt        githubv4.DateTime
+	IsOneTimePayment githubv4.Boolean
+	PrivacyLevel     githubv4.String
+	Tier             sponsorsTierNode
+	SponsorEntity    struct {
+		Typename     githubv4.String `graphql:"__typename"`
+		User         sponsorAccount  `graphql:"... on User"`
+		Organization sponsorAccount  `graphql:"... on Organization"`
+	}
+}
+
+type sponsorshipsConnection struct {
+	TotalCount githubv4.Int
+	Nodes      []sponsorshipNode
+	PageInfo   GraphQLPageInfo
+}
+
+type sponsorableSponsorships struct {
+	SponsorshipsAsMaintainer sponsorshipsConnection `graphql:"sponsorshipsAsMaintainer(first: $first, after: $cursor, activeOnly: $activeOnly, includePrivate: $includePrivate)"`
+}
+
+type sponsorshipsQuery struct {
+	RepositoryOwner struct {
+		Typename     githubv4.String         `graphql:"__typename"`
+		User         sponsorableSponsorships `graphql:"... on User"`
+		Organization sponsorableSponsorships `graphql:"... on Organization"`
+	} `graphql:"repositoryOwner(login: $login)"`
+	RateLimit GraphQLRateLimit `graphql:"rateLimit"`
+}
+
+type sponsorableListing struct {
+	HasSponsorsListing       githubv4.Boolean
+	SponsorshipsAsMaintainer struct {
+		TotalCount githubv4.Int
+	} `graphql:"sponsorshipsAsMaintainer(first: 1)"`
+	SponsorsListing struct {
+		Name             githubv4.String
+		ShortDescription githubv4.String
+		IsPublic         githubv4.Boolean
+		ActiveGoal       struct {
+			Kind            githubv4.String
+			Title           githubv4.String
+			TargetValue     githubv4.Int
+			PercentComplete githubv4.Int
+		}
+		Tiers struct {
+			Nodes []sponsorsTierNode
+		} `graphql:"tiers(first: 50)"`
+	}
+}
+
+type sponsorsListingQuery struct {
+	RepositoryOwner struct {
+		Typename     githubv4.String    `graphql:"__typename"`
+		User         sponsorableListing `graphql:"... on User"`
+		Organization sponsorableListing `graphql:"... on Organization"`
+	} `graphql:"repositoryOwner(login: $login)"`
+}
+
+type sponsorableIncome struct {
+	MonthlyEstimatedSponsorsIncomeInCents githubv4.Int
+}
+
+type sponsorsIncomeQuery struct {
+	RepositoryOwner struct {
+		Typename     githubv4.String   `graphql:"__typename"`
+		User         sponsorableIncome `graphql:"... on User"`
+		Organization sponsorableIncome `graphql:"... on Organization"`
+	} `graphql:"repositoryOwner(login: $login)"`
+}
+
+type sponsorableCheck struct {
+	IsSponsoredBy githubv4.Boolean `graphql:"isSponsoredBy(accountLogin: $sponsor)"`
+}
+
+type sponsorshipCheckQuery struct {
+	RepositoryOwner struct {
+		Typename     githubv4.String  `graphql:"__typename"`
+		User         sponsorableCheck `graphql:"... on User"`
+		Organization sponsorableCheck `graphql:"... on Organization"`
+	} `graphql:"repositoryOwner(login: $login)"`
+}
+
+// sponsorableFields returns the fields selected on the user or on the organization, depending on what the login
+// resolved to, and false when it resolved to neither.
+func sponsorableFields[T any](typename githubv4.String, user, organization T) (T, bool) {
+	switch typename {
+	case "User":
+		return user, true
+	case "Organization":
+		return organization, true
+	}
+	var zero T
+	return zero, false
+}
+
+// sponsorsTier is a tier of a Sponsors listing.
+type sponsorsTier struct {
+	Name            string `json:"name"`
+	MonthlyPriceUSD int    `json:"monthly_price_usd"`
+	OneTime         bool   `json:"one_time,omitempty"`
+	CustomAmount    bool   `json:"custom_amount,omitempty"`
+}
+
+func newSponsorsTier(node sponsorsTierNode) sponsorsTier {
+	return sponsorsTier{
+		Name:            string(node.Name),
+		MonthlyPriceUSD: int(node.MonthlyPriceInDollars),
+		OneTime:         bool(node.IsOneTime),
+		CustomAmount:    bool(node.IsCustomAmount),
+	}
+}
+
+// sponsor is a sponsorship in the result of list_sponsors.
+type sponsor struct {
+	Login   string       `json:"login"`
+	Name    string       `json:"name,omitempty"`
+	Type    string       `json:"type"`
+	Tier    sponsorsTier `json:"tier"`
+	Private bool         `json:"private,omitempty"`
+	Since   time.Time    `json:"since"`
+}
+
+// sponsorTierCount counts the listed sponsors of a tier.
+type sponsorTierCount struct {
+	sponsorsTier
+	Sponsors int `json:"sponsors"`
+}
+
+// sponsorsList is the result of list_sponsors.
+type sponsorsList struct {
+	Login      string `json:"login"`
+	TotalCount int    `json:"total_count"`
+	// MonthlyRecurringUSD sums the monthly price of the recurring sponsorships listed.
+	MonthlyRecurringUSD int                `json:"monthly_recurring_usd"`
+	ByTier              []sponsorTierCount `json:"by_tier"`
+	Sponsors            []sponsor          `json:"sponsors"`
+	Truncated           bool               `json:"truncated,omitempty"`
+}
+
+// summarizeSponsors totals the recurring sponsorships and counts the sponsors of each tier, the most expensive tier
+// first.
+func summarizeSponsors(list *sponsorsList) {
+	counts := map[sponsorsTier]int{}
+	for _, s := range list.Sponsors {
+		counts[s.Tier]++
+		if !s.Tier.OneTime {
+			list.MonthlyRecurringUSD += s.Tier.MonthlyPriceUSD
+		}
+	}
+	list.ByTier = make([]sponsorTierCount, 0, len(counts))
+	for tier, count := range counts {
+		list.ByTier = append(list.ByTier, sponsorTierCount{sponsorsTier: tier, Sponsors: count})
+	}
+	sort.Slice(list.ByTier, func(i, j int) bool {
+		a, b := list.ByTier[i], list.ByTier[j]
+		if a.MonthlyPriceUSD != b.MonthlyPriceUSD {
+			return a.MonthlyPriceUSD > b.MonthlyPriceUSD
+		}
+		if a.OneTime != b.OneTime {
+			return !a.OneTime
+		}
+		return a.Name < b.Name
+	})
+}
+
+// ListSponsors creates a tool to list the sponsors of a user or organization.
+func ListSponsors(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_sponsors",
+			mcp.WithDescription(t("TOOL_LIST_SPONSORS_DESCRIPTION", "List the GitHub Sponsors of a user or organization with the tier of each sponsorship, the number of sponsors by tier and the monthly total of the recurring sponsorships listed. Private sponsorships are only visible to the sponsored account.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SPONSORS_USER_TITLE", "List sponsors"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("login",
+				mcp.Required(),
+				mcp.Description("Login of the sponsored user or organization"),
+			),
+			mcp.WithBoolean("include_private",
+				mcp.Description("Include private sponsorships, when visible"),
+			),
+			mcp.WithBoolean("include_inactive",
+				mcp.Description("Include past sponsorships"),
+			),
+			mcp.WithNumber("max_sponsors",
+				mcp.Description(fmt.Sprintf("Number of sponsorships to list, defaults to %d, at most %d", defaultMaxSponsors, maxSponsors)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListSponsorsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxSponsors <= 0 {
+				params.MaxSponsors = defaultMaxSponsors
+			}
+			if params.MaxSponsors > maxSponsors {
+				return mcp.NewToolResultError(fmt.Sprintf("max_sponsors must be at most %d", maxSponsors)), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			found := true
+			totalCount := 0
+			sponsorships, err := PaginateGraphQL(ctx, GraphQLPaginationOptions{MaxNodes: params.MaxSponsors}, func(ctx context.Context, first githubv4.Int, after *githubv4.String) (GraphQLPage[sponsorshipNode], error) {
+				var query sponsorshipsQuery
+				if err := client.Query(ctx, &query, map[string]any{
+					"login":          githubv4.String(params.Login),
+					"first":          first,
+					"cursor":         after,
+					"activeOnly":     githubv4.Boolean(!params.IncludeInactive),
+					"includePrivate": githubv4.Boolean(params.IncludePrivate),
+				}); err != nil {
+					return GraphQLPage[sponsorshipNode]{}, err
+				}
+				var fields sponsorableSponsorships
+				fields, found = sponsorableFields(query.RepositoryOwner.Typename, query.RepositoryOwner.User, query.RepositoryOwner.Organization)
+				totalCount = int(fields.SponsorshipsAsMaintainer.TotalCount)
+				return GraphQLPage[sponsorshipNode]{
+					Nodes:     fields.SponsorshipsAsMaintainer.Nodes,
+					PageInfo:  fields.SponsorshipsAsMaintainer.PageInfo,
+					RateLimit: query.RateLimit,
+				}, nil
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !found {
+				return mcp.NewToolResultError(fmt.Sprintf("no user or organization %s", params.Login)), nil
+			}
+
+			result := sponsorsList{
+				Login:      params.Login,
+				TotalCount: totalCount,
+				Sponsors:   make([]sponsor, 0, len(sponsorships.Nodes)),
+				Truncated:  sponsorships.Truncated,
+			}
+			for _, node := range sponsorships.Nodes {
+				account := node.SponsorEntity.User
+				if node.SponsorEntity.Typename == "Organization" {
+					account = node.SponsorEntity.Organization
+				}
+				result.Sponsors = append(result.Sponsors, sponsor{
+					Login:   string(account.Login),
+					Name:    string(account.Name),
+					Type:    string(node.SponsorEntity.Typename),
+					Tier:    newSponsorsTier(node.Tier),
+					Private: node.PrivacyLevel == "PRIVATE",
+					Since:   node.CreatedAt.Time,
+				})
+			}
+			summarizeSponsors(&result)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// sponsorsGoal is the active goal of a Sponsors listing.
+type sponsorsGoal struct {
+	Kind            string `json:"kind"`
+	Title           string `json:"title"`
+	TargetValue     int    `json:"target_value"`
+	PercentComplete int    `json:"percent_complete"`
+}
+
+// sponsorsListing is the result of get_sponsors_listing.
+type sponsorsListing struct {
+	Login            string         `json:"login"`
+	Name             string         `json:"name"`
+	ShortDescription string         `json:"short_description,omitempty"`
+	Public           bool           `json:"public"`
+	ActiveSponsors   int            `json:"active_sponsors"`
+	Tiers            []sponsorsTier `json:"tiers"`
+	Goal             *sponsorsGoal  `json:"goal,omitempty"`
+	// MonthlyEstimatedIncomeUSD is only visible to the sponsored account, and the admins of an organization.
+	MonthlyEstimatedIncomeUSD *float64 `json:"monthly_estimated_income_usd,omitempty"`
+	Notes                     []string `json:"notes,omitempty"`
+}
+
+// GetSponsorsListing creates a tool to get the Sponsors listing of a user or organization.
+func GetSponsorsListing(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_sponsors_listing",
+			mcp.WithDescription(t("TOOL_GET_SPONSORS_LISTING_DESCRIPTION", "Get the GitHub Sponsors listing of a user or organization: its tiers, active goal and number of active sponsors, and the estimated monthly income, which only the sponsored account and the admins of an organization can see.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SPONSORS_LISTING_USER_TITLE", "Get Sponsors listing"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("login",
+				mcp.Required(),
+				mcp.Description("Login of the sponsored user or organization"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetSponsorsListingParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{"login": githubv4.String(params.Login)}
+			var query sponsorsListingQuery
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fields, ok := sponsorableFields(query.RepositoryOwner.Typename, query.RepositoryOwner.User, query.RepositoryOwner.Organization)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("no user or organization %s", params.Login)), nil
+			}
+			if !fields.HasSponsorsListing {
+				return mcp.NewToolResultError(fmt.Sprintf("%s has no GitHub Sponsors listing", params.Login)), nil
+			}
+
+			listing := fields.SponsorsListing
+			result := sponsorsListing{
+				Login:            params.Login,
+				Name:             string(listing.Name),
+				ShortDescription: string(listing.ShortDescription),
+				Public:           bool(listing.IsPublic),
+				ActiveSponsors:   int(fields.SponsorshipsAsMaintainer.TotalCount),
+				Tiers:            make([]sponsorsTier, 0, len(listing.Tiers.Nodes)),
+			}
+			for _, node := range listing.Tiers.Nodes {
+				result.Tiers = append(result.Tiers, newSponsorsTier(node))
+			}
+			if listing.ActiveGoal.Kind != "" {
+				result.Goal = &sponsorsGoal{
+					Kind:            string(listing.ActiveGoal.Kind),
+					Title:           string(listing.ActiveGoal.Title),
+					TargetValue:     int(listing.ActiveGoal.TargetValue),
+					PercentComplete: int(listing.ActiveGoal.PercentComplete),
+				}
+			}
+
+			// The income is queried separately, as the query fails for those who cannot see it
+			var income sponsorsIncomeQuery
+			if err := client.Query(ctx, &income, vars); err != nil {
+				result.Notes = append(result.Notes, fmt.Sprintf("the estimated monthly income is not visible: %v", err))
+			} else if fields, ok := sponsorableFields(income.RepositoryOwner.Typename, income.RepositoryOwner.User, income.RepositoryOwner.Organization); ok {
+				dollars := float64(fields.MonthlyEstimatedSponsorsIncomeInCents) / 100
+				result.MonthlyEstimatedIncomeUSD = &dollars
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// sponsorshipCheck is the result of check_sponsorship.
+type sponsorshipCheck struct {
+	Login     string `json:"login"`
+	Sponsor   string `json:"sponsor"`
+	IsSponsor bool   `json:"is_sponsor"`
+}
+
+// CheckSponsorship creates a tool to check whether an account sponsors a user or organization.
+func CheckSponsorship(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("check_sponsorship",
+			mcp.WithDescription(t("TOOL_CHECK_SPONSORSHIP_DESCRIPTION", "Check whether a user or organization sponsors another through GitHub Sponsors, e.g. to thank sponsors or give them priority support. Private sponsorships are only visible to the accounts involved.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_SPONSORSHIP_USER_TITLE", "Check sponsorship"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("login",
+				mcp.Required(),
+				mcp.Description("Login of the sponsored user or organization"),
+			),
+			mcp.WithString("sponsor",
+				mcp.Required(),
+				mcp.Description("Login of the possible sponsor"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCheckSponsorshipParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query sponsorshipCheckQuery
+			if err := client.Query(ctx, &query, map[string]any{
+				"login":   githubv4.String(params.Login),
+				"sponsor": githubv4.String(params.Sponsor),
+			}); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fields, ok := sponsorableFields(query.RepositoryOwner.Typename, query.RepositoryOwner.User, query.RepositoryOwner.Organization)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("no user or organization %s", params.Login)), nil
+			}
+
+			r, err := json.Marshal(sponsorshipCheck{
+				Login:     params.Login,
+				Sponsor:   params.Sponsor,
+				IsSponsor: bool(fields.IsSponsoredBy),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,241 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListSponsors(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListSponsors(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_sponsors", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "login")
+	assert.Contains(t, tool.InputSchema.Properties, "include_private")
+	assert.Contains(t, tool.InputSchema.Properties, "include_inactive")
+	assert.Contains(t, tool.InputSchema.Properties, "max_sponsors")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"login"})
+
+	sponsorship := func(typename, login, tier string, price int, oneTime bool, privacy string) map[string]any {
+		return map[string]any{
+			"createdAt":        "2026-01-02T03:04:05Z",
+			"isOneTimePayment": oneTime,
+			"privacyLevel":     privacy,
+			"tier": map[string]any{
+				"name":                  tier,
+				"monthlyPriceInDollars": price,
+				"isOneTime":             oneTime,
+				"isCustomAmount":        false,
+			},
+			"sponsorEntity": map[string]any{"__typename": typename, "login": login, "name": ""},
+		}
+	}
+	matcher := githubv4mock.NewQueryMatcher(
+		sponsorshipsQuery{},
+		map[string]any{
+			"login":          githubv4.String("octo-org"),
+			"first":          githubv4.Int(2),
+			"cursor":         (*githubv4.String)(nil),
+			"activeOnly":     githubv4.Boolean(true),
+			"includePrivate": githubv4.Boolean(true),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{
+				"__typename": "Organization",
+				"sponsorshipsAsMaintainer": map[string]any{
+					"totalCount": 3,
+					"nodes": []any{
+						sponsorship("User", "alice", "$5 a month", 5, false, "PUBLIC"),
+						sponsorship("Organization", "acme", "$100 a month", 100, false, "PRIVATE"),
+					},
+					"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c2"},
+				},
+			},
+			"rateLimit": map[string]any{"cost": 1, "remaining": 4999},
+		}),
+	)
+
+	_, handler := ListSponsors(stubGetGQLClientFn(githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"login":           "octo-org",
+		"include_private": true,
+		"max_sponsors":    float64(2),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var list sponsorsList
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &list))
+	assert.Equal(t, 3, list.TotalCount)
+	assert.True(t, list.Truncated)
+	assert.Equal(t, 105, list.MonthlyRecurringUSD)
+	require.Len(t, list.Sponsors, 2)
+	assert.Equal(t, sponsor{
+		Login:   "acme",
+		Type:    "Organization",
+		Tier:    sponsorsTier{Name: "$100 a month", MonthlyPriceUSD: 100},
+		Private: true,
+		Since:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, list.Sponsors[1])
+	require.Len(t, list.ByTier, 2)
+	assert.Equal(t, "$100 a month", list.ByTier[0].Name)
+	assert.Equal(t, 1, list.ByTier[0].Sponsors)
+}
+
+func Test_SummarizeSponsors(t *testing.T) {
+	monthly := sponsorsTier{Name: "$10 a month", MonthlyPriceUSD: 10}
+	oneTime := sponsorsTier{Name: "$10 one time", MonthlyPriceUSD: 10, OneTime: true}
+	list := sponsorsList{Sponsors: []sponsor{{Tier: oneTime}, {Tier: monthly}, {Tier: monthly}}}
+	summarizeSponsors(&list)
+
+	assert.Equal(t, 20, list.MonthlyRecurringUSD)
+	assert.Equal(t, []sponsorTierCount{
+		{sponsorsTier: monthly, Sponsors: 2},
+		{sponsorsTier: oneTime, Sponsors: 1},
+	}, list.ByTier)
+}
+
+func Test_GetSponsorsListing(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := GetSponsorsListing(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_sponsors_listing", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"login"})
+
+	vars := map[string]any{"login": githubv4.String("octocat")}
+	listingMatcher := githubv4mock.NewQueryMatcher(
+		sponsorsListingQuery{},
+		vars,
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{
+				"__typename":               "User",
+				"hasSponsorsListing":       true,
+				"sponsorshipsAsMaintainer": map[string]any{"totalCount": 42},
+				"sponsorsListing": map[string]any{
+					"name":             "sponsors-octocat",
+					"shortDescription": "Building tools for everyone",
+					"isPublic":         true,
+					"activeGoal": map[string]any{
+						"kind":            "MONTHLY_SPONSORSHIP_AMOUNT",
+						"title":           "Full time open source",
+						"targetValue":     5000,
+						"percentComplete": 40,
+					},
+					"tiers": map[string]any{
+						"nodes": []any{
+							map[string]any{"name": "$5 a month", "monthlyPriceInDollars": 5, "isOneTime": false, "isCustomAmount": false},
+						},
+					},
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		incomeResponse githubv4mock.GQLResponse
+		expectedIncome *float64
+		expectNote     bool
+	}{
+		{
+			name: "with income",
+			incomeResponse: githubv4mock.DataResponse(map[string]any{
+				"repositoryOwner": map[string]any{"__typename": "User", "monthlyEstimatedSponsorsIncomeInCents": 201050},
+			}),
+			expectedIncome: githubv4mock.Ptr(2010.5),
+		},
+		{
+			name:           "income not visible",
+			incomeResponse: githubv4mock.ErrorResponse("must be the sponsorable to view the income"),
+			expectNote:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4mock.NewMockedHTTPClient(listingMatcher, githubv4mock.NewQueryMatcher(sponsorsIncomeQuery{}, vars, tc.incomeResponse))
+			_, handler := GetSponsorsListing(stubGetGQLClientFn(githubv4.NewClient(client)), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"login": "octocat"}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			require.False(t, result.IsError, textContent.Text)
+
+			var listing sponsorsListing
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &listing))
+			assert.Equal(t, "sponsors-octocat", listing.Name)
+			assert.Equal(t, 42, listing.ActiveSponsors)
+			assert.Equal(t, []sponsorsTier{{Name: "$5 a month", MonthlyPriceUSD: 5}}, listing.Tiers)
+			require.NotNil(t, listing.Goal)
+			assert.Equal(t, 40, listing.Goal.PercentComplete)
+			assert.Equal(t, tc.expectedIncome, listing.MonthlyEstimatedIncomeUSD)
+			assert.Equal(t, tc.expectNote, len(listing.Notes) == 1)
+		})
+	}
+}
+
+func Test_CheckSponsorship(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := CheckSponsorship(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "check_sponsorship", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"login", "sponsor"})
+
+	vars := map[string]any{"login": githubv4.String("octo-org"), "sponsor": githubv4.String("alice")}
+	tests := []struct {
+		name          string
+		response      githubv4mock.GQLResponse
+		expectError   string
+		expectSponsor bool
+	}{
+		{
+			name: "sponsor",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repositoryOwner": map[string]any{"__typename": "Organization", "isSponsoredBy": true},
+			}),
+			expectSponsor: true,
+		},
+		{
+			name: "not a sponsor",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repositoryOwner": map[string]any{"__typename": "Organization", "isSponsoredBy": false},
+			}),
+		},
+		{
+			name:        "unknown account",
+			response:    githubv4mock.DataResponse(map[string]any{"repositoryOwner": nil}),
+			expectError: "no user or organization octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(sponsorshipCheckQuery{}, vars, tc.response))
+			_, handler := CheckSponsorship(stubGetGQLClientFn(githubv4.NewClient(client)), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"login": "octo-org", "sponsor": "alice"}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError != "" {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectError, textContent.Text)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+			var check sponsorshipCheck
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &check))
+			assert.Equal(t, tc.expectSponsor, check.IsSponsor)
+		})
+	}
+}
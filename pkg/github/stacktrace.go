@@ -0,0 +1,307 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultStackFrameContextLines is the number of lines shown before and after the line of each frame.
+	defaultStackFrameContextLines = 5
+
+	// defaultMaxStackFrames is the number of frames resolved when the caller does not say.
+	defaultMaxStackFrames = 20
+)
+
+// stackFramePatterns match the file and line of a frame in the stack traces of common languages. Each has the
+// named groups file and line, and optionally function.
+var stackFramePatterns = []*regexp.Regexp{
+	// Python: File "app/models.py", line 12, in save
+	regexp.MustCompile(`File "(?P<file>[^"]+)", line (?P<line>\d+)(?:, in (?P<function>\S+))?`),
+	// .NET: at App.Models.Save() in C:\src\App\Models.cs:line 12
+	regexp.MustCompile(`at (?P<function>\S+?)(?:\(.*?\))? in (?P<file>.+?):line (?P<line>\d+)`),
+	// Java, Kotlin and Scala only give the name of the file, the package is resolved in parseStackFrame
+	regexp.MustCompile(`at (?P<function>[\w$.<>/]+)\((?P<file>[\w$-]+\.(?:java|kt|scala|groovy)):(?P<line>\d+)\)`),
+	// JavaScript: at save (/app/src/models.js:12:5)
+	regexp.MustCompile(`at (?P<function>[^\s(]+) \((?P<file>[^()\s]+?):(?P<line>\d+)(?::\d+)?\)`),
+	// Go, Ruby, Rust, anonymous JavaScript frames and most others: path/to/file.ext:12
+	regexp.MustCompile(`(?:^|[\s(\[@'"])(?P<file>(?:[A-Za-z]:)?[\w./\\@+~-]*\w\.\w+):(?P<line>\d+)`),
+}
+
+// stackFrame is a frame of a stack trace, and where it was found in the repository.
+type stackFrame struct {
+	Frame    string `json:"frame"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function,omitempty"`
+
+	Path       string   `json:"path,omitempty"`
+	Snippet    string   `json:"snippet,omitempty"`
+	Candidates []string `json:"candidates,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// stackTraceResolution is the result of the resolve_stack_trace tool.
+type stackTraceResolution struct {
+	Ref        string       `json:"ref"`
+	Frames     []stackFrame `json:"frames"`
+	Unresolved []stackFrame `json:"unresolved"`
+	Truncated  bool         `json:"truncated,omitempty"`
+	Warnings   []string     `json:"warnings,omitempty"`
+}
+
+// parseStackFrame finds the file and line of a line of a stack trace, if it has any.
+func parseStackFrame(line string) (stackFrame, bool) {
+	line = strings.NewReplacer("file://", "", "webpack:///", "").Replace(line)
+	for _, pattern := range stackFramePatterns {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		frame := stackFrame{Frame: strings.TrimSpace(line)}
+		for i, name := range pattern.SubexpNames() {
+			switch name {
+			case "file":
+				frame.File = match[i]
+			case "line":
+				frame.Line, _ = strconv.Atoi(match[i])
+			case "function":
+				frame.Function = match[i]
+			}
+		}
+		if frame.Line == 0 {
+			continue
+		}
+		// A JVM frame names the method of a class, whose package gives the directory of the file. Newer JVMs
+		// prefix it with the class loader or module, as in app//com.example.Main.run
+		switch path.Ext(frame.File) {
+		case ".java", ".kt", ".scala", ".groovy":
+			method := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+			if parts := strings.Split(method, "."); len(parts) > 2 && !strings.Contains(frame.File, "/") {
+				frame.File = strings.Join(parts[:len(parts)-2], "/") + "/" + frame.File
+			}
+		}
+		return frame, true
+	}
+	return stackFrame{}, false
+}
+
+// parseStackTrace returns the frames of a stack trace that name a file and a line, skipping repeated frames.
+func parseStackTrace(trace string) []stackFrame {
+	var frames []stackFrame
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(trace, "\n") {
+		frame, ok := parseStackFrame(line)
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// pathSegments splits a file path as it appears in a stack trace into its segments, whatever the platform.
+func pathSegments(file string) []string {
+	file = strings.ReplaceAll(file, `\`, "/")
+	if len(file) > 1 && file[1] == ':' {
+		file = file[2:]
+	}
+	var segments []string
+	for _, segment := range strings.Split(path.Clean("/"+file), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// matchRepositoryPath finds the file of the repository a stack trace path refers to. Traces hold the paths of the
+// machine that produced them, so the repository path is looked for at the end of the trace path, or the other way
+// around for the relative paths of some languages. The candidates sharing the most trailing segments win, several
+// of them make the match ambiguous.
+func matchRepositoryPath(file string, filesByName map[string][]string) (match string, candidates []string) {
+	segments := pathSegments(file)
+	if len(segments) == 0 {
+		return "", nil
+	}
+
+	best := 0
+	for _, candidate := range filesByName[segments[len(segments)-1]] {
+		candidateSegments := strings.Split(candidate, "/")
+		shared := 0
+		for shared < len(segments) && shared < len(candidateSegments) &&
+			segments[len(segments)-1-shared] == candidateSegments[len(candidateSegments)-1-shared] {
+			shared++
+		}
+		if shared != len(segments) && shared != len(candidateSegments) {
+			continue
+		}
+		switch {
+		case shared > best:
+			best = shared
+			candidates = []string{candidate}
+		case shared == best:
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	return "", candidates
+}
+
+// sourceSnippet returns the lines around a line of a file, numbered, with the line itself marked.
+func sourceSnippet(content string, line, contextLines int) (string, error) {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if line > len(lines) {
+		return "", fmt.Errorf("line %d is past the end of the file (%d lines)", line, len(lines))
+	}
+	return numberedLines(lines, max(line-contextLines, 1), min(line+contextLines, len(lines)), line, line), nil
+}
+
+// ResolveStackTrace creates a tool to map the frames of a stack trace to the source of a repository.
+func ResolveStackTrace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("resolve_stack_trace",
+			mcp.WithDescription(t("TOOL_RESOLVE_STACK_TRACE_DESCRIPTION", "Parse a stack trace, find the repository file of each frame at a ref and return the source around the line of each frame in one call. Understands the traces of Go, Python, Java, Kotlin, JavaScript, Ruby, Rust and .NET among others; frames in files outside the repository are listed as unresolved.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESOLVE_STACK_TRACE_USER_TITLE", "Resolve stack trace to source"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("stack_trace",
+				mcp.Required(),
+				mcp.Description("The stack trace, as printed"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA the trace comes from, defaults to the default branch"),
+			),
+			mcp.WithNumber("context_lines",
+				mcp.Description(fmt.Sprintf("Number of lines shown before and after the line of each frame (default %d)", defaultStackFrameContextLines)),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("max_frames",
+				mcp.Description(fmt.Sprintf("Maximum number of frames to resolve, from the top of the trace (default %d)", defaultMaxStackFrames)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseResolveStackTraceParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Zero context lines is a valid choice, only a missing parameter gets the default
+			if _, ok := request.GetArguments()["context_lines"]; !ok {
+				params.ContextLines = defaultStackFrameContextLines
+			}
+			if params.ContextLines < 0 {
+				return mcp.NewToolResultError("context_lines must not be negative"), nil
+			}
+			if params.MaxFrames <= 0 {
+				params.MaxFrames = defaultMaxStackFrames
+			}
+
+			frames := parseStackTrace(params.StackTrace)
+			if len(frames) == 0 {
+				return mcp.NewToolResultError("no frame with a file and line number found in the stack trace"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref := params.Ref
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, ref, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree of %s: %w", ref, err)
+			}
+			_ = resp.Body.Close()
+
+			result := stackTraceResolution{
+				Ref:        ref,
+				Frames:     []stackFrame{},
+				Unresolved: []stackFrame{},
+			}
+			if tree.GetTruncated() {
+				result.Warnings = append(result.Warnings, "the repository tree is too large to list completely, frames in files that were not listed are reported as unresolved")
+			}
+			if len(frames) > params.MaxFrames {
+				frames = frames[:params.MaxFrames]
+				result.Truncated = true
+			}
+
+			filesByName := make(map[string][]string)
+			for _, entry := range tree.Entries {
+				if entry.GetType() == "blob" {
+					filesByName[path.Base(entry.GetPath())] = append(filesByName[path.Base(entry.GetPath())], entry.GetPath())
+				}
+			}
+
+			contents := make(map[string]string)
+			fetchErrors := make(map[string]error)
+			for _, frame := range frames {
+				frame.Path, frame.Candidates = matchRepositoryPath(frame.File, filesByName)
+				if frame.Path == "" {
+					result.Unresolved = append(result.Unresolved, frame)
+					continue
+				}
+
+				content, fetched := contents[frame.Path]
+				if err := fetchErrors[frame.Path]; err == nil && !fetched {
+					data, _, found, err := getFileAtRef(ctx, client, params.Owner, params.Repo, frame.Path, ref)
+					switch {
+					case err != nil:
+						fetchErrors[frame.Path] = err
+					case !found:
+						fetchErrors[frame.Path] = fmt.Errorf("%s not found at %s", frame.Path, ref)
+					default:
+						content = string(data)
+						contents[frame.Path] = content
+					}
+				}
+				if err := fetchErrors[frame.Path]; err != nil {
+					frame.Error = err.Error()
+				} else if frame.Snippet, err = sourceSnippet(content, frame.Line, params.ContextLines); err != nil {
+					frame.Error = err.Error()
+				}
+				result.Frames = append(result.Frames, frame)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
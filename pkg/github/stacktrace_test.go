@@ -0,0 +1,282 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseStackFrame(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		file     string
+		lineNo   int
+		function string
+	}{
+		{
+			name:   "go",
+			line:   "\t/home/runner/work/app/app/pkg/server.go:42 +0x1d",
+			file:   "/home/runner/work/app/app/pkg/server.go",
+			lineNo: 42,
+		},
+		{
+			name:     "python",
+			line:     `  File "/srv/app/models/user.py", line 12, in save`,
+			file:     "/srv/app/models/user.py",
+			lineNo:   12,
+			function: "save",
+		},
+		{
+			name:     "java",
+			line:     "\tat com.example.app.Main.run(Main.java:42)",
+			file:     "com/example/app/Main.java",
+			lineNo:   42,
+			function: "com.example.app.Main.run",
+		},
+		{
+			name:     "java with class loader",
+			line:     "\tat app//com.example.Service.call(Service.java:7)",
+			file:     "com/example/Service.java",
+			lineNo:   7,
+			function: "app//com.example.Service.call",
+		},
+		{
+			name:     "javascript",
+			line:     "    at save (/app/src/models.js:12:5)",
+			file:     "/app/src/models.js",
+			lineNo:   12,
+			function: "save",
+		},
+		{
+			name:   "anonymous javascript",
+			line:   "    at file:///app/src/index.mjs:3:1",
+			file:   "/app/src/index.mjs",
+			lineNo: 3,
+		},
+		{
+			name:   "ruby",
+			line:   "app/models/user.rb:12:in `save'",
+			file:   "app/models/user.rb",
+			lineNo: 12,
+		},
+		{
+			name:     "dotnet",
+			line:     `   at App.Models.Save() in C:\src\App\Models.cs:line 12`,
+			file:     `C:\src\App\Models.cs`,
+			lineNo:   12,
+			function: "App.Models.Save",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			frame, ok := parseStackFrame(tc.line)
+			require.True(t, ok)
+			assert.Equal(t, tc.file, frame.File)
+			assert.Equal(t, tc.lineNo, frame.Line)
+			assert.Equal(t, tc.function, frame.Function)
+		})
+	}
+
+	_, ok := parseStackFrame("panic: runtime error: invalid memory address or nil pointer dereference")
+	assert.False(t, ok)
+	_, ok = parseStackFrame("goroutine 1 [running]:")
+	assert.False(t, ok)
+}
+
+func Test_MatchRepositoryPath(t *testing.T) {
+	files := []string{
+		"pkg/server.go",
+		"cmd/server/main.go",
+		"tools/main.go",
+		"src/main/java/com/example/app/Main.java",
+		"packages/web/src/index.js",
+		"packages/api/src/index.js",
+	}
+	filesByName := make(map[string][]string)
+	for _, f := range files {
+		name := f[strings.LastIndex(f, "/")+1:]
+		filesByName[name] = append(filesByName[name], f)
+	}
+
+	tests := []struct {
+		file       string
+		match      string
+		candidates []string
+	}{
+		{file: "/home/runner/work/app/app/pkg/server.go", match: "pkg/server.go"},
+		{file: `C:\build\app\cmd\server\main.go`, match: "cmd/server/main.go"},
+		{file: "com/example/app/Main.java", match: "src/main/java/com/example/app/Main.java"},
+		{file: "/app/packages/web/src/index.js", match: "packages/web/src/index.js"},
+		{file: "src/index.js", candidates: []string{"packages/web/src/index.js", "packages/api/src/index.js"}},
+		{file: "/usr/local/go/src/net/http/server.go"},
+		{file: "lib/missing.rb"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			match, candidates := matchRepositoryPath(tc.file, filesByName)
+			assert.Equal(t, tc.match, match)
+			assert.Equal(t, tc.candidates, candidates)
+		})
+	}
+}
+
+func Test_SourceSnippet(t *testing.T) {
+	content := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\n"
+
+	snippet, err := sourceSnippet(content, 10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "   9 | nine\n> 10 | ten\n  11 | eleven\n", snippet)
+
+	snippet, err = sourceSnippet(content, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "> 1 | one\n", snippet)
+
+	_, err = sourceSnippet(content, 12, 1)
+	assert.Error(t, err)
+}
+
+func Test_ResolveStackTrace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ResolveStackTrace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "resolve_stack_trace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "stack_trace")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "context_lines")
+	assert.Contains(t, tool.InputSchema.Properties, "max_frames")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "stack_trace"})
+
+	trace := `panic: runtime error: invalid memory address or nil pointer dereference
+
+goroutine 1 [running]:
+github.com/octo-org/app/pkg.(*Server).Handle(0x0)
+	/home/runner/work/app/app/pkg/server.go:4 +0x1d
+github.com/octo-org/app/pkg.(*Server).Serve(0x0)
+	/home/runner/work/app/app/pkg/server.go:8 +0x2e
+net/http.HandlerFunc.ServeHTTP(0x0)
+	/usr/local/go/src/net/http/server.go:2136 +0x29
+main.main()
+	/home/runner/work/app/app/cmd/app/main.go:3 +0x25
+`
+	serverGo := "package pkg\n\nfunc (s *Server) Handle() {\n\ts.handler.Run()\n}\n\nfunc (s *Server) Serve() {\n\ts.Handle()\n}\n"
+	mainGo := "package main\n\nfunc main() { pkg.NewServer().Serve() }\n"
+
+	contentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "v1.2.0", r.URL.Query().Get("ref"))
+		var content string
+		switch r.URL.Path {
+		case "/repos/octo-org/app/contents/pkg/server.go":
+			content = serverGo
+		case "/repos/octo-org/app/contents/cmd/app/main.go":
+			content = mainGo
+		default:
+			mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			SHA:      github.Ptr("abc"),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		})(w, r)
+	})
+	tree := &github.Tree{
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("pkg"), Type: github.Ptr("tree")},
+			{Path: github.Ptr("pkg/server.go"), Type: github.Ptr("blob")},
+			{Path: github.Ptr("cmd/app/main.go"), Type: github.Ptr("blob")},
+		},
+	}
+
+	t.Run("resolves frames in the repository", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				expectPath(t, "/repos/octo-org/app/git/trees/v1.2.0").andThen(mockResponse(t, http.StatusOK, tree)),
+			),
+			mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+		)
+		_, handler := ResolveStackTrace(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "octo-org",
+			"repo":          "app",
+			"stack_trace":   trace,
+			"ref":           "v1.2.0",
+			"context_lines": float64(1),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var resolution stackTraceResolution
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &resolution))
+		assert.Equal(t, "v1.2.0", resolution.Ref)
+		require.Len(t, resolution.Frames, 3)
+
+		assert.Equal(t, "pkg/server.go", resolution.Frames[0].Path)
+		assert.Equal(t, 4, resolution.Frames[0].Line)
+		assert.Equal(t, "  3 | func (s *Server) Handle() {\n> 4 | \ts.handler.Run()\n  5 | }\n", resolution.Frames[0].Snippet)
+		assert.Equal(t, "pkg/server.go", resolution.Frames[1].Path)
+		assert.Equal(t, "  7 | func (s *Server) Serve() {\n> 8 | \ts.Handle()\n  9 | }\n", resolution.Frames[1].Snippet)
+		assert.Equal(t, "cmd/app/main.go", resolution.Frames[2].Path)
+		assert.Equal(t, "  2 | \n> 3 | func main() { pkg.NewServer().Serve() }\n", resolution.Frames[2].Snippet)
+
+		require.Len(t, resolution.Unresolved, 1)
+		assert.Equal(t, "/usr/local/go/src/net/http/server.go", resolution.Unresolved[0].File)
+		assert.False(t, resolution.Truncated)
+	})
+
+	t.Run("limits frames and uses the default branch", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("v1.2.0")}),
+			mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, tree),
+			mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, contentsHandler),
+		)
+		_, handler := ResolveStackTrace(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "octo-org",
+			"repo":        "app",
+			"stack_trace": trace,
+			"max_frames":  float64(1),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var resolution stackTraceResolution
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &resolution))
+		require.Len(t, resolution.Frames, 1)
+		assert.Contains(t, resolution.Frames[0].Snippet, "> 4 | \ts.handler.Run()\n")
+		assert.True(t, resolution.Truncated)
+	})
+
+	t.Run("no frames", func(t *testing.T) {
+		_, handler := ResolveStackTrace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "octo-org",
+			"repo":        "app",
+			"stack_trace": "something went wrong",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "no frame")
+	})
+}
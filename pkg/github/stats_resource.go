@@ -0,0 +1,317 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxStatsPages bounds the pages of stargazers and of forks read for the stats resource, the most recent ones.
+const maxStatsPages = 10
+
+// statsDateLayout is the layout of the dates of the stats resource.
+const statsDateLayout = "2006-01-02"
+
+// repositoryStatsDay holds the stats of a repository on a day. Values are nil when unknown on that day: stars and
+// forks before the oldest ones read, traffic outside of the last 14 days.
+type repositoryStatsDay struct {
+	Date         string `json:"date"`
+	Stars        *int   `json:"stars"`
+	Forks        *int   `json:"forks"`
+	Views        *int   `json:"views"`
+	UniqueViews  *int   `json:"unique_views"`
+	Clones       *int   `json:"clones"`
+	UniqueClones *int   `json:"unique_clones"`
+}
+
+// repositoryStats is the content of the stats resource.
+type repositoryStats struct {
+	Repository string               `json:"repository"`
+	Stars      int                  `json:"stars"`
+	Forks      int                  `json:"forks"`
+	Days       []repositoryStatsDay `json:"days"`
+	Notes      []string             `json:"notes,omitempty"`
+}
+
+// statsTraffic holds the traffic of a repository by day, keyed by date.
+type statsTraffic struct {
+	views, uniqueViews, clones, uniqueClones map[string]int
+}
+
+// cumulativeByDay returns the running total of events by day, starting from base before the first one.
+func cumulativeByDay(base int, dates []time.Time) map[string]int {
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	totals := make(map[string]int, len(dates))
+	for i, date := range dates {
+		totals[date.UTC().Format(statsDateLayout)] = base + i + 1
+	}
+	return totals
+}
+
+// buildRepositoryStats assembles the daily series of a repository from the dates of its most recent stars and forks,
+// stars and forks being their total counts, and its traffic, from the first day known up to today.
+func buildRepositoryStats(stats *repositoryStats, starDates, forkDates []time.Time, traffic *statsTraffic, today time.Time) {
+	starTotals := cumulativeByDay(stats.Stars-len(starDates), starDates)
+	forkTotals := cumulativeByDay(stats.Forks-len(forkDates), forkDates)
+
+	// Each series starts on the day of its oldest event read, or today when there is none: a repository without
+	// stars has 0 stars today, nothing is known of the days before
+	first := today
+	starsSince, forksSince := today, today
+	for _, date := range starDates {
+		if date.Before(starsSince) {
+			starsSince = date
+		}
+	}
+	for _, date := range forkDates {
+		if date.Before(forksSince) {
+			forksSince = date
+		}
+	}
+	starsSince, forksSince = starsSince.UTC().Truncate(24*time.Hour), forksSince.UTC().Truncate(24*time.Hour)
+	for _, since := range []time.Time{starsSince, forksSince} {
+		if since.Before(first) {
+			first = since
+		}
+	}
+	if traffic != nil {
+		for _, series := range []map[string]int{traffic.views, traffic.clones} {
+			for date := range series {
+				if day, err := time.Parse(statsDateLayout, date); err == nil && day.Before(first) {
+					first = day
+				}
+			}
+		}
+	}
+
+	stars, forks := stats.Stars-len(starDates), stats.Forks-len(forkDates)
+	for day := first.UTC().Truncate(24 * time.Hour); !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format(statsDateLayout)
+		entry := repositoryStatsDay{Date: date}
+		if total, ok := starTotals[date]; ok {
+			stars = total
+		}
+		if !day.Before(starsSince) {
+			entry.Stars = github.Ptr(stars)
+		}
+		if total, ok := forkTotals[date]; ok {
+			forks = total
+		}
+		if !day.Before(forksSince) {
+			entry.Forks = github.Ptr(forks)
+		}
+		if traffic != nil {
+			entry.Views, entry.UniqueViews = trafficOn(traffic.views, traffic.uniqueViews, date)
+			entry.Clones, entry.UniqueClones = trafficOn(traffic.clones, traffic.uniqueClones, date)
+		}
+		stats.Days = append(stats.Days, entry)
+	}
+}
+
+// trafficOn returns the count and unique count of a traffic series on a day, nil when the day is not in the series.
+func trafficOn(counts, uniques map[string]int, date string) (*int, *int) {
+	count, ok := counts[date]
+	if !ok {
+		return nil, nil
+	}
+	return github.Ptr(count), github.Ptr(uniques[date])
+}
+
+// repositoryStatsCSV renders the daily series as CSV, unknown values as empty cells.
+func repositoryStatsCSV(stats *repositoryStats) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"date", "stars", "forks", "views", "unique_views", "clones", "unique_clones"}); err != nil {
+		return nil, err
+	}
+	cell := func(v *int) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	}
+	for _, day := range stats.Days {
+		if err := w.Write([]string{day.Date, cell(day.Stars), cell(day.Forks), cell(day.Views), cell(day.UniqueViews), cell(day.Clones), cell(day.UniqueClones)}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// listRecentStargazers returns the most recent stargazers of a repository, oldest first. Stargazers are listed
+// oldest first, so the given number of pages is read from the end of the list.
+func listRecentStargazers(ctx context.Context, client *github.Client, owner, repo string, stars, pages int) ([]*github.Stargazer, error) {
+	lastPage := (stars + 99) / 100
+	var stargazers []*github.Stargazer
+	for page := max(1, lastPage-pages+1); page <= lastPage; page++ {
+		list, resp, err := client.Activity.ListStargazers(ctx, owner, repo, &github.ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stargazers: %w", err)
+		}
+		_ = resp.Body.Close()
+		stargazers = append(stargazers, list...)
+	}
+	return stargazers, nil
+}
+
+// listRecentForkDates returns the creation dates of the most recent forks of a repository.
+func listRecentForkDates(ctx context.Context, client *github.Client, owner, repo string) ([]time.Time, error) {
+	var dates []time.Time
+	opts := &github.RepositoryListForksOptions{Sort: "newest", ListOptions: github.ListOptions{PerPage: 100}}
+	for page := 0; page < maxStatsPages; page++ {
+		forks, resp, err := client.Repositories.ListForks(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list forks: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, fork := range forks {
+			dates = append(dates, fork.GetCreatedAt().Time)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return dates, nil
+}
+
+// getStatsTraffic returns the daily views and clones of the last 14 days of a repository, or nil when the traffic is
+// not accessible, which needs push access.
+func getStatsTraffic(ctx context.Context, client *github.Client, owner, repo string) (*statsTraffic, error) {
+	traffic := &statsTraffic{views: map[string]int{}, uniqueViews: map[string]int{}, clones: map[string]int{}, uniqueClones: map[string]int{}}
+
+	views, resp, err := client.Repositories.ListTrafficViews(ctx, owner, repo, &github.TrafficBreakdownOptions{Per: "day"})
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get traffic views: %w", err)
+	}
+	for _, view := range views.Views {
+		date := view.GetTimestamp().UTC().Format(statsDateLayout)
+		traffic.views[date], traffic.uniqueViews[date] = view.GetCount(), view.GetUniques()
+	}
+
+	clones, resp, err := client.Repositories.ListTrafficClones(ctx, owner, repo, &github.TrafficBreakdownOptions{Per: "day"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get traffic clones: %w", err)
+	}
+	_ = resp.Body.Close()
+	for _, clone := range clones.Clones {
+		date := clone.GetTimestamp().UTC().Format(statsDateLayout)
+		traffic.clones[date], traffic.uniqueClones[date] = clone.GetCount(), clone.GetUniques()
+	}
+	return traffic, nil
+}
+
+// GetRepositoryStatsResource defines the resource template and handler for the stars, forks and traffic of a
+// repository over time.
+func GetRepositoryStatsResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"stats://{owner}/{repo}{?format}", // Resource template
+			t("RESOURCE_REPOSITORY_STATS_DESCRIPTION", "Repository Stars, Forks and Traffic over Time"),
+			mcp.WithTemplateDescription("Daily stars and forks of a repository, counted by when its current stargazers and forks were given, and its views and clones of the last 14 days, as JSON or, with format=csv, as CSV"),
+		),
+		RepositoryStatsResourceHandler(getClient, time.Now)
+}
+
+// RepositoryStatsResourceHandler returns a handler function for repository stats requests.
+func RepositoryStatsResourceHandler(getClient GetClientFn, now func() time.Time) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		o, ok := request.Params.Arguments["owner"].([]string)
+		if !ok || len(o) == 0 {
+			return nil, errors.New("owner is required")
+		}
+		owner := o[0]
+
+		r, ok := request.Params.Arguments["repo"].([]string)
+		if !ok || len(r) == 0 {
+			return nil, errors.New("repo is required")
+		}
+		repo := r[0]
+
+		format := "json"
+		if f, ok := request.Params.Arguments["format"].([]string); ok && len(f) > 0 {
+			format = f[0]
+		}
+		if format != "json" && format != "csv" {
+			return nil, fmt.Errorf("format must be json or csv, not %s", format)
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		stats := &repositoryStats{
+			Repository: fmt.Sprintf("%s/%s", owner, repo),
+			Stars:      repository.GetStargazersCount(),
+			Forks:      repository.GetForksCount(),
+		}
+		stargazers, err := listRecentStargazers(ctx, client, owner, repo, stats.Stars, maxStatsPages)
+		if err != nil {
+			return nil, err
+		}
+		starDates := make([]time.Time, 0, len(stargazers))
+		for _, stargazer := range stargazers {
+			starDates = append(starDates, stargazer.GetStarredAt().Time)
+		}
+		forkDates, err := listRecentForkDates(ctx, client, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if len(starDates) < stats.Stars {
+			stats.Notes = append(stats.Notes, fmt.Sprintf("stars are known from the %d most recent ones", len(starDates)))
+		}
+		if len(forkDates) < stats.Forks {
+			stats.Notes = append(stats.Notes, fmt.Sprintf("forks are known from the %d most recent ones", len(forkDates)))
+		}
+		traffic, err := getStatsTraffic(ctx, client, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if traffic == nil {
+			stats.Notes = append(stats.Notes, "traffic is only available with push access to the repository")
+		}
+		buildRepositoryStats(stats, starDates, forkDates, traffic, now().UTC().Truncate(24*time.Hour))
+
+		if format == "csv" {
+			content, err := repositoryStatsCSV(stats)
+			if err != nil {
+				return nil, fmt.Errorf("failed to write CSV: %w", err)
+			}
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/csv", Text: string(content)},
+			}, nil
+		}
+		content, err := json.Marshal(stats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal stats: %w", err)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/json", Text: string(content)},
+		}, nil
+	}
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BuildRepositoryStats(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 12, 0, 0, 0, time.UTC) }
+	stats := &repositoryStats{Stars: 12, Forks: 1}
+	traffic := &statsTraffic{
+		views:        map[string]int{"2026-10-04": 30},
+		uniqueViews:  map[string]int{"2026-10-04": 10},
+		clones:       map[string]int{"2026-10-04": 2},
+		uniqueClones: map[string]int{"2026-10-04": 1},
+	}
+	buildRepositoryStats(stats, []time.Time{day(3), day(2), day(3)}, nil, traffic, time.Date(2026, 10, 4, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, []repositoryStatsDay{
+		{Date: "2026-10-02", Stars: github.Ptr(10)},
+		{Date: "2026-10-03", Stars: github.Ptr(12)},
+		{Date: "2026-10-04", Stars: github.Ptr(12), Forks: github.Ptr(1), Views: github.Ptr(30), UniqueViews: github.Ptr(10), Clones: github.Ptr(2), UniqueClones: github.Ptr(1)},
+	}, stats.Days)
+
+	content, err := repositoryStatsCSV(stats)
+	require.NoError(t, err)
+	assert.Equal(t, "date,stars,forks,views,unique_views,clones,unique_clones\n"+
+		"2026-10-02,10,,,,,\n"+
+		"2026-10-03,12,,,,,\n"+
+		"2026-10-04,12,1,30,10,2,1\n", string(content))
+}
+
+func Test_RepositoryStatsResourceHandler(t *testing.T) {
+	now := func() time.Time { return time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC) }
+	options := func(traffic bool) []mock.MockBackendOption {
+		options := []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{
+				StargazersCount: github.Ptr(2),
+				ForksCount:      github.Ptr(1),
+			}),
+			mock.WithRequestMatch(mock.GetReposStargazersByOwnerByRepo, []*github.Stargazer{
+				{StarredAt: &github.Timestamp{Time: time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)}},
+				{StarredAt: &github.Timestamp{Time: time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)}},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposForksByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"sort": "newest", "per_page": "100"}).andThen(
+					mockResponse(t, http.StatusOK, []*github.Repository{
+						{CreatedAt: &github.Timestamp{Time: time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)}},
+					}),
+				),
+			),
+		}
+		if !traffic {
+			return append(options, mock.WithRequestMatchHandler(
+				mock.GetReposTrafficViewsByOwnerByRepo,
+				mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have push access to repository"}),
+			))
+		}
+		return append(options,
+			mock.WithRequestMatch(mock.GetReposTrafficViewsByOwnerByRepo, &github.TrafficViews{Views: []*github.TrafficData{
+				{Timestamp: &github.Timestamp{Time: time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)}, Count: github.Ptr(8), Uniques: github.Ptr(3)},
+			}}),
+			mock.WithRequestMatch(mock.GetReposTrafficClonesByOwnerByRepo, &github.TrafficClones{Clones: []*github.TrafficData{
+				{Timestamp: &github.Timestamp{Time: time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)}, Count: github.Ptr(2), Uniques: github.Ptr(1)},
+			}}),
+		)
+	}
+
+	read := func(t *testing.T, client *http.Client, uri string, arguments map[string]any) mcp.TextResourceContents {
+		handler := RepositoryStatsResourceHandler(stubGetClientFn(github.NewClient(client)), now)
+		request := mcp.ReadResourceRequest{}
+		request.Params.URI = uri
+		request.Params.Arguments = arguments
+		contents, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+		return contents[0].(mcp.TextResourceContents)
+	}
+
+	t.Run("json", func(t *testing.T) {
+		content := read(t, mock.NewMockedHTTPClient(options(true)...), "stats://owner/repo", map[string]any{
+			"owner": []string{"owner"},
+			"repo":  []string{"repo"},
+		})
+		assert.Equal(t, "application/json", content.MIMEType)
+
+		var stats repositoryStats
+		require.NoError(t, json.Unmarshal([]byte(content.Text), &stats))
+		assert.Equal(t, repositoryStats{
+			Repository: "owner/repo",
+			Stars:      2,
+			Forks:      1,
+			Days: []repositoryStatsDay{
+				{Date: "2026-10-13", Views: github.Ptr(8), UniqueViews: github.Ptr(3), Clones: github.Ptr(2), UniqueClones: github.Ptr(1)},
+				{Date: "2026-10-14", Stars: github.Ptr(1)},
+				{Date: "2026-10-15", Stars: github.Ptr(2), Forks: github.Ptr(1)},
+			},
+		}, stats)
+	})
+
+	t.Run("csv without traffic access", func(t *testing.T) {
+		content := read(t, mock.NewMockedHTTPClient(options(false)...), "stats://owner/repo?format=csv", map[string]any{
+			"owner":  []string{"owner"},
+			"repo":   []string{"repo"},
+			"format": []string{"csv"},
+		})
+		assert.Equal(t, "text/csv", content.MIMEType)
+		assert.Equal(t, "date,stars,forks,views,unique_views,clones,unique_clones\n"+
+			"2026-10-14,1,,,,,\n"+
+			"2026-10-15,2,1,,,,\n", content.Text)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		handler := RepositoryStatsResourceHandler(stubGetClientFn(github.NewClient(nil)), now)
+		request := mcp.ReadResourceRequest{}
+		request.Params.Arguments = map[string]any{
+			"owner":  []string{"owner"},
+			"repo":   []string{"repo"},
+			"format": []string{"xml"},
+		}
+		_, err := handler(context.Background(), request)
+		assert.EqualError(t, err, "format must be json or csv, not xml")
+	})
+}
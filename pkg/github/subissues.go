@@ -0,0 +1,943 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The sub-issues API is not covered by go-github, so its requests are built by hand and its responses decoded into
+// the types below.
+
+const (
+	// defaultSubIssueTreeDepth is how many levels of sub-issues list_sub_issue_tree walks by default.
+	defaultSubIssueTreeDepth = 3
+
+	// maxSubIssueTreeDepth is the deepest list_sub_issue_tree walks. GitHub allows 8 levels of sub-issues.
+	maxSubIssueTreeDepth = 8
+
+	// maxSubIssueTreeNodes bounds the issues list_sub_issue_tree returns, deeper levels are left out beyond it.
+	maxSubIssueTreeNodes = 500
+
+	// subIssueDetailsConcurrency bounds the sub-issues list_sub_issues fetches the details of at once.
+	subIssueDetailsConcurrency = 5
+)
+
+// subIssuesSummary counts the sub-issues of an issue.
+type subIssuesSummary struct {
+	Total            int `json:"total"`
+	Completed        int `json:"completed"`
+	PercentCompleted int `json:"percent_completed"`
+}
+
+// subIssue is an issue with the summary of its own sub-issues, which go-github does not decode.
+type subIssue struct {
+	*github.Issue
+	SubIssuesSummary *subIssuesSummary `json:"sub_issues_summary,omitempty"`
+	// Details are only fetched by list_sub_issues with include_details.
+	Details *subIssueDetails `json:"details,omitempty"`
+}
+
+// linkedPullRequest is a pull request cross-referencing an issue.
+type linkedPullRequest struct {
+	Repository string `json:"repository,omitempty"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	// State is open, closed or merged.
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// subIssueDetails is what list_sub_issues adds to each sub-issue with include_details.
+type subIssueDetails struct {
+	LinkedPullRequests []linkedPullRequest `json:"linked_pull_requests"`
+	Error              string              `json:"error,omitempty"`
+}
+
+// listLinkedPullRequests returns the pull requests cross-referencing an issue, from its timeline.
+func listLinkedPullRequests(ctx context.Context, client *github.Client, owner, repo string, number int) ([]linkedPullRequest, error) {
+	linked := []linkedPullRequest{}
+	seen := map[string]bool{}
+	opts := &github.ListOptions{PerPage: 100}
+	for pages := 1; pages <= maxIssueTimelinePages; pages++ {
+		events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue timeline: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, event := range events {
+			source := event.GetSource().GetIssue()
+			if event.GetEvent() != "cross-referenced" || source == nil || !source.IsPullRequest() || seen[source.GetHTMLURL()] {
+				continue
+			}
+			seen[source.GetHTMLURL()] = true
+			pr := linkedPullRequest{
+				Number:  source.GetNumber(),
+				Title:   source.GetTitle(),
+				State:   source.GetState(),
+				HTMLURL: source.GetHTMLURL(),
+			}
+			if source.GetPullRequestLinks().GetMergedAt() != (github.Timestamp{}) {
+				pr.State = "merged"
+			}
+			if o, r := issueRepository(source, "", ""); o != "" {
+				pr.Repository = o + "/" + r
+			}
+			linked = append(linked, pr)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return linked, nil
+}
+
+// fetchSubIssueDetails fetches the details of sub-issues concurrently. A sub-issue whose details cannot be fetched
+// gets the error in its details rather than failing the others.
+func fetchSubIssueDetails(ctx context.Context, client *github.Client, owner, repo string, subIssues []*subIssue) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, subIssueDetailsConcurrency)
+	for _, sub := range subIssues {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			subOwner, subRepo := issueRepository(sub.Issue, owner, repo)
+			linked, err := listLinkedPullRequests(ctx, client, subOwner, subRepo, sub.GetNumber())
+			if err != nil {
+				sub.Details = &subIssueDetails{LinkedPullRequests: []linkedPullRequest{}, Error: err.Error()}
+				return
+			}
+			sub.Details = &subIssueDetails{LinkedPullRequests: linked}
+		}()
+	}
+	wg.Wait()
+}
+
+// listSubIssuesPage lists a page of the sub-issues of an issue.
+func listSubIssuesPage(ctx context.Context, client *github.Client, owner, repo string, number, page, perPage int) ([]*subIssue, *github.Response, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+	u := fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues?%s", owner, repo, number, query.Encode())
+
+	req, err := client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	var subIssues []*subIssue
+	resp, err := client.Do(ctx, req, &subIssues)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	return subIssues, resp, nil
+}
+
+// listAllSubIssues lists all the sub-issues of an issue.
+func listAllSubIssues(ctx context.Context, client *github.Client, owner, repo string, number int) ([]*subIssue, error) {
+	var all []*subIssue
+	for page := 1; ; {
+		subIssues, resp, err := listSubIssuesPage(ctx, client, owner, repo, number, page, 100)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, subIssues...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		page = resp.NextPage
+	}
+}
+
+// getParentIssue returns the parent of an issue, or nil if it has none.
+func getParentIssue(ctx context.Context, client *github.Client, owner, repo string, number int) (*subIssue, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("repos/%s/%s/issues/%d/parent", owner, repo, number), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	var parent *subIssue
+	resp, err := client.Do(ctx, req, &parent)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+		// The parent is not found both when the issue has none and when the issue itself does not exist
+		_, resp, err := client.Issues.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		return nil, nil
+	}
+	return parent, nil
+}
+
+// issueRepository returns the owner and name of the repository of an issue, which for sub-issues can differ from
+// the repository of the parent.
+func issueRepository(issue *github.Issue, owner, repo string) (string, string) {
+	if _, fullName, ok := strings.Cut(issue.GetRepositoryURL(), "/repos/"); ok {
+		if o, r, ok := strings.Cut(fullName, "/"); ok {
+			return o, r
+		}
+	}
+	return owner, repo
+}
+
+// subIssueNode is an issue of a sub-issue tree with its sub-issues.
+type subIssueNode struct {
+	Repository string   `json:"repository"`
+	Number     int      `json:"number"`
+	Title      string   `json:"title"`
+	State      string   `json:"state"`
+	HTMLURL    string   `json:"html_url,omitempty"`
+	Assignees  []string `json:"assignees,omitempty"`
+	// SubIssuesNotListed is set when the issue has sub-issues that were not listed, because of max_depth or the
+	// bound on the size of the tree.
+	SubIssuesNotListed bool            `json:"sub_issues_not_listed,omitempty"`
+	Error              string          `json:"error,omitempty"`
+	SubIssues          []*subIssueNode `json:"sub_issues,omitempty"`
+
+	// subIssues is the number of sub-issues of the issue, -1 when unknown.
+	subIssues int
+}
+
+// subIssueTree walks the sub-issues of an issue breadth first, so that when the tree is too large it is the deepest
+// levels that are left out.
+type subIssueTree struct {
+	client   *github.Client
+	maxDepth int
+	nodes    int
+	visited  map[string]bool
+}
+
+func newSubIssueNode(issue *github.Issue, summary *subIssuesSummary, owner, repo string) *subIssueNode {
+	owner, repo = issueRepository(issue, owner, repo)
+	node := &subIssueNode{
+		Repository: owner + "/" + repo,
+		Number:     issue.GetNumber(),
+		Title:      issue.GetTitle(),
+		State:      issue.GetState(),
+		HTMLURL:    issue.GetHTMLURL(),
+		subIssues:  -1,
+	}
+	if summary != nil {
+		node.subIssues = summary.Total
+	}
+	for _, assignee := range issue.Assignees {
+		node.Assignees = append(node.Assignees, assignee.GetLogin())
+	}
+	return node
+}
+
+func (tree *subIssueTree) build(ctx context.Context, root *subIssueNode) {
+	tree.visited = map[string]bool{fmt.Sprintf("%s#%d", root.Repository, root.Number): true}
+	tree.nodes = 1
+	level := []*subIssueNode{root}
+	for depth := 1; len(level) > 0; depth++ {
+		var next []*subIssueNode
+		for _, node := range level {
+			if node.subIssues == 0 {
+				continue
+			}
+			if depth > tree.maxDepth || tree.nodes >= maxSubIssueTreeNodes {
+				node.SubIssuesNotListed = true
+				continue
+			}
+			owner, repo, _ := strings.Cut(node.Repository, "/")
+			subIssues, err := listAllSubIssues(ctx, tree.client, owner, repo, node.Number)
+			if err != nil {
+				node.Error = fmt.Sprintf("failed to list sub-issues: %v", err)
+				continue
+			}
+			for _, issue := range subIssues {
+				child := newSubIssueNode(issue.Issue, issue.SubIssuesSummary, owner, repo)
+				key := fmt.Sprintf("%s#%d", child.Repository, child.Number)
+				if tree.visited[key] {
+					continue
+				}
+				tree.visited[key] = true
+				tree.nodes++
+				node.SubIssues = append(node.SubIssues, child)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+}
+
+// maxSubIssuesPerCall bounds the sub-issues add_sub_issues attaches in one call. GitHub allows 100 sub-issues per
+// issue.
+const maxSubIssuesPerCall = 100
+
+// subIssueAddition is the outcome of attaching one sub-issue to a parent.
+type subIssueAddition struct {
+	SubIssueID int64  `json:"sub_issue_id"`
+	Added      bool   `json:"added"`
+	Error      string `json:"error,omitempty"`
+}
+
+// parseSubIssueIDs validates the sub_issue_ids argument, leaving out repeated IDs.
+func parseSubIssueIDs(ids []any) ([]int64, error) {
+	if len(ids) > maxSubIssuesPerCall {
+		return nil, fmt.Errorf("at most %d sub-issues can be added at once", maxSubIssuesPerCall)
+	}
+	seen := make(map[int64]bool, len(ids))
+	parsed := make([]int64, 0, len(ids))
+	for i, id := range ids {
+		f, ok := id.(float64)
+		if !ok || f <= 0 || f != float64(int64(f)) {
+			return nil, fmt.Errorf("sub_issue_ids[%d] must be a positive integer", i)
+		}
+		if !seen[int64(f)] {
+			seen[int64(f)] = true
+			parsed = append(parsed, int64(f))
+		}
+	}
+	return parsed, nil
+}
+
+// addSubIssue attaches an issue, by ID, as a sub-issue of another.
+func addSubIssue(ctx context.Context, client *github.Client, owner, repo string, number int, subIssueID int64, replaceParent bool) error {
+	body := map[string]any{"sub_issue_id": subIssueID}
+	if replaceParent {
+		body["replace_parent"] = true
+	}
+	req, err := client.NewRequest("POST", fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues", owner, repo, number), body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// removeSubIssue detaches an issue, by ID, from its parent.
+func removeSubIssue(ctx context.Context, client *github.Client, owner, repo string, number int, subIssueID int64) error {
+	req, err := client.NewRequest("DELETE", fmt.Sprintf("repos/%s/%s/issues/%d/sub_issue", owner, repo, number), map[string]any{"sub_issue_id": subIssueID})
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// ListSubIssues creates a tool to list the sub-issues of an issue.
+func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_sub_issues",
+			mcp.WithDescription(t("TOOL_LIST_SUB_ISSUES_DESCRIPTION", "List the sub-issues of an issue in a GitHub repository, with their assignees and labels. With include_details, the pull requests linked to each sub-issue are also fetched, concurrently, with their state: open, closed or merged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SUB_ISSUES_USER_TITLE", "List sub-issues"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithBoolean("include_details",
+				mcp.Description("Also fetch the pull requests linked to each sub-issue and their state, one more request per sub-issue"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListSubIssuesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subIssues, _, err := listSubIssuesPage(ctx, client, params.Owner, params.Repo, params.IssueNumber, params.Page, params.PerPage)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list sub-issues: %w", err)
+			}
+			if params.IncludeDetails {
+				fetchSubIssueDetails(ctx, client, params.Owner, params.Repo, subIssues)
+			}
+
+			r, err := json.Marshal(subIssues)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListSubIssueTree creates a tool to list the sub-issues of an issue recursively, as a tree.
+func ListSubIssueTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_sub_issue_tree",
+			mcp.WithDescription(t("TOOL_LIST_SUB_ISSUE_TREE_DESCRIPTION", fmt.Sprintf("List the sub-issues of an issue recursively and return them as a tree, e.g. to see the whole breakdown of an epic in one call. Issues whose sub-issues were not listed, because of max_depth or because the tree has more than %d issues, are marked with sub_issues_not_listed.", maxSubIssueTreeNodes))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SUB_ISSUE_TREE_USER_TITLE", "List sub-issue tree"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the root issue"),
+			),
+			mcp.WithNumber("max_depth",
+				mcp.Description(fmt.Sprintf("Levels of sub-issues to list (default %d, max %d)", defaultSubIssueTreeDepth, maxSubIssueTreeDepth)),
+				mcp.Min(1),
+				mcp.Max(maxSubIssueTreeDepth),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseListSubIssueTreeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxDepth == 0 {
+				params.MaxDepth = defaultSubIssueTreeDepth
+			}
+			if params.MaxDepth < 1 || params.MaxDepth > maxSubIssueTreeDepth {
+				return mcp.NewToolResultError(fmt.Sprintf("max_depth must be between 1 and %d", maxSubIssueTreeDepth)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			issue, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, params.IssueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			root := newSubIssueNode(issue, nil, params.Owner, params.Repo)
+			tree := &subIssueTree{client: client, maxDepth: params.MaxDepth}
+			tree.build(ctx, root)
+
+			r, err := json.Marshal(root)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// issueAncestry is the result of get_parent_issue.
+type issueAncestry struct {
+	Repository  string    `json:"repository"`
+	IssueNumber int       `json:"issue_number"`
+	Parent      *subIssue `json:"parent"`
+	// Ancestors are the parents of the parent, up to the root issue, when asked for.
+	Ancestors []*subIssue `json:"ancestors,omitempty"`
+}
+
+// GetParentIssue creates a tool to get the parent of a sub-issue.
+func GetParentIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_parent_issue",
+			mcp.WithDescription(t("TOOL_GET_PARENT_ISSUE_DESCRIPTION", "Get the parent issue of a sub-issue, null when the issue has no parent, to navigate up the issue hierarchy. With include_ancestors, the parents of the parent are returned too, up to the root issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PARENT_ISSUE_USER_TITLE", "Get parent issue"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the sub-issue"),
+			),
+			mcp.WithBoolean("include_ancestors",
+				mcp.Description("Also return the ancestors of the parent, up to the root issue"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetParentIssueParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			parent, err := getParentIssue(ctx, client, params.Owner, params.Repo, params.IssueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent issue: %w", err)
+			}
+			result := issueAncestry{
+				Repository:  fmt.Sprintf("%s/%s", params.Owner, params.Repo),
+				IssueNumber: params.IssueNumber,
+				Parent:      parent,
+			}
+
+			if params.IncludeAncestors && parent != nil {
+				owner, repo := issueRepository(parent.Issue, params.Owner, params.Repo)
+				visited := map[string]bool{
+					fmt.Sprintf("%s/%s#%d", params.Owner, params.Repo, params.IssueNumber): true,
+					fmt.Sprintf("%s/%s#%d", owner, repo, parent.GetNumber()):               true,
+				}
+				// GitHub allows 8 levels of sub-issues, so no issue has more ancestors than that
+				for current := parent; len(result.Ancestors) < maxSubIssueTreeDepth; {
+					ancestor, err := getParentIssue(ctx, client, owner, repo, current.GetNumber())
+					if err != nil {
+						return nil, fmt.Errorf("failed to get parent of %s/%s#%d: %w", owner, repo, current.GetNumber(), err)
+					}
+					if ancestor == nil {
+						break
+					}
+					owner, repo = issueRepository(ancestor.Issue, owner, repo)
+					key := fmt.Sprintf("%s/%s#%d", owner, repo, ancestor.GetNumber())
+					if visited[key] {
+						break
+					}
+					visited[key] = true
+					result.Ancestors = append(result.Ancestors, ancestor)
+					current = ancestor
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddSubIssues creates a tool to attach several issues as sub-issues of a parent issue.
+func AddSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_sub_issues",
+			mcp.WithDescription(t("TOOL_ADD_SUB_ISSUES_DESCRIPTION", "Attach several issues as sub-issues of a parent issue in one call, reporting for each whether it was added. A failure for one sub-issue does not stop the others.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SUB_ISSUES_USER_TITLE", "Add sub-issues"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithArray("sub_issue_ids",
+				mcp.Required(),
+				mcp.Items(map[string]any{"type": "number"}),
+				mcp.Description(fmt.Sprintf("IDs, not numbers, of the issues to attach (at most %d)", maxSubIssuesPerCall)),
+			),
+			mcp.WithBoolean("replace_parent",
+				mcp.Description("Move issues that already have another parent instead of failing"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseAddSubIssuesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ids, err := parseSubIssueIDs(params.SubIssueIds)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results := make([]subIssueAddition, 0, len(ids))
+			for _, id := range ids {
+				result := subIssueAddition{SubIssueID: id}
+				if err := addSubIssue(ctx, client, params.Owner, params.Repo, params.IssueNumber, id, params.ReplaceParent); err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Added = true
+				}
+				results = append(results, result)
+			}
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// createdSubIssue is the result of create_sub_issue: the created issue, with the error linking it to its parent
+// when that failed.
+type createdSubIssue struct {
+	*github.Issue
+	ParentIssueNumber int    `json:"parent_issue_number"`
+	LinkError         string `json:"link_error,omitempty"`
+}
+
+// CreateSubIssue creates a tool to create an issue as a sub-issue of another.
+func CreateSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_sub_issue",
+			mcp.WithDescription(t("TOOL_CREATE_SUB_ISSUE_DESCRIPTION", "Create a new issue and attach it as a sub-issue of a parent issue of the same repository in one call. If the issue is created but cannot be attached, it is returned with link_error: attach it with add_sub_issues using its id rather than creating it again.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_SUB_ISSUE_USER_TITLE", "Create sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("parent_issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Issue title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Issue body content"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Usernames to assign to the issue"),
+			),
+			mcp.WithArray("labels",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Labels to apply to the issue"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseCreateSubIssueParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Check the parent first, so that no issue is left behind for a parent that does not exist
+			parent, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, params.ParentIssueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent issue: %w", err)
+			}
+			_ = resp.Body.Close()
+			if parent.IsPullRequest() {
+				return mcp.NewToolResultError(fmt.Sprintf("#%d is a pull request, not an issue", params.ParentIssueNumber)), nil
+			}
+
+			issue, resp, err := client.Issues.Create(ctx, params.Owner, params.Repo, &github.IssueRequest{
+				Title:     github.Ptr(params.Title),
+				Body:      github.Ptr(params.Body),
+				Assignees: &params.Assignees,
+				Labels:    &params.Labels,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create issue: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			result := createdSubIssue{Issue: issue, ParentIssueNumber: params.ParentIssueNumber}
+			if err := addSubIssue(ctx, client, params.Owner, params.Repo, params.ParentIssueNumber, issue.GetID(), false); err != nil {
+				result.LinkError = err.Error()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// subIssueMove is the result of move_sub_issue.
+type subIssueMove struct {
+	SubIssueID      int64 `json:"sub_issue_id"`
+	FromIssueNumber int   `json:"from_issue_number"`
+	ToIssueNumber   int   `json:"to_issue_number"`
+	Moved           bool  `json:"moved"`
+}
+
+// MoveSubIssue creates a tool to move a sub-issue from a parent issue to another.
+func MoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("move_sub_issue",
+			mcp.WithDescription(t("TOOL_MOVE_SUB_ISSUE_DESCRIPTION", "Move a sub-issue from its parent issue to another parent of the same repository. The sub-issue is removed from its parent and then attached to the new one; if attaching fails, it is attached back to its former parent, at the end of its sub-issues.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MOVE_SUB_ISSUE_USER_TITLE", "Move sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("sub_issue_id",
+				mcp.Required(),
+				mcp.Description("ID, not number, of the sub-issue to move"),
+			),
+			mcp.WithNumber("from_issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the current parent issue"),
+			),
+			mcp.WithNumber("to_issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the new parent issue"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseMoveSubIssueParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.FromIssueNumber == params.ToIssueNumber {
+				return mcp.NewToolResultError("from_issue_number and to_issue_number must differ"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Check the new parent first, so that the sub-issue is not detached for a parent that does not exist
+			parent, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, params.ToIssueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get new parent issue: %w", err)
+			}
+			_ = resp.Body.Close()
+			if parent.IsPullRequest() {
+				return mcp.NewToolResultError(fmt.Sprintf("#%d is a pull request, not an issue", params.ToIssueNumber)), nil
+			}
+
+			subIssueID := int64(params.SubIssueID)
+			if err := removeSubIssue(ctx, client, params.Owner, params.Repo, params.FromIssueNumber, subIssueID); err != nil {
+				return nil, fmt.Errorf("failed to remove sub-issue from #%d: %w", params.FromIssueNumber, err)
+			}
+			if err := addSubIssue(ctx, client, params.Owner, params.Repo, params.ToIssueNumber, subIssueID, false); err != nil {
+				if rollbackErr := addSubIssue(ctx, client, params.Owner, params.Repo, params.FromIssueNumber, subIssueID, false); rollbackErr != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to add sub-issue to #%d: %v. It could not be attached back to #%d either, it has no parent now: %v", params.ToIssueNumber, err, params.FromIssueNumber, rollbackErr)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add sub-issue to #%d: %v. It was attached back to #%d", params.ToIssueNumber, err, params.FromIssueNumber)), nil
+			}
+
+			r, err := json.Marshal(subIssueMove{
+				SubIssueID:      subIssueID,
+				FromIssueNumber: params.FromIssueNumber,
+				ToIssueNumber:   params.ToIssueNumber,
+				Moved:           true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// issueProgress counts the open and closed issues among sub-issues.
+type issueProgress struct {
+	Total            int `json:"total"`
+	Open             int `json:"open"`
+	Closed           int `json:"closed"`
+	PercentCompleted int `json:"percent_completed"`
+}
+
+func (p *issueProgress) add(node *subIssueNode) {
+	p.Total++
+	if node.State == "closed" {
+		p.Closed++
+	} else {
+		p.Open++
+	}
+	p.PercentCompleted = p.Closed * 100 / p.Total
+}
+
+// subIssueProgressItem is a sub-issue in the result of sub_issue_progress.
+type subIssueProgressItem struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	// Progress counts the sub-issues of the sub-issue at all levels, when counted recursively and it has any.
+	Progress *issueProgress `json:"progress,omitempty"`
+}
+
+// subIssueProgress is the result of sub_issue_progress.
+type subIssueProgress struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	// Progress counts the direct sub-issues, Recursive the sub-issues at all levels.
+	Progress  issueProgress  `json:"progress"`
+	Recursive *issueProgress `json:"recursive,omitempty"`
+	// Incomplete is set when some sub-issues were not counted, because of max_depth, the bound on the size of the
+	// tree or errors.
+	Incomplete bool                   `json:"incomplete,omitempty"`
+	SubIssues  []subIssueProgressItem `json:"sub_issues,omitempty"`
+}
+
+// descendantProgress counts the sub-issues of a node at all levels, reporting whether some were not counted.
+func descendantProgress(node *subIssueNode) (progress issueProgress, incomplete bool) {
+	incomplete = node.SubIssuesNotListed || node.Error != ""
+	for _, child := range node.SubIssues {
+		progress.add(child)
+		childProgress, childIncomplete := descendantProgress(child)
+		progress.Total += childProgress.Total
+		progress.Open += childProgress.Open
+		progress.Closed += childProgress.Closed
+		incomplete = incomplete || childIncomplete
+	}
+	if progress.Total > 0 {
+		progress.PercentCompleted = progress.Closed * 100 / progress.Total
+	}
+	return progress, incomplete
+}
+
+// SubIssueProgress creates a tool to summarize the completion of the sub-issues of an issue.
+func SubIssueProgress(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("sub_issue_progress",
+			mcp.WithDescription(t("TOOL_SUB_ISSUE_PROGRESS_DESCRIPTION", "Summarize the completion of the sub-issues of an issue, as open and closed counts and a percentage, e.g. for a status report. With recursive, the sub-issues of the sub-issues are counted too, at all levels. Only counts are returned, with the number, title and state of each direct sub-issue when asked for.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUB_ISSUE_PROGRESS_USER_TITLE", "Sub-issue progress"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithBoolean("recursive",
+				mcp.Description("Also count the sub-issues of the sub-issues, at all levels"),
+			),
+			mcp.WithNumber("max_depth",
+				mcp.Description(fmt.Sprintf("Levels of sub-issues to count when recursive (default and max %d)", maxSubIssueTreeDepth)),
+				mcp.Min(1),
+				mcp.Max(maxSubIssueTreeDepth),
+			),
+			mcp.WithBoolean("include_sub_issues",
+				mcp.Description("List the direct sub-issues with their state, and their own progress when recursive"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseSubIssueProgressParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxDepth == 0 {
+				params.MaxDepth = maxSubIssueTreeDepth
+			}
+			if params.MaxDepth < 1 || params.MaxDepth > maxSubIssueTreeDepth {
+				return mcp.NewToolResultError(fmt.Sprintf("max_depth must be between 1 and %d", maxSubIssueTreeDepth)), nil
+			}
+			if !params.Recursive {
+				params.MaxDepth = 1
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			issue, resp, err := client.Issues.Get(ctx, params.Owner, params.Repo, params.IssueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			root := newSubIssueNode(issue, nil, params.Owner, params.Repo)
+			tree := &subIssueTree{client: client, maxDepth: params.MaxDepth}
+			tree.build(ctx, root)
+			if root.Error != "" {
+				return nil, errors.New(root.Error)
+			}
+
+			result := subIssueProgress{
+				Repository: root.Repository,
+				Number:     root.Number,
+				Title:      root.Title,
+				State:      root.State,
+			}
+			for _, child := range root.SubIssues {
+				result.Progress.add(child)
+				if params.IncludeSubIssues {
+					item := subIssueProgressItem{
+						Repository: child.Repository,
+						Number:     child.Number,
+						Title:      child.Title,
+						State:      child.State,
+					}
+					if progress, _ := descendantProgress(child); params.Recursive && progress.Total > 0 {
+						item.Progress = &progress
+					}
+					result.SubIssues = append(result.SubIssues, item)
+				}
+			}
+			if params.Recursive {
+				recursive, incomplete := descendantProgress(root)
+				result.Recursive, result.Incomplete = &recursive, incomplete
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
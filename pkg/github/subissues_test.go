@@ -0,0 +1,770 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSubIssue returns a sub-issue of owner/repo, unless another repository is given, with total sub-issues of its
+// own.
+func mockSubIssue(number int, title string, total int, repository ...string) *subIssue {
+	fullName := "owner/repo"
+	if len(repository) > 0 {
+		fullName = repository[0]
+	}
+	return &subIssue{
+		Issue: &github.Issue{
+			Number:        github.Ptr(number),
+			Title:         github.Ptr(title),
+			State:         github.Ptr("open"),
+			RepositoryURL: github.Ptr("https://api.github.com/repos/" + fullName),
+		},
+		SubIssuesSummary: &subIssuesSummary{Total: total},
+	}
+}
+
+func Test_ListSubIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSubIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_sub_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "include_details")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+			expectPath(t, "/repos/owner/repo/issues/1/sub_issues").andThen(
+				expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+					mockResponse(t, http.StatusOK, []*subIssue{mockSubIssue(2, "Design", 3)}),
+				),
+			),
+		),
+	))
+	_, handler := ListSubIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(1),
+		"page":         float64(2),
+		"perPage":      float64(10),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var subIssues []*subIssue
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &subIssues))
+	require.Len(t, subIssues, 1)
+	assert.Equal(t, 2, subIssues[0].GetNumber())
+	assert.Equal(t, "Design", subIssues[0].GetTitle())
+	assert.Equal(t, 3, subIssues[0].SubIssuesSummary.Total)
+}
+
+func Test_ListSubIssuesWithDetails(t *testing.T) {
+	pullRequest := func(number int, state string, merged bool) *github.Timeline {
+		links := &github.PullRequestLinks{}
+		if merged {
+			links.MergedAt = &github.Timestamp{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+		}
+		return &github.Timeline{
+			Event: github.Ptr("cross-referenced"),
+			Source: &github.Source{Issue: &github.Issue{
+				Number:           github.Ptr(number),
+				Title:            github.Ptr(fmt.Sprintf("PR %d", number)),
+				State:            github.Ptr(state),
+				HTMLURL:          github.Ptr(fmt.Sprintf("https://github.com/owner/repo/pull/%d", number)),
+				RepositoryURL:    github.Ptr("https://api.github.com/repos/owner/repo"),
+				PullRequestLinks: links,
+			}},
+		}
+	}
+	timelines := map[string][]*github.Timeline{
+		"/repos/owner/repo/issues/2/timeline": {
+			pullRequest(10, "closed", true),
+			{Event: github.Ptr("commented")},
+			pullRequest(10, "closed", true),
+		},
+		"/repos/other/repo/issues/3/timeline": {
+			pullRequest(11, "open", false),
+		},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+			[]*subIssue{mockSubIssue(2, "Design", 0), mockSubIssue(3, "Build", 0, "other/repo"), mockSubIssue(4, "Ship", 0)},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				events, ok := timelines[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				mockResponse(t, http.StatusOK, events)(w, r)
+			}),
+		),
+	))
+	_, handler := ListSubIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":           "owner",
+		"repo":            "repo",
+		"issue_number":    float64(1),
+		"include_details": true,
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var subIssues []*subIssue
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &subIssues))
+	require.Len(t, subIssues, 3)
+	require.NotNil(t, subIssues[0].Details)
+	assert.Equal(t, []linkedPullRequest{{
+		Repository: "owner/repo",
+		Number:     10,
+		Title:      "PR 10",
+		State:      "merged",
+		HTMLURL:    "https://github.com/owner/repo/pull/10",
+	}}, subIssues[0].Details.LinkedPullRequests)
+	require.NotNil(t, subIssues[1].Details)
+	require.Len(t, subIssues[1].Details.LinkedPullRequests, 1)
+	assert.Equal(t, "open", subIssues[1].Details.LinkedPullRequests[0].State)
+	require.NotNil(t, subIssues[2].Details)
+	assert.Empty(t, subIssues[2].Details.LinkedPullRequests)
+	assert.Contains(t, subIssues[2].Details.Error, "failed to get issue timeline")
+}
+
+func Test_ListSubIssueTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSubIssueTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_sub_issue_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "max_depth")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	// owner/repo#1 has the sub-issues #2 and other/repo#3, #2 has #4, which has 2 sub-issues of its own
+	subIssues := map[string][]*subIssue{
+		"/repos/owner/repo/issues/1/sub_issues": {mockSubIssue(2, "Backend", 1), mockSubIssue(3, "Docs", 0, "other/repo")},
+		"/repos/owner/repo/issues/2/sub_issues": {mockSubIssue(4, "API", 2)},
+		"/repos/owner/repo/issues/4/sub_issues": {mockSubIssue(5, "Endpoint", 0), mockSubIssue(2, "Backend", 1)},
+	}
+	options := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, &github.Issue{
+				Number: github.Ptr(1),
+				Title:  github.Ptr("Epic"),
+				State:  github.Ptr("open"),
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					issues, ok := subIssues[r.URL.Path]
+					if !ok {
+						t.Errorf("unexpected request for %s", r.URL.Path)
+					}
+					mockResponse(t, http.StatusOK, issues)(w, r)
+				}),
+			),
+		}
+	}
+
+	run := func(t *testing.T, args map[string]any) subIssueNode {
+		client := github.NewClient(mock.NewMockedHTTPClient(options()...))
+		_, handler := ListSubIssueTree(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var root subIssueNode
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &root))
+		return root
+	}
+
+	t.Run("stops at max depth", func(t *testing.T) {
+		root := run(t, map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(1),
+			"max_depth":    float64(2),
+		})
+		assert.Equal(t, "owner/repo", root.Repository)
+		assert.Equal(t, "Epic", root.Title)
+		require.Len(t, root.SubIssues, 2)
+		assert.Equal(t, "other/repo", root.SubIssues[1].Repository)
+		assert.Empty(t, root.SubIssues[1].SubIssues)
+		assert.False(t, root.SubIssues[1].SubIssuesNotListed)
+
+		backend := root.SubIssues[0]
+		require.Len(t, backend.SubIssues, 1)
+		assert.Equal(t, 4, backend.SubIssues[0].Number)
+		assert.True(t, backend.SubIssues[0].SubIssuesNotListed)
+		assert.Empty(t, backend.SubIssues[0].SubIssues)
+	})
+
+	t.Run("walks the whole tree once", func(t *testing.T) {
+		root := run(t, map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(1),
+		})
+		api := root.SubIssues[0].SubIssues[0]
+		assert.False(t, api.SubIssuesNotListed)
+		require.Len(t, api.SubIssues, 1)
+		assert.Equal(t, "Endpoint", api.SubIssues[0].Title)
+	})
+
+	t.Run("invalid max depth", func(t *testing.T) {
+		_, handler := ListSubIssueTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(1),
+			"max_depth":    float64(maxSubIssueTreeDepth + 1),
+		}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
+
+// GetReposIssuesParentByOwnerByRepoByIssueNumber is the endpoint of the parent of an issue, which go-github-mock
+// does not know yet.
+var GetReposIssuesParentByOwnerByRepoByIssueNumber = mock.EndpointPattern{
+	Pattern: "/repos/{owner}/{repo}/issues/{issue_number}/parent",
+	Method:  "GET",
+}
+
+func Test_GetParentIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetParentIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_parent_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_ancestors")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	// owner/repo#5 is a sub-issue of other/repo#4, itself a sub-issue of owner/repo#1
+	parents := map[string]*subIssue{
+		"/repos/owner/repo/issues/5/parent": mockSubIssue(4, "API", 1, "other/repo"),
+		"/repos/other/repo/issues/4/parent": mockSubIssue(1, "Epic", 2),
+	}
+	options := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				GetReposIssuesParentByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					parent, ok := parents[r.URL.Path]
+					if !ok {
+						mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, parent)(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/repos/owner/repo/issues/1" {
+						mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(1)})(w, r)
+				}),
+			),
+		}
+	}
+
+	run := func(t *testing.T, args map[string]any) issueAncestry {
+		client := github.NewClient(mock.NewMockedHTTPClient(options()...))
+		_, handler := GetParentIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var ancestry issueAncestry
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &ancestry))
+		return ancestry
+	}
+
+	t.Run("parent", func(t *testing.T) {
+		ancestry := run(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(5)})
+		require.NotNil(t, ancestry.Parent)
+		assert.Equal(t, 4, ancestry.Parent.GetNumber())
+		assert.Equal(t, "https://api.github.com/repos/other/repo", ancestry.Parent.GetRepositoryURL())
+		assert.Empty(t, ancestry.Ancestors)
+	})
+
+	t.Run("ancestors across repositories", func(t *testing.T) {
+		ancestry := run(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(5), "include_ancestors": true})
+		require.NotNil(t, ancestry.Parent)
+		require.Len(t, ancestry.Ancestors, 1)
+		assert.Equal(t, 1, ancestry.Ancestors[0].GetNumber())
+		assert.Equal(t, 2, ancestry.Ancestors[0].SubIssuesSummary.Total)
+	})
+
+	t.Run("no parent", func(t *testing.T) {
+		ancestry := run(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(1), "include_ancestors": true})
+		assert.Equal(t, issueAncestry{Repository: "owner/repo", IssueNumber: 1}, ancestry)
+	})
+
+	t.Run("missing issue", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(options()...))
+		_, handler := GetParentIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+		_, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(9)}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get parent issue")
+	})
+}
+
+func Test_AddSubIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddSubIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_sub_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_ids")
+	assert.Contains(t, tool.InputSchema.Properties, "replace_parent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_ids"})
+
+	t.Run("reports each sub-issue", func(t *testing.T) {
+		var bodies []map[string]any
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+				expectPath(t, "/repos/owner/repo/issues/1/sub_issues").andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body map[string]any
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						bodies = append(bodies, body)
+						if body["sub_issue_id"] == float64(30) {
+							mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Issue may not contain duplicate sub-issues"}`)(w, r)
+							return
+						}
+						mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(1)})(w, r)
+					}),
+				),
+			),
+		))
+		_, handler := AddSubIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"issue_number":   float64(1),
+			"sub_issue_ids":  []any{float64(20), float64(30), float64(20), float64(40)},
+			"replace_parent": true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var additions []subIssueAddition
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &additions))
+		require.Len(t, additions, 3)
+		assert.Equal(t, subIssueAddition{SubIssueID: 20, Added: true}, additions[0])
+		assert.False(t, additions[1].Added)
+		assert.Contains(t, additions[1].Error, "duplicate sub-issues")
+		assert.Equal(t, subIssueAddition{SubIssueID: 40, Added: true}, additions[2])
+		assert.Equal(t, map[string]any{"sub_issue_id": float64(20), "replace_parent": true}, bodies[0])
+	})
+
+	for name, ids := range map[string][]any{
+		"no sub-issues":   {},
+		"not an integer":  {float64(1.5)},
+		"not a number":    {"20"},
+		"too many issues": make([]any, maxSubIssuesPerCall+1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, handler := AddSubIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_number":  float64(1),
+				"sub_issue_ids": ids,
+			}))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+}
+
+func Test_CreateSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "parent_issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "parent_issue_number", "title"})
+
+	parent := mock.WithRequestMatchHandler(
+		mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+		expectPath(t, "/repos/owner/repo/issues/1").andThen(
+			mockResponse(t, http.StatusOK, &github.Issue{ID: github.Ptr(int64(1001)), Number: github.Ptr(1)}),
+		),
+	)
+	created := &github.Issue{ID: github.Ptr(int64(1042)), Number: github.Ptr(42), Title: github.Ptr("Add endpoint")}
+	createIssue := mock.WithRequestMatchHandler(
+		mock.PostReposIssuesByOwnerByRepo,
+		expectRequestBody(t, map[string]any{
+			"title":     "Add endpoint",
+			"body":      "Part of the API epic",
+			"labels":    []any{"api"},
+			"assignees": []any{"octocat"},
+		}).andThen(
+			mockResponse(t, http.StatusCreated, created),
+		),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedLink   string
+	}{
+		{
+			name: "creates and links the issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				parent,
+				createIssue,
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/1/sub_issues").andThen(
+						expectRequestBody(t, map[string]any{"sub_issue_id": float64(1042)}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(1)}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"parent_issue_number": float64(1),
+				"title":               "Add endpoint",
+				"body":                "Part of the API epic",
+				"labels":              []any{"api"},
+				"assignees":           []any{"octocat"},
+			},
+		},
+		{
+			name: "reports the created issue when linking fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				parent,
+				createIssue,
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Parent cannot have more than 100 sub-issues"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"parent_issue_number": float64(1),
+				"title":               "Add endpoint",
+				"body":                "Part of the API epic",
+				"labels":              []any{"api"},
+				"assignees":           []any{"octocat"},
+			},
+			expectedLink: "more than 100 sub-issues",
+		},
+		{
+			name: "parent is a pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, &github.Issue{
+					Number:           github.Ptr(1),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"parent_issue_number": float64(1),
+				"title":               "Add endpoint",
+			},
+			expectError:    true,
+			expectedErrMsg: "#1 is a pull request, not an issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var issue createdSubIssue
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &issue))
+			assert.Equal(t, 42, issue.GetNumber())
+			assert.Equal(t, 1, issue.ParentIssueNumber)
+			if tc.expectedLink == "" {
+				assert.Empty(t, issue.LinkError)
+			} else {
+				assert.Contains(t, issue.LinkError, tc.expectedLink)
+			}
+		})
+	}
+}
+
+func Test_MoveSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MoveSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "move_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_id")
+	assert.Contains(t, tool.InputSchema.Properties, "from_issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "to_issue_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sub_issue_id", "from_issue_number", "to_issue_number"})
+
+	newParent := mock.WithRequestMatchHandler(
+		mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+		expectPath(t, "/repos/owner/repo/issues/2").andThen(
+			mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(2)}),
+		),
+	)
+	remove := mock.WithRequestMatchHandler(
+		mock.DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber,
+		expectPath(t, "/repos/owner/repo/issues/1/sub_issue").andThen(
+			expectRequestBody(t, map[string]any{"sub_issue_id": float64(1042)}).andThen(
+				mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(42)}),
+			),
+		),
+	)
+	// add answers the attachment to each parent with the given status
+	add := func(statuses map[string]int) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status, ok := statuses[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected attachment %s", r.URL.Path)
+				}
+				if status != http.StatusCreated {
+					mockResponse(t, status, `{"message": "Parent cannot have more than 100 sub-issues"}`)(w, r)
+					return
+				}
+				mockResponse(t, status, &github.Issue{Number: github.Ptr(42)})(w, r)
+			}),
+		)
+	}
+	args := map[string]any{
+		"owner":             "owner",
+		"repo":              "repo",
+		"sub_issue_id":      float64(1042),
+		"from_issue_number": float64(1),
+		"to_issue_number":   float64(2),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "moves the sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				newParent,
+				remove,
+				add(map[string]int{"/repos/owner/repo/issues/2/sub_issues": http.StatusCreated}),
+			),
+			requestArgs: args,
+		},
+		{
+			name: "attaches back to the former parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				newParent,
+				remove,
+				add(map[string]int{
+					"/repos/owner/repo/issues/2/sub_issues": http.StatusUnprocessableEntity,
+					"/repos/owner/repo/issues/1/sub_issues": http.StatusCreated,
+				}),
+			),
+			requestArgs:    args,
+			expectError:    true,
+			expectedErrMsg: "It was attached back to #1",
+		},
+		{
+			name: "reports a failed rollback",
+			mockedClient: mock.NewMockedHTTPClient(
+				newParent,
+				remove,
+				add(map[string]int{
+					"/repos/owner/repo/issues/2/sub_issues": http.StatusUnprocessableEntity,
+					"/repos/owner/repo/issues/1/sub_issues": http.StatusUnprocessableEntity,
+				}),
+			),
+			requestArgs:    args,
+			expectError:    true,
+			expectedErrMsg: "it has no parent now",
+		},
+		{
+			name:         "same parent",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"sub_issue_id":      float64(1042),
+				"from_issue_number": float64(1),
+				"to_issue_number":   float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "from_issue_number and to_issue_number must differ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MoveSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var move subIssueMove
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &move))
+			assert.Equal(t, subIssueMove{SubIssueID: 1042, FromIssueNumber: 1, ToIssueNumber: 2, Moved: true}, move)
+		})
+	}
+}
+
+func Test_SubIssueProgress(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SubIssueProgress(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "sub_issue_progress", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "recursive")
+	assert.Contains(t, tool.InputSchema.Properties, "max_depth")
+	assert.Contains(t, tool.InputSchema.Properties, "include_sub_issues")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	closed := func(issue *subIssue) *subIssue {
+		issue.State = github.Ptr("closed")
+		return issue
+	}
+	// owner/repo#1 has the open #2 and the closed other/repo#3, #2 has the closed #4 and the open #5
+	subIssues := map[string][]*subIssue{
+		"/repos/owner/repo/issues/1/sub_issues": {mockSubIssue(2, "Backend", 2), closed(mockSubIssue(3, "Docs", 0, "other/repo"))},
+		"/repos/owner/repo/issues/2/sub_issues": {closed(mockSubIssue(4, "API", 0)), mockSubIssue(5, "Endpoint", 0)},
+	}
+	run := func(t *testing.T, args map[string]any) subIssueProgress {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, &github.Issue{
+				Number: github.Ptr(1),
+				Title:  github.Ptr("Epic"),
+				State:  github.Ptr("open"),
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					issues, ok := subIssues[r.URL.Path]
+					if !ok {
+						t.Errorf("unexpected request for %s", r.URL.Path)
+					}
+					mockResponse(t, http.StatusOK, issues)(w, r)
+				}),
+			),
+		))
+		_, handler := SubIssueProgress(stubGetClientFn(client), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var progress subIssueProgress
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &progress))
+		return progress
+	}
+
+	t.Run("direct sub-issues", func(t *testing.T) {
+		progress := run(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(1)})
+		assert.Equal(t, subIssueProgress{
+			Repository: "owner/repo",
+			Number:     1,
+			Title:      "Epic",
+			State:      "open",
+			Progress:   issueProgress{Total: 2, Open: 1, Closed: 1, PercentCompleted: 50},
+		}, progress)
+	})
+
+	t.Run("recursive with sub-issues", func(t *testing.T) {
+		progress := run(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(1), "recursive": true, "include_sub_issues": true})
+		assert.Equal(t, issueProgress{Total: 2, Open: 1, Closed: 1, PercentCompleted: 50}, progress.Progress)
+		assert.Equal(t, &issueProgress{Total: 4, Open: 2, Closed: 2, PercentCompleted: 50}, progress.Recursive)
+		assert.False(t, progress.Incomplete)
+		assert.Equal(t, []subIssueProgressItem{
+			{Repository: "owner/repo", Number: 2, Title: "Backend", State: "open", Progress: &issueProgress{Total: 2, Open: 1, Closed: 1, PercentCompleted: 50}},
+			{Repository: "other/repo", Number: 3, Title: "Docs", State: "closed"},
+		}, progress.SubIssues)
+	})
+
+	t.Run("recursive beyond max_depth", func(t *testing.T) {
+		progress := run(t, map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(1), "recursive": true, "max_depth": float64(1)})
+		assert.Equal(t, &issueProgress{Total: 2, Open: 1, Closed: 1, PercentCompleted: 50}, progress.Recursive)
+		assert.True(t, progress.Incomplete)
+	})
+}
@@ -0,0 +1,270 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultSymbolDefinitions is the default number of definitions find_symbol_definitions returns.
+	defaultSymbolDefinitions = 10
+	// maxSymbolDefinitions bounds the definitions find_symbol_definitions returns.
+	maxSymbolDefinitions = 30
+	// maxSymbolCandidateFiles bounds the files find_symbol_definitions fetches to look for definitions in.
+	maxSymbolCandidateFiles = 20
+	// defaultSymbolContextLines is the default number of lines shown around a definition.
+	defaultSymbolContextLines = 3
+)
+
+// symbolNamePattern matches the names find_symbol_definitions can look for: identifiers.
+var symbolNamePattern = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+
+// definitionPatterns are the patterns of the lines defining a symbol in each language, with %[1]s standing for the
+// quoted name of the symbol. They are heuristics: they find most definitions, and some declarations.
+var definitionPatterns = map[string][]string{
+	"Go": {
+		`^\s*func\s+(\([^)]*\)\s*)?%[1]s\s*[\[(]`,
+		`^\s*(type|var|const)\s+%[1]s\b`,
+		`^\s+%[1]s\s+(struct|interface)\s*\{`,
+	},
+	"Python": {
+		`^\s*(async\s+)?def\s+%[1]s\s*\(`,
+		`^\s*class\s+%[1]s\b`,
+		`^%[1]s\s*(:[^=]+)?=([^=]|$)`,
+	},
+	"JavaScript": {
+		`\bfunction\s*\*?\s*%[1]s\s*\(`,
+		`\bclass\s+%[1]s\b`,
+		`\b(const|let|var)\s+%[1]s\s*=`,
+		`^\s*(static\s+|async\s+|get\s+|set\s+)*%[1]s\s*\([^)]*\)\s*\{`,
+	},
+	"TypeScript": {
+		`\bfunction\s*\*?\s*%[1]s\s*[<(]`,
+		`\b(class|interface|type|enum|namespace)\s+%[1]s\b`,
+		`\b(const|let|var)\s+%[1]s\s*[:=]`,
+		`^\s*((public|private|protected|static|async|readonly|abstract)\s+)*%[1]s\s*[<(][^;]*$`,
+	},
+	"Java": {
+		`\b(class|interface|enum|record)\s+%[1]s\b`,
+		`^\s*((public|private|protected|static|final|abstract|synchronized|default)\s+)*[\w<>\[\],.?]+\s+%[1]s\s*\([^;]*$`,
+	},
+	"Kotlin": {
+		`\b(class|interface|object|typealias)\s+%[1]s\b`,
+		`\bfun\s+(<[^>]*>\s*)?([\w.]+\.)?%[1]s\s*\(`,
+		`\b(val|var)\s+%[1]s\b`,
+	},
+	"Scala": {
+		`\b(class|trait|object|type)\s+%[1]s\b`,
+		`\b(def|val|var)\s+%[1]s\b`,
+	},
+	"C#": {
+		`\b(class|interface|enum|struct|record|delegate)\s+%[1]s\b`,
+		`^\s*((public|private|protected|internal|static|virtual|override|abstract|sealed|async|partial)\s+)*[\w<>\[\],.?]+\s+%[1]s\s*[<(][^;]*$`,
+	},
+	"Ruby": {
+		`^\s*def\s+(self\.)?%[1]s\b`,
+		`^\s*(class|module)\s+%[1]s\b`,
+	},
+	"Rust": {
+		`\b(fn|struct|enum|trait|type|mod|const|static|union)\s+%[1]s\b`,
+		`\bmacro_rules!\s*%[1]s\b`,
+	},
+	"C": {
+		`^\s*([\w*]+\s+)+\**%[1]s\s*\([^;]*$`,
+		`\b(struct|enum|union)\s+%[1]s\b\s*\{`,
+		`^\s*typedef\b.*\b%[1]s\s*;`,
+		`^\s*#\s*define\s+%[1]s\b`,
+	},
+	"C++": {
+		`^\s*([\w:*&<>]+\s+)+[*&]*([\w:]+::)?%[1]s\s*\([^;]*$`,
+		`\b(class|struct|enum|union|namespace)\s+%[1]s\b\s*[:{]?`,
+		`^\s*(typedef|using)\b.*\b%[1]s\b`,
+		`^\s*#\s*define\s+%[1]s\b`,
+	},
+	"PHP": {
+		`\bfunction\s+%[1]s\s*\(`,
+		`\b(class|interface|trait|enum)\s+%[1]s\b`,
+	},
+	"Swift": {
+		`\b(func|class|struct|enum|protocol|typealias|actor|extension)\s+%[1]s\b`,
+	},
+}
+
+// genericDefinitionPatterns are used for the languages without patterns of their own.
+var genericDefinitionPatterns = []string{
+	`\b(func|function|def|fn|fun|sub|class|struct|interface|type|enum|trait|module)\s+%[1]s\b`,
+}
+
+// definitionMatcher returns the pattern of the lines defining name in a language.
+func definitionMatcher(language, name string) *regexp.Regexp {
+	patterns, ok := definitionPatterns[language]
+	if !ok {
+		patterns = genericDefinitionPatterns
+	}
+	alternatives := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		alternatives[i] = "(?:" + fmt.Sprintf(pattern, regexp.QuoteMeta(name)) + ")"
+	}
+	return regexp.MustCompile(strings.Join(alternatives, "|"))
+}
+
+// findDefinitionLines returns the numbers of the lines of a file defining name.
+func findDefinitionLines(content, language, name string) []int {
+	matcher := definitionMatcher(language, name)
+	var lines []int
+	for i, line := range strings.Split(content, "\n") {
+		if matcher.MatchString(line) {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
+
+// symbolDefinition is a definition found by find_symbol_definitions.
+type symbolDefinition struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	Language   string `json:"language"`
+	HTMLURL    string `json:"html_url"`
+	Snippet    string `json:"snippet"`
+}
+
+// symbolDefinitions is the result of find_symbol_definitions.
+type symbolDefinitions struct {
+	Name string `json:"name"`
+	// Method is symbol when the search used the symbol qualifier, text when it fell back to a text search.
+	Method       string             `json:"method"`
+	FilesScanned int                `json:"files_scanned"`
+	Definitions  []symbolDefinition `json:"definitions"`
+	Truncated    bool               `json:"truncated,omitempty"`
+}
+
+// FindSymbolDefinitions creates a tool to find where a function, type or other symbol is defined.
+func FindSymbolDefinitions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_symbol_definitions",
+			mcp.WithDescription(t("TOOL_FIND_SYMBOL_DEFINITIONS_DESCRIPTION", "Find where a function, method, type or constant is defined, with the source around each definition. Searches code for the symbol, falling back to a text search where the symbol qualifier is not supported, and keeps the lines matching the definition syntax of the language of each file. Definitions are found by heuristics, calls are left out but some declarations may be included.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_SYMBOL_DEFINITIONS_USER_TITLE", "Find symbol definitions"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the symbol, e.g. NewServer, without its package or class"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repositories to search"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Only search this repository of the owner"),
+			),
+			mcp.WithString("language",
+				mcp.Description("Only search files of this language, e.g. go or typescript"),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description(fmt.Sprintf("Maximum number of definitions to return, defaults to %d, at most %d", defaultSymbolDefinitions, maxSymbolDefinitions)),
+			),
+			mcp.WithNumber("context_lines",
+				mcp.Description(fmt.Sprintf("Lines of source to show before and after each definition, defaults to %d", defaultSymbolContextLines)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseFindSymbolDefinitionsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !symbolNamePattern.MatchString(params.Name) {
+				return mcp.NewToolResultError(fmt.Sprintf("name %q is not an identifier", params.Name)), nil
+			}
+			if params.MaxResults <= 0 {
+				params.MaxResults = defaultSymbolDefinitions
+			}
+			if params.MaxResults > maxSymbolDefinitions {
+				return mcp.NewToolResultError(fmt.Sprintf("max_results must be at most %d", maxSymbolDefinitions)), nil
+			}
+			if params.ContextLines <= 0 {
+				params.ContextLines = defaultSymbolContextLines
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			scope := "user:" + params.Owner
+			if params.Repo != "" {
+				scope = "repo:" + params.Owner + "/" + params.Repo
+			}
+			if params.Language != "" {
+				scope += " language:" + params.Language
+			}
+			opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: maxSymbolCandidateFiles}}
+
+			result := symbolDefinitions{Name: params.Name, Method: "symbol", Definitions: []symbolDefinition{}}
+			found, resp, err := client.Search.Code(ctx, fmt.Sprintf("symbol:%s %s", params.Name, scope), opts)
+			if err != nil && resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+				// The REST API does not support the symbol qualifier of the newer code search everywhere
+				result.Method = "text"
+				found, resp, err = client.Search.Code(ctx, params.Name+" "+scope, opts)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to search code: %w", err)
+			}
+			_ = resp.Body.Close()
+
+		files:
+			for _, item := range found.CodeResults {
+				owner, repo, err := splitRepoFullName(item.GetRepository().GetFullName())
+				if err != nil {
+					continue
+				}
+				// The blob of the search result is the indexed version of the file its lines refer to
+				data, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, item.GetSHA())
+				if err != nil {
+					return nil, fmt.Errorf("failed to get %s of %s: %w", item.GetPath(), item.GetRepository().GetFullName(), err)
+				}
+				_ = resp.Body.Close()
+				result.FilesScanned++
+
+				content := string(data)
+				language := fileLanguage(item.GetPath())
+				for _, line := range findDefinitionLines(content, language, params.Name) {
+					if len(result.Definitions) == params.MaxResults {
+						result.Truncated = true
+						break files
+					}
+					snippet, err := sourceSnippet(content, line, params.ContextLines)
+					if err != nil {
+						return nil, err
+					}
+					result.Definitions = append(result.Definitions, symbolDefinition{
+						Repository: item.GetRepository().GetFullName(),
+						Path:       item.GetPath(),
+						Line:       line,
+						Language:   language,
+						HTMLURL:    fmt.Sprintf("%s#L%d", item.GetHTMLURL(), line),
+						Snippet:    snippet,
+					})
+				}
+			}
+			if found.GetTotal() > len(found.CodeResults) {
+				result.Truncated = true
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindDefinitionLines(t *testing.T) {
+	tests := []struct {
+		language string
+		name     string
+		content  string
+		expected []int
+	}{
+		{
+			language: "Go",
+			name:     "NewServer",
+			content:  "package x\n\nfunc NewServer(cfg Config) *Server {\n\treturn &Server{}\n}\n\nfunc (s *Server) Start() { NewServer(cfg) }\n",
+			expected: []int{3},
+		},
+		{
+			language: "Go",
+			name:     "Start",
+			content:  "type Config struct{}\n\nfunc (s *Server) Start() error {\n\ts.Start()\n}\n",
+			expected: []int{3},
+		},
+		{
+			language: "Go",
+			name:     "Config",
+			content:  "type Config struct {\n}\n\nvar c Config\n",
+			expected: []int{1},
+		},
+		{
+			language: "Python",
+			name:     "parse",
+			content:  "def parse(text):\n    pass\n\nresult = parse(x)\nparse == None\n",
+			expected: []int{1},
+		},
+		{
+			language: "TypeScript",
+			name:     "Client",
+			content:  "export interface Client {}\nexport class Client implements Base {\n}\nconst c = new Client();\n",
+			expected: []int{1, 2},
+		},
+		{
+			language: "JavaScript",
+			name:     "handler",
+			content:  "const handler = async (req) => {}\nhandler(req)\n",
+			expected: []int{1},
+		},
+		{
+			language: "Java",
+			name:     "process",
+			content:  "public class Job {\n    public void process(Item item) {\n        process(next);\n        return process(x);\n    }\n}\n",
+			expected: []int{2},
+		},
+		{
+			language: "Rust",
+			name:     "Parser",
+			content:  "pub struct Parser {\n}\nlet p = Parser::new();\n",
+			expected: []int{1},
+		},
+		{
+			language: "Other",
+			name:     "deploy",
+			content:  "sub deploy {\n}\ndeploy();\n",
+			expected: []int{1},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.language+" "+tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, findDefinitionLines(tc.content, tc.language, tc.name))
+		})
+	}
+}
+
+func Test_FindSymbolDefinitions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindSymbolDefinitions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "find_symbol_definitions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "language")
+	assert.Contains(t, tool.InputSchema.Properties, "max_results")
+	assert.Contains(t, tool.InputSchema.Properties, "context_lines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name", "owner"})
+
+	searchResult := &github.CodeSearchResult{
+		Total: github.Ptr(2),
+		CodeResults: []*github.CodeResult{
+			{
+				Path:       github.Ptr("server/server.go"),
+				SHA:        github.Ptr("sha1"),
+				HTMLURL:    github.Ptr("https://github.com/octo/api/blob/abc/server/server.go"),
+				Repository: &github.Repository{FullName: github.Ptr("octo/api")},
+			},
+			{
+				Path:       github.Ptr("cmd/main.go"),
+				SHA:        github.Ptr("sha2"),
+				HTMLURL:    github.Ptr("https://github.com/octo/api/blob/abc/cmd/main.go"),
+				Repository: &github.Repository{FullName: github.Ptr("octo/api")},
+			},
+		},
+	}
+	blobs := map[string]string{
+		"/repos/octo/api/git/blobs/sha1": "package server\n\n// NewServer creates a server\nfunc NewServer() *Server {\n\treturn &Server{}\n}\n",
+		"/repos/octo/api/git/blobs/sha2": "package main\n\nfunc main() {\n\tserver.NewServer()\n}\n",
+	}
+	getBlob := mock.WithRequestMatchHandler(
+		mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(blobs[r.URL.Path]))
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedMethod string
+	}{
+		{
+			name: "finds definitions with the symbol qualifier",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCode,
+					expectQueryParams(t, map[string]string{"q": "symbol:NewServer repo:octo/api language:go", "per_page": "20"}).andThen(
+						mockResponse(t, http.StatusOK, searchResult),
+					),
+				),
+				getBlob,
+			),
+			requestArgs:    map[string]any{"name": "NewServer", "owner": "octo", "repo": "api", "language": "go", "context_lines": float64(1)},
+			expectedMethod: "symbol",
+		},
+		{
+			name: "falls back to a text search",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCode,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if strings.HasPrefix(r.URL.Query().Get("q"), "symbol:") {
+							w.WriteHeader(http.StatusUnprocessableEntity)
+							_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+							return
+						}
+						assert.Equal(t, "NewServer user:octo", r.URL.Query().Get("q"))
+						mockResponse(t, http.StatusOK, searchResult)(w, r)
+					}),
+				),
+				getBlob,
+			),
+			requestArgs:    map[string]any{"name": "NewServer", "owner": "octo", "context_lines": float64(1)},
+			expectedMethod: "text",
+		},
+		{
+			name:           "rejects names that are not identifiers",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"name": "New Server", "owner": "octo"},
+			expectError:    true,
+			expectedErrMsg: `name "New Server" is not an identifier`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := FindSymbolDefinitions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var returned symbolDefinitions
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedMethod, returned.Method)
+			assert.Equal(t, 2, returned.FilesScanned)
+			require.Len(t, returned.Definitions, 1)
+			definition := returned.Definitions[0]
+			assert.Equal(t, "server/server.go", definition.Path)
+			assert.Equal(t, 4, definition.Line)
+			assert.Equal(t, "Go", definition.Language)
+			assert.Equal(t, "https://github.com/octo/api/blob/abc/server/server.go#L4", definition.HTMLURL)
+			assert.Equal(t, "  3 | // NewServer creates a server\n> 4 | func NewServer() *Server {\n  5 | \treturn &Server{}\n", definition.Snippet)
+		})
+	}
+}
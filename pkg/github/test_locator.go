@@ -0,0 +1,334 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultTestLocatorPullRequests is the default number of recent pull requests find_tests_for_file checks.
+	defaultTestLocatorPullRequests = 5
+	// maxTestLocatorPullRequests bounds the pull requests find_tests_for_file checks, as each one costs requests.
+	maxTestLocatorPullRequests = 20
+	// testLocatorSearchResults is the number of code search results find_tests_for_file looks through.
+	testLocatorSearchResults = 30
+)
+
+// testDirectories are the directory names that hold tests.
+var testDirectories = map[string]bool{
+	"test":      true,
+	"tests":     true,
+	"spec":      true,
+	"specs":     true,
+	"__tests__": true,
+}
+
+// isTestPath reports whether a file looks like a test, from its name or directory.
+func isTestPath(filePath string) bool {
+	segments := strings.Split(filePath, "/")
+	for _, dir := range segments[:len(segments)-1] {
+		if testDirectories[strings.ToLower(dir)] {
+			return true
+		}
+	}
+	name := path.Base(filePath)
+	stem := strings.TrimSuffix(name, path.Ext(name))
+	lower := strings.ToLower(stem)
+	switch {
+	case strings.HasPrefix(lower, "test_"), strings.HasSuffix(lower, "_test"), strings.HasSuffix(lower, "_spec"),
+		strings.HasSuffix(lower, ".test"), strings.HasSuffix(lower, ".spec"), strings.HasSuffix(lower, "_unittest"):
+		return true
+	case strings.HasSuffix(stem, "Test"), strings.HasSuffix(stem, "Tests"), strings.HasSuffix(stem, "Spec"):
+		return true
+	}
+	return false
+}
+
+// testFileNames returns the names the tests of a source file have by the conventions of its language.
+func testFileNames(filePath string) []string {
+	name := path.Base(filePath)
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	switch fileLanguage(filePath) {
+	case "Go":
+		return []string{stem + "_test.go"}
+	case "Python":
+		return []string{"test_" + stem + ".py", stem + "_test.py"}
+	case "JavaScript", "TypeScript":
+		var names []string
+		for _, e := range []string{".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs"} {
+			names = append(names, stem+".test"+e, stem+".spec"+e)
+		}
+		return names
+	case "Java", "Kotlin", "Scala", "Groovy":
+		return []string{stem + "Test" + ext, stem + "Tests" + ext, stem + "Spec" + ext, stem + "IT" + ext}
+	case "C#":
+		return []string{stem + "Tests.cs", stem + "Test.cs"}
+	case "PHP":
+		return []string{stem + "Test.php"}
+	case "Swift":
+		return []string{stem + "Tests.swift"}
+	case "Ruby":
+		return []string{stem + "_spec.rb", stem + "_test.rb", "test_" + stem + ".rb"}
+	case "Elixir":
+		return []string{stem + "_test.exs"}
+	case "Dart":
+		return []string{stem + "_test.dart"}
+	case "Rust":
+		return []string{stem + "_test.rs", stem + "_tests.rs"}
+	case "C", "C++":
+		var names []string
+		for _, e := range []string{".c", ".cc", ".cpp"} {
+			names = append(names, stem+"_test"+e, "test_"+stem+e, stem+"_unittest"+e)
+		}
+		return names
+	}
+	return nil
+}
+
+// sharedPrefixDepth returns the number of leading directories two paths share, to rank tests nearest the source first.
+func sharedPrefixDepth(a, b string) int {
+	dirsA, dirsB := strings.Split(path.Dir(a), "/"), strings.Split(path.Dir(b), "/")
+	n := 0
+	for n < len(dirsA) && n < len(dirsB) && dirsA[n] == dirsB[n] {
+		n++
+	}
+	return n
+}
+
+// testFileMatch is a test file found for a source file.
+type testFileMatch struct {
+	Path string `json:"path"`
+	// Match is "naming" for tests named after the source file, "reference" for tests found by code search.
+	Match string `json:"match"`
+}
+
+// untestedPullRequest is a recent pull request that changed a source file without changing any test.
+type untestedPullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	URL      string     `json:"url"`
+	Author   string     `json:"author"`
+	State    string     `json:"state"`
+	MergedAt *time.Time `json:"merged_at,omitempty"`
+}
+
+// testsForFile is the result of find_tests_for_file.
+type testsForFile struct {
+	Path                 string                `json:"path"`
+	Ref                  string                `json:"ref"`
+	Language             string                `json:"language"`
+	Tests                []testFileMatch       `json:"tests"`
+	PullRequestsChecked  int                   `json:"pull_requests_checked"`
+	UntestedPullRequests []untestedPullRequest `json:"untested_pull_requests"`
+	Notes                []string              `json:"notes,omitempty"`
+}
+
+// FindTestsForFile creates a tool to locate the tests of a source file, and the recent pull requests that changed it
+// without changing tests.
+func FindTestsForFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_tests_for_file",
+			mcp.WithDescription(t("TOOL_FIND_TESTS_FOR_FILE_DESCRIPTION", "Locate the test files of a source file: files named after it by the test conventions of its language (e.g. foo_test.go, test_foo.py, foo.spec.ts, FooTest.java), nearest first, then test files referencing it found by code search. Also lists the recent pull requests that changed the file without changing any test file. Helps reviewers check test coverage of a change.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_TESTS_FOR_FILE_USER_TITLE", "Find tests for file"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the source file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit, defaults to the default branch"),
+			),
+			mcp.WithBoolean("search_references",
+				mcp.Description("Also search the code for test files referencing the file, defaults to true. Code search only covers the default branch"),
+			),
+			mcp.WithNumber("max_pull_requests",
+				mcp.Description(fmt.Sprintf("Number of recent pull requests that changed the file to check for test changes, defaults to %d, 0 to skip", defaultTestLocatorPullRequests)),
+				mcp.Min(0),
+				mcp.Max(maxTestLocatorPullRequests),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseFindTestsForFileParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params.Path = strings.Trim(params.Path, "/")
+			if _, ok := request.GetArguments()["search_references"]; !ok {
+				params.SearchReferences = true
+			}
+			if _, ok := request.GetArguments()["max_pull_requests"]; !ok {
+				params.MaxPullRequests = defaultTestLocatorPullRequests
+			}
+			if params.MaxPullRequests > maxTestLocatorPullRequests {
+				return mcp.NewToolResultError(fmt.Sprintf("max_pull_requests must be at most %d", maxTestLocatorPullRequests)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref := params.Ref
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, ref, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree of %s: %w", ref, err)
+			}
+			_ = resp.Body.Close()
+			blobs := make(map[string]bool)
+			for _, entry := range tree.Entries {
+				if entry.GetType() == "blob" {
+					blobs[entry.GetPath()] = true
+				}
+			}
+			if !blobs[params.Path] {
+				return mcp.NewToolResultError(fmt.Sprintf("%s was not found at %s", params.Path, ref)), nil
+			}
+
+			result := testsForFile{
+				Path:                 params.Path,
+				Ref:                  ref,
+				Language:             fileLanguage(params.Path),
+				Tests:                []testFileMatch{},
+				UntestedPullRequests: []untestedPullRequest{},
+			}
+			if tree.GetTruncated() {
+				result.Notes = append(result.Notes, "the repository tree is too large to list completely, some tests may be missing")
+			}
+			if isTestPath(params.Path) {
+				result.Notes = append(result.Notes, fmt.Sprintf("%s looks like a test file itself", params.Path))
+			}
+
+			names := make(map[string]bool)
+			for _, name := range testFileNames(params.Path) {
+				names[name] = true
+			}
+			var named []string
+			for blobPath := range blobs {
+				if names[path.Base(blobPath)] {
+					named = append(named, blobPath)
+				}
+			}
+			sort.Slice(named, func(i, j int) bool {
+				di, dj := sharedPrefixDepth(params.Path, named[i]), sharedPrefixDepth(params.Path, named[j])
+				if di != dj {
+					return di > dj
+				}
+				return named[i] < named[j]
+			})
+			found := make(map[string]bool)
+			for _, p := range named {
+				found[p] = true
+				result.Tests = append(result.Tests, testFileMatch{Path: p, Match: "naming"})
+			}
+
+			if params.SearchReferences {
+				name := path.Base(params.Path)
+				stem := strings.TrimSuffix(name, path.Ext(name))
+				query := fmt.Sprintf("%q repo:%s/%s", stem, params.Owner, params.Repo)
+				results, resp, err := client.Search.Code(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: testLocatorSearchResults}})
+				if err != nil {
+					return nil, fmt.Errorf("failed to search code for %s: %w", stem, err)
+				}
+				_ = resp.Body.Close()
+				for _, r := range results.CodeResults {
+					p := r.GetPath()
+					if found[p] || p == params.Path || !isTestPath(p) {
+						continue
+					}
+					found[p] = true
+					result.Tests = append(result.Tests, testFileMatch{Path: p, Match: "reference"})
+				}
+			}
+
+			if params.MaxPullRequests > 0 {
+				commits, resp, err := client.Repositories.ListCommits(ctx, params.Owner, params.Repo, &github.CommitsListOptions{
+					SHA:         ref,
+					Path:        params.Path,
+					ListOptions: github.ListOptions{PerPage: params.MaxPullRequests * 2},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list commits of %s: %w", params.Path, err)
+				}
+				_ = resp.Body.Close()
+				seen := make(map[int]bool)
+			commits:
+				for _, commit := range commits {
+					prs, resp, err := client.PullRequests.ListPullRequestsWithCommit(ctx, params.Owner, params.Repo, commit.GetSHA(), nil)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list pull requests of commit %s: %w", commit.GetSHA(), err)
+					}
+					_ = resp.Body.Close()
+					for _, pr := range prs {
+						if seen[pr.GetNumber()] {
+							continue
+						}
+						seen[pr.GetNumber()] = true
+						files, resp, err := client.PullRequests.ListFiles(ctx, params.Owner, params.Repo, pr.GetNumber(), &github.ListOptions{PerPage: 100})
+						if err != nil {
+							return nil, fmt.Errorf("failed to list files of pull request #%d: %w", pr.GetNumber(), err)
+						}
+						_ = resp.Body.Close()
+						result.PullRequestsChecked++
+						changedTests := false
+						for _, f := range files {
+							if isTestPath(f.GetFilename()) {
+								changedTests = true
+								break
+							}
+						}
+						if !changedTests {
+							untested := untestedPullRequest{
+								Number: pr.GetNumber(),
+								Title:  pr.GetTitle(),
+								URL:    pr.GetHTMLURL(),
+								Author: pr.GetUser().GetLogin(),
+								State:  pr.GetState(),
+							}
+							if pr.MergedAt != nil {
+								untested.MergedAt = &pr.MergedAt.Time
+							}
+							result.UntestedPullRequests = append(result.UntestedPullRequests, untested)
+						}
+						if result.PullRequestsChecked == params.MaxPullRequests {
+							break commits
+						}
+					}
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
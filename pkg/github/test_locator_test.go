@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsTestPath(t *testing.T) {
+	for _, p := range []string{
+		"pkg/github/repositories_test.go",
+		"tests/test_parser.py",
+		"src/app.spec.ts",
+		"src/components/__tests__/Button.tsx",
+		"src/test/java/com/example/ParserTest.java",
+		"spec/models/user_spec.rb",
+		"lib/parser_unittest.cc",
+	} {
+		assert.True(t, isTestPath(p), p)
+	}
+	for _, p := range []string{
+		"pkg/github/repositories.go",
+		"src/testing_utils.py",
+		"src/contest.ts",
+		"docs/latest.md",
+	} {
+		assert.False(t, isTestPath(p), p)
+	}
+}
+
+func Test_TestFileNames(t *testing.T) {
+	assert.Equal(t, []string{"server_test.go"}, testFileNames("pkg/server.go"))
+	assert.Equal(t, []string{"test_parser.py", "parser_test.py"}, testFileNames("src/parser.py"))
+	assert.Contains(t, testFileNames("src/app.tsx"), "app.test.tsx")
+	assert.Contains(t, testFileNames("src/app.tsx"), "app.spec.ts")
+	assert.Equal(t, []string{"ParserTest.java", "ParserTests.java", "ParserSpec.java", "ParserIT.java"}, testFileNames("src/main/java/Parser.java"))
+	assert.Nil(t, testFileNames("README.md"))
+}
+
+func Test_FindTestsForFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := FindTestsForFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "find_tests_for_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "search_references")
+	assert.Contains(t, tool.InputSchema.Properties, "max_pull_requests")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	blob := func(p string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(p), Type: github.Ptr("blob")}
+	}
+	options := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				expectPath(t, "/repos/owner/repo/git/trees/main").andThen(
+					mockResponse(t, http.StatusOK, &github.Tree{Entries: []*github.TreeEntry{
+						blob("src/parser.py"),
+						blob("tests/test_parser.py"),
+						blob("src/test_parser.py"),
+						blob("tests/test_cli.py"),
+						blob("src/cli.py"),
+					}}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetSearchCode,
+				expectQueryParams(t, map[string]string{"q": `"parser" repo:owner/repo`, "per_page": "30"}).andThen(
+					mockResponse(t, http.StatusOK, &github.CodeSearchResult{CodeResults: []*github.CodeResult{
+						{Path: github.Ptr("src/parser.py")},
+						{Path: github.Ptr("src/cli.py")},
+						{Path: github.Ptr("tests/test_cli.py")},
+						{Path: github.Ptr("tests/test_parser.py")},
+					}}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"sha": "main", "path": "src/parser.py", "per_page": "10"}).andThen(
+					mockResponse(t, http.StatusOK, []*github.RepositoryCommit{
+						{SHA: github.Ptr("c2")},
+						{SHA: github.Ptr("c1")},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsPullsByOwnerByRepoByCommitSha,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					number := 11
+					if r.URL.Path == "/repos/owner/repo/commits/c2/pulls" {
+						number = 12
+					}
+					mockResponse(t, http.StatusOK, []*github.PullRequest{{
+						Number:  github.Ptr(number),
+						Title:   github.Ptr("Change parser"),
+						State:   github.Ptr("closed"),
+						HTMLURL: github.Ptr(fmt.Sprintf("https://github.com/owner/repo/pull/%d", number)),
+						User:    &github.User{Login: github.Ptr("octocat")},
+					}})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					files := []*github.CommitFile{{Filename: github.Ptr("src/parser.py")}}
+					if r.URL.Path == "/repos/owner/repo/pulls/11/files" {
+						files = append(files, &github.CommitFile{Filename: github.Ptr("tests/test_parser.py")})
+					}
+					mockResponse(t, http.StatusOK, files)(w, r)
+				}),
+			),
+		}
+	}
+
+	t.Run("finds tests and untested pull requests", func(t *testing.T) {
+		_, handler := FindTestsForFile(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"path":  "src/parser.py",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var found testsForFile
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &found))
+		assert.Equal(t, "main", found.Ref)
+		assert.Equal(t, "Python", found.Language)
+		assert.Equal(t, []testFileMatch{
+			{Path: "src/test_parser.py", Match: "naming"},
+			{Path: "tests/test_parser.py", Match: "naming"},
+			{Path: "tests/test_cli.py", Match: "reference"},
+		}, found.Tests)
+		assert.Equal(t, 2, found.PullRequestsChecked)
+		require.Len(t, found.UntestedPullRequests, 1)
+		assert.Equal(t, 12, found.UntestedPullRequests[0].Number)
+		assert.Equal(t, "octocat", found.UntestedPullRequests[0].Author)
+	})
+
+	t.Run("only applies the naming conventions", func(t *testing.T) {
+		_, handler := FindTestsForFile(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":             "owner",
+			"repo":              "repo",
+			"path":              "/src/parser.py",
+			"ref":               "main",
+			"search_references": false,
+			"max_pull_requests": float64(0),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var found testsForFile
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &found))
+		assert.Len(t, found.Tests, 2)
+		assert.Equal(t, 0, found.PullRequestsChecked)
+	})
+
+	t.Run("rejects a missing file", func(t *testing.T) {
+		_, handler := FindTestsForFile(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient(options()...))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"path":  "src/missing.py",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "src/missing.py was not found at main")
+	})
+}
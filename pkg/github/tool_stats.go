@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// statsSummaryTools is how many tools the periodic summary of ToolStats lists, the most called ones.
+const statsSummaryTools = 5
+
+// toolCounters are the counters of the calls of a tool.
+type toolCounters struct {
+	calls   int
+	errors  int
+	latency time.Duration
+}
+
+func (c *toolCounters) add(other toolCounters) {
+	c.calls += other.calls
+	c.errors += other.errors
+	c.latency += other.latency
+}
+
+// ToolStat is the usage of a tool, or of a toolset, since the server started.
+type ToolStat struct {
+	Name         string  `json:"name"`
+	Toolset      string  `json:"toolset,omitempty"`
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	ErrorRate    float64 `json:"error_rate"`
+	AvgLatencyMS int64   `json:"avg_latency_ms"`
+}
+
+func newToolStat(name, toolset string, counters toolCounters) ToolStat {
+	stat := ToolStat{Name: name, Toolset: toolset, Calls: counters.calls, Errors: counters.errors}
+	if counters.calls > 0 {
+		stat.ErrorRate = float64(counters.errors) / float64(counters.calls)
+		stat.AvgLatencyMS = (counters.latency / time.Duration(counters.calls)).Milliseconds()
+	}
+	return stat
+}
+
+// ServerStats is the usage of the tools of the server, returned by get_server_stats.
+type ServerStats struct {
+	StartedAt string     `json:"started_at"`
+	Calls     int        `json:"calls"`
+	Errors    int        `json:"errors"`
+	Toolsets  []ToolStat `json:"toolsets"`
+	Tools     []ToolStat `json:"tools"`
+	// SHACache holds the hits and misses of the cache of results by commit SHA, when enabled.
+	SHACache *SHACacheStats `json:"sha_cache,omitempty"`
+}
+
+// ToolStats counts the calls, errors and latency of each tool in memory, so that operators can see which toolsets
+// are used and which tools fail often. Calls failing with an error and calls returning an error result both count
+// as errors.
+type ToolStats struct {
+	now     func() time.Time
+	started time.Time
+
+	mu       sync.Mutex
+	tools    map[string]*toolCounters
+	toolsets map[string]string
+}
+
+// NewToolStats creates empty tool statistics.
+func NewToolStats() *ToolStats {
+	return &ToolStats{
+		now:      time.Now,
+		started:  time.Now(),
+		tools:    make(map[string]*toolCounters),
+		toolsets: make(map[string]string),
+	}
+}
+
+// SetToolsets sets the toolset of each tool, by tool name, that the statistics of the tools are grouped by.
+func (s *ToolStats) SetToolsets(toolsets map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toolsets = toolsets
+}
+
+// Record is a middleware counting the calls of a tool.
+func (s *ToolStats) Record(st server.ServerTool) server.ServerTool {
+	next := st.Handler
+	st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := s.now()
+		result, err := next(ctx, request)
+		elapsed := s.now().Sub(start)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		counters, ok := s.tools[st.Tool.Name]
+		if !ok {
+			counters = &toolCounters{}
+			s.tools[st.Tool.Name] = counters
+		}
+		counters.calls++
+		counters.latency += elapsed
+		if err != nil || (result != nil && result.IsError) {
+			counters.errors++
+		}
+		return result, err
+	}
+	return st
+}
+
+// Snapshot returns the statistics of the tools called so far, the most called first.
+func (s *ToolStats) Snapshot() ServerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := ServerStats{
+		StartedAt: s.started.UTC().Format(time.RFC3339),
+		Toolsets:  []ToolStat{},
+		Tools:     make([]ToolStat, 0, len(s.tools)),
+	}
+	toolsets := make(map[string]*toolCounters)
+	for name, counters := range s.tools {
+		toolset := s.toolsets[name]
+		stats.Tools = append(stats.Tools, newToolStat(name, toolset, *counters))
+		stats.Calls += counters.calls
+		stats.Errors += counters.errors
+		if toolset == "" {
+			continue
+		}
+		if toolsets[toolset] == nil {
+			toolsets[toolset] = &toolCounters{}
+		}
+		toolsets[toolset].add(*counters)
+	}
+	for name, counters := range toolsets {
+		stats.Toolsets = append(stats.Toolsets, newToolStat(name, "", *counters))
+	}
+	sortToolStats(stats.Tools)
+	sortToolStats(stats.Toolsets)
+	return stats
+}
+
+// sortToolStats sorts stats from the most to the least called, by name when called as often.
+func sortToolStats(stats []ToolStat) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Calls != stats[j].Calls {
+			return stats[i].Calls > stats[j].Calls
+		}
+		return stats[i].Name < stats[j].Name
+	})
+}
+
+// summary describes the usage of the tools in a line, for the periodic log.
+func (s ServerStats) summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d tool calls, %d errors since %s", s.Calls, s.Errors, s.StartedAt)
+	for i, tool := range s.Tools {
+		if i == statsSummaryTools {
+			break
+		}
+		if i == 0 {
+			b.WriteString(", most called:")
+		} else {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, " %s %d (%.0f%% errors, %dms)", tool.Name, tool.Calls, tool.ErrorRate*100, tool.AvgLatencyMS)
+	}
+	return b.String()
+}
+
+// LogSummaries logs a summary of the usage of the tools at info level every interval until ctx is done, unless no
+// tool was called since the previous summary.
+func (s *ToolStats) LogSummaries(ctx context.Context, logger *logrus.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	logged := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := s.Snapshot()
+			if stats.Calls == logged {
+				continue
+			}
+			logged = stats.Calls
+			logger.WithField("tool_calls", stats.Calls).Info(stats.summary())
+		}
+	}
+}
+
+// GetServerStats creates a tool returning the usage of the tools of the server since it started.
+func GetServerStats(stats *ToolStats, cache *SHACache, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_server_stats",
+			mcp.WithDescription(t("TOOL_GET_SERVER_STATS_DESCRIPTION", "Get the usage of the tools of this server since it started: the calls, errors, error rate and average latency of each tool and toolset, the most called first, and the hits of the result cache.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SERVER_STATS_USER_TITLE", "Get server stats"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			snapshot := stats.Snapshot()
+			if cache != nil && cache.enabled() {
+				cacheStats := cache.Stats()
+				snapshot.SHACache = &cacheStats
+			}
+
+			r, err := json.Marshal(snapshot)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
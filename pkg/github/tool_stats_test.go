@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ToolStats(t *testing.T) {
+	stats := NewToolStats()
+	stats.started = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	now := stats.started
+	stats.now = func() time.Time {
+		// The time is read before and after each call, which takes 100ms
+		now = now.Add(100 * time.Millisecond)
+		return now
+	}
+	stats.SetToolsets(map[string]string{"get_issue": "issues", "list_issues": "issues", "get_me": "context"})
+
+	tool := func(name string) server.ServerTool {
+		return stats.Record(server.ServerTool{
+			Tool: mcp.NewTool(name),
+			Handler: func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				switch request.GetArguments()["outcome"] {
+				case "error":
+					return nil, errors.New("failed")
+				case "error_result":
+					return mcp.NewToolResultError("not found"), nil
+				}
+				return mcp.NewToolResultText("ok"), nil
+			},
+		})
+	}
+	getIssue, listIssues, getMe := tool("get_issue"), tool("list_issues"), tool("get_me")
+	call := func(st server.ServerTool, outcome string) {
+		_, _ = st.Handler(context.Background(), createMCPRequest(map[string]any{"outcome": outcome}))
+	}
+	call(getIssue, "")
+	call(getIssue, "error_result")
+	call(getIssue, "error")
+	call(getIssue, "")
+	call(listIssues, "")
+	call(getMe, "")
+
+	snapshot := stats.Snapshot()
+	assert.Equal(t, "2024-05-01T10:00:00Z", snapshot.StartedAt)
+	assert.Equal(t, 6, snapshot.Calls)
+	assert.Equal(t, 2, snapshot.Errors)
+	assert.Equal(t, []ToolStat{
+		{Name: "get_issue", Toolset: "issues", Calls: 4, Errors: 2, ErrorRate: 0.5, AvgLatencyMS: 100},
+		{Name: "get_me", Toolset: "context", Calls: 1, AvgLatencyMS: 100},
+		{Name: "list_issues", Toolset: "issues", Calls: 1, AvgLatencyMS: 100},
+	}, snapshot.Tools)
+	assert.Equal(t, []ToolStat{
+		{Name: "issues", Calls: 5, Errors: 2, ErrorRate: 0.4, AvgLatencyMS: 100},
+		{Name: "context", Calls: 1, AvgLatencyMS: 100},
+	}, snapshot.Toolsets)
+	assert.Equal(t, "6 tool calls, 2 errors since 2024-05-01T10:00:00Z, most called: get_issue 4 (50% errors, 100ms), get_me 1 (0% errors, 100ms), list_issues 1 (0% errors, 100ms)", snapshot.summary())
+}
+
+func Test_GetServerStats(t *testing.T) {
+	stats := NewToolStats()
+	cache := NewSHACache(DefaultSHACacheSize)
+	tool, handler := GetServerStats(stats, cache, translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_server_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	recorded := stats.Record(server.ServerTool{
+		Tool: mcp.NewTool("get_me"),
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	})
+	_, err := recorded.Handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var returned ServerStats
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, 1, returned.Calls)
+	require.Len(t, returned.Tools, 1)
+	assert.Equal(t, "get_me", returned.Tools[0].Name)
+	assert.Empty(t, returned.Toolsets)
+	require.NotNil(t, returned.SHACache)
+	assert.Equal(t, SHACacheStats{}, *returned.SHACache)
+}
@@ -16,6 +16,18 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 var DefaultTools = []string{"all"}
 
 func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (*toolsets.ToolsetGroup, error) {
+	tsg := DefaultToolsetGroup(readOnly, getClient, getGQLClient, t)
+
+	// Enable the requested features
+	if err := tsg.EnableToolsets(passedToolsets); err != nil {
+		return nil, err
+	}
+
+	return tsg, nil
+}
+
+// DefaultToolsetGroup creates the built-in toolsets, all of them disabled.
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
 	// Create a new toolset group
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
@@ -25,12 +37,26 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, t)),
+			toolsets.NewServerTool(GetCodeSnippet(getClient, t)),
+			toolsets.NewServerTool(DiffFileBetweenRefs(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
+			toolsets.NewServerTool(SearchCodeInOrg(getClient, t)),
+			toolsets.NewServerTool(FindSymbolDefinitions(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(SummarizeBranchChanges(getClient, t)),
+			toolsets.NewServerTool(AuditProtectionCoverage(getClient, t)),
+			toolsets.NewServerTool(FindSuspectCommits(getGQLClient, t)),
+			toolsets.NewServerTool(ResolveStackTrace(getClient, t)),
+			toolsets.NewServerTool(GetCodebaseStats(getClient, t)),
+			toolsets.NewServerTool(BuildContextBundle(getClient, t)),
+			toolsets.NewServerTool(FindLatestGreenCommit(getClient, t)),
+			toolsets.NewServerTool(FindTestsForFile(getClient, t)),
+			toolsets.NewServerTool(ListAutolinks(getClient, t)),
+			toolsets.NewServerTool(AnalyzeForkNetwork(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
@@ -38,20 +64,49 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(ProposeTextReplacement(getClient, t)),
+			toolsets.NewServerTool(OpenPRsAcrossRepos(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(FindMergedBranches(getClient, t)),
+			toolsets.NewServerTool(CutReleaseBranch(getClient, t)),
+			toolsets.NewServerTool(ProposeVersionBump(getClient, t)),
+			toolsets.NewServerTool(ChangeRepositoryVisibility(getClient, t)),
+			toolsets.NewServerTool(CreateAutolink(getClient, t)),
+			toolsets.NewServerTool(DeleteAutolink(getClient, t)),
+			toolsets.NewServerTool(SuggestRepositoryTopics(getClient, t)),
 		)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetIssue(getClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
+			toolsets.NewServerTool(FindDuplicateIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(ListIssueEvents(getClient, t)),
+			toolsets.NewServerTool(GetIssueTimeline(getClient, t)),
+			toolsets.NewServerTool(GetIssueAttachments(getClient, t)),
+			toolsets.NewServerTool(CheckIssueSLAs(getClient, t)),
+			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(ListSubIssueTree(getClient, t)),
+			toolsets.NewServerTool(GetParentIssue(getClient, t)),
+			toolsets.NewServerTool(SubIssueProgress(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
+			toolsets.NewServerTool(UploadIssueAttachment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
+			toolsets.NewServerTool(BulkUpdateLabels(getClient, t)),
+			toolsets.NewServerTool(PostCommentToItems(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
+			toolsets.NewServerTool(ConvertIssueToDiscussion(getGQLClient, t)),
+			toolsets.NewServerTool(CreateDiscussionAnnouncement(getGQLClient, t)),
+			toolsets.NewServerTool(CloseExpiredAnnouncements(getGQLClient, t)),
+			toolsets.NewServerTool(TransferIssue(getGQLClient, t)),
+			toolsets.NewServerTool(IdentifyFirstTimeContributors(getClient, t)),
+			toolsets.NewServerTool(AddSubIssues(getClient, t)),
+			toolsets.NewServerTool(CreateSubIssue(getClient, t)),
+			toolsets.NewServerTool(MoveSubIssue(getClient, t)),
 		)
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
@@ -63,33 +118,69 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestChecks(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
+			toolsets.NewServerTool(ListPullRequestReviewThreads(getGQLClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(EvaluatePRDescription(getClient, t)),
+			toolsets.NewServerTool(DetectAffectedPackages(getClient, t)),
+			toolsets.NewServerTool(GetMergeQueue(getGQLClient, t)),
+			toolsets.NewServerTool(ListMergeConflicts(getClient, t)),
+			toolsets.NewServerTool(DetectConflictRisk(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
-			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, t)),
+			toolsets.NewServerTool(EnablePullRequestAutoMerge(getGQLClient, t)),
+			toolsets.NewServerTool(DisablePullRequestAutoMerge(getGQLClient, t)),
+			toolsets.NewServerTool(EnqueuePullRequest(getGQLClient, t)),
+			toolsets.NewServerTool(DequeuePullRequest(getGQLClient, t)),
+			toolsets.NewServerTool(MarkPullRequestReadyForReview(getGQLClient, t)),
+			toolsets.NewServerTool(ConvertPullRequestToDraft(getGQLClient, t)),
+			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
+			toolsets.NewServerTool(CheckCommitConventions(getClient, t)),
+			toolsets.NewServerTool(BackportPullRequest(getClient, t)),
 
 			// Reviews
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(CreatePendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(AddPullRequestReviewCommentToPendingReview(getGQLClient, t)),
+			toolsets.NewServerTool(AddPullRequestReviewCommentsToPendingReview(getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+			toolsets.NewServerTool(ResolveReviewThread(getGQLClient, t)),
+			toolsets.NewServerTool(UnresolveReviewThread(getGQLClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(GetOrgSecurityOverview(getClient, t)),
+			toolsets.NewServerTool(GetSecuritySettings(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateSecuritySettings(getClient, t)),
 		)
 	secretProtection := toolsets.NewToolset("secret_protection", "Secret protection related tools, such as GitHub Secret Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetSecretScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListSecretScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListPushProtectionBypassRequests(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ReviewPushProtectionBypassRequest(getClient, t)),
+		)
+
+	dependabot := toolsets.NewToolset("dependabot", "Dependabot tools, such as managing the Dependabot configuration").
+		AddReadTools(
+			toolsets.NewServerTool(GetDependabotConfig(getClient, t)),
+			toolsets.NewServerTool(ListDependabotPullRequests(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateDependabotConfig(getClient, t)),
 		)
 
 	notifications := toolsets.NewToolset("notifications", "GitHub Notifications related tools").
@@ -104,6 +195,45 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 			toolsets.NewServerTool(ManageRepositoryNotificationSubscription(getClient, t)),
 		)
 
+	projects := toolsets.NewToolset("projects", "GitHub Projects related tools").
+		AddWriteTools(
+			toolsets.NewServerTool(RolloverProjectIteration(getGQLClient, t)),
+			toolsets.NewServerTool(CloneProjectV2(getGQLClient, t)),
+		)
+
+	sponsors := toolsets.NewToolset("sponsors", "GitHub Sponsors related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListSponsors(getGQLClient, t)),
+			toolsets.NewServerTool(GetSponsorsListing(getGQLClient, t)),
+			toolsets.NewServerTool(CheckSponsorship(getGQLClient, t)),
+		)
+
+	reactions := toolsets.NewToolset("reactions", "Reactions on issues, pull requests and comments").
+		AddReadTools(
+			toolsets.NewServerTool(ListReactions(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddReaction(getClient, t)),
+			toolsets.NewServerTool(DeleteReaction(getClient, t)),
+		)
+
+	actions := toolsets.NewToolset("actions", "GitHub Actions related tools").
+		AddReadTools(
+			toolsets.NewServerTool(GetCIMatrix(getClient, t)),
+			toolsets.NewServerTool(ListPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(DiffWorkflows(getClient, t)),
+			toolsets.NewServerTool(MapWorkflowDependencies(getClient, t)),
+			toolsets.NewServerTool(AnalyzeCheckFailures(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowDurationTrends(getClient, t)),
+			toolsets.NewServerTool(GetJobStepTimings(getClient, t)),
+			toolsets.NewServerTool(InventorySecretsAndVars(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ReviewDeploymentProtectionRule(getClient, t)),
+			toolsets.NewServerTool(AuditActionPins(getClient, t)),
+			toolsets.NewServerTool(GetArtifactStorageReport(getClient, t)),
+		)
+
 	// Keep experiments alive so the system doesn't error out when it's always enabled
 	experiments := toolsets.NewToolset("experiments", "Experimental features that are not considered stable yet")
 
@@ -114,15 +244,15 @@ func InitToolsets(passedToolsets []string, readOnly bool, getClient GetClientFn,
 	tsg.AddToolset(pullRequests)
 	tsg.AddToolset(codeSecurity)
 	tsg.AddToolset(secretProtection)
+	tsg.AddToolset(dependabot)
 	tsg.AddToolset(notifications)
+	tsg.AddToolset(projects)
+	tsg.AddToolset(actions)
+	tsg.AddToolset(sponsors)
+	tsg.AddToolset(reactions)
 	tsg.AddToolset(experiments)
-	// Enable the requested features
 
-	if err := tsg.EnableToolsets(passedToolsets); err != nil {
-		return nil, err
-	}
-
-	return tsg, nil
+	return tsg
 }
 
 func InitContextToolset(getClient GetClientFn, t translations.TranslationHelperFunc) *toolsets.Toolset {
@@ -135,6 +265,46 @@ func InitContextToolset(getClient GetClientFn, t translations.TranslationHelperF
 	return contextTools
 }
 
+// InitUndoToolset creates a toolset that reverts the mutations recorded in the undo log
+func InitUndoToolset(log *UndoLog, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	undoTools := toolsets.NewToolset("undo", "Tools that revert changes made through this server during the current session").
+		AddWriteTools(
+			toolsets.NewServerTool(UndoLastAction(log, t)),
+		)
+	undoTools.Enabled = true
+	return undoTools
+}
+
+// InitContinuationToolset creates a toolset that reads the rest of the results too large to return at once
+func InitContinuationToolset(store *ContinuationStore, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	continuationTools := toolsets.NewToolset("continuation", "Tools that read the rest of results too large to return at once").
+		AddReadTools(
+			toolsets.NewServerTool(FetchMore(store, t)),
+		)
+	continuationTools.Enabled = true
+	return continuationTools
+}
+
+// InitSessionToolset creates the toolset exporting the log of the current session, enabled by default.
+func InitSessionToolset(log *SessionLog, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	sessionTools := toolsets.NewToolset("session", "Tools that export the log of the current session").
+		AddReadTools(
+			toolsets.NewServerTool(ExportSession(log, t)),
+		)
+	sessionTools.Enabled = true
+	return sessionTools
+}
+
+// InitStatsToolset creates the toolset reporting the usage of the tools of the server, enabled by default.
+func InitStatsToolset(stats *ToolStats, cache *SHACache, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	statsTools := toolsets.NewToolset("stats", "Tools that report the usage of the tools of the server").
+		AddReadTools(
+			toolsets.NewServerTool(GetServerStats(stats, cache, t)),
+		)
+	statsTools.Enabled = true
+	return statsTools
+}
+
 // InitDynamicToolset creates a dynamic toolset that can be used to enable other toolsets, and so requires the server and toolset group as arguments
 func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) *toolsets.Toolset {
 	// Create a new dynamic toolset
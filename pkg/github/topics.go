@@ -0,0 +1,439 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultSuggestedTopics is the default number of topics suggest_repository_topics proposes.
+	defaultSuggestedTopics = 10
+	// maxRepositoryTopics is the most topics GitHub allows on a repository.
+	maxRepositoryTopics = 20
+	// minTopicLanguageShare is the share of the code a language other than the main one needs to be suggested.
+	minTopicLanguageShare = 0.1
+	// minReadmeKeywordMentions is how often the README must mention a keyword for it to be suggested. A single
+	// mention in the description is enough.
+	minReadmeKeywordMentions = 2
+)
+
+// topicPattern matches the topics GitHub accepts.
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// topicKeywords maps phrases of a README or description to the topic they suggest.
+var topicKeywords = map[string]string{
+	"kubernetes":                  "kubernetes",
+	"docker":                      "docker",
+	"terraform":                   "terraform",
+	"graphql":                     "graphql",
+	"rest api":                    "rest-api",
+	"command line":                "cli",
+	"command-line":                "cli",
+	"machine learning":            "machine-learning",
+	"deep learning":               "deep-learning",
+	"large language model":        "llm",
+	"llm":                         "llm",
+	"model context protocol":      "mcp",
+	"github actions":              "github-actions",
+	"webassembly":                 "webassembly",
+	"microservices":               "microservices",
+	"serverless":                  "serverless",
+	"raspberry pi":                "raspberry-pi",
+	"home assistant":              "home-assistant",
+	"neovim":                      "neovim",
+	"visual studio code":          "vscode",
+	"static site generator":       "static-site-generator",
+	"game engine":                 "game-engine",
+	"compiler":                    "compiler",
+	"self-hosted":                 "self-hosted",
+	"observability":               "observability",
+	"monitoring":                  "monitoring",
+	"chatbot":                     "chatbot",
+	"browser extension":           "browser-extension",
+	"continuous integration":      "continuous-integration",
+	"infrastructure as code":      "infrastructure-as-code",
+	"natural language processing": "nlp",
+}
+
+// topicDependencies maps the dependencies of each kind of manifest to the topic they suggest. Go modules also match
+// their major versions and packages, e.g. github.com/google/go-github/v69.
+var topicDependencies = map[string]map[string]string{
+	"go.mod": {
+		"github.com/spf13/cobra":                          "cli",
+		"github.com/urfave/cli":                           "cli",
+		"github.com/gin-gonic/gin":                        "gin",
+		"k8s.io/client-go":                                "kubernetes",
+		"sigs.k8s.io/controller-runtime":                  "kubernetes-operator",
+		"google.golang.org/grpc":                          "grpc",
+		"github.com/mark3labs/mcp-go":                     "mcp",
+		"github.com/hashicorp/terraform-plugin-sdk":       "terraform-provider",
+		"github.com/hashicorp/terraform-plugin-framework": "terraform-provider",
+		"github.com/prometheus/client_golang":             "prometheus",
+		"github.com/google/go-github":                     "github-api",
+		"github.com/shurcooL/githubv4":                    "github-api",
+	},
+	"package.json": {
+		"react":                     "react",
+		"react-native":              "react-native",
+		"vue":                       "vue",
+		"svelte":                    "svelte",
+		"@angular/core":             "angular",
+		"next":                      "nextjs",
+		"express":                   "express",
+		"electron":                  "electron",
+		"@nestjs/core":              "nestjs",
+		"typescript":                "typescript",
+		"graphql":                   "graphql",
+		"@modelcontextprotocol/sdk": "mcp",
+		"tailwindcss":               "tailwindcss",
+		"@octokit/rest":             "github-api",
+	},
+	"requirements.txt": {
+		"django":       "django",
+		"flask":        "flask",
+		"fastapi":      "fastapi",
+		"torch":        "pytorch",
+		"tensorflow":   "tensorflow",
+		"scikit-learn": "machine-learning",
+		"pandas":       "data-science",
+		"transformers": "transformers",
+		"langchain":    "langchain",
+		"mcp":          "mcp",
+	},
+	"Cargo.toml": {
+		"tokio":        "async",
+		"actix-web":    "actix",
+		"axum":         "axum",
+		"clap":         "cli",
+		"wasm-bindgen": "webassembly",
+		"bevy":         "game-development",
+	},
+}
+
+// topicManifests are the manifests read for dependencies, at the root of the repository, in the order they are read.
+var topicManifests = []string{"go.mod", "package.json", "requirements.txt", "Cargo.toml"}
+
+// languageTopic returns the topic of a language, e.g. cpp for C++ and csharp for C#.
+func languageTopic(language string) string {
+	topic := strings.ToLower(language)
+	topic = strings.NewReplacer("+", "p", "#", "sharp", " ", "-").Replace(topic)
+	if !topicPattern.MatchString(topic) {
+		return ""
+	}
+	return topic
+}
+
+// parseRequirementsDependencies returns the normalized names of the packages of a requirements.txt file.
+func parseRequirementsDependencies(content string) []string {
+	var dependencies []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.Split(line, "#")[0])
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if i := strings.IndexAny(line, "=<>!~;[ "); i >= 0 {
+			line = line[:i]
+		}
+		dependencies = append(dependencies, strings.ReplaceAll(strings.ToLower(line), "_", "-"))
+	}
+	return dependencies
+}
+
+// parseCargoDependencies returns the crates of the dependency sections of a Cargo.toml file.
+func parseCargoDependencies(content string) []string {
+	var dependencies []string
+	inDependencies := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.Split(line, "#")[0])
+		if strings.HasPrefix(line, "[") {
+			section := strings.Trim(line, "[]")
+			inDependencies = strings.HasSuffix(section, "dependencies")
+			continue
+		}
+		if name, _, ok := strings.Cut(line, "="); ok && inDependencies {
+			dependencies = append(dependencies, strings.Trim(strings.TrimSpace(name), `"`))
+		}
+	}
+	return dependencies
+}
+
+// manifestDependencies returns the dependencies of a manifest, by its file name.
+func manifestDependencies(manifest, content string) []string {
+	switch manifest {
+	case "go.mod":
+		_, requires := parseGoModDependencies(content)
+		return requires
+	case "package.json":
+		_, dependencies := parsePackageJSONDependencies(content)
+		return dependencies
+	case "requirements.txt":
+		return parseRequirementsDependencies(content)
+	case "Cargo.toml":
+		return parseCargoDependencies(content)
+	}
+	return nil
+}
+
+// dependencyTopic returns the topic a dependency of a manifest suggests, or "" if none.
+func dependencyTopic(manifest, dependency string) string {
+	topics := topicDependencies[manifest]
+	if topic, ok := topics[dependency]; ok {
+		return topic
+	}
+	if manifest == "go.mod" {
+		for module, topic := range topics {
+			if strings.HasPrefix(dependency, module+"/") {
+				return topic
+			}
+		}
+	}
+	return ""
+}
+
+// countKeyword returns how often a keyword appears as whole words in lowercase text.
+func countKeyword(text, keyword string) int {
+	return len(regexp.MustCompile(`(^|[^a-z0-9-])`+regexp.QuoteMeta(keyword)+`($|[^a-z0-9-])`).FindAllStringIndex(text, -1))
+}
+
+// topicSuggestion is a topic suggest_repository_topics proposes, with the reasons for it.
+type topicSuggestion struct {
+	Topic   string   `json:"topic"`
+	Reasons []string `json:"reasons"`
+}
+
+// topicSuggestions is the result of suggest_repository_topics.
+type topicSuggestions struct {
+	Repository string `json:"repository"`
+	// Topics are the topics of the repository, after the suggestions were applied when asked to.
+	Topics      []string          `json:"topics"`
+	Suggestions []topicSuggestion `json:"suggestions"`
+	Applied     []string          `json:"applied,omitempty"`
+	Warnings    []string          `json:"warnings,omitempty"`
+}
+
+// topicSuggester collects suggestions and their reasons, skipping the topics a repository already has.
+type topicSuggester struct {
+	existing    map[string]bool
+	suggestions map[string]*topicSuggestion
+}
+
+func (s *topicSuggester) suggest(topic, reason string) {
+	if topic == "" || s.existing[topic] {
+		return
+	}
+	suggestion, ok := s.suggestions[topic]
+	if !ok {
+		suggestion = &topicSuggestion{Topic: topic}
+		s.suggestions[topic] = suggestion
+	}
+	for _, r := range suggestion.Reasons {
+		if r == reason {
+			return
+		}
+	}
+	suggestion.Reasons = append(suggestion.Reasons, reason)
+}
+
+// ranked returns the suggestions with the most reasons first, at most limit of them.
+func (s *topicSuggester) ranked(limit int) []topicSuggestion {
+	ranked := make([]topicSuggestion, 0, len(s.suggestions))
+	for _, suggestion := range s.suggestions {
+		ranked = append(ranked, *suggestion)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if len(ranked[i].Reasons) != len(ranked[j].Reasons) {
+			return len(ranked[i].Reasons) > len(ranked[j].Reasons)
+		}
+		return ranked[i].Topic < ranked[j].Topic
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// suggestLanguageTopics suggests the main language of a repository and the languages making up a significant share
+// of its code.
+func (s *topicSuggester) suggestLanguageTopics(languages map[string]int) {
+	total := 0
+	for _, size := range languages {
+		total += size
+	}
+	if total == 0 {
+		return
+	}
+	main := ""
+	for language, size := range languages {
+		if main == "" || size > languages[main] || (size == languages[main] && language < main) {
+			main = language
+		}
+	}
+	for _, language := range slices.Sorted(maps.Keys(languages)) {
+		share := float64(languages[language]) / float64(total)
+		if language == main || share >= minTopicLanguageShare {
+			s.suggest(languageTopic(language), fmt.Sprintf("language: %s (%.0f%% of the code)", language, share*100))
+		}
+	}
+}
+
+// suggestKeywordTopics suggests the topics of the keywords mentioned in the description or the README.
+func (s *topicSuggester) suggestKeywordTopics(description, readme string) {
+	description, readme = strings.ToLower(description), strings.ToLower(readme)
+	for _, keyword := range slices.Sorted(maps.Keys(topicKeywords)) {
+		topic := topicKeywords[keyword]
+		if countKeyword(description, keyword) > 0 {
+			s.suggest(topic, fmt.Sprintf("description mentions %q", keyword))
+		}
+		if n := countKeyword(readme, keyword); n >= minReadmeKeywordMentions {
+			s.suggest(topic, fmt.Sprintf("README mentions %q %d times", keyword, n))
+		}
+	}
+}
+
+// getReadme returns the content of the README of a repository, or "" if it has none.
+func getReadme(ctx context.Context, client *github.Client, owner, repo string) (string, error) {
+	readme, resp, err := client.Repositories.GetReadme(ctx, owner, repo, nil)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get README: %w", err)
+	}
+	content, err := readme.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode README: %w", err)
+	}
+	return content, nil
+}
+
+// SuggestRepositoryTopics creates a tool to propose topics for a repository from its languages, README and
+// dependencies, and optionally add them.
+func SuggestRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("suggest_repository_topics",
+			mcp.WithDescription(t("TOOL_SUGGEST_REPOSITORY_TOPICS_DESCRIPTION", "Propose topics for a repository, to make it easier to discover, from its languages, the keywords of its description and README, and the dependencies of its root manifests (go.mod, package.json, requirements.txt, Cargo.toml). Each suggestion comes with its reasons, the best supported first. With apply, the suggestions are added to the topics of the repository, which are never removed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUGGEST_REPOSITORY_TOPICS_USER_TITLE", "Suggest repository topics"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("max_topics",
+				mcp.Description(fmt.Sprintf("Number of topics to suggest, defaults to %d", defaultSuggestedTopics)),
+			),
+			mcp.WithBoolean("apply",
+				mcp.Description(fmt.Sprintf("Add the suggested topics to the repository, up to the limit of %d topics", maxRepositoryTopics)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseSuggestRepositoryTopicsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxTopics <= 0 {
+				params.MaxTopics = defaultSuggestedTopics
+			}
+			if params.MaxTopics > maxRepositoryTopics {
+				return mcp.NewToolResultError(fmt.Sprintf("max_topics must be at most %d", maxRepositoryTopics)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			suggester := &topicSuggester{existing: map[string]bool{}, suggestions: map[string]*topicSuggestion{}}
+			for _, topic := range repository.Topics {
+				suggester.existing[topic] = true
+			}
+
+			languages, resp, err := client.Repositories.ListLanguages(ctx, params.Owner, params.Repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list languages: %w", err)
+			}
+			_ = resp.Body.Close()
+			suggester.suggestLanguageTopics(languages)
+
+			readme, err := getReadme(ctx, client, params.Owner, params.Repo)
+			if err != nil {
+				return nil, err
+			}
+			suggester.suggestKeywordTopics(repository.GetDescription(), readme)
+
+			var warnings []string
+			for _, manifest := range topicManifests {
+				content, _, found, err := getFileAtRef(ctx, client, params.Owner, params.Repo, manifest, repository.GetDefaultBranch())
+				if err != nil {
+					warnings = append(warnings, err.Error())
+					continue
+				}
+				if !found {
+					continue
+				}
+				for _, dependency := range manifestDependencies(manifest, string(content)) {
+					suggester.suggest(dependencyTopic(manifest, dependency), fmt.Sprintf("dependency: %s in %s", dependency, manifest))
+				}
+			}
+
+			result := topicSuggestions{
+				Repository:  fmt.Sprintf("%s/%s", params.Owner, params.Repo),
+				Topics:      repository.Topics,
+				Suggestions: suggester.ranked(params.MaxTopics),
+				Warnings:    warnings,
+			}
+			if result.Topics == nil {
+				result.Topics = []string{}
+			}
+
+			if params.Apply && len(result.Suggestions) > 0 {
+				room := maxRepositoryTopics - len(repository.Topics)
+				for _, suggestion := range result.Suggestions {
+					if len(result.Applied) == room {
+						result.Warnings = append(result.Warnings, fmt.Sprintf("repositories have at most %d topics, %s and the next suggestions were not applied", maxRepositoryTopics, suggestion.Topic))
+						break
+					}
+					result.Applied = append(result.Applied, suggestion.Topic)
+				}
+				if len(result.Applied) > 0 {
+					topics, resp, err := client.Repositories.ReplaceAllTopics(ctx, params.Owner, params.Repo, append(append([]string{}, repository.Topics...), result.Applied...))
+					if err != nil {
+						return nil, fmt.Errorf("failed to update topics: %w", err)
+					}
+					_ = resp.Body.Close()
+					result.Topics = topics
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
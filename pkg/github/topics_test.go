@@ -0,0 +1,169 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LanguageTopic(t *testing.T) {
+	assert.Equal(t, "go", languageTopic("Go"))
+	assert.Equal(t, "cpp", languageTopic("C++"))
+	assert.Equal(t, "csharp", languageTopic("C#"))
+	assert.Equal(t, "jupyter-notebook", languageTopic("Jupyter Notebook"))
+	assert.Equal(t, "", languageTopic("Ren'Py"))
+}
+
+func Test_ManifestDependencies(t *testing.T) {
+	requirements := "# web\nDjango>=4.2\nscikit_learn==1.5 ; python_version > '3.9'\n-r dev.txt\nrequests[socks]\n"
+	assert.Equal(t, []string{"django", "scikit-learn", "requests"}, manifestDependencies("requirements.txt", requirements))
+
+	cargo := "[package]\nname = \"app\"\n\n[dependencies]\ntokio = { version = \"1\" }\nserde = \"1\"\n\n[dev-dependencies]\nclap = \"4\"\n"
+	assert.Equal(t, []string{"tokio", "serde", "clap"}, manifestDependencies("Cargo.toml", cargo))
+
+	assert.Equal(t, "github-api", dependencyTopic("go.mod", "github.com/google/go-github/v69"))
+	assert.Equal(t, "", dependencyTopic("go.mod", "github.com/google/go-github-mock"))
+	assert.Equal(t, "nextjs", dependencyTopic("package.json", "next"))
+}
+
+func Test_SuggestRepositoryTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SuggestRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "suggest_repository_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "max_topics")
+	assert.Contains(t, tool.InputSchema.Properties, "apply")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	readme := "# tool\n\nA command line tool for Kubernetes.\n\nRun it in Kubernetes or with the command line.\n\nDocker images are published.\n"
+	goMod := "module github.com/owner/tool\n\nrequire (\n\tgithub.com/spf13/cobra v1.8.0\n\tk8s.io/client-go v0.30.0\n)\n"
+	options := func(topics []string) []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{
+				DefaultBranch: github.Ptr("main"),
+				Description:   github.Ptr("Deploy to Kubernetes"),
+				Topics:        topics,
+			}),
+			mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, map[string]int{"Go": 9000, "Shell": 800, "Makefile": 200}),
+			mock.WithRequestMatch(mock.GetReposReadmeByOwnerByRepo, &github.RepositoryContent{Content: github.Ptr(readme)}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/") != "go.mod" {
+							mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, &github.RepositoryContent{Content: github.Ptr(goMod)})(w, r)
+					}),
+				),
+			),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       topicSuggestions
+	}{
+		{
+			name:         "suggests topics",
+			mockedClient: mock.NewMockedHTTPClient(options(nil)...),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expected: topicSuggestions{
+				Repository: "owner/repo",
+				Topics:     []string{},
+				Suggestions: []topicSuggestion{
+					{Topic: "kubernetes", Reasons: []string{
+						`description mentions "kubernetes"`,
+						`README mentions "kubernetes" 2 times`,
+						"dependency: k8s.io/client-go in go.mod",
+					}},
+					{Topic: "cli", Reasons: []string{
+						`README mentions "command line" 2 times`,
+						"dependency: github.com/spf13/cobra in go.mod",
+					}},
+					{Topic: "go", Reasons: []string{"language: Go (90% of the code)"}},
+				},
+			},
+		},
+		{
+			name: "applies suggestions",
+			mockedClient: mock.NewMockedHTTPClient(append(options([]string{"go", "devops"}),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"names": []any{"go", "devops", "kubernetes"}}).andThen(
+						mockResponse(t, http.StatusOK, map[string][]string{"names": {"go", "devops", "kubernetes"}}),
+					),
+				),
+			)...),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"max_topics": float64(1),
+				"apply":      true,
+			},
+			expected: topicSuggestions{
+				Repository: "owner/repo",
+				Topics:     []string{"go", "devops", "kubernetes"},
+				Suggestions: []topicSuggestion{
+					{Topic: "kubernetes", Reasons: []string{
+						`description mentions "kubernetes"`,
+						`README mentions "kubernetes" 2 times`,
+						"dependency: k8s.io/client-go in go.mod",
+					}},
+				},
+				Applied: []string{"kubernetes"},
+			},
+		},
+		{
+			name:         "too many topics",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"max_topics": float64(21),
+			},
+			expectError:    true,
+			expectedErrMsg: "max_topics must be at most 20",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SuggestRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var suggestions topicSuggestions
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &suggestions))
+			assert.Equal(t, tc.expected, suggestions)
+		})
+	}
+}
@@ -0,0 +1,691 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxUndoEntries bounds the number of actions remembered per session.
+const maxUndoEntries = 50
+
+// undoEntry is a mutation recorded in the undo log, along with the call that compensates for it.
+type undoEntry struct {
+	Tool        string
+	Description string
+	// undo performs the compensating call and describes what it did. It is nil when the action cannot be undone.
+	undo func(ctx context.Context, client *github.Client) (string, error)
+}
+
+// undoRecorder is called before a write tool runs. It may capture the state the tool is about to change, and returns
+// a function that builds the undo entry from the tool's successful result.
+type undoRecorder func(ctx context.Context, client *github.Client, request mcp.CallToolRequest) (func(result *mcp.CallToolResult) (*undoEntry, error), error)
+
+// undoRecorders are the write tools that have a compensating action.
+var undoRecorders = map[string]undoRecorder{
+	"add_issue_comment":     recordAddIssueComment,
+	"create_issue":          recordCreateIssue,
+	"update_issue":          recordUpdateIssue,
+	"create_or_update_file": recordCreateOrUpdateFile,
+	"delete_file":           recordDeleteFile,
+	"create_branch":         recordCreateBranch,
+	"create_pull_request":   recordCreatePullRequest,
+	"find_merged_branches":  recordFindMergedBranches,
+
+	"identify_first_time_contributors": recordIdentifyFirstTimeContributors,
+	"bulk_update_labels":               recordBulkUpdateLabels,
+	"post_comment_to_items":            recordPostCommentToItems,
+}
+
+// UndoLog records the mutations performed in each session so that they can be reverted with undo_last_action.
+type UndoLog struct {
+	getClient GetClientFn
+
+	mu       sync.Mutex
+	sessions map[string][]undoEntry
+}
+
+// NewUndoLog creates an empty undo log. The client is used to capture state before mutations and to run
+// compensating calls.
+func NewUndoLog(getClient GetClientFn) *UndoLog {
+	return &UndoLog{
+		getClient: getClient,
+		sessions:  make(map[string][]undoEntry),
+	}
+}
+
+// sessionKey identifies the client session of a request. Requests outside of a session share a single log.
+func sessionKey(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+func (l *UndoLog) push(ctx context.Context, entry undoEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := sessionKey(ctx)
+	entries := append(l.sessions[key], entry)
+	if len(entries) > maxUndoEntries {
+		entries = entries[len(entries)-maxUndoEntries:]
+	}
+	l.sessions[key] = entries
+}
+
+func (l *UndoLog) pop(ctx context.Context) (undoEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := sessionKey(ctx)
+	entries := l.sessions[key]
+	if len(entries) == 0 {
+		return undoEntry{}, false
+	}
+	entry := entries[len(entries)-1]
+	l.sessions[key] = entries[:len(entries)-1]
+	return entry, true
+}
+
+// Record wraps a write tool so that its successful calls are added to the undo log.
+func (l *UndoLog) Record(st server.ServerTool) server.ServerTool {
+	next := st.Handler
+	name := st.Tool.Name
+	st.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		recorder, ok := undoRecorders[name]
+		if !ok {
+			result, err := next(ctx, request)
+			if err == nil && result != nil && !result.IsError {
+				l.push(ctx, undoEntry{Tool: name, Description: fmt.Sprintf("%s call", name)})
+			}
+			return result, err
+		}
+
+		client, err := l.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		// A failure to capture state, such as a missing parameter, is left for the tool itself to report.
+		finish, captureErr := recorder(ctx, client, request)
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		var entry *undoEntry
+		if captureErr != nil {
+			err = captureErr
+		} else {
+			entry, err = finish(result)
+		}
+		if err != nil {
+			// The mutation happened, so keep a record of it even though it cannot be reverted.
+			entry = &undoEntry{Tool: name, Description: fmt.Sprintf("%s call (%s)", name, err)}
+		}
+		l.push(ctx, *entry)
+		return result, nil
+	}
+	return st
+}
+
+// UndoLastAction creates a tool that reverts the most recent mutation of the current session.
+func UndoLastAction(log *UndoLog, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("undo_last_action",
+			mcp.WithDescription(t("TOOL_UNDO_LAST_ACTION_DESCRIPTION", "Revert the most recent change made through this server in the current session by running a compensating action: deleting a created comment, closing a created issue or pull request, restoring an edited issue, reverting a file change, deleting a created branch, restoring deleted branches, removing welcome comments and labels or restoring bulk updated labels. Call repeatedly to undo earlier actions.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNDO_LAST_ACTION_USER_TITLE", "Undo last action"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			entry, ok := log.pop(ctx)
+			if !ok {
+				return mcp.NewToolResultError("there are no actions to undo in this session"), nil
+			}
+			if entry.undo == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("the last action (%s) cannot be undone automatically and must be reverted manually; it has been removed from the undo log so earlier actions can be undone", entry.Description)), nil
+			}
+
+			client, err := log.getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			summary, err := entry.undo(ctx, client)
+			if err != nil {
+				// Keep the entry so the undo can be retried. Undos of several items only retry the items left.
+				log.push(ctx, entry)
+				return nil, fmt.Errorf("failed to undo %s: %w", entry.Description, err)
+			}
+
+			r, err := json.Marshal(map[string]string{
+				"undone": entry.Description,
+				"action": summary,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// decodeResult unmarshals the JSON text of a tool result.
+func decodeResult(result *mcp.CallToolResult, v any) error {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return json.Unmarshal([]byte(text.Text), v)
+		}
+	}
+	return fmt.Errorf("result has no text content")
+}
+
+// undoEach reverts the items left in *items in order, dropping each one once it is reverted, so that an undo that
+// failed part way can be retried without reverting the same items again. undo may update an item to record the
+// steps already done for it. It returns the number of items reverted.
+func undoEach[T any](items *[]T, undo func(item *T) error) (int, error) {
+	n := 0
+	for len(*items) > 0 {
+		if err := undo(&(*items)[0]); err != nil {
+			return n, err
+		}
+		*items = (*items)[1:]
+		n++
+	}
+	return n, nil
+}
+
+// partialUndoError reports an undo of several items that failed part way.
+func partialUndoError(reverted, left int, items string, err error) error {
+	return fmt.Errorf("%d %s were reverted and %d are left, call undo_last_action again to retry them: %w", reverted, items, left, err)
+}
+
+func ownerRepoParams(request mcp.CallToolRequest) (string, string, error) {
+	owner, err := requiredParam[string](request, "owner")
+	if err != nil {
+		return "", "", err
+	}
+	repo, err := requiredParam[string](request, "repo")
+	if err != nil {
+		return "", "", err
+	}
+	return owner, repo, nil
+}
+
+func recordAddIssueComment(_ context.Context, _ *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var comment github.IssueComment
+		if err := decodeResult(result, &comment); err != nil {
+			return nil, err
+		}
+		return &undoEntry{
+			Tool:        "add_issue_comment",
+			Description: fmt.Sprintf("comment %d on %s/%s", comment.GetID(), owner, repo),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				resp, err := client.Issues.DeleteComment(ctx, owner, repo, comment.GetID())
+				if err != nil {
+					return "", err
+				}
+				_ = resp.Body.Close()
+				return "deleted the comment", nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordCreateIssue(_ context.Context, _ *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var issue github.Issue
+		if err := decodeResult(result, &issue); err != nil {
+			return nil, err
+		}
+		number := issue.GetNumber()
+		return &undoEntry{
+			Tool:        "create_issue",
+			Description: fmt.Sprintf("creation of issue %s/%s#%d", owner, repo, number),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				// Issues cannot be deleted through the REST API, so closing is the closest compensation.
+				_, resp, err := client.Issues.Edit(ctx, owner, repo, number, &github.IssueRequest{
+					State:       github.Ptr("closed"),
+					StateReason: github.Ptr("not_planned"),
+				})
+				if err != nil {
+					return "", err
+				}
+				_ = resp.Body.Close()
+				return "closed the issue as not planned", nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordUpdateIssue(ctx context.Context, client *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	number, err := RequiredInt(request, "issue_number")
+	if err != nil {
+		return nil, err
+	}
+
+	before, resp, err := client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	labels := make([]string, 0, len(before.Labels))
+	for _, label := range before.Labels {
+		labels = append(labels, label.GetName())
+	}
+	assignees := make([]string, 0, len(before.Assignees))
+	for _, assignee := range before.Assignees {
+		assignees = append(assignees, assignee.GetLogin())
+	}
+	restore := &github.IssueRequest{
+		Title:     github.Ptr(before.GetTitle()),
+		Body:      github.Ptr(before.GetBody()),
+		State:     github.Ptr(before.GetState()),
+		Labels:    &labels,
+		Assignees: &assignees,
+	}
+	if before.Milestone != nil {
+		restore.Milestone = github.Ptr(before.Milestone.GetNumber())
+	}
+
+	return func(_ *mcp.CallToolResult) (*undoEntry, error) {
+		return &undoEntry{
+			Tool:        "update_issue",
+			Description: fmt.Sprintf("update of issue %s/%s#%d", owner, repo, number),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				_, resp, err := client.Issues.Edit(ctx, owner, repo, number, restore)
+				if err != nil {
+					return "", err
+				}
+				_ = resp.Body.Close()
+				return fmt.Sprintf("restored the issue's previous title, body, %s state, labels, assignees and milestone", before.GetState()), nil
+			},
+		}, nil
+	}, nil
+}
+
+// getFile returns the content and blob SHA of a file, or nil if it does not exist.
+func getFile(ctx context.Context, client *github.Client, owner, repo, path, ref string) (*github.RepositoryContent, error) {
+	fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	if fileContent == nil {
+		return nil, fmt.Errorf("path %s is a directory", path)
+	}
+	return fileContent, nil
+}
+
+// fileChangeParams returns the owner, repo, path and branch parameters shared by the file tools.
+func fileChangeParams(request mcp.CallToolRequest) (owner, repo, path, branch string, err error) {
+	if owner, repo, err = ownerRepoParams(request); err != nil {
+		return
+	}
+	if path, err = requiredParam[string](request, "path"); err != nil {
+		return
+	}
+	branch, err = requiredParam[string](request, "branch")
+	return
+}
+
+func recordCreateOrUpdateFile(ctx context.Context, client *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, path, branch, err := fileChangeParams(request)
+	if err != nil {
+		return nil, err
+	}
+	before, err := getFile(ctx, client, owner, repo, path, branch)
+	if err != nil {
+		return nil, err
+	}
+	var previous string
+	if before != nil {
+		if previous, err = before.GetContent(); err != nil {
+			return nil, fmt.Errorf("failed to decode file: %w", err)
+		}
+	}
+
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var written github.RepositoryContentResponse
+		if err := decodeResult(result, &written); err != nil {
+			return nil, err
+		}
+		currentSHA := written.Content.GetSHA()
+
+		return &undoEntry{
+			Tool:        "create_or_update_file",
+			Description: fmt.Sprintf("change to %s on %s/%s@%s", path, owner, repo, branch),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				if before == nil {
+					_, resp, err := client.Repositories.DeleteFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+						Message: github.Ptr(fmt.Sprintf("Revert creation of %s", path)),
+						SHA:     github.Ptr(currentSHA),
+						Branch:  github.Ptr(branch),
+					})
+					if err != nil {
+						return "", err
+					}
+					_ = resp.Body.Close()
+					return "deleted the created file", nil
+				}
+
+				_, resp, err := client.Repositories.UpdateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+					Message: github.Ptr(fmt.Sprintf("Revert changes to %s", path)),
+					Content: []byte(previous),
+					SHA:     github.Ptr(currentSHA),
+					Branch:  github.Ptr(branch),
+				})
+				if err != nil {
+					return "", err
+				}
+				_ = resp.Body.Close()
+				return "committed the previous file content", nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordDeleteFile(ctx context.Context, client *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, path, branch, err := fileChangeParams(request)
+	if err != nil {
+		return nil, err
+	}
+	before, err := getFile(ctx, client, owner, repo, path, branch)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		// Nothing to restore, the deletion itself will fail.
+		return func(_ *mcp.CallToolResult) (*undoEntry, error) {
+			return nil, fmt.Errorf("%s did not exist", path)
+		}, nil
+	}
+	previous, err := before.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file: %w", err)
+	}
+
+	return func(_ *mcp.CallToolResult) (*undoEntry, error) {
+		return &undoEntry{
+			Tool:        "delete_file",
+			Description: fmt.Sprintf("deletion of %s on %s/%s@%s", path, owner, repo, branch),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				_, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+					Message: github.Ptr(fmt.Sprintf("Restore %s", path)),
+					Content: []byte(previous),
+					Branch:  github.Ptr(branch),
+				})
+				if err != nil {
+					return "", err
+				}
+				_ = resp.Body.Close()
+				return "restored the deleted file", nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordCreateBranch(_ context.Context, _ *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requiredParam[string](request, "branch")
+	if err != nil {
+		return nil, err
+	}
+	return func(_ *mcp.CallToolResult) (*undoEntry, error) {
+		return &undoEntry{
+			Tool:        "create_branch",
+			Description: fmt.Sprintf("creation of branch %s on %s/%s", branch, owner, repo),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				resp, err := client.Git.DeleteRef(ctx, owner, repo, "refs/heads/"+branch)
+				if err != nil {
+					return "", err
+				}
+				_ = resp.Body.Close()
+				return "deleted the branch", nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordCreatePullRequest(_ context.Context, _ *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var pr github.PullRequest
+		if err := decodeResult(result, &pr); err != nil {
+			return nil, err
+		}
+		number := pr.GetNumber()
+		return &undoEntry{
+			Tool:        "create_pull_request",
+			Description: fmt.Sprintf("creation of pull request %s/%s#%d", owner, repo, number),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				_, resp, err := client.PullRequests.Edit(ctx, owner, repo, number, &github.PullRequest{State: github.Ptr("closed")})
+				if err != nil {
+					return "", err
+				}
+				_ = resp.Body.Close()
+				return "closed the pull request", nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordFindMergedBranches(_ context.Context, _ *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var report mergedBranchesReport
+		if err := decodeResult(result, &report); err != nil {
+			return nil, err
+		}
+		var deleted []mergedBranch
+		for _, branch := range report.Merged {
+			if branch.Deleted {
+				deleted = append(deleted, branch)
+			}
+		}
+		if len(deleted) == 0 {
+			return &undoEntry{Tool: "find_merged_branches", Description: fmt.Sprintf("listing of merged branches of %s/%s", owner, repo)}, nil
+		}
+		return &undoEntry{
+			Tool:        "find_merged_branches",
+			Description: fmt.Sprintf("deletion of %d merged branches of %s/%s", len(deleted), owner, repo),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				n, err := undoEach(&deleted, func(branch *mergedBranch) error {
+					_, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+						Ref:    github.Ptr("refs/heads/" + branch.Name),
+						Object: &github.GitObject{SHA: github.Ptr(branch.SHA)},
+					})
+					if err != nil {
+						return fmt.Errorf("failed to restore branch %s: %w", branch.Name, err)
+					}
+					_ = resp.Body.Close()
+					return nil
+				})
+				if err != nil {
+					return "", partialUndoError(n, len(deleted), "branches", err)
+				}
+				return fmt.Sprintf("restored %d branches", n), nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordIdentifyFirstTimeContributors(_ context.Context, _ *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	label, _ := request.GetArguments()["label"].(string)
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var report firstTimeContributorsReport
+		if err := decodeResult(result, &report); err != nil {
+			return nil, err
+		}
+		var welcomed []firstTimeContribution
+		for _, contribution := range report.Contributions {
+			if contribution.CommentID != 0 || contribution.Labeled {
+				welcomed = append(welcomed, contribution)
+			}
+		}
+		if len(welcomed) == 0 {
+			return &undoEntry{Tool: "identify_first_time_contributors", Description: fmt.Sprintf("listing of first-time contributors of %s/%s", owner, repo)}, nil
+		}
+		return &undoEntry{
+			Tool:        "identify_first_time_contributors",
+			Description: fmt.Sprintf("welcome of %d first-time contributions to %s/%s", len(welcomed), owner, repo),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				n, err := undoEach(&welcomed, func(contribution *firstTimeContribution) error {
+					if contribution.CommentID != 0 {
+						resp, err := client.Issues.DeleteComment(ctx, owner, repo, contribution.CommentID)
+						if err != nil {
+							return fmt.Errorf("failed to delete the welcome comment on #%d: %w", contribution.Number, err)
+						}
+						_ = resp.Body.Close()
+						contribution.CommentID = 0
+					}
+					if contribution.Labeled {
+						resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, contribution.Number, label)
+						if err != nil {
+							return fmt.Errorf("failed to remove label %s from #%d: %w", label, contribution.Number, err)
+						}
+						_ = resp.Body.Close()
+						contribution.Labeled = false
+					}
+					return nil
+				})
+				if err != nil {
+					return "", partialUndoError(n, len(welcomed), "contributions", err)
+				}
+				return fmt.Sprintf("removed the welcome from %d contributions", n), nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordBulkUpdateLabels(_ context.Context, _ *github.Client, request mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	owner, repo, err := ownerRepoParams(request)
+	if err != nil {
+		return nil, err
+	}
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var update bulkLabelUpdate
+		if err := decodeResult(result, &update); err != nil {
+			return nil, err
+		}
+		var changed []issueLabelUpdate
+		for _, issue := range update.Issues {
+			if len(issue.Added) > 0 || len(issue.Removed) > 0 {
+				changed = append(changed, issue)
+			}
+		}
+		if len(changed) == 0 {
+			return &undoEntry{Tool: "bulk_update_labels", Description: fmt.Sprintf("label update of issues of %s/%s, which changed nothing", owner, repo)}, nil
+		}
+		return &undoEntry{
+			Tool:        "bulk_update_labels",
+			Description: fmt.Sprintf("label update of %d issues of %s/%s", len(changed), owner, repo),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				n, err := undoEach(&changed, func(issue *issueLabelUpdate) error {
+					for len(issue.Added) > 0 {
+						label := issue.Added[0]
+						resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, issue.Number, label)
+						if err != nil {
+							return fmt.Errorf("failed to remove label %s from #%d: %w", label, issue.Number, err)
+						}
+						_ = resp.Body.Close()
+						issue.Added = issue.Added[1:]
+					}
+					if len(issue.Removed) > 0 {
+						_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, issue.Number, issue.Removed)
+						if err != nil {
+							return fmt.Errorf("failed to restore the labels of #%d: %w", issue.Number, err)
+						}
+						_ = resp.Body.Close()
+						issue.Removed = nil
+					}
+					return nil
+				})
+				if err != nil {
+					return "", partialUndoError(n, len(changed), "issues", err)
+				}
+				return fmt.Sprintf("restored the labels of %d issues", n), nil
+			},
+		}, nil
+	}, nil
+}
+
+func recordPostCommentToItems(_ context.Context, _ *github.Client, _ mcp.CallToolRequest) (func(*mcp.CallToolResult) (*undoEntry, error), error) {
+	return func(result *mcp.CallToolResult) (*undoEntry, error) {
+		var comments itemComments
+		if err := decodeResult(result, &comments); err != nil {
+			return nil, err
+		}
+		var posted []itemComment
+		for _, item := range comments.Items {
+			if item.CommentID != 0 {
+				posted = append(posted, item)
+			}
+		}
+		if len(posted) == 0 {
+			return &undoEntry{Tool: "post_comment_to_items", Description: "comments on issues, of which none was posted"}, nil
+		}
+		return &undoEntry{
+			Tool:        "post_comment_to_items",
+			Description: fmt.Sprintf("comments on %d issues and pull requests", len(posted)),
+			undo: func(ctx context.Context, client *github.Client) (string, error) {
+				n, err := undoEach(&posted, func(item *itemComment) error {
+					owner, repo, err := splitRepoFullName(item.Repository)
+					if err != nil {
+						return err
+					}
+					resp, err := client.Issues.DeleteComment(ctx, owner, repo, item.CommentID)
+					if err != nil {
+						return fmt.Errorf("failed to delete the comment on %s#%d: %w", item.Repository, item.Number, err)
+					}
+					_ = resp.Body.Close()
+					return nil
+				})
+				if err != nil {
+					return "", partialUndoError(n, len(posted), "comments", err)
+				}
+				return fmt.Sprintf("deleted %d comments", n), nil
+			},
+		}, nil
+	}, nil
+}
@@ -0,0 +1,414 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UndoLastAction(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UndoLastAction(NewUndoLog(stubGetClientFn(github.NewClient(nil))), translations.NullTranslationHelper)
+	assert.Equal(t, "undo_last_action", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	t.Run("nothing to undo", func(t *testing.T) {
+		_, handler := UndoLastAction(NewUndoLog(stubGetClientFn(github.NewClient(nil))), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "there are no actions to undo in this session")
+	})
+
+	t.Run("deletes a created comment", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(987)), Body: github.Ptr("hello")}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesCommentsByOwnerByRepoByCommentId,
+				expectPath(t, "/repos/owner/repo/issues/comments/987").andThen(
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		))
+		log := NewUndoLog(stubGetClientFn(client))
+		addComment := log.Record(toolsets.NewServerTool(AddIssueComment(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := addComment.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(42),
+			"body":         "hello",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var returned map[string]string
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+		assert.Equal(t, "comment 987 on owner/repo", returned["undone"])
+		assert.Equal(t, "deleted the comment", returned["action"])
+
+		// The log is now empty
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+	})
+
+	t.Run("reopens a closed issue", func(t *testing.T) {
+		var edits []map[string]any
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				&github.Issue{
+					Number: github.Ptr(42),
+					Title:  github.Ptr("Bug"),
+					Body:   github.Ptr("It is broken"),
+					State:  github.Ptr("open"),
+					Labels: []*github.Label{{Name: github.Ptr("bug")}},
+				},
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					edits = append(edits, body)
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(mock.MustMarshal(&github.Issue{Number: github.Ptr(42)}))
+				}),
+			),
+		))
+		log := NewUndoLog(stubGetClientFn(client))
+		updateIssue := log.Record(toolsets.NewServerTool(UpdateIssue(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := updateIssue.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": float64(42),
+			"state":        "closed",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Contains(t, textContent.Text, "update of issue owner/repo#42")
+
+		require.Len(t, edits, 2)
+		assert.Equal(t, map[string]any{"state": "closed"}, edits[0])
+		assert.Equal(t, map[string]any{
+			"title":     "Bug",
+			"body":      "It is broken",
+			"state":     "open",
+			"labels":    []any{"bug"},
+			"assignees": []any{},
+		}, edits[1])
+	})
+
+	t.Run("restores deleted branches", func(t *testing.T) {
+		options := append(mergedBranchesOptions(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"ref": "refs/heads/merged",
+					"sha": "merged-sha",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/heads/merged")})),
+			),
+		)
+		client := github.NewClient(mock.NewMockedHTTPClient(options...))
+		log := NewUndoLog(stubGetClientFn(client))
+		findMerged := log.Record(toolsets.NewServerTool(FindMergedBranches(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := findMerged.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"delete": true,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Contains(t, textContent.Text, "deletion of 1 merged branches of owner/repo")
+		assert.Contains(t, textContent.Text, "restored 1 branches")
+	})
+
+	t.Run("removes welcome comments and labels", func(t *testing.T) {
+		options := append(firstTimeContributorsOptions(t),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesCommentsByOwnerByRepoByCommentId,
+				expectPath(t, "/repos/owner/repo/issues/comments/101").andThen(mockResponse(t, http.StatusNoContent, nil)),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				expectPath(t, "/repos/owner/repo/issues/1/labels/good first contribution").andThen(mockResponse(t, http.StatusOK, []*github.Label{})),
+			),
+		)
+		client := github.NewClient(mock.NewMockedHTTPClient(options...))
+		log := NewUndoLog(stubGetClientFn(client))
+		identify := log.Record(toolsets.NewServerTool(IdentifyFirstTimeContributors(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := identify.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"welcome": true,
+			"message": "Welcome @{author}!",
+			"label":   "good first contribution",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Contains(t, textContent.Text, "welcome of 1 first-time contributions to owner/repo")
+		assert.Contains(t, textContent.Text, "removed the welcome from 1 contributions")
+	})
+
+	t.Run("restores bulk updated labels", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				&github.Issue{Number: github.Ptr(1), Labels: []*github.Label{{Name: github.Ptr("needs-triage")}}},
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusOK, []*github.Label{}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				mockResponse(t, http.StatusOK, []*github.Label{}),
+			),
+		))
+		log := NewUndoLog(stubGetClientFn(client))
+		bulkUpdate := log.Record(toolsets.NewServerTool(BulkUpdateLabels(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := bulkUpdate.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1)},
+			"add_labels":    []any{"triaged"},
+			"remove_labels": []any{"needs-triage"},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Contains(t, textContent.Text, "label update of 1 issues of owner/repo")
+		assert.Contains(t, textContent.Text, "restored the labels of 1 issues")
+	})
+
+	t.Run("deletes comments posted to several items", func(t *testing.T) {
+		var deleted []string
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(987))}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesCommentsByOwnerByRepoByCommentId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					deleted = append(deleted, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			),
+		))
+		log := NewUndoLog(stubGetClientFn(client))
+		postComments := log.Record(toolsets.NewServerTool(PostCommentToItems(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := postComments.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"body":             "Moved",
+			"items":            []any{map[string]any{"number": float64(1)}, map[string]any{"number": float64(2), "repository": "owner/other"}},
+			"interval_seconds": float64(0),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Contains(t, textContent.Text, "deleted 2 comments")
+		assert.Equal(t, []string{"/repos/owner/repo/issues/comments/987", "/repos/owner/other/issues/comments/987"}, deleted)
+	})
+
+	t.Run("retries of a partial undo only revert the items left", func(t *testing.T) {
+		var deleted []string
+		failures := 1
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(987))}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesCommentsByOwnerByRepoByCommentId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/repos/owner/other/issues/comments/987" && failures > 0 {
+						failures--
+						w.WriteHeader(http.StatusBadGateway)
+						return
+					}
+					deleted = append(deleted, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			),
+		))
+		log := NewUndoLog(stubGetClientFn(client))
+		postComments := log.Record(toolsets.NewServerTool(PostCommentToItems(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := postComments.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"body":             "Moved",
+			"items":            []any{map[string]any{"number": float64(1)}, map[string]any{"number": float64(2), "repository": "owner/other"}},
+			"interval_seconds": float64(0),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		_, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 comments were reverted and 1 are left, call undo_last_action again to retry them")
+		assert.Contains(t, err.Error(), "failed to delete the comment on owner/other#2")
+		assert.Equal(t, []string{"/repos/owner/repo/issues/comments/987"}, deleted)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+		assert.Contains(t, textContent.Text, "deleted 1 comments")
+		assert.Equal(t, []string{"/repos/owner/repo/issues/comments/987", "/repos/owner/other/issues/comments/987"}, deleted)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("retries of a partial undo skip the steps done for an item", func(t *testing.T) {
+		var removed []string
+		failures := 1
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				&github.Issue{Number: github.Ptr(1), Labels: []*github.Label{{Name: github.Ptr("needs-triage")}}},
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusOK, []*github.Label{}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.HasSuffix(r.URL.Path, "/p1") && failures > 0 {
+						failures--
+						w.WriteHeader(http.StatusBadGateway)
+						return
+					}
+					removed = append(removed, r.URL.Path)
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte("[]"))
+				}),
+			),
+		))
+		log := NewUndoLog(stubGetClientFn(client))
+		bulkUpdate := log.Record(toolsets.NewServerTool(BulkUpdateLabels(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := bulkUpdate.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1)},
+			"add_labels":    []any{"triaged", "p1"},
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		_, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "0 issues were reverted and 1 are left")
+		assert.Equal(t, []string{"/repos/owner/repo/issues/1/labels/triaged"}, removed)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		assert.Equal(t, []string{"/repos/owner/repo/issues/1/labels/triaged", "/repos/owner/repo/issues/1/labels/p1"}, removed)
+	})
+
+	t.Run("actions without a compensation are reported", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+				&github.PullRequestMergeResult{Merged: github.Ptr(true)},
+			),
+		))
+		log := NewUndoLog(stubGetClientFn(client))
+		merge := log.Record(toolsets.NewServerTool(MergePullRequest(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		_, err := merge.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(1),
+		}))
+		require.NoError(t, err)
+
+		result, err := undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "the last action (merge_pull_request call) cannot be undone automatically")
+	})
+
+	t.Run("failed calls are not recorded", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient())
+		log := NewUndoLog(stubGetClientFn(client))
+		addComment := log.Record(toolsets.NewServerTool(AddIssueComment(stubGetClientFn(client), translations.NullTranslationHelper)))
+		_, undo := UndoLastAction(log, translations.NullTranslationHelper)
+
+		result, err := addComment.Handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		result, err = undo(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		assert.Contains(t, getTextResult(t, result).Text, "there are no actions to undo in this session")
+	})
+}
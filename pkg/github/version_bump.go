@@ -0,0 +1,351 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxChangelogPullRequests bounds the merged pull requests listed in a changelog section.
+const maxChangelogPullRequests = 200
+
+// semverPattern matches a semantic version, with an optional v prefix, prerelease and build metadata.
+var semverPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// versionFilePatterns locate the version in the version files propose_version_bump knows, by file name. The version
+// is the second group, between a prefix and a suffix kept as is.
+var versionFilePatterns = map[string]*regexp.Regexp{
+	"package.json": regexp.MustCompile(`("version"\s*:\s*")([^"]+)(")`),
+	"Chart.yaml":   regexp.MustCompile(`(?m)(^version:\s*["']?)([^"'\s]+)(["']?\s*$)`),
+	"version.go":   regexp.MustCompile(`(\bVersion\s*=\s*"v?)([^"]+)(")`),
+	"VERSION":      regexp.MustCompile(`^(\s*v?)(\S+)(\s*)$`),
+}
+
+// vendoredDirs are the directories of third-party code, whose version files are not the ones of the project.
+var vendoredDirs = map[string]bool{"node_modules": true, "vendor": true, "third_party": true}
+
+// isVendoredPath reports whether a file is in a directory of third-party code.
+func isVendoredPath(filePath string) bool {
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		if vendoredDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// bumpVersion returns the version following a semantic version at a level, major, minor or patch. As npm does, a
+// prerelease is bumped to its release when that release is at the level, e.g. 2.0.0-rc.1 to 2.0.0 for major.
+func bumpVersion(version, level string) (string, error) {
+	match := semverPattern.FindStringSubmatch(version)
+	if match == nil {
+		return "", fmt.Errorf("%s is not a semantic version", version)
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+	prerelease := match[4] != ""
+	switch level {
+	case "major":
+		if !prerelease || minor != 0 || patch != 0 {
+			major, minor, patch = major+1, 0, 0
+		}
+	case "minor":
+		if !prerelease || patch != 0 {
+			minor, patch = minor+1, 0
+		}
+	case "patch":
+		if !prerelease {
+			patch++
+		}
+	default:
+		return "", fmt.Errorf("level must be major, minor or patch")
+	}
+	return fmt.Sprintf("%d.%d.%d", major, minor, patch), nil
+}
+
+// changelogEntry is a merged pull request listed in the changelog.
+type changelogEntry struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	Author   string    `json:"author"`
+	MergedAt time.Time `json:"merged_at"`
+}
+
+// changelogSection renders the changelog section of a release.
+func changelogSection(version string, date time.Time, entries []changelogEntry) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s - %s\n\n", version, date.Format("2006-01-02"))
+	if len(entries) == 0 {
+		sb.WriteString("No changes.\n")
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(&sb, "- %s (#%d) @%s\n", entry.Title, entry.Number, entry.Author)
+	}
+	return sb.String()
+}
+
+// prependChangelogSection adds a section at the top of a changelog, after its title if it has one.
+func prependChangelogSection(changelog, section string) string {
+	if changelog == "" {
+		return "# Changelog\n\n" + section
+	}
+	if strings.HasPrefix(changelog, "# ") {
+		title, rest, _ := strings.Cut(changelog, "\n")
+		return title + "\n\n" + section + "\n" + strings.TrimLeft(rest, "\n")
+	}
+	return section + "\n" + changelog
+}
+
+// versionFileChange is a file changed by a version bump.
+type versionFileChange struct {
+	Path string `json:"path"`
+	Diff string `json:"diff"`
+}
+
+// versionBump is the result of propose_version_bump: the plan of the bump, and what was created for it unless it
+// was a dry run.
+type versionBump struct {
+	Current      string              `json:"current"`
+	Next         string              `json:"next"`
+	Base         string              `json:"base"`
+	Branch       string              `json:"branch"`
+	Since        *time.Time          `json:"since,omitempty"`
+	PullRequests []changelogEntry    `json:"pull_requests"`
+	Files        []versionFileChange `json:"files"`
+	DryRun       bool                `json:"dry_run"`
+	Commit       string              `json:"commit,omitempty"`
+	PullRequest  int                 `json:"pull_request,omitempty"`
+	URL          string              `json:"url,omitempty"`
+}
+
+// ProposeVersionBump creates a tool to bump the version of a project in its version files and changelog, and open a
+// pull request with the change.
+func ProposeVersionBump(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("propose_version_bump",
+			mcp.WithDescription(t("TOOL_PROPOSE_VERSION_BUMP_DESCRIPTION", "Bump the semantic version of a project at a level and open a pull request with the change. The version is updated in the version files, package.json, Chart.yaml, version.go or VERSION, which must agree on the current version, and a changelog section is added listing the pull requests merged since the latest release.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PROPOSE_VERSION_BUMP_USER_TITLE", "Propose version bump"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("level",
+				mcp.Required(),
+				mcp.Description("Semantic version level to bump"),
+				mcp.Enum("major", "minor", "patch"),
+			),
+			mcp.WithArray("files",
+				mcp.Items(map[string]any{"type": "string"}),
+				mcp.Description("Paths of the version files, each named package.json, Chart.yaml, version.go or VERSION. Defaults to all the files with those names, outside of vendored directories"),
+			),
+			mcp.WithString("changelog",
+				mcp.Description("Path of the changelog, defaults to CHANGELOG.md"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to bump the version on, defaults to the default branch"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to create for the pull request, defaults to bump-version-<version>"),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Only return the new version and the changes, without creating the branch and pull request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseProposeVersionBumpParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, file := range params.Files {
+				if versionFilePatterns[path.Base(file)] == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is not a known version file: package.json, Chart.yaml, version.go or VERSION", file)), nil
+				}
+			}
+			if params.Changelog == "" {
+				params.Changelog = "CHANGELOG.md"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if params.Base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				params.Base = repository.GetDefaultBranch()
+			}
+			baseRef, resp, err := client.Git.GetRef(ctx, params.Owner, params.Repo, "refs/heads/"+params.Base)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+			}
+			_ = resp.Body.Close()
+			baseCommit, resp, err := client.Git.GetCommit(ctx, params.Owner, params.Repo, baseRef.GetObject().GetSHA())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get base commit: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			if len(params.Files) == 0 {
+				tree, resp, err := client.Git.GetTree(ctx, params.Owner, params.Repo, baseCommit.GetTree().GetSHA(), true)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get tree: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, entry := range tree.Entries {
+					p := entry.GetPath()
+					if entry.GetType() == "blob" && versionFilePatterns[path.Base(p)] != nil && !isVendoredPath(p) {
+						params.Files = append(params.Files, p)
+					}
+				}
+				if len(params.Files) == 0 {
+					return mcp.NewToolResultError("no version file was found, pass their paths with files"), nil
+				}
+			}
+
+			// Read the version files, which must all hold the same version
+			contents := map[string]string{}
+			result := versionBump{Base: params.Base, DryRun: params.DryRun, PullRequests: []changelogEntry{}, Files: []versionFileChange{}}
+			for _, file := range params.Files {
+				content, _, found, err := getFileAtRef(ctx, client, params.Owner, params.Repo, file, params.Base)
+				if err != nil {
+					return nil, err
+				}
+				if !found {
+					return mcp.NewToolResultError(fmt.Sprintf("%s was not found on %s", file, params.Base)), nil
+				}
+				match := versionFilePatterns[path.Base(file)].FindStringSubmatch(string(content))
+				if match == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("no version was found in %s", file)), nil
+				}
+				version := strings.TrimPrefix(match[2], "v")
+				if result.Current != "" && version != result.Current {
+					return mcp.NewToolResultError(fmt.Sprintf("the version files disagree, pass the ones to bump with files: %s has %s, %s has %s", params.Files[0], result.Current, file, version)), nil
+				}
+				result.Current = version
+				contents[file] = string(content)
+			}
+			result.Next, err = bumpVersion(result.Current, params.Level)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Branch == "" {
+				params.Branch = "bump-version-" + result.Next
+			}
+			result.Branch = params.Branch
+
+			var entries []*github.TreeEntry
+			for _, file := range params.Files {
+				content := contents[file]
+				// Only the first occurrence is the version of the project, not e.g. the one of a dependency
+				loc := versionFilePatterns[path.Base(file)].FindStringSubmatchIndex(content)
+				replaced := content[:loc[4]] + result.Next + content[loc[5]:]
+				result.Files = append(result.Files, versionFileChange{Path: file, Diff: unifiedDiff(content, replaced, defaultDiffContextLines)})
+				entries = append(entries, &github.TreeEntry{Path: github.Ptr(file), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), Content: github.Ptr(replaced)})
+			}
+
+			// List the pull requests merged since the latest release
+			release, resp, err := client.Repositories.GetLatestRelease(ctx, params.Owner, params.Repo)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				since := release.GetPublishedAt().Time
+				result.Since = &since
+			case resp == nil || resp.StatusCode != http.StatusNotFound:
+				return nil, fmt.Errorf("failed to get latest release: %w", err)
+			}
+			prOpts := &github.PullRequestListOptions{State: "closed", Base: params.Base, Sort: "updated", Direction: "desc", ListOptions: github.ListOptions{PerPage: 100}}
+		pages:
+			for {
+				prs, resp, err := client.PullRequests.List(ctx, params.Owner, params.Repo, prOpts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list pull requests: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, pr := range prs {
+					// Pull requests merged since were updated since
+					if result.Since != nil && pr.GetUpdatedAt().Before(*result.Since) {
+						break pages
+					}
+					if pr.MergedAt == nil || (result.Since != nil && pr.GetMergedAt().Before(*result.Since)) {
+						continue
+					}
+					result.PullRequests = append(result.PullRequests, changelogEntry{
+						Number:   pr.GetNumber(),
+						Title:    pr.GetTitle(),
+						Author:   pr.GetUser().GetLogin(),
+						MergedAt: pr.GetMergedAt().Time,
+					})
+					if len(result.PullRequests) == maxChangelogPullRequests {
+						break pages
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				prOpts.Page = resp.NextPage
+			}
+
+			changelog, _, _, err := getFileAtRef(ctx, client, params.Owner, params.Repo, params.Changelog, params.Base)
+			if err != nil {
+				return nil, err
+			}
+			updated := prependChangelogSection(string(changelog), changelogSection(result.Next, time.Now(), result.PullRequests))
+			result.Files = append(result.Files, versionFileChange{Path: params.Changelog, Diff: unifiedDiff(string(changelog), updated, defaultDiffContextLines)})
+			entries = append(entries, &github.TreeEntry{Path: github.Ptr(params.Changelog), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), Content: github.Ptr(updated)})
+
+			if !params.DryRun {
+				title := fmt.Sprintf("Bump version to %s", result.Next)
+				newCommit, err := commitToNewBranch(ctx, client, params.Owner, params.Repo, baseCommit, entries, title, params.Branch)
+				if err != nil {
+					return nil, err
+				}
+				result.Commit = newCommit.GetSHA()
+
+				var body strings.Builder
+				fmt.Fprintf(&body, "Bumps the %s version from %s to %s, with %d merged pull request(s) in the changelog.\n\n", params.Level, result.Current, result.Next, len(result.PullRequests))
+				for _, file := range result.Files {
+					fmt.Fprintf(&body, "- `%s`\n", file.Path)
+				}
+				pr, resp, err := client.PullRequests.Create(ctx, params.Owner, params.Repo, &github.NewPullRequest{
+					Title: github.Ptr(title),
+					Head:  github.Ptr(params.Branch),
+					Base:  github.Ptr(params.Base),
+					Body:  github.Ptr(body.String()),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create pull request: %w", err)
+				}
+				_ = resp.Body.Close()
+				result.PullRequest = pr.GetNumber()
+				result.URL = pr.GetHTMLURL()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,240 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BumpVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		level    string
+		expected string
+	}{
+		{"1.2.3", "major", "2.0.0"},
+		{"1.2.3", "minor", "1.3.0"},
+		{"v1.2.3", "patch", "1.2.4"},
+		{"2.0.0-rc.1", "major", "2.0.0"},
+		{"1.3.0-beta+build.5", "minor", "1.3.0"},
+		{"1.3.1-beta", "minor", "1.4.0"},
+		{"1.2.4-alpha", "patch", "1.2.4"},
+	}
+	for _, tc := range tests {
+		next, err := bumpVersion(tc.version, tc.level)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, next, "%s %s", tc.version, tc.level)
+	}
+
+	_, err := bumpVersion("1.2", "patch")
+	assert.EqualError(t, err, "1.2 is not a semantic version")
+}
+
+func Test_PrependChangelogSection(t *testing.T) {
+	section := "## 1.1.0 - 2026-10-15\n\n- Add export (#3) @octocat\n"
+	assert.Equal(t, "# Changelog\n\n"+section, prependChangelogSection("", section))
+	assert.Equal(t,
+		"# Changelog\n\n"+section+"\n## 1.0.0 - 2026-01-01\n",
+		prependChangelogSection("# Changelog\n\n## 1.0.0 - 2026-01-01\n", section))
+	assert.Equal(t, section+"\n## 1.0.0\n", prependChangelogSection("## 1.0.0\n", section))
+}
+
+func Test_ProposeVersionBump(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ProposeVersionBump(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "propose_version_bump", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "level")
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "changelog")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "dry_run")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "level"})
+
+	packageJSON := "{\n  \"name\": \"app\",\n  \"version\": \"1.2.3\",\n  \"dependencies\": {\"left-pad\": {\"version\": \"1.0.0\"}}\n}\n"
+	chart := "apiVersion: v2\nname: app\nversion: 1.2.3\nappVersion: \"1.2.3\"\n"
+	changelog := "# Changelog\n\n## 1.2.3 - 2026-09-01\n\n- Fix crash (#9) @octocat\n"
+	released := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	options := func(contents map[string]*github.RepositoryContent) []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, &github.Reference{Object: &github.GitObject{SHA: github.Ptr("base-sha")}}),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, &github.Commit{
+				SHA:  github.Ptr("base-sha"),
+				Tree: &github.Tree{SHA: github.Ptr("base-tree")},
+			}),
+			mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, &github.Tree{Entries: []*github.TreeEntry{
+				{Path: github.Ptr("package.json"), Type: github.Ptr("blob")},
+				{Path: github.Ptr("deploy/app/Chart.yaml"), Type: github.Ptr("blob")},
+				{Path: github.Ptr("node_modules/left-pad/package.json"), Type: github.Ptr("blob")},
+				{Path: github.Ptr("README.md"), Type: github.Ptr("blob")},
+			}}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						content, ok := contents[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/")]
+						if !ok {
+							mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+							return
+						}
+						mockResponse(t, http.StatusOK, content)(w, r)
+					}),
+				),
+			),
+			mock.WithRequestMatch(mock.GetReposReleasesLatestByOwnerByRepo, &github.RepositoryRelease{
+				PublishedAt: &github.Timestamp{Time: released},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"state": "closed", "base": "main", "sort": "updated", "direction": "desc", "per_page": "100"}).andThen(
+					mockResponse(t, http.StatusOK, []*github.PullRequest{
+						{Number: github.Ptr(12), Title: github.Ptr("Add export"), User: &github.User{Login: github.Ptr("octocat")}, UpdatedAt: &github.Timestamp{Time: released.AddDate(0, 0, 10)}, MergedAt: &github.Timestamp{Time: released.AddDate(0, 0, 9)}},
+						// Closed without merging
+						{Number: github.Ptr(11), UpdatedAt: &github.Timestamp{Time: released.AddDate(0, 0, 5)}},
+						// Merged before the release, commented on since
+						{Number: github.Ptr(10), UpdatedAt: &github.Timestamp{Time: released.AddDate(0, 0, 2)}, MergedAt: &github.Timestamp{Time: released.AddDate(0, 0, -1)}},
+						{Number: github.Ptr(9), UpdatedAt: &github.Timestamp{Time: released.AddDate(0, 0, -1)}, MergedAt: &github.Timestamp{Time: released.AddDate(0, 0, -2)}},
+					}),
+				),
+			),
+		}
+	}
+
+	t.Run("bumps the version files and changelog", func(t *testing.T) {
+		var tree []any
+		mockedClient := mock.NewMockedHTTPClient(append(options(map[string]*github.RepositoryContent{
+			"package.json":          fileContent("package.json", packageJSON),
+			"deploy/app/Chart.yaml": fileContent("deploy/app/Chart.yaml", chart),
+			"CHANGELOG.md":          fileContent("CHANGELOG.md", changelog),
+		}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "base-tree", body["base_tree"])
+					tree = body["tree"].([]any)
+					mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("new-tree")})(w, r)
+				}),
+			),
+			mock.WithRequestMatch(mock.PostReposGitCommitsByOwnerByRepo, &github.Commit{SHA: github.Ptr("new-sha")}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitRefsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"ref": "refs/heads/bump-version-1.3.0",
+					"sha": "new-sha",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{})),
+			),
+			mock.WithRequestMatch(mock.PostReposPullsByOwnerByRepo, &github.PullRequest{
+				Number:  github.Ptr(13),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/pull/13"),
+			}),
+		)...)
+		_, handler := ProposeVersionBump(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"level": "minor",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var bump versionBump
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &bump))
+		assert.Equal(t, "1.2.3", bump.Current)
+		assert.Equal(t, "1.3.0", bump.Next)
+		assert.Equal(t, "main", bump.Base)
+		assert.Equal(t, released, bump.Since.UTC())
+		require.Len(t, bump.PullRequests, 1)
+		assert.Equal(t, 12, bump.PullRequests[0].Number)
+		assert.Equal(t, "new-sha", bump.Commit)
+		assert.Equal(t, 13, bump.PullRequest)
+
+		contents := map[string]string{}
+		for _, entry := range tree {
+			entry := entry.(map[string]any)
+			contents[entry["path"].(string)] = entry["content"].(string)
+		}
+		assert.Equal(t, strings.Replace(packageJSON, `"version": "1.2.3"`, `"version": "1.3.0"`, 1), contents["package.json"])
+		assert.Equal(t, strings.Replace(chart, "version: 1.2.3", "version: 1.3.0", 1), contents["deploy/app/Chart.yaml"])
+		today := time.Now().Format("2006-01-02")
+		assert.Equal(t, "# Changelog\n\n## 1.3.0 - "+today+"\n\n- Add export (#12) @octocat\n\n## 1.2.3 - 2026-09-01\n\n- Fix crash (#9) @octocat\n", contents["CHANGELOG.md"])
+	})
+
+	t.Run("dry run creates the changelog", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(options(map[string]*github.RepositoryContent{
+			"version.go": fileContent("version.go", "package main\n\nconst Version = \"v0.9.0\"\n"),
+		})...)
+		_, handler := ProposeVersionBump(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"level":   "major",
+			"files":   []any{"version.go"},
+			"base":    "main",
+			"dry_run": true,
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var bump versionBump
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &bump))
+		assert.Equal(t, "1.0.0", bump.Next)
+		assert.True(t, bump.DryRun)
+		assert.Empty(t, bump.Commit)
+		require.Len(t, bump.Files, 2)
+		assert.Contains(t, bump.Files[0].Diff, "+const Version = \"v1.0.0\"")
+		assert.Equal(t, "CHANGELOG.md", bump.Files[1].Path)
+		assert.Contains(t, bump.Files[1].Diff, "+# Changelog")
+	})
+
+	t.Run("rejects disagreeing version files", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(options(map[string]*github.RepositoryContent{
+			"package.json":          fileContent("package.json", packageJSON),
+			"deploy/app/Chart.yaml": fileContent("deploy/app/Chart.yaml", "version: 0.4.0\n"),
+		})...)
+		_, handler := ProposeVersionBump(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"level": "patch",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "package.json has 1.2.3, deploy/app/Chart.yaml has 0.4.0")
+	})
+
+	t.Run("rejects an unknown version file", func(t *testing.T) {
+		_, handler := ProposeVersionBump(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"level": "patch",
+			"files": []any{"setup.py"},
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "setup.py is not a known version file")
+	})
+}
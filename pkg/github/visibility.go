@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// visibilityChange is the result of change_repository_visibility.
+type visibilityChange struct {
+	Repository   string   `json:"repository"`
+	From         string   `json:"from"`
+	To           string   `json:"to"`
+	Changed      bool     `json:"changed"`
+	Consequences []string `json:"consequences,omitempty"`
+}
+
+// visibilityConfirmation is the phrase change_repository_visibility must be given to change the visibility of a
+// repository, naming both the repository and the visibility so that it cannot be reused for another change.
+func visibilityConfirmation(owner, repo, visibility string) string {
+	return fmt.Sprintf("make %s/%s %s", owner, repo, visibility)
+}
+
+// visibilityConsequences describes what changing the visibility of a repository from one visibility to another
+// implies, given the repository as it is and the number of its workflows.
+func visibilityConsequences(repository *github.Repository, from, to string, workflows int) []string {
+	var consequences []string
+	switch {
+	case to == "public":
+		consequences = append(consequences,
+			"The code, the whole commit history, issues and pull requests become visible to anyone: check the history for secrets first.")
+		if repository.GetForksCount() > 0 {
+			consequences = append(consequences, fmt.Sprintf("The %d forks stay %s and are detached into standalone repositories.", repository.GetForksCount(), from))
+		}
+		if workflows > 0 {
+			consequences = append(consequences,
+				fmt.Sprintf("The %d workflows can be triggered by pull requests from forks: review pull_request_target workflows and do not use self-hosted runners.", workflows),
+				"Actions minutes and storage are no longer billed.")
+		}
+	case from == "public":
+		consequences = append(consequences, "The repository is no longer visible to anyone and anonymous Git access stops.")
+		if repository.GetForksCount() > 0 {
+			consequences = append(consequences, fmt.Sprintf("The %d forks stay public and are detached into a separate network.", repository.GetForksCount()))
+		}
+		if repository.GetStargazersCount() > 0 || repository.GetSubscribersCount() > 0 {
+			consequences = append(consequences, fmt.Sprintf("The %d stars and %d watchers are lost and are not restored if the repository is made public again.", repository.GetStargazersCount(), repository.GetSubscribersCount()))
+		}
+		if workflows > 0 {
+			consequences = append(consequences, fmt.Sprintf("The %d workflows start using the Actions minutes and storage of the account.", workflows))
+		}
+		if repository.GetHasPages() {
+			consequences = append(consequences, "The GitHub Pages site is unpublished unless the plan supports Pages for non-public repositories.")
+		}
+		consequences = append(consequences, "Code scanning and secret scanning need GitHub Advanced Security.")
+	case to == "internal":
+		consequences = append(consequences, "The repository becomes visible to all members of the enterprise.")
+	default:
+		consequences = append(consequences, "The repository is no longer visible to the members of the enterprise who are not collaborators.")
+	}
+	return consequences
+}
+
+// ChangeRepositoryVisibility creates a tool to change the visibility of a repository, once confirmed.
+func ChangeRepositoryVisibility(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("change_repository_visibility",
+			mcp.WithDescription(t("TOOL_CHANGE_REPOSITORY_VISIBILITY_DESCRIPTION", "Change the visibility of a repository to public, private or internal. As the change is high impact and cannot be fully reverted, it needs the confirmation phrase 'make <owner>/<repo> <visibility>': without it, the consequences of the change, such as forks being detached, stars lost or Actions billing, are returned and nothing is changed. Only confirm once the user has agreed to the consequences.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_CHANGE_REPOSITORY_VISIBILITY_USER_TITLE", "Change repository visibility"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("visibility",
+				mcp.Required(),
+				mcp.Description("New visibility. Internal is only available to repositories of an enterprise organization"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			mcp.WithString("confirm",
+				mcp.Description("Confirmation phrase 'make <owner>/<repo> <visibility>', e.g. 'make octo-org/hello-world private'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseChangeRepositoryVisibilityParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch params.Visibility {
+			case "public", "private", "internal":
+			default:
+				return mcp.NewToolResultError("visibility must be one of public, private and internal"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, params.Owner, params.Repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			change := visibilityChange{
+				Repository: params.Owner + "/" + params.Repo,
+				From:       repository.GetVisibility(),
+				To:         params.Visibility,
+			}
+			if change.From == change.To {
+				r, err := json.Marshal(change)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			workflows := 0
+			if list, resp, err := client.Actions.ListWorkflows(ctx, params.Owner, params.Repo, &github.ListOptions{PerPage: 1}); err == nil {
+				_ = resp.Body.Close()
+				workflows = list.GetTotalCount()
+			}
+			change.Consequences = visibilityConsequences(repository, change.From, change.To, workflows)
+
+			phrase := visibilityConfirmation(params.Owner, params.Repo, params.Visibility)
+			if params.Confirm != phrase {
+				var sb strings.Builder
+				fmt.Fprintf(&sb, "the visibility of %s was not changed from %s to %s: call again with confirm %q once the user has agreed to the consequences:\n", change.Repository, change.From, change.To, phrase)
+				for _, consequence := range change.Consequences {
+					fmt.Fprintf(&sb, "- %s\n", consequence)
+				}
+				return mcp.NewToolResultError(sb.String()), nil
+			}
+
+			_, resp, err = client.Repositories.Edit(ctx, params.Owner, params.Repo, &github.Repository{Visibility: github.Ptr(params.Visibility)})
+			if err != nil {
+				return nil, fmt.Errorf("failed to change repository visibility: %w", err)
+			}
+			_ = resp.Body.Close()
+			change.Changed = true
+
+			r, err := json.Marshal(change)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
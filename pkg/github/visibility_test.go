@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VisibilityConsequences(t *testing.T) {
+	repository := &github.Repository{
+		ForksCount:       github.Ptr(3),
+		StargazersCount:  github.Ptr(40),
+		SubscribersCount: github.Ptr(5),
+		HasPages:         github.Ptr(true),
+	}
+
+	consequences := visibilityConsequences(repository, "public", "private", 2)
+	assert.Contains(t, consequences, "The 3 forks stay public and are detached into a separate network.")
+	assert.Contains(t, consequences, "The 40 stars and 5 watchers are lost and are not restored if the repository is made public again.")
+	assert.Contains(t, consequences, "The 2 workflows start using the Actions minutes and storage of the account.")
+	assert.Contains(t, consequences, "The GitHub Pages site is unpublished unless the plan supports Pages for non-public repositories.")
+
+	consequences = visibilityConsequences(&github.Repository{}, "private", "public", 0)
+	assert.Len(t, consequences, 1)
+	assert.Contains(t, consequences[0], "check the history for secrets")
+
+	assert.Equal(t, []string{"The repository becomes visible to all members of the enterprise."}, visibilityConsequences(repository, "private", "internal", 0))
+}
+
+func Test_ChangeRepositoryVisibility(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ChangeRepositoryVisibility(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "change_repository_visibility", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "confirm")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "visibility"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	repository := &github.Repository{
+		Visibility:      github.Ptr("public"),
+		ForksCount:      github.Ptr(2),
+		StargazersCount: github.Ptr(10),
+	}
+	getRepository := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposByOwnerByRepo, repository)
+	}
+	listWorkflows := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(mock.GetReposActionsWorkflowsByOwnerByRepo, &github.Workflows{TotalCount: github.Ptr(1)})
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedChange visibilityChange
+	}{
+		{
+			name:         "reports consequences without confirmation",
+			mockedClient: mock.NewMockedHTTPClient(getRepository(), listWorkflows()),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "private",
+			},
+			expectError:    true,
+			expectedErrMsg: `call again with confirm "make owner/repo private"`,
+		},
+		{
+			name:         "confirmation for another visibility",
+			mockedClient: mock.NewMockedHTTPClient(getRepository(), listWorkflows()),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "private",
+				"confirm":    "make owner/repo internal",
+			},
+			expectError:    true,
+			expectedErrMsg: "The 2 forks stay public and are detached into a separate network.",
+		},
+		{
+			name: "changes visibility once confirmed",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRepository(),
+				listWorkflows(),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"visibility": "private"}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{Visibility: github.Ptr("private")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "private",
+				"confirm":    "make owner/repo private",
+			},
+			expectedChange: visibilityChange{
+				Repository: "owner/repo",
+				From:       "public",
+				To:         "private",
+				Changed:    true,
+				Consequences: []string{
+					"The repository is no longer visible to anyone and anonymous Git access stops.",
+					"The 2 forks stay public and are detached into a separate network.",
+					"The 10 stars and 0 watchers are lost and are not restored if the repository is made public again.",
+					"The 1 workflows start using the Actions minutes and storage of the account.",
+					"Code scanning and secret scanning need GitHub Advanced Security.",
+				},
+			},
+		},
+		{
+			name:         "already has the visibility",
+			mockedClient: mock.NewMockedHTTPClient(getRepository()),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "public",
+			},
+			expectedChange: visibilityChange{Repository: "owner/repo", From: "public", To: "public"},
+		},
+		{
+			name:         "invalid visibility",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "secret",
+			},
+			expectError:    true,
+			expectedErrMsg: "visibility must be one of public, private and internal",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ChangeRepositoryVisibility(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var change visibilityChange
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &change))
+			assert.Equal(t, tc.expectedChange, change)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 - GitHub still signs deliveries with HMAC-SHA1 in X-Hub-Signature
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// InitWebhookToolset creates the toolset of the tools using the webhook secrets the server is configured with, by
+// name.
+func InitWebhookToolset(secrets map[string]string, t translations.TranslationHelperFunc) *toolsets.Toolset {
+	return toolsets.NewToolset("webhooks", "Webhook related tools, available when the server is configured with webhook secrets").
+		AddReadTools(
+			toolsets.NewServerTool(VerifyWebhookSignature(secrets, t)),
+		)
+}
+
+// webhookSignatureCheck is the result of verify_webhook_signature.
+type webhookSignatureCheck struct {
+	Secret    string `json:"secret"`
+	Algorithm string `json:"algorithm"`
+	Valid     bool   `json:"valid"`
+}
+
+// webhookSignatureValid reports whether signature, the value of the X-Hub-Signature-256 or X-Hub-Signature header
+// of a delivery, is the signature of payload with secret. It also returns the algorithm of the signature.
+func webhookSignatureValid(payload []byte, signature, secret string) (string, bool, error) {
+	algorithm, digest, ok := strings.Cut(strings.TrimSpace(signature), "=")
+	if !ok {
+		return "", false, fmt.Errorf("signature must be of the form sha256=<hex digest>")
+	}
+	var newHash func() hash.Hash
+	switch algorithm {
+	case "sha256":
+		newHash = sha256.New
+	case "sha1":
+		newHash = sha1.New
+	default:
+		return "", false, fmt.Errorf("unsupported signature algorithm %q, expected sha256 or sha1", algorithm)
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return "", false, fmt.Errorf("signature digest is not hexadecimal: %w", err)
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(payload)
+	return algorithm, hmac.Equal(mac.Sum(nil), expected), nil
+}
+
+// VerifyWebhookSignature creates a tool to check the signature of a webhook delivery against a configured secret.
+func VerifyWebhookSignature(secrets map[string]string, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("verify_webhook_signature",
+			mcp.WithDescription(t("TOOL_VERIFY_WEBHOOK_SIGNATURE_DESCRIPTION", "Check that a webhook delivery was sent by GitHub: verify its X-Hub-Signature-256 (or legacy X-Hub-Signature) header against one of the webhook secrets the server is configured with, referred to by name. The secret itself is never returned.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_VERIFY_WEBHOOK_SIGNATURE_USER_TITLE", "Verify webhook signature"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("payload",
+				mcp.Required(),
+				mcp.Description("Body of the delivery, exactly as received"),
+			),
+			mcp.WithString("signature",
+				mcp.Required(),
+				mcp.Description("Value of the X-Hub-Signature-256 header, e.g. sha256=<hex digest>, or of X-Hub-Signature"),
+			),
+			mcp.WithString("secret",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Name of the configured webhook secret the delivery was signed with, one of: %s", strings.Join(slices.Sorted(maps.Keys(secrets)), ", "))),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseVerifyWebhookSignatureParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			name := params.Secret
+			secret, ok := secrets[name]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("no webhook secret named %q is configured", name)), nil
+			}
+			algorithm, valid, err := webhookSignatureValid([]byte(params.Payload), params.Signature, secret)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			r, err := json.Marshal(webhookSignatureCheck{Secret: name, Algorithm: algorithm, Valid: valid})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VerifyWebhookSignature(t *testing.T) {
+	secrets := map[string]string{"issues-hook": "It's a Secret to Everybody", "other": "x"}
+
+	// Verify tool definition once
+	tool, _ := VerifyWebhookSignature(secrets, translations.NullTranslationHelper)
+
+	assert.Equal(t, "verify_webhook_signature", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "payload")
+	assert.Contains(t, tool.InputSchema.Properties, "signature")
+	assert.Contains(t, tool.InputSchema.Properties, "secret")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"payload", "signature", "secret"})
+
+	// The example of the GitHub documentation on validating webhook deliveries
+	const payload = "Hello, World!"
+	const signature = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedCheck  webhookSignatureCheck
+	}{
+		{
+			name:          "valid signature",
+			requestArgs:   map[string]any{"payload": payload, "signature": signature, "secret": "issues-hook"},
+			expectedCheck: webhookSignatureCheck{Secret: "issues-hook", Algorithm: "sha256", Valid: true},
+		},
+		{
+			name:          "tampered payload",
+			requestArgs:   map[string]any{"payload": payload + " ", "signature": signature, "secret": "issues-hook"},
+			expectedCheck: webhookSignatureCheck{Secret: "issues-hook", Algorithm: "sha256", Valid: false},
+		},
+		{
+			name:          "other secret",
+			requestArgs:   map[string]any{"payload": payload, "signature": signature, "secret": "other"},
+			expectedCheck: webhookSignatureCheck{Secret: "other", Algorithm: "sha256", Valid: false},
+		},
+		{
+			name:           "unknown secret",
+			requestArgs:    map[string]any{"payload": payload, "signature": signature, "secret": "missing"},
+			expectError:    true,
+			expectedErrMsg: `no webhook secret named "missing" is configured`,
+		},
+		{
+			name:           "unsupported algorithm",
+			requestArgs:    map[string]any{"payload": payload, "signature": "md5=abcd", "secret": "issues-hook"},
+			expectError:    true,
+			expectedErrMsg: `unsupported signature algorithm "md5"`,
+		},
+		{
+			name:           "malformed signature",
+			requestArgs:    map[string]any{"payload": payload, "signature": "757107ea", "secret": "issues-hook"},
+			expectError:    true,
+			expectedErrMsg: "signature must be of the form sha256=<hex digest>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := VerifyWebhookSignature(secrets, translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, textContent.Text)
+
+			var check webhookSignatureCheck
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &check))
+			assert.Equal(t, tc.expectedCheck, check)
+		})
+	}
+}
+
+func Test_WebhookSignatureValidSHA1(t *testing.T) {
+	algorithm, valid, err := webhookSignatureValid([]byte("Hello, World!"), "sha1=01dc10d0c83e72ed246219cdd91669667fe2ca59", "It's a Secret to Everybody")
+	require.NoError(t, err)
+	assert.Equal(t, "sha1", algorithm)
+	assert.True(t, valid)
+}
@@ -0,0 +1,371 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultWorkflowDependencyDepth is how many levels of uses: references map_workflow_dependencies follows by
+	// default.
+	defaultWorkflowDependencyDepth = 3
+	// maxWorkflowDependencyDepth bounds the levels of uses: references map_workflow_dependencies follows.
+	maxWorkflowDependencyDepth = 5
+	// maxWorkflowDependencyFiles bounds the reusable workflows and action metadata files map_workflow_dependencies
+	// fetches, besides the workflows of the repository.
+	maxWorkflowDependencyFiles = 100
+)
+
+// actionMetadata is a parsed action.yml file, holding the parts map_workflow_dependencies looks at.
+type actionMetadata struct {
+	Runs struct {
+		Using string         `yaml:"using"`
+		Steps []workflowStep `yaml:"steps"`
+	} `yaml:"runs"`
+}
+
+// workflowDependencyNode is a workflow, reusable workflow or action in the graph of map_workflow_dependencies.
+type workflowDependencyNode struct {
+	ID         string `json:"id"`
+	Kind       string `json:"kind"`
+	Repository string `json:"repository,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	// Using is how an action runs, e.g. node20, docker or composite.
+	Using string `json:"using,omitempty"`
+	// Resolved is true when the file of the node was fetched and its own references followed.
+	Resolved bool   `json:"resolved"`
+	Error    string `json:"error,omitempty"`
+	// UsedBy are the workflows of the repository depending on the node, directly or not.
+	UsedBy []string `json:"used_by,omitempty"`
+
+	owner, repo string
+}
+
+// workflowDependencyEdge is a uses: reference from a workflow or composite action to another node.
+type workflowDependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Job is the job holding the reference, empty for the steps of composite actions.
+	Job string `json:"job,omitempty"`
+}
+
+// workflowDependencyGraph is the result of map_workflow_dependencies.
+type workflowDependencyGraph struct {
+	Repository string                   `json:"repository"`
+	Ref        string                   `json:"ref,omitempty"`
+	Nodes      []workflowDependencyNode `json:"nodes"`
+	Edges      []workflowDependencyEdge `json:"edges"`
+	// Truncated is set when references were left unresolved because of the depth or file limits.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// workflowDependencyID identifies a node by repository, path and ref, as owner/repo/path@ref.
+func workflowDependencyID(owner, repo, filePath, ref string) string {
+	id := owner + "/" + repo
+	if filePath != "" {
+		id += "/" + filePath
+	}
+	if ref != "" {
+		id += "@" + ref
+	}
+	return id
+}
+
+// isWorkflowPath reports whether a path is a workflow file, which reusable workflows must be.
+func isWorkflowPath(filePath string) bool {
+	return path.Dir(filePath) == workflowsDir && (path.Ext(filePath) == ".yml" || path.Ext(filePath) == ".yaml")
+}
+
+// usesTarget returns the node a uses: reference of a file in owner/repo at ref refers to. Local references are
+// resolved against the repository and ref of the file holding them.
+func usesTarget(uses, owner, repo, ref string) workflowDependencyNode {
+	if strings.HasPrefix(uses, "docker://") {
+		return workflowDependencyNode{ID: uses, Kind: "docker_action", Resolved: true}
+	}
+
+	node := workflowDependencyNode{owner: owner, repo: repo, Ref: ref}
+	if local, ok := strings.CutPrefix(uses, "./"); ok {
+		node.Path = path.Clean(local)
+	} else {
+		action, actionRef, _ := strings.Cut(uses, "@")
+		parts := strings.SplitN(action, "/", 3)
+		node.owner, node.repo, node.Ref = parts[0], "", actionRef
+		if len(parts) > 1 {
+			node.repo = parts[1]
+		}
+		if len(parts) > 2 {
+			node.Path = path.Clean(parts[2])
+		}
+	}
+	node.Kind = "action"
+	if isWorkflowPath(node.Path) {
+		node.Kind = "reusable_workflow"
+	}
+	node.Repository = node.owner + "/" + node.repo
+	node.ID = workflowDependencyID(node.owner, node.repo, node.Path, node.Ref)
+	return node
+}
+
+// workflowDependencyMapper builds the graph of map_workflow_dependencies, fetching the files of the nodes it follows.
+type workflowDependencyMapper struct {
+	client       *github.Client
+	sharedOwners []string
+	maxDepth     int
+
+	graph   workflowDependencyGraph
+	nodes   map[string]int
+	edges   map[workflowDependencyEdge]bool
+	fetched int
+}
+
+type pendingDependency struct {
+	id    string
+	depth int
+}
+
+// addNode adds a node to the graph unless it is already there, and returns its index.
+func (m *workflowDependencyMapper) addNode(node workflowDependencyNode) (int, bool) {
+	if i, ok := m.nodes[node.ID]; ok {
+		return i, false
+	}
+	m.graph.Nodes = append(m.graph.Nodes, node)
+	m.nodes[node.ID] = len(m.graph.Nodes) - 1
+	return len(m.graph.Nodes) - 1, true
+}
+
+// addReferences adds the uses: references of the steps of a job, or of a composite action when job is empty, and
+// returns the nodes to follow.
+func (m *workflowDependencyMapper) addReferences(from workflowDependencyNode, job string, uses []string, depth int) []pendingDependency {
+	var pending []pendingDependency
+	for _, ref := range uses {
+		target := usesTarget(ref, from.owner, from.repo, from.Ref)
+		i, added := m.addNode(target)
+		edge := workflowDependencyEdge{From: from.ID, To: target.ID, Job: job}
+		if !m.edges[edge] {
+			m.edges[edge] = true
+			m.graph.Edges = append(m.graph.Edges, edge)
+		}
+		if !added || m.graph.Nodes[i].Resolved || !slices.Contains(m.sharedOwners, strings.ToLower(target.owner)) {
+			continue
+		}
+		if depth >= m.maxDepth {
+			m.graph.Truncated = true
+			continue
+		}
+		pending = append(pending, pendingDependency{id: target.ID, depth: depth + 1})
+	}
+	return pending
+}
+
+// addWorkflow adds the references of the jobs of a workflow file, by job ID.
+func (m *workflowDependencyMapper) addWorkflow(from workflowDependencyNode, wf workflowFile, depth int) []pendingDependency {
+	jobs := make([]string, 0, len(wf.Jobs))
+	for id := range wf.Jobs {
+		jobs = append(jobs, id)
+	}
+	sort.Strings(jobs)
+	var pending []pendingDependency
+	for _, id := range jobs {
+		pending = append(pending, m.addReferences(from, id, wf.Jobs[id].actions(), depth)...)
+	}
+	return pending
+}
+
+// resolve fetches the file of a node and returns the nodes it refers to, recording on the node why it could not be
+// resolved otherwise.
+func (m *workflowDependencyMapper) resolve(ctx context.Context, node *workflowDependencyNode, depth int) ([]pendingDependency, error) {
+	if m.fetched >= maxWorkflowDependencyFiles {
+		m.graph.Truncated = true
+		return nil, nil
+	}
+
+	if node.Kind == "reusable_workflow" {
+		m.fetched++
+		content, _, found, err := getFileAtRef(ctx, m.client, node.owner, node.repo, node.Path, node.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			node.Error = "workflow file not found"
+			return nil, nil
+		}
+		wf := parseWorkflowFile(node.Path, string(content))
+		if wf.ParseError != "" {
+			node.Error = wf.ParseError
+			return nil, nil
+		}
+		node.Resolved = true
+		return m.addWorkflow(*node, wf, depth), nil
+	}
+
+	for _, name := range []string{"action.yml", "action.yaml"} {
+		m.fetched++
+		content, _, found, err := getFileAtRef(ctx, m.client, node.owner, node.repo, path.Join(node.Path, name), node.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		var metadata actionMetadata
+		if err := yaml.Unmarshal(content, &metadata); err != nil {
+			node.Error = err.Error()
+			return nil, nil
+		}
+		node.Using = metadata.Runs.Using
+		node.Resolved = true
+		uses := make([]string, 0, len(metadata.Runs.Steps))
+		for _, step := range metadata.Runs.Steps {
+			if step.Uses != "" {
+				uses = append(uses, step.Uses)
+			}
+		}
+		return m.addReferences(*node, "", uses, depth), nil
+	}
+	node.Error = "action metadata file not found"
+	return nil, nil
+}
+
+// setUsedBy records on each node the workflows of the repository reaching it through the edges of the graph.
+func (m *workflowDependencyMapper) setUsedBy(roots []string) {
+	next := map[string][]string{}
+	for _, edge := range m.graph.Edges {
+		next[edge.From] = append(next[edge.From], edge.To)
+	}
+	for _, root := range roots {
+		seen := map[string]bool{root: true}
+		stack := []string{root}
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, to := range next[id] {
+				if !seen[to] {
+					seen[to] = true
+					stack = append(stack, to)
+					m.graph.Nodes[m.nodes[to]].UsedBy = append(m.graph.Nodes[m.nodes[to]].UsedBy, root)
+				}
+			}
+		}
+	}
+}
+
+// MapWorkflowDependencies creates a tool to map the reusable workflows and actions the workflows of a repository
+// depend on.
+func MapWorkflowDependencies(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("map_workflow_dependencies",
+			mcp.WithDescription(t("TOOL_MAP_WORKFLOW_DEPENDENCIES_DESCRIPTION", "Map the reusable workflows and actions the GitHub Actions workflows of a repository depend on, as a graph resolving uses: references. References to the repository itself and to the shared owners are followed into the called workflows and composite actions. Each node lists the workflows of the repository depending on it, directly or not, to assess the blast radius of changing shared CI.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MAP_WORKFLOW_DEPENDENCIES_USER_TITLE", "Map workflow dependencies"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit of the workflows, defaults to the default branch"),
+			),
+			mcp.WithArray("shared_owners",
+				mcp.Description("Other owners whose referenced workflows and actions are followed too, e.g. the organization holding shared workflows. References to the owner of the repository are always followed"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("max_depth",
+				mcp.Description(fmt.Sprintf("Levels of references to follow, defaults to %d, at most %d", defaultWorkflowDependencyDepth, maxWorkflowDependencyDepth)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseMapWorkflowDependenciesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.MaxDepth <= 0 {
+				params.MaxDepth = defaultWorkflowDependencyDepth
+			}
+			if params.MaxDepth > maxWorkflowDependencyDepth {
+				return mcp.NewToolResultError(fmt.Sprintf("max_depth must be at most %d", maxWorkflowDependencyDepth)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			workflows, err := getWorkflowFiles(ctx, client, params.Owner, params.Repo, params.Ref)
+			if err != nil {
+				return nil, err
+			}
+
+			m := &workflowDependencyMapper{
+				client:   client,
+				maxDepth: params.MaxDepth,
+				graph: workflowDependencyGraph{
+					Repository: params.Owner + "/" + params.Repo,
+					Ref:        params.Ref,
+					Nodes:      []workflowDependencyNode{},
+					Edges:      []workflowDependencyEdge{},
+				},
+				nodes: map[string]int{},
+				edges: map[workflowDependencyEdge]bool{},
+			}
+			// References to the repository itself are always followed
+			m.sharedOwners = append(m.sharedOwners, strings.ToLower(params.Owner))
+			for _, owner := range params.SharedOwners {
+				m.sharedOwners = append(m.sharedOwners, strings.ToLower(owner))
+			}
+
+			var roots []string
+			var pending []pendingDependency
+			for _, wf := range workflows {
+				root := workflowDependencyNode{
+					ID:         workflowDependencyID(params.Owner, params.Repo, wf.Path, params.Ref),
+					Kind:       "workflow",
+					Repository: params.Owner + "/" + params.Repo,
+					Path:       wf.Path,
+					Ref:        params.Ref,
+					Resolved:   wf.ParseError == "",
+					Error:      wf.ParseError,
+					owner:      params.Owner,
+					repo:       params.Repo,
+				}
+				m.addNode(root)
+				roots = append(roots, root.ID)
+				pending = append(pending, m.addWorkflow(root, wf, 0)...)
+			}
+			for len(pending) > 0 {
+				next := pending[0]
+				pending = pending[1:]
+				// resolve adds nodes, so the node is updated on a copy
+				i := m.nodes[next.id]
+				node := m.graph.Nodes[i]
+				found, err := m.resolve(ctx, &node, next.depth)
+				if err != nil {
+					return nil, fmt.Errorf("failed to resolve %s: %w", next.id, err)
+				}
+				m.graph.Nodes[i] = node
+				pending = append(pending, found...)
+			}
+			m.setUsedBy(roots)
+
+			r, err := json.Marshal(m.graph)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
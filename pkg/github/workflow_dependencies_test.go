@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UsesTarget(t *testing.T) {
+	tests := []struct {
+		uses     string
+		wantID   string
+		wantKind string
+	}{
+		{"actions/checkout@v4", "actions/checkout@v4", "action"},
+		{"github/codeql-action/init@v3", "github/codeql-action/init@v3", "action"},
+		{"octo/shared/.github/workflows/deploy.yml@main", "octo/shared/.github/workflows/deploy.yml@main", "reusable_workflow"},
+		{"./.github/workflows/build.yml", "octo/api/.github/workflows/build.yml@v2", "reusable_workflow"},
+		{"./.github/actions/setup/", "octo/api/.github/actions/setup@v2", "action"},
+		{"docker://alpine:3", "docker://alpine:3", "docker_action"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.uses, func(t *testing.T) {
+			node := usesTarget(tc.uses, "octo", "api", "v2")
+			assert.Equal(t, tc.wantID, node.ID)
+			assert.Equal(t, tc.wantKind, node.Kind)
+		})
+	}
+}
+
+func Test_MapWorkflowDependencies(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MapWorkflowDependencies(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "map_workflow_dependencies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "shared_owners")
+	assert.Contains(t, tool.InputSchema.Properties, "max_depth")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	ci := `
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: ./.github/actions/setup
+  deploy:
+    uses: octo/shared/.github/workflows/deploy.yml@v1
+`
+	release := `
+on: release
+jobs:
+  release:
+    uses: octo/shared/.github/workflows/deploy.yml@v1
+`
+	setup := `
+runs:
+  using: composite
+  steps:
+    - uses: actions/setup-go@v5
+`
+	deploy := `
+on: workflow_call
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: octo/shared/.github/actions/notify@v1
+      - uses: docker://alpine:3
+`
+	contents := map[string]any{
+		"/repos/octo/api/contents/.github/workflows": []*github.RepositoryContent{
+			{Type: github.Ptr("file"), Name: github.Ptr("ci.yml"), Path: github.Ptr(".github/workflows/ci.yml")},
+			{Type: github.Ptr("file"), Name: github.Ptr("release.yml"), Path: github.Ptr(".github/workflows/release.yml")},
+		},
+		"/repos/octo/api/contents/.github/workflows/ci.yml":              fileContent(".github/workflows/ci.yml", ci),
+		"/repos/octo/api/contents/.github/workflows/release.yml":         fileContent(".github/workflows/release.yml", release),
+		"/repos/octo/api/contents/.github/actions/setup/action.yml":      fileContent(".github/actions/setup/action.yml", setup),
+		"/repos/octo/shared/contents/.github/workflows/deploy.yml":       fileContent(".github/workflows/deploy.yml", deploy),
+		"/repos/octo/shared/contents/.github/actions/notify/action.yaml": fileContent(".github/actions/notify/action.yaml", "runs:\n  using: node20\n"),
+	}
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				content, ok := contents[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				mockResponse(t, http.StatusOK, content)(w, r)
+			}),
+		),
+	)
+	_, handler := MapWorkflowDependencies(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	mapDependencies := func(t *testing.T, args map[string]any) (workflowDependencyGraph, map[string]workflowDependencyNode) {
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var graph workflowDependencyGraph
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &graph))
+		nodes := map[string]workflowDependencyNode{}
+		for _, node := range graph.Nodes {
+			nodes[node.ID] = node
+		}
+		return graph, nodes
+	}
+
+	t.Run("follows references to the owner of the repository", func(t *testing.T) {
+		graph, nodes := mapDependencies(t, map[string]any{
+			"owner": "octo",
+			"repo":  "api",
+		})
+		assert.False(t, graph.Truncated)
+		require.Len(t, graph.Nodes, 8)
+
+		ciID, releaseID := "octo/api/.github/workflows/ci.yml", "octo/api/.github/workflows/release.yml"
+		assert.Equal(t, "workflow", nodes[ciID].Kind)
+
+		shared := nodes["octo/shared/.github/workflows/deploy.yml@v1"]
+		assert.Equal(t, "reusable_workflow", shared.Kind)
+		assert.True(t, shared.Resolved)
+		assert.Equal(t, []string{ciID, releaseID}, shared.UsedBy)
+
+		setupAction := nodes["octo/api/.github/actions/setup"]
+		assert.True(t, setupAction.Resolved)
+		assert.Equal(t, "composite", setupAction.Using)
+		assert.Equal(t, []string{ciID}, nodes["actions/setup-go@v5"].UsedBy)
+
+		notify := nodes["octo/shared/.github/actions/notify@v1"]
+		assert.True(t, notify.Resolved)
+		assert.Equal(t, "node20", notify.Using)
+		assert.Equal(t, []string{ciID, releaseID}, notify.UsedBy)
+		assert.Equal(t, "docker_action", nodes["docker://alpine:3"].Kind)
+
+		// Actions of other owners are not followed
+		assert.False(t, nodes["actions/checkout@v4"].Resolved)
+		assert.Contains(t, graph.Edges, workflowDependencyEdge{From: ciID, To: "octo/shared/.github/workflows/deploy.yml@v1", Job: "deploy"})
+		assert.Contains(t, graph.Edges, workflowDependencyEdge{From: "octo/api/.github/actions/setup", To: "actions/setup-go@v5"})
+	})
+
+	t.Run("stops at the maximum depth", func(t *testing.T) {
+		graph, nodes := mapDependencies(t, map[string]any{
+			"owner":     "octo",
+			"repo":      "api",
+			"max_depth": float64(1),
+		})
+		assert.True(t, graph.Truncated)
+		assert.True(t, nodes["octo/shared/.github/workflows/deploy.yml@v1"].Resolved)
+		assert.False(t, nodes["octo/shared/.github/actions/notify@v1"].Resolved)
+	})
+
+	t.Run("rejects too deep maps", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":     "octo",
+			"repo":      "api",
+			"max_depth": float64(6),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "max_depth must be at most 5")
+	})
+}
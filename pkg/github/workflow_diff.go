@@ -0,0 +1,277 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stringSetDiff compares two sets of strings.
+type stringSetDiff struct {
+	OnlyInA []string `json:"only_in_a,omitempty"`
+	OnlyInB []string `json:"only_in_b,omitempty"`
+	Common  []string `json:"common,omitempty"`
+}
+
+// diffStringSets compares two lists of strings as sets, each part sorted.
+func diffStringSets(a, b []string) stringSetDiff {
+	var diff stringSetDiff
+	for _, s := range a {
+		if slices.Contains(b, s) {
+			diff.Common = append(diff.Common, s)
+		} else {
+			diff.OnlyInA = append(diff.OnlyInA, s)
+		}
+	}
+	for _, s := range b {
+		if !slices.Contains(a, s) {
+			diff.OnlyInB = append(diff.OnlyInB, s)
+		}
+	}
+	for _, part := range [][]string{diff.OnlyInA, diff.OnlyInB, diff.Common} {
+		sort.Strings(part)
+	}
+	diff.OnlyInA, diff.OnlyInB, diff.Common = slices.Compact(diff.OnlyInA), slices.Compact(diff.OnlyInB), slices.Compact(diff.Common)
+	return diff
+}
+
+// jobDiff is how a job present in both workflows differs.
+type jobDiff struct {
+	Job            string   `json:"job"`
+	RunsOnA        string   `json:"runs_on_a,omitempty"`
+	RunsOnB        string   `json:"runs_on_b,omitempty"`
+	ActionsOnlyInA []string `json:"actions_only_in_a,omitempty"`
+	ActionsOnlyInB []string `json:"actions_only_in_b,omitempty"`
+}
+
+// workflowDiff compares a workflow of each repository.
+type workflowDiff struct {
+	Name     string        `json:"name,omitempty"`
+	PathA    string        `json:"path_a"`
+	PathB    string        `json:"path_b"`
+	Triggers stringSetDiff `json:"triggers"`
+	Jobs     stringSetDiff `json:"jobs"`
+	JobDiffs []jobDiff     `json:"job_differences,omitempty"`
+}
+
+// actionVersionDiff is an action both repositories use, at different refs.
+type actionVersionDiff struct {
+	Action string   `json:"action"`
+	RefsA  []string `json:"refs_a"`
+	RefsB  []string `json:"refs_b"`
+}
+
+// workflowsComparison is the result of diff_workflows.
+type workflowsComparison struct {
+	A                string              `json:"a"`
+	B                string              `json:"b"`
+	WorkflowsOnlyInA []string            `json:"workflows_only_in_a"`
+	WorkflowsOnlyInB []string            `json:"workflows_only_in_b"`
+	Workflows        []workflowDiff      `json:"workflows"`
+	ActionVersions   []actionVersionDiff `json:"action_versions"`
+	ActionsOnlyInA   []string            `json:"actions_only_in_a"`
+	ActionsOnlyInB   []string            `json:"actions_only_in_b"`
+	ParseErrors      []string            `json:"parse_errors,omitempty"`
+}
+
+// diffWorkflow compares a workflow of each repository.
+func diffWorkflow(a, b workflowFile) workflowDiff {
+	diff := workflowDiff{
+		Name:     a.Name,
+		PathA:    a.Path,
+		PathB:    b.Path,
+		Triggers: diffStringSets(a.triggers(), b.triggers()),
+	}
+	var jobsA, jobsB []string
+	for id := range a.Jobs {
+		jobsA = append(jobsA, id)
+	}
+	for id := range b.Jobs {
+		jobsB = append(jobsB, id)
+	}
+	diff.Jobs = diffStringSets(jobsA, jobsB)
+	for _, id := range diff.Jobs.Common {
+		jobA, jobB := a.Jobs[id], b.Jobs[id]
+		d := jobDiff{Job: id}
+		if jobA.runsOn() != jobB.runsOn() {
+			d.RunsOnA, d.RunsOnB = jobA.runsOn(), jobB.runsOn()
+		}
+		actions := diffStringSets(jobA.actions(), jobB.actions())
+		d.ActionsOnlyInA, d.ActionsOnlyInB = actions.OnlyInA, actions.OnlyInB
+		if d.RunsOnA != "" || d.RunsOnB != "" || len(d.ActionsOnlyInA) > 0 || len(d.ActionsOnlyInB) > 0 {
+			diff.JobDiffs = append(diff.JobDiffs, d)
+		}
+	}
+	return diff
+}
+
+// actionRefs returns the refs each action or reusable workflow is used at in a set of workflows. Local actions,
+// which have no ref, are left out.
+func actionRefs(workflows []workflowFile) map[string][]string {
+	refs := make(map[string][]string)
+	for _, wf := range workflows {
+		for _, job := range wf.Jobs {
+			for _, uses := range job.actions() {
+				i := strings.LastIndex(uses, "@")
+				if i < 0 {
+					continue
+				}
+				action, ref := uses[:i], uses[i+1:]
+				if !slices.Contains(refs[action], ref) {
+					refs[action] = append(refs[action], ref)
+				}
+			}
+		}
+	}
+	for _, r := range refs {
+		sort.Strings(r)
+	}
+	return refs
+}
+
+// compareWorkflows compares the workflows of two repositories. Workflows are paired by file name, then by name.
+func compareWorkflows(a, b []workflowFile) workflowsComparison {
+	comparison := workflowsComparison{
+		WorkflowsOnlyInA: []string{},
+		WorkflowsOnlyInB: []string{},
+		Workflows:        []workflowDiff{},
+		ActionVersions:   []actionVersionDiff{},
+	}
+	var parsedA, parsedB []workflowFile
+	for _, wf := range a {
+		if wf.ParseError != "" {
+			comparison.ParseErrors = append(comparison.ParseErrors, fmt.Sprintf("a: %s: %s", wf.Path, wf.ParseError))
+			continue
+		}
+		parsedA = append(parsedA, wf)
+	}
+	for _, wf := range b {
+		if wf.ParseError != "" {
+			comparison.ParseErrors = append(comparison.ParseErrors, fmt.Sprintf("b: %s: %s", wf.Path, wf.ParseError))
+			continue
+		}
+		parsedB = append(parsedB, wf)
+	}
+
+	paired := make(map[string]bool)
+	pair := func(same func(x, y workflowFile) bool) {
+		for _, wfA := range parsedA {
+			if paired["a:"+wfA.Path] {
+				continue
+			}
+			for _, wfB := range parsedB {
+				if !paired["b:"+wfB.Path] && same(wfA, wfB) {
+					paired["a:"+wfA.Path], paired["b:"+wfB.Path] = true, true
+					comparison.Workflows = append(comparison.Workflows, diffWorkflow(wfA, wfB))
+					break
+				}
+			}
+		}
+	}
+	pair(func(x, y workflowFile) bool { return path.Base(x.Path) == path.Base(y.Path) })
+	pair(func(x, y workflowFile) bool { return x.Name != "" && strings.EqualFold(x.Name, y.Name) })
+	for _, wf := range parsedA {
+		if !paired["a:"+wf.Path] {
+			comparison.WorkflowsOnlyInA = append(comparison.WorkflowsOnlyInA, wf.Path)
+		}
+	}
+	for _, wf := range parsedB {
+		if !paired["b:"+wf.Path] {
+			comparison.WorkflowsOnlyInB = append(comparison.WorkflowsOnlyInB, wf.Path)
+		}
+	}
+
+	refsA, refsB := actionRefs(parsedA), actionRefs(parsedB)
+	var actionsA, actionsB []string
+	for action := range refsA {
+		actionsA = append(actionsA, action)
+	}
+	for action := range refsB {
+		actionsB = append(actionsB, action)
+	}
+	actions := diffStringSets(actionsA, actionsB)
+	comparison.ActionsOnlyInA = append([]string{}, actions.OnlyInA...)
+	comparison.ActionsOnlyInB = append([]string{}, actions.OnlyInB...)
+	for _, action := range actions.Common {
+		if !slices.Equal(refsA[action], refsB[action]) {
+			comparison.ActionVersions = append(comparison.ActionVersions, actionVersionDiff{Action: action, RefsA: refsA[action], RefsB: refsB[action]})
+		}
+	}
+	return comparison
+}
+
+// DiffWorkflows creates a tool to compare the GitHub Actions workflows of two repositories.
+func DiffWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("diff_workflows",
+			mcp.WithDescription(t("TOOL_DIFF_WORKFLOWS_DESCRIPTION", "Compare the GitHub Actions workflows of two repositories, possibly in different organizations: which workflows only one has, and for the workflows both have, paired by file name then by name, the differences in triggers, jobs, runners and actions used. Also lists the actions both use at different versions. Helps converging divergent pipelines.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DIFF_WORKFLOWS_USER_TITLE", "Compare workflows of two repositories"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("repository",
+				mcp.Required(),
+				mcp.Description("First repository, as owner/repo"),
+			),
+			mcp.WithString("other_repository",
+				mcp.Required(),
+				mcp.Description("Second repository, as owner/repo"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit of the first repository, defaults to its default branch"),
+			),
+			mcp.WithString("other_ref",
+				mcp.Description("Branch, tag or commit of the second repository, defaults to its default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseDiffWorkflowsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ownerA, repoA, err := splitRepoFullName(params.Repository)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ownerB, repoB, err := splitRepoFullName(params.OtherRepository)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			workflowsA, err := getWorkflowFiles(ctx, client, ownerA, repoA, params.Ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflows of %s: %w", params.Repository, err)
+			}
+			workflowsB, err := getWorkflowFiles(ctx, client, ownerB, repoB, params.OtherRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflows of %s: %w", params.OtherRepository, err)
+			}
+
+			comparison := compareWorkflows(workflowsA, workflowsB)
+			comparison.A, comparison.B = ownerA+"/"+repoA, ownerB+"/"+repoB
+			if params.Ref != "" {
+				comparison.A += "@" + params.Ref
+			}
+			if params.OtherRef != "" {
+				comparison.B += "@" + params.OtherRef
+			}
+
+			r, err := json.Marshal(comparison)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const workflowA = `name: CI
+on: [push, pull_request]
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+      - run: go test ./...
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`
+
+const workflowB = `name: CI
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+jobs:
+  test:
+    runs-on: [self-hosted, linux]
+    steps:
+      - uses: actions/checkout@v3
+      - uses: actions/setup-go@v5
+  deploy:
+    uses: octo-org/workflows/.github/workflows/deploy.yml@main
+`
+
+func Test_WorkflowTriggersAndRunners(t *testing.T) {
+	assert.Equal(t, []string{"push"}, parseWorkflowFile("a.yml", "on: push").triggers())
+	assert.Equal(t, []string{"pull_request", "push"}, parseWorkflowFile("a.yml", workflowA).triggers())
+	assert.Equal(t, []string{"push", "workflow_dispatch"}, parseWorkflowFile("b.yml", workflowB).triggers())
+
+	assert.Equal(t, "ubuntu-latest", workflowJob{RunsOn: "ubuntu-latest"}.runsOn())
+	assert.Equal(t, "self-hosted, linux", workflowJob{RunsOn: []any{"self-hosted", "linux"}}.runsOn())
+	assert.Equal(t, "group: large, labels: gpu", workflowJob{RunsOn: map[string]any{"labels": "gpu", "group": "large"}}.runsOn())
+}
+
+func Test_CompareWorkflows(t *testing.T) {
+	comparison := compareWorkflows(
+		[]workflowFile{
+			parseWorkflowFile(".github/workflows/ci.yml", workflowA),
+			parseWorkflowFile(".github/workflows/release.yml", "name: Release\non: release\njobs: {}\n"),
+			parseWorkflowFile(".github/workflows/broken.yml", "jobs: ["),
+		},
+		[]workflowFile{
+			parseWorkflowFile(".github/workflows/build.yml", workflowB),
+			parseWorkflowFile(".github/workflows/stale.yml", "name: Stale\non: schedule\njobs: {}\n"),
+		},
+	)
+
+	assert.Equal(t, []string{".github/workflows/release.yml"}, comparison.WorkflowsOnlyInA)
+	assert.Equal(t, []string{".github/workflows/stale.yml"}, comparison.WorkflowsOnlyInB)
+	assert.Equal(t, []workflowDiff{{
+		Name:  "CI",
+		PathA: ".github/workflows/ci.yml",
+		PathB: ".github/workflows/build.yml",
+		Triggers: stringSetDiff{
+			OnlyInA: []string{"pull_request"},
+			OnlyInB: []string{"workflow_dispatch"},
+			Common:  []string{"push"},
+		},
+		Jobs: stringSetDiff{
+			OnlyInA: []string{"lint"},
+			OnlyInB: []string{"deploy"},
+			Common:  []string{"test"},
+		},
+		JobDiffs: []jobDiff{{
+			Job:            "test",
+			RunsOnA:        "ubuntu-latest",
+			RunsOnB:        "self-hosted, linux",
+			ActionsOnlyInA: []string{"actions/checkout@v4"},
+			ActionsOnlyInB: []string{"actions/checkout@v3"},
+		}},
+	}}, comparison.Workflows)
+	assert.Equal(t, []actionVersionDiff{
+		{Action: "actions/checkout", RefsA: []string{"v4"}, RefsB: []string{"v3"}},
+	}, comparison.ActionVersions)
+	assert.Empty(t, comparison.ActionsOnlyInA)
+	assert.Equal(t, []string{"octo-org/workflows/.github/workflows/deploy.yml"}, comparison.ActionsOnlyInB)
+	require.Len(t, comparison.ParseErrors, 1)
+	assert.True(t, strings.HasPrefix(comparison.ParseErrors[0], "a: .github/workflows/broken.yml: "))
+}
+
+func Test_DiffWorkflows(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DiffWorkflows(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "diff_workflows", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repository")
+	assert.Contains(t, tool.InputSchema.Properties, "other_repository")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "other_ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"repository", "other_repository"})
+
+	contents := map[string]any{
+		"/repos/octo/api/contents/.github/workflows": []*github.RepositoryContent{
+			{Type: github.Ptr("file"), Name: github.Ptr("ci.yml"), Path: github.Ptr(".github/workflows/ci.yml")},
+		},
+		"/repos/octo/api/contents/.github/workflows/ci.yml": fileContent(".github/workflows/ci.yml", workflowA),
+		"/repos/other-org/web/contents/.github/workflows": []*github.RepositoryContent{
+			{Type: github.Ptr("file"), Name: github.Ptr("ci.yml"), Path: github.Ptr(".github/workflows/ci.yml")},
+			{Type: github.Ptr("file"), Name: github.Ptr("README.md"), Path: github.Ptr(".github/workflows/README.md")},
+		},
+		"/repos/other-org/web/contents/.github/workflows/ci.yml": fileContent(".github/workflows/ci.yml", workflowB),
+	}
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/repos/octo/api/") {
+					assert.Equal(t, "v2", r.URL.Query().Get("ref"))
+				}
+				mockResponse(t, http.StatusOK, contents[r.URL.Path])(w, r)
+			}),
+		),
+	)
+	_, handler := DiffWorkflows(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	t.Run("compares the workflows", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"repository":       "octo/api",
+			"ref":              "v2",
+			"other_repository": "other-org/web",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var comparison workflowsComparison
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comparison))
+		assert.Equal(t, "octo/api@v2", comparison.A)
+		assert.Equal(t, "other-org/web", comparison.B)
+		require.Len(t, comparison.Workflows, 1)
+		assert.Equal(t, []string{"pull_request"}, comparison.Workflows[0].Triggers.OnlyInA)
+		assert.Equal(t, []actionVersionDiff{
+			{Action: "actions/checkout", RefsA: []string{"v4"}, RefsB: []string{"v3"}},
+		}, comparison.ActionVersions)
+	})
+
+	t.Run("rejects an invalid repository", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"repository":       "octo",
+			"other_repository": "other-org/web",
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "expected owner/repo")
+	})
+}
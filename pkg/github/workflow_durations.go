@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxDurationTrendRuns bounds the workflow runs get_workflow_duration_trends fetches.
+	maxDurationTrendRuns = 1000
+	// maxDurationTrendWindows bounds the time windows get_workflow_duration_trends reports.
+	maxDurationTrendWindows = 12
+)
+
+// percentile returns the p-th percentile of sorted values, using the nearest-rank method.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// durationWindow holds the run durations of a workflow in a time window.
+type durationWindow struct {
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Runs       int       `json:"runs"`
+	P50Seconds float64   `json:"p50_seconds"`
+	P95Seconds float64   `json:"p95_seconds"`
+
+	durations []float64
+}
+
+// workflowDurationTrend is how the run durations of a workflow evolved over the time windows.
+type workflowDurationTrend struct {
+	Workflow string           `json:"workflow"`
+	Windows  []durationWindow `json:"windows"`
+	// P50ChangePercent compares the median duration of the last window with runs to that of the first one.
+	P50ChangePercent *float64 `json:"p50_change_percent,omitempty"`
+}
+
+// workflowDurationTrends is the result of get_workflow_duration_trends.
+type workflowDurationTrends struct {
+	WindowDays   int                     `json:"window_days"`
+	RunsAnalyzed int                     `json:"runs_analyzed"`
+	Workflows    []workflowDurationTrend `json:"workflows"`
+	Warnings     []string                `json:"warnings,omitempty"`
+}
+
+// durationTrends groups completed runs by workflow and by time window, the windows ending at end, oldest first.
+// Cancelled and skipped runs are left out, as their durations say nothing about the workflow.
+func durationTrends(runs []*github.WorkflowRun, end time.Time, windowDays, windows int) []workflowDurationTrend {
+	window := time.Duration(windowDays) * 24 * time.Hour
+	start := end.Add(-time.Duration(windows) * window)
+	byWorkflow := make(map[string][]durationWindow)
+	for _, run := range runs {
+		if run.GetStatus() != "completed" || run.GetConclusion() == "cancelled" || run.GetConclusion() == "skipped" {
+			continue
+		}
+		startedAt := run.GetRunStartedAt().Time
+		if startedAt.IsZero() {
+			startedAt = run.GetCreatedAt().Time
+		}
+		if startedAt.Before(start) || !startedAt.Before(end) {
+			continue
+		}
+		name := run.GetName()
+		if _, ok := byWorkflow[name]; !ok {
+			byWorkflow[name] = make([]durationWindow, windows)
+			for i := range byWorkflow[name] {
+				byWorkflow[name][i].Start = start.Add(time.Duration(i) * window)
+				byWorkflow[name][i].End = start.Add(time.Duration(i+1) * window)
+			}
+		}
+		i := int(startedAt.Sub(start) / window)
+		w := &byWorkflow[name][i]
+		w.durations = append(w.durations, run.GetUpdatedAt().Sub(startedAt).Seconds())
+	}
+
+	trends := []workflowDurationTrend{}
+	for name, ws := range byWorkflow {
+		trend := workflowDurationTrend{Workflow: name, Windows: ws}
+		var first, last *durationWindow
+		for i := range ws {
+			w := &ws[i]
+			if len(w.durations) == 0 {
+				continue
+			}
+			sort.Float64s(w.durations)
+			w.Runs = len(w.durations)
+			w.P50Seconds = percentile(w.durations, 50)
+			w.P95Seconds = percentile(w.durations, 95)
+			if first == nil {
+				first = w
+			}
+			last = w
+		}
+		if first != nil && first != last && first.P50Seconds > 0 {
+			change := math.Round((last.P50Seconds-first.P50Seconds)/first.P50Seconds*1000) / 10
+			trend.P50ChangePercent = &change
+		}
+		trends = append(trends, trend)
+	}
+	sort.Slice(trends, func(i, j int) bool { return trends[i].Workflow < trends[j].Workflow })
+	return trends
+}
+
+// GetWorkflowDurationTrends creates a tool to report how the run durations of workflows evolve over time.
+func GetWorkflowDurationTrends(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_duration_trends",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_DURATION_TRENDS_DESCRIPTION", "Report the median (p50) and 95th percentile (p95) run durations of the GitHub Actions workflows of a repository over consecutive time windows, oldest first, with the change of the median between the first and the last window, to spot CI slowdowns. Cancelled and skipped runs are left out.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_DURATION_TRENDS_USER_TITLE", "Get workflow duration trends"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("workflow",
+				mcp.Description("Only report this workflow, by file name (e.g. ci.yml) or ID"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Only count the runs of this branch"),
+			),
+			mcp.WithNumber("window_days",
+				mcp.Description("Length of each time window in days, defaults to 7"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("windows",
+				mcp.Description(fmt.Sprintf("Number of time windows, defaults to 4, at most %d", maxDurationTrendWindows)),
+				mcp.Min(1),
+				mcp.Max(maxDurationTrendWindows),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params, err := parseGetWorkflowDurationTrendsParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.WindowDays <= 0 {
+				params.WindowDays = 7
+			}
+			if params.Windows <= 0 {
+				params.Windows = 4
+			}
+			if params.Windows > maxDurationTrendWindows {
+				return mcp.NewToolResultError(fmt.Sprintf("windows must be at most %d", maxDurationTrendWindows)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			end := time.Now().UTC()
+			start := end.AddDate(0, 0, -params.WindowDays*params.Windows)
+			opts := &github.ListWorkflowRunsOptions{
+				Branch:      params.Branch,
+				Status:      "completed",
+				Created:     ">=" + start.Format("2006-01-02"),
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			result := workflowDurationTrends{WindowDays: params.WindowDays}
+			var runs []*github.WorkflowRun
+			for {
+				var page *github.WorkflowRuns
+				var resp *github.Response
+				if params.Workflow != "" {
+					page, resp, err = client.Actions.ListWorkflowRunsByFileName(ctx, params.Owner, params.Repo, params.Workflow, opts)
+				} else {
+					page, resp, err = client.Actions.ListRepositoryWorkflowRuns(ctx, params.Owner, params.Repo, opts)
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+				}
+				_ = resp.Body.Close()
+				runs = append(runs, page.WorkflowRuns...)
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(runs) >= maxDurationTrendRuns {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("only the latest %d runs were analyzed, so the oldest windows may be incomplete", maxDurationTrendRuns))
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			result.RunsAnalyzed = len(runs)
+			result.Workflows = durationTrends(runs, end, params.WindowDays, params.Windows)
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
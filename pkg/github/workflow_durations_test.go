@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// completedWorkflowRun returns a run of workflow that started at startedAt and took minutes.
+func completedWorkflowRun(workflow, conclusion string, startedAt time.Time, minutes int) *github.WorkflowRun {
+	return &github.WorkflowRun{
+		Name:         github.Ptr(workflow),
+		Status:       github.Ptr("completed"),
+		Conclusion:   github.Ptr(conclusion),
+		RunStartedAt: &github.Timestamp{Time: startedAt},
+		UpdatedAt:    &github.Timestamp{Time: startedAt.Add(time.Duration(minutes) * time.Minute)},
+	}
+}
+
+func Test_Percentile(t *testing.T) {
+	assert.Equal(t, 0.0, percentile(nil, 50))
+	assert.Equal(t, 3.0, percentile([]float64{1, 2, 3, 4, 5}, 50))
+	assert.Equal(t, 5.0, percentile([]float64{1, 2, 3, 4, 5}, 95))
+	assert.Equal(t, 7.0, percentile([]float64{7}, 95))
+}
+
+func Test_DurationTrends(t *testing.T) {
+	end := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	trends := durationTrends([]*github.WorkflowRun{
+		completedWorkflowRun("CI", "success", end.Add(-13*day), 10),
+		completedWorkflowRun("CI", "failure", end.Add(-12*day), 12),
+		completedWorkflowRun("CI", "success", end.Add(-2*day), 15),
+		completedWorkflowRun("CI", "cancelled", end.Add(-2*day), 1),
+		completedWorkflowRun("CI", "success", end.Add(-30*day), 1),
+		completedWorkflowRun("Docs", "success", end.Add(-1*day), 2),
+		{Name: github.Ptr("Docs"), Status: github.Ptr("in_progress"), RunStartedAt: &github.Timestamp{Time: end.Add(-day)}},
+	}, end, 7, 2)
+
+	require.Len(t, trends, 2)
+	ci := trends[0]
+	assert.Equal(t, "CI", ci.Workflow)
+	require.Len(t, ci.Windows, 2)
+	assert.Equal(t, end.Add(-14*day), ci.Windows[0].Start)
+	assert.Equal(t, end.Add(-7*day), ci.Windows[0].End)
+	assert.Equal(t, 2, ci.Windows[0].Runs)
+	assert.Equal(t, 600.0, ci.Windows[0].P50Seconds)
+	assert.Equal(t, 720.0, ci.Windows[0].P95Seconds)
+	assert.Equal(t, 1, ci.Windows[1].Runs)
+	assert.Equal(t, 900.0, ci.Windows[1].P50Seconds)
+	require.NotNil(t, ci.P50ChangePercent)
+	assert.Equal(t, 50.0, *ci.P50ChangePercent)
+
+	docs := trends[1]
+	assert.Equal(t, "Docs", docs.Workflow)
+	assert.Equal(t, 0, docs.Windows[0].Runs)
+	assert.Equal(t, 1, docs.Windows[1].Runs)
+	assert.Nil(t, docs.P50ChangePercent, "a single window with runs shows no trend")
+}
+
+func Test_GetWorkflowDurationTrends(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowDurationTrends(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow_duration_trends", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "workflow")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "window_days")
+	assert.Contains(t, tool.InputSchema.Properties, "windows")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	now := time.Now()
+	runs := &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{
+		completedWorkflowRun("CI", "success", now.Add(-time.Hour), 20),
+		completedWorkflowRun("CI", "success", now.Add(-10*24*time.Hour), 10),
+	}}
+	since := ">=" + now.UTC().AddDate(0, 0, -28).Format("2006-01-02")
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"status": "completed", "created": since, "per_page": "100"}).andThen(
+				mockResponse(t, http.StatusOK, runs),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+			expectPath(t, "/repos/owner/repo/actions/workflows/ci.yml/runs").andThen(
+				mockResponse(t, http.StatusOK, runs),
+			),
+		),
+	)
+	_, handler := GetWorkflowDurationTrends(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	t.Run("reports the trends of every workflow", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var trends workflowDurationTrends
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &trends))
+		assert.Equal(t, 7, trends.WindowDays)
+		assert.Equal(t, 2, trends.RunsAnalyzed)
+		require.Len(t, trends.Workflows, 1)
+		require.Len(t, trends.Workflows[0].Windows, 4)
+		assert.Equal(t, 1, trends.Workflows[0].Windows[2].Runs)
+		assert.Equal(t, 1200.0, trends.Workflows[0].Windows[3].P50Seconds)
+		require.NotNil(t, trends.Workflows[0].P50ChangePercent)
+		assert.Equal(t, 100.0, *trends.Workflows[0].P50ChangePercent)
+	})
+
+	t.Run("reports the trend of a workflow", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":    "owner",
+			"repo":     "repo",
+			"workflow": "ci.yml",
+			"windows":  float64(1),
+		}))
+		require.NoError(t, err)
+		textContent := getTextResult(t, result)
+		require.False(t, result.IsError, textContent.Text)
+
+		var trends workflowDurationTrends
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &trends))
+		require.Len(t, trends.Workflows, 1)
+		require.Len(t, trends.Workflows[0].Windows, 1)
+		assert.Equal(t, 1, trends.Workflows[0].Windows[0].Runs)
+	})
+
+	t.Run("rejects too many windows", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"windows": float64(13),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "windows must be at most 12")
+	})
+}
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v69/github"
+	"gopkg.in/yaml.v3"
+)
+
+// workflowsDir is where GitHub Actions looks for workflow files.
+const workflowsDir = ".github/workflows"
+
+// workflowFile is a parsed GitHub Actions workflow file, holding the parts the tools look at.
+type workflowFile struct {
+	Path string                 `yaml:"-"`
+	Name string                 `yaml:"name"`
+	On   any                    `yaml:"on"`
+	Jobs map[string]workflowJob `yaml:"jobs"`
+
+	// Content is the raw content of the file, for tools that rewrite it.
+	Content string `yaml:"-"`
+	// ParseError is set when the file could not be parsed, in which case only Path and Content are set.
+	ParseError string `yaml:"-"`
+}
+
+// workflowJob is a job of a workflow file.
+type workflowJob struct {
+	Name   string         `yaml:"name"`
+	RunsOn any            `yaml:"runs-on"`
+	Uses   string         `yaml:"uses"`
+	Steps  []workflowStep `yaml:"steps"`
+}
+
+// workflowStep is a step of a workflow job.
+type workflowStep struct {
+	Uses string `yaml:"uses"`
+}
+
+// triggers returns the events that trigger a workflow, sorted. The on key may be a single event, a list of events or
+// a map of events to their configuration.
+func (wf workflowFile) triggers() []string {
+	var events []string
+	switch on := wf.On.(type) {
+	case string:
+		events = []string{on}
+	case []any:
+		for _, event := range on {
+			events = append(events, fmt.Sprint(event))
+		}
+	case map[string]any:
+		for event := range on {
+			events = append(events, event)
+		}
+	}
+	sort.Strings(events)
+	return events
+}
+
+// runsOn returns the runner labels of a job as a single string. runs-on may be a label, a list of labels or a map
+// with a group and labels.
+func (j workflowJob) runsOn() string {
+	switch runsOn := j.RunsOn.(type) {
+	case nil:
+		return ""
+	case []any:
+		labels := make([]string, len(runsOn))
+		for i, label := range runsOn {
+			labels[i] = fmt.Sprint(label)
+		}
+		return strings.Join(labels, ", ")
+	case map[string]any:
+		keys := make([]string, 0, len(runsOn))
+		for key := range runsOn {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = fmt.Sprintf("%s: %v", key, runsOn[key])
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(runsOn)
+	}
+}
+
+// actions returns the actions and reusable workflows a job uses, as action@ref.
+func (j workflowJob) actions() []string {
+	var actions []string
+	if j.Uses != "" {
+		actions = append(actions, j.Uses)
+	}
+	for _, step := range j.Steps {
+		if step.Uses != "" {
+			actions = append(actions, step.Uses)
+		}
+	}
+	return actions
+}
+
+// checkName returns the name of the check run reported for a job: its name if it has one, its ID otherwise.
+func (j workflowJob) checkName(id string) string {
+	if j.Name != "" {
+		return j.Name
+	}
+	return id
+}
+
+// jobMatchesCheck reports whether a check run name can come from a job with the given check name. Matrix jobs report
+// one check per combination, as "name (a, b)", jobs calling a reusable workflow report "name / called job", and
+// names containing expressions are only known up to the first expression.
+func jobMatchesCheck(jobCheckName, check string) bool {
+	if check == jobCheckName || strings.HasPrefix(check, jobCheckName+" (") || strings.HasPrefix(check, jobCheckName+" / ") {
+		return true
+	}
+	if i := strings.Index(jobCheckName, "${{"); i >= 0 {
+		return strings.HasPrefix(check, jobCheckName[:i])
+	}
+	return false
+}
+
+// parseWorkflowFile parses the contents of a workflow file.
+func parseWorkflowFile(filePath, content string) workflowFile {
+	var wf workflowFile
+	if err := yaml.Unmarshal([]byte(content), &wf); err != nil {
+		return workflowFile{Path: filePath, ParseError: err.Error()}
+	}
+	wf.Path = filePath
+	return wf
+}
+
+// getWorkflowFiles fetches and parses the workflow files of a repository at the given ref. It returns no files,
+// without an error, when the repository has no workflows directory.
+func getWorkflowFiles(ctx context.Context, client *github.Client, owner, repo, ref string) ([]workflowFile, error) {
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+	_, entries, resp, err := client.Repositories.GetContents(ctx, owner, repo, workflowsDir, opts)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s: %w", workflowsDir, err)
+	}
+
+	var files []workflowFile
+	for _, entry := range entries {
+		if entry.GetType() != "file" || (path.Ext(entry.GetName()) != ".yml" && path.Ext(entry.GetName()) != ".yaml") {
+			continue
+		}
+		fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, entry.GetPath(), opts)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", entry.GetPath(), err)
+		}
+		content, err := fileContent.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", entry.GetPath(), err)
+		}
+		wf := parseWorkflowFile(entry.GetPath(), content)
+		wf.Content = content
+		files = append(files, wf)
+	}
+	return files, nil
+}
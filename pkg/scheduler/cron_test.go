@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSchedule(t *testing.T) {
+	tests := []struct {
+		name           string
+		expr           string
+		expectedErrMsg string
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "lists, ranges and steps", expr: "*/15 9-17 1,15 * 1-5"},
+		{name: "macro", expr: "@daily"},
+		{name: "sunday as seven", expr: "0 0 * * 7"},
+		{name: "too few fields", expr: "0 0 * *", expectedErrMsg: "expected 5 fields, got 4"},
+		{name: "out of range", expr: "60 * * * *", expectedErrMsg: "minute field value \"60\" out of range 0-59"},
+		{name: "bad step", expr: "*/0 * * * *", expectedErrMsg: "invalid step \"0\" in minute field"},
+		{name: "not a number", expr: "* noon * * *", expectedErrMsg: "invalid value \"noon\" in hour field"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSchedule(tc.expr)
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_ScheduleNext(t *testing.T) {
+	// Monday
+	from := time.Date(2024, 1, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{
+			name:     "every minute",
+			expr:     "* * * * *",
+			expected: time.Date(2024, 1, 15, 10, 8, 0, 0, time.UTC),
+		},
+		{
+			name:     "every quarter hour",
+			expr:     "*/15 * * * *",
+			expected: time.Date(2024, 1, 15, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:     "nightly rolls over to the next day",
+			expr:     "0 2 * * *",
+			expected: time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "single day of week",
+			expr:     "30 9 * * 6",
+			expected: time.Date(2024, 1, 20, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "restricted day of month or day of week",
+			expr:     "0 0 1 * 3",
+			expected: time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "monthly rolls over to the next year",
+			expr:     "0 0 1 1 *",
+			expected: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, schedule.Next(from))
+		})
+	}
+
+	t.Run("impossible date", func(t *testing.T) {
+		schedule, err := ParseSchedule("0 0 31 2 *")
+		require.NoError(t, err)
+		assert.True(t, schedule.Next(from).IsZero())
+	})
+}
@@ -0,0 +1,263 @@
+// Package scheduler runs configured tools periodically and delivers their results to a file, gist or issue
+// comment, allowing the server to double as a lightweight automation runner.
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the scheduler configuration file.
+type Config struct {
+	Jobs []Job `mapstructure:"jobs"`
+}
+
+// Job is a tool invocation run on a schedule.
+type Job struct {
+	// Name identifies the job in logs and reports.
+	Name string `mapstructure:"name"`
+
+	// Schedule is a five field cron expression, evaluated in the server's local time zone.
+	Schedule string `mapstructure:"schedule"`
+
+	// Tool is the name of the tool to call, and Arguments the arguments to call it with.
+	Tool      string         `mapstructure:"tool"`
+	Arguments map[string]any `mapstructure:"arguments"`
+
+	// Output is where the result of the tool is written.
+	Output Output `mapstructure:"output"`
+}
+
+// LoadConfig reads a scheduler configuration file in YAML, or JSON. The file is not read with viper, which would
+// lowercase the tool argument names.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read schedule config: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return Config{}, fmt.Errorf("failed to parse schedule config: %w", err)
+	}
+
+	var cfg Config
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      &cfg,
+	})
+	if err != nil {
+		return Config{}, err
+	}
+	if err := decoder.Decode(raw); err != nil {
+		return Config{}, fmt.Errorf("failed to parse schedule config: %w", err)
+	}
+
+	// Tool handlers expect arguments as decoded from JSON, e.g. numbers as float64
+	for i, job := range cfg.Jobs {
+		b, err := json.Marshal(job.Arguments)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid arguments for job %q: %w", job.Name, err)
+		}
+		cfg.Jobs[i].Arguments = nil
+		if err := json.Unmarshal(b, &cfg.Jobs[i].Arguments); err != nil {
+			return Config{}, fmt.Errorf("invalid arguments for job %q: %w", job.Name, err)
+		}
+	}
+	return cfg, nil
+}
+
+// ToolCaller invokes a tool by name.
+type ToolCaller interface {
+	CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error)
+}
+
+// ServerToolCaller calls tools registered on an MCP server, going through the same request handling as calls made
+// by clients.
+func ServerToolCaller(s *server.MCPServer) ToolCaller {
+	return &serverToolCaller{server: s}
+}
+
+type serverToolCaller struct {
+	server *server.MCPServer
+	nextID atomic.Int64
+}
+
+func (c *serverToolCaller) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	request := map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      fmt.Sprintf("scheduler-%d", c.nextID.Add(1)),
+		"method":  string(mcp.MethodToolsCall),
+		"params": map[string]any{
+			"name":      name,
+			"arguments": arguments,
+		},
+	}
+	message, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool call: %w", err)
+	}
+
+	switch response := c.server.HandleMessage(ctx, message).(type) {
+	case mcp.JSONRPCResponse:
+		switch result := response.Result.(type) {
+		case mcp.CallToolResult:
+			return &result, nil
+		case *mcp.CallToolResult:
+			return result, nil
+		default:
+			return nil, fmt.Errorf("unexpected result type %T", response.Result)
+		}
+	case mcp.JSONRPCError:
+		return nil, errors.New(response.Error.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response type %T", response)
+	}
+}
+
+type scheduledJob struct {
+	Job
+	schedule *Schedule
+	sink     Sink
+}
+
+// Scheduler runs jobs on their schedules.
+type Scheduler struct {
+	jobs   []*scheduledJob
+	caller ToolCaller
+	logger *logrus.Logger
+	now    func() time.Time
+}
+
+// New validates the configuration and creates a scheduler. The client is used to write gist and issue comment
+// outputs.
+func New(cfg Config, caller ToolCaller, client *github.Client, logger *logrus.Logger) (*Scheduler, error) {
+	s := &Scheduler{
+		caller: caller,
+		logger: logger,
+		now:    time.Now,
+	}
+
+	names := make(map[string]bool, len(cfg.Jobs))
+	for i, job := range cfg.Jobs {
+		if job.Name == "" {
+			return nil, fmt.Errorf("job %d: name is required", i)
+		}
+		if names[job.Name] {
+			return nil, fmt.Errorf("job %s: duplicate name", job.Name)
+		}
+		names[job.Name] = true
+
+		if job.Tool == "" {
+			return nil, fmt.Errorf("job %s: tool is required", job.Name)
+		}
+		schedule, err := ParseSchedule(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		sink, err := newSink(job.Output, job.Name, client)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		s.jobs = append(s.jobs, &scheduledJob{Job: job, schedule: schedule, sink: sink})
+	}
+	return s, nil
+}
+
+// Run blocks, running jobs as they become due, until the context is cancelled. Jobs run one at a time; a job that
+// is still running when its next run is due skips that run.
+func (s *Scheduler) Run(ctx context.Context) {
+	if len(s.jobs) == 0 {
+		return
+	}
+
+	next := make([]time.Time, len(s.jobs))
+	for i, job := range s.jobs {
+		next[i] = job.schedule.Next(s.now())
+	}
+
+	for {
+		var earliest time.Time
+		for _, t := range next {
+			if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+				earliest = t
+			}
+		}
+		if earliest.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := s.now()
+		for i, job := range s.jobs {
+			if next[i].IsZero() || next[i].After(now) {
+				continue
+			}
+			if err := s.RunJob(ctx, job.Name); err != nil {
+				s.logger.WithError(err).WithField("job", job.Name).Error("scheduled job failed")
+			}
+			next[i] = job.schedule.Next(s.now())
+		}
+	}
+}
+
+// RunJob runs a single job immediately and writes its result to the job's output.
+func (s *Scheduler) RunJob(ctx context.Context, name string) error {
+	var job *scheduledJob
+	for _, j := range s.jobs {
+		if j.Name == name {
+			job = j
+			break
+		}
+	}
+	if job == nil {
+		return fmt.Errorf("job %s not found", name)
+	}
+
+	ranAt := s.now()
+	result, err := s.caller.CallTool(ctx, job.Tool, job.Arguments)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", job.Tool, err)
+	}
+	content := resultText(result)
+	if result.IsError {
+		return fmt.Errorf("%s returned an error: %s", job.Tool, content)
+	}
+
+	if err := job.sink.Write(ctx, Report{Job: job.Name, Tool: job.Tool, RanAt: ranAt, Content: content}); err != nil {
+		return err
+	}
+	s.logger.WithField("job", job.Name).Info("scheduled job completed")
+	return nil
+}
+
+// resultText joins the text content of a tool result.
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
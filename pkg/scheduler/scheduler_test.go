@@ -0,0 +1,211 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCaller struct {
+	result *mcp.CallToolResult
+	err    error
+	calls  []string
+}
+
+func (f *fakeCaller) CallTool(_ context.Context, name string, _ map[string]any) (*mcp.CallToolResult, error) {
+	f.calls = append(f.calls, name)
+	return f.result, f.err
+}
+
+func Test_LoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+jobs:
+  - name: stale-issues
+    schedule: "0 6 * * 1-5"
+    tool: list_issues
+    arguments:
+      owner: octo
+      repo: app
+      perPage: 50
+    output:
+      type: issue_comment
+      owner: octo
+      repo: app
+      issue_number: 42
+`), 0o600))
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Jobs, 1)
+
+	job := cfg.Jobs[0]
+	assert.Equal(t, "stale-issues", job.Name)
+	assert.Equal(t, "0 6 * * 1-5", job.Schedule)
+	assert.Equal(t, "list_issues", job.Tool)
+	assert.Equal(t, "octo", job.Arguments["owner"])
+	assert.Equal(t, float64(50), job.Arguments["perPage"])
+	assert.Equal(t, OutputIssueComment, job.Output.Type)
+	assert.Equal(t, 42, job.Output.IssueNumber)
+
+	t.Run("unknown keys are rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "schedule.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("jobs:\n  - name: x\n    cron: \"* * * * *\"\n"), 0o600))
+		_, err := LoadConfig(path)
+		require.Error(t, err)
+	})
+}
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name           string
+		jobs           []Job
+		client         *github.Client
+		expectedErrMsg string
+	}{
+		{
+			name:           "missing tool",
+			jobs:           []Job{{Name: "a", Schedule: "@daily", Output: Output{Type: OutputFile, Path: "out"}}},
+			expectedErrMsg: "job a: tool is required",
+		},
+		{
+			name:           "invalid schedule",
+			jobs:           []Job{{Name: "a", Schedule: "daily", Tool: "get_me", Output: Output{Type: OutputFile, Path: "out"}}},
+			expectedErrMsg: "job a: invalid cron expression",
+		},
+		{
+			name:           "unknown output",
+			jobs:           []Job{{Name: "a", Schedule: "@daily", Tool: "get_me", Output: Output{Type: "slack"}}},
+			expectedErrMsg: "job a: unknown output type \"slack\"",
+		},
+		{
+			name:           "gist output without client",
+			jobs:           []Job{{Name: "a", Schedule: "@daily", Tool: "get_me", Output: Output{Type: OutputGist}}},
+			expectedErrMsg: "job a: gist output requires a GitHub client",
+		},
+		{
+			name:           "gist output without gist ID",
+			jobs:           []Job{{Name: "a", Schedule: "@daily", Tool: "get_me", Output: Output{Type: OutputGist}}},
+			client:         github.NewClient(nil),
+			expectedErrMsg: "job a: gist output requires a gist_id, or a gist_id_file",
+		},
+		{
+			name: "duplicate names",
+			jobs: []Job{
+				{Name: "a", Schedule: "@daily", Tool: "get_me", Output: Output{Type: OutputFile, Path: "out"}},
+				{Name: "a", Schedule: "@daily", Tool: "get_me", Output: Output{Type: OutputFile, Path: "out"}},
+			},
+			expectedErrMsg: "job a: duplicate name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(Config{Jobs: tc.jobs}, &fakeCaller{}, tc.client, logrus.New())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErrMsg)
+		})
+	}
+}
+
+func Test_RunJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "me.json")
+	cfg := Config{Jobs: []Job{{
+		Name:     "me",
+		Schedule: "@hourly",
+		Tool:     "get_me",
+		Output:   Output{Type: OutputFile, Path: path},
+	}}}
+
+	t.Run("writes the tool result", func(t *testing.T) {
+		caller := &fakeCaller{result: mcp.NewToolResultText(`{"login":"octocat"}`)}
+		s, err := New(cfg, caller, nil, logrus.New())
+		require.NoError(t, err)
+
+		require.NoError(t, s.RunJob(context.Background(), "me"))
+		assert.Equal(t, []string{"get_me"}, caller.calls)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `{"login":"octocat"}`, string(content))
+	})
+
+	t.Run("tool errors are not written", func(t *testing.T) {
+		require.NoError(t, os.Remove(path))
+		caller := &fakeCaller{result: mcp.NewToolResultError("bad credentials")}
+		s, err := New(cfg, caller, nil, logrus.New())
+		require.NoError(t, err)
+
+		err = s.RunJob(context.Background(), "me")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "get_me returned an error: bad credentials")
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("call failures", func(t *testing.T) {
+		caller := &fakeCaller{err: errors.New("boom")}
+		s, err := New(cfg, caller, nil, logrus.New())
+		require.NoError(t, err)
+
+		err = s.RunJob(context.Background(), "me")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to call get_me: boom")
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		s, err := New(cfg, &fakeCaller{}, nil, logrus.New())
+		require.NoError(t, err)
+		require.EqualError(t, s.RunJob(context.Background(), "other"), "job other not found")
+	})
+}
+
+func Test_ServerToolCaller(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.1", server.WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("echo", mcp.WithString("text")), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		text, _ := request.GetArguments()["text"].(string)
+		return mcp.NewToolResultText(text), nil
+	})
+	caller := ServerToolCaller(s)
+
+	result, err := caller.CallTool(context.Background(), "echo", map[string]any{"text": "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", resultText(result))
+
+	_, err = caller.CallTool(context.Background(), "missing", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tool 'missing' not found")
+}
+
+func Test_RunStopsWithContext(t *testing.T) {
+	s, err := New(Config{Jobs: []Job{{
+		Name:     "me",
+		Schedule: "@yearly",
+		Tool:     "get_me",
+		Output:   Output{Type: OutputFile, Path: filepath.Join(t.TempDir(), "out")},
+	}}}, &fakeCaller{}, nil, logrus.New())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+}
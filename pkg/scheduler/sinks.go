@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+)
+
+// Output types a job can write its report to.
+const (
+	OutputFile         = "file"
+	OutputGist         = "gist"
+	OutputIssueComment = "issue_comment"
+)
+
+// Output configures where the result of a job is written.
+type Output struct {
+	// Type is one of file, gist or issue_comment.
+	Type string `mapstructure:"type"`
+
+	// Path of the local file to write, for the file output. The file is overwritten on each run.
+	Path string `mapstructure:"path"`
+
+	// GistID of an existing gist to update, for the gist output. When empty a secret gist is created on the
+	// first run, and its ID saved to GistIDFile so that it is updated afterwards, across restarts.
+	GistID     string `mapstructure:"gist_id"`
+	GistIDFile string `mapstructure:"gist_id_file"`
+	// Filename of the report within the gist, defaults to the job name.
+	Filename string `mapstructure:"filename"`
+
+	// Owner, Repo and IssueNumber identify the issue to comment on, for the issue_comment output.
+	Owner       string `mapstructure:"owner"`
+	Repo        string `mapstructure:"repo"`
+	IssueNumber int    `mapstructure:"issue_number"`
+}
+
+// Sink receives the reports produced by a job.
+type Sink interface {
+	Write(ctx context.Context, report Report) error
+}
+
+// Report is the outcome of a single job run.
+type Report struct {
+	Job     string
+	Tool    string
+	RanAt   time.Time
+	Content string
+}
+
+func newSink(out Output, jobName string, client *github.Client) (Sink, error) {
+	switch out.Type {
+	case OutputFile:
+		if out.Path == "" {
+			return nil, fmt.Errorf("file output requires a path")
+		}
+		return &fileSink{path: out.Path}, nil
+	case OutputGist:
+		if client == nil {
+			return nil, fmt.Errorf("gist output requires a GitHub client")
+		}
+		if out.GistID == "" && out.GistIDFile == "" {
+			return nil, fmt.Errorf("gist output requires a gist_id, or a gist_id_file to save the ID of the gist it creates")
+		}
+		filename := out.Filename
+		if filename == "" {
+			filename = jobName + ".md"
+		}
+		gistID := out.GistID
+		if gistID == "" {
+			data, err := os.ReadFile(out.GistIDFile)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("failed to read gist ID: %w", err)
+			}
+			gistID = strings.TrimSpace(string(data))
+		}
+		return &gistSink{client: client, gistID: gistID, idFile: out.GistIDFile, filename: filename}, nil
+	case OutputIssueComment:
+		if client == nil {
+			return nil, fmt.Errorf("issue_comment output requires a GitHub client")
+		}
+		if out.Owner == "" || out.Repo == "" || out.IssueNumber <= 0 {
+			return nil, fmt.Errorf("issue_comment output requires owner, repo and issue_number")
+		}
+		return &issueCommentSink{client: client, owner: out.Owner, repo: out.Repo, issueNumber: out.IssueNumber}, nil
+	default:
+		return nil, fmt.Errorf("unknown output type %q", out.Type)
+	}
+}
+
+// formatReport renders a report as markdown for outputs that are read on GitHub, in a code block highlighted as JSON
+// when the result is JSON.
+func formatReport(report Report) string {
+	language := ""
+	if json.Valid([]byte(report.Content)) {
+		language = "json"
+	}
+	// The fence must be longer than any run of backticks in the result
+	fence := "```"
+	for strings.Contains(report.Content, fence) {
+		fence += "`"
+	}
+	return fmt.Sprintf("### %s\n\n_Result of `%s`, generated at %s._\n\n%s%s\n%s\n%s\n",
+		report.Job, report.Tool, report.RanAt.UTC().Format(time.RFC3339), fence, language, report.Content, fence)
+}
+
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) Write(_ context.Context, report Report) error {
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(s.path, []byte(report.Content), 0o600); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+type gistSink struct {
+	client   *github.Client
+	filename string
+	// idFile is where the ID of the gist created on the first run is saved.
+	idFile string
+
+	mu     sync.Mutex
+	gistID string
+}
+
+func (s *gistSink) Write(ctx context.Context, report Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content := formatReport(report)
+	gist := &github.Gist{
+		Description: github.Ptr(fmt.Sprintf("Scheduled report: %s", report.Job)),
+		Files: map[github.GistFilename]github.GistFile{
+			github.GistFilename(s.filename): {Content: github.Ptr(content)},
+		},
+	}
+
+	if s.gistID == "" {
+		gist.Public = github.Ptr(false)
+		created, resp, err := s.client.Gists.Create(ctx, gist)
+		if err != nil {
+			return fmt.Errorf("failed to create gist: %w", err)
+		}
+		_ = resp.Body.Close()
+		s.gistID = created.GetID()
+		if err := os.MkdirAll(filepath.Dir(s.idFile), 0o750); err != nil {
+			return fmt.Errorf("failed to save the ID of gist %s: %w", s.gistID, err)
+		}
+		if err := os.WriteFile(s.idFile, []byte(s.gistID+"\n"), 0o600); err != nil {
+			return fmt.Errorf("failed to save the ID of gist %s: %w", s.gistID, err)
+		}
+		return nil
+	}
+
+	_, resp, err := s.client.Gists.Edit(ctx, s.gistID, gist)
+	if err != nil {
+		return fmt.Errorf("failed to update gist %s: %w", s.gistID, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+type issueCommentSink struct {
+	client      *github.Client
+	owner       string
+	repo        string
+	issueNumber int
+}
+
+func (s *issueCommentSink) Write(ctx context.Context, report Report) error {
+	comment := &github.IssueComment{Body: github.Ptr(formatReport(report))}
+	_, resp, err := s.client.Issues.CreateComment(ctx, s.owner, s.repo, s.issueNumber, comment)
+	if err != nil {
+		return fmt.Errorf("failed to comment on %s/%s#%d: %w", s.owner, s.repo, s.issueNumber, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
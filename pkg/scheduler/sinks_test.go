@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testReport = Report{
+	Job:     "me",
+	Tool:    "get_me",
+	RanAt:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	Content: `{"login":"octocat"}`,
+}
+
+func Test_FormatReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "json",
+			content:  `{"login":"octocat"}`,
+			expected: "### me\n\n_Result of `get_me`, generated at 2025-01-02T03:04:05Z._\n\n```json\n{\"login\":\"octocat\"}\n```\n",
+		},
+		{
+			name:     "other formats",
+			content:  "login: octocat",
+			expected: "### me\n\n_Result of `get_me`, generated at 2025-01-02T03:04:05Z._\n\n```\nlogin: octocat\n```\n",
+		},
+		{
+			name:     "backticks in the result",
+			content:  "see ```go\nfmt.Println()\n```",
+			expected: "### me\n\n_Result of `get_me`, generated at 2025-01-02T03:04:05Z._\n\n````\nsee ```go\nfmt.Println()\n```\n````\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			report := testReport
+			report.Content = tc.content
+			assert.Equal(t, tc.expected, formatReport(report))
+		})
+	}
+}
+
+// gistRequest decodes the gist sent in a request.
+func gistRequest(t *testing.T, r *http.Request) github.Gist {
+	var gist github.Gist
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&gist))
+	return gist
+}
+
+func Test_GistSink(t *testing.T) {
+	idFile := filepath.Join(t.TempDir(), "state", "me.gist")
+	out := Output{Type: OutputGist, GistIDFile: idFile}
+
+	t.Run("creates a gist on the first run", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostGists,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gist := gistRequest(t, r)
+					assert.False(t, gist.GetPublic())
+					assert.Equal(t, formatReport(testReport), *gist.Files["me.md"].Content)
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"id":"abc123"}`))
+				}),
+			),
+		))
+		sink, err := newSink(out, "me", client)
+		require.NoError(t, err)
+
+		require.NoError(t, sink.Write(context.Background(), testReport))
+		data, err := os.ReadFile(idFile)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123\n", string(data))
+	})
+
+	t.Run("updates the saved gist afterwards", func(t *testing.T) {
+		edits := 0
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PatchGistsByGistId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					edits++
+					assert.Equal(t, "/gists/abc123", r.URL.Path)
+					assert.Equal(t, formatReport(testReport), *gistRequest(t, r).Files["me.md"].Content)
+					_, _ = w.Write([]byte(`{"id":"abc123"}`))
+				}),
+			),
+		))
+		// A new sink, as after a restart, reads the ID saved by the first run
+		sink, err := newSink(out, "me", client)
+		require.NoError(t, err)
+
+		require.NoError(t, sink.Write(context.Background(), testReport))
+		require.NoError(t, sink.Write(context.Background(), testReport))
+		assert.Equal(t, 2, edits)
+	})
+
+	t.Run("gist_id takes precedence", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PatchGistsByGistId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "/gists/def456", r.URL.Path)
+					_, _ = w.Write([]byte(`{"id":"def456"}`))
+				}),
+			),
+		))
+		sink, err := newSink(Output{Type: OutputGist, GistID: "def456", GistIDFile: idFile, Filename: "report.md"}, "me", client)
+		require.NoError(t, err)
+		require.NoError(t, sink.Write(context.Background(), testReport))
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostGists,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+				}),
+			),
+		))
+		sink, err := newSink(Output{Type: OutputGist, GistIDFile: filepath.Join(t.TempDir(), "new.gist")}, "me", client)
+		require.NoError(t, err)
+
+		err = sink.Write(context.Background(), testReport)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create gist")
+	})
+}
+
+func Test_IssueCommentSink(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/owner/repo/issues/42/comments", r.URL.Path)
+				var comment github.IssueComment
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+				assert.Equal(t, formatReport(testReport), comment.GetBody())
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":1}`))
+			}),
+		),
+	))
+	sink, err := newSink(Output{Type: OutputIssueComment, Owner: "owner", Repo: "repo", IssueNumber: 42}, "me", client)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Write(context.Background(), testReport))
+}
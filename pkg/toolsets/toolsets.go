@@ -2,6 +2,7 @@ package toolsets
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -79,6 +80,30 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 	return t
 }
 
+// WrapWriteTools replaces each write tool of the toolset with the result of wrap, allowing behaviour that only
+// applies to mutating tools to be layered on top of their handlers.
+func (t *Toolset) WrapWriteTools(wrap func(server.ServerTool) server.ServerTool) *Toolset {
+	for i, tool := range t.writeTools {
+		t.writeTools[i] = wrap(tool)
+	}
+	return t
+}
+
+// WrapTools replaces each read and write tool of the toolset with the result of wrap.
+func (t *Toolset) WrapTools(wrap func(server.ServerTool) server.ServerTool) *Toolset {
+	for i, tool := range t.readTools {
+		t.readTools[i] = wrap(tool)
+	}
+	return t.WrapWriteTools(wrap)
+}
+
+// KeepTools removes the read and write tools of the toolset for which keep returns false.
+func (t *Toolset) KeepTools(keep func(server.ServerTool) bool) *Toolset {
+	t.readTools = slices.DeleteFunc(t.readTools, func(tool server.ServerTool) bool { return !keep(tool) })
+	t.writeTools = slices.DeleteFunc(t.writeTools, func(tool server.ServerTool) bool { return !keep(tool) })
+	return t
+}
+
 type ToolsetGroup struct {
 	Toolsets     map[string]*Toolset
 	everythingOn bool
@@ -157,8 +182,39 @@ func (tg *ToolsetGroup) EnableToolset(name string) error {
 	return nil
 }
 
+// GetActiveTools returns the tools of every enabled toolset in the group.
+func (tg *ToolsetGroup) GetActiveTools() []server.ServerTool {
+	var tools []server.ServerTool
+	for _, toolset := range tg.Toolsets {
+		tools = append(tools, toolset.GetActiveTools()...)
+	}
+	return tools
+}
+
 func (tg *ToolsetGroup) RegisterTools(s *server.MCPServer) {
 	for _, toolset := range tg.Toolsets {
 		toolset.RegisterTools(s)
 	}
 }
+
+// WrapWriteTools applies wrap to the write tools of every toolset in the group.
+func (tg *ToolsetGroup) WrapWriteTools(wrap func(server.ServerTool) server.ServerTool) {
+	for _, toolset := range tg.Toolsets {
+		toolset.WrapWriteTools(wrap)
+	}
+}
+
+// WrapTools applies wrap to the read and write tools of every toolset in the group.
+func (tg *ToolsetGroup) WrapTools(wrap func(server.ServerTool) server.ServerTool) {
+	for _, toolset := range tg.Toolsets {
+		toolset.WrapTools(wrap)
+	}
+}
+
+// KeepTools removes the tools of every toolset in the group for which keep returns false. Toolsets left without
+// tools stay in the group, so that enabling them by name still works.
+func (tg *ToolsetGroup) KeepTools(keep func(server.ServerTool) bool) {
+	for _, toolset := range tg.Toolsets {
+		toolset.KeepTools(keep)
+	}
+}
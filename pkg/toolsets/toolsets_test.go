@@ -2,6 +2,9 @@ package toolsets
 
 import (
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroup(t *testing.T) {
@@ -228,3 +231,55 @@ func TestIsEnabledWithEverythingOn(t *testing.T) {
 		t.Error("Expected IsEnabled to return true for any toolset when everythingOn is true")
 	}
 }
+
+func TestWrapWriteTools(t *testing.T) {
+	readOnlyHint := true
+	writeHint := false
+	readTool := NewServerTool(mcp.NewTool("read", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnlyHint})), nil)
+	writeTool := NewServerTool(mcp.NewTool("write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &writeHint})), nil)
+
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("test-toolset", "A test toolset").AddReadTools(readTool).AddWriteTools(writeTool))
+
+	tsg.WrapWriteTools(func(tool server.ServerTool) server.ServerTool {
+		tool.Tool.Description = "wrapped"
+		return tool
+	})
+
+	for _, tool := range tsg.Toolsets["test-toolset"].GetAvailableTools() {
+		wrapped := tool.Tool.Description == "wrapped"
+		if tool.Tool.Name == "write" && !wrapped {
+			t.Error("Expected write tool to be wrapped")
+		}
+		if tool.Tool.Name == "read" && wrapped {
+			t.Error("Expected read tool not to be wrapped")
+		}
+	}
+}
+
+func TestKeepTools(t *testing.T) {
+	readOnlyHint := true
+	writeHint := false
+	publicTool := NewServerTool(mcp.NewTool("public", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnlyHint})), nil)
+	privateTool := NewServerTool(mcp.NewTool("private", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnlyHint})), nil)
+	writeTool := NewServerTool(mcp.NewTool("write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &writeHint})), nil)
+
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("test-toolset", "A test toolset").AddReadTools(publicTool, privateTool).AddWriteTools(writeTool))
+	tsg.AddToolset(NewToolset("private-toolset", "A toolset left empty").AddReadTools(privateTool))
+
+	tsg.KeepTools(func(tool server.ServerTool) bool {
+		return tool.Tool.Name == "public"
+	})
+
+	tools := tsg.Toolsets["test-toolset"].GetAvailableTools()
+	if len(tools) != 1 || tools[0].Tool.Name != "public" {
+		t.Errorf("Expected only the public tool to be kept, got %v", tools)
+	}
+	if err := tsg.EnableToolset("private-toolset"); err != nil {
+		t.Errorf("Expected the emptied toolset to still be enabled by name, got %v", err)
+	}
+	if len(tsg.GetActiveTools()) != 0 {
+		t.Error("Expected the emptied toolset to have no tools")
+	}
+}